@@ -0,0 +1,19 @@
+package api
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// dashboardHTML is the web dashboard: a single page that polls the API and
+// follows the event stream. It holds no town data itself.
+//
+//go:embed dashboard.html
+var dashboardHTML []byte
+
+// serveDashboard writes the dashboard page.
+func serveDashboard(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	_, _ = w.Write(dashboardHTML)
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// eventsKeepalive is how often an idle event stream gets a comment line, so
+// proxies and phones on flaky networks don't drop the connection.
+const eventsKeepalive = 30 * time.Second
+
+// maxEventsTail caps the ?tail= backlog of an event stream.
+const maxEventsTail = 1000
+
+// handleEvents streams the town's events log as server-sent events, one
+// event JSON per message. ?tail=N first replays the last N events; ?types=
+// is a comma-separated list of event types to keep.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming not supported"))
+		return
+	}
+
+	q := r.URL.Query()
+	tail := 0
+	if v := q.Get("tail"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid tail %q", v))
+			return
+		}
+		tail = min(n, maxEventsTail)
+	}
+	var types map[string]bool
+	if v := q.Get("types"); v != "" {
+		types = make(map[string]bool)
+		for _, t := range strings.Split(v, ",") {
+			types[strings.TrimSpace(t)] = true
+		}
+	}
+
+	// Follow from the end before reading the backlog, so nothing written in
+	// between is missed
+	path := filepath.Join(s.townRoot, events.EventsFile)
+	tailer, err := events.NewTailer(path, false)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer tailer.Close()
+
+	// The stream outlives the server's write timeout
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(line string) {
+		var e events.Event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			return // Skip malformed lines
+		}
+		if types != nil && !types[e.Type] {
+			return
+		}
+		fmt.Fprintf(w, "data: %s\n\n", line)
+	}
+
+	for _, line := range lastLines(path, tail) {
+		send(line)
+	}
+	flusher.Flush()
+
+	keepalive := time.NewTicker(eventsKeepalive)
+	defer keepalive.Stop()
+	lines := tailer.Lines()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepalive.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+			flusher.Flush()
+		case line, ok := <-lines:
+			if !ok {
+				return
+			}
+			send(line)
+			flusher.Flush()
+		}
+	}
+}
+
+// lastLines returns the last n non-empty lines of the file at path.
+func lastLines(path string, n int) []string {
+	if n == 0 {
+		return nil
+	}
+	f, err := os.Open(path) //nolint:gosec // G304: path is constructed from trusted town root
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+			if len(lines) > n {
+				lines = lines[1:]
+			}
+		}
+	}
+	return lines
+}
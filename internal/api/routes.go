@@ -0,0 +1,432 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+// routes registers all API endpoints on the server mux.
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET "+APIPrefix+"/rigs", s.handleListRigs)
+	s.mux.HandleFunc("GET "+APIPrefix+"/rigs/{rig}", s.handleGetRig)
+	s.mux.HandleFunc("GET "+APIPrefix+"/rigs/{rig}/crew", s.handleListCrew)
+	s.mux.HandleFunc("GET "+APIPrefix+"/rigs/{rig}/polecats", s.handleListPolecats)
+	s.mux.HandleFunc("GET "+APIPrefix+"/rigs/{rig}/polecats/{name}/session", s.handleSessionStatus)
+	s.mux.HandleFunc("POST "+APIPrefix+"/rigs/{rig}/polecats/{name}/inject", s.handleInject)
+	s.mux.HandleFunc("GET "+APIPrefix+"/rigs/{rig}/witness", s.handleWitnessStatus)
+	s.mux.HandleFunc("GET "+APIPrefix+"/sessions", s.handleListSessions)
+	s.mux.HandleFunc("GET "+APIPrefix+"/mail", s.handleListMail)
+	s.mux.HandleFunc("POST "+APIPrefix+"/mail", s.handleSendMail)
+	s.mux.HandleFunc("GET "+APIPrefix+"/beads", s.handleListBeads)
+	s.mux.HandleFunc("GET "+APIPrefix+"/beads/{id}", s.handleShowBead)
+	s.mux.HandleFunc("GET "+APIPrefix+"/events", s.handleEvents)
+}
+
+// rigManager builds a rig manager from the town's rigs.json.
+func (s *Server) rigManager() (*rig.Manager, error) {
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(s.townRoot))
+	if err != nil {
+		return nil, fmt.Errorf("loading rigs config: %w", err)
+	}
+	return rig.NewManager(s.townRoot, rigsConfig, git.NewGit(s.townRoot)), nil
+}
+
+// lookupRig resolves the {rig} path value, writing a 404 if it is unknown.
+func (s *Server) lookupRig(w http.ResponseWriter, r *http.Request) (*rig.Rig, bool) {
+	mgr, err := s.rigManager()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	rg, err := mgr.GetRig(r.PathValue("rig"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return nil, false
+	}
+	return rg, true
+}
+
+// authorize checks the town permission policy for op on behalf of the
+// identity the request's token was issued to, writing a 403 and returning
+// false when denied.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, op string) bool {
+	actor := requestIdentity(r)
+	if actor == "" {
+		writeError(w, http.StatusForbidden, errors.New("request has no identity"))
+		return false
+	}
+
+	policy, err := config.LoadOrCreatePermissionsConfig(config.PermissionsConfigPath(s.townRoot))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return false
+	}
+	if !policy.Allows(actor, config.RoleForAddress(actor), op) {
+		writeError(w, http.StatusForbidden, fmt.Errorf("%s may not perform %s", actor, op))
+		return false
+	}
+	return true
+}
+
+func (s *Server) handleListRigs(w http.ResponseWriter, _ *http.Request) {
+	mgr, err := s.rigManager()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	rigs, err := mgr.DiscoverRigs()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	summaries := make([]rig.RigSummary, 0, len(rigs))
+	for _, rg := range rigs {
+		summaries = append(summaries, rg.Summary())
+	}
+	writeJSON(w, http.StatusOK, summaries)
+}
+
+func (s *Server) handleGetRig(w http.ResponseWriter, r *http.Request) {
+	rg, ok := s.lookupRig(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, rg)
+}
+
+// crewStatus is the API representation of a crew worker.
+type crewStatus struct {
+	*crew.CrewWorker
+	Running bool `json:"running"`
+}
+
+func (s *Server) handleListCrew(w http.ResponseWriter, r *http.Request) {
+	rg, ok := s.lookupRig(w, r)
+	if !ok {
+		return
+	}
+
+	mgr := crew.NewManager(rg, git.NewGit(rg.Path))
+	workers, err := mgr.List()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	out := make([]crewStatus, 0, len(workers))
+	for _, worker := range workers {
+		running, _ := mgr.IsRunning(worker.Name)
+		out = append(out, crewStatus{CrewWorker: worker, Running: running})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// polecatStatus is the API representation of a polecat.
+type polecatStatus struct {
+	*polecat.Polecat
+	Running bool `json:"running"`
+}
+
+func (s *Server) handleListPolecats(w http.ResponseWriter, r *http.Request) {
+	rg, ok := s.lookupRig(w, r)
+	if !ok {
+		return
+	}
+
+	t := tmux.NewTmux()
+	mgr := polecat.NewManager(rg, git.NewGit(rg.Path), t)
+	sessMgr := polecat.NewSessionManager(t, rg)
+
+	polecats, err := mgr.List()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	out := make([]polecatStatus, 0, len(polecats))
+	for _, p := range polecats {
+		running, _ := sessMgr.IsRunning(p.Name)
+		out = append(out, polecatStatus{Polecat: p, Running: running})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleSessionStatus(w http.ResponseWriter, r *http.Request) {
+	rg, ok := s.lookupRig(w, r)
+	if !ok {
+		return
+	}
+
+	sessMgr := polecat.NewSessionManager(tmux.NewTmux(), rg)
+	info, err := sessMgr.Status(r.PathValue("name"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+// injectRequest is the body for POST .../inject.
+type injectRequest struct {
+	Message string `json:"message"`
+}
+
+func (s *Server) handleInject(w http.ResponseWriter, r *http.Request) {
+	if !s.authorize(w, r, config.OpSessionInject) {
+		return
+	}
+	rg, ok := s.lookupRig(w, r)
+	if !ok {
+		return
+	}
+
+	var req injectRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.Message == "" {
+		writeError(w, http.StatusBadRequest, errors.New("message is required"))
+		return
+	}
+
+	sessMgr := polecat.NewSessionManager(tmux.NewTmux(), rg)
+	if err := sessMgr.Inject(r.PathValue("name"), req.Message); err != nil {
+		if errors.Is(err, polecat.ErrSessionNotFound) {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// witnessStatus is the API representation of a rig's witness.
+type witnessStatus struct {
+	Rig     string `json:"rig"`
+	Session string `json:"session"`
+	Running bool   `json:"running"`
+}
+
+func (s *Server) handleWitnessStatus(w http.ResponseWriter, r *http.Request) {
+	rg, ok := s.lookupRig(w, r)
+	if !ok {
+		return
+	}
+
+	mgr := witness.NewManager(rg)
+	running, err := mgr.IsRunning()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, witnessStatus{
+		Rig:     rg.Name,
+		Session: mgr.SessionName(),
+		Running: running,
+	})
+}
+
+// sessionStatus is the API representation of a running agent session.
+type sessionStatus struct {
+	Session string `json:"session"`
+	Address string `json:"address"`
+	Role    string `json:"role"`
+	Rig     string `json:"rig,omitempty"`
+	Name    string `json:"name,omitempty"`
+}
+
+func (s *Server) handleListSessions(w http.ResponseWriter, _ *http.Request) {
+	names, err := tmux.NewTmux().ListSessions()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	out := make([]sessionStatus, 0, len(names))
+	for _, name := range names {
+		identity, err := session.ParseSessionName(name)
+		if err != nil {
+			continue // Not a Gas Town session
+		}
+		out = append(out, sessionStatus{
+			Session: name,
+			Address: identity.Address(),
+			Role:    string(identity.Role),
+			Rig:     identity.Rig,
+			Name:    identity.Name,
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleListMail(w http.ResponseWriter, r *http.Request) {
+	address := r.URL.Query().Get("address")
+	if address == "" {
+		writeError(w, http.StatusBadRequest, errors.New("address query parameter is required"))
+		return
+	}
+	// Anyone may read their own mail; reading another mailbox is guarded
+	own := mail.AddressToIdentity(address) == mail.AddressToIdentity(requestIdentity(r))
+	if !own && !s.authorize(w, r, config.OpMailRead) {
+		return
+	}
+
+	router := mail.NewRouterWithTownRoot(s.townRoot, s.townRoot)
+	mailbox, err := router.GetMailbox(address)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var messages []*mail.Message
+	if r.URL.Query().Get("unread") == "true" {
+		messages, err = mailbox.ListUnread()
+	} else {
+		messages, err = mailbox.List()
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if messages == nil {
+		messages = []*mail.Message{}
+	}
+	writeJSON(w, http.StatusOK, messages)
+}
+
+// sendMailRequest is the body for POST /mail.
+type sendMailRequest struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+	Priority string `json:"priority,omitempty"`
+}
+
+func (s *Server) handleSendMail(w http.ResponseWriter, r *http.Request) {
+	if !s.authorize(w, r, config.OpMailSend) {
+		return
+	}
+	var req sendMailRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.To == "" || req.Subject == "" {
+		writeError(w, http.StatusBadRequest, errors.New("to and subject are required"))
+		return
+	}
+	// Mail is from the caller; only the overseer may send on others' behalf
+	actor := requestIdentity(r)
+	if req.From == "" {
+		req.From = actor
+	} else if req.From != actor && config.RoleForAddress(actor) != config.RoleOverseer {
+		writeError(w, http.StatusForbidden, fmt.Errorf("%s may not send mail as %s", actor, req.From))
+		return
+	}
+
+	msg := mail.NewMessage(req.From, req.To, req.Subject, req.Body)
+	if req.Priority != "" {
+		msg.Priority = mail.ParsePriority(req.Priority)
+	}
+
+	router := mail.NewRouterWithTownRoot(s.townRoot, s.townRoot)
+	if err := router.Send(msg); err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, msg)
+}
+
+// beadSummary is the API representation of a bead in a listing.
+type beadSummary struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Priority int    `json:"priority"`
+	Type     string `json:"type"`
+	Assignee string `json:"assignee,omitempty"`
+	Updated  string `json:"updated_at"`
+}
+
+// beadsListing is the response for GET /beads: the matching beads and how
+// many of them are in each status.
+type beadsListing struct {
+	Counts map[string]int `json:"counts"`
+	Beads  []beadSummary  `json:"beads"`
+}
+
+func (s *Server) handleListBeads(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	opts := beads.ListOptions{
+		Status:   q.Get("status"),
+		Assignee: q.Get("assignee"),
+		Label:    q.Get("label"),
+		Priority: -1,
+		Limit:    50,
+		Sort:     "-updated",
+	}
+	if opts.Status == "" {
+		opts.Status = "open"
+	}
+	if limit := q.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit %q", limit))
+			return
+		}
+		opts.Limit = n
+	}
+
+	issues, err := beads.New(s.townRoot).List(opts)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	out := beadsListing{Counts: map[string]int{}, Beads: make([]beadSummary, 0, len(issues))}
+	for _, issue := range issues {
+		out.Counts[issue.Status]++
+		out.Beads = append(out.Beads, beadSummary{
+			ID:       issue.ID,
+			Title:    issue.Title,
+			Status:   issue.Status,
+			Priority: issue.Priority,
+			Type:     issue.Type,
+			Assignee: issue.Assignee,
+			Updated:  issue.UpdatedAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleShowBead(w http.ResponseWriter, r *http.Request) {
+	b := beads.New(s.townRoot)
+	issue, err := b.Show(r.PathValue("id"))
+	if err != nil {
+		if errors.Is(err, beads.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, issue)
+}
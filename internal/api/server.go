@@ -0,0 +1,130 @@
+// Package api provides a localhost HTTP/JSON API for driving a Gas Town
+// programmatically.
+//
+// The API exposes the same managers the CLI uses (rig, crew, polecat sessions,
+// witness, mail, beads) so editors, bots, and remote UIs can query and control
+// the town without shelling out to gt and scraping text output.
+//
+// All endpoints require a bearer token, which identifies its holder: each
+// token is issued to the overseer or to one agent address (see Tokens), and
+// the town permission policy is checked for that identity. Browsers, whose
+// EventSource can't set headers, may pass it as ?token= instead. The web
+// dashboard page at / is static and served without a token; it calls the API
+// with the token the user gives it.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIPrefix is the path prefix for all versioned API endpoints.
+const APIPrefix = "/api/v1"
+
+// Common errors
+var (
+	ErrUnauthorized = errors.New("missing or invalid API token")
+	ErrNoTokens     = errors.New("API token store is required")
+)
+
+// Server is the HTTP handler for the Gas Town API.
+type Server struct {
+	townRoot string
+	tokens   *Tokens
+	mux      *http.ServeMux
+}
+
+// identityKey is the request context key of the caller's identity.
+type identityKey struct{}
+
+// NewServer creates an API server for the given town root.
+// Every request must present a token from tokens as
+// "Authorization: Bearer <token>".
+func NewServer(townRoot string, tokens *Tokens) (*Server, error) {
+	if tokens == nil {
+		return nil, ErrNoTokens
+	}
+
+	s := &Server{
+		townRoot: townRoot,
+		tokens:   tokens,
+		mux:      http.NewServeMux(),
+	}
+	s.routes()
+	return s, nil
+}
+
+// TownRoot returns the town root this server operates on.
+func (s *Server) TownRoot() string {
+	return s.townRoot
+}
+
+// Handle registers an additional handler on the server's mux.
+// Handlers registered this way are subject to the same token auth.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// ServeHTTP authenticates the request and dispatches it to the API routes.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && r.URL.Path == "/" {
+		serveDashboard(w)
+		return
+	}
+	identity, ok := s.identify(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="gastown"`)
+		writeError(w, http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+	s.mux.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), identityKey{}, identity)))
+}
+
+// identify returns the identity of the token the request carries, in its
+// Authorization header or its token query parameter.
+func (s *Server) identify(r *http.Request) (string, bool) {
+	got := r.URL.Query().Get("token")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		got = strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	return s.tokens.Identify(got)
+}
+
+// requestIdentity returns the identity ServeHTTP resolved for a request.
+func requestIdentity(r *http.Request) string {
+	identity, _ := r.Context().Value(identityKey{}).(string)
+	return identity
+}
+
+// errorResponse is the JSON body returned for failed requests.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+// writeError writes err as a JSON error response.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// decodeBody decodes a JSON request body into v.
+func decodeBody(r *http.Request, v interface{}) error {
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 1<<20))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
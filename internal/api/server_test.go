@@ -0,0 +1,285 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func setupTown(t *testing.T) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	mayorDir := filepath.Join(townRoot, "mayor")
+	if err := os.MkdirAll(mayorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	rigsJSON := `{"version":1,"rigs":{}}`
+	if err := os.WriteFile(filepath.Join(mayorDir, "rigs.json"), []byte(rigsJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return townRoot
+}
+
+// newTestServer returns a server for townRoot and a token issued to identity.
+func newTestServer(t *testing.T, townRoot, identity string) (*Server, string) {
+	t.Helper()
+	tokens := OpenTokens(townRoot)
+	token, err := tokens.Issue(identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewServer(townRoot, tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, token
+}
+
+func TestNewServer_NoTokens(t *testing.T) {
+	if _, err := NewServer(t.TempDir(), nil); err != ErrNoTokens {
+		t.Errorf("NewServer() error = %v, want ErrNoTokens", err)
+	}
+}
+
+func TestServer_RejectsMissingToken(t *testing.T) {
+	s, token := newTestServer(t, setupTown(t), config.RoleOverseer)
+
+	for _, auth := range []string{"", "Bearer wrong", "Basic " + token} {
+		req := httptest.NewRequest("GET", APIPrefix+"/rigs", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("auth %q: status = %d, want %d", auth, w.Code, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestServer_ListRigs(t *testing.T) {
+	s, token := newTestServer(t, setupTown(t), config.RoleOverseer)
+
+	req := httptest.NewRequest("GET", APIPrefix+"/rigs", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if got := strings.TrimSpace(w.Body.String()); got != "[]" {
+		t.Errorf("body = %q, want []", got)
+	}
+}
+
+func TestServer_UnknownRig(t *testing.T) {
+	s, token := newTestServer(t, setupTown(t), config.RoleOverseer)
+
+	req := httptest.NewRequest("GET", APIPrefix+"/rigs/nope", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestServer_SendMailValidation(t *testing.T) {
+	s, token := newTestServer(t, setupTown(t), config.RoleOverseer)
+
+	req := httptest.NewRequest("POST", APIPrefix+"/mail", strings.NewReader(`{"to":""}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestServer_PermissionDenied(t *testing.T) {
+	townRoot := setupTown(t)
+	policy := config.NewPermissionsConfig()
+	policy.Roles["polecat"] = &config.RolePermissions{Deny: []string{"mail.*"}}
+	if err := config.SavePermissionsConfig(config.PermissionsConfigPath(townRoot), policy); err != nil {
+		t.Fatal(err)
+	}
+	s, token := newTestServer(t, townRoot, "gastown/polecats/toast")
+
+	req := httptest.NewRequest("POST", APIPrefix+"/mail", strings.NewReader(`{"to":"mayor/","subject":"hi"}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestServer_DashboardAndQueryToken(t *testing.T) {
+	s, token := newTestServer(t, setupTown(t), config.RoleOverseer)
+
+	// The dashboard page needs no token; the API still does
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "Gas Town") {
+		t.Errorf("dashboard status = %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", APIPrefix+"/rigs?token="+token, nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("query token: status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestServer_EventStream(t *testing.T) {
+	townRoot := setupTown(t)
+	log := `{"ts":"2026-01-01T00:00:00Z","type":"sling","actor":"mayor"}
+{"ts":"2026-01-01T00:00:01Z","type":"mail","actor":"mayor"}
+{"ts":"2026-01-01T00:00:02Z","type":"done","actor":"gastown/polecats/toast"}
+`
+	path := filepath.Join(townRoot, events.EventsFile)
+	if err := os.WriteFile(path, []byte(log), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, token := newTestServer(t, townRoot, config.RoleOverseer)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + APIPrefix + "/events?tail=2&types=sling,done&token=" + token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	// The backlog holds the last two events, of which only done matches;
+	// then new events follow
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"ts":"2026-01-01T00:00:03Z","type":"sling","actor":"mayor"}` + "\n")
+	_ = f.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	var got []string
+	for len(got) < 2 && scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			var e events.Event
+			if err := json.Unmarshal([]byte(data), &e); err != nil {
+				t.Fatalf("bad event %q: %v", data, err)
+			}
+			got = append(got, e.Type+"@"+e.Timestamp)
+		}
+	}
+	want := []string{"done@2026-01-01T00:00:02Z", "sling@2026-01-01T00:00:03Z"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("streamed %v, want %v", got, want)
+	}
+}
+
+func TestServer_MailSenderIsTokenIdentity(t *testing.T) {
+	s, token := newTestServer(t, setupTown(t), "gastown/crew/joe")
+
+	req := httptest.NewRequest("POST", APIPrefix+"/mail", strings.NewReader(`{"from":"overseer","to":"mayor/","subject":"hi"}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestTokens(t *testing.T) {
+	townRoot := t.TempDir()
+	tokens := OpenTokens(townRoot)
+
+	overseer, err := tokens.Issue(config.RoleOverseer)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	joe, err := tokens.Issue("gastown/crew/joe")
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	if len(overseer) != 64 || overseer == joe {
+		t.Errorf("tokens %q and %q should be distinct 64-char tokens", overseer, joe)
+	}
+
+	// Only hashes are stored, in a private file
+	data, err := os.ReadFile(TokensPath(townRoot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), overseer) || strings.Contains(string(data), joe) {
+		t.Error("token store should not contain the tokens")
+	}
+	if info, _ := os.Stat(TokensPath(townRoot)); info.Mode().Perm() != 0600 {
+		t.Errorf("token store mode = %o, want 600", info.Mode().Perm())
+	}
+
+	// Another process's view of the store
+	other := OpenTokens(townRoot)
+	for token, want := range map[string]string{overseer: config.RoleOverseer, joe: "gastown/crew/joe", "nope": ""} {
+		if got, _ := other.Identify(token); got != want {
+			t.Errorf("Identify(%q) = %q, want %q", token, got, want)
+		}
+	}
+
+	if n, err := tokens.Revoke("gastown/crew/joe"); err != nil || n != 1 {
+		t.Fatalf("Revoke() = %d, %v; want 1, nil", n, err)
+	}
+	if _, ok := other.Identify(joe); ok {
+		t.Error("revoked token should not identify anyone")
+	}
+	if got, _ := other.Identify(overseer); got != config.RoleOverseer {
+		t.Errorf("Identify(overseer token) = %q after revoking joe", got)
+	}
+}
+
+func TestServer_ListMailOtherMailbox(t *testing.T) {
+	s, token := newTestServer(t, setupTown(t), "gastown/polecats/toast")
+
+	for _, address := range []string{"overseer", "mayor/", "gastown/crew/joe"} {
+		req := httptest.NewRequest("GET", APIPrefix+"/mail?address="+address, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("address=%s: status = %d, want %d", address, w.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestServer_ListMailOwnMailbox(t *testing.T) {
+	s, token := newTestServer(t, setupTown(t), "gastown/polecats/toast")
+
+	// Any spelling of the caller's own address is theirs to read
+	for _, address := range []string{"gastown/polecats/toast", "gastown/toast"} {
+		req := httptest.NewRequest("GET", APIPrefix+"/mail?address="+address, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, req)
+
+		if w.Code == http.StatusForbidden {
+			t.Errorf("address=%s: reading own mailbox was forbidden: %s", address, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// TokensFile is the name of the API token store within the town runtime dir.
+const TokensFile = "api-tokens.json"
+
+// TokenEntry is an issued API token: whom it identifies and the SHA-256 of
+// the token itself.
+type TokenEntry struct {
+	Identity string    `json:"identity"`
+	Hash     string    `json:"hash"`
+	Created  time.Time `json:"created"`
+}
+
+// Tokens maps API tokens to the identities they were issued to. Only token
+// hashes are stored, so an agent reading the store (it runs as the same OS
+// user) learns nothing it can present to the API. A token is shown once,
+// when it is issued.
+type Tokens struct {
+	path string
+
+	mu      sync.Mutex
+	info    os.FileInfo // of the store when entries were read
+	entries []TokenEntry
+}
+
+// TokensPath returns the path of the API token store for a town.
+func TokensPath(townRoot string) string {
+	return filepath.Join(constants.TownRuntimePath(townRoot), TokensFile)
+}
+
+// OpenTokens returns the token store of a town. Tokens issued or revoked
+// by other processes are picked up when the file changes.
+func OpenTokens(townRoot string) *Tokens {
+	return &Tokens{path: TokensPath(townRoot)}
+}
+
+// Issue generates a token for identity (an agent address or "overseer"),
+// records its hash, and returns the token.
+func (t *Tokens) Issue(identity string) (string, error) {
+	if identity == "" {
+		return "", errors.New("identity is required")
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	err := t.update(func(entries []TokenEntry) []TokenEntry {
+		return append(entries, TokenEntry{Identity: identity, Hash: hashToken(token), Created: time.Now().UTC()})
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Revoke removes every token issued to identity and returns how many there were.
+func (t *Tokens) Revoke(identity string) (int, error) {
+	removed := 0
+	err := t.update(func(entries []TokenEntry) []TokenEntry {
+		kept := entries[:0]
+		for _, e := range entries {
+			if e.Identity == identity {
+				removed++
+				continue
+			}
+			kept = append(kept, e)
+		}
+		return kept
+	})
+	return removed, err
+}
+
+// List returns the issued tokens.
+func (t *Tokens) List() ([]TokenEntry, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.reload(); err != nil {
+		return nil, err
+	}
+	return append([]TokenEntry(nil), t.entries...), nil
+}
+
+// Identify returns the identity a token was issued to.
+func (t *Tokens) Identify(token string) (string, bool) {
+	if token == "" {
+		return "", false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.reload(); err != nil {
+		return "", false
+	}
+	hash := []byte(hashToken(token))
+	identity := ""
+	for _, e := range t.entries {
+		// Compare against every entry so timing doesn't reveal which matched
+		if subtle.ConstantTimeCompare(hash, []byte(e.Hash)) == 1 {
+			identity = e.Identity
+		}
+	}
+	return identity, identity != ""
+}
+
+// reload rereads the store if it changed. Callers hold t.mu.
+func (t *Tokens) reload() error {
+	info, err := os.Stat(t.path)
+	if os.IsNotExist(err) {
+		t.entries, t.info = nil, nil
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// Writes replace the file, so a new inode means new contents
+	if t.entries != nil && os.SameFile(info, t.info) && info.ModTime().Equal(t.info.ModTime()) {
+		return nil
+	}
+	entries, err := readTokens(t.path)
+	if err != nil {
+		return err
+	}
+	if entries == nil {
+		entries = []TokenEntry{}
+	}
+	t.entries, t.info = entries, info
+	return nil
+}
+
+// update applies fn to the stored entries under a file lock.
+func (t *Tokens) update(fn func([]TokenEntry) []TokenEntry) error {
+	if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+		return fmt.Errorf("creating runtime dir: %w", err)
+	}
+	lock := flock.New(t.path + ".lock")
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf("locking token store: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	entries, err := readTokens(t.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	data, err := json.MarshalIndent(fn(entries), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := util.AtomicWriteFile(t.path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("writing token store: %w", err)
+	}
+
+	t.mu.Lock()
+	t.entries = nil // force a reload
+	t.mu.Unlock()
+	return nil
+}
+
+// readTokens reads the token store at path.
+func readTokens(path string) ([]TokenEntry, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed from trusted town root
+	if err != nil {
+		return nil, err
+	}
+	var entries []TokenEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// hashToken returns the hex SHA-256 of a token.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,168 @@
+// Package audit provides an append-only log of state-mutating gt invocations.
+//
+// Every mutating command records who ran it, from where, with which
+// arguments, and how it ended. The log lives at <town>/logs/audit.jsonl and
+// is only ever appended to; review it with 'gt audit list' and
+// 'gt audit search'.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// FileName is the audit log file name within the town logs directory.
+const FileName = "audit.jsonl"
+
+// maxArgLen caps recorded argument length so large mail bodies or prompts
+// don't bloat the log.
+const maxArgLen = 200
+
+// Result values for Entry.Result.
+const (
+	ResultOK     = "ok"
+	ResultError  = "error"
+	ResultDenied = "denied" // refused by the town permission policy
+)
+
+// Entry is a single recorded gt invocation.
+type Entry struct {
+	Timestamp  time.Time `json:"timestamp"` // when the command started
+	DurationMs int64     `json:"duration_ms"`
+	Command    string    `json:"command"` // full command path, e.g. "gt crew remove"
+	Args       []string  `json:"args,omitempty"`
+	Actor      string    `json:"actor"`
+	Cwd        string    `json:"cwd,omitempty"`
+	Session    string    `json:"session,omitempty"` // tmux session, if run inside one
+	Result     string    `json:"result"`            // "ok", "error", or "denied"
+	Error      string    `json:"error,omitempty"`
+}
+
+// Path returns the audit log path for a town.
+func Path(townRoot string) string {
+	return filepath.Join(townRoot, "logs", FileName)
+}
+
+// Append writes an entry to the town's audit log.
+// Each entry is written with a single O_APPEND write so concurrent
+// invocations don't interleave lines.
+func Append(townRoot string, e Entry) error {
+	// Copy before truncating: callers may pass os.Args
+	args := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		args[i] = truncateArg(arg)
+	}
+	e.Args = args
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encoding audit entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	path := Path(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating log directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) //nolint:gosec // G304: path is constructed from trusted town root
+	if err != nil {
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("writing audit log: %w", err)
+	}
+	return nil
+}
+
+// truncateArg caps arg at maxArgLen bytes, cutting on a rune boundary.
+func truncateArg(arg string) string {
+	if len(arg) <= maxArgLen {
+		return arg
+	}
+	n := maxArgLen
+	for n > 0 && !utf8.RuneStart(arg[n]) {
+		n--
+	}
+	return arg[:n] + "…"
+}
+
+// Read returns all audit entries at or after since (zero means all).
+// Malformed lines are skipped. A missing log yields no entries.
+func Read(townRoot string, since time.Time) ([]Entry, error) {
+	f, err := os.Open(Path(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if !since.IsZero() && e.Timestamp.Before(since) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// Query selects audit entries. Empty fields match every entry; string
+// matches are case-insensitive.
+type Query struct {
+	Actor   string // substring of the actor address
+	Command string // command path prefix, with or without "gt " (e.g. "crew remove")
+	Result  string // exact result
+	Session string // substring of the tmux session
+	Text    string // substring of the command line, actor, cwd, session, or error
+}
+
+// Matches reports whether e satisfies every field of q.
+func (q Query) Matches(e Entry) bool {
+	if q.Actor != "" && !containsFold(e.Actor, q.Actor) {
+		return false
+	}
+	if q.Command != "" {
+		want := strings.ToLower(strings.TrimPrefix(q.Command, "gt "))
+		if !strings.HasPrefix(strings.ToLower(strings.TrimPrefix(e.Command, "gt ")), want) {
+			return false
+		}
+	}
+	if q.Result != "" && e.Result != q.Result {
+		return false
+	}
+	if q.Session != "" && !containsFold(e.Session, q.Session) {
+		return false
+	}
+	if q.Text != "" {
+		fields := append([]string{e.Command, e.Actor, e.Cwd, e.Session, e.Error}, e.Args...)
+		for _, f := range fields {
+			if containsFold(f, q.Text) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// containsFold reports whether substr is within s, ignoring case.
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,107 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	townRoot := t.TempDir()
+	now := time.Now()
+
+	old := Entry{Timestamp: now.Add(-48 * time.Hour), Command: "gt crew add", Actor: "overseer", Result: ResultOK}
+	recent := Entry{
+		Timestamp: now,
+		Command:   "gt crew remove",
+		Args:      []string{"emma", strings.Repeat("x", 500)},
+		Actor:     "gastown/crew/joe",
+		Result:    ResultError,
+		Error:     "uncommitted changes",
+	}
+	for _, e := range []Entry{old, recent} {
+		if err := Append(townRoot, e); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	all, err := Read(townRoot, time.Time{})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("Read() returned %d entries, want 2", len(all))
+	}
+
+	filtered, err := Read(townRoot, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filtered) != 1 || filtered[0].Command != "gt crew remove" {
+		t.Fatalf("Read(since) = %+v, want only the recent entry", filtered)
+	}
+	if got := len(filtered[0].Args[1]); got > maxArgLen+len("…") {
+		t.Errorf("long arg not truncated: len = %d", got)
+	}
+}
+
+func TestAppendTruncatesCopy(t *testing.T) {
+	// "é" is two bytes; a cut at maxArgLen would split one
+	long := "a" + strings.Repeat("é", maxArgLen)
+	args := []string{long}
+	if err := Append(t.TempDir(), Entry{Command: "gt mail send", Args: args, Result: ResultOK}); err != nil {
+		t.Fatal(err)
+	}
+	if args[0] != long {
+		t.Error("Append modified the caller's args")
+	}
+
+	got := truncateArg(long)
+	if !utf8.ValidString(got) || !strings.HasSuffix(got, "…") || len(got) > maxArgLen+len("…") {
+		t.Errorf("truncateArg = %q", got)
+	}
+}
+
+func TestReadMissingLog(t *testing.T) {
+	entries, err := Read(t.TempDir(), time.Time{})
+	if err != nil || entries != nil {
+		t.Errorf("Read() on missing log = %v, %v; want nil, nil", entries, err)
+	}
+}
+
+func TestQueryMatches(t *testing.T) {
+	e := Entry{
+		Command: "gt crew remove",
+		Args:    []string{"crew", "remove", "dave", "--force"},
+		Actor:   "gastown/witness",
+		Session: "gt-gastown-witness",
+		Result:  ResultDenied,
+		Error:   "permission denied",
+	}
+
+	tests := []struct {
+		name string
+		q    Query
+		want bool
+	}{
+		{"empty", Query{}, true},
+		{"actor", Query{Actor: "WITNESS"}, true},
+		{"other actor", Query{Actor: "mayor"}, false},
+		{"command prefix", Query{Command: "crew"}, true},
+		{"command with gt", Query{Command: "gt crew remove"}, true},
+		{"other command", Query{Command: "polecat"}, false},
+		{"result", Query{Result: ResultDenied}, true},
+		{"other result", Query{Result: ResultOK}, false},
+		{"session", Query{Session: "witness"}, true},
+		{"text in args", Query{Text: "Dave"}, true},
+		{"text in error", Query{Text: "permission"}, true},
+		{"text missing", Query{Text: "emma"}, false},
+		{"all fields", Query{Actor: "witness", Command: "crew remove", Text: "dave"}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.q.Matches(e); got != tt.want {
+			t.Errorf("%s: Matches() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -24,6 +24,7 @@ type DetachOptions struct {
 	Operation string // "detach", "burn", "squash" - defaults to "detach"
 	Agent     string // Who is performing the detach
 	Reason    string // Optional reason for the detach
+	Molecule  string // Molecule to detach; defaults to the top of the attachment stack
 }
 
 // DetachMoleculeWithAudit removes molecule attachment from a pinned bead and logs the operation.
@@ -38,8 +39,18 @@ func (b *Beads) DetachMoleculeWithAudit(pinnedBeadID string, opts DetachOptions)
 	// Get current attachment info for audit
 	attachment := ParseAttachmentFields(issue)
 	if attachment == nil {
+		if opts.Molecule != "" {
+			return nil, fmt.Errorf("molecule %s is not attached to %s", opts.Molecule, pinnedBeadID)
+		}
 		return issue, nil // Nothing to detach
 	}
+	detached := opts.Molecule
+	if detached == "" {
+		detached = attachment.AttachedMolecule
+	}
+	if !attachment.Remove(opts.Molecule) {
+		return nil, fmt.Errorf("molecule %s is not attached to %s", opts.Molecule, pinnedBeadID)
+	}
 
 	// Log the detach operation
 	operation := opts.Operation
@@ -50,7 +61,7 @@ func (b *Beads) DetachMoleculeWithAudit(pinnedBeadID string, opts DetachOptions)
 		Timestamp:        currentTimestamp(),
 		Operation:        operation,
 		PinnedBeadID:     pinnedBeadID,
-		DetachedMolecule: attachment.AttachedMolecule,
+		DetachedMolecule: detached,
 		DetachedBy:       opts.Agent,
 		Reason:           opts.Reason,
 		PreviousState:    issue.Status,
@@ -60,16 +71,8 @@ func (b *Beads) DetachMoleculeWithAudit(pinnedBeadID string, opts DetachOptions)
 		fmt.Fprintf(os.Stderr, "Warning: failed to write audit log: %v\n", err)
 	}
 
-	// Clear attachment fields by passing nil
-	newDesc := SetAttachmentFields(issue, nil)
-
-	// Update the issue
-	if err := b.Update(pinnedBeadID, UpdateOptions{Description: &newDesc}); err != nil {
-		return nil, fmt.Errorf("updating pinned bead: %w", err)
-	}
-
-	// Re-fetch to return updated state
-	return b.Show(pinnedBeadID)
+	// Write the remaining stack; the next suspended molecule (if any) resumes
+	return b.updateAttachment(issue, attachment)
 }
 
 // LogDetachAudit appends an audit entry to the audit log file.
@@ -10,7 +10,9 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/steveyegge/gastown/internal/logging"
 	"github.com/steveyegge/gastown/internal/runtime"
 )
 
@@ -68,6 +70,8 @@ type IssueDep struct {
 }
 
 // ListOptions specifies filters for listing issues.
+// All filters are passed through to bd, so issues are filtered in the
+// database rather than after pulling everything into gt.
 type ListOptions struct {
 	Status     string // "open", "closed", "all"
 	Type       string // Deprecated: use Label instead. "task", "bug", "feature", "epic"
@@ -76,6 +80,15 @@ type ListOptions struct {
 	Parent     string // filter by parent ID
 	Assignee   string // filter by assignee (e.g., "gastown/Toast")
 	NoAssignee bool   // filter for issues with no assignee
+	Limit      int    // max results: 0 for bd's default, -1 for no limit
+
+	Labels        []string  // issues must have all of these labels (and Label, if set)
+	CreatedAfter  time.Time // zero for no bound
+	CreatedBefore time.Time // zero for no bound
+	UpdatedAfter  time.Time // zero for no bound
+	TitleContains string    // case-insensitive substring of the title
+	DescContains  string    // case-insensitive substring of the description
+	Sort          string    // field to sort by (e.g., "created", "updated", "priority"); "-" prefix for descending
 }
 
 // CreateOptions specifies options for creating an issue.
@@ -85,8 +98,9 @@ type CreateOptions struct {
 	Priority    int    // 0-4
 	Description string
 	Parent      string
-	Actor       string // Who is creating this issue (populates created_by)
-	Ephemeral   bool   // Create as ephemeral (wisp) - not exported to JSONL
+	Actor       string   // Who is creating this issue (populates created_by)
+	Ephemeral   bool     // Create as ephemeral (wisp) - not exported to JSONL
+	Labels      []string // Extra labels to set at creation
 }
 
 // UpdateOptions specifies options for updating an issue.
@@ -114,6 +128,7 @@ type Beads struct {
 	workDir  string
 	beadsDir string // Optional BEADS_DIR override for cross-database access
 	isolated bool   // If true, suppress inherited beads env vars (for test isolation)
+	cache    *Cache // Optional issue cache (see WithCache)
 }
 
 // New creates a new Beads wrapper for the given directory.
@@ -162,10 +177,7 @@ func (b *Beads) run(args ...string) ([]byte, error) {
 	// Always explicitly set BEADS_DIR to prevent inherited env vars from
 	// causing prefix mismatches. Use explicit beadsDir if set, otherwise
 	// resolve from working directory.
-	beadsDir := b.beadsDir
-	if beadsDir == "" {
-		beadsDir = ResolveBeadsDir(b.workDir)
-	}
+	beadsDir := b.resolvedBeadsDir()
 
 	// In isolated mode, use --db flag to force specific database path
 	// This bypasses bd's routing logic that can redirect to .beads-planning
@@ -193,7 +205,16 @@ func (b *Beads) run(args ...string) ([]byte, error) {
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
+	start := time.Now()
 	err := cmd.Run()
+	logging.Exec("bd", fullArgs, cmd.Dir, start, err, stderr.String())
+
+	// Drop cached issues after anything that may have written, even if it
+	// failed partway
+	if b.cache != nil && len(args) > 0 && !readCommands[args[0]] {
+		b.cache.Invalidate(beadsDir)
+	}
+
 	if err != nil {
 		return nil, b.wrapError(err, stderr.String(), args)
 	}
@@ -263,6 +284,23 @@ func filterBeadsEnv(environ []string) []string {
 
 // List returns issues matching the given options.
 func (b *Beads) List(opts ListOptions) ([]*Issue, error) {
+	args := listArgs(opts)
+
+	out, err := b.run(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []*Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return nil, fmt.Errorf("parsing bd list output: %w", err)
+	}
+
+	return issues, nil
+}
+
+// listArgs builds the bd list arguments for the given options.
+func listArgs(opts ListOptions) []string {
 	args := []string{"list", "--json"}
 
 	if opts.Status != "" {
@@ -275,6 +313,9 @@ func (b *Beads) List(opts ListOptions) ([]*Issue, error) {
 		// Deprecated: convert type to label for backward compatibility
 		args = append(args, "--label=gt:"+opts.Type)
 	}
+	for _, label := range opts.Labels {
+		args = append(args, "--label="+label)
+	}
 	if opts.Priority >= 0 {
 		args = append(args, fmt.Sprintf("--priority=%d", opts.Priority))
 	}
@@ -287,18 +328,30 @@ func (b *Beads) List(opts ListOptions) ([]*Issue, error) {
 	if opts.NoAssignee {
 		args = append(args, "--no-assignee")
 	}
-
-	out, err := b.run(args...)
-	if err != nil {
-		return nil, err
+	if !opts.CreatedAfter.IsZero() {
+		args = append(args, "--created-after="+opts.CreatedAfter.UTC().Format(time.RFC3339))
 	}
-
-	var issues []*Issue
-	if err := json.Unmarshal(out, &issues); err != nil {
-		return nil, fmt.Errorf("parsing bd list output: %w", err)
+	if !opts.CreatedBefore.IsZero() {
+		args = append(args, "--created-before="+opts.CreatedBefore.UTC().Format(time.RFC3339))
 	}
-
-	return issues, nil
+	if !opts.UpdatedAfter.IsZero() {
+		args = append(args, "--updated-after="+opts.UpdatedAfter.UTC().Format(time.RFC3339))
+	}
+	if opts.TitleContains != "" {
+		args = append(args, "--title-contains="+opts.TitleContains)
+	}
+	if opts.DescContains != "" {
+		args = append(args, "--desc-contains="+opts.DescContains)
+	}
+	if opts.Sort != "" {
+		args = append(args, "--sort="+opts.Sort)
+	}
+	if opts.Limit < 0 {
+		args = append(args, "--limit=0")
+	} else if opts.Limit > 0 {
+		args = append(args, fmt.Sprintf("--limit=%d", opts.Limit))
+	}
+	return args
 }
 
 // ListByAssignee returns all issues assigned to a specific assignee.
@@ -376,6 +429,16 @@ func (b *Beads) ReadyWithType(issueType string) ([]*Issue, error) {
 
 // Show returns detailed information about an issue.
 func (b *Beads) Show(id string) (*Issue, error) {
+	if b.cache != nil {
+		return b.cache.Issue(b.resolvedBeadsDir(), id, func() (*Issue, error) {
+			return b.show(id)
+		})
+	}
+	return b.show(id)
+}
+
+// show runs bd show for one issue.
+func (b *Beads) show(id string) (*Issue, error) {
 	out, err := b.run("show", id, "--json")
 	if err != nil {
 		return nil, err
@@ -394,31 +457,131 @@ func (b *Beads) Show(id string) (*Issue, error) {
 	return issues[0], nil
 }
 
-// ShowMultiple fetches multiple issues by ID in a single bd call.
-// Returns a map of ID to Issue. Missing IDs are not included in the map.
-func (b *Beads) ShowMultiple(ids []string) (map[string]*Issue, error) {
-	if len(ids) == 0 {
-		return make(map[string]*Issue), nil
+// Comment is a comment on an issue.
+type Comment struct {
+	ID        int64  `json:"id"`
+	IssueID   string `json:"issue_id"`
+	Author    string `json:"author"`
+	Text      string `json:"text"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Comments returns an issue's comments, oldest first.
+func (b *Beads) Comments(id string) ([]*Comment, error) {
+	out, err := b.run("comments", id, "--json")
+	if err != nil {
+		return nil, err
 	}
 
-	// bd show supports multiple IDs
+	var comments []*Comment
+	if err := json.Unmarshal(out, &comments); err != nil {
+		return nil, fmt.Errorf("parsing bd comments output: %w", err)
+	}
+	return comments, nil
+}
+
+// showBatchSize caps the IDs passed to one bd show call, to stay well under
+// the OS argument length limit.
+const showBatchSize = 100
+
+// ShowMany fetches issues by ID with one bd call per showBatchSize IDs,
+// instead of one per issue. Issues are returned in the order of ids, without
+// duplicates; IDs that don't exist are left out. If a batch names a missing
+// issue, bd rejects the whole batch, so that batch is retried one ID at a
+// time. With a cache, only IDs without a fresh cached issue are fetched.
+func (b *Beads) ShowMany(ids []string) ([]*Issue, error) {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	byID := make(map[string]*Issue, len(unique))
+	toFetch := unique
+	if b.cache != nil {
+		beadsDir := b.resolvedBeadsDir()
+		toFetch = toFetch[:0:0]
+		for _, id := range unique {
+			if issue, ok := b.cache.get(beadsDir, id); ok {
+				byID[id] = issue
+			} else {
+				toFetch = append(toFetch, id)
+			}
+		}
+	}
+
+	for start := 0; start < len(toFetch); start += showBatchSize {
+		batch := toFetch[start:min(start+showBatchSize, len(toFetch))]
+		issues, err := b.showBatch(batch)
+		if errors.Is(err, ErrNotFound) {
+			issues, err = b.showEach(batch)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			byID[issue.ID] = issue
+			if b.cache != nil {
+				b.cache.put(b.resolvedBeadsDir(), issue)
+			}
+		}
+	}
+
+	result := make([]*Issue, 0, len(byID))
+	for _, id := range unique {
+		if issue, ok := byID[id]; ok {
+			result = append(result, issue)
+		}
+	}
+	return result, nil
+}
+
+// showBatch fetches issues with a single bd show call.
+func (b *Beads) showBatch(ids []string) ([]*Issue, error) {
 	args := append([]string{"show", "--json"}, ids...)
 	out, err := b.run(args...)
 	if err != nil {
-		// If bd fails, return empty map (some IDs might not exist)
-		return make(map[string]*Issue), nil
+		return nil, err
 	}
 
 	var issues []*Issue
 	if err := json.Unmarshal(out, &issues); err != nil {
 		return nil, fmt.Errorf("parsing bd show output: %w", err)
 	}
+	return issues, nil
+}
+
+// showEach fetches issues one bd call at a time, skipping missing ones.
+func (b *Beads) showEach(ids []string) ([]*Issue, error) {
+	var issues []*Issue
+	for _, id := range ids {
+		issue, err := b.Show(id)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// ShowMultiple fetches multiple issues by ID, batched like ShowMany.
+// Returns a map of ID to Issue. Missing IDs are not included in the map.
+func (b *Beads) ShowMultiple(ids []string) (map[string]*Issue, error) {
+	issues, err := b.ShowMany(ids)
+	if err != nil {
+		return make(map[string]*Issue), err
+	}
 
 	result := make(map[string]*Issue, len(issues))
 	for _, issue := range issues {
 		result[issue.ID] = issue
 	}
-
 	return result, nil
 }
 
@@ -447,8 +610,12 @@ func (b *Beads) Create(opts CreateOptions) (*Issue, error) {
 		args = append(args, "--title="+opts.Title)
 	}
 	// Type is deprecated: convert to gt:<type> label
+	labels := opts.Labels
 	if opts.Type != "" {
-		args = append(args, "--labels=gt:"+opts.Type)
+		labels = append([]string{"gt:" + opts.Type}, labels...)
+	}
+	if len(labels) > 0 {
+		args = append(args, "--labels="+strings.Join(labels, ","))
 	}
 	if opts.Priority >= 0 {
 		args = append(args, fmt.Sprintf("--priority=%d", opts.Priority))
@@ -630,18 +797,27 @@ func (b *Beads) ReleaseWithReason(id, reason string) error {
 	return err
 }
 
-// AddDependency adds a dependency: issue depends on dependsOn.
+// AddDependency adds a dependency: issue depends on dependsOn. dependsOn may
+// be a bead of another rig (otherrig:gt-42), stored as an external reference.
 func (b *Beads) AddDependency(issue, dependsOn string) error {
-	_, err := b.run("dep", "add", issue, dependsOn)
+	_, err := b.run("dep", "add", issue, dependsOnArg(dependsOn))
 	return err
 }
 
 // RemoveDependency removes a dependency.
 func (b *Beads) RemoveDependency(issue, dependsOn string) error {
-	_, err := b.run("dep", "remove", issue, dependsOn)
+	_, err := b.run("dep", "remove", issue, dependsOnArg(dependsOn))
 	return err
 }
 
+// dependsOnArg converts a cross-rig reference to bd's external form.
+func dependsOnArg(ref string) string {
+	if rig, id := ParseRef(ref); rig != "" {
+		return ExternalRef(rig, id)
+	}
+	return ref
+}
+
 // Sync syncs beads with remote.
 func (b *Beads) Sync() error {
 	_, err := b.run("sync")
@@ -6,8 +6,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestNew verifies the constructor.
@@ -33,6 +35,69 @@ func TestListOptions(t *testing.T) {
 	}
 }
 
+// TestListArgs verifies ListOptions filters are passed through to bd.
+func TestListArgs(t *testing.T) {
+	since := time.Date(2025, 12, 21, 10, 0, 0, 0, time.FixedZone("EST", -5*3600))
+
+	tests := []struct {
+		name string
+		opts ListOptions
+		want []string
+	}{
+		{
+			name: "defaults",
+			opts: ListOptions{Priority: -1},
+			want: []string{"list", "--json"},
+		},
+		{
+			name: "deprecated type",
+			opts: ListOptions{Type: "task", Priority: -1},
+			want: []string{"list", "--json", "--label=gt:task"},
+		},
+		{
+			name: "all filters",
+			opts: ListOptions{
+				Status:        "open",
+				Label:         "gt:molecule",
+				Labels:        []string{"digest", "patrol"},
+				Priority:      1,
+				Assignee:      "gastown/polecats/nux",
+				CreatedAfter:  since,
+				CreatedBefore: since.Add(24 * time.Hour),
+				UpdatedAfter:  since,
+				TitleContains: "Digest: mol-",
+				DescContains:  "attached_molecule:",
+				Sort:          "-created",
+				Limit:         5,
+			},
+			want: []string{
+				"list", "--json",
+				"--status=open",
+				"--label=gt:molecule",
+				"--label=digest",
+				"--label=patrol",
+				"--priority=1",
+				"--assignee=gastown/polecats/nux",
+				"--created-after=2025-12-21T15:00:00Z",
+				"--created-before=2025-12-22T15:00:00Z",
+				"--updated-after=2025-12-21T15:00:00Z",
+				"--title-contains=Digest: mol-",
+				"--desc-contains=attached_molecule:",
+				"--sort=-created",
+				"--limit=5",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := listArgs(tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("listArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestCreateOptions verifies CreateOptions fields.
 func TestCreateOptions(t *testing.T) {
 	opts := CreateOptions{
@@ -898,11 +963,65 @@ func TestAttachmentFieldsRoundTrip(t *testing.T) {
 		t.Fatal("round-trip parse returned nil")
 	}
 
-	if *parsed != *original {
+	if !reflect.DeepEqual(parsed, original) {
 		t.Errorf("round-trip mismatch:\ngot  %+v\nwant %+v", parsed, original)
 	}
 }
 
+// TestAttachmentStack tests pushing and removing molecules on the attachment stack.
+func TestAttachmentStack(t *testing.T) {
+	fields := &AttachmentFields{
+		AttachedMolecule: "mol-feature",
+		AttachedAt:       "2025-12-21T10:00:00Z",
+		AttachedArgs:     "focus on tests",
+	}
+	fields.Push("mol-hotfix", "2025-12-21T11:00:00Z")
+	fields.Push("mol-page", "2025-12-21T12:00:00Z")
+
+	if got, want := fields.Molecules(), []string{"mol-page", "mol-hotfix", "mol-feature"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Molecules() = %v, want %v", got, want)
+	}
+	if fields.AttachedArgs != "" {
+		t.Errorf("pushed molecule inherited args %q", fields.AttachedArgs)
+	}
+
+	// The stack survives a round trip through the description
+	desc := SetAttachmentFields(&Issue{Description: "Handoff notes."}, fields)
+	parsed := ParseAttachmentFields(&Issue{Description: desc})
+	if !reflect.DeepEqual(parsed, fields) {
+		t.Fatalf("round-trip mismatch:\ngot  %+v\nwant %+v\ndescription:\n%s", parsed, fields, desc)
+	}
+	if !strings.HasSuffix(desc, "Handoff notes.") {
+		t.Errorf("other content lost:\n%s", desc)
+	}
+
+	// Removing a suspended molecule leaves the top alone
+	if !parsed.Remove("mol-hotfix") {
+		t.Fatal("Remove(mol-hotfix) = false")
+	}
+	if parsed.AttachedMolecule != "mol-page" {
+		t.Errorf("top = %q after removing suspended molecule", parsed.AttachedMolecule)
+	}
+
+	// Removing the top resumes the suspended molecule with its own fields
+	if !parsed.Remove("") {
+		t.Fatal("Remove(top) = false")
+	}
+	if parsed.AttachedMolecule != "mol-feature" || parsed.AttachedArgs != "focus on tests" || len(parsed.Suspended) != 0 {
+		t.Errorf("after pop: %+v", parsed)
+	}
+
+	if parsed.Remove("mol-missing") {
+		t.Error("Remove(mol-missing) = true")
+	}
+	if !parsed.Remove("") || parsed.AttachedMolecule != "" {
+		t.Errorf("after final pop: %+v", parsed)
+	}
+	if SetAttachmentFields(&Issue{Description: desc}, nil) != "Handoff notes." {
+		t.Error("clearing the attachment left stack lines behind")
+	}
+}
+
 // TestResolveBeadsDir tests the redirect following logic.
 func TestResolveBeadsDir(t *testing.T) {
 	// Create temp directory structure
@@ -2374,3 +2493,116 @@ func TestCloseAndClearAgentBead_ReasonVariations(t *testing.T) {
 		})
 	}
 }
+
+// installShowStub puts a bd stub on PATH that answers "show" with one issue
+// per ID and fails the whole call if any ID is "gt-missing". Each call is
+// logged to the returned file, one line per call.
+func installShowStub(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "bd.log")
+	script := `#!/bin/sh
+while :; do
+  case "$1" in
+    --db) shift 2 ;;
+    --no-daemon|--allow-stale) shift ;;
+    *) break ;;
+  esac
+done
+[ "$1" = "show" ] || exit 1
+shift
+echo "$*" >> "` + logPath + `"
+out="["
+sep=""
+for arg in "$@"; do
+  case "$arg" in
+    --json) ;;
+    gt-missing) echo "Error: Issue not found: gt-missing" >&2; exit 1 ;;
+    *) out="$out$sep{\"id\":\"$arg\",\"title\":\"$arg\"}"; sep="," ;;
+  esac
+done
+echo "$out]"
+`
+	if err := os.WriteFile(filepath.Join(dir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return logPath
+}
+
+func bdCalls(t *testing.T, logPath string) []string {
+	t.Helper()
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestShowMany(t *testing.T) {
+	logPath := installShowStub(t)
+	b := NewIsolated(t.TempDir())
+
+	issues, err := b.ShowMany([]string{"gt-b", "gt-a", "gt-b", "gt-missing", ""})
+	if err != nil {
+		t.Fatalf("ShowMany: %v", err)
+	}
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.ID)
+	}
+	if want := []string{"gt-b", "gt-a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ShowMany IDs = %v, want %v", got, want)
+	}
+	// One batch call rejected for the missing ID, then one call per ID
+	if calls := bdCalls(t, logPath); len(calls) != 4 {
+		t.Errorf("got %d bd calls, want 4: %q", len(calls), calls)
+	}
+}
+
+func TestShowMany_Batches(t *testing.T) {
+	logPath := installShowStub(t)
+	b := NewIsolated(t.TempDir())
+
+	ids := make([]string, 2*showBatchSize+1)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("gt-%d", i)
+	}
+	issues, err := b.ShowMany(ids)
+	if err != nil {
+		t.Fatalf("ShowMany: %v", err)
+	}
+	if len(issues) != len(ids) || issues[0].ID != "gt-0" || issues[len(ids)-1].ID != ids[len(ids)-1] {
+		t.Errorf("got %d issues, want %d in order", len(issues), len(ids))
+	}
+	if calls := bdCalls(t, logPath); len(calls) != 3 {
+		t.Errorf("got %d bd calls, want 3", len(calls))
+	}
+}
+
+func TestComments(t *testing.T) {
+	dir := t.TempDir()
+	script := `#!/bin/sh
+while :; do
+  case "$1" in
+    --db) shift 2 ;;
+    --no-daemon|--allow-stale) shift ;;
+    *) break ;;
+  esac
+done
+[ "$1" = "comments" ] && [ "$2" = "gt-abc" ] || exit 1
+echo '[{"id":1,"issue_id":"gt-abc","author":"gastown/witness","text":"stuck on tests","created_at":"2026-01-02T03:04:05Z"}]'
+`
+	if err := os.WriteFile(filepath.Join(dir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	comments, err := NewIsolated(t.TempDir()).Comments("gt-abc")
+	if err != nil {
+		t.Fatalf("Comments: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Author != "gastown/witness" || comments[0].Text != "stuck on tests" {
+		t.Errorf("comments = %+v", comments)
+	}
+}
@@ -0,0 +1,218 @@
+package beads
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// DefaultCacheTTL is how long a cached issue is served before bd is asked
+// again. It is short: the cache saves repeated reads within a refresh loop,
+// not across minutes of other agents' writes.
+const DefaultCacheTTL = 5 * time.Second
+
+// readCommands are the bd commands that don't change issues. Any other
+// command run through a cached Beads drops that database's cached issues.
+var readCommands = map[string]bool{
+	"blocked": true,
+	"list":    true,
+	"ready":   true,
+	"search":  true,
+	"show":    true,
+	"stats":   true,
+	"version": true,
+}
+
+// Cache holds recently read issues, keyed by beads database and issue ID,
+// so loops that Show the same beads over and over (the feed TUI's convoy
+// panel, patrols) don't run bd for each read. Entries expire after the TTL,
+// and writes made through a Beads using the cache drop the database's
+// entries at once; writes by other processes are only seen once the TTL
+// runs out.
+//
+// A Cache is safe for concurrent use. With a directory it also keeps
+// entries on disk, so short-lived gt processes can share reads.
+type Cache struct {
+	ttl time.Duration
+	dir string // on-disk store; "" keeps entries in memory only
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+}
+
+type cacheKey struct {
+	beadsDir string
+	id       string
+}
+
+type cacheEntry struct {
+	Issue     *Issue    `json:"issue"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// NewCache creates an in-memory issue cache. A ttl <= 0 uses DefaultCacheTTL.
+func NewCache(ttl time.Duration) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &Cache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[cacheKey]cacheEntry),
+	}
+}
+
+// NewDiskCache creates an issue cache that also keeps entries as files
+// under dir, shared by every process using the same dir.
+func NewDiskCache(ttl time.Duration, dir string) *Cache {
+	c := NewCache(ttl)
+	c.dir = dir
+	return c
+}
+
+// WithCache returns a copy of b that reads issues through c. Show and
+// ShowMany serve fresh cached issues, and any write through the copy drops
+// the database's cached issues.
+func (b *Beads) WithCache(c *Cache) *Beads {
+	cached := *b
+	cached.cache = c
+	return &cached
+}
+
+// resolvedBeadsDir returns the beads directory b's commands run against.
+func (b *Beads) resolvedBeadsDir() string {
+	if b.beadsDir != "" {
+		return b.beadsDir
+	}
+	return ResolveBeadsDir(b.workDir)
+}
+
+// Issue returns the issue id of the database at beadsDir, calling fetch to
+// read it when there is no fresh cached copy. For callers that read beads
+// without a Beads, e.g. with their own timeouts.
+func (c *Cache) Issue(beadsDir, id string, fetch func() (*Issue, error)) (*Issue, error) {
+	if issue, ok := c.get(beadsDir, id); ok {
+		return issue, nil
+	}
+	issue, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.put(beadsDir, issue)
+	return issue, nil
+}
+
+// get returns a copy of the cached issue if it hasn't expired.
+func (c *Cache) get(beadsDir, id string) (*Issue, bool) {
+	key := cacheKey{beadsDir, id}
+	now := c.now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if !ok && c.dir != "" {
+		entry, ok = c.readDisk(key)
+		if ok {
+			c.mu.Lock()
+			c.entries[key] = entry
+			c.mu.Unlock()
+		}
+	}
+	if !ok || now.Sub(entry.FetchedAt) >= c.ttl {
+		return nil, false
+	}
+	return cloneIssue(entry.Issue), true
+}
+
+// put caches a copy of issue.
+func (c *Cache) put(beadsDir string, issue *Issue) {
+	if issue == nil || issue.ID == "" {
+		return
+	}
+	key := cacheKey{beadsDir, issue.ID}
+	entry := cacheEntry{Issue: cloneIssue(issue), FetchedAt: c.now()}
+
+	c.mu.Lock()
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	if c.dir != "" {
+		// Best effort: a failed write only costs a later bd call
+		_ = os.MkdirAll(c.diskDir(beadsDir), 0755)
+		_ = util.AtomicWriteJSON(c.diskPath(key), entry)
+	}
+}
+
+// Invalidate drops cached issues of the database at beadsDir: the given
+// IDs, or all of them if none are given.
+func (c *Cache) Invalidate(beadsDir string, ids ...string) {
+	c.mu.Lock()
+	if len(ids) == 0 {
+		for key := range c.entries {
+			if key.beadsDir == beadsDir {
+				delete(c.entries, key)
+			}
+		}
+	}
+	for _, id := range ids {
+		delete(c.entries, cacheKey{beadsDir, id})
+	}
+	c.mu.Unlock()
+
+	if c.dir == "" {
+		return
+	}
+	if len(ids) == 0 {
+		_ = os.RemoveAll(c.diskDir(beadsDir))
+	}
+	for _, id := range ids {
+		_ = os.Remove(c.diskPath(cacheKey{beadsDir, id}))
+	}
+}
+
+// readDisk loads an entry from the on-disk store.
+func (c *Cache) readDisk(key cacheKey) (cacheEntry, bool) {
+	data, err := os.ReadFile(c.diskPath(key))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Issue == nil {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// diskDir is where a database's entries are kept: one directory per beads
+// directory, named by a hash of its path.
+func (c *Cache) diskDir(beadsDir string) string {
+	sum := sha256.Sum256([]byte(beadsDir))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:8]))
+}
+
+func (c *Cache) diskPath(key cacheKey) string {
+	return filepath.Join(c.diskDir(key.beadsDir), url.PathEscape(key.id)+".json")
+}
+
+// cloneIssue copies an issue so callers can't change cached entries.
+func cloneIssue(issue *Issue) *Issue {
+	clone := *issue
+	clone.Children = slices.Clone(issue.Children)
+	clone.DependsOn = slices.Clone(issue.DependsOn)
+	clone.Blocks = slices.Clone(issue.Blocks)
+	clone.BlockedBy = slices.Clone(issue.BlockedBy)
+	clone.Labels = slices.Clone(issue.Labels)
+	clone.Dependencies = slices.Clone(issue.Dependencies)
+	clone.Dependents = slices.Clone(issue.Dependents)
+	return &clone
+}
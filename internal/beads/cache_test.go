@@ -0,0 +1,117 @@
+package beads
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheTTL(t *testing.T) {
+	now := time.Date(2025, 12, 21, 10, 0, 0, 0, time.UTC)
+	c := NewCache(5 * time.Second)
+	c.now = func() time.Time { return now }
+
+	fetches := 0
+	fetch := func() (*Issue, error) {
+		fetches++
+		return &Issue{ID: "gt-abc", Status: "open", Labels: []string{"gt:task"}}, nil
+	}
+
+	issue, err := c.Issue("/town/.beads", "gt-abc", fetch)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	// Changing a returned issue doesn't change the cached one
+	issue.Status = "closed"
+	issue.Labels[0] = "changed"
+
+	now = now.Add(4 * time.Second)
+	issue, _ = c.Issue("/town/.beads", "gt-abc", fetch)
+	if fetches != 1 {
+		t.Errorf("fetches = %d within TTL, want 1", fetches)
+	}
+	if issue.Status != "open" || issue.Labels[0] != "gt:task" {
+		t.Errorf("cached issue was modified through a returned copy: %+v", issue)
+	}
+
+	// Same ID in another database is a separate entry
+	_, _ = c.Issue("/town/gastown/.beads", "gt-abc", fetch)
+	if fetches != 2 {
+		t.Errorf("fetches = %d for another database, want 2", fetches)
+	}
+
+	now = now.Add(time.Second)
+	_, _ = c.Issue("/town/.beads", "gt-abc", fetch)
+	if fetches != 3 {
+		t.Errorf("fetches = %d after TTL, want 3", fetches)
+	}
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	c := NewCache(time.Minute)
+	c.put("/a/.beads", &Issue{ID: "gt-1"})
+	c.put("/a/.beads", &Issue{ID: "gt-2"})
+	c.put("/b/.beads", &Issue{ID: "gt-1"})
+
+	c.Invalidate("/a/.beads", "gt-1")
+	if _, ok := c.get("/a/.beads", "gt-1"); ok {
+		t.Error("gt-1 still cached after Invalidate(gt-1)")
+	}
+	if _, ok := c.get("/a/.beads", "gt-2"); !ok {
+		t.Error("gt-2 dropped by Invalidate(gt-1)")
+	}
+
+	c.Invalidate("/a/.beads")
+	if _, ok := c.get("/a/.beads", "gt-2"); ok {
+		t.Error("gt-2 still cached after invalidating its database")
+	}
+	if _, ok := c.get("/b/.beads", "gt-1"); !ok {
+		t.Error("other database's entry dropped")
+	}
+}
+
+func TestDiskCacheShared(t *testing.T) {
+	dir := t.TempDir()
+	writer := NewDiskCache(time.Minute, dir)
+	reader := NewDiskCache(time.Minute, dir)
+
+	writer.put("/town/.beads", &Issue{ID: "hq-cv.1", Title: "Convoy step"})
+	issue, ok := reader.get("/town/.beads", "hq-cv.1")
+	if !ok || issue.Title != "Convoy step" {
+		t.Fatalf("reader.get = %+v, %v; want entry written by another cache", issue, ok)
+	}
+
+	writer.Invalidate("/town/.beads")
+	if _, ok := NewDiskCache(time.Minute, dir).get("/town/.beads", "hq-cv.1"); ok {
+		t.Error("entry still on disk after Invalidate")
+	}
+}
+
+func TestBeadsWithCache(t *testing.T) {
+	logPath := installShowStub(t)
+	b := NewIsolated(t.TempDir()).WithCache(NewCache(time.Minute))
+
+	if _, err := b.Show("gt-a"); err != nil {
+		t.Fatalf("Show: %v", err)
+	}
+	if _, err := b.Show("gt-a"); err != nil {
+		t.Fatalf("Show: %v", err)
+	}
+	issues, err := b.ShowMany([]string{"gt-a", "gt-b"})
+	if err != nil || len(issues) != 2 {
+		t.Fatalf("ShowMany = %v, %v; want 2 issues", issues, err)
+	}
+	// One call for gt-a, one batch with only gt-b
+	calls := bdCalls(t, logPath)
+	if want := []string{"gt-a --json", "--json gt-b"}; len(calls) != 2 || calls[0] != want[0] || calls[1] != want[1] {
+		t.Errorf("bd calls = %q, want %q", calls, want)
+	}
+
+	// A write drops the cache, even when it fails (the stub only knows show)
+	_ = b.Close("gt-a")
+	if _, err := b.Show("gt-a"); err != nil {
+		t.Fatalf("Show: %v", err)
+	}
+	if calls := bdCalls(t, logPath); len(calls) != 3 {
+		t.Errorf("got %d bd show calls after a write, want 3: %q", len(calls), calls)
+	}
+}
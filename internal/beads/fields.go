@@ -2,6 +2,7 @@
 package beads
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -16,11 +17,94 @@ func ParseAgentFieldsFromDescription(description string) *AgentFields {
 
 // AttachmentFields holds the attachment info for pinned beads.
 // These fields track which molecule is attached to a handoff/pinned bead.
+//
+// Attachments form a stack: AttachedMolecule is the top, the molecule being
+// worked on. Pushing a molecule suspends the current one, and detaching the
+// top resumes the most recently suspended.
 type AttachmentFields struct {
-	AttachedMolecule string // Root issue ID of the attached molecule
-	AttachedAt       string // ISO 8601 timestamp when attached
-	AttachedArgs     string // Natural language args passed via gt sling --args (no-tmux mode)
-	DispatchedBy     string // Agent ID that dispatched this work (for completion notification)
+	AttachedMolecule string                // Root issue ID of the attached molecule
+	AttachedAt       string                // ISO 8601 timestamp when attached
+	AttachedArgs     string                // Natural language args passed via gt sling --args (no-tmux mode)
+	DispatchedBy     string                // Agent ID that dispatched this work (for completion notification)
+	Suspended        []SuspendedAttachment // Molecules pushed down the stack, most recent first
+}
+
+// SuspendedAttachment is a molecule suspended below the top of the
+// attachment stack, with the attachment fields it had when it was the top.
+type SuspendedAttachment struct {
+	Molecule     string `json:"molecule"`
+	AttachedAt   string `json:"attached_at,omitempty"`
+	AttachedArgs string `json:"attached_args,omitempty"`
+	DispatchedBy string `json:"dispatched_by,omitempty"`
+}
+
+// Molecules returns the attached molecules, top of the stack first.
+func (f *AttachmentFields) Molecules() []string {
+	if f == nil {
+		return nil
+	}
+	var ids []string
+	if f.AttachedMolecule != "" {
+		ids = append(ids, f.AttachedMolecule)
+	}
+	return append(ids, f.SuspendedMolecules()...)
+}
+
+// SuspendedMolecules returns the molecules suspended below the top, in the
+// order they resume.
+func (f *AttachmentFields) SuspendedMolecules() []string {
+	if f == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(f.Suspended))
+	for _, s := range f.Suspended {
+		ids = append(ids, s.Molecule)
+	}
+	return ids
+}
+
+// Push makes moleculeID the top of the stack, suspending the current top.
+func (f *AttachmentFields) Push(moleculeID, attachedAt string) {
+	if f.AttachedMolecule != "" {
+		f.Suspended = append([]SuspendedAttachment{{
+			Molecule:     f.AttachedMolecule,
+			AttachedAt:   f.AttachedAt,
+			AttachedArgs: f.AttachedArgs,
+			DispatchedBy: f.DispatchedBy,
+		}}, f.Suspended...)
+	}
+	f.AttachedMolecule = moleculeID
+	f.AttachedAt = attachedAt
+	f.AttachedArgs = ""
+	f.DispatchedBy = ""
+}
+
+// Remove takes moleculeID off the stack; an empty ID means the top. When the
+// top is removed, the most recently suspended molecule resumes. Returns false
+// if moleculeID isn't attached.
+func (f *AttachmentFields) Remove(moleculeID string) bool {
+	if moleculeID == "" || moleculeID == f.AttachedMolecule {
+		if f.AttachedMolecule == "" && len(f.Suspended) == 0 {
+			return false
+		}
+		f.AttachedMolecule, f.AttachedAt, f.AttachedArgs, f.DispatchedBy = "", "", "", ""
+		if len(f.Suspended) > 0 {
+			next := f.Suspended[0]
+			f.Suspended = f.Suspended[1:]
+			f.AttachedMolecule = next.Molecule
+			f.AttachedAt = next.AttachedAt
+			f.AttachedArgs = next.AttachedArgs
+			f.DispatchedBy = next.DispatchedBy
+		}
+		return true
+	}
+	for i, s := range f.Suspended {
+		if s.Molecule == moleculeID {
+			f.Suspended = append(f.Suspended[:i:i], f.Suspended[i+1:]...)
+			return true
+		}
+	}
+	return false
 }
 
 // ParseAttachmentFields extracts attachment fields from an issue's description.
@@ -65,6 +149,10 @@ func ParseAttachmentFields(issue *Issue) *AttachmentFields {
 		case "dispatched_by", "dispatched-by", "dispatchedby":
 			fields.DispatchedBy = value
 			hasFields = true
+		case "suspended_molecules", "suspended-molecules", "suspendedmolecules":
+			if err := json.Unmarshal([]byte(value), &fields.Suspended); err == nil && len(fields.Suspended) > 0 {
+				hasFields = true
+			}
 		}
 	}
 
@@ -95,6 +183,11 @@ func FormatAttachmentFields(fields *AttachmentFields) string {
 	if fields.DispatchedBy != "" {
 		lines = append(lines, "dispatched_by: "+fields.DispatchedBy)
 	}
+	if len(fields.Suspended) > 0 {
+		if data, err := json.Marshal(fields.Suspended); err == nil {
+			lines = append(lines, "suspended_molecules: "+string(data))
+		}
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -105,18 +198,21 @@ func FormatAttachmentFields(fields *AttachmentFields) string {
 func SetAttachmentFields(issue *Issue, fields *AttachmentFields) string {
 	// Known attachment field keys (lowercase)
 	attachmentKeys := map[string]bool{
-		"attached_molecule": true,
-		"attached-molecule": true,
-		"attachedmolecule":  true,
-		"attached_at":       true,
-		"attached-at":       true,
-		"attachedat":        true,
-		"attached_args":     true,
-		"attached-args":     true,
-		"attachedargs":      true,
-		"dispatched_by":     true,
-		"dispatched-by":     true,
-		"dispatchedby":      true,
+		"attached_molecule":   true,
+		"attached-molecule":   true,
+		"attachedmolecule":    true,
+		"attached_at":         true,
+		"attached-at":         true,
+		"attachedat":          true,
+		"attached_args":       true,
+		"attached-args":       true,
+		"attachedargs":        true,
+		"dispatched_by":       true,
+		"dispatched-by":       true,
+		"dispatchedby":        true,
+		"suspended_molecules": true,
+		"suspended-molecules": true,
+		"suspendedmolecules":  true,
 	}
 
 	// Collect non-attachment lines from existing description
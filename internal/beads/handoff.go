@@ -22,12 +22,13 @@ func HandoffBeadTitle(role string) string {
 // FindHandoffBead finds the pinned handoff bead for a role by title.
 // Returns nil if not found (not an error).
 func (b *Beads) FindHandoffBead(role string) (*Issue, error) {
-	issues, err := b.List(ListOptions{Status: StatusPinned, Priority: -1})
+	targetTitle := HandoffBeadTitle(role)
+	issues, err := b.List(ListOptions{Status: StatusPinned, TitleContains: targetTitle, Priority: -1})
 	if err != nil {
 		return nil, fmt.Errorf("listing pinned issues: %w", err)
 	}
 
-	targetTitle := HandoffBeadTitle(role)
+	// title-contains is a substring match; the title must match exactly
 	for _, issue := range issues {
 		if issue.Title == targetTitle {
 			return issue, nil
@@ -149,21 +150,13 @@ func (b *Beads) ClearMail(reason string) (*ClearMailResult, error) {
 }
 
 // AttachMolecule attaches a molecule to a pinned bead by updating its description.
-// The moleculeID is the root issue ID of the molecule to attach.
+// The moleculeID is the root issue ID of the molecule to attach. It replaces
+// the top of the attachment stack; suspended molecules are kept.
 // Returns the updated issue.
 func (b *Beads) AttachMolecule(pinnedBeadID, moleculeID string) (*Issue, error) {
-	// Fetch the pinned bead
-	issue, err := b.Show(pinnedBeadID)
+	issue, err := b.showAttachable(pinnedBeadID)
 	if err != nil {
-		return nil, fmt.Errorf("fetching pinned bead: %w", err)
-	}
-
-	// Allow pinned beads OR open polecat agent beads (polecats have a lifecycle, not permanent)
-	if issue.Status != StatusPinned {
-		_, role, _, ok := ParseAgentBeadID(pinnedBeadID)
-		if !(issue.Status == "open" && ok && role == "polecat") {
-			return nil, fmt.Errorf("issue %s is not pinned or open polecat (status: %s)", pinnedBeadID, issue.Status)
-		}
+		return nil, err
 	}
 
 	// Build attachment fields with current timestamp
@@ -171,20 +164,39 @@ func (b *Beads) AttachMolecule(pinnedBeadID, moleculeID string) (*Issue, error)
 		AttachedMolecule: moleculeID,
 		AttachedAt:       currentTimestamp(),
 	}
+	if existing := ParseAttachmentFields(issue); existing != nil {
+		fields.Suspended = existing.Suspended
+	}
 
-	// Update description with attachment fields
-	newDesc := SetAttachmentFields(issue, fields)
+	return b.updateAttachment(issue, fields)
+}
 
-	// Update the issue
-	if err := b.Update(pinnedBeadID, UpdateOptions{Description: &newDesc}); err != nil {
-		return nil, fmt.Errorf("updating pinned bead: %w", err)
+// PushMolecule attaches a molecule on top of the pinned bead's attachment
+// stack, suspending the molecule currently attached (if any). Detaching the
+// pushed molecule resumes the suspended one.
+// Returns the updated issue.
+func (b *Beads) PushMolecule(pinnedBeadID, moleculeID string) (*Issue, error) {
+	issue, err := b.showAttachable(pinnedBeadID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Re-fetch to return updated state
-	return b.Show(pinnedBeadID)
+	fields := ParseAttachmentFields(issue)
+	if fields == nil {
+		fields = &AttachmentFields{}
+	}
+	for _, id := range fields.Molecules() {
+		if id == moleculeID {
+			return nil, fmt.Errorf("molecule %s is already attached to %s", moleculeID, pinnedBeadID)
+		}
+	}
+	fields.Push(moleculeID, currentTimestamp())
+
+	return b.updateAttachment(issue, fields)
 }
 
-// DetachMolecule removes molecule attachment from a pinned bead.
+// DetachMolecule removes the top molecule from a pinned bead's attachment
+// stack, resuming the most recently suspended molecule if there is one.
 // Returns the updated issue.
 func (b *Beads) DetachMolecule(pinnedBeadID string) (*Issue, error) {
 	// Fetch the pinned bead
@@ -194,20 +206,46 @@ func (b *Beads) DetachMolecule(pinnedBeadID string) (*Issue, error) {
 	}
 
 	// Check if there's anything to detach
-	if ParseAttachmentFields(issue) == nil {
+	fields := ParseAttachmentFields(issue)
+	if fields == nil {
 		return issue, nil // Nothing to detach
 	}
+	fields.Remove("")
 
-	// Clear attachment fields by passing nil
-	newDesc := SetAttachmentFields(issue, nil)
+	return b.updateAttachment(issue, fields)
+}
 
-	// Update the issue
-	if err := b.Update(pinnedBeadID, UpdateOptions{Description: &newDesc}); err != nil {
+// showAttachable fetches a bead molecules can be attached to: a pinned bead
+// or an open polecat agent bead (polecats have a lifecycle, not permanent).
+func (b *Beads) showAttachable(pinnedBeadID string) (*Issue, error) {
+	issue, err := b.Show(pinnedBeadID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching pinned bead: %w", err)
+	}
+
+	if issue.Status != StatusPinned {
+		_, role, _, ok := ParseAgentBeadID(pinnedBeadID)
+		if !(issue.Status == "open" && ok && role == "polecat") {
+			return nil, fmt.Errorf("issue %s is not pinned or open polecat (status: %s)", pinnedBeadID, issue.Status)
+		}
+	}
+	return issue, nil
+}
+
+// updateAttachment writes attachment fields to a pinned bead's description
+// and returns the re-fetched bead. Empty fields clear the attachment.
+func (b *Beads) updateAttachment(issue *Issue, fields *AttachmentFields) (*Issue, error) {
+	if fields != nil && fields.AttachedMolecule == "" && len(fields.Suspended) == 0 {
+		fields = nil
+	}
+	newDesc := SetAttachmentFields(issue, fields)
+
+	if err := b.Update(issue.ID, UpdateOptions{Description: &newDesc}); err != nil {
 		return nil, fmt.Errorf("updating pinned bead: %w", err)
 	}
 
 	// Re-fetch to return updated state
-	return b.Show(pinnedBeadID)
+	return b.Show(issue.ID)
 }
 
 // GetAttachment returns the attachment fields from a pinned bead.
@@ -4,8 +4,10 @@ package beads
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // MoleculeStep represents a parsed step from a molecule definition.
@@ -14,10 +16,13 @@ type MoleculeStep struct {
 	Title        string         // Step title (first non-empty line or ref)
 	Instructions string         // Prose instructions for this step
 	Needs        []string       // Step refs this step depends on
+	External     []string       // Beads of other rigs this step depends on (otherrig:gt-42)
 	WaitsFor     []string       // Dynamic wait conditions (e.g., "all-children")
 	Tier         string         // Optional tier hint: haiku, sonnet, opus
 	Type         string         // Step type: "task" (default), "wait", etc.
 	Backoff      *BackoffConfig // Backoff configuration for wait-type steps
+	Condition    string         // Optional "If:" expression over context variables
+	SLA          time.Duration  // Optional time the step may take once started
 }
 
 // BackoffConfig defines exponential backoff parameters for wait-type steps.
@@ -49,6 +54,13 @@ var typeLineRegex = regexp.MustCompile(`(?i)^Type:\s*(\w+)\s*$`)
 // Parses backoff configuration for wait-type steps.
 var backoffLineRegex = regexp.MustCompile(`(?i)^Backoff:\s*(.+)$`)
 
+// ifLineRegex matches "If: <expression>" lines.
+// The step is only instantiated when the expression holds (see StepCondition).
+var ifLineRegex = regexp.MustCompile(`(?i)^If:\s*(.+)$`)
+
+// slaLineRegex matches "SLA: <duration>" lines, e.g. "SLA: 30m".
+var slaLineRegex = regexp.MustCompile(`(?i)^SLA:\s*(\S+)$`)
+
 // templateVarRegex matches {{variable}} placeholders.
 var templateVarRegex = regexp.MustCompile(`\{\{(\w+)\}\}`)
 
@@ -58,12 +70,15 @@ var templateVarRegex = regexp.MustCompile(`\{\{(\w+)\}\}`)
 //
 //	## Step: <ref>
 //	<prose instructions>
-//	Needs: <step>, <step>  # optional
+//	Needs: <step>, <rig>:<bead-id>  # optional; rig-qualified IDs are beads of other rigs
 //	Tier: haiku|sonnet|opus  # optional
 //	Type: task|wait  # optional, default is "task"
 //	Backoff: base=30s, multiplier=2, max=10m  # optional, for wait-type steps
+//	If: db == true  # optional, step is skipped when false at instantiation
+//	SLA: 30m  # optional, the witness escalates a step running longer
 //
-// Returns an empty slice if no steps are found.
+// Returns an empty slice if no steps are found, or an error if an If:
+// expression or SLA: duration doesn't parse.
 func ParseMoleculeSteps(description string) ([]MoleculeStep, error) {
 	if description == "" {
 		return nil, nil
@@ -73,6 +88,7 @@ func ParseMoleculeSteps(description string) ([]MoleculeStep, error) {
 	var steps []MoleculeStep
 	var currentStep *MoleculeStep
 	var contentLines []string
+	var parseErr error
 
 	// Helper to finalize current step
 	finalizeStep := func() {
@@ -90,7 +106,9 @@ func ParseMoleculeSteps(description string) ([]MoleculeStep, error) {
 				deps := strings.Split(matches[1], ",")
 				for _, dep := range deps {
 					dep = strings.TrimSpace(dep)
-					if dep != "" {
+					if IsCrossRigRef(dep) {
+						currentStep.External = append(currentStep.External, dep)
+					} else if dep != "" {
 						currentStep.Needs = append(currentStep.Needs, dep)
 					}
 				}
@@ -127,6 +145,25 @@ func ParseMoleculeSteps(description string) ([]MoleculeStep, error) {
 				continue
 			}
 
+			// Check for If: line
+			if matches := ifLineRegex.FindStringSubmatch(trimmed); matches != nil {
+				currentStep.Condition = strings.TrimSpace(matches[1])
+				if _, err := ParseStepCondition(currentStep.Condition); err != nil && parseErr == nil {
+					parseErr = fmt.Errorf("step %q: %w", currentStep.Ref, err)
+				}
+				continue
+			}
+
+			// Check for SLA: line
+			if matches := slaLineRegex.FindStringSubmatch(trimmed); matches != nil {
+				sla, err := time.ParseDuration(matches[1])
+				if (err != nil || sla <= 0) && parseErr == nil {
+					parseErr = fmt.Errorf("step %q: invalid SLA %q (want a duration like 30m)", currentStep.Ref, matches[1])
+				}
+				currentStep.SLA = sla
+				continue
+			}
+
 			// Regular instruction line
 			instructionLines = append(instructionLines, line)
 		}
@@ -171,6 +208,9 @@ func ParseMoleculeSteps(description string) ([]MoleculeStep, error) {
 	// Finalize last step
 	finalizeStep()
 
+	if parseErr != nil {
+		return nil, parseErr
+	}
 	return steps, nil
 }
 
@@ -256,15 +296,50 @@ type InstantiateOptions struct {
 //
 // For each step, this creates:
 //   - A child issue with ID "{parent.ID}.{step.Ref}"
-//   - Title from step title
+//   - Title from step title (with template vars expanded)
 //   - Description from step instructions (with template vars expanded)
 //   - Type: task
 //   - Priority: inherited from parent
 //   - Dependencies wired according to template
 //
 // The function is atomic via bd CLI - either all issues are created or none.
-// Returns the created step issues.
+// Returns the created step issues. Use PlanMolecule to see the steps without
+// creating them.
 func (b *Beads) InstantiateMolecule(mol *Issue, parent *Issue, opts InstantiateOptions) ([]*Issue, error) {
+	plan, err := b.PlanMolecule(mol, parent, opts)
+	if err != nil {
+		return nil, err
+	}
+	return b.createPlannedSteps(mol, parent, plan)
+}
+
+// PlannedStep is a step bead InstantiateMolecule would create.
+type PlannedStep struct {
+	Ref         string   `json:"ref"` // step ref, or template issue ID for child-issue molecules
+	Title       string   `json:"title"`
+	Type        string   `json:"type"`
+	Priority    int      `json:"priority"`
+	Description string   `json:"description"` // after context substitution, with provenance
+	Tier        string   `json:"tier,omitempty"`
+	Needs       []string `json:"needs,omitempty"`    // refs of planned steps this one depends on
+	External    []string `json:"external,omitempty"` // beads of other rigs it depends on (otherrig:gt-42)
+
+	// Unresolved lists {{variables}} left in the title or description
+	// because the context didn't define them.
+	Unresolved []string `json:"unresolved_vars,omitempty"`
+}
+
+// PlanMolecule works out the step beads InstantiateMolecule would create
+// under parent for the given context, without writing anything. Titles and
+// descriptions have template variables expanded, steps whose If: condition
+// is false are left out, and Needs only name steps in the plan.
+//
+// If the proto declares context variables (see MoleculeVar), the context is
+// checked against them first: missing required variables and ill-typed
+// values are errors, defaults are filled in, and placeholders for
+// undeclared variables are errors too. Protos without Var: lines leave
+// unknown placeholders as-is.
+func (b *Beads) PlanMolecule(mol *Issue, parent *Issue, opts InstantiateOptions) ([]PlannedStep, error) {
 	if mol == nil {
 		return nil, fmt.Errorf("molecule issue is nil")
 	}
@@ -283,24 +358,70 @@ func (b *Beads) InstantiateMolecule(mol *Issue, parent *Issue, opts InstantiateO
 		templateChildren = nil
 	}
 
+	// Check the context against the proto's Var: declarations, filling in defaults
+	schema, err := ParseMoleculeVarSchema(mol.Description)
+	if err != nil {
+		return nil, fmt.Errorf("context variables: %w", err)
+	}
+	if len(schema) > 0 {
+		if opts.Context, err = ResolveMoleculeVars(schema, opts.Context); err != nil {
+			return nil, err
+		}
+	}
+
+	var plan []PlannedStep
 	if len(templateChildren) > 0 {
 		// NEW FORMAT: Use child issues as templates
-		return b.instantiateFromChildren(mol, parent, templateChildren, opts)
+		plan, err = planFromChildren(mol, parent, templateChildren, opts)
+	} else {
+		// OLD FORMAT: Parse steps from molecule description
+		plan, err = planFromMarkdown(mol, parent, opts)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	// OLD FORMAT: Parse steps from molecule description
-	return b.instantiateFromMarkdown(mol, parent, opts)
+	// A proto that declares its variables must declare all of them; every
+	// declared one is in the context by now, so leftovers are undeclared.
+	if len(schema) > 0 {
+		for _, step := range plan {
+			if len(step.Unresolved) > 0 {
+				return nil, fmt.Errorf("step %q uses undeclared context variable(s): %s",
+					step.Ref, strings.Join(step.Unresolved, ", "))
+			}
+		}
+	}
+	return plan, nil
 }
 
-// instantiateFromChildren creates steps from template child issues (new format).
-func (b *Beads) instantiateFromChildren(mol *Issue, parent *Issue, templates []*Issue, opts InstantiateOptions) ([]*Issue, error) {
-	var createdIssues []*Issue
-	templateToNew := make(map[string]string) // template ID -> new issue ID
+// planFromChildren plans steps from template child issues (new format).
+// A template whose description carries an "If:" line is skipped when the
+// condition is false, and dependencies through it are bypassed.
+func planFromChildren(mol *Issue, parent *Issue, templates []*Issue, opts InstantiateOptions) ([]PlannedStep, error) {
+	// Evaluate step conditions before planning anything
+	kept := make(map[string]bool, len(templates))
+	needs := make(map[string][]string, len(templates))
+	descriptions := make(map[string]string, len(templates))
+	for _, tmpl := range templates {
+		condition, rest := splitConditionLine(tmpl.Description)
+		ok, err := EvalStepCondition(condition, opts.Context)
+		if err != nil {
+			return nil, fmt.Errorf("template %q: %w", tmpl.ID, err)
+		}
+		kept[tmpl.ID] = ok
+		needs[tmpl.ID] = tmpl.DependsOn
+		descriptions[tmpl.ID] = rest
+	}
+	dependsOn := bypassSkipped(needs, kept)
 
-	// First pass: create all child issues
+	var plan []PlannedStep
 	for _, tmpl := range templates {
+		if !kept[tmpl.ID] {
+			continue
+		}
+
 		// Expand template variables in description
-		description := tmpl.Description
+		description := descriptions[tmpl.ID]
 		if opts.Context != nil {
 			description = ExpandTemplateVars(description, opts.Context)
 		}
@@ -311,56 +432,30 @@ func (b *Beads) instantiateFromChildren(mol *Issue, parent *Issue, templates []*
 		}
 		description += fmt.Sprintf("instantiated_from: %s\ntemplate_step: %s", mol.ID, tmpl.ID)
 
-		// Create the child issue
-		childOpts := CreateOptions{
-			Title:       tmpl.Title,
+		step := PlannedStep{
+			Ref:         tmpl.ID,
+			Title:       ExpandTemplateVars(tmpl.Title, opts.Context),
 			Type:        tmpl.Type,
 			Priority:    parent.Priority,
 			Description: description,
-			Parent:      parent.ID,
-		}
-		if childOpts.Type == "" {
-			childOpts.Type = "task"
-		}
-
-		child, err := b.Create(childOpts)
-		if err != nil {
-			// Attempt to clean up created issues on failure (best-effort cleanup)
-			for _, created := range createdIssues {
-				_ = b.Close(created.ID)
-			}
-			return nil, fmt.Errorf("creating step from template %q: %w", tmpl.ID, err)
 		}
-
-		createdIssues = append(createdIssues, child)
-		templateToNew[tmpl.ID] = child.ID
-	}
-
-	// Second pass: wire dependencies based on template dependencies
-	for _, tmpl := range templates {
-		if len(tmpl.DependsOn) == 0 {
-			continue
+		if step.Type == "" {
+			step.Type = "task"
 		}
-
-		newChildID := templateToNew[tmpl.ID]
-		for _, depTemplateID := range tmpl.DependsOn {
-			newDepID, ok := templateToNew[depTemplateID]
-			if !ok {
-				// Dependency points outside the template - skip
-				continue
-			}
-			if err := b.AddDependency(newChildID, newDepID); err != nil {
-				// Log but don't fail - the issues are created
-				return createdIssues, fmt.Errorf("adding dependency %s -> %s: %w", newChildID, newDepID, err)
+		step.Unresolved = unresolvedTemplateVars(step.Title, step.Description)
+		for _, dep := range dependsOn[tmpl.ID] {
+			// Dependencies pointing outside the template are dropped
+			if kept[dep] {
+				step.Needs = append(step.Needs, dep)
 			}
 		}
+		plan = append(plan, step)
 	}
-
-	return createdIssues, nil
+	return plan, nil
 }
 
-// instantiateFromMarkdown creates steps from embedded markdown (old format).
-func (b *Beads) instantiateFromMarkdown(mol *Issue, parent *Issue, opts InstantiateOptions) ([]*Issue, error) {
+// planFromMarkdown plans steps from embedded markdown (old format).
+func planFromMarkdown(mol *Issue, parent *Issue, opts InstantiateOptions) ([]PlannedStep, error) {
 	// Parse steps from molecule
 	steps, err := ParseMoleculeSteps(mol.Description)
 	if err != nil {
@@ -386,10 +481,16 @@ func (b *Beads) instantiateFromMarkdown(mol *Issue, parent *Issue, opts Instanti
 		}
 	}
 
-	// Create child issues for each step
-	var createdIssues []*Issue
-	stepIssueIDs := make(map[string]string) // step ref -> issue ID
+	// Drop steps whose If: condition is false for this context
+	steps, err = SelectMoleculeSteps(steps, opts.Context)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("no molecule steps apply to the given context")
+	}
 
+	plan := make([]PlannedStep, 0, len(steps))
 	for _, step := range steps {
 		// Expand template variables in instructions
 		instructions := step.Instructions
@@ -406,17 +507,59 @@ func (b *Beads) instantiateFromMarkdown(mol *Issue, parent *Issue, opts Instanti
 		if step.Tier != "" {
 			description += fmt.Sprintf("\ntier: %s", step.Tier)
 		}
+		if step.SLA > 0 {
+			description += fmt.Sprintf("\nsla: %s", step.SLA)
+		}
 
-		// Create the child issue
-		childOpts := CreateOptions{
-			Title:       step.Title,
+		planned := PlannedStep{
+			Ref:         step.Ref,
+			Title:       ExpandTemplateVars(step.Title, opts.Context),
 			Type:        "task",
 			Priority:    parent.Priority,
 			Description: description,
-			Parent:      parent.ID,
+			Tier:        step.Tier,
+			Needs:       step.Needs,
+			External:    step.External,
 		}
+		planned.Unresolved = unresolvedTemplateVars(planned.Title, planned.Description)
+		plan = append(plan, planned)
+	}
+	return plan, nil
+}
 
-		child, err := b.Create(childOpts)
+// unresolvedTemplateVars returns the {{variable}} names still present in
+// texts, sorted and without duplicates.
+func unresolvedTemplateVars(texts ...string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, text := range texts {
+		for _, m := range templateVarRegex.FindAllStringSubmatch(text, -1) {
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				names = append(names, m[1])
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// createPlannedSteps creates the planned step beads under parent and wires
+// their dependencies. If a step can't be created, the ones already created
+// are closed.
+func (b *Beads) createPlannedSteps(mol *Issue, parent *Issue, plan []PlannedStep) ([]*Issue, error) {
+	var createdIssues []*Issue
+	stepIssueIDs := make(map[string]string) // step ref -> issue ID
+
+	for _, step := range plan {
+		child, err := b.Create(CreateOptions{
+			Title:       step.Title,
+			Type:        step.Type,
+			Priority:    step.Priority,
+			Description: step.Description,
+			Parent:      parent.ID,
+			Labels:      []string{MoleculeInstanceLabel(mol.ID)},
+		})
 		if err != nil {
 			// Attempt to clean up created issues on failure (best-effort cleanup)
 			for _, created := range createdIssues {
@@ -429,12 +572,8 @@ func (b *Beads) instantiateFromMarkdown(mol *Issue, parent *Issue, opts Instanti
 		stepIssueIDs[step.Ref] = child.ID
 	}
 
-	// Wire inter-step dependencies based on Needs: declarations
-	for _, step := range steps {
-		if len(step.Needs) == 0 {
-			continue
-		}
-
+	// Wire inter-step dependencies
+	for _, step := range plan {
 		childID := stepIssueIDs[step.Ref]
 		for _, need := range step.Needs {
 			dependsOnID := stepIssueIDs[need]
@@ -444,6 +583,11 @@ func (b *Beads) instantiateFromMarkdown(mol *Issue, parent *Issue, opts Instanti
 				return createdIssues, fmt.Errorf("adding dependency %s -> %s: %w", childID, dependsOnID, err)
 			}
 		}
+		for _, ref := range step.External {
+			if err := b.AddDependency(childID, ref); err != nil {
+				return createdIssues, fmt.Errorf("adding dependency %s -> %s: %w", childID, ref, err)
+			}
+		}
 	}
 
 	return createdIssues, nil
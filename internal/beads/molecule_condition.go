@@ -0,0 +1,367 @@
+package beads
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// StepCondition is a parsed "If:" expression on a molecule step. The step
+// is instantiated only when the condition holds for the molecule's context
+// variables.
+//
+// Grammar:
+//
+//	expr   = and { ("||" | "or") and }
+//	and    = unary { ("&&" | "and") unary }
+//	unary  = ("!" | "not") unary | "(" expr ")" | var [ ("==" | "=" | "!=") value ]
+//	value  = word | "quoted string"
+//
+// A bare variable is true when it is set to anything other than "", "false",
+// "0", "no", or "off". Undefined variables are empty.
+type StepCondition struct {
+	expr string
+	root condNode
+}
+
+type condNode interface {
+	eval(ctx map[string]string) bool
+}
+
+type (
+	condVar struct{ name string }
+	condCmp struct {
+		name, value string
+		negate      bool
+	}
+	condNot struct{ x condNode }
+	condAnd struct{ l, r condNode }
+	condOr  struct{ l, r condNode }
+)
+
+func (n condVar) eval(ctx map[string]string) bool { return truthy(ctx[n.name]) }
+func (n condCmp) eval(ctx map[string]string) bool { return (ctx[n.name] == n.value) != n.negate }
+func (n condNot) eval(ctx map[string]string) bool { return !n.x.eval(ctx) }
+func (n condAnd) eval(ctx map[string]string) bool { return n.l.eval(ctx) && n.r.eval(ctx) }
+func (n condOr) eval(ctx map[string]string) bool  { return n.l.eval(ctx) || n.r.eval(ctx) }
+
+// truthy reports whether a context value counts as true.
+func truthy(v string) bool {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "", "false", "0", "no", "off":
+		return false
+	}
+	return true
+}
+
+// ParseStepCondition parses an "If:" expression.
+func ParseStepCondition(expr string) (*StepCondition, error) {
+	toks, err := tokenizeCondition(expr)
+	if err != nil {
+		return nil, fmt.Errorf("condition %q: %w", expr, err)
+	}
+	p := &condParser{toks: toks}
+	root, err := p.parseOr()
+	if err == nil && p.pos < len(p.toks) {
+		err = fmt.Errorf("unexpected %q", p.toks[p.pos].text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("condition %q: %w", expr, err)
+	}
+	return &StepCondition{expr: expr, root: root}, nil
+}
+
+// Eval reports whether the condition holds for ctx.
+func (c *StepCondition) Eval(ctx map[string]string) bool {
+	return c.root.eval(ctx)
+}
+
+// Vars returns the context variables the condition reads, sorted.
+func (c *StepCondition) Vars() []string {
+	seen := make(map[string]bool)
+	var walk func(n condNode)
+	walk = func(n condNode) {
+		switch n := n.(type) {
+		case condVar:
+			seen[n.name] = true
+		case condCmp:
+			seen[n.name] = true
+		case condNot:
+			walk(n.x)
+		case condAnd:
+			walk(n.l)
+			walk(n.r)
+		case condOr:
+			walk(n.l)
+			walk(n.r)
+		}
+	}
+	walk(c.root)
+	vars := make([]string, 0, len(seen))
+	for v := range seen {
+		vars = append(vars, v)
+	}
+	sort.Strings(vars)
+	return vars
+}
+
+// String returns the expression as written.
+func (c *StepCondition) String() string {
+	return c.expr
+}
+
+// EvalStepCondition parses and evaluates expr against ctx. An empty
+// expression is true.
+func EvalStepCondition(expr string, ctx map[string]string) (bool, error) {
+	if strings.TrimSpace(expr) == "" {
+		return true, nil
+	}
+	c, err := ParseStepCondition(expr)
+	if err != nil {
+		return false, err
+	}
+	return c.Eval(ctx), nil
+}
+
+type condTokKind int
+
+const (
+	tokWord condTokKind = iota
+	tokString
+	tokOp
+)
+
+type condTok struct {
+	kind condTokKind
+	text string
+}
+
+// tokenizeCondition splits an expression into words, quoted strings, and
+// operators.
+func tokenizeCondition(s string) ([]condTok, error) {
+	var toks []condTok
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			toks = append(toks, condTok{tokString, s[i+1 : i+1+end]})
+			i += end + 2
+		case strings.HasPrefix(s[i:], "&&"), strings.HasPrefix(s[i:], "||"),
+			strings.HasPrefix(s[i:], "=="), strings.HasPrefix(s[i:], "!="):
+			toks = append(toks, condTok{tokOp, s[i : i+2]})
+			i += 2
+		case c == '!' || c == '(' || c == ')' || c == '=':
+			toks = append(toks, condTok{tokOp, string(c)})
+			i++
+		case isConditionWordByte(c):
+			start := i
+			for i < len(s) && isConditionWordByte(s[i]) {
+				i++
+			}
+			toks = append(toks, condTok{tokWord, s[start:i]})
+		default:
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+	return toks, nil
+}
+
+func isConditionWordByte(c byte) bool {
+	return c == '_' || c == '-' || c == '.' || c == '/' || c == ':' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+type condParser struct {
+	toks []condTok
+	pos  int
+}
+
+// accept consumes the next token if it is one of the given operators or
+// keywords.
+func (p *condParser) accept(ops ...string) bool {
+	if p.pos >= len(p.toks) {
+		return false
+	}
+	t := p.toks[p.pos]
+	for _, op := range ops {
+		if (t.kind == tokOp && t.text == op) || (t.kind == tokWord && strings.EqualFold(t.text, op)) {
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
+
+func (p *condParser) parseOr() (condNode, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||", "or") {
+		r, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l = condOr{l, r}
+	}
+	return l, nil
+}
+
+func (p *condParser) parseAnd() (condNode, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&", "and") {
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = condAnd{l, r}
+	}
+	return l, nil
+}
+
+func (p *condParser) parseUnary() (condNode, error) {
+	if p.accept("!", "not") {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return condNot{x}, nil
+	}
+	if p.accept("(") {
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, fmt.Errorf("missing )")
+		}
+		return x, nil
+	}
+	if p.pos >= len(p.toks) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	t := p.toks[p.pos]
+	if t.kind != tokWord {
+		return nil, fmt.Errorf("expected variable, got %q", t.text)
+	}
+	p.pos++
+	name := t.text
+
+	negate := false
+	switch {
+	case p.accept("==", "="):
+	case p.accept("!="):
+		negate = true
+	default:
+		return condVar{name}, nil
+	}
+	if p.pos >= len(p.toks) || p.toks[p.pos].kind == tokOp {
+		return nil, fmt.Errorf("expected value after %s", name)
+	}
+	value := p.toks[p.pos].text
+	p.pos++
+	return condCmp{name: name, value: value, negate: negate}, nil
+}
+
+// bypassSkipped rewires a dependency graph around skipped nodes: a kept
+// node that depended on a skipped one depends on the skipped node's own
+// (transitively kept) dependencies instead. needs maps node -> dependencies;
+// the result covers only kept nodes. Dependencies on nodes not in needs are
+// passed through unchanged.
+func bypassSkipped(needs map[string][]string, kept map[string]bool) map[string][]string {
+	resolved := make(map[string][]string)
+	visiting := make(map[string]bool)
+	var resolve func(node string) []string
+	resolve = func(node string) []string {
+		if deps, ok := resolved[node]; ok {
+			return deps
+		}
+		if visiting[node] {
+			return nil // cycle; validation reports it
+		}
+		visiting[node] = true
+		var deps []string
+		seen := make(map[string]bool)
+		for _, dep := range needs[node] {
+			targets := []string{dep}
+			if _, known := needs[dep]; known && !kept[dep] {
+				targets = resolve(dep)
+			}
+			for _, t := range targets {
+				if !seen[t] {
+					seen[t] = true
+					deps = append(deps, t)
+				}
+			}
+		}
+		visiting[node] = false
+		resolved[node] = deps
+		return deps
+	}
+
+	out := make(map[string][]string)
+	for node := range needs {
+		if kept[node] {
+			out[node] = resolve(node)
+		}
+	}
+	return out
+}
+
+// SelectMoleculeSteps returns the steps whose If: condition holds for ctx.
+// Skipped steps are bypassed: a kept step that needed a skipped one needs
+// the skipped step's own dependencies instead, so ordering is preserved.
+func SelectMoleculeSteps(steps []MoleculeStep, ctx map[string]string) ([]MoleculeStep, error) {
+	kept := make(map[string]bool, len(steps))
+	needs := make(map[string][]string, len(steps))
+	for _, step := range steps {
+		ok, err := EvalStepCondition(step.Condition, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("step %q: %w", step.Ref, err)
+		}
+		kept[step.Ref] = ok
+		needs[step.Ref] = step.Needs
+	}
+
+	rewired := bypassSkipped(needs, kept)
+	var selected []MoleculeStep
+	for _, step := range steps {
+		if !kept[step.Ref] {
+			continue
+		}
+		step.Needs = rewired[step.Ref]
+		selected = append(selected, step)
+	}
+	return selected, nil
+}
+
+// TemplateStepCondition returns the "If:" expression on a template child's
+// description, or "" if it has none.
+func TemplateStepCondition(description string) string {
+	condition, _ := splitConditionLine(description)
+	return condition
+}
+
+// splitConditionLine removes an "If:" line from a template child's
+// description and returns the expression and the remaining description.
+func splitConditionLine(description string) (condition, rest string) {
+	lines := strings.Split(description, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if matches := ifLineRegex.FindStringSubmatch(strings.TrimSpace(line)); matches != nil && condition == "" {
+			condition = strings.TrimSpace(matches[1])
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return condition, strings.TrimSpace(strings.Join(kept, "\n"))
+}
@@ -0,0 +1,67 @@
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// digestTraceHeader starts the machine-readable section of a molecule digest.
+const digestTraceHeader = "## Trace"
+
+// DigestTrace is the machine-readable record of a squashed molecule: which
+// step beads it had, which commits were made for it, and how long it ran.
+// It is written to the digest as a fenced JSON block under "## Trace".
+type DigestTrace struct {
+	Molecule         string         `json:"molecule"`
+	InstantiatedFrom string         `json:"instantiated_from,omitempty"`
+	StartedAt        string         `json:"started_at,omitempty"` // RFC3339, earliest step start
+	EndedAt          string         `json:"ended_at"`             // RFC3339, last step close or squash time
+	WallTimeSecs     int64          `json:"wall_time_secs"`
+	Steps            []DigestStep   `json:"steps"`
+	Commits          []DigestCommit `json:"commits,omitempty"`
+}
+
+// DigestStep is a step bead of a squashed molecule.
+type DigestStep struct {
+	ID           string `json:"id"`
+	Title        string `json:"title"`
+	Status       string `json:"status"` // status when squashed; "closed" means done
+	DurationSecs int64  `json:"duration_secs,omitempty"`
+}
+
+// DigestCommit is a commit made while a molecule ran.
+type DigestCommit struct {
+	Hash    string `json:"hash"`
+	Subject string `json:"subject"`
+	Time    string `json:"time"` // RFC3339 committer time
+	Ref     string `json:"ref"`  // molecule or step ID the commit references
+	Via     string `json:"via"`  // "message" or "branch"
+}
+
+// FormatDigestTrace renders the trace section of a digest description.
+func FormatDigestTrace(trace *DigestTrace) (string, error) {
+	data, err := json.MarshalIndent(trace, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encoding digest trace: %w", err)
+	}
+	return digestTraceHeader + "\n```json\n" + string(data) + "\n```\n", nil
+}
+
+// ParseDigestTrace extracts the trace from a digest description. Returns
+// nil if the digest has none (older digests, cancellations).
+func ParseDigestTrace(description string) (*DigestTrace, error) {
+	_, section, ok := strings.Cut(description, digestTraceHeader+"\n```json\n")
+	if !ok {
+		return nil, nil
+	}
+	body, _, ok := strings.Cut(section, "\n```")
+	if !ok {
+		return nil, fmt.Errorf("digest trace: unterminated JSON block")
+	}
+	var trace DigestTrace
+	if err := json.Unmarshal([]byte(body), &trace); err != nil {
+		return nil, fmt.Errorf("digest trace: %w", err)
+	}
+	return &trace, nil
+}
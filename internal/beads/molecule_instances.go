@@ -0,0 +1,115 @@
+// Package beads provides molecule instance lookup.
+package beads
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// moleculeInstanceLabelPrefix labels every step bead created by
+// InstantiateMolecule with the proto it came from, so instances can be found
+// with an indexed label query instead of scanning descriptions.
+const moleculeInstanceLabelPrefix = "instantiated_from:"
+
+// MoleculeInstanceLabel returns the label carried by steps instantiated
+// from the molecule proto molID.
+func MoleculeInstanceLabel(molID string) string {
+	return moleculeInstanceLabelPrefix + molID
+}
+
+// MoleculeInstance is one instantiation of a molecule proto: the parent
+// issue the steps were created under, and the steps themselves.
+type MoleculeInstance struct {
+	RootID string   `json:"root_id"`
+	Steps  []*Issue `json:"steps"`
+}
+
+// StepMoleculeID returns the proto a step was instantiated from, read from
+// its instantiated_from label, or from its description for steps created
+// before the label existed. Returns "" if the issue is not a molecule step.
+func StepMoleculeID(issue *Issue) string {
+	for _, label := range issue.Labels {
+		if molID, ok := strings.CutPrefix(label, moleculeInstanceLabelPrefix); ok {
+			return molID
+		}
+	}
+	for _, line := range strings.Split(issue.Description, "\n") {
+		if molID, ok := strings.CutPrefix(strings.TrimSpace(line), "instantiated_from:"); ok {
+			return strings.TrimSpace(molID)
+		}
+	}
+	return ""
+}
+
+// FindMoleculeInstances returns every instance of the molecule proto molID,
+// sorted by root ID. Steps are looked up by their instantiated_from label,
+// so the query is served by bd's label index. Instances created before
+// steps were labeled are not found. If since is non-zero, only instances
+// created since then are returned.
+func (b *Beads) FindMoleculeInstances(molID string, since time.Time) ([]*MoleculeInstance, error) {
+	steps, err := b.List(ListOptions{
+		Status:       "all",
+		Label:        MoleculeInstanceLabel(molID),
+		Priority:     -1,
+		Limit:        -1,
+		CreatedAfter: since,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing steps of %s: %w", molID, err)
+	}
+	return groupMoleculeInstances(steps), nil
+}
+
+// OpenMoleculeInstances returns the molecule instances, of any proto, that
+// still have steps that are not closed. Only open steps are listed, so an
+// instance's Steps holds its remaining work.
+func (b *Beads) OpenMoleculeInstances() ([]*MoleculeInstance, error) {
+	issues, err := b.List(ListOptions{Priority: -1, Limit: -1}) // bd list omits closed issues
+	if err != nil {
+		return nil, fmt.Errorf("listing open issues: %w", err)
+	}
+	return openMoleculeInstances(issues), nil
+}
+
+func openMoleculeInstances(issues []*Issue) []*MoleculeInstance {
+	var steps []*Issue
+	for _, issue := range issues {
+		if issue.Status != "closed" && StepMoleculeID(issue) != "" {
+			steps = append(steps, issue)
+		}
+	}
+	return groupMoleculeInstances(steps)
+}
+
+// groupMoleculeInstances groups steps by the issue they were instantiated
+// under, keeping step order within each instance.
+func groupMoleculeInstances(steps []*Issue) []*MoleculeInstance {
+	byRoot := make(map[string]*MoleculeInstance)
+	var instances []*MoleculeInstance
+	for _, step := range steps {
+		rootID := step.Parent
+		if rootID == "" {
+			// List output may omit the parent; hierarchical step IDs
+			// are <root>.<n>.
+			if i := strings.LastIndex(step.ID, "."); i > 0 {
+				rootID = step.ID[:i]
+			}
+		}
+		if rootID == "" {
+			continue
+		}
+		inst := byRoot[rootID]
+		if inst == nil {
+			inst = &MoleculeInstance{RootID: rootID}
+			byRoot[rootID] = inst
+			instances = append(instances, inst)
+		}
+		inst.Steps = append(inst.Steps, step)
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].RootID < instances[j].RootID
+	})
+	return instances
+}
@@ -0,0 +1,153 @@
+package beads
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// anyTierLineRegex matches any "Tier:" line, so lint can report tiers that
+// tierLineRegex doesn't accept.
+var anyTierLineRegex = regexp.MustCompile(`(?i)^Tier:\s*(.*)$`)
+
+// MoleculeLintIssue is a problem found in a molecule definition.
+type MoleculeLintIssue struct {
+	Line    int    `json:"line,omitempty"` // 1-based line in the description, 0 if not tied to one
+	Step    string `json:"step,omitempty"` // step ref, if the problem is in a step
+	Message string `json:"message"`
+}
+
+func (i MoleculeLintIssue) String() string {
+	var b strings.Builder
+	if i.Line > 0 {
+		fmt.Fprintf(&b, "line %d: ", i.Line)
+	}
+	if i.Step != "" {
+		fmt.Fprintf(&b, "step %q: ", i.Step)
+	}
+	b.WriteString(i.Message)
+	return b.String()
+}
+
+// LintMolecule checks a molecule definition (the markdown format parsed by
+// ParseMoleculeSteps) without touching the database. It reports invalid If:
+// expressions and SLAs, duplicate or unknown step refs, dependency cycles,
+// unknown tiers, malformed or duplicate Var: declarations, and context
+// variables used in {{placeholders}} or If: expressions but not declared
+// with a Var: line. Returns nil if the definition is clean.
+func LintMolecule(description string) []MoleculeLintIssue {
+	var issues []MoleculeLintIssue
+
+	// Line-level checks, tracking which step each line belongs to
+	headerLine := make(map[string]int) // step ref -> line of its first header
+	varLine := make(map[string]int)    // variable -> line of its first declaration
+	current := ""
+	for i, line := range strings.Split(description, "\n") {
+		lineNo := i + 1
+		if current == "" {
+			if matches := varLineRegex.FindStringSubmatch(strings.TrimSpace(line)); matches != nil {
+				name := matches[1]
+				if _, err := parseVarDecl(name, matches[2]); err != nil {
+					issues = append(issues, MoleculeLintIssue{Line: lineNo, Message: err.Error()})
+				}
+				if first, ok := varLine[name]; ok {
+					issues = append(issues, MoleculeLintIssue{Line: lineNo,
+						Message: fmt.Sprintf("variable %q declared twice (first on line %d)", name, first)})
+				} else {
+					varLine[name] = lineNo
+				}
+			}
+		}
+		if matches := stepHeaderRegex.FindStringSubmatch(line); matches != nil {
+			current = matches[1]
+			if first, ok := headerLine[current]; ok {
+				issues = append(issues, MoleculeLintIssue{Line: lineNo, Step: current,
+					Message: fmt.Sprintf("duplicate step ref (first defined on line %d)", first)})
+			} else {
+				headerLine[current] = lineNo
+			}
+			continue
+		}
+		if current == "" {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if matches := anyTierLineRegex.FindStringSubmatch(trimmed); matches != nil && !tierLineRegex.MatchString(trimmed) {
+			issues = append(issues, MoleculeLintIssue{Line: lineNo, Step: current,
+				Message: fmt.Sprintf("unknown tier %q (want haiku, sonnet, or opus)", strings.TrimSpace(matches[1]))})
+		}
+		if matches := slaLineRegex.FindStringSubmatch(trimmed); matches != nil {
+			if sla, err := time.ParseDuration(matches[1]); err != nil || sla <= 0 {
+				issues = append(issues, MoleculeLintIssue{Line: lineNo, Step: current,
+					Message: fmt.Sprintf("invalid SLA %q (want a duration like 30m)", matches[1])})
+			}
+		}
+		if matches := ifLineRegex.FindStringSubmatch(trimmed); matches != nil {
+			if _, err := ParseStepCondition(strings.TrimSpace(matches[1])); err != nil {
+				issues = append(issues, MoleculeLintIssue{Line: lineNo, Step: current, Message: err.Error()})
+			}
+		}
+	}
+	if len(headerLine) == 0 {
+		return append(issues, MoleculeLintIssue{Message: "no steps defined (expected \"## Step: <ref>\" sections)"})
+	}
+
+	steps, err := ParseMoleculeSteps(description)
+	if err != nil {
+		// Invalid If: expressions and SLAs were reported above with their lines
+		return issues
+	}
+
+	// Dependency checks
+	refsOK := true
+	for _, step := range steps {
+		for _, need := range step.Needs {
+			switch {
+			case need == step.Ref:
+				refsOK = false
+				issues = append(issues, MoleculeLintIssue{Line: headerLine[step.Ref], Step: step.Ref,
+					Message: "depends on itself"})
+			case headerLine[need] == 0:
+				refsOK = false
+				issues = append(issues, MoleculeLintIssue{Line: headerLine[step.Ref], Step: step.Ref,
+					Message: fmt.Sprintf("needs unknown step %q", need)})
+			}
+		}
+	}
+	if refsOK && len(steps) == len(headerLine) {
+		if err := detectCycles(steps); err != nil {
+			issues = append(issues, MoleculeLintIssue{Message: err.Error()})
+		}
+	}
+
+	// Context variables
+	for _, step := range steps {
+		used := make(map[string]bool)
+		for _, m := range templateVarRegex.FindAllStringSubmatch(step.Title+"\n"+step.Instructions, -1) {
+			used[m[1]] = true
+		}
+		if step.Condition != "" {
+			if cond, err := ParseStepCondition(step.Condition); err == nil {
+				for _, v := range cond.Vars() {
+					used[v] = true
+				}
+			}
+		}
+		var undefined []string
+		for v := range used {
+			if varLine[v] == 0 {
+				undefined = append(undefined, v)
+			}
+		}
+		sort.Strings(undefined)
+		for _, v := range undefined {
+			issues = append(issues, MoleculeLintIssue{Line: headerLine[step.Ref], Step: step.Ref,
+				Message: fmt.Sprintf("uses undefined context variable %q (declare it with \"Var: %s\")", v, v)})
+		}
+	}
+
+	sort.SliceStable(issues, func(a, b int) bool { return issues[a].Line < issues[b].Line })
+	return issues
+}
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseMoleculeSteps_EmptyDescription(t *testing.T) {
@@ -29,6 +30,32 @@ Just some prose text.`
 	}
 }
 
+func TestParseMoleculeSteps_CrossRigNeeds(t *testing.T) {
+	desc := `## Step: design
+Write the design.
+
+## Step: implement
+Build it.
+Needs: design, beads:bd-42`
+
+	steps, err := ParseMoleculeSteps(desc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(steps) != 2 {
+		t.Fatalf("expected 2 steps, got %d", len(steps))
+	}
+	if !reflect.DeepEqual(steps[1].Needs, []string{"design"}) {
+		t.Errorf("Needs = %v, want [design]", steps[1].Needs)
+	}
+	if !reflect.DeepEqual(steps[1].External, []string{"beads:bd-42"}) {
+		t.Errorf("External = %v, want [beads:bd-42]", steps[1].External)
+	}
+	if err := ValidateMolecule(&Issue{ID: "mol-x", Type: "molecule", Description: desc}); err != nil {
+		t.Errorf("ValidateMolecule: %v", err)
+	}
+}
+
 func TestParseMoleculeSteps_SingleStep(t *testing.T) {
 	desc := `## Step: implement
 Write the code carefully.
@@ -787,3 +814,508 @@ Needs: step1`
 		t.Errorf("step[1].Type = %q, want task", steps[1].Type)
 	}
 }
+
+func TestParseMoleculeSteps_WithCondition(t *testing.T) {
+	desc := `## Step: migrate
+Run the database migration.
+If: db == true
+Needs: setup
+
+## Step: setup
+Prepare the environment.`
+
+	steps, err := ParseMoleculeSteps(desc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if steps[0].Condition != "db == true" {
+		t.Errorf("Condition = %q, want 'db == true'", steps[0].Condition)
+	}
+	if steps[0].Instructions != "Run the database migration." {
+		t.Errorf("Instructions = %q, If: line should be stripped", steps[0].Instructions)
+	}
+	if steps[1].Condition != "" {
+		t.Errorf("Condition = %q, want empty", steps[1].Condition)
+	}
+
+	if _, err := ParseMoleculeSteps("## Step: a\nDo it.\nIf: db ==\n"); err == nil {
+		t.Error("expected error for invalid If: expression")
+	}
+}
+
+func TestEvalStepCondition(t *testing.T) {
+	ctx := map[string]string{"db": "true", "env": "prod", "docs": "no", "name": "my app"}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"", true},
+		{"db", true},
+		{"docs", false},
+		{"missing", false},
+		{"!docs", true},
+		{"not db", false},
+		{"db == true", true},
+		{"db=true", true},
+		{"env != prod", false},
+		{"env == staging || db", true},
+		{"env == staging or db and docs", false},
+		{"(env == staging || db) && !docs", true},
+		{`name == "my app"`, true},
+		{"name == 'my app' AND env == prod", true},
+		{"missing == ''", true},
+	}
+	for _, tt := range tests {
+		got, err := EvalStepCondition(tt.expr, ctx)
+		if err != nil {
+			t.Errorf("EvalStepCondition(%q) error: %v", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("EvalStepCondition(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"db ==", "(db", "db env", "&& db", `db == "open`, "db > 1"} {
+		if _, err := EvalStepCondition(expr, ctx); err == nil {
+			t.Errorf("EvalStepCondition(%q) expected error", expr)
+		}
+	}
+}
+
+func TestStepConditionVars(t *testing.T) {
+	cond, err := ParseStepCondition("(env == prod || db) && !db && docs != x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cond.Vars(), []string{"db", "docs", "env"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Vars() = %v, want %v", got, want)
+	}
+}
+
+func TestSelectMoleculeSteps(t *testing.T) {
+	steps := []MoleculeStep{
+		{Ref: "setup"},
+		{Ref: "migrate", Needs: []string{"setup"}, Condition: "db"},
+		{Ref: "seed", Needs: []string{"migrate"}, Condition: "db && seed"},
+		{Ref: "test", Needs: []string{"seed", "setup"}},
+	}
+
+	selected, err := SelectMoleculeSteps(steps, map[string]string{"db": "false"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(selected) != 2 || selected[0].Ref != "setup" || selected[1].Ref != "test" {
+		t.Fatalf("selected = %+v, want setup and test", selected)
+	}
+	// test needed seed -> migrate -> setup; bypassing both leaves setup once.
+	if !reflect.DeepEqual(selected[1].Needs, []string{"setup"}) {
+		t.Errorf("test.Needs = %v, want [setup]", selected[1].Needs)
+	}
+
+	selected, err = SelectMoleculeSteps(steps, map[string]string{"db": "true"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(selected) != 3 || !reflect.DeepEqual(selected[2].Needs, []string{"migrate", "setup"}) {
+		t.Errorf("selected = %+v, want test to need migrate and setup", selected)
+	}
+	// The input is not modified.
+	if !reflect.DeepEqual(steps[3].Needs, []string{"seed", "setup"}) {
+		t.Errorf("input Needs modified: %v", steps[3].Needs)
+	}
+}
+
+func TestSplitConditionLine(t *testing.T) {
+	cond, rest := splitConditionLine("Run the migration.\nif: db == true\n\nCheck logs.")
+	if cond != "db == true" || rest != "Run the migration.\n\nCheck logs." {
+		t.Errorf("splitConditionLine = %q, %q", cond, rest)
+	}
+	if cond, rest := splitConditionLine("No condition."); cond != "" || rest != "No condition." {
+		t.Errorf("splitConditionLine = %q, %q", cond, rest)
+	}
+}
+
+func TestParseMoleculeVars(t *testing.T) {
+	desc := `# Deploy
+Var: env
+var: db
+Var: bad name
+
+## Step: deploy
+Var: ignored
+Deploy to {{env}}.`
+
+	if got, want := ParseMoleculeVars(desc), []string{"env", "db", "bad"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseMoleculeVars = %v, want %v", got, want)
+	}
+}
+
+func TestLintMolecule_Clean(t *testing.T) {
+	desc := `# Deploy
+Var: env
+Var: db
+
+## Step: migrate
+Migrate the {{env}} database.
+If: db
+Tier: haiku
+
+## Step: deploy
+Deploy to {{env}}.
+Needs: migrate`
+
+	if issues := LintMolecule(desc); len(issues) != 0 {
+		t.Errorf("LintMolecule = %v, want no issues", issues)
+	}
+}
+
+func TestLintMolecule_Problems(t *testing.T) {
+	desc := `# Broken
+Var: env
+
+## Step: build
+Build for {{env}} in {{region}}.
+Tier: gpt
+If: db == 
+
+## Step: test
+Run tests.
+Needs: build, lint
+
+## Step: build
+Again.`
+
+	var got []string
+	for _, issue := range LintMolecule(desc) {
+		got = append(got, issue.String())
+	}
+	want := []string{
+		`line 6: step "build": unknown tier "gpt" (want haiku, sonnet, or opus)`,
+		`line 7: step "build": condition "db ==": expected value after db`,
+		`line 13: step "build": duplicate step ref (first defined on line 4)`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LintMolecule =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Once the If: parses, dependency and variable checks run too.
+	desc = strings.Replace(desc, "If: db == ", "If: db", 1)
+	got = nil
+	for _, issue := range LintMolecule(desc) {
+		got = append(got, issue.String())
+	}
+	want = []string{
+		`line 4: step "build": uses undefined context variable "db" (declare it with "Var: db")`,
+		`line 4: step "build": uses undefined context variable "region" (declare it with "Var: region")`,
+		`line 6: step "build": unknown tier "gpt" (want haiku, sonnet, or opus)`,
+		`line 9: step "test": needs unknown step "lint"`,
+		`line 13: step "build": duplicate step ref (first defined on line 4)`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LintMolecule =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestLintMolecule_CycleAndNoSteps(t *testing.T) {
+	desc := `## Step: a
+Needs: b
+
+## Step: b
+Needs: a`
+
+	issues := LintMolecule(desc)
+	if len(issues) != 1 || !strings.Contains(issues[0].Message, "cycle detected") {
+		t.Errorf("LintMolecule = %v, want a cycle", issues)
+	}
+
+	issues = LintMolecule("# Empty\nJust prose.")
+	if len(issues) != 1 || !strings.Contains(issues[0].Message, "no steps defined") {
+		t.Errorf("LintMolecule = %v, want no steps", issues)
+	}
+}
+
+func TestStepMoleculeID(t *testing.T) {
+	labeled := &Issue{Labels: []string{"gt:task", MoleculeInstanceLabel("mol-deploy")}}
+	if got := StepMoleculeID(labeled); got != "mol-deploy" {
+		t.Errorf("labeled step: got %q, want mol-deploy", got)
+	}
+
+	// Steps created before labeling carry provenance in the description only
+	legacy := &Issue{Description: "Do it.\n\ninstantiated_from: mol-old\nstep: build"}
+	if got := StepMoleculeID(legacy); got != "mol-old" {
+		t.Errorf("legacy step: got %q, want mol-old", got)
+	}
+
+	if got := StepMoleculeID(&Issue{Description: "plain issue"}); got != "" {
+		t.Errorf("plain issue: got %q, want empty", got)
+	}
+}
+
+func TestGroupMoleculeInstances(t *testing.T) {
+	steps := []*Issue{
+		{ID: "gt-b.1", Parent: "gt-b"},
+		{ID: "gt-a.1", Parent: "gt-a"},
+		{ID: "gt-b.2", Parent: "gt-b"},
+		{ID: "gt-a.2"},    // parent inferred from hierarchical ID
+		{ID: "gt-orphan"}, // no parent, no hierarchy: dropped
+	}
+
+	instances := groupMoleculeInstances(steps)
+	if len(instances) != 2 {
+		t.Fatalf("got %d instances, want 2", len(instances))
+	}
+	if instances[0].RootID != "gt-a" || instances[1].RootID != "gt-b" {
+		t.Errorf("roots = %s, %s; want gt-a, gt-b", instances[0].RootID, instances[1].RootID)
+	}
+	if len(instances[0].Steps) != 2 || instances[0].Steps[1].ID != "gt-a.2" {
+		t.Errorf("gt-a steps = %v", instances[0].Steps)
+	}
+	if len(instances[1].Steps) != 2 || instances[1].Steps[0].ID != "gt-b.1" {
+		t.Errorf("gt-b steps = %v", instances[1].Steps)
+	}
+}
+
+func TestOpenMoleculeInstances(t *testing.T) {
+	label := []string{MoleculeInstanceLabel("mol-review")}
+	issues := []*Issue{
+		{ID: "gt-a.1", Parent: "gt-a", Status: "open", Labels: label},
+		{ID: "gt-a.2", Parent: "gt-a", Status: "closed", Labels: label},
+		{ID: "gt-b.1", Parent: "gt-b", Status: "closed", Labels: label},
+		{ID: "gt-c.1", Parent: "gt-c", Status: "in_progress", Description: "instantiated_from: mol-old"},
+		{ID: "gt-d.1", Parent: "gt-d", Status: "open"}, // not a molecule step
+	}
+
+	instances := openMoleculeInstances(issues)
+	if len(instances) != 2 || instances[0].RootID != "gt-a" || instances[1].RootID != "gt-c" {
+		t.Fatalf("instances = %+v; want gt-a and gt-c", instances)
+	}
+	if len(instances[0].Steps) != 1 {
+		t.Errorf("gt-a has %d open steps, want 1", len(instances[0].Steps))
+	}
+}
+
+func TestPlanFromMarkdown(t *testing.T) {
+	mol := &Issue{ID: "mol-deploy", Description: `## Step: build
+Build {{service}} for {{env}}.
+Tier: sonnet
+
+## Step: canary
+Canary {{service}}.
+Needs: build
+If: env == prod
+
+## Step: release
+Release {{service}} ({{version}}).
+Needs: canary`}
+	parent := &Issue{ID: "gt-abc", Priority: 2}
+
+	plan, err := planFromMarkdown(mol, parent, InstantiateOptions{Context: map[string]string{"service": "api", "env": "staging"}})
+	if err != nil {
+		t.Fatalf("planFromMarkdown: %v", err)
+	}
+	if len(plan) != 2 {
+		t.Fatalf("got %d steps, want 2 (canary skipped): %+v", len(plan), plan)
+	}
+
+	build, release := plan[0], plan[1]
+	if build.Ref != "build" || build.Tier != "sonnet" || build.Priority != 2 || build.Type != "task" {
+		t.Errorf("build = %+v", build)
+	}
+	if !strings.Contains(build.Description, "Build api for staging.") ||
+		!strings.Contains(build.Description, "instantiated_from: mol-deploy\nstep: build\ntier: sonnet") {
+		t.Errorf("build description = %q", build.Description)
+	}
+	if len(build.Unresolved) != 0 {
+		t.Errorf("build unresolved = %v, want none", build.Unresolved)
+	}
+
+	// canary was skipped, so release needs what canary needed
+	if !reflect.DeepEqual(release.Needs, []string{"build"}) {
+		t.Errorf("release needs = %v, want [build]", release.Needs)
+	}
+	if !reflect.DeepEqual(release.Unresolved, []string{"version"}) {
+		t.Errorf("release unresolved = %v, want [version]", release.Unresolved)
+	}
+}
+
+func TestPlanFromChildren(t *testing.T) {
+	mol := &Issue{ID: "mol-proto"}
+	parent := &Issue{ID: "gt-abc", Priority: 1}
+	templates := []*Issue{
+		{ID: "mol-proto.1", Title: "Prepare {{target}}", Description: "Get ready."},
+		{ID: "mol-proto.2", Title: "Migrate", Type: "chore", Description: "If: migrate\nRun migrations.", DependsOn: []string{"mol-proto.1"}},
+		{ID: "mol-proto.3", Title: "Ship", Description: "Ship it.", DependsOn: []string{"mol-proto.2", "gt-outside"}},
+	}
+
+	plan, err := planFromChildren(mol, parent, templates, InstantiateOptions{Context: map[string]string{"target": "db"}})
+	if err != nil {
+		t.Fatalf("planFromChildren: %v", err)
+	}
+	if len(plan) != 2 {
+		t.Fatalf("got %d steps, want 2 (migrate skipped): %+v", len(plan), plan)
+	}
+	if plan[0].Title != "Prepare db" || plan[0].Type != "task" {
+		t.Errorf("first step = %+v", plan[0])
+	}
+	// Ship inherits Migrate's dependency; the outside dependency is dropped
+	if !reflect.DeepEqual(plan[1].Needs, []string{"mol-proto.1"}) {
+		t.Errorf("ship needs = %v, want [mol-proto.1]", plan[1].Needs)
+	}
+	if !strings.HasSuffix(plan[1].Description, "instantiated_from: mol-proto\ntemplate_step: mol-proto.3") {
+		t.Errorf("ship description = %q", plan[1].Description)
+	}
+}
+
+func TestParseMoleculeVarSchema(t *testing.T) {
+	desc := `# Deploy
+Var: env string required -- Environment to deploy to
+Var: replicas int default=3 -- Pods to run
+Var: note default="two words"
+Var: canary bool optional
+Var: region
+
+## Step: deploy
+Var: ignored string
+Deploy.`
+
+	got, err := ParseMoleculeVarSchema(desc)
+	if err != nil {
+		t.Fatalf("ParseMoleculeVarSchema: %v", err)
+	}
+	want := []MoleculeVar{
+		{Name: "env", Type: "string", Required: true, Description: "Environment to deploy to", Line: 2},
+		{Name: "replicas", Type: "int", Default: "3", HasDefault: true, Description: "Pods to run", Line: 3},
+		{Name: "note", Type: "string", Default: "two words", HasDefault: true, Line: 4},
+		{Name: "canary", Type: "bool", Line: 5},
+		{Name: "region", Type: "string", Required: true, Line: 6},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseMoleculeVarSchema =\n%+v\nwant\n%+v", got, want)
+	}
+
+	for _, bad := range []string{
+		"Var: x float",
+		"Var: x required optional",
+		"Var: x required default=1",
+		"Var: x int default=many",
+	} {
+		if _, err := ParseMoleculeVarSchema(bad); err == nil {
+			t.Errorf("ParseMoleculeVarSchema(%q): expected error", bad)
+		}
+	}
+}
+
+func TestResolveMoleculeVars(t *testing.T) {
+	schema := []MoleculeVar{
+		{Name: "env", Type: "string", Required: true},
+		{Name: "replicas", Type: "int", Default: "3", HasDefault: true},
+		{Name: "canary", Type: "bool"},
+	}
+
+	got, err := ResolveMoleculeVars(schema, map[string]string{"env": "prod", "extra": "x"})
+	if err != nil {
+		t.Fatalf("ResolveMoleculeVars: %v", err)
+	}
+	want := map[string]string{"env": "prod", "replicas": "3", "canary": "", "extra": "x"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveMoleculeVars = %v, want %v", got, want)
+	}
+
+	if _, err := ResolveMoleculeVars(schema, nil); err == nil || !strings.Contains(err.Error(), "missing required context variable(s): env") {
+		t.Errorf("missing env: err = %v", err)
+	}
+	if _, err := ResolveMoleculeVars(schema, map[string]string{"env": "prod", "replicas": "lots"}); err == nil {
+		t.Error("replicas=lots: expected type error")
+	}
+	if _, err := ResolveMoleculeVars(schema, map[string]string{"env": "prod", "canary": "maybe"}); err == nil {
+		t.Error("canary=maybe: expected type error")
+	}
+}
+
+func TestLintMolecule_Vars(t *testing.T) {
+	desc := `# Vars
+Var: env string required
+Var: env
+Var: count number
+
+## Step: go
+Go to {{env}} {{count}} times.`
+
+	var got []string
+	for _, issue := range LintMolecule(desc) {
+		got = append(got, issue.String())
+	}
+	want := []string{
+		`line 3: variable "env" declared twice (first on line 2)`,
+		`line 4: variable "count": unknown attribute "number" (want a type, required, optional, default=<value>, or -- <description>)`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LintMolecule =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestParseMoleculeSteps_SLA(t *testing.T) {
+	steps, err := ParseMoleculeSteps("## Step: review\nReview the change.\nSLA: 45m")
+	if err != nil {
+		t.Fatalf("ParseMoleculeSteps: %v", err)
+	}
+	if steps[0].SLA != 45*time.Minute || steps[0].Instructions != "Review the change." {
+		t.Errorf("step = %+v", steps[0])
+	}
+
+	if _, err := ParseMoleculeSteps("## Step: review\nSLA: soon"); err == nil {
+		t.Error("SLA: soon: expected error")
+	}
+	issues := LintMolecule("## Step: review\nReview.\nSLA: soon")
+	if len(issues) != 1 || issues[0].String() != `line 3: step "review": invalid SLA "soon" (want a duration like 30m)` {
+		t.Errorf("LintMolecule = %v", issues)
+	}
+}
+
+func TestStepTimingFields(t *testing.T) {
+	issue := &Issue{Description: "Do it.\n\ninstantiated_from: mol-x\nstep: a\nsla: 30m0s\n"}
+	fields := ParseStepTimingFields(issue)
+	if fields.SLA != "30m0s" || fields.StartedAt != "" {
+		t.Fatalf("fields = %+v", fields)
+	}
+
+	fields.StartedAt = "2026-01-02T10:00:00Z"
+	issue.Description = SetStepTimingFields(issue, fields)
+	want := "Do it.\n\ninstantiated_from: mol-x\nstep: a\nsla: 30m0s\nstarted_at: 2026-01-02T10:00:00Z"
+	if issue.Description != want {
+		t.Errorf("description = %q, want %q", issue.Description, want)
+	}
+	if got := ParseStepTimingFields(issue); got.StartedAt != fields.StartedAt {
+		t.Errorf("round trip StartedAt = %q", got.StartedAt)
+	}
+}
+
+func TestTimeMoleculeSteps(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	steps := []*Issue{
+		// Done: started 10:00 per started_at, closed 10:30
+		{ID: "gt-m.1", Status: "closed", CreatedAt: "2026-01-02T09:00:00Z", ClosedAt: "2026-01-02T10:30:00Z",
+			Description: "sla: 20m\nstarted_at: 2026-01-02T10:00:00Z"},
+		// Running without started_at: ready when gt-m.1 closed, 90m ago
+		{ID: "gt-m.2", Status: "in_progress", CreatedAt: "2026-01-02T09:00:00Z", DependsOn: []string{"gt-m.1"},
+			Description: "sla: 2h"},
+		// Not picked up yet
+		{ID: "gt-m.3", Status: "open", CreatedAt: "2026-01-02T09:00:00Z", DependsOn: []string{"gt-m.2"}},
+	}
+
+	timing := TimeMoleculeSteps(steps, now)
+	if len(timing.Steps) != 2 {
+		t.Fatalf("got %d timed steps, want 2: %+v", len(timing.Steps), timing.Steps)
+	}
+	done, running := timing.Steps[0], timing.Steps[1]
+	if done.Duration() != 30*time.Minute || !done.OverSLA || done.Running || done.Estimated {
+		t.Errorf("done step = %+v", done)
+	}
+	if running.Duration() != 90*time.Minute || running.OverSLA || !running.Running || !running.Estimated {
+		t.Errorf("running step = %+v", running)
+	}
+	if timing.StartedAt != "2026-01-02T10:00:00Z" || timing.ClosedAt != "" || timing.DurationSecs != int64(2*time.Hour/time.Second) {
+		t.Errorf("instance timing = %+v", timing)
+	}
+}
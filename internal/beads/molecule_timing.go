@@ -0,0 +1,224 @@
+package beads
+
+import (
+	"strings"
+	"time"
+)
+
+// StepTimingFields holds the timing fields of an instantiated molecule step.
+// The SLA is written at instantiation (from the proto's "SLA:" line);
+// started_at when an agent picks the step up; sla_escalated_at when the
+// witness escalates the step for running past its SLA.
+type StepTimingFields struct {
+	SLA            string // e.g. "30m0s"
+	StartedAt      string // RFC3339
+	SLAEscalatedAt string // RFC3339
+}
+
+// stepTimingKeys are the description keys of StepTimingFields (lowercase).
+var stepTimingKeys = map[string]bool{
+	"sla":              true,
+	"started_at":       true,
+	"sla_escalated_at": true,
+}
+
+// ParseStepTimingFields extracts timing fields from a step's description.
+func ParseStepTimingFields(issue *Issue) *StepTimingFields {
+	fields := &StepTimingFields{}
+	if issue == nil {
+		return fields
+	}
+	for _, line := range strings.Split(issue.Description, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "sla":
+			fields.SLA = value
+		case "started_at":
+			fields.StartedAt = value
+		case "sla_escalated_at":
+			fields.SLAEscalatedAt = value
+		}
+	}
+	return fields
+}
+
+// SetStepTimingFields updates a step's description with the given timing
+// fields. Existing timing lines are replaced and written at the end, with the
+// step's other provenance; other content is preserved. Returns the new
+// description.
+func SetStepTimingFields(issue *Issue, fields *StepTimingFields) string {
+	var lines []string
+	if issue != nil && issue.Description != "" {
+		for _, line := range strings.Split(issue.Description, "\n") {
+			if key, _, ok := strings.Cut(strings.TrimSpace(line), ":"); ok && stepTimingKeys[strings.ToLower(strings.TrimSpace(key))] {
+				continue
+			}
+			lines = append(lines, line)
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if fields.SLA != "" {
+		lines = append(lines, "sla: "+fields.SLA)
+	}
+	if fields.StartedAt != "" {
+		lines = append(lines, "started_at: "+fields.StartedAt)
+	}
+	if fields.SLAEscalatedAt != "" {
+		lines = append(lines, "sla_escalated_at: "+fields.SLAEscalatedAt)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// MarkStepStarted records when a molecule step was picked up, unless it
+// already has a start time.
+func (b *Beads) MarkStepStarted(id string, at time.Time) error {
+	issue, err := b.Show(id)
+	if err != nil {
+		return err
+	}
+	fields := ParseStepTimingFields(issue)
+	if fields.StartedAt != "" {
+		return nil
+	}
+	fields.StartedAt = at.UTC().Format(time.RFC3339)
+	description := SetStepTimingFields(issue, fields)
+	return b.Update(id, UpdateOptions{Description: &description})
+}
+
+// MarkStepSLAEscalated records that a step was escalated for running past
+// its SLA, so it is escalated only once.
+func (b *Beads) MarkStepSLAEscalated(issue *Issue, at time.Time) error {
+	fields := ParseStepTimingFields(issue)
+	fields.SLAEscalatedAt = at.UTC().Format(time.RFC3339)
+	description := SetStepTimingFields(issue, fields)
+	return b.Update(issue.ID, UpdateOptions{Description: &description})
+}
+
+// StepTiming is how long one molecule step has taken.
+type StepTiming struct {
+	ID           string `json:"id"`
+	Title        string `json:"title"`
+	StartedAt    string `json:"started_at"`          // RFC3339
+	ClosedAt     string `json:"closed_at,omitempty"` // RFC3339
+	DurationSecs int64  `json:"duration_secs"`       // to closed_at, or to now if running
+	SLASecs      int64  `json:"sla_secs,omitempty"`  // 0 if the step has no SLA
+	OverSLA      bool   `json:"over_sla,omitempty"`  // ran (or is running) past its SLA
+	Running      bool   `json:"running,omitempty"`   // started, not closed
+	Estimated    bool   `json:"estimated,omitempty"` // no started_at; start inferred from dependencies
+	Escalated    bool   `json:"escalated,omitempty"` // witness already escalated the SLA breach
+}
+
+// Duration returns the step's duration.
+func (t StepTiming) Duration() time.Duration {
+	return time.Duration(t.DurationSecs) * time.Second
+}
+
+// SLA returns the step's SLA, or 0.
+func (t StepTiming) SLA() time.Duration {
+	return time.Duration(t.SLASecs) * time.Second
+}
+
+// MoleculeTiming is how long a molecule instance has taken, step by step.
+type MoleculeTiming struct {
+	StartedAt    string       `json:"started_at,omitempty"` // RFC3339, earliest step start
+	ClosedAt     string       `json:"closed_at,omitempty"`  // RFC3339, set once every step is closed
+	DurationSecs int64        `json:"duration_secs"`
+	Steps        []StepTiming `json:"steps"`
+}
+
+// TimeMoleculeSteps works out the timing of an instance's steps as of now.
+// A step started when its started_at says; steps picked up without one
+// (e.g. set in progress with bd directly) are taken to have started when
+// they were created or their last dependency closed, whichever is later.
+// Steps still waiting to be picked up have no timing and are left out.
+func TimeMoleculeSteps(steps []*Issue, now time.Time) *MoleculeTiming {
+	byID := make(map[string]*Issue, len(steps))
+	for _, s := range steps {
+		byID[s.ID] = s
+	}
+
+	timing := &MoleculeTiming{}
+	var first, last time.Time
+	allClosed := len(steps) > 0
+	for _, s := range steps {
+		closed := s.Status == "closed"
+		if !closed {
+			allClosed = false
+		}
+		fields := ParseStepTimingFields(s)
+
+		st := StepTiming{ID: s.ID, Title: s.Title, Escalated: fields.SLAEscalatedAt != ""}
+		started, err := time.Parse(time.RFC3339, fields.StartedAt)
+		if err != nil && (closed || s.Status != "open") {
+			started, st.Estimated = StepReadyAt(s, byID), true
+		}
+		if started.IsZero() {
+			continue
+		}
+
+		end := now
+		if closed {
+			if end, err = time.Parse(time.RFC3339, s.ClosedAt); err != nil {
+				continue
+			}
+			st.ClosedAt = s.ClosedAt
+			if end.After(last) {
+				last = end
+			}
+		} else {
+			st.Running = true
+		}
+		st.StartedAt = started.UTC().Format(time.RFC3339)
+		if end.After(started) {
+			st.DurationSecs = int64(end.Sub(started) / time.Second)
+		}
+		if sla, err := time.ParseDuration(fields.SLA); err == nil && sla > 0 {
+			st.SLASecs = int64(sla / time.Second)
+			st.OverSLA = st.Duration() > sla
+		}
+		timing.Steps = append(timing.Steps, st)
+
+		if first.IsZero() || started.Before(first) {
+			first = started
+		}
+	}
+
+	if first.IsZero() {
+		return timing
+	}
+	timing.StartedAt = first.UTC().Format(time.RFC3339)
+	end := now
+	if allClosed && !last.IsZero() {
+		end = last
+		timing.ClosedAt = last.UTC().Format(time.RFC3339)
+	}
+	if end.After(first) {
+		timing.DurationSecs = int64(end.Sub(first) / time.Second)
+	}
+	return timing
+}
+
+// StepReadyAt returns when a step became workable: when it was created or
+// when its last dependency among byID closed, whichever is later. Returns
+// the zero time if the step's creation time doesn't parse.
+func StepReadyAt(step *Issue, byID map[string]*Issue) time.Time {
+	start, err := time.Parse(time.RFC3339, step.CreatedAt)
+	if err != nil {
+		return time.Time{}
+	}
+	for _, dep := range step.DependsOn {
+		if d, ok := byID[dep]; ok {
+			if t, err := time.Parse(time.RFC3339, d.ClosedAt); err == nil && t.After(start) {
+				start = t
+			}
+		}
+	}
+	return start
+}
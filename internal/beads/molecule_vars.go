@@ -0,0 +1,186 @@
+package beads
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// varLineRegex matches "Var: <name> [attributes]" context variable
+// declarations in a molecule's preamble (before the first step).
+var varLineRegex = regexp.MustCompile(`(?i)^Var:\s*(\w+)\b(.*)$`)
+
+// varDefaultRegex matches a default=<value> attribute; the value is a single
+// word or a double-quoted string.
+var varDefaultRegex = regexp.MustCompile(`\bdefault=("(?:[^"\\]|\\.)*"|\S*)`)
+
+// Context variable types.
+const (
+	VarTypeString = "string"
+	VarTypeInt    = "int"
+	VarTypeBool   = "bool"
+)
+
+// MoleculeVar is a context variable declared by a molecule proto:
+//
+//	Var: <name> [string|int|bool] [required|optional] [default=<value>] [-- <description>]
+//
+// The type defaults to string. A variable is required unless it has a
+// default or is marked optional; an optional variable that isn't given
+// expands to the empty string.
+type MoleculeVar struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required"`
+	Default     string `json:"default,omitempty"`
+	HasDefault  bool   `json:"has_default,omitempty"`
+	Description string `json:"description,omitempty"`
+	Line        int    `json:"-"` // 1-based line of the declaration
+}
+
+// ParseMoleculeVars returns the names of the context variables a molecule
+// declares with "Var:" lines before its first step, in order.
+func ParseMoleculeVars(description string) []string {
+	var vars []string
+	for _, line := range strings.Split(description, "\n") {
+		if stepHeaderRegex.MatchString(line) {
+			break
+		}
+		if matches := varLineRegex.FindStringSubmatch(strings.TrimSpace(line)); matches != nil {
+			vars = append(vars, matches[1])
+		}
+	}
+	return vars
+}
+
+// ParseMoleculeVarSchema returns the context variables a molecule declares
+// with "Var:" lines before its first step, in order. It fails on the first
+// declaration that doesn't parse.
+func ParseMoleculeVarSchema(description string) ([]MoleculeVar, error) {
+	var vars []MoleculeVar
+	for i, line := range strings.Split(description, "\n") {
+		if stepHeaderRegex.MatchString(line) {
+			break
+		}
+		matches := varLineRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+		v, err := parseVarDecl(matches[1], matches[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		v.Line = i + 1
+		vars = append(vars, v)
+	}
+	return vars, nil
+}
+
+// parseVarDecl parses the attributes following a variable's name.
+func parseVarDecl(name, attrs string) (MoleculeVar, error) {
+	v := MoleculeVar{Name: name, Type: VarTypeString}
+
+	if before, after, ok := strings.Cut(attrs, "--"); ok {
+		attrs, v.Description = before, strings.TrimSpace(after)
+	}
+
+	if m := varDefaultRegex.FindStringSubmatchIndex(attrs); m != nil {
+		value := attrs[m[2]:m[3]]
+		if strings.HasPrefix(value, `"`) {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return v, fmt.Errorf("variable %q: bad default %s", name, value)
+			}
+			value = unquoted
+		}
+		v.Default, v.HasDefault = value, true
+		attrs = attrs[:m[0]] + attrs[m[1]:]
+	}
+
+	optional := false
+	for _, attr := range strings.Fields(attrs) {
+		switch strings.ToLower(attr) {
+		case VarTypeString, VarTypeInt, VarTypeBool:
+			v.Type = strings.ToLower(attr)
+		case "required":
+			v.Required = true
+		case "optional":
+			optional = true
+		default:
+			return v, fmt.Errorf("variable %q: unknown attribute %q (want a type, required, optional, default=<value>, or -- <description>)", name, attr)
+		}
+	}
+
+	switch {
+	case v.Required && optional:
+		return v, fmt.Errorf("variable %q: both required and optional", name)
+	case v.Required && v.HasDefault:
+		return v, fmt.Errorf("variable %q: a required variable can't have a default", name)
+	case !optional && !v.HasDefault:
+		v.Required = true
+	}
+
+	if v.HasDefault {
+		if err := v.Check(v.Default); err != nil {
+			return v, fmt.Errorf("default: %w", err)
+		}
+	}
+	return v, nil
+}
+
+// Check reports whether value is valid for the variable's type.
+func (v MoleculeVar) Check(value string) error {
+	switch v.Type {
+	case VarTypeInt:
+		if _, err := strconv.Atoi(strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("variable %q: want an int, got %q", v.Name, value)
+		}
+	case VarTypeBool:
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "true", "false", "yes", "no", "on", "off", "1", "0":
+		default:
+			return fmt.Errorf("variable %q: want a bool (true or false), got %q", v.Name, value)
+		}
+	}
+	return nil
+}
+
+// MissingMoleculeVars returns the names of required variables that ctx
+// doesn't set.
+func MissingMoleculeVars(schema []MoleculeVar, ctx map[string]string) []string {
+	var missing []string
+	for _, v := range schema {
+		if _, ok := ctx[v.Name]; !ok && v.Required {
+			missing = append(missing, v.Name)
+		}
+	}
+	return missing
+}
+
+// ResolveMoleculeVars checks ctx against a molecule's variable schema and
+// returns the context to instantiate with: given values, then defaults,
+// then "" for optional variables. It fails if a required variable is
+// missing or a value doesn't match its type. Variables the schema doesn't
+// declare are passed through unchanged.
+func ResolveMoleculeVars(schema []MoleculeVar, ctx map[string]string) (map[string]string, error) {
+	if missing := MissingMoleculeVars(schema, ctx); len(missing) > 0 {
+		return nil, fmt.Errorf("missing required context variable(s): %s", strings.Join(missing, ", "))
+	}
+
+	resolved := make(map[string]string, len(ctx)+len(schema))
+	for name, value := range ctx {
+		resolved[name] = value
+	}
+	for _, v := range schema {
+		value, ok := resolved[v.Name]
+		if !ok {
+			resolved[v.Name] = v.Default
+			continue
+		}
+		if err := v.Check(value); err != nil {
+			return nil, err
+		}
+	}
+	return resolved, nil
+}
@@ -0,0 +1,146 @@
+package beads
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// TownRefRig is the rig name that qualifies town-level beads in a cross-rig
+// reference (hq:hq-abc).
+const TownRefRig = "hq"
+
+// externalRefPrefix starts bd's form of a dependency on another database.
+const externalRefPrefix = "external:"
+
+// ErrAmbiguousRef is returned when an unqualified bead ID's prefix is used by
+// more than one rig.
+var ErrAmbiguousRef = errors.New("bead prefix is used by more than one rig")
+
+// ParseRef splits a bead reference into its rig and ID. A reference is
+// either a plain ID (gt-42), a cross-rig reference (otherrig:gt-42), or
+// bd's external dependency form (external:otherrig:gt-42). The rig is empty
+// for a plain ID.
+func ParseRef(ref string) (rig, id string) {
+	ref = strings.TrimPrefix(ref, externalRefPrefix)
+	if rig, id, ok := strings.Cut(ref, ":"); ok && rig != "" && ExtractPrefix(id) != "" {
+		return rig, id
+	}
+	return "", ref
+}
+
+// IsCrossRigRef reports whether ref names its rig (otherrig:gt-42 or
+// external:otherrig:gt-42).
+func IsCrossRigRef(ref string) bool {
+	rig, _ := ParseRef(ref)
+	return rig != ""
+}
+
+// ExternalRef returns the form bd stores a dependency on a bead of another
+// rig in: external:<rig>:<id>.
+func ExternalRef(rig, id string) string {
+	return externalRefPrefix + rig + ":" + id
+}
+
+// ResolvedRef is a bead reference resolved to the database holding it.
+type ResolvedRef struct {
+	Rig     string // rig name, or TownRefRig for town-level beads
+	ID      string // bead ID within that rig's database
+	WorkDir string // directory to run bd in for this bead
+}
+
+// Router resolves bead references to rig databases using the town's
+// routes.jsonl. Unlike bd's own prefix routing, it can tell apart rigs that
+// share a prefix when references are qualified with the rig name.
+type Router struct {
+	townRoot string
+	routes   []Route
+}
+
+// NewRouter loads the town's routes.
+func NewRouter(townRoot string) (*Router, error) {
+	routes, err := LoadRoutes(GetTownBeadsPath(townRoot))
+	if err != nil {
+		return nil, fmt.Errorf("loading routes: %w", err)
+	}
+	return &Router{townRoot: townRoot, routes: routes}, nil
+}
+
+// routeRig returns the rig a route belongs to: the first element of its
+// path, or TownRefRig for the town's own database.
+func routeRig(r Route) string {
+	if r.Path == "." {
+		return TownRefRig
+	}
+	rig, _, _ := strings.Cut(r.Path, "/")
+	return rig
+}
+
+// Resolve finds the database holding ref. A qualified reference must name a
+// rig with a route for the ID's prefix. An unqualified one is found by its
+// prefix alone, and fails with ErrAmbiguousRef if several rigs use it.
+// External references whose qualifier isn't a rig are resolved by prefix.
+func (r *Router) Resolve(ref string) (*ResolvedRef, error) {
+	rig, id := ParseRef(ref)
+	prefix := ExtractPrefix(id)
+	if prefix == "" {
+		return nil, fmt.Errorf("invalid bead ID %q", id)
+	}
+
+	matches, rigKnown := r.match(rig, prefix)
+	if !rigKnown && strings.HasPrefix(ref, externalRefPrefix) {
+		// Older external references were qualified by a made-up project
+		// name rather than a rig; fall back to the prefix
+		rig = ""
+		matches, rigKnown = r.match(rig, prefix)
+	}
+
+	switch {
+	case rig != "" && !rigKnown:
+		return nil, fmt.Errorf("%s: no rig named %q in routes", ref, rig)
+	case len(matches) == 0 && rig != "":
+		return nil, fmt.Errorf("%s: rig %s has no beads with prefix %s", ref, rig, prefix)
+	case len(matches) == 0:
+		return nil, fmt.Errorf("%s: no route for prefix %s", ref, prefix)
+	case len(matches) > 1 && rig == "":
+		var rigs []string
+		for _, m := range matches {
+			rigs = append(rigs, routeRig(m))
+		}
+		sort.Strings(rigs)
+		return nil, fmt.Errorf("%s: %w (%s); qualify it as <rig>:%s", ref, ErrAmbiguousRef, strings.Join(rigs, ", "), id)
+	}
+
+	return &ResolvedRef{
+		Rig:     routeRig(matches[0]),
+		ID:      id,
+		WorkDir: filepath.Join(r.townRoot, matches[0].Path),
+	}, nil
+}
+
+// match returns the routes of rig (or of any rig, if rig is empty) for
+// prefix, and whether rig has any routes at all.
+func (r *Router) match(rig, prefix string) (matches []Route, rigKnown bool) {
+	for _, route := range r.routes {
+		if rig != "" && routeRig(route) != rig {
+			continue
+		}
+		rigKnown = true
+		if route.Prefix == prefix {
+			matches = append(matches, route)
+		}
+	}
+	return matches, rigKnown
+}
+
+// Beads returns a Beads for the database holding ref, and the bead's ID
+// within it.
+func (r *Router) Beads(ref string) (*Beads, string, error) {
+	resolved, err := r.Resolve(ref)
+	if err != nil {
+		return nil, "", err
+	}
+	return New(resolved.WorkDir), resolved.ID, nil
+}
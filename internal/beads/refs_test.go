@@ -0,0 +1,86 @@
+package beads
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		ref     string
+		wantRig string
+		wantID  string
+	}{
+		{"gt-42", "", "gt-42"},
+		{"beads:bd-42", "beads", "bd-42"},
+		{"external:beads:bd-42", "beads", "bd-42"},
+		{"external:gt-mol:gt-mol-xyz", "gt-mol", "gt-mol-xyz"},
+		{"hq:hq-cv-abc", "hq", "hq-cv-abc"},
+		{"step-a", "", "step-a"},
+		{"note:nohyphen", "", "note:nohyphen"},
+		{":gt-42", "", ":gt-42"},
+	}
+	for _, tt := range tests {
+		rig, id := ParseRef(tt.ref)
+		if rig != tt.wantRig || id != tt.wantID {
+			t.Errorf("ParseRef(%q) = %q, %q; want %q, %q", tt.ref, rig, id, tt.wantRig, tt.wantID)
+		}
+	}
+
+	if got := dependsOnArg("beads:bd-42"); got != "external:beads:bd-42" {
+		t.Errorf("dependsOnArg(beads:bd-42) = %q", got)
+	}
+	if got := dependsOnArg("gt-42"); got != "gt-42" {
+		t.Errorf("dependsOnArg(gt-42) = %q", got)
+	}
+}
+
+func TestRouterResolve(t *testing.T) {
+	townRoot := t.TempDir()
+	err := WriteRoutes(GetTownBeadsPath(townRoot), []Route{
+		{Prefix: "hq-", Path: "."},
+		{Prefix: "gt-", Path: "gastown/mayor/rig"},
+		{Prefix: "gt-", Path: "greenfield/mayor/rig"},
+		{Prefix: "bd-", Path: "beads/mayor/rig"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	router, err := NewRouter(townRoot)
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+
+	tests := []struct {
+		ref     string
+		wantRig string
+		wantDir string
+	}{
+		{"bd-42", "beads", "beads/mayor/rig"},
+		{"hq-cv-abc", "hq", "."},
+		{"hq:hq-cv-abc", "hq", "."},
+		{"greenfield:gt-42", "greenfield", "greenfield/mayor/rig"},
+		{"external:gastown:gt-42", "gastown", "gastown/mayor/rig"},
+		{"external:bd-task:bd-task-1", "beads", "beads/mayor/rig"},
+	}
+	for _, tt := range tests {
+		resolved, err := router.Resolve(tt.ref)
+		if err != nil {
+			t.Errorf("Resolve(%q): %v", tt.ref, err)
+			continue
+		}
+		if resolved.Rig != tt.wantRig || resolved.WorkDir != filepath.Join(townRoot, tt.wantDir) {
+			t.Errorf("Resolve(%q) = %+v; want rig %s in %s", tt.ref, resolved, tt.wantRig, tt.wantDir)
+		}
+	}
+
+	if _, err := router.Resolve("gt-42"); !errors.Is(err, ErrAmbiguousRef) {
+		t.Errorf("Resolve(gt-42) error = %v; want ErrAmbiguousRef", err)
+	}
+	for _, ref := range []string{"nowhere:gt-42", "beads:gt-42", "zz-42", "noprefix"} {
+		if _, err := router.Resolve(ref); err == nil {
+			t.Errorf("Resolve(%q) succeeded; want error", ref)
+		}
+	}
+}
@@ -63,8 +63,9 @@ Examples:
   gt account add work
   gt account add work --email steve@company.com
   gt account add work --email steve@company.com --desc "Work account"`,
-	Args: cobra.ExactArgs(1),
-	RunE: runAccountAdd,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runAccountAdd,
 }
 
 var accountDefaultCmd = &cobra.Command{
@@ -78,8 +79,9 @@ is specified during spawn or attach.
 Examples:
   gt account default work
   gt account default personal`,
-	Args: cobra.ExactArgs(1),
-	RunE: runAccountDefault,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runAccountDefault,
 }
 
 // AccountListItem represents an account in list output.
@@ -279,8 +281,9 @@ After switching, you must restart Claude Code for the change to take effect.
 Examples:
   gt account switch work       # Switch to work account
   gt account switch personal   # Switch to personal account`,
-	Args: cobra.ExactArgs(1),
-	RunE: runAccountSwitch,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runAccountSwitch,
 }
 
 func runAccountStatus(cmd *cobra.Command, args []string) error {
@@ -65,8 +65,9 @@ EXAMPLES:
 
   # Get state as JSON
   gt agent state gt-gastown-witness --json`,
-	Args: cobra.ExactArgs(1),
-	RunE: runAgentState,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runAgentState,
 }
 
 func init() {
@@ -11,6 +11,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/daemon"
 	"github.com/steveyegge/gastown/internal/lock"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -107,7 +108,8 @@ This command:
 For collisions with live processes, you must manually:
   - Kill the duplicate session, OR
   - Decide which agent should own the identity`,
-	RunE: runAgentsFix,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runAgentsFix,
 }
 
 var (
@@ -192,7 +194,7 @@ func categorizeSession(name string) *AgentSession {
 // getAgentSessions returns all categorized Gas Town sessions.
 func getAgentSessions(includePolecats bool) ([]*AgentSession, error) {
 	t := tmux.NewTmux()
-	sessions, err := t.ListSessions()
+	sessions, err := daemon.ListSessions("", t)
 	if err != nil {
 		return nil, err
 	}
@@ -335,7 +337,7 @@ func runAgents(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("tmux not found: %w", err)
 	}
 
-	execCmd := exec.Command(tmuxPath, menuArgs...)
+	execCmd := exec.Command(tmuxPath, tmux.Args(menuArgs...)...)
 	execCmd.Stdin = os.Stdin
 	execCmd.Stdout = os.Stdout
 	execCmd.Stderr = os.Stderr
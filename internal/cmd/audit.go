@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/audit"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/style"
@@ -21,10 +22,11 @@ import (
 
 // Audit command flags
 var (
-	auditActor string
-	auditSince string
-	auditLimit int
-	auditJSON  bool
+	auditActor    string
+	auditSince    string
+	auditLimit    int
+	auditJSON     bool
+	auditCommands bool
 )
 
 var auditCmd = &cobra.Command{
@@ -39,6 +41,12 @@ Shows a unified timeline of work performed by an actor including:
   - Beads closed by the actor (via assignee)
   - Town log events (spawn, done, handoff, etc.)
   - Activity feed events
+  - State-mutating gt invocations (logs/audit.jsonl)
+
+Every mutating gt command (add, remove, kill, send, sling, ...) is recorded
+with its arguments, actor, working directory, session, and result. Use
+--commands to review only those invocations in the timeline, or
+'gt audit list' and 'gt audit search' to filter them.
 
 Examples:
   gt audit --actor=greenplace/crew/joe       # Show all work by joe
@@ -46,6 +54,8 @@ Examples:
   gt audit --actor=mayor                  # Show mayor's activity
   gt audit --since=24h                    # Show all activity in last 24h
   gt audit --actor=joe --since=1h         # Combined filters
+  gt audit --commands --since=24h         # Who ran what in the last day
+  gt audit search dave                    # Invocations mentioning dave
   gt audit --json                         # Output as JSON`,
 	RunE: runAudit,
 }
@@ -55,6 +65,7 @@ func init() {
 	auditCmd.Flags().StringVar(&auditSince, "since", "", "Show events since duration (e.g., 1h, 24h, 7d)")
 	auditCmd.Flags().IntVarP(&auditLimit, "limit", "n", 50, "Maximum number of entries to show")
 	auditCmd.Flags().BoolVar(&auditJSON, "json", false, "Output as JSON")
+	auditCmd.Flags().BoolVar(&auditCommands, "commands", false, "Show only recorded gt command invocations")
 
 	rootCmd.AddCommand(auditCmd)
 }
@@ -89,34 +100,43 @@ func runAudit(cmd *cobra.Command, args []string) error {
 	// Collect entries from all sources
 	var allEntries []AuditEntry
 
-	// 1. Git commits
-	gitEntries, err := collectGitCommits(townRoot, auditActor, sinceTime)
+	// 0. Recorded gt command invocations
+	commandEntries, err := collectCommandLog(townRoot, auditActor, sinceTime)
 	if err != nil {
-		// Non-fatal: log and continue
-		fmt.Fprintf(os.Stderr, "Warning: could not query git commits: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Warning: could not read command audit log: %v\n", err)
 	}
-	allEntries = append(allEntries, gitEntries...)
+	allEntries = append(allEntries, commandEntries...)
 
-	// 2. Beads (created_by, assignee)
-	beadsEntries, err := collectBeadsActivity(townRoot, auditActor, sinceTime)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not query beads: %v\n", err)
-	}
-	allEntries = append(allEntries, beadsEntries...)
+	if !auditCommands {
+		// 1. Git commits
+		gitEntries, err := collectGitCommits(townRoot, auditActor, sinceTime)
+		if err != nil {
+			// Non-fatal: log and continue
+			fmt.Fprintf(os.Stderr, "Warning: could not query git commits: %v\n", err)
+		}
+		allEntries = append(allEntries, gitEntries...)
 
-	// 3. Town log events
-	townlogEntries, err := collectTownlogEvents(townRoot, auditActor, sinceTime)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not query town log: %v\n", err)
-	}
-	allEntries = append(allEntries, townlogEntries...)
+		// 2. Beads (created_by, assignee)
+		beadsEntries, err := collectBeadsActivity(townRoot, auditActor, sinceTime)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not query beads: %v\n", err)
+		}
+		allEntries = append(allEntries, beadsEntries...)
 
-	// 4. Activity feed events
-	feedEntries, err := collectFeedEvents(townRoot, auditActor, sinceTime)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not query events feed: %v\n", err)
+		// 3. Town log events
+		townlogEntries, err := collectTownlogEvents(townRoot, auditActor, sinceTime)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not query town log: %v\n", err)
+		}
+		allEntries = append(allEntries, townlogEntries...)
+
+		// 4. Activity feed events
+		feedEntries, err := collectFeedEvents(townRoot, auditActor, sinceTime)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not query events feed: %v\n", err)
+		}
+		allEntries = append(allEntries, feedEntries...)
 	}
-	allEntries = append(allEntries, feedEntries...)
 
 	// Sort by timestamp (newest first)
 	sort.Slice(allEntries, func(i, j int) bool {
@@ -262,10 +282,13 @@ func collectBeadsActivity(townRoot, actor string, since time.Time) ([]AuditEntry
 	gastownBeadsPath := filepath.Join(townRoot, "gastown", "mayor", "rig")
 	b := beads.New(gastownBeadsPath)
 
-	// List all issues to filter by created_by and assignee
+	// List all issues to filter by created_by and assignee. Anything created
+	// or closed since the cutoff has been updated since it, so bd can narrow
+	// by update time.
 	issues, err := b.List(beads.ListOptions{
-		Status:   "all",
-		Priority: -1,
+		Status:       "all",
+		Priority:     -1,
+		UpdatedAfter: since,
 	})
 	if err != nil {
 		return nil, err
@@ -445,6 +468,38 @@ func collectFeedEvents(townRoot, actor string, since time.Time) ([]AuditEntry, e
 	return entries, nil
 }
 
+// collectCommandLog reads recorded gt invocations from the command audit log.
+func collectCommandLog(townRoot, actor string, since time.Time) ([]AuditEntry, error) {
+	records, err := audit.Read(townRoot, since)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []AuditEntry
+	for _, rec := range records {
+		if actor != "" && !matchesActor(rec.Actor, actor) {
+			continue
+		}
+
+		entryType := "invocation"
+		details := rec.Cwd
+		if rec.Result == audit.ResultError {
+			entryType = "invocation_failed"
+			details = rec.Error
+		}
+		entries = append(entries, AuditEntry{
+			Timestamp: rec.Timestamp,
+			Source:    "command",
+			Type:      entryType,
+			Actor:     rec.Actor,
+			Summary:   strings.TrimSpace("gt " + strings.Join(rec.Args, " ")),
+			Details:   details,
+			ID:        rec.Session,
+		})
+	}
+	return entries, nil
+}
+
 // formatFeedSummary creates a readable summary from a feed event.
 func formatFeedSummary(e events.Event) string {
 	switch e.Type {
@@ -536,6 +591,8 @@ func formatSource(source string) string {
 		return style.Dim.Render("[log]")
 	case "events":
 		return style.Warning.Render("[events]")
+	case "command":
+		return style.Bold.Render("[gt]")
 	default:
 		return fmt.Sprintf("[%s]", source)
 	}
@@ -563,6 +620,8 @@ func formatType(t string) string {
 		return style.Success.Render("merged")
 	case "merge_failed":
 		return style.Error.Render("merge_failed")
+	case "invocation_failed":
+		return style.Error.Render("failed")
 	default:
 		return t
 	}
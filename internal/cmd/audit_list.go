@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/audit"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Audit list/search flags
+var (
+	auditLogQuery audit.Query
+	auditLogSince string
+	auditLogLimit int
+	auditLogJSON  bool
+)
+
+var auditListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recorded gt command invocations",
+	Long: `List the state-mutating gt commands recorded in logs/audit.jsonl,
+newest first: who ran them, from which directory and tmux session, with
+which arguments, and whether they succeeded, failed, or were denied by the
+permission policy.
+
+Examples:
+  gt audit list                             # Last 50 invocations
+  gt audit list --since=24h --result=error  # Failures in the last day
+  gt audit list --command="crew remove"     # Every crew removal
+  gt audit list --actor=witness --json      # Witness invocations as JSON`,
+	Args: cobra.NoArgs,
+	RunE: runAuditList,
+}
+
+var auditSearchCmd = &cobra.Command{
+	Use:   "search <text>",
+	Short: "Search recorded gt command invocations",
+	Long: `Search the recorded gt command invocations for text in the command
+line, actor, working directory, session, or error. Matching ignores case.
+Takes the same filters as 'gt audit list'.
+
+Examples:
+  gt audit search dave                      # "Who killed dave's session?"
+  gt audit search --result=denied nuke      # Refused polecat nukes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAuditSearch,
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{auditListCmd, auditSearchCmd} {
+		cmd.Flags().StringVar(&auditLogQuery.Actor, "actor", "", "Filter by actor (substring of the agent address)")
+		cmd.Flags().StringVar(&auditLogQuery.Command, "command", "", `Filter by command prefix (e.g. "crew remove")`)
+		cmd.Flags().StringVar(&auditLogQuery.Result, "result", "", "Filter by result: ok, error, or denied")
+		cmd.Flags().StringVar(&auditLogQuery.Session, "session", "", "Filter by tmux session")
+		cmd.Flags().StringVar(&auditLogSince, "since", "", "Show invocations since duration (e.g., 1h, 24h, 7d)")
+		cmd.Flags().IntVarP(&auditLogLimit, "limit", "n", 50, "Maximum number of invocations to show (0 for all)")
+		cmd.Flags().BoolVar(&auditLogJSON, "json", false, "Output as JSON")
+	}
+
+	auditCmd.AddCommand(auditListCmd)
+	auditCmd.AddCommand(auditSearchCmd)
+}
+
+func runAuditList(cmd *cobra.Command, args []string) error {
+	return showAuditLog(auditLogQuery)
+}
+
+func runAuditSearch(cmd *cobra.Command, args []string) error {
+	q := auditLogQuery
+	q.Text = args[0]
+	return showAuditLog(q)
+}
+
+// showAuditLog prints the audit log entries matching q, newest first.
+func showAuditLog(q audit.Query) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	switch q.Result {
+	case "", audit.ResultOK, audit.ResultError, audit.ResultDenied:
+	default:
+		return fmt.Errorf("invalid --result %q (want ok, error, or denied)", q.Result)
+	}
+
+	var since time.Time
+	if auditLogSince != "" {
+		duration, err := parseDuration(auditLogSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since duration: %w", err)
+		}
+		since = time.Now().Add(-duration)
+	}
+
+	records, err := audit.Read(townRoot, since)
+	if err != nil {
+		return fmt.Errorf("reading audit log: %w", err)
+	}
+	matched := selectAuditEntries(records, q, auditLogLimit)
+
+	if auditLogJSON {
+		if matched == nil {
+			matched = []audit.Entry{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(matched)
+	}
+
+	if len(matched) == 0 {
+		fmt.Printf("%s No recorded invocations match\n", style.Dim.Render("○"))
+		return nil
+	}
+	printAuditLog(matched)
+	return nil
+}
+
+// selectAuditEntries returns the entries matching q, newest first, at most
+// limit of them (0 for no limit). The log is in append order.
+func selectAuditEntries(records []audit.Entry, q audit.Query, limit int) []audit.Entry {
+	var matched []audit.Entry
+	for i := len(records) - 1; i >= 0; i-- {
+		if !q.Matches(records[i]) {
+			continue
+		}
+		matched = append(matched, records[i])
+		if limit > 0 && len(matched) == limit {
+			break
+		}
+	}
+	return matched
+}
+
+// printAuditLog prints audit entries grouped by date.
+func printAuditLog(entries []audit.Entry) {
+	var currentDate string
+	for _, e := range entries {
+		ts := e.Timestamp.Local()
+		if date := ts.Format("2006-01-02"); date != currentDate {
+			if currentDate != "" {
+				fmt.Println()
+			}
+			fmt.Printf("%s\n", style.Bold.Render("─── "+date+" ───────────────────────────────────────────"))
+			currentDate = date
+		}
+
+		var mark string
+		switch e.Result {
+		case audit.ResultOK:
+			mark = style.SuccessPrefix
+		case audit.ResultDenied:
+			mark = style.Warning.Render("⊘")
+		default:
+			mark = style.ErrorPrefix
+		}
+
+		fmt.Printf("%s %s %s\n", style.Dim.Render(ts.Format("15:04:05")), mark,
+			strings.TrimSpace("gt "+strings.Join(e.Args, " ")))
+
+		where := "by " + e.Actor
+		if e.Session != "" {
+			where += " in " + e.Session
+		}
+		if e.Cwd != "" {
+			where += " at " + e.Cwd
+		}
+		fmt.Printf("         %s\n", style.Dim.Render(where))
+		if e.Error != "" {
+			fmt.Printf("         %s\n", style.Error.Render(firstLine(e.Error)))
+		}
+	}
+}
+
+// firstLine returns s up to its first line break.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/audit"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// annotationAudit is the cobra annotation that opts a command into the audit
+// log. Commands that change town state set it to auditMutating where they
+// are defined; read-only commands (list, status, show, inbox, peek, ...)
+// leave it unset.
+const (
+	annotationAudit = "audit"
+	auditMutating   = "mutating"
+)
+
+// isMutatingCommand reports whether an invocation of cmd should be audited.
+func isMutatingCommand(cmd *cobra.Command) bool {
+	if cmd == nil || cmd == rootCmd {
+		return false
+	}
+	return cmd.Annotations[annotationAudit] == auditMutating
+}
+
+// recordInvocation appends a state-mutating invocation to the town audit log.
+// Errors are swallowed: auditing must never change a command's outcome.
+func recordInvocation(cmd *cobra.Command, args []string, started time.Time, runErr error) {
+	if !isMutatingCommand(cmd) {
+		return
+	}
+
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return
+	}
+
+	// The identity the permission guard checked, so denials name who was denied
+	actor, _ := currentPermissionIdentity()
+	cwd, _ := os.Getwd()
+	entry := audit.Entry{
+		Timestamp:  started,
+		DurationMs: time.Since(started).Milliseconds(),
+		Command:    buildCommandPath(cmd),
+		Args:       args,
+		Actor:      actor,
+		Cwd:        cwd,
+		Session:    detectCurrentTmuxSession(),
+		Result:     audit.ResultOK,
+	}
+	if runErr != nil {
+		entry.Result = audit.ResultError
+		if errors.Is(runErr, ErrPermissionDenied) {
+			entry.Result = audit.ResultDenied
+		}
+		entry.Error = runErr.Error()
+	}
+
+	_ = audit.Append(townRoot, entry)
+}
@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/audit"
 )
 
 func TestParseDuration(t *testing.T) {
@@ -148,3 +153,104 @@ func TestFormatType(t *testing.T) {
 		}
 	}
 }
+
+func TestIsMutatingCommand(t *testing.T) {
+	tests := []struct {
+		cmd  *cobra.Command
+		want bool
+	}{
+		{&cobra.Command{Use: "remove", Annotations: map[string]string{annotationAudit: auditMutating}}, true},
+		{&cobra.Command{Use: "remove"}, false},
+		{crewAddCmd, true},
+		{crewListCmd, false},
+		{rootCmd, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := isMutatingCommand(tt.cmd); got != tt.want {
+			name := "<nil>"
+			if tt.cmd != nil {
+				name = tt.cmd.Name()
+			}
+			t.Errorf("isMutatingCommand(%s) = %v, want %v", name, got, tt.want)
+		}
+	}
+}
+
+// findCommand returns the command at path ("gt crew add"), or nil.
+func findCommand(path string) *cobra.Command {
+	cmd, rest, err := rootCmd.Find(strings.Fields(path)[1:])
+	if err != nil || len(rest) != 0 || buildCommandPath(cmd) != path {
+		return nil
+	}
+	return cmd
+}
+
+func TestGuardedCommandsAreAudited(t *testing.T) {
+	// A guarded command changes state, and its denials belong in the log
+	for path := range guardedCommands {
+		cmd := findCommand(path)
+		if cmd == nil {
+			t.Errorf("guarded command %q does not exist", path)
+			continue
+		}
+		if !isMutatingCommand(cmd) {
+			t.Errorf("guarded command %q is not audited", path)
+		}
+	}
+}
+
+func TestMutatingCommandsAreAudited(t *testing.T) {
+	for _, path := range []string{
+		"gt mol instantiate", "gt mol cancel", "gt mail compact", "gt mail mark-read",
+		"gt digest rollup", "gt snapshot", "gt crew pair", "gt patrol digest",
+		"gt schedule run", "gt serve token issue", "gt serve token revoke",
+	} {
+		cmd := findCommand(path)
+		if cmd == nil {
+			t.Errorf("command %q does not exist", path)
+			continue
+		}
+		if !isMutatingCommand(cmd) {
+			t.Errorf("command %q is not audited", path)
+		}
+	}
+}
+
+func TestSelectAuditEntries(t *testing.T) {
+	records := []audit.Entry{
+		{Command: "gt crew add", Args: []string{"crew", "add", "dave"}, Result: audit.ResultOK},
+		{Command: "gt session stop", Args: []string{"session", "stop", "gastown/dave"}, Result: audit.ResultOK},
+		{Command: "gt crew remove", Args: []string{"crew", "remove", "dave"}, Result: audit.ResultDenied},
+		{Command: "gt crew add", Args: []string{"crew", "add", "emma"}, Result: audit.ResultOK},
+	}
+
+	got := selectAuditEntries(records, audit.Query{Text: "dave"}, 2)
+	if len(got) != 2 || got[0].Command != "gt crew remove" || got[1].Command != "gt session stop" {
+		t.Errorf("selectAuditEntries(dave, 2) = %+v, want newest two dave entries", got)
+	}
+	if got := selectAuditEntries(records, audit.Query{Command: "crew"}, 0); len(got) != 3 {
+		t.Errorf("selectAuditEntries(crew, 0) returned %d entries, want 3", len(got))
+	}
+}
+
+func TestRecordInvocationActorMatchesPermissionIdentity(t *testing.T) {
+	townRoot := setupTestTownForCrewList(t, map[string][]string{"gastown": {"joe"}})
+	// A human in a crew workspace, outside any agent session
+	t.Setenv(EnvGTRole, "")
+	t.Setenv("TMUX", "")
+	t.Chdir(filepath.Join(townRoot, "gastown", "crew", "joe"))
+
+	recordInvocation(crewRemoveCmd, []string{"emma"}, time.Now(), ErrPermissionDenied)
+
+	entries, err := audit.Read(townRoot, time.Time{})
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("audit.Read = %+v, %v; want one entry", entries, err)
+	}
+	if want, _ := currentPermissionIdentity(); entries[0].Actor != want {
+		t.Errorf("recorded actor %q, permission identity %q", entries[0].Actor, want)
+	}
+	if entries[0].Result != audit.ResultDenied {
+		t.Errorf("result = %q, want denied", entries[0].Result)
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 var beadCmd = &cobra.Command{
 	Use:     "bead",
+	Aliases: []string{"beads"},
 	GroupID: GroupWork,
 	Short:   "Bead management utilities",
 	Long:    `Utilities for managing beads across repositories.`,
@@ -33,12 +34,48 @@ Examples:
   gt bead move gt-abc123 bd-     # Move gt-abc123 to beads repo as bd-*
   gt bead move hq-xyz bd-        # Move hq-xyz to beads repo
   gt bead move bd-123 gt-        # Move bd-123 to gastown repo`,
-	Args: cobra.ExactArgs(2),
-	RunE: runBeadMove,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runBeadMove,
 }
 
 var beadMoveDryRun bool
 
+var beadGraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Export the web of beads as Mermaid, Graphviz or JSON",
+	Long: `Export beads and the links between them: parent/child links and
+dependencies (blocks, tracks, ...).
+
+With --root, the graph holds the beads within --depth hops of the root,
+following parents, children and dependencies in both directions. Without
+it, the graph holds every bead of the local database with the given
+statuses (open by default) and the links among them.
+
+--status keeps only beads with one of the given statuses (comma-separated,
+or "all"); the walk from --root still goes through the others, and the
+root is always kept.
+
+Parent/child links are drawn solid, dependencies dashed and labeled with
+their type; beads are colored by status. The JSON format lists nodes and
+edges; for dependency edges, "from" must finish before "to".
+
+Examples:
+  gt beads graph --root gt-abc                       # Mermaid, 3 hops
+  gt beads graph --root gt-abc --depth 0 --format dot | dot -Tsvg > web.svg
+  gt beads graph --status open,in_progress --format json
+  gt bead graph --root hq-cv-xyz --status all`,
+	Args: cobra.NoArgs,
+	RunE: runBeadGraph,
+}
+
+var (
+	beadGraphRoot   string
+	beadGraphFormat string
+	beadGraphDepth  int
+	beadGraphStatus string
+)
+
 var beadShowCmd = &cobra.Command{
 	Use:   "show <bead-id> [flags]",
 	Short: "Show details of a bead",
@@ -77,7 +114,12 @@ Examples:
 
 func init() {
 	beadMoveCmd.Flags().BoolVarP(&beadMoveDryRun, "dry-run", "n", false, "Show what would be done")
+	beadGraphCmd.Flags().StringVar(&beadGraphRoot, "root", "", "Walk the graph from this bead")
+	beadGraphCmd.Flags().StringVar(&beadGraphFormat, "format", graphFormatMermaid, "Output format: mermaid, dot or json")
+	beadGraphCmd.Flags().IntVar(&beadGraphDepth, "depth", 3, "Hops to follow from --root (0 for no limit)")
+	beadGraphCmd.Flags().StringVar(&beadGraphStatus, "status", "", "Statuses to keep, comma-separated or \"all\" (default: all with --root, open without)")
 	beadCmd.AddCommand(beadMoveCmd)
+	beadCmd.AddCommand(beadGraphCmd)
 	beadCmd.AddCommand(beadShowCmd)
 	beadCmd.AddCommand(beadReadCmd)
 	rootCmd.AddCommand(beadCmd)
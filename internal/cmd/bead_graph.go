@@ -0,0 +1,362 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// parentChildDep is the dependency type bd uses for parent/child links.
+const parentChildDep = "parent-child"
+
+// Graph output formats for 'gt bead graph', besides graphFormatMermaid and
+// graphFormatDot.
+const graphFormatJSON = "json"
+
+// beadStatusColors are the fill and stroke colors of bead statuses; other
+// statuses are drawn grey.
+var beadStatusColors = map[string][2]string{
+	"closed":      {"#d4edda", "#28a745"},
+	"in_progress": {"#fff3cd", "#e0a800"},
+	"hooked":      {"#fff3cd", "#e0a800"},
+	"open":        {"#d1ecf1", "#17a2b8"},
+	"blocked":     {"#f8d7da", "#dc3545"},
+}
+
+var beadOtherColors = [2]string{"#f5f5f5", "#999999"}
+
+// BeadGraph is a web of beads: parent/child links and dependencies.
+type BeadGraph struct {
+	Root  string          `json:"root,omitempty"`
+	Nodes []BeadGraphNode `json:"nodes"`
+	Edges []BeadGraphEdge `json:"edges"`
+}
+
+// BeadGraphNode is a bead in a graph.
+type BeadGraphNode struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Type   string `json:"type,omitempty"`
+	Depth  int    `json:"depth"` // hops from the root; 0 without a root
+}
+
+// BeadGraphEdge links two beads. For parent-child edges From is the parent;
+// for dependencies From must finish before To, and Kind is the dependency
+// type (blocks, tracks, ...).
+type BeadGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// beadGraphSource reads beads for a graph walk.
+type beadGraphSource interface {
+	ShowMany(ids []string) ([]*beads.Issue, error)
+	List(opts beads.ListOptions) ([]*beads.Issue, error)
+}
+
+func runBeadGraph(cmd *cobra.Command, args []string) error {
+	var render func(*BeadGraph) (string, error)
+	switch beadGraphFormat {
+	case graphFormatMermaid:
+		render = renderBeadGraphMermaid
+	case graphFormatDot:
+		render = renderBeadGraphDot
+	case graphFormatJSON:
+		render = renderBeadGraphJSON
+	default:
+		return fmt.Errorf("unknown format %q (want %s, %s or %s)", beadGraphFormat, graphFormatMermaid, graphFormatDot, graphFormatJSON)
+	}
+	if beadGraphDepth < 0 {
+		return fmt.Errorf("--depth must not be negative")
+	}
+
+	workDir, err := findLocalBeadsDir()
+	if err != nil {
+		return fmt.Errorf("not in a beads workspace: %w", err)
+	}
+	b := beads.New(workDir)
+
+	status := beadGraphStatus
+	if status == "" && beadGraphRoot == "" {
+		status = "open" // the whole database is rarely wanted
+	}
+	statuses := parseStatusFilter(status)
+	var graph *BeadGraph
+	if beadGraphRoot != "" {
+		graph, err = walkBeadGraph(b, beadGraphRoot, beadGraphDepth, statuses)
+	} else {
+		graph, err = listBeadGraph(b, statuses)
+	}
+	if err != nil {
+		return err
+	}
+
+	out, err := render(graph)
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+	return nil
+}
+
+// parseStatusFilter turns a comma-separated status list into a set; nil
+// (no filter) for "" or "all".
+func parseStatusFilter(value string) map[string]bool {
+	if value == "" || value == "all" {
+		return nil
+	}
+	statuses := make(map[string]bool)
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			statuses[s] = true
+		}
+	}
+	return statuses
+}
+
+// beadLinks returns the parent/child links and dependencies of a bead, as
+// seen in its bd show output.
+func beadLinks(issue *beads.Issue) []BeadGraphEdge {
+	var edges []BeadGraphEdge
+	if issue.Parent != "" {
+		edges = append(edges, BeadGraphEdge{From: issue.Parent, To: issue.ID, Kind: parentChildDep})
+	}
+	for _, dep := range issue.Dependencies {
+		if dep.DependencyType == parentChildDep {
+			edges = append(edges, BeadGraphEdge{From: dep.ID, To: issue.ID, Kind: parentChildDep})
+			continue
+		}
+		kind := dep.DependencyType
+		if kind == "" {
+			kind = "blocks"
+		}
+		edges = append(edges, BeadGraphEdge{From: dep.ID, To: issue.ID, Kind: kind})
+	}
+	for _, id := range issue.DependsOn {
+		edges = append(edges, BeadGraphEdge{From: id, To: issue.ID, Kind: "blocks"})
+	}
+	for _, dep := range issue.Dependents {
+		if dep.DependencyType == parentChildDep {
+			edges = append(edges, BeadGraphEdge{From: issue.ID, To: dep.ID, Kind: parentChildDep})
+		}
+	}
+	return edges
+}
+
+// walkBeadGraph collects the beads within depth hops of root (0 for no
+// limit), following parents, children and dependencies. Beads whose status
+// isn't in statuses are left out of the graph, but the walk goes through
+// them; the root is always kept.
+func walkBeadGraph(src beadGraphSource, root string, depth int, statuses map[string]bool) (*BeadGraph, error) {
+	depthOf := map[string]int{root: 0}
+	var issues []*beads.Issue
+	var edges []BeadGraphEdge
+
+	frontier := []string{root}
+	for d := 0; len(frontier) > 0; d++ {
+		found, err := src.ShowMany(frontier)
+		if err != nil {
+			return nil, fmt.Errorf("reading beads: %w", err)
+		}
+		if d == 0 && len(found) == 0 {
+			return nil, fmt.Errorf("bead %s not found", root)
+		}
+
+		var next []string
+		for _, issue := range found {
+			issues = append(issues, issue)
+			for _, edge := range beadLinks(issue) {
+				edges = append(edges, edge)
+				if depth > 0 && d >= depth {
+					continue
+				}
+				for _, id := range []string{edge.From, edge.To} {
+					if _, ok := depthOf[id]; !ok {
+						depthOf[id] = d + 1
+						next = append(next, id)
+					}
+				}
+			}
+		}
+		frontier = next
+	}
+
+	keep := func(issue *beads.Issue) bool {
+		return issue.ID == root || statuses == nil || statuses[issue.Status]
+	}
+	graph := newBeadGraph(issues, edges, keep, depthOf)
+	graph.Root = root
+	return graph, nil
+}
+
+// listBeadGraph graphs every bead with one of the given statuses (nil for
+// any), with the links between them.
+func listBeadGraph(src beadGraphSource, statuses map[string]bool) (*BeadGraph, error) {
+	status := "all"
+	if len(statuses) == 1 {
+		for s := range statuses {
+			status = s
+		}
+	}
+	listed, err := src.List(beads.ListOptions{Status: status, Priority: -1, Limit: -1})
+	if err != nil {
+		return nil, fmt.Errorf("listing beads: %w", err)
+	}
+	ids := make([]string, 0, len(listed))
+	for _, issue := range listed {
+		if statuses == nil || statuses[issue.Status] {
+			ids = append(ids, issue.ID)
+		}
+	}
+
+	// Links are only in bd show output
+	issues, err := src.ShowMany(ids)
+	if err != nil {
+		return nil, fmt.Errorf("reading beads: %w", err)
+	}
+	var edges []BeadGraphEdge
+	for _, issue := range issues {
+		edges = append(edges, beadLinks(issue)...)
+	}
+	keep := func(issue *beads.Issue) bool {
+		return statuses == nil || statuses[issue.Status]
+	}
+	return newBeadGraph(issues, edges, keep, nil), nil
+}
+
+// newBeadGraph builds a graph from the kept issues and the edges between
+// them, without duplicates, sorted by depth and ID.
+func newBeadGraph(issues []*beads.Issue, edges []BeadGraphEdge, keep func(*beads.Issue) bool, depthOf map[string]int) *BeadGraph {
+	graph := &BeadGraph{Nodes: []BeadGraphNode{}, Edges: []BeadGraphEdge{}}
+	kept := make(map[string]bool)
+	for _, issue := range issues {
+		if kept[issue.ID] || !keep(issue) {
+			continue
+		}
+		kept[issue.ID] = true
+		graph.Nodes = append(graph.Nodes, BeadGraphNode{
+			ID:     issue.ID,
+			Title:  issue.Title,
+			Status: issue.Status,
+			Type:   issue.Type,
+			Depth:  depthOf[issue.ID],
+		})
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		a, b := graph.Nodes[i], graph.Nodes[j]
+		if a.Depth != b.Depth {
+			return a.Depth < b.Depth
+		}
+		return a.ID < b.ID
+	})
+
+	seen := make(map[BeadGraphEdge]bool)
+	for _, edge := range edges {
+		if seen[edge] || !kept[edge.From] || !kept[edge.To] {
+			continue
+		}
+		seen[edge] = true
+		graph.Edges = append(graph.Edges, edge)
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		a, b := graph.Edges[i], graph.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Kind < b.Kind
+	})
+	return graph
+}
+
+// beadColors returns the fill and stroke colors of a status.
+func beadColors(status string) [2]string {
+	if c, ok := beadStatusColors[status]; ok {
+		return c
+	}
+	return beadOtherColors
+}
+
+// renderBeadGraphMermaid renders a bead graph as a Mermaid flowchart:
+// parent/child links are solid, dependencies dashed and labeled with their
+// type, and beads are colored by status.
+func renderBeadGraphMermaid(graph *BeadGraph) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+
+	mid := make(map[string]string, len(graph.Nodes))
+	for i, node := range graph.Nodes {
+		mid[node.ID] = fmt.Sprintf("b%d", i)
+	}
+	for _, node := range graph.Nodes {
+		shape := "[\"%s<br/>%s\"]"
+		if node.ID == graph.Root {
+			shape = "[[\"%s<br/>%s\"]]"
+		}
+		fmt.Fprintf(&sb, "  %s"+shape+"\n", mid[node.ID], node.ID, mermaidText(node.Title))
+	}
+	for _, edge := range graph.Edges {
+		if edge.Kind == parentChildDep {
+			fmt.Fprintf(&sb, "  %s --> %s\n", mid[edge.From], mid[edge.To])
+		} else {
+			fmt.Fprintf(&sb, "  %s -.->|%s| %s\n", mid[edge.From], mermaidText(edge.Kind), mid[edge.To])
+		}
+	}
+	for _, node := range graph.Nodes {
+		c := beadColors(node.Status)
+		fmt.Fprintf(&sb, "  style %s fill:%s,stroke:%s\n", mid[node.ID], c[0], c[1])
+	}
+	return sb.String(), nil
+}
+
+// renderBeadGraphDot renders a bead graph as a Graphviz digraph, styled
+// like the Mermaid output.
+func renderBeadGraphDot(graph *BeadGraph) (string, error) {
+	var sb strings.Builder
+	name := "beads"
+	if graph.Root != "" {
+		name = graph.Root
+	}
+	fmt.Fprintf(&sb, "digraph %s {\n", dotQuote(name))
+	sb.WriteString("  rankdir=TB;\n")
+	sb.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	sb.WriteString("\n")
+
+	for _, node := range graph.Nodes {
+		c := beadColors(node.Status)
+		attrs := fmt.Sprintf("label=%s, fillcolor=%s, color=%s",
+			dotQuote(node.ID+"\n"+node.Title+"\n("+strings.ReplaceAll(node.Status, "_", " ")+")"),
+			dotQuote(c[0]), dotQuote(c[1]))
+		if node.ID == graph.Root {
+			attrs += ", penwidth=3"
+		}
+		fmt.Fprintf(&sb, "  %s [%s];\n", dotQuote(node.ID), attrs)
+	}
+
+	sb.WriteString("\n")
+	for _, edge := range graph.Edges {
+		attrs := ""
+		if edge.Kind != parentChildDep {
+			attrs = fmt.Sprintf(" [style=dashed, label=%s]", dotQuote(edge.Kind))
+		}
+		fmt.Fprintf(&sb, "  %s -> %s%s;\n", dotQuote(edge.From), dotQuote(edge.To), attrs)
+	}
+	sb.WriteString("}\n")
+	return sb.String(), nil
+}
+
+func renderBeadGraphJSON(graph *BeadGraph) (string, error) {
+	data, err := json.MarshalIndent(graph, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// fakeBeadSource serves beads from memory.
+type fakeBeadSource map[string]*beads.Issue
+
+func (f fakeBeadSource) ShowMany(ids []string) ([]*beads.Issue, error) {
+	var issues []*beads.Issue
+	for _, id := range ids {
+		if issue, ok := f[id]; ok {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+func (f fakeBeadSource) List(opts beads.ListOptions) ([]*beads.Issue, error) {
+	var issues []*beads.Issue
+	for _, issue := range f {
+		if opts.Status == "all" || issue.Status == opts.Status {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+func testBeadWeb() fakeBeadSource {
+	return fakeBeadSource{
+		"gt-epic": {ID: "gt-epic", Title: "Epic", Status: "open",
+			Dependents: []beads.IssueDep{
+				{ID: "gt-a", DependencyType: "parent-child"},
+				{ID: "gt-b", DependencyType: "parent-child"},
+			}},
+		"gt-a": {ID: "gt-a", Title: "Step A", Status: "closed", Parent: "gt-epic"},
+		"gt-b": {ID: "gt-b", Title: "Step B", Status: "open", Parent: "gt-epic",
+			Dependencies: []beads.IssueDep{
+				{ID: "gt-epic", DependencyType: "parent-child"},
+				{ID: "gt-a", DependencyType: "blocks"},
+				{ID: "gt-lib", DependencyType: "blocks"},
+			}},
+		"gt-lib": {ID: "gt-lib", Title: "Library fix", Status: "in_progress",
+			Dependencies: []beads.IssueDep{{ID: "gt-far", DependencyType: "tracks"}}},
+		"gt-far": {ID: "gt-far", Title: "Far away", Status: "open"},
+	}
+}
+
+func TestWalkBeadGraph(t *testing.T) {
+	graph, err := walkBeadGraph(testBeadWeb(), "gt-epic", 2, nil)
+	if err != nil {
+		t.Fatalf("walkBeadGraph: %v", err)
+	}
+
+	var nodes []string
+	for _, n := range graph.Nodes {
+		nodes = append(nodes, n.ID)
+	}
+	// gt-far is three hops away
+	if want := []string{"gt-epic", "gt-a", "gt-b", "gt-lib"}; !reflect.DeepEqual(nodes, want) {
+		t.Errorf("nodes = %v, want %v", nodes, want)
+	}
+	wantEdges := []BeadGraphEdge{
+		{From: "gt-a", To: "gt-b", Kind: "blocks"},
+		{From: "gt-epic", To: "gt-a", Kind: "parent-child"},
+		{From: "gt-epic", To: "gt-b", Kind: "parent-child"},
+		{From: "gt-lib", To: "gt-b", Kind: "blocks"},
+	}
+	if !reflect.DeepEqual(graph.Edges, wantEdges) {
+		t.Errorf("edges = %v, want %v", graph.Edges, wantEdges)
+	}
+
+	// Status filter drops closed beads but keeps the root
+	graph, err = walkBeadGraph(testBeadWeb(), "gt-epic", 0, parseStatusFilter("in_progress, open"))
+	if err != nil {
+		t.Fatalf("walkBeadGraph: %v", err)
+	}
+	nodes = nil
+	for _, n := range graph.Nodes {
+		nodes = append(nodes, n.ID)
+	}
+	if want := []string{"gt-epic", "gt-b", "gt-lib", "gt-far"}; !reflect.DeepEqual(nodes, want) {
+		t.Errorf("filtered nodes = %v, want %v", nodes, want)
+	}
+
+	if _, err := walkBeadGraph(testBeadWeb(), "gt-nope", 1, nil); err == nil {
+		t.Error("walkBeadGraph of a missing root succeeded")
+	}
+}
+
+func TestRenderBeadGraph(t *testing.T) {
+	graph, err := listBeadGraph(testBeadWeb(), parseStatusFilter("open"))
+	if err != nil {
+		t.Fatalf("listBeadGraph: %v", err)
+	}
+	if len(graph.Nodes) != 3 || len(graph.Edges) != 1 {
+		t.Fatalf("graph of open beads = %+v", graph)
+	}
+
+	graph, _ = walkBeadGraph(testBeadWeb(), "gt-epic", 1, nil)
+	mermaid, _ := renderBeadGraphMermaid(graph)
+	for _, want := range []string{
+		"flowchart TD\n",
+		"  b0[[\"gt-epic<br/>Epic\"]]\n",
+		"  b0 --> b1\n",
+		"  b1 -.->|blocks| b2\n",
+		"  style b1 fill:#d4edda,stroke:#28a745\n",
+	} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("mermaid output missing %q:\n%s", want, mermaid)
+		}
+	}
+
+	dot, _ := renderBeadGraphDot(graph)
+	for _, want := range []string{
+		"digraph \"gt-epic\" {\n",
+		"  \"gt-epic\" -> \"gt-a\";\n",
+		"  \"gt-a\" -> \"gt-b\" [style=dashed, label=\"blocks\"];\n",
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("dot output missing %q:\n%s", want, dot)
+		}
+	}
+}
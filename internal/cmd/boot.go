@@ -64,7 +64,8 @@ what action to take on the Deacon.
 
 Boot runs to completion and exits - it doesn't maintain state
 between invocations.`,
-	RunE: runBootSpawn,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runBootSpawn,
 }
 
 var bootTriageCmd = &cobra.Command{
@@ -79,7 +80,8 @@ It performs basic observation and takes conservative action:
   - Otherwise: do nothing
 
 Use --degraded flag when running in degraded mode.`,
-	RunE: runBootTriage,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runBootTriage,
 }
 
 func init() {
@@ -39,8 +39,9 @@ Examples:
   gt broadcast --rig greenplace "New priority work available"
   gt broadcast --all "System maintenance in 5 minutes"
   gt broadcast --dry-run "Test message"`,
-	Args: cobra.ExactArgs(1),
-	RunE: runBroadcast,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runBroadcast,
 }
 
 func runBroadcast(cmd *cobra.Command, args []string) error {
@@ -100,7 +100,8 @@ Note: Witnesses and Refineries handle routine operations autonomously.
 They only send escalations for genuine problems, not status reports.
 
 Unknown message types are logged but left unprocessed.`,
-	RunE: runCallbacksProcess,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCallbacksProcess,
 }
 
 var (
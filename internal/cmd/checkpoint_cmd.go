@@ -53,10 +53,11 @@ var checkpointReadCmd = &cobra.Command{
 }
 
 var checkpointClearCmd = &cobra.Command{
-	Use:   "clear",
-	Short: "Clear the checkpoint file",
-	Long:  `Remove the checkpoint file. Use after work is complete or checkpoint is no longer needed.`,
-	RunE:  runCheckpointClear,
+	Use:         "clear",
+	Short:       "Clear the checkpoint file",
+	Long:        `Remove the checkpoint file. Use after work is complete or checkpoint is no longer needed.`,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCheckpointClear,
 }
 
 var (
@@ -22,6 +22,7 @@ Examples:
   gt close --reason "Done"     # Close with reason
   gt close --force             # Force close pinned beads`,
 	DisableFlagParsing: true, // Pass all flags through to bd close
+	Annotations:        map[string]string{annotationAudit: auditMutating},
 	RunE:               runClose,
 }
 
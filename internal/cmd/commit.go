@@ -7,11 +7,10 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
-	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 // DefaultAgentEmailDomain is the default domain for agent git emails.
-const DefaultAgentEmailDomain = "gastown.local"
+const DefaultAgentEmailDomain = config.DefaultAgentEmailDomain
 
 var commitCmd = &cobra.Command{
 	Use:   "commit [flags] [-- git-commit-args...]",
@@ -36,6 +35,7 @@ Identity mapping:
                                 Email: gastown.crew.jack@gastown.local
 
 When run without GT_ROLE (human), passes through to git commit with no changes.`,
+	Annotations:        map[string]string{annotationAudit: auditMutating},
 	RunE:               runCommit,
 	DisableFlagParsing: true, // We'll parse flags ourselves to pass them to git
 }
@@ -56,12 +56,8 @@ func runCommit(cmd *cobra.Command, args []string) error {
 
 	// Load agent email domain from town settings
 	domain := DefaultAgentEmailDomain
-	townRoot, err := workspace.FindFromCwd()
-	if err == nil && townRoot != "" {
-		settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
-		if err == nil && settings.AgentEmailDomain != "" {
-			domain = settings.AgentEmailDomain
-		}
+	if scope, err := cwdConfigScope(); err == nil {
+		domain = config.ResolveString(scope, "agent_email_domain")
 	}
 
 	// Convert identity to git-friendly email
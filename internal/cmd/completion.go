@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/daemon"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// completionBeadLimit caps how many beads are offered when completing IDs.
+const completionBeadLimit = 200
+
+// completer returns candidates for one positional argument or flag value.
+type completer func(cmd *cobra.Command, toComplete string) []string
+
+// argCompleters maps the placeholders used in command Use strings to the
+// completer for that kind of argument.
+var argCompleters = map[string]completer{
+	"<rig>":             completeRigs,
+	"<rig>/<polecat>":   completePolecatAddresses,
+	"<rig>/<worker>":    completeWorkerAddresses,
+	"<rig/polecat>":     completePolecatAddresses,
+	"<bead-id>":         completeBeadIDs,
+	"[bead-id]":         completeBeadIDs,
+	"<issue-id>":        completeBeadIDs,
+	"[issue-id]":        completeBeadIDs,
+	"<bead-or-formula>": completeBeadIDs,
+	"<convoy-id>":       completeBeadIDs,
+	"[convoy-id]":       completeBeadIDs,
+	"<molecule-id>":     completeMoleculeIDs,
+	"[pinned-bead-id]":  completeBeadIDs,
+}
+
+// flagCompleters maps flag names to completers, for every command that
+// defines the flag.
+var flagCompleters = map[string]completer{
+	"rig":      completeRigs,
+	"molecule": completeMoleculeIDs,
+}
+
+// registerCompletions attaches dynamic completion to the command tree:
+// positional arguments are completed from their Use placeholders (<rig>,
+// <rig>/<polecat>, <bead-id>, ...) and well-known flags by name. Commands
+// that set their own ValidArgsFunction keep it.
+func registerCompletions(root *cobra.Command) {
+	_ = root.RegisterFlagCompletionFunc("town", flagCompletion(completeTowns))
+
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		if c.ValidArgsFunction == nil {
+			if kinds, variadic := argPlaceholders(c); kinds != nil {
+				c.ValidArgsFunction = positionalCompletion(kinds, variadic)
+			}
+		}
+		for name, complete := range flagCompleters {
+			if c.LocalNonPersistentFlags().Lookup(name) != nil {
+				_ = c.RegisterFlagCompletionFunc(name, flagCompletion(complete))
+			}
+		}
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+}
+
+// argPlaceholders returns one completer per positional argument, read from
+// the command's Use string, or nil if none of them can be completed. Only
+// the first usage form (before "|") counts. A nil entry means the argument
+// is not completed; variadic means the last one repeats ("<rig>...").
+func argPlaceholders(c *cobra.Command) (kinds []completer, variadic bool) {
+	fields := strings.Fields(c.Use)
+	if len(fields) < 2 {
+		return nil, false
+	}
+
+	found := false
+	for _, f := range fields[1:] {
+		if f == "|" {
+			break
+		}
+		if strings.HasPrefix(f, "-") {
+			continue
+		}
+		variadic = strings.Contains(f, "...")
+		f = strings.Replace(f, "...", "", 1)
+
+		complete := argCompleters[f]
+		if complete == nil && (f == "<name>" || f == "[<name>]") {
+			complete = nameCompleter(c)
+		}
+		if complete != nil {
+			found = true
+		}
+		kinds = append(kinds, complete)
+		if variadic {
+			break
+		}
+	}
+	if !found {
+		return nil, false
+	}
+	return kinds, variadic
+}
+
+// nameCompleter completes <name> for the commands where it names a rig
+// or crew member.
+func nameCompleter(c *cobra.Command) completer {
+	if c.Parent() == nil {
+		return nil
+	}
+	switch c.Parent().Name() {
+	case "crew":
+		if c.Name() == "add" {
+			return nil // new names
+		}
+		return completeCrew
+	case "rig":
+		if c.Name() == "add" {
+			return nil
+		}
+		return completeRigs
+	}
+	return nil
+}
+
+func positionalCompletion(kinds []completer, variadic bool) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		i := len(args)
+		if variadic && i >= len(kinds) {
+			i = len(kinds) - 1
+		}
+		var complete completer
+		if i < len(kinds) {
+			complete = kinds[i]
+		}
+		if complete == nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		return filterPrefix(complete(cmd, toComplete), toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func flagCompletion(complete completer) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return filterPrefix(complete(cmd, toComplete), toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// filterPrefix keeps the candidates that start with toComplete. Candidates
+// may carry a tab-separated description.
+func filterPrefix(candidates []string, toComplete string) []string {
+	var out []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, toComplete) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// completionTownRoot finds the town for completion, quietly. Completion
+// does not run persistentPreRun, so --town is honored here.
+func completionTownRoot() string {
+	if townFlag != "" {
+		townRoot, _ := workspace.LookupTown(townFlag)
+		return townRoot
+	}
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return ""
+	}
+	return townRoot
+}
+
+// completionRigNames returns the registered rig names, sorted.
+func completionRigNames(townRoot string) []string {
+	rigsConfig, err := daemon.LoadRigsConfig(townRoot)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(rigsConfig.Rigs))
+	for name := range rigsConfig.Rigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// subdirNames lists the visible subdirectories of dir.
+func subdirNames(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+func completeRigs(_ *cobra.Command, _ string) []string {
+	townRoot := completionTownRoot()
+	if townRoot == "" {
+		return nil
+	}
+	return completionRigNames(townRoot)
+}
+
+func completeTowns(_ *cobra.Command, _ string) []string {
+	reg, err := workspace.LoadTownsRegistry()
+	if err != nil {
+		return nil
+	}
+	return reg.Names()
+}
+
+// completeCrew lists crew names in the rig given by --rig, the rig of the
+// current directory, or every rig.
+func completeCrew(cmd *cobra.Command, _ string) []string {
+	townRoot := completionTownRoot()
+	if townRoot == "" {
+		return nil
+	}
+	rigs := completionRigNames(townRoot)
+	if f := cmd.Flags().Lookup("rig"); f != nil && f.Value.String() != "" {
+		rigs = []string{f.Value.String()}
+	} else if rigName, err := inferRigFromCwd(townRoot); err == nil && slices.Contains(rigs, rigName) {
+		rigs = []string{rigName}
+	}
+
+	var names []string
+	for _, r := range rigs {
+		names = append(names, subdirNames(filepath.Join(townRoot, r, "crew"))...)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// completePolecatAddresses completes <rig>/<polecat>: rig names until a
+// slash is typed, then that rig's polecats.
+func completePolecatAddresses(_ *cobra.Command, toComplete string) []string {
+	return completeAddresses(toComplete, "polecats")
+}
+
+// completeWorkerAddresses is like completePolecatAddresses but also offers
+// crew members.
+func completeWorkerAddresses(_ *cobra.Command, toComplete string) []string {
+	return completeAddresses(toComplete, "polecats", "crew")
+}
+
+func completeAddresses(toComplete string, workerDirs ...string) []string {
+	townRoot := completionTownRoot()
+	if townRoot == "" {
+		return nil
+	}
+	rigName, _, hasSlash := strings.Cut(toComplete, "/")
+	if !hasSlash {
+		var out []string
+		for _, r := range completionRigNames(townRoot) {
+			out = append(out, r+"/")
+		}
+		return out
+	}
+
+	var out []string
+	for _, dir := range workerDirs {
+		for _, name := range subdirNames(filepath.Join(townRoot, rigName, dir)) {
+			out = append(out, rigName+"/"+name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// completeBeadIDs lists open beads visible from the current directory,
+// with their titles as descriptions.
+func completeBeadIDs(_ *cobra.Command, _ string) []string {
+	return completeIssues(beads.ListOptions{Priority: -1, Limit: completionBeadLimit})
+}
+
+// completeMoleculeIDs lists molecule protos.
+func completeMoleculeIDs(_ *cobra.Command, _ string) []string {
+	return completeIssues(beads.ListOptions{Label: "gt:molecule", Status: "all", Priority: -1, Limit: completionBeadLimit})
+}
+
+func completeIssues(opts beads.ListOptions) []string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	issues, err := beads.New(cwd).List(opts)
+	if err != nil {
+		return nil
+	}
+	out := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		out = append(out, issue.ID+"\t"+issue.Title)
+	}
+	return out
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestPositionalCompletionFromUse(t *testing.T) {
+	townRoot := t.TempDir()
+	for _, dir := range []string{"mayor", "gastown/crew/max", "gastown/polecats/toast", "gastown/polecats/.claude", "beads"} {
+		if err := os.MkdirAll(filepath.Join(townRoot, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{
+		"mayor/town.json": `{"type":"town","version":2,"name":"test"}`,
+		"mayor/rigs.json": `{"version":1,"rigs":{"gastown":{},"beads":{}}}`,
+	}
+	for path, content := range files {
+		if err := os.WriteFile(filepath.Join(townRoot, path), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cwd, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(cwd) })
+	if err := os.Chdir(filepath.Join(townRoot, "gastown")); err != nil {
+		t.Fatal(err)
+	}
+
+	root := &cobra.Command{Use: "gt"}
+	crew := &cobra.Command{Use: "crew"}
+	root.AddCommand(
+		&cobra.Command{Use: "park <rig>..."},
+		&cobra.Command{Use: "peek <rig/polecat> [count]"},
+		&cobra.Command{Use: "nuke <rig>/<polecat>... | <rig> --all"},
+		&cobra.Command{Use: "version"},
+		crew,
+	)
+	crew.AddCommand(&cobra.Command{Use: "remove <name...> | --all"}, &cobra.Command{Use: "add <name...>"})
+	registerCompletions(root)
+
+	complete := func(args []string, toComplete string) []string {
+		t.Helper()
+		c, _, err := root.Find(args)
+		if err != nil {
+			t.Fatalf("Find(%v): %v", args, err)
+		}
+		if c.ValidArgsFunction == nil {
+			return nil
+		}
+		depth := 0
+		for p := c; p != root; p = p.Parent() {
+			depth++
+		}
+		got, _ := c.ValidArgsFunction(c, args[depth:], toComplete)
+		return got
+	}
+
+	tests := []struct {
+		args       []string
+		toComplete string
+		want       []string
+	}{
+		{[]string{"park"}, "", []string{"beads", "gastown"}},
+		{[]string{"park", "beads"}, "ga", []string{"gastown"}}, // variadic
+		{[]string{"peek"}, "", []string{"beads/", "gastown/"}},
+		{[]string{"peek"}, "gastown/", []string{"gastown/toast"}},
+		{[]string{"peek", "gastown/toast"}, "", nil}, // [count]
+		{[]string{"nuke", "gastown/toast"}, "gastown/t", []string{"gastown/toast"}},
+		{[]string{"crew", "remove"}, "", []string{"max"}},
+		{[]string{"crew", "add"}, "", nil}, // new names
+		{[]string{"version"}, "", nil},
+	}
+	for _, tt := range tests {
+		if got := complete(tt.args, tt.toComplete); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("complete(%v, %q) = %v, want %v", tt.args, tt.toComplete, got, tt.want)
+		}
+	}
+}
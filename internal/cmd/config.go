@@ -29,7 +29,9 @@ Commands:
   gt config agent get <name>         Show agent configuration
   gt config agent set <name> <cmd>   Set custom agent command
   gt config agent remove <name>      Remove custom agent
-  gt config default-agent [name]     Get or set default agent`,
+  gt config default-agent [name]     Get or set default agent
+  gt config get [key]                Show effective settings and their source
+  gt config validate                 Check config files and pending migrations`,
 }
 
 // Agent subcommands
@@ -79,8 +81,9 @@ Examples:
   gt config agent set claude-glm \"claude-glm --model glm-4\"
   gt config agent set gemini-custom gemini --approval-mode yolo
   gt config agent set claude \"claude-glm\"  # Override built-in claude`,
-	Args: cobra.ExactArgs(2),
-	RunE: runConfigAgentSet,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runConfigAgentSet,
 }
 
 var configAgentRemoveCmd = &cobra.Command{
@@ -93,8 +96,9 @@ This removes a custom agent from your town settings. Built-in agents
 
 Examples:
   gt config agent remove claude-glm`,
-	Args: cobra.ExactArgs(1),
-	RunE: runConfigAgentRemove,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runConfigAgentRemove,
 }
 
 // Default-agent subcommand
@@ -116,7 +120,8 @@ Examples:
   gt config default-agent claude    # Set to claude
   gt config default-agent gemini    # Set to gemini
   gt config default-agent my-custom # Set to custom agent`,
-	RunE: runConfigDefaultAgent,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runConfigDefaultAgent,
 }
 
 var configAgentEmailDomainCmd = &cobra.Command{
@@ -137,7 +142,8 @@ Examples:
   gt config agent-email-domain                 # Show current domain
   gt config agent-email-domain gastown.local   # Set to gastown.local
   gt config agent-email-domain example.com     # Set custom domain`,
-	RunE: runConfigAgentEmailDomain,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runConfigAgentEmailDomain,
 }
 
 // Flags
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var configGetJSON bool
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Show effective settings and where they come from",
+	Long: `Show the effective value of layered settings for the current directory.
+
+Settings are resolved through a precedence chain, highest first:
+
+  1. GT_* environment variable       (e.g. GT_DEFAULT_AGENT)
+  2. Workspace overrides              <rig>/crew/<name>/.runtime/settings.json
+  3. Rig settings                     <rig>/settings/config.json
+  4. Town settings                    settings/config.json
+  5. Built-in default
+
+Each settings file may set any key in its "overrides" map:
+
+  {"overrides": {"agent_email_domain": "example.com"}}
+
+Town and rig settings also honor their dedicated fields (default_agent,
+agent, agent_email_domain); "overrides" wins over them within a layer.
+
+Examples:
+  gt config get                      # All settings
+  gt config get default_agent        # One setting
+  gt config get --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigGet,
+}
+
+func init() {
+	configGetCmd.Flags().BoolVar(&configGetJSON, "json", false, "Output as JSON")
+
+	configCmd.AddCommand(configGetCmd)
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	scope, err := cwdConfigScope()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	var values []config.ResolvedValue
+	if len(args) == 1 {
+		v, err := config.Resolve(scope, args[0])
+		if err != nil {
+			return err
+		}
+		values = append(values, v)
+	} else {
+		values = config.ResolveAll(scope)
+	}
+
+	if configGetJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(values)
+	}
+
+	if len(args) == 1 {
+		fmt.Println(values[0].Value)
+		return nil
+	}
+	for _, v := range values {
+		source := string(v.Layer)
+		if v.Source != "" {
+			source += ": " + v.Source
+		}
+		fmt.Printf("%-20s %s %s\n", v.Key, style.Bold.Render(v.Value), style.Dim.Render("("+source+")"))
+	}
+	return nil
+}
+
+// cwdConfigScope returns the config scope (town, rig, workspace) for the
+// current directory.
+func cwdConfigScope() (config.Scope, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return config.Scope{}, err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return config.Scope{TownRoot: townRoot}, nil
+	}
+	return config.ScopeFromPath(townRoot, cwd), nil
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var configValidateJSON bool
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate town and rig config files",
+	Long: `Validate the town's config files against their schemas.
+
+Checks mayor/town.json, mayor/rigs.json, settings/config.json, and each
+registered rig's config.json and settings/config.json. For each file it
+reports parse errors with line and column, unsupported versions, fields
+the current gt does not know, and whether the file is on an older schema
+version.
+
+Older files are upgraded automatically (and written back) the next time
+a command loads them; validate never modifies anything.
+
+Examples:
+  gt config validate
+  gt config validate --json`,
+	RunE: runConfigValidate,
+}
+
+func init() {
+	configValidateCmd.Flags().BoolVar(&configValidateJSON, "json", false, "Output as JSON")
+
+	configCmd.AddCommand(configValidateCmd)
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	var reports []*config.ValidationReport
+	failed := 0
+	for _, f := range config.TownConfigFiles(townRoot) {
+		report := config.ValidateFile(f.Path, f.Schema)
+		if report.Error != "" {
+			failed++
+		}
+		reports = append(reports, report)
+	}
+
+	if configValidateJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(reports); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range reports {
+			printValidationReport(townRoot, r)
+		}
+		fmt.Println()
+		if failed == 0 {
+			fmt.Printf("%s All config files are valid\n", style.Success.Render("✓"))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d config file(s) invalid", failed)
+	}
+	return nil
+}
+
+// printValidationReport prints one line per file, plus any warnings.
+func printValidationReport(townRoot string, r *config.ValidationReport) {
+	rel, err := filepath.Rel(townRoot, r.Path)
+	if err != nil {
+		rel = r.Path
+	}
+
+	switch {
+	case r.Error != "":
+		fmt.Printf("%s %s: %s\n", style.Error.Render("✗"), rel, r.Error)
+	case r.Missing:
+		fmt.Printf("%s %s %s\n", style.Dim.Render("-"), rel, style.Dim.Render("(not present)"))
+	case r.NeedsMigration():
+		fmt.Printf("%s %s %s\n", style.Warning.Render("↑"), rel,
+			style.Dim.Render(fmt.Sprintf("(%s v%d, will be upgraded to v%d on next load)", r.Schema, r.Version, r.Current)))
+	default:
+		fmt.Printf("%s %s %s\n", style.Success.Render("✓"), rel, style.Dim.Render(fmt.Sprintf("(%s v%d)", r.Schema, r.Version)))
+	}
+	for _, w := range r.Warnings {
+		fmt.Printf("    %s %s\n", style.Warning.Render("⚠"), w)
+	}
+}
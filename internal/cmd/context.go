@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/runtime"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var contextJSON bool
+
+var contextCmd = &cobra.Command{
+	Use:     "context",
+	GroupID: GroupDiag,
+	Short:   "Show the detected town, rig, role and session",
+	Long: `Show what gt detects about where it is running: town root, rig, role,
+worker name, identity, tmux session, runtime session ID and beads directory.
+
+Detection is the same as every other command uses: GT_ROLE (with GT_RIG,
+GT_CREW, GT_POLECAT) when set, otherwise the current directory. Agents can
+use it to identify themselves in prompts and scripts.
+
+Examples:
+  gt context
+  gt context --json | jq -r .identity`,
+	Args: cobra.NoArgs,
+	RunE: runContext,
+}
+
+func init() {
+	contextCmd.Flags().BoolVar(&contextJSON, "json", false, "Output as JSON")
+	rootCmd.AddCommand(contextCmd)
+}
+
+// AgentContext is the output of gt context.
+type AgentContext struct {
+	TownRoot    string `json:"town_root"`
+	Rig         string `json:"rig,omitempty"`
+	Role        Role   `json:"role"`
+	Worker      string `json:"worker,omitempty"`
+	Identity    string `json:"identity,omitempty"`
+	Source      string `json:"source"` // "env" or "cwd"
+	Home        string `json:"home,omitempty"`
+	WorkDir     string `json:"work_dir"`
+	Session     string `json:"session,omitempty"`      // tmux session for the role
+	TmuxSession string `json:"tmux_session,omitempty"` // tmux session this process runs in
+	SessionID   string `json:"session_id,omitempty"`   // runtime (agent) session ID
+	BeadsDir    string `json:"beads_dir,omitempty"`
+	Mismatch    bool   `json:"mismatch,omitempty"` // GT_ROLE disagrees with the directory
+	CwdRole     Role   `json:"cwd_role,omitempty"`
+}
+
+func runContext(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+	info, err := GetRoleWithContext(cwd, townRoot)
+	if err != nil {
+		return err
+	}
+
+	ctx := detectAgentContext(info)
+	if contextJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(ctx)
+	}
+	printAgentContext(ctx)
+	return nil
+}
+
+// detectAgentContext fills in the session and beads details for a role.
+func detectAgentContext(info RoleInfo) AgentContext {
+	ctx := AgentContext{
+		TownRoot:  info.TownRoot,
+		Rig:       info.Rig,
+		Role:      info.Role,
+		Worker:    info.Polecat,
+		Identity:  buildAgentIdentity(info),
+		Source:    info.Source,
+		Home:      info.Home,
+		WorkDir:   info.WorkDir,
+		SessionID: runtime.SessionIDFromEnv(),
+		Mismatch:  info.Mismatch,
+	}
+	if info.Mismatch {
+		ctx.CwdRole = info.CwdRole
+	}
+
+	id := session.AgentIdentity{Role: session.Role(info.Role), Rig: info.Rig, Name: info.Polecat}
+	ctx.Session = id.SessionName()
+	if tmux.IsInsideTmux() {
+		ctx.TmuxSession, _ = getCurrentTmuxSession()
+	}
+
+	// The beads directory bd uses here: BEADS_DIR or the nearest .beads,
+	// with redirects followed
+	if dir, err := findLocalBeadsDir(); err == nil {
+		ctx.BeadsDir = beads.ResolveBeadsDir(dir)
+	}
+	return ctx
+}
+
+func printAgentContext(ctx AgentContext) {
+	row := func(label, value string) {
+		if value == "" {
+			value = style.Dim.Render("-")
+		}
+		fmt.Printf("%s %s\n", style.Bold.Render(fmt.Sprintf("%-11s", label)), value)
+	}
+	row("Town:", ctx.TownRoot)
+	row("Rig:", ctx.Rig)
+	row("Role:", string(ctx.Role)+style.Dim.Render(" (from "+ctx.Source+")"))
+	row("Worker:", ctx.Worker)
+	row("Identity:", ctx.Identity)
+	row("Home:", ctx.Home)
+	row("Session:", ctx.Session)
+	if ctx.TmuxSession != "" && ctx.TmuxSession != ctx.Session {
+		row("In session:", ctx.TmuxSession)
+	}
+	row("Session ID:", ctx.SessionID)
+	row("Beads:", ctx.BeadsDir)
+	if ctx.Mismatch {
+		fmt.Println()
+		style.PrintWarning("GT_ROLE says %s but the current directory looks like %s", ctx.Role, ctx.CwdRole)
+	}
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectAgentContextFromCwd(t *testing.T) {
+	townRoot := t.TempDir()
+	workDir := filepath.Join(townRoot, "gastown", "polecats", "toast")
+	for _, dir := range []string{"mayor", "gastown/.beads", workDir} {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(townRoot, dir)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cwd, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(cwd) })
+	if err := os.Chdir(workDir); err != nil {
+		t.Fatal(err)
+	}
+	for _, env := range []string{"GT_ROLE", "GT_RIG", "GT_CREW", "GT_POLECAT", "BEADS_DIR", "TMUX"} {
+		t.Setenv(env, "")
+	}
+
+	info, err := GetRoleWithContext(workDir, townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := detectAgentContext(info)
+
+	if ctx.Role != RolePolecat || ctx.Rig != "gastown" || ctx.Worker != "toast" || ctx.Source != "cwd" {
+		t.Errorf("role = %s %s/%s from %s, want polecat gastown/toast from cwd", ctx.Role, ctx.Rig, ctx.Worker, ctx.Source)
+	}
+	if ctx.Identity != "gastown/polecats/toast" {
+		t.Errorf("Identity = %q", ctx.Identity)
+	}
+	if ctx.Session != "gt-gastown-toast" {
+		t.Errorf("Session = %q", ctx.Session)
+	}
+	if want := filepath.Join(townRoot, "gastown", ".beads"); ctx.BeadsDir != want {
+		t.Errorf("BeadsDir = %q, want %q", ctx.BeadsDir, want)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/tui/convoy"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -134,8 +135,9 @@ Examples:
   gt convoy create "Release prep" gt-abc --notify ops/      # notify ops/
   gt convoy create "Feature rollout" gt-a gt-b --owner mayor/ --notify ops/
   gt convoy create "Feature rollout" gt-a gt-b gt-c --molecule mol-release`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runConvoyCreate,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runConvoyCreate,
 }
 
 var convoyStatusCmd = &cobra.Command{
@@ -173,8 +175,9 @@ If the convoy is closed, it will be automatically reopened.
 Examples:
   gt convoy add hq-cv-abc gt-new-issue
   gt convoy add hq-cv-abc gt-issue1 gt-issue2 gt-issue3`,
-	Args: cobra.MinimumNArgs(2),
-	RunE: runConvoyAdd,
+	Args:        cobra.MinimumNArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runConvoyAdd,
 }
 
 var convoyCheckCmd = &cobra.Command{
@@ -193,8 +196,9 @@ Examples:
   gt convoy check              # Check all open convoys
   gt convoy check hq-cv-abc    # Check specific convoy
   gt convoy check --dry-run    # Preview what would close without acting`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runConvoyCheck,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runConvoyCheck,
 }
 
 var convoyStrandedCmd = &cobra.Command{
@@ -234,8 +238,9 @@ Examples:
   gt convoy close hq-cv-abc
   gt convoy close hq-cv-abc --reason="work done differently"
   gt convoy close hq-cv-xyz --notify mayor/`,
-	Args: cobra.ExactArgs(1),
-	RunE: runConvoyClose,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runConvoyClose,
 }
 
 func init() {
@@ -861,7 +866,7 @@ func isReadyIssue(t trackedIssueInfo, blockedIssues map[string]bool) bool {
 	}
 
 	// Check if tmux session exists
-	checkCmd := exec.Command("tmux", "has-session", "-t", sessionName)
+	checkCmd := tmux.Command("has-session", "-t", sessionName)
 	if err := checkCmd.Run(); err != nil {
 		return true // Session doesn't exist = ready
 	}
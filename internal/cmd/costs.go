@@ -43,6 +43,7 @@ var (
 
 var costsCmd = &cobra.Command{
 	Use:     "costs",
+	Aliases: []string{"cost"},
 	GroupID: GroupDiag,
 	Short:   "Show costs for running Claude sessions [DISABLED]",
 	Long: `Display costs for Claude Code sessions in Gas Town.
@@ -70,6 +71,7 @@ Examples:
   gt costs --json       # Output as JSON
 
 Subcommands:
+  gt costs report       # Token usage and cost parsed from agent transcripts
   gt costs record       # Record session cost as ephemeral wisp (Stop hook)
   gt costs digest       # Aggregate wisps into daily digest bead (Deacon patrol)`,
 	RunE: runCosts,
@@ -109,7 +111,8 @@ Examples:
   gt costs digest --yesterday   # Digest yesterday's costs (default for patrol)
   gt costs digest --date 2026-01-07  # Digest a specific date
   gt costs digest --yesterday --dry-run  # Preview without changes`,
-	RunE: runCostsDigest,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCostsDigest,
 }
 
 var costsMigrateCmd = &cobra.Command{
@@ -130,7 +133,8 @@ with the new wisp-based cost tracking.
 Examples:
   gt costs migrate            # Migrate legacy beads
   gt costs migrate --dry-run  # Preview what would be migrated`,
-	RunE: runCostsMigrate,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCostsMigrate,
 }
 
 func init() {
@@ -913,7 +917,7 @@ func deriveSessionName() string {
 // Note: We don't check TMUX env var because it may not be inherited when Claude Code
 // runs bash commands, even though we are inside a tmux session.
 func detectCurrentTmuxSession() string {
-	cmd := exec.Command("tmux", "display-message", "-p", "#S")
+	cmd := tmux.Command("display-message", "-p", "#S")
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/cost"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/notify"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	costsReportSince string
+	costsReportBy    string
+	costsReportJSON  bool
+	costsReportAlert bool
+)
+
+var costsReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report token usage and estimated cost from agent transcripts",
+	Long: `Aggregate agent token usage and estimated API cost for the town.
+
+Usage is parsed from Claude Code session transcripts (~/.claude/projects and
+$CLAUDE_CONFIG_DIR/projects). Each assistant turn is attributed to a rig,
+actor, and bead using the session's working directory and git branch, so no
+wrapping of agent invocations is required. Cost is estimated at list prices.
+
+Wall-clock time is the span between the first and last turn of each session.
+
+Budgets are configured in settings/config.json:
+
+  "cost_budget": {
+    "daily_usd": 200,
+    "rig_daily_usd": {"gastown": 80},
+    "severity": "high"
+  }
+
+With --alert, exceeded budgets are routed through settings/escalation.json
+(the same rules used by 'gt escalate').
+
+Examples:
+  gt costs report                     # Last 24h, grouped by rig
+  gt costs report --since 7d --by day # Daily totals for the week
+  gt costs report --by actor          # Which agents cost the most
+  gt costs report --by bead           # Cost per hooked bead (polecats)
+  gt costs report --alert             # Also route budget alerts`,
+	RunE: runCostsReport,
+}
+
+func init() {
+	costsCmd.AddCommand(costsReportCmd)
+	costsReportCmd.Flags().StringVar(&costsReportSince, "since", "24h", "Report window (e.g., 24h, 7d)")
+	costsReportCmd.Flags().StringVar(&costsReportBy, "by", "rig", "Group by: rig, actor, day, session, bead, model")
+	costsReportCmd.Flags().BoolVar(&costsReportJSON, "json", false, "Output as JSON")
+	costsReportCmd.Flags().BoolVar(&costsReportAlert, "alert", false, "Route exceeded budgets through escalation rules")
+}
+
+func runCostsReport(cmd *cobra.Command, args []string) error {
+	groupBy := cost.GroupBy(costsReportBy)
+	if !cost.ValidGroupBy(groupBy) {
+		return fmt.Errorf("invalid --by %q: must be rig, actor, day, session, bead, or model", costsReportBy)
+	}
+
+	window, err := parseDuration(costsReportSince)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	since := time.Now().Add(-window)
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	// Budgets are daily, so always scan at least since midnight.
+	now := time.Now()
+	scanSince := since
+	if midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()); midnight.Before(scanSince) {
+		scanSince = midnight
+	}
+
+	records, err := cost.Scan(cost.ScanOptions{TownRoot: townRoot, Since: scanSince})
+	if err != nil {
+		return fmt.Errorf("scanning transcripts: %w", err)
+	}
+
+	var windowed []cost.Record
+	for _, rec := range records {
+		if !rec.Timestamp.Before(since) {
+			windowed = append(windowed, rec)
+		}
+	}
+	report := cost.Aggregate(windowed, groupBy, since)
+
+	var alerts []cost.BudgetAlert
+	settings, _ := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if settings != nil && settings.CostBudget != nil {
+		alerts = cost.CheckDailyBudget(records, now, settings.CostBudget.DailyUSD, settings.CostBudget.RigDailyUSD)
+	}
+
+	if costsReportJSON {
+		out := struct {
+			*cost.Report
+			Alerts []cost.BudgetAlert `json:"alerts,omitempty"`
+		}{report, alerts}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			return err
+		}
+	} else {
+		printCostReport(report)
+		for _, a := range alerts {
+			style.PrintWarning("budget exceeded: %s", a)
+		}
+	}
+
+	if costsReportAlert && len(alerts) > 0 {
+		routeBudgetAlerts(townRoot, settings.CostBudget, alerts)
+	}
+	return nil
+}
+
+// printCostReport renders a cost report as a table.
+func printCostReport(report *cost.Report) {
+	fmt.Printf("%s Agent usage since %s (by %s)\n\n",
+		style.Bold.Render("💸"), report.Since.Local().Format("2006-01-02 15:04"), report.GroupBy)
+
+	if len(report.Rows) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("No agent transcripts found for this window"))
+		return
+	}
+
+	fmt.Printf("  %-32s %8s %6s %10s %12s %10s\n", "KEY", "SESSIONS", "TURNS", "WALL", "TOKENS", "COST")
+	for _, row := range report.Rows {
+		fmt.Printf("  %-32s %8d %6d %10s %12s %10s\n",
+			truncateCostKey(row.Key, 32), row.Sessions, row.Turns,
+			row.WallClock.Round(time.Minute), formatTokens(row.Usage.TotalTokens()),
+			fmt.Sprintf("$%.2f", row.Usage.CostUSD))
+	}
+	fmt.Printf("\n  %-32s %8s %6s %10s %12s %10s\n", style.Bold.Render("TOTAL"), "", "", "",
+		formatTokens(report.Total.TotalTokens()), fmt.Sprintf("$%.2f", report.Total.CostUSD))
+}
+
+// routeBudgetAlerts sends budget alerts to the mail targets configured for
+// the budget severity in settings/escalation.json, and logs them to the feed.
+func routeBudgetAlerts(townRoot string, budget *config.CostBudgetConfig, alerts []cost.BudgetAlert) {
+	severity := budget.Severity
+	if !config.IsValidSeverity(severity) {
+		severity = config.SeverityHigh
+	}
+
+	escalationConfig, err := config.LoadOrCreateEscalationConfig(config.EscalationConfigPath(townRoot))
+	if err != nil {
+		style.PrintWarning("loading escalation config: %v", err)
+		return
+	}
+	actions := escalationConfig.GetRouteForSeverity(severity)
+
+	var lines []string
+	for _, a := range alerts {
+		lines = append(lines, "- "+a.String())
+	}
+	subject := fmt.Sprintf("[%s] Agent cost budget exceeded", strings.ToUpper(severity))
+	body := "Daily agent spend has exceeded the configured budget:\n\n" + strings.Join(lines, "\n") +
+		"\n\nRun 'gt costs report --by actor' for a breakdown."
+
+	router := mail.NewRouter(townRoot)
+	for _, target := range extractMailTargetsFromActions(actions) {
+		msg := mail.NewMessage("gt-costs", target, subject, body)
+		msg.Type = mail.TypeTask
+		msg.Priority = mail.PriorityHigh
+		if err := router.Send(msg); err != nil {
+			style.PrintWarning("failed to send budget alert to %s: %v", target, err)
+		}
+	}
+	executeExternalActions(townRoot, actions, escalationConfig, notify.Notification{
+		Event:    notify.EventCostAlert,
+		Severity: severity,
+		Title:    subject,
+		Body:     body,
+		Source:   "gt-costs",
+	})
+
+	_ = events.LogFeed(events.TypeEscalationSent, "gt-costs", map[string]interface{}{
+		"reason":   subject,
+		"severity": severity,
+		"alerts":   strings.Join(lines, "; "),
+	})
+}
+
+// formatTokens renders a token count compactly (e.g., 1.2M, 340k).
+func formatTokens(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.0fk", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+// truncateCostKey shortens a report key to fit a table column.
+func truncateCostKey(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-1] + "…"
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -22,6 +23,12 @@ var (
 	crewListAll       bool
 	crewDryRun        bool
 	crewDebug         bool
+	crewParallel      int
+	crewFromHandoff   int
+	crewMoveToRig     string
+	crewMoveCherry    bool
+	crewMoveKeep      bool
+	crewSparse        []string
 )
 
 var crewCmd = &cobra.Command{
@@ -48,32 +55,44 @@ Features:
 Commands:
   gt crew start <name>     Start session (creates workspace if needed)
   gt crew stop <name>      Stop session(s)
-  gt crew add <name>       Create workspace without starting
+  gt crew add <name...>    Create workspace(s) without starting
+  gt crew clone <src> <dst> Fork a worker's state into a new workspace
   gt crew list             List workspaces with status
   gt crew at <name>        Attach to session
-  gt crew remove <name>    Remove workspace
-  gt crew refresh <name>   Context cycle with handoff mail
-  gt crew restart <name>   Kill and restart session fresh`,
+  gt crew remove <name...> Remove workspace(s)
+  gt crew refresh <name...> Context cycle with handoff mail
+  gt crew restart <name>   Kill and restart session fresh
+  gt crew config <name>    Per-worker model and launch settings
+  gt crew handoffs <name>  List past refresh handoffs`,
 }
 
 var crewAddCmd = &cobra.Command{
-	Use:   "add <name>",
-	Short: "Create a new crew workspace",
+	Use:   "add <name...>",
+	Short: "Create crew workspace(s)",
 	Long: `Create new crew workspace(s) with a clone of the rig repository.
 
 Each workspace is created at <rig>/crew/<name>/ with:
-- A full git clone of the project repository
+- A full git clone of the project repository, or a git worktree of the
+  rig's shared repo when the rig's checkout mode is worktree
+  (shallow, partial or sparse as the rig's checkout settings say)
 - Mail directory for message delivery
 - CLAUDE.md with crew worker prompting
 - Optional feature branch (crew/<name>)
 
+Multiple workspaces are created concurrently (see --parallel), followed by
+a per-worker summary table.
+
 Examples:
   gt crew add dave                       # Create single workspace
   gt crew add murgen croaker goblin      # Create multiple at once
   gt crew add emma --rig greenplace      # Create in specific rig
-  gt crew add fred --branch              # Create with feature branch`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runCrewAdd,
+  gt crew add fred --branch              # Create with feature branch
+  gt crew add ann --sparse /services/api/ --sparse /libs/
+                                         # Check out only those directories
+  gt crew add dave emma fred --json      # Per-worker results as JSON`,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCrewAdd,
 }
 
 var crewListCmd = &cobra.Command{
@@ -81,12 +100,22 @@ var crewListCmd = &cobra.Command{
 	Short: "List crew workspaces with status",
 	Long: `List all crew workspaces in a rig with their status.
 
-Shows git branch, session state, and git status for each workspace.
+Shows session state (● running), git branch, git status, and last activity
+for each workspace. Activity is the session's last activity when running,
+otherwise the last commit.
+
+Formats:
+  table   one line per worker (default)
+  wide    table plus dirty file count and workspace path
+  names   rig/name per line, for scripts
 
 Examples:
   gt crew list                    # List in current rig
   gt crew list --rig greenplace   # List in specific rig
   gt crew list --all              # List in all rigs
+  gt crew list --sort activity    # Most recently active first
+  gt crew list --dirty-only       # Workspaces with uncommitted changes
+  gt crew list --running-only --format names
   gt crew list --json             # JSON output`,
 	RunE: runCrewList,
 }
@@ -111,17 +140,26 @@ Role Discovery:
   current directory. If you're in <rig>/crew/<name>/, it will attach to
   that workspace automatically.
 
+Rig Hooks:
+  Executables in <rig>/.runtime/hooks/ run in the workspace with GT_RIG,
+  GT_CREW, GT_ROOT, and GT_SESSION set. A failing hook only warns.
+    crew-post-create  after a new session is created, before the agent
+                      starts (e.g., install dependencies)
+    crew-pre-attach   before the session is handed to you, unless
+                      --detached (e.g., start a dev server in the background)
+
 Examples:
   gt crew at dave                 # Attach to dave's session
   gt crew at                      # Auto-detect from cwd
   gt crew at dave --detached      # Start session without attaching
   gt crew at dave --no-tmux       # Just print path`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runCrewAt,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCrewAt,
 }
 
 var crewRemoveCmd = &cobra.Command{
-	Use:   "remove <name...>",
+	Use:   "remove <name...> | --all",
 	Short: "Remove crew workspace(s)",
 	Long: `Remove one or more crew workspaces from the rig.
 
@@ -132,6 +170,9 @@ The agent bead is CLOSED by default (preserves CV history). Use --purge
 to DELETE the agent bead entirely (for accidental/test crew that should
 leave no trace in the ledger).
 
+Multiple workspaces are removed concurrently (see --parallel), followed by
+a per-worker summary table. --all removes every crew workspace in the rig.
+
 --purge also:
   - Deletes the agent bead (not just closes it)
   - Unassigns any beads assigned to this crew member
@@ -143,24 +184,33 @@ Examples:
   gt crew remove dave emma fred             # Remove multiple
   gt crew remove beads/grip beads/fang      # Remove from specific rig
   gt crew remove dave --force               # Force remove (closes bead)
-  gt crew remove test-crew --purge          # Obliterate (deletes bead)`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runCrewRemove,
+  gt crew remove test-crew --purge          # Obliterate (deletes bead)
+  gt crew remove --all --rig beads --force  # Tear down the whole squad`,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCrewRemove,
 }
 
 var crewRefreshCmd = &cobra.Command{
-	Use:   "refresh <name>",
+	Use:   "refresh <name...> | --all",
 	Short: "Context cycling with mail-to-self handoff",
-	Long: `Cycle a crew workspace session with handoff.
+	Long: `Cycle crew workspace sessions with handoff.
 
 Sends a handoff mail to the workspace's own inbox, then restarts the session.
 The new session reads the handoff mail and resumes work.
 
+Every handoff is kept in the worker's handoff log (see 'gt crew handoffs');
+--from-handoff replays a logged handoff instead of writing a new one.
+
+Multiple workers are refreshed concurrently (see --parallel).
+
 Examples:
   gt crew refresh dave                           # Refresh with auto-generated handoff
-  gt crew refresh dave -m "Working on gt-123"    # Add custom message`,
-	Args: cobra.ExactArgs(1),
-	RunE: runCrewRefresh,
+  gt crew refresh dave -m "Working on gt-123"    # Add custom message
+  gt crew refresh dave --from-handoff 3          # Replay handoff #3
+  gt crew refresh dave emma                      # Refresh several workers
+  gt crew refresh --all                          # Refresh every worker in the rig`,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCrewRefresh,
 }
 
 var crewStatusCmd = &cobra.Command{
@@ -171,10 +221,15 @@ var crewStatusCmd = &cobra.Command{
 Displays session state, git status, branch info, and mail inbox status.
 If no name given, shows status for all crew workers.
 
+With --watch, shows a live view that refreshes every --interval with each
+worker's session liveness, uncommitted files, unread mail, and the last
+line of output in its session. Press r to refresh now, q to quit.
+
 Examples:
   gt crew status                  # Status of all crew workers
   gt crew status dave             # Status of specific worker
-  gt crew status --json           # JSON output`,
+  gt crew status --json           # JSON output
+  gt crew status --watch          # Live view, refreshed every 3s`,
 	RunE: runCrewStatus,
 }
 
@@ -214,22 +269,79 @@ Examples:
 		}
 		return nil
 	},
-	RunE: runCrewRestart,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCrewRestart,
 }
 
 var crewRenameCmd = &cobra.Command{
 	Use:   "rename <old-name> <new-name>",
 	Short: "Rename a crew workspace",
-	Long: `Rename a crew workspace.
+	Long: `Rename a crew workspace, keeping its local state and mail.
 
-Kills any running session, renames the directory, and updates state.
-The new session will use the new name (gt-<rig>-crew-<new-name>).
+Renames the directory and updates state, renames a crew/<old-name> feature
+branch to crew/<new-name>, and moves the worker's mail (read and unread) to
+the new address. A running session is renamed in place
+(gt-<rig>-crew-<new-name>); restart it for the agent to pick up its new
+identity.
 
 Examples:
   gt crew rename dave david       # Rename dave to david
   gt crew rename madmax max       # Rename madmax to max`,
-	Args: cobra.ExactArgs(2),
-	RunE: runCrewRename,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCrewRename,
+}
+
+var crewCloneCmd = &cobra.Command{
+	Use:   "clone <source> <dest>",
+	Short: "Create a crew workspace from an existing worker's state",
+	Long: `Create a new crew workspace that starts where an existing worker is.
+
+Copies into the new workspace:
+  - the source's branch, including commits not yet pushed
+    (a crew/<source> branch becomes crew/<dest>)
+  - uncommitted changes and untracked files
+  - the source's mail, read and unread (the new worker gets its own copy)
+  - CLAUDE.md, CLAUDE.local.md, and .claude/settings.local.json
+
+The source workspace is left untouched. Useful for splitting one worker's
+large task across two identities.
+
+Examples:
+  gt crew clone dave dave2            # Fork dave's state into dave2
+  gt crew clone beads/emma fred       # Source in a specific rig`,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCrewClone,
+}
+
+var crewMoveCmd = &cobra.Command{
+	Use:   "move <name> --to-rig <rig>",
+	Short: "Move a crew worker to another rig",
+	Long: `Redeploy a crew worker's identity onto another rig's project.
+
+Clones the target rig's repo into a new workspace with the same name and
+carries over:
+  - the workspace mail directory, and beads mail (read and unread)
+  - CLAUDE.md, CLAUDE.local.md, and .claude/settings.local.json (unless the
+    target project has its own)
+  - per-worker launch settings (crew.json) and the handoff log
+
+With --cherry-pick, commits on the worker's branch that aren't on the source
+rig's default branch are replayed onto the new clone (a crew/<name> branch is
+recreated). The repos must be related enough for the commits to apply.
+
+The old workspace is removed unless --keep. Removal is refused if it holds
+uncommitted work or commits that aren't carried over, unless --force.
+A running session must be stopped first.
+
+Examples:
+  gt crew move dave --to-rig beads                 # Fresh start in beads
+  gt crew move gastown/dave --to-rig beads --cherry-pick
+  gt crew move dave --to-rig beads --keep          # Copy, keep the original`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCrewMove,
 }
 
 var crewPristineCmd = &cobra.Command{
@@ -244,7 +356,8 @@ Examples:
   gt crew pristine                # Pristine all crew workers
   gt crew pristine dave           # Pristine specific worker
   gt crew pristine --json         # JSON output`,
-	RunE: runCrewPristine,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCrewPristine,
 }
 
 var crewNextCmd = &cobra.Command{
@@ -287,7 +400,8 @@ Examples:
 		//        2+ args (rig + specific crew names)
 		return nil
 	},
-	RunE: runCrewStart,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCrewStart,
 }
 
 var crewStopCmd = &cobra.Command{
@@ -323,17 +437,25 @@ Examples:
 		//        1+ args (specific crew names)
 		return nil
 	},
-	RunE: runCrewStop,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCrewStop,
 }
 
 func init() {
 	// Add flags
 	crewAddCmd.Flags().StringVar(&crewRig, "rig", "", "Rig to create crew workspace in")
 	crewAddCmd.Flags().BoolVar(&crewBranch, "branch", false, "Create a feature branch (crew/<name>)")
+	crewAddCmd.Flags().BoolVar(&crewJSON, "json", false, "Output results as JSON")
+	crewAddCmd.Flags().IntVarP(&crewParallel, "parallel", "j", defaultCrewParallel, "Maximum workspaces to create at once")
+	crewAddCmd.Flags().StringArrayVar(&crewSparse, "sparse", nil, "Check out only paths matching this sparse-checkout pattern (repeatable; replaces the rig's)")
 
 	crewListCmd.Flags().StringVar(&crewRig, "rig", "", "Filter by rig name")
 	crewListCmd.Flags().BoolVar(&crewListAll, "all", false, "List crew workspaces in all rigs")
 	crewListCmd.Flags().BoolVar(&crewJSON, "json", false, "Output as JSON")
+	crewListCmd.Flags().StringVar(&crewListSort, "sort", "name", "Sort by: name, activity, dirty")
+	crewListCmd.Flags().StringVar(&crewListFormat, "format", "table", "Output format: table, wide, names")
+	crewListCmd.Flags().BoolVar(&crewListDirtyOnly, "dirty-only", false, "Only workspaces with uncommitted changes")
+	crewListCmd.Flags().BoolVar(&crewListRunningOnly, "running-only", false, "Only workers with a running session")
 
 	crewAtCmd.Flags().StringVar(&crewRig, "rig", "", "Rig to use")
 	crewAtCmd.Flags().BoolVar(&crewNoTmux, "no-tmux", false, "Just print directory path")
@@ -345,15 +467,33 @@ func init() {
 	crewRemoveCmd.Flags().StringVar(&crewRig, "rig", "", "Rig to use")
 	crewRemoveCmd.Flags().BoolVar(&crewForce, "force", false, "Force remove (skip safety checks)")
 	crewRemoveCmd.Flags().BoolVar(&crewPurge, "purge", false, "Obliterate: delete agent bead, unassign work, clear mail")
+	crewRemoveCmd.Flags().BoolVar(&crewAll, "all", false, "Remove all crew workspaces in the rig")
+	crewRemoveCmd.Flags().BoolVar(&crewJSON, "json", false, "Output results as JSON")
+	crewRemoveCmd.Flags().IntVarP(&crewParallel, "parallel", "j", defaultCrewParallel, "Maximum workspaces to remove at once")
 
 	crewRefreshCmd.Flags().StringVar(&crewRig, "rig", "", "Rig to use")
 	crewRefreshCmd.Flags().StringVarP(&crewMessage, "message", "m", "", "Custom handoff message")
+	crewRefreshCmd.Flags().BoolVar(&crewAll, "all", false, "Refresh all crew workers in the rig")
+	crewRefreshCmd.Flags().IntVar(&crewFromHandoff, "from-handoff", 0, "Replay a handoff from the worker's handoff log (by number)")
+	crewRefreshCmd.Flags().BoolVar(&crewJSON, "json", false, "Output results as JSON")
+	crewRefreshCmd.Flags().IntVarP(&crewParallel, "parallel", "j", defaultCrewParallel, "Maximum workers to refresh at once")
 
 	crewStatusCmd.Flags().StringVar(&crewRig, "rig", "", "Filter by rig name")
 	crewStatusCmd.Flags().BoolVar(&crewJSON, "json", false, "Output as JSON")
+	crewStatusCmd.Flags().BoolVarP(&crewStatusWatch, "watch", "w", false, "Show a live view that refreshes periodically")
+	crewStatusCmd.Flags().DurationVar(&crewStatusInterval, "interval", 3*time.Second, "Refresh interval for --watch")
 
 	crewRenameCmd.Flags().StringVar(&crewRig, "rig", "", "Rig to use")
 
+	crewCloneCmd.Flags().StringVar(&crewRig, "rig", "", "Rig to use")
+
+	crewMoveCmd.Flags().StringVar(&crewRig, "rig", "", "Rig the worker is in")
+	crewMoveCmd.Flags().StringVar(&crewMoveToRig, "to-rig", "", "Rig to move the worker to (required)")
+	crewMoveCmd.Flags().BoolVar(&crewMoveCherry, "cherry-pick", false, "Replay the worker's branch commits onto the new workspace")
+	crewMoveCmd.Flags().BoolVar(&crewMoveKeep, "keep", false, "Keep the old workspace")
+	crewMoveCmd.Flags().BoolVar(&crewForce, "force", false, "Remove the old workspace even if work would be lost")
+	_ = crewMoveCmd.MarkFlagRequired("to-rig")
+
 	crewPristineCmd.Flags().StringVar(&crewRig, "rig", "", "Filter by rig name")
 	crewPristineCmd.Flags().BoolVar(&crewJSON, "json", false, "Output as JSON")
 
@@ -378,6 +518,8 @@ func init() {
 	crewCmd.AddCommand(crewRefreshCmd)
 	crewCmd.AddCommand(crewStatusCmd)
 	crewCmd.AddCommand(crewRenameCmd)
+	crewCmd.AddCommand(crewCloneCmd)
+	crewCmd.AddCommand(crewMoveCmd)
 	crewCmd.AddCommand(crewPristineCmd)
 	crewCmd.AddCommand(crewRestartCmd)
 
@@ -3,12 +3,14 @@ package cmd
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/crew"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -49,7 +51,7 @@ func runCrewAdd(cmd *cobra.Command, args []string) error {
 	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
 	r, err := rigMgr.GetRig(baseRig)
 	if err != nil {
-		return fmt.Errorf("rig '%s' not found", baseRig)
+		return fmt.Errorf("rig '%s': %w", baseRig, err)
 	}
 
 	// Create crew manager
@@ -58,85 +60,139 @@ func runCrewAdd(cmd *cobra.Command, args []string) error {
 
 	bd := beads.New(beads.ResolveBeadsDir(r.Path))
 
-	// Track results
-	var created []string
-	var failed []string
-	var lastWorker *crew.CrewWorker
-
-	// Process each name
-	for _, arg := range args {
-		name := arg
-		rigName := baseRig
-
-		// Parse rig/name format (e.g., "beads/emma" -> rig=beads, name=emma)
-		if parsedRig, crewName, ok := parseRigSlashName(arg); ok {
-			// For rig/name format, use that rig (but warn if different from base)
-			if parsedRig != baseRig {
-				style.PrintWarning("%s: different rig '%s' ignored (use --rig to change)", arg, parsedRig)
-			}
-			name = crewName
+	// Rig prefixes in names must match the base rig; --rig selects a rig
+	targets := parseCrewTargets(args)
+	for i, t := range targets {
+		if t.Name != t.Arg && t.Rig != baseRig {
+			style.PrintWarning("%s: different rig '%s' ignored (use --rig to change)", t.Arg, t.Rig)
 		}
+		targets[i].Rig = r.Name
+	}
 
-		// Create crew workspace
-		fmt.Printf("Creating crew workspace %s in %s...\n", name, rigName)
+	if !crewJSON {
+		fmt.Printf("Creating %d crew workspace(s) in %s...\n\n", len(targets), r.Name)
+	}
+	results := runCrewBulk(targets, crewParallel, func(t crewTarget) crewOpResult {
+		return addCrewWorker(townRoot, crewMgr, bd, t)
+	})
+	err = reportCrewBulk("created", results)
 
-		worker, err := crewMgr.Add(name, crewBranch)
-		if err != nil {
-			if err == crew.ErrCrewExists {
-				style.PrintWarning("crew workspace '%s' already exists, skipping", name)
-				failed = append(failed, name+" (exists)")
-				continue
-			}
-			style.PrintWarning("creating crew workspace '%s': %v", name, err)
-			failed = append(failed, name)
-			continue
+	if !crewJSON && len(results) == 1 && results[0].Status == crewOpOK {
+		fmt.Printf("\n%s\n", style.Dim.Render("Start working with: cd "+results[0].Detail))
+	}
+	return err
+}
+
+// addCrewWorker creates one crew workspace and its agent bead.
+func addCrewWorker(townRoot string, crewMgr *crew.Manager, bd *beads.Beads, t crewTarget) crewOpResult {
+	address := t.Rig + "/" + t.Name
+	worker, err := crewMgr.AddWithOptions(t.Name, crew.AddOptions{CreateBranch: crewBranch, Sparse: crewSparse})
+	if err != nil {
+		if err == crew.ErrCrewExists {
+			return crewOpResult{Worker: address, Status: crewOpSkipped, Detail: "already exists"}
 		}
+		return crewOpFailure(address, err)
+	}
+
+	res := crewOpResult{Worker: address, Status: crewOpOK, Detail: worker.ClonePath}
+	if crewBranch {
+		res.Notes = append(res.Notes, "branch: "+worker.Branch)
+	}
+	for _, w := range worker.Warnings {
+		res.Notes = append(res.Notes, "warning: "+w)
+	}
+
+	if note := ensureCrewAgentBead(townRoot, bd, t.Rig, t.Name); note != "" {
+		res.Notes = append(res.Notes, note)
+	}
+	return res
+}
+
+// ensureCrewAgentBead creates the agent bead for a crew worker if missing.
+// Returns a note describing what happened, or "" if the bead already existed.
+func ensureCrewAgentBead(townRoot string, bd *beads.Beads, rigName, name string) string {
+	prefix := beads.GetPrefixForRig(townRoot, rigName)
+	crewID := beads.CrewBeadIDWithPrefix(prefix, rigName, name)
+	if _, err := bd.Show(crewID); err == nil {
+		return ""
+	}
+	fields := &beads.AgentFields{
+		RoleType:   "crew",
+		Rig:        rigName,
+		AgentState: "idle",
+	}
+	desc := fmt.Sprintf("Crew worker %s in %s - human-managed persistent workspace.", name, rigName)
+	if _, err := bd.CreateAgentBead(crewID, desc, fields); err != nil {
+		return fmt.Sprintf("could not create agent bead: %v", err)
+	}
+	return "agent bead: " + crewID
+}
 
-		fmt.Printf("%s Created crew workspace: %s/%s\n",
-			style.Bold.Render("✓"), rigName, name)
-		fmt.Printf("  Path: %s\n", worker.ClonePath)
-		fmt.Printf("  Branch: %s\n", worker.Branch)
-
-		// Create agent bead for the crew worker
-		prefix := beads.GetPrefixForRig(townRoot, rigName)
-		crewID := beads.CrewBeadIDWithPrefix(prefix, rigName, name)
-		if _, err := bd.Show(crewID); err != nil {
-			// Agent bead doesn't exist, create it
-			fields := &beads.AgentFields{
-				RoleType:   "crew",
-				Rig:        rigName,
-				AgentState: "idle",
-			}
-			desc := fmt.Sprintf("Crew worker %s in %s - human-managed persistent workspace.", name, rigName)
-			if _, err := bd.CreateAgentBead(crewID, desc, fields); err != nil {
-				style.PrintWarning("could not create agent bead for %s: %v", name, err)
-			} else {
-				fmt.Printf("  Agent bead: %s\n", crewID)
-			}
+func runCrewClone(cmd *cobra.Command, args []string) error {
+	srcName, destName := args[0], args[1]
+	// Parse rig/name format for the source (e.g., "beads/emma" -> rig=beads, name=emma)
+	if rigName, crewName, ok := parseRigSlashName(srcName); ok {
+		if crewRig == "" {
+			crewRig = rigName
 		}
+		srcName = crewName
+	}
 
-		created = append(created, name)
-		lastWorker = worker
-		fmt.Println()
+	crewMgr, r, err := getCrewManager(crewRig)
+	if err != nil {
+		return err
 	}
 
-	// Summary
-	if len(created) > 0 {
-		fmt.Printf("%s Created %d crew workspace(s): %v\n",
-			style.Bold.Render("✓"), len(created), created)
-		if lastWorker != nil && len(created) == 1 {
-			fmt.Printf("\n%s\n", style.Dim.Render("Start working with: cd "+lastWorker.ClonePath))
+	fmt.Printf("Cloning crew workspace %s/%s → %s/%s...\n", r.Name, srcName, r.Name, destName)
+	res, err := crewMgr.Clone(srcName, destName)
+	if err != nil {
+		if err == crew.ErrCrewNotFound {
+			return fmt.Errorf("crew workspace '%s': %w", srcName, err)
 		}
+		if err == crew.ErrCrewExists {
+			return fmt.Errorf("crew workspace '%s': %w", destName, err)
+		}
+		return fmt.Errorf("cloning crew workspace: %w", err)
 	}
-	if len(failed) > 0 {
-		fmt.Printf("%s Failed to create %d workspace(s): %v\n",
-			style.Warning.Render("!"), len(failed), failed)
+
+	townRoot, _ := workspace.Find(r.Path)
+	if townRoot == "" {
+		townRoot = filepath.Dir(r.Path)
 	}
+	bd := beads.New(beads.ResolveBeadsDir(r.Path))
+	note := ensureCrewAgentBead(townRoot, bd, r.Name, destName)
 
-	// Return error if all failed
-	if len(created) == 0 && len(failed) > 0 {
-		return fmt.Errorf("failed to create any crew workspaces")
+	for _, w := range res.Worker.Warnings {
+		style.PrintWarning("%s", w)
+	}
+	fmt.Printf("%s Created crew workspace: %s/%s\n", style.Bold.Render("✓"), r.Name, destName)
+	fmt.Printf("  Path: %s\n", res.Worker.ClonePath)
+	fmt.Printf("  Branch: %s @ %s\n", res.Worker.Branch, res.Head[:min(len(res.Head), 8)])
+	if res.HasPatch || len(res.Untracked) > 0 {
+		fmt.Printf("  Uncommitted work: changes applied: %v, untracked files: %d\n", res.HasPatch, len(res.Untracked))
 	}
 
+	// Beads mail is addressed by name; give the clone its own copy.
+	// (Legacy JSONL inboxes live in the workspace and were copied with it.)
+	srcAddress := fmt.Sprintf("%s/crew/%s", r.Name, srcName)
+	destAddress := fmt.Sprintf("%s/crew/%s", r.Name, destName)
+	if mailbox, err := mail.NewRouterWithTownRoot(r.Path, townRoot).GetMailbox(srcAddress); err == nil {
+		copied, err := mailbox.CopyTo(destAddress)
+		if err != nil {
+			style.PrintWarning("copied %d message(s) before failing: %v", copied, err)
+		} else if copied > 0 {
+			fmt.Printf("  Mail: copied %d message(s) to %s\n", copied, destAddress)
+		}
+	}
+	if len(res.Mail) > 0 {
+		fmt.Printf("  Workspace mail: %s\n", strings.Join(res.Mail, ", "))
+	}
+	if len(res.Local) > 0 {
+		fmt.Printf("  Customizations: %s\n", strings.Join(res.Local, ", "))
+	}
+	if note != "" {
+		fmt.Printf("  %s\n", note)
+	}
+	fmt.Printf("\n%s\n", style.Dim.Render("Start it with: gt crew start "+destName))
 	return nil
 }
@@ -73,7 +73,7 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 	worker, err := crewMgr.Get(name)
 	if err != nil {
 		if err == crew.ErrCrewNotFound {
-			return fmt.Errorf("crew workspace '%s' not found", name)
+			return fmt.Errorf("crew workspace '%s': %w", name, err)
 		}
 		return fmt.Errorf("getting crew worker: %w", err)
 	}
@@ -142,8 +142,8 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 
 			// Outside tmux: attach unless --detached flag is set
 			if crewDetached {
-				fmt.Printf("Existing session: '%s'. Run 'tmux attach -t %s' to attach.\n",
-					existingSession, existingSession)
+				fmt.Printf("Existing session: '%s'. Run '%s' to attach.\n",
+					existingSession, tmux.AttachHint(existingSession))
 				return nil
 			}
 
@@ -152,6 +152,18 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Use centralized AgentEnv for consistency across all role startup paths,
+	// plus any extra env the rig declares in rigs.json
+	envVars := config.AgentEnv(config.AgentEnvConfig{
+		Role:             "crew",
+		Rig:              r.Name,
+		AgentName:        name,
+		TownRoot:         townRoot,
+		RuntimeConfigDir: claudeConfigDir,
+		BeadsNoDaemon:    true,
+	})
+	rigEnv := config.RigEnv(r.Env, envVars)
+
 	if !hasSession {
 		// Create new session
 		if err := t.NewSession(sessionID, worker.ClonePath); err != nil {
@@ -159,16 +171,7 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 		}
 
 		// Set environment (non-fatal: session works without these)
-		// Use centralized AgentEnv for consistency across all role startup paths
-		envVars := config.AgentEnv(config.AgentEnvConfig{
-			Role:             "crew",
-			Rig:              r.Name,
-			AgentName:        name,
-			TownRoot:         townRoot,
-			RuntimeConfigDir: claudeConfigDir,
-			BeadsNoDaemon:    true,
-		})
-		for k, v := range envVars {
+		for k, v := range config.MergeEnv(rigEnv, envVars) {
 			_ = t.SetEnvironment(sessionID, k, v)
 		}
 
@@ -177,6 +180,11 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 		theme := getThemeForRig(r.Name)
 		_ = t.ConfigureGasTownSession(sessionID, theme, r.Name, name, "crew")
 
+		// Rig hook: prepare the workspace before the agent starts (non-fatal)
+		if _, err := crewMgr.RunHook(crew.HookPostCreate, name); err != nil {
+			style.PrintWarning("%v", err)
+		}
+
 		// Wait for shell to be ready after session creation
 		if err := t.WaitForShellReady(sessionID, constants.ShellReadyTimeout); err != nil {
 			return fmt.Errorf("waiting for shell: %w", err)
@@ -209,6 +217,7 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 		if runtimeConfig.Session != nil && runtimeConfig.Session.ConfigDirEnv != "" && claudeConfigDir != "" {
 			startupCmd = config.PrependEnv(startupCmd, map[string]string{runtimeConfig.Session.ConfigDirEnv: claudeConfigDir})
 		}
+		startupCmd = config.PrependEnv(startupCmd, rigEnv)
 		if err := t.RespawnPane(paneID, startupCmd); err != nil {
 			return fmt.Errorf("starting runtime: %w", err)
 		}
@@ -252,6 +261,7 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 			if runtimeConfig.Session != nil && runtimeConfig.Session.ConfigDirEnv != "" && claudeConfigDir != "" {
 				startupCmd = config.PrependEnv(startupCmd, map[string]string{runtimeConfig.Session.ConfigDirEnv: claudeConfigDir})
 			}
+			startupCmd = config.PrependEnv(startupCmd, rigEnv)
 			if err := t.RespawnPane(paneID, startupCmd); err != nil {
 				return fmt.Errorf("restarting runtime: %w", err)
 			}
@@ -283,6 +293,13 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 		return execAgent(agentCfg, beacon)
 	}
 
+	// Rig hook: runs each time the session is handed to the user (non-fatal)
+	if !crewDetached {
+		if _, err := crewMgr.RunHook(crew.HookPreAttach, name); err != nil {
+			style.PrintWarning("%v", err)
+		}
+	}
+
 	// If inside tmux (but different session), don't switch - just inform user
 	insideTmux := tmux.IsInsideTmux()
 	if debug {
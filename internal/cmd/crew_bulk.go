@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// defaultCrewParallel bounds concurrent crew operations. Clones and bd calls
+// are I/O heavy; a handful at a time keeps the machine responsive.
+const defaultCrewParallel = 4
+
+// Bulk crew operation statuses.
+const (
+	crewOpOK      = "ok"
+	crewOpSkipped = "skipped"
+	crewOpFailed  = "failed"
+)
+
+// crewTarget is one worker named on a bulk crew command line.
+type crewTarget struct {
+	Arg  string // as given on the command line
+	Rig  string // rig override ("" = --rig or cwd)
+	Name string
+}
+
+// crewOpResult is the outcome of a bulk crew operation on one worker.
+type crewOpResult struct {
+	Worker string   `json:"worker"` // rig/name, or the argument if the rig was unresolved
+	Status string   `json:"status"` // ok, skipped, or failed
+	Detail string   `json:"detail,omitempty"`
+	Notes  []string `json:"notes,omitempty"` // non-fatal warnings and side effects
+	Error  string   `json:"error,omitempty"`
+
+	err error // the failure, for the exit code
+}
+
+func crewOpFailure(worker string, err error) crewOpResult {
+	return crewOpResult{Worker: worker, Status: crewOpFailed, Error: err.Error(), err: err}
+}
+
+// crewBulkCause returns the failure that decides the exit code of a bulk
+// operation: the first failure, if every failure maps to the same exit
+// code; otherwise nil (exit 1).
+func crewBulkCause(results []crewOpResult) error {
+	var cause error
+	for _, res := range results {
+		if res.Status != crewOpFailed {
+			continue
+		}
+		if res.err == nil {
+			return nil
+		}
+		if cause == nil {
+			cause = res.err
+		} else if ExitCodeFor(res.err) != ExitCodeFor(cause) {
+			return nil
+		}
+	}
+	return cause
+}
+
+// parseCrewTargets splits rig/name arguments. A rig in the argument is used
+// only when --rig isn't given.
+func parseCrewTargets(args []string) []crewTarget {
+	targets := make([]crewTarget, 0, len(args))
+	for _, arg := range args {
+		t := crewTarget{Arg: arg, Rig: crewRig, Name: arg}
+		if rigName, name, ok := parseRigSlashName(arg); ok {
+			if t.Rig == "" {
+				t.Rig = rigName
+			}
+			t.Name = name
+		}
+		targets = append(targets, t)
+	}
+	return targets
+}
+
+// allCrewTargets returns every crew worker in the rig from --rig or cwd.
+func allCrewTargets() ([]crewTarget, error) {
+	crewMgr, r, err := getCrewManager(crewRig)
+	if err != nil {
+		return nil, err
+	}
+	workers, err := crewMgr.List()
+	if err != nil {
+		return nil, fmt.Errorf("listing crew: %w", err)
+	}
+	targets := make([]crewTarget, 0, len(workers))
+	for _, w := range workers {
+		targets = append(targets, crewTarget{Arg: w.Name, Rig: r.Name, Name: w.Name})
+	}
+	return targets, nil
+}
+
+// resolveCrewTargets returns the workers named in args, or every worker in
+// the rig when --all is set.
+func resolveCrewTargets(args []string) ([]crewTarget, error) {
+	if crewAll {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("cannot combine worker names with --all")
+		}
+		return allCrewTargets()
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("requires at least one crew worker name (or --all)")
+	}
+	return parseCrewTargets(args), nil
+}
+
+// runCrewBulk applies op to every target using at most parallel workers.
+// Results are returned in target order.
+func runCrewBulk(targets []crewTarget, parallel int, op func(crewTarget) crewOpResult) []crewOpResult {
+	if parallel < 1 {
+		parallel = 1
+	}
+	results := make([]crewOpResult, len(targets))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, t crewTarget) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = op(t)
+		}(i, t)
+	}
+	wg.Wait()
+	return results
+}
+
+// reportCrewBulk prints results as a table (or JSON with --json) and returns
+// an error if any operation failed. verb labels successes (e.g., "created").
+func reportCrewBulk(verb string, results []crewOpResult) error {
+	failed := 0
+	for _, res := range results {
+		if res.Status == crewOpFailed {
+			failed++
+		}
+	}
+
+	if crewJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return err
+		}
+		if failed > 0 {
+			if cause := crewBulkCause(results); cause != nil {
+				return NewSilentExit(ExitCodeFor(cause))
+			}
+			return NewSilentExit(ExitError)
+		}
+		return nil
+	}
+
+	width := len("WORKER")
+	for _, res := range results {
+		width = max(width, len(res.Worker))
+	}
+	fmt.Printf("%-*s  %-8s  %s\n", width, "WORKER", "STATUS", "DETAIL")
+	counts := make(map[string]int)
+	for _, res := range results {
+		counts[res.Status]++
+		var status, detail string
+		switch res.Status {
+		case crewOpOK:
+			status = style.Success.Render(fmt.Sprintf("%-8s", verb))
+			detail = res.Detail
+		case crewOpSkipped:
+			status = style.Dim.Render(fmt.Sprintf("%-8s", "skipped"))
+			detail = style.Dim.Render(res.Detail)
+		default:
+			status = style.Error.Render(fmt.Sprintf("%-8s", "failed"))
+			detail = res.Error
+		}
+		fmt.Printf("%-*s  %s  %s\n", width, res.Worker, status, detail)
+		for _, note := range res.Notes {
+			fmt.Printf("%-*s            %s\n", width, "", style.Dim.Render(note))
+		}
+	}
+
+	parts := []string{fmt.Sprintf("%d %s", counts[crewOpOK], verb)}
+	if n := counts[crewOpSkipped]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", n))
+	}
+	if failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", failed))
+	}
+	fmt.Printf("\n%s\n", strings.Join(parts, ", "))
+
+	if failed > 0 {
+		err := fmt.Errorf("%d of %d crew operation(s) failed", failed, len(results))
+		if cause := crewBulkCause(results); cause != nil {
+			return withExitClass(err, cause)
+		}
+		return err
+	}
+	return nil
+}
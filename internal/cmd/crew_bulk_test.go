@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunCrewBulkBoundsConcurrencyAndKeepsOrder(t *testing.T) {
+	targets := parseCrewTargets([]string{"dave", "beads/emma", "fred", "gus", "hal"})
+	if targets[1].Rig != "beads" || targets[1].Name != "emma" {
+		t.Fatalf("parseCrewTargets rig/name = %+v", targets[1])
+	}
+
+	var running, peak int32
+	results := runCrewBulk(targets, 2, func(tg crewTarget) crewOpResult {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		if tg.Name == "fred" {
+			return crewOpFailure(tg.Name, fmt.Errorf("boom"))
+		}
+		return crewOpResult{Worker: tg.Name, Status: crewOpOK}
+	})
+
+	if peak > 2 {
+		t.Errorf("peak concurrency = %d, want <= 2", peak)
+	}
+	for i, want := range []string{"dave", "emma", "fred", "gus", "hal"} {
+		if results[i].Worker != want {
+			t.Errorf("results[%d].Worker = %q, want %q", i, results[i].Worker, want)
+		}
+	}
+	if results[2].Status != crewOpFailed || results[2].Error != "boom" {
+		t.Errorf("results[2] = %+v, want failed with boom", results[2])
+	}
+}
+
+func TestResolveCrewTargetsRequiresNamesOrAll(t *testing.T) {
+	oldAll := crewAll
+	t.Cleanup(func() { crewAll = oldAll })
+
+	crewAll = false
+	if _, err := resolveCrewTargets(nil); err == nil {
+		t.Error("expected error with no names and no --all")
+	}
+	crewAll = true
+	if _, err := resolveCrewTargets([]string{"dave"}); err == nil {
+		t.Error("expected error combining names with --all")
+	}
+}
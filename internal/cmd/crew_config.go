@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	crewConfigModel          string
+	crewConfigMaxTurns       int
+	crewConfigPermissionMode string
+	crewConfigAgent          string
+	crewConfigAllowedTools   []string
+	crewConfigDisallowed     []string
+	crewConfigArgs           []string
+	crewConfigReset          bool
+)
+
+var crewConfigCmd = &cobra.Command{
+	Use:   "config <name>",
+	Short: "Show or set a crew worker's launch settings",
+	Long: `Show or set per-worker launch settings, stored in crew.json in the
+crew workspace.
+
+Settings layer on top of the agent resolved for the crew role and are used
+by 'gt crew start', 'gt crew at', 'gt crew refresh', and 'gt crew restart':
+  --model             claude --model (e.g., sonnet, opus, haiku)
+  --max-turns         claude --max-turns
+  --permission-mode   claude --permission-mode (default, acceptEdits, plan,
+                      bypassPermissions); replaces --dangerously-skip-permissions
+  --allowed-tools     claude --allowedTools (repeatable)
+  --disallowed-tools  claude --disallowedTools (repeatable)
+  --agent             agent alias to run (an explicit --agent flag still wins)
+  --arg               extra runtime argument, appended verbatim (repeatable)
+
+With no flags, prints the settings and the resulting launch command.
+Changes apply the next time the session starts.
+
+Examples:
+  gt crew config docs-writer --model haiku --max-turns 40
+  gt crew config refactorer --model opus --permission-mode acceptEdits
+  gt crew config dave                 # Show settings
+  gt crew config dave --reset         # Back to rig defaults`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCrewConfig,
+}
+
+func init() {
+	crewConfigCmd.Flags().StringVar(&crewRig, "rig", "", "Rig to use")
+	crewConfigCmd.Flags().StringVar(&crewConfigModel, "model", "", "Model to run")
+	crewConfigCmd.Flags().IntVar(&crewConfigMaxTurns, "max-turns", 0, "Maximum agent turns (0 = runtime default)")
+	crewConfigCmd.Flags().StringVar(&crewConfigPermissionMode, "permission-mode", "", "Permission mode")
+	crewConfigCmd.Flags().StringVar(&crewConfigAgent, "agent", "", "Agent alias to run")
+	crewConfigCmd.Flags().StringArrayVar(&crewConfigAllowedTools, "allowed-tools", nil, "Allowed tool pattern (repeatable)")
+	crewConfigCmd.Flags().StringArrayVar(&crewConfigDisallowed, "disallowed-tools", nil, "Disallowed tool pattern (repeatable)")
+	crewConfigCmd.Flags().StringArrayVar(&crewConfigArgs, "arg", nil, "Extra runtime argument (repeatable)")
+	crewConfigCmd.Flags().BoolVar(&crewConfigReset, "reset", false, "Remove crew.json (use rig defaults)")
+	crewConfigCmd.Flags().BoolVar(&crewJSON, "json", false, "Output settings as JSON")
+
+	crewCmd.AddCommand(crewConfigCmd)
+}
+
+func runCrewConfig(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if rigName, crewName, ok := parseRigSlashName(name); ok {
+		if crewRig == "" {
+			crewRig = rigName
+		}
+		name = crewName
+	}
+
+	crewMgr, r, err := getCrewManager(crewRig)
+	if err != nil {
+		return err
+	}
+	worker, err := crewMgr.Get(name)
+	if err != nil {
+		return fmt.Errorf("crew workspace '%s': %w", name, err)
+	}
+	path := config.CrewWorkerConfigPath(worker.ClonePath)
+
+	if crewConfigReset {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing %s: %w", path, err)
+		}
+		fmt.Printf("%s %s/%s now uses rig defaults\n", style.Success.Render("✓"), r.Name, name)
+		return nil
+	}
+
+	cfg, err := config.LoadCrewWorkerConfig(path)
+	if errors.Is(err, config.ErrNotFound) {
+		cfg = &config.CrewWorkerConfig{}
+	} else if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	changed := false
+	if flags.Changed("model") {
+		cfg.Model, changed = crewConfigModel, true
+	}
+	if flags.Changed("max-turns") {
+		cfg.MaxTurns, changed = crewConfigMaxTurns, true
+	}
+	if flags.Changed("permission-mode") {
+		cfg.PermissionMode, changed = crewConfigPermissionMode, true
+	}
+	if flags.Changed("agent") {
+		cfg.Agent, changed = crewConfigAgent, true
+	}
+	if flags.Changed("allowed-tools") {
+		cfg.AllowedTools, changed = crewConfigAllowedTools, true
+	}
+	if flags.Changed("disallowed-tools") {
+		cfg.DisallowedTools, changed = crewConfigDisallowed, true
+	}
+	if flags.Changed("arg") {
+		cfg.Args, changed = crewConfigArgs, true
+	}
+
+	if changed {
+		cfg.Type = "crew"
+		cfg.Version = config.CurrentCrewWorkerConfigVersion
+		if err := config.SaveCrewWorkerConfig(path, cfg); err != nil {
+			return err
+		}
+		// Surface provider mismatches now rather than at next start
+		if _, err := config.BuildCrewStartupCommandWithAgentOverride(r.Name, name, r.Path, "", ""); err != nil {
+			style.PrintWarning("%v", err)
+		}
+		fmt.Printf("%s Updated %s\n", style.Success.Render("✓"), path)
+	}
+
+	if crewJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(cfg)
+	}
+
+	fmt.Printf("%s %s/%s\n", style.Bold.Render("Crew settings:"), r.Name, name)
+	printCrewConfigField("agent", cfg.Agent)
+	printCrewConfigField("model", cfg.Model)
+	if cfg.MaxTurns > 0 {
+		printCrewConfigField("max_turns", fmt.Sprint(cfg.MaxTurns))
+	} else {
+		printCrewConfigField("max_turns", "")
+	}
+	printCrewConfigField("permission_mode", cfg.PermissionMode)
+	printCrewConfigField("allowed_tools", fmt.Sprint(cfg.AllowedTools))
+	printCrewConfigField("disallowed_tools", fmt.Sprint(cfg.DisallowedTools))
+	printCrewConfigField("args", fmt.Sprint(cfg.Args))
+
+	launch, err := config.BuildCrewStartupCommandWithAgentOverride(r.Name, name, r.Path, "", "")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n%s\n  %s\n", style.Bold.Render("Launch command:"), style.Dim.Render(launch))
+	return nil
+}
+
+func printCrewConfigField(key, value string) {
+	if value == "" || value == "[]" {
+		value = style.Dim.Render("(default)")
+	}
+	fmt.Printf("  %-17s %s\n", key+":", value)
+}
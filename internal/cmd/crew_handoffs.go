@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var crewHandoffsShow int
+
+var crewHandoffsCmd = &cobra.Command{
+	Use:   "handoffs <name>",
+	Short: "List a crew worker's past refresh handoffs",
+	Long: `List the handoffs sent by 'gt crew refresh' for a crew worker.
+
+Handoff mail disappears once read; the handoff log keeps every message so it
+can be reviewed or replayed with 'gt crew refresh <name> --from-handoff <n>'.
+
+Examples:
+  gt crew handoffs dave            # List handoffs, newest first
+  gt crew handoffs dave --show 3   # Print handoff #3 in full
+  gt crew handoffs dave --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCrewHandoffs,
+}
+
+func init() {
+	crewHandoffsCmd.Flags().StringVar(&crewRig, "rig", "", "Rig to use")
+	crewHandoffsCmd.Flags().IntVar(&crewHandoffsShow, "show", 0, "Print one handoff in full")
+	crewHandoffsCmd.Flags().BoolVar(&crewJSON, "json", false, "Output as JSON")
+
+	crewCmd.AddCommand(crewHandoffsCmd)
+}
+
+func runCrewHandoffs(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if rigName, crewName, ok := parseRigSlashName(name); ok {
+		if crewRig == "" {
+			crewRig = rigName
+		}
+		name = crewName
+	}
+
+	crewMgr, r, err := getCrewManager(crewRig)
+	if err != nil {
+		return err
+	}
+
+	if crewHandoffsShow > 0 {
+		rec, err := crewMgr.Handoff(name, crewHandoffsShow)
+		if err != nil {
+			return err
+		}
+		if crewJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(rec)
+		}
+		fmt.Printf("%s #%d  %s\n", style.Bold.Render("Handoff"), rec.Seq, rec.Timestamp.Local().Format("2006-01-02 15:04:05"))
+		fmt.Printf("Subject: %s\n", rec.Subject)
+		if rec.ReplayOf > 0 {
+			fmt.Printf("Replay of: #%d\n", rec.ReplayOf)
+		}
+		fmt.Printf("\n%s\n", rec.Message)
+		return nil
+	}
+
+	records, err := crewMgr.Handoffs(name)
+	if err != nil {
+		return fmt.Errorf("crew workspace '%s': %w", name, err)
+	}
+	if crewJSON {
+		if records == nil {
+			fmt.Println("[]")
+			return nil
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	}
+	if len(records) == 0 {
+		fmt.Printf("%s No handoffs logged for %s/crew/%s\n", style.Dim.Render("○"), r.Name, name)
+		return nil
+	}
+
+	fmt.Printf("%s %s/crew/%s\n\n", style.Bold.Render("Handoffs for"), r.Name, name)
+	for i := len(records) - 1; i >= 0; i-- {
+		rec := records[i]
+		summary := strings.SplitN(strings.TrimSpace(rec.Message), "\n", 2)[0]
+		if len(summary) > 70 {
+			summary = summary[:67] + "..."
+		}
+		replay := ""
+		if rec.ReplayOf > 0 {
+			replay = style.Dim.Render(fmt.Sprintf(" (replay of #%d)", rec.ReplayOf))
+		}
+		fmt.Printf("  #%-3d %s  %s%s\n", rec.Seq, style.Dim.Render(formatAge(rec.Timestamp)), summary, replay)
+	}
+	fmt.Printf("\n%s\n", style.Dim.Render("Replay with: gt crew refresh "+name+" --from-handoff <n>"))
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 	"syscall"
 
@@ -14,33 +13,10 @@ import (
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
-// inferRigFromCwd tries to determine the rig from the current directory.
-func inferRigFromCwd(townRoot string) (string, error) {
-	cwd, err := filepath.Abs(".")
-	if err != nil {
-		return "", err
-	}
-
-	// Check if cwd is within a rig
-	rel, err := filepath.Rel(townRoot, cwd)
-	if err != nil {
-		return "", fmt.Errorf("not in workspace")
-	}
-
-	// Normalize and split path - first component is the rig name
-	rel = filepath.ToSlash(rel)
-	parts := strings.Split(rel, "/")
-
-	if len(parts) > 0 && parts[0] != "" && parts[0] != "." {
-		return parts[0], nil
-	}
-
-	return "", fmt.Errorf("could not infer rig from current directory")
-}
-
 // getCrewManager returns a crew manager for the specified or inferred rig.
 func getCrewManager(rigName string) (*crew.Manager, *rig.Rig, error) {
 	// Handle optional rig inference from cwd
@@ -95,11 +71,6 @@ type crewDetection struct {
 // detectCrewFromCwd attempts to detect the crew workspace from the current directory.
 // It looks for the pattern <town>/<rig>/crew/<name>/ in the current path.
 func detectCrewFromCwd() (*crewDetection, error) {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return nil, fmt.Errorf("getting cwd: %w", err)
-	}
-
 	// Find town root
 	townRoot, err := workspace.FindFromCwd()
 	if err != nil {
@@ -109,31 +80,14 @@ func detectCrewFromCwd() (*crewDetection, error) {
 		return nil, fmt.Errorf("not in Gas Town workspace")
 	}
 
-	// Get relative path from town root
-	relPath, err := filepath.Rel(townRoot, cwd)
-	if err != nil {
-		return nil, fmt.Errorf("getting relative path: %w", err)
-	}
-
-	// Normalize and split path
-	relPath = filepath.ToSlash(relPath)
-	parts := strings.Split(relPath, "/")
-
-	// Look for pattern: <rig>/crew/<name>/...
-	// Minimum: rig, crew, name = 3 parts
-	if len(parts) < 3 {
+	ctx, err := inferRigContextFromCwd(townRoot)
+	if err != nil || ctx.Role != RoleCrew {
 		return nil, fmt.Errorf("not inside a crew workspace - specify the crew name or cd into a crew directory (e.g., gastown/crew/max)")
 	}
 
-	rigName := parts[0]
-	if parts[1] != "crew" {
-		return nil, fmt.Errorf("not in a crew workspace (not in crew/ directory)")
-	}
-	crewName := parts[2]
-
 	return &crewDetection{
-		rigName:  rigName,
-		crewName: crewName,
+		rigName:  ctx.Rig,
+		crewName: ctx.Worker,
 	}, nil
 }
 
@@ -197,13 +151,12 @@ func execRuntime(prompt, rigPath, configDir string) error {
 func isInTmuxSession(targetSession string) bool {
 	// TMUX env var format: /tmp/tmux-501/default,12345,0
 	// We need to get the current session name via tmux display-message
-	tmuxEnv := os.Getenv("TMUX")
-	if tmuxEnv == "" {
-		return false // Not in tmux at all
+	if !tmux.IsInsideTmux() {
+		return false // Not in tmux at all, or in a personal tmux server
 	}
 
 	// Get current session name
-	cmd := exec.Command("tmux", "display-message", "-p", "#{session_name}")
+	cmd := tmux.Command("display-message", "-p", "#{session_name}")
 	out, err := cmd.Output()
 	if err != nil {
 		return false
@@ -216,24 +169,21 @@ func isInTmuxSession(targetSession string) bool {
 // attachToTmuxSession attaches to a tmux session.
 // If already inside tmux, uses switch-client instead of attach-session.
 func attachToTmuxSession(sessionID string) error {
-	tmuxPath, err := exec.LookPath("tmux")
-	if err != nil {
+	if _, err := exec.LookPath("tmux"); err != nil {
 		return fmt.Errorf("tmux not found: %w", err)
 	}
 
-	// Check if we're already inside a tmux session
-	var cmd *exec.Cmd
-	if os.Getenv("TMUX") != "" {
+	// Check if we're already inside a Gas Town tmux session
+	if tmux.IsInsideTmux() {
 		// Inside tmux: switch to the target session
-		cmd = exec.Command(tmuxPath, "switch-client", "-t", sessionID)
-	} else {
-		// Outside tmux: attach to the session
-		cmd = exec.Command(tmuxPath, "attach-session", "-t", sessionID)
-	}
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+		cmd := tmux.Command("switch-client", "-t", sessionID)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	}
+	// Outside tmux (or in a personal one): attach to the session
+	return tmux.AttachCommand(sessionID).Run()
 }
 
 // ensureDefaultBranch checks if a git directory is on the default branch.
@@ -317,7 +267,7 @@ func parseCrewSessionName(sessionName string) (rigName, crewName string, ok bool
 // findRigCrewSessions returns all crew sessions for a given rig, sorted alphabetically.
 // Uses tmux list-sessions to find sessions matching gt-<rig>-crew-* pattern.
 func findRigCrewSessions(rigName string) ([]string, error) { //nolint:unparam // error return kept for future use
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}")
+	cmd := tmux.Command("list-sessions", "-F", "#{session_name}")
 	out, err := cmd.Output()
 	if err != nil {
 		// No tmux server or no sessions
@@ -24,219 +24,237 @@ import (
 )
 
 func runCrewRemove(cmd *cobra.Command, args []string) error {
-	var lastErr error
+	targets, err := resolveCrewTargets(args)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		fmt.Println("No crew workspaces to remove")
+		return nil
+	}
 
 	// --purge implies --force
 	forceRemove := crewForce || crewPurge
 
-	for _, arg := range args {
-		name := arg
-		rigOverride := crewRig
+	results := runCrewBulk(targets, crewParallel, func(t crewTarget) crewOpResult {
+		return removeCrewWorker(t, forceRemove)
+	})
+	return reportCrewBulk("removed", results)
+}
 
-		// Parse rig/name format (e.g., "beads/emma" -> rig=beads, name=emma)
-		if rig, crewName, ok := parseRigSlashName(name); ok {
-			if rigOverride == "" {
-				rigOverride = rig
-			}
-			name = crewName
-		}
+// removeCrewWorker kills a crew worker's session, removes its workspace, and
+// closes (or with --purge, deletes) its agent bead.
+func removeCrewWorker(target crewTarget, forceRemove bool) crewOpResult {
+	name := target.Name
+	crewMgr, r, err := getCrewManager(target.Rig)
+	if err != nil {
+		return crewOpFailure(target.Arg, err)
+	}
+	address := r.Name + "/" + name
+	res := crewOpResult{Worker: address, Status: crewOpOK}
 
-		crewMgr, r, err := getCrewManager(rigOverride)
-		if err != nil {
-			fmt.Printf("Error removing %s: %v\n", arg, err)
-			lastErr = err
-			continue
-		}
+	// Check for running session (unless forced)
+	t := tmux.NewTmux()
+	sessionID := crewSessionName(r.Name, name)
+	hasSession, _ := t.HasSession(sessionID)
+	if hasSession && !forceRemove {
+		return crewOpFailure(address, fmt.Errorf("session '%s' is running (use --force to kill and remove)", sessionID))
+	}
 
-		// Check for running session (unless forced)
-		if !forceRemove {
-			t := tmux.NewTmux()
-			sessionID := crewSessionName(r.Name, name)
-			hasSession, _ := t.HasSession(sessionID)
-			if hasSession {
-				fmt.Printf("Error removing %s: session '%s' is running (use --force to kill and remove)\n", arg, sessionID)
-				lastErr = fmt.Errorf("session running")
-				continue
-			}
+	// Kill session if it exists (with proper process cleanup to avoid orphans)
+	if hasSession {
+		if err := t.KillSessionWithProcesses(sessionID); err != nil {
+			return crewOpFailure(address, fmt.Errorf("killing session: %w", err))
 		}
+		res.Notes = append(res.Notes, "killed session "+sessionID)
+	}
 
-		// Kill session if it exists (with proper process cleanup to avoid orphans)
-		t := tmux.NewTmux()
-		sessionID := crewSessionName(r.Name, name)
-		if hasSession, _ := t.HasSession(sessionID); hasSession {
-			if err := t.KillSessionWithProcesses(sessionID); err != nil {
-				fmt.Printf("Error killing session for %s: %v\n", arg, err)
-				lastErr = err
-				continue
-			}
-			fmt.Printf("Killed session %s\n", sessionID)
-		}
+	// Determine workspace path
+	crewPath := filepath.Join(r.Path, "crew", name)
 
-		// Determine workspace path
-		crewPath := filepath.Join(r.Path, "crew", name)
+	// Check if this is a worktree (has .git file) vs regular clone (has .git directory)
+	isWorktree := false
+	gitPath := filepath.Join(crewPath, ".git")
+	if info, err := os.Stat(gitPath); err == nil && !info.IsDir() {
+		isWorktree = true
+	}
 
-		// Check if this is a worktree (has .git file) vs regular clone (has .git directory)
-		isWorktree := false
-		gitPath := filepath.Join(crewPath, ".git")
-		if info, err := os.Stat(gitPath); err == nil && !info.IsDir() {
-			isWorktree = true
+	// Remove the workspace
+	if isWorktree {
+		// For worktrees, use git worktree remove
+		mayorRigPath := constants.RigMayorPath(r.Path)
+		removeArgs := []string{"worktree", "remove", crewPath}
+		if forceRemove {
+			removeArgs = []string{"worktree", "remove", "--force", crewPath}
 		}
-
-		// Remove the workspace
-		if isWorktree {
-			// For worktrees, use git worktree remove
-			mayorRigPath := constants.RigMayorPath(r.Path)
-			removeArgs := []string{"worktree", "remove", crewPath}
-			if forceRemove {
-				removeArgs = []string{"worktree", "remove", "--force", crewPath}
-			}
-			removeCmd := exec.Command("git", removeArgs...)
-			removeCmd.Dir = mayorRigPath
-			if output, err := removeCmd.CombinedOutput(); err != nil {
-				fmt.Printf("Error removing worktree %s: %v\n%s", arg, err, string(output))
-				lastErr = err
-				continue
-			}
-			fmt.Printf("%s Removed crew worktree: %s/%s\n",
-				style.Bold.Render("✓"), r.Name, name)
-		} else {
-			// For regular clones, use the crew manager
-			if err := crewMgr.Remove(name, forceRemove); err != nil {
-				if err == crew.ErrCrewNotFound {
-					fmt.Printf("Error removing %s: crew workspace not found\n", arg)
-				} else if err == crew.ErrHasChanges {
-					fmt.Printf("Error removing %s: uncommitted changes (use --force)\n", arg)
-				} else {
-					fmt.Printf("Error removing %s: %v\n", arg, err)
-				}
-				lastErr = err
-				continue
+		removeCmd := exec.Command("git", removeArgs...)
+		removeCmd.Dir = mayorRigPath
+		if output, err := removeCmd.CombinedOutput(); err != nil {
+			return crewOpFailure(address, fmt.Errorf("removing worktree: %v: %s", err, strings.TrimSpace(string(output))))
+		}
+		res.Detail = "worktree removed"
+	} else {
+		// For regular clones, use the crew manager
+		if err := crewMgr.Remove(name, forceRemove); err != nil {
+			if err == crew.ErrCrewNotFound {
+				return crewOpFailure(address, err)
+			} else if err == crew.ErrHasChanges {
+				return crewOpFailure(address, fmt.Errorf("%w (use --force)", err))
 			}
-			fmt.Printf("%s Removed crew workspace: %s/%s\n",
-				style.Bold.Render("✓"), r.Name, name)
+			return crewOpFailure(address, err)
 		}
+		res.Detail = "workspace removed"
+	}
 
-		// Handle agent bead
-		townRoot, _ := workspace.Find(r.Path)
-		if townRoot == "" {
-			townRoot = r.Path
-		}
-		prefix := beads.GetPrefixForRig(townRoot, r.Name)
-		agentBeadID := beads.CrewBeadIDWithPrefix(prefix, r.Name, name)
-
-		if crewPurge {
-			// --purge: DELETE the agent bead entirely (obliterate)
-			deleteArgs := []string{"delete", agentBeadID, "--force"}
-			deleteCmd := exec.Command("bd", deleteArgs...)
-			deleteCmd.Dir = r.Path
-			if output, err := deleteCmd.CombinedOutput(); err != nil {
-				// Non-fatal: bead might not exist
-				if !strings.Contains(string(output), "no issue found") &&
-					!strings.Contains(string(output), "not found") {
-					style.PrintWarning("could not delete agent bead %s: %v", agentBeadID, err)
-				}
-			} else {
-				fmt.Printf("Deleted agent bead: %s\n", agentBeadID)
+	// Handle agent bead
+	townRoot, _ := workspace.Find(r.Path)
+	if townRoot == "" {
+		townRoot = r.Path
+	}
+	prefix := beads.GetPrefixForRig(townRoot, r.Name)
+	agentBeadID := beads.CrewBeadIDWithPrefix(prefix, r.Name, name)
+
+	if crewPurge {
+		// --purge: DELETE the agent bead entirely (obliterate)
+		deleteArgs := []string{"delete", agentBeadID, "--force"}
+		deleteCmd := exec.Command("bd", deleteArgs...)
+		deleteCmd.Dir = r.Path
+		if output, err := deleteCmd.CombinedOutput(); err != nil {
+			// Non-fatal: bead might not exist
+			if !strings.Contains(string(output), "no issue found") &&
+				!strings.Contains(string(output), "not found") {
+				res.Notes = append(res.Notes, fmt.Sprintf("could not delete agent bead %s: %v", agentBeadID, err))
 			}
-
-			// Unassign any beads assigned to this crew member
-			agentAddr := fmt.Sprintf("%s/crew/%s", r.Name, name)
-			unassignArgs := []string{"list", "--assignee=" + agentAddr, "--format=id"}
-			unassignCmd := exec.Command("bd", unassignArgs...)
-			unassignCmd.Dir = r.Path
-			if output, err := unassignCmd.CombinedOutput(); err == nil {
-				ids := strings.Fields(strings.TrimSpace(string(output)))
-				for _, id := range ids {
-					if id == "" {
-						continue
-					}
-					updateCmd := exec.Command("bd", "update", id, "--unassign")
-					updateCmd.Dir = r.Path
-					if _, err := updateCmd.CombinedOutput(); err == nil {
-						fmt.Printf("Unassigned: %s\n", id)
-					}
+		} else {
+			res.Notes = append(res.Notes, "deleted agent bead "+agentBeadID)
+		}
+
+		// Unassign any beads assigned to this crew member
+		agentAddr := fmt.Sprintf("%s/crew/%s", r.Name, name)
+		unassignArgs := []string{"list", "--assignee=" + agentAddr, "--format=id"}
+		unassignCmd := exec.Command("bd", unassignArgs...)
+		unassignCmd.Dir = r.Path
+		if output, err := unassignCmd.CombinedOutput(); err == nil {
+			ids := strings.Fields(strings.TrimSpace(string(output)))
+			for _, id := range ids {
+				if id == "" {
+					continue
+				}
+				updateCmd := exec.Command("bd", "update", id, "--unassign")
+				updateCmd.Dir = r.Path
+				if _, err := updateCmd.CombinedOutput(); err == nil {
+					res.Notes = append(res.Notes, "unassigned "+id)
 				}
 			}
-
-			// Clear mail directory if it exists
-			mailDir := filepath.Join(crewPath, "mail")
-			if _, err := os.Stat(mailDir); err == nil {
-				// Mail dir was removed with the workspace, so nothing to do
-				// But if we want to be extra thorough, we could look in town beads
+		}
+		// Mail lives in the workspace, so it was removed with it.
+	} else {
+		// Default: CLOSE the agent bead (preserves CV history)
+		closeArgs := []string{"close", agentBeadID, "--reason=Crew workspace removed"}
+		if sessionID := runtime.SessionIDFromEnv(); sessionID != "" {
+			closeArgs = append(closeArgs, "--session="+sessionID)
+		}
+		closeCmd := exec.Command("bd", closeArgs...)
+		closeCmd.Dir = r.Path
+		if output, err := closeCmd.CombinedOutput(); err != nil {
+			// Non-fatal: bead might not exist or already be closed
+			if !strings.Contains(string(output), "no issue found") &&
+				!strings.Contains(string(output), "already closed") {
+				res.Notes = append(res.Notes, fmt.Sprintf("could not close agent bead %s: %v", agentBeadID, err))
 			}
 		} else {
-			// Default: CLOSE the agent bead (preserves CV history)
-			closeArgs := []string{"close", agentBeadID, "--reason=Crew workspace removed"}
-			if sessionID := runtime.SessionIDFromEnv(); sessionID != "" {
-				closeArgs = append(closeArgs, "--session="+sessionID)
-			}
-			closeCmd := exec.Command("bd", closeArgs...)
-			closeCmd.Dir = r.Path
-			if output, err := closeCmd.CombinedOutput(); err != nil {
-				// Non-fatal: bead might not exist or already be closed
-				if !strings.Contains(string(output), "no issue found") &&
-					!strings.Contains(string(output), "already closed") {
-					style.PrintWarning("could not close agent bead %s: %v", agentBeadID, err)
-				}
-			} else {
-				fmt.Printf("Closed agent bead: %s\n", agentBeadID)
-			}
+			res.Notes = append(res.Notes, "closed agent bead "+agentBeadID)
 		}
 	}
 
-	return lastErr
+	return res
 }
 
 func runCrewRefresh(cmd *cobra.Command, args []string) error {
-	name := args[0]
-	// Parse rig/name format (e.g., "beads/emma" -> rig=beads, name=emma)
-	if rig, crewName, ok := parseRigSlashName(name); ok {
-		if crewRig == "" {
-			crewRig = rig
+	targets, err := resolveCrewTargets(args)
+	if err != nil {
+		return err
+	}
+	if crewFromHandoff > 0 {
+		if len(targets) != 1 {
+			return fmt.Errorf("--from-handoff replays one worker's handoff; name exactly one worker")
+		}
+		if crewMessage != "" {
+			return fmt.Errorf("cannot combine --from-handoff with --message")
 		}
-		name = crewName
+	}
+	if len(targets) == 0 {
+		fmt.Println("No crew workspaces to refresh")
+		return nil
 	}
 
-	crewMgr, r, err := getCrewManager(crewRig)
+	results := runCrewBulk(targets, crewParallel, refreshCrewWorker)
+	err = reportCrewBulk("refreshed", results)
+	if !crewJSON && len(results) == 1 && results[0].Status == crewOpOK {
+		fmt.Printf("\nAttach with: %s\n", style.Dim.Render(fmt.Sprintf("gt crew at %s", targets[0].Name)))
+	}
+	return err
+}
+
+// refreshCrewWorker sends a handoff mail to the worker's own inbox, then
+// restarts its session so the new session picks up the handoff.
+func refreshCrewWorker(target crewTarget) crewOpResult {
+	name := target.Name
+	crewMgr, r, err := getCrewManager(target.Rig)
 	if err != nil {
-		return err
+		return crewOpFailure(target.Arg, err)
 	}
+	address := r.Name + "/" + name
 
 	// Get the crew worker (must exist for refresh)
 	worker, err := crewMgr.Get(name)
 	if err != nil {
 		if err == crew.ErrCrewNotFound {
-			return fmt.Errorf("crew workspace '%s' not found", name)
+			return crewOpFailure(address, fmt.Errorf("crew workspace '%s': %w", name, err))
 		}
-		return fmt.Errorf("getting crew worker: %w", err)
+		return crewOpFailure(address, fmt.Errorf("getting crew worker: %w", err))
 	}
 
-	// Create handoff message
+	// Create handoff message, replaying a logged one if asked
+	subject := "🤝 HANDOFF: Context Refresh"
 	handoffMsg := crewMessage
+	if crewFromHandoff > 0 {
+		prev, err := crewMgr.Handoff(name, crewFromHandoff)
+		if err != nil {
+			return crewOpFailure(address, err)
+		}
+		subject = prev.Subject
+		handoffMsg = prev.Message
+	}
 	if handoffMsg == "" {
 		handoffMsg = fmt.Sprintf("Context refresh for %s. Check mail and beads for current work state.", name)
 	}
 
 	// Send handoff mail to self
-	mailDir := filepath.Join(worker.ClonePath, "mail")
-	if _, err := os.Stat(mailDir); os.IsNotExist(err) {
-		if err := os.MkdirAll(mailDir, 0755); err != nil {
-			return fmt.Errorf("creating mail dir: %w", err)
-		}
+	routes, err := mail.LoadRoutingTable(filepath.Dir(r.Path))
+	if err != nil {
+		return crewOpFailure(address, err)
+	}
+	mailbox, err := routes.Mailbox(r.Name + "/crew/" + worker.Name)
+	if err != nil {
+		return crewOpFailure(address, err)
 	}
-
-	// Create and send mail
-	mailbox := mail.NewMailbox(mailDir)
 	msg := &mail.Message{
 		From:    fmt.Sprintf("%s/%s", r.Name, name),
 		To:      fmt.Sprintf("%s/%s", r.Name, name),
-		Subject: "🤝 HANDOFF: Context Refresh",
+		Subject: subject,
 		Body:    handoffMsg,
 	}
 	if err := mailbox.Append(msg); err != nil {
-		return fmt.Errorf("sending handoff mail: %w", err)
+		return crewOpFailure(address, fmt.Errorf("sending handoff mail: %w", err))
+	}
+
+	// Keep the handoff after the mail is read (gt crew handoffs)
+	var notes []string
+	rec, err := crewMgr.RecordHandoff(name, subject, handoffMsg, crewFromHandoff)
+	if err != nil {
+		notes = append(notes, fmt.Sprintf("could not log handoff: %v", err))
 	}
-	fmt.Printf("Sent handoff mail to %s/%s\n", r.Name, name)
 
 	// Use manager's Start() with refresh options
 	err = crewMgr.Start(name, crew.StartOptions{
@@ -246,14 +264,17 @@ func runCrewRefresh(cmd *cobra.Command, args []string) error {
 		AgentOverride: crewAgentOverride,
 	})
 	if err != nil {
-		return fmt.Errorf("starting crew session: %w", err)
+		return crewOpFailure(address, fmt.Errorf("starting crew session: %w", err))
 	}
 
-	fmt.Printf("%s Refreshed crew workspace: %s/%s\n",
-		style.Bold.Render("✓"), r.Name, name)
-	fmt.Printf("Attach with: %s\n", style.Dim.Render(fmt.Sprintf("gt crew at %s", name)))
-
-	return nil
+	detail := "handoff sent, session restarted"
+	if rec != nil {
+		detail = fmt.Sprintf("handoff #%d sent, session restarted", rec.Seq)
+		if crewFromHandoff > 0 {
+			detail = fmt.Sprintf("handoff #%d (replay of #%d) sent, session restarted", rec.Seq, crewFromHandoff)
+		}
+	}
+	return crewOpResult{Worker: address, Status: crewOpOK, Detail: detail, Notes: notes}
 }
 
 // runCrewStart starts crew workers in a rig.
@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/crew"
@@ -13,20 +17,40 @@ import (
 	"github.com/steveyegge/gastown/internal/tmux"
 )
 
+// Crew list flags
+var (
+	crewListSort        string
+	crewListFormat      string
+	crewListDirtyOnly   bool
+	crewListRunningOnly bool
+)
+
 // CrewListItem represents a crew worker in list output.
 type CrewListItem struct {
-	Name       string `json:"name"`
-	Rig        string `json:"rig"`
-	Branch     string `json:"branch"`
-	Path       string `json:"path"`
-	HasSession bool   `json:"has_session"`
-	GitClean   bool   `json:"git_clean"`
+	Name         string    `json:"name"`
+	Rig          string    `json:"rig"`
+	Branch       string    `json:"branch"`
+	Path         string    `json:"path"`
+	HasSession   bool      `json:"has_session"`
+	GitClean     bool      `json:"git_clean"`
+	DirtyFiles   int       `json:"dirty_files"`
+	LastActivity time.Time `json:"last_activity,omitzero"` // session activity, else last commit
 }
 
 func runCrewList(cmd *cobra.Command, args []string) error {
 	if crewListAll && crewRig != "" {
 		return fmt.Errorf("cannot use --all with --rig")
 	}
+	switch crewListSort {
+	case "", "name", "activity", "dirty":
+	default:
+		return fmt.Errorf("invalid --sort %q (valid: name, activity, dirty)", crewListSort)
+	}
+	switch crewListFormat {
+	case "", "table", "wide", "names":
+	default:
+		return fmt.Errorf("invalid --format %q (valid: table, wide, names)", crewListFormat)
+	}
 
 	var rigs []*rig.Rig
 	if crewListAll {
@@ -58,54 +82,126 @@ func runCrewList(cmd *cobra.Command, args []string) error {
 		}
 
 		for _, w := range workers {
+			item := CrewListItem{
+				Name:     w.Name,
+				Rig:      r.Name,
+				Branch:   w.Branch,
+				Path:     w.ClonePath,
+				GitClean: true,
+			}
+
 			sessionID := crewSessionName(r.Name, w.Name)
-			hasSession, _ := t.HasSession(sessionID)
+			item.HasSession, _ = t.HasSession(sessionID)
+			if item.HasSession {
+				if info, err := t.GetSessionInfo(sessionID); err == nil {
+					if secs, err := strconv.ParseInt(info.Activity, 10, 64); err == nil && secs > 0 {
+						item.LastActivity = time.Unix(secs, 0)
+					}
+				}
+			}
 
 			workerGit := git.NewGit(w.ClonePath)
-			gitClean := true
 			if status, err := workerGit.Status(); err == nil {
-				gitClean = status.Clean
+				item.GitClean = status.Clean
+				item.DirtyFiles = len(status.Modified) + len(status.Added) + len(status.Deleted) + len(status.Untracked)
+			}
+			if item.LastActivity.IsZero() {
+				if ct, err := workerGit.LastCommitTime(); err == nil {
+					item.LastActivity = ct
+				}
 			}
 
-			items = append(items, CrewListItem{
-				Name:       w.Name,
-				Rig:        r.Name,
-				Branch:     w.Branch,
-				Path:       w.ClonePath,
-				HasSession: hasSession,
-				GitClean:   gitClean,
-			})
+			if crewListDirtyOnly && item.GitClean {
+				continue
+			}
+			if crewListRunningOnly && !item.HasSession {
+				continue
+			}
+			items = append(items, item)
 		}
 	}
 
-	if len(items) == 0 {
-		fmt.Println("No crew workspaces found.")
-		return nil
-	}
+	sortCrewListItems(items, crewListSort)
 
 	if crewJSON {
+		if items == nil {
+			items = []CrewListItem{}
+		}
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
 		return enc.Encode(items)
 	}
 
-	// Text output
-	fmt.Printf("%s\n\n", style.Bold.Render("Crew Workspaces"))
+	if crewListFormat == "names" {
+		for _, item := range items {
+			fmt.Printf("%s/%s\n", item.Rig, item.Name)
+		}
+		return nil
+	}
+
+	if len(items) == 0 {
+		fmt.Println("No crew workspaces found.")
+		return nil
+	}
+
+	printCrewListTable(items, crewListFormat == "wide")
+	return nil
+}
+
+// sortCrewListItems orders items by name (rig, then worker), most recent
+// activity first, or most dirty files first. Ties fall back to name order.
+func sortCrewListItems(items []CrewListItem, by string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		switch by {
+		case "activity":
+			if !a.LastActivity.Equal(b.LastActivity) {
+				return a.LastActivity.After(b.LastActivity)
+			}
+		case "dirty":
+			if a.DirtyFiles != b.DirtyFiles {
+				return a.DirtyFiles > b.DirtyFiles
+			}
+		}
+		if a.Rig != b.Rig {
+			return a.Rig < b.Rig
+		}
+		return a.Name < b.Name
+	})
+}
+
+func printCrewListTable(items []CrewListItem, wide bool) {
+	nameWidth, branchWidth := len("WORKER"), len("BRANCH")
+	for _, item := range items {
+		nameWidth = max(nameWidth, len(item.Rig)+1+len(item.Name))
+		branchWidth = max(branchWidth, len(item.Branch))
+	}
+
+	header := fmt.Sprintf("  %-*s  %-*s  %-5s  %-8s", nameWidth, "WORKER", branchWidth, "BRANCH", "GIT", "ACTIVITY")
+	if wide {
+		header += fmt.Sprintf("  %5s  %s", "DIRTY", "PATH")
+	}
+	fmt.Println(style.Bold.Render(strings.TrimRight(header, " ")))
+
 	for _, item := range items {
 		status := style.Dim.Render("○")
 		if item.HasSession {
 			status = style.Bold.Render("●")
 		}
-
 		gitStatus := style.Dim.Render("clean")
 		if !item.GitClean {
 			gitStatus = style.Bold.Render("dirty")
 		}
+		activity := "-"
+		if !item.LastActivity.IsZero() {
+			activity = formatAge(item.LastActivity)
+		}
 
-		fmt.Printf("  %s %s/%s\n", status, item.Rig, item.Name)
-		fmt.Printf("    Branch: %s  Git: %s\n", item.Branch, gitStatus)
-		fmt.Printf("    %s\n", style.Dim.Render(item.Path))
+		line := fmt.Sprintf("%s %-*s  %-*s  %s  %-8s", status, nameWidth, item.Rig+"/"+item.Name,
+			branchWidth, item.Branch, gitStatus, activity)
+		if wide {
+			line += fmt.Sprintf("  %5d  %s", item.DirtyFiles, style.Dim.Render(item.Path))
+		}
+		fmt.Println(strings.TrimRight(line, " "))
 	}
-
-	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -125,3 +126,47 @@ func TestRunCrewList_AllAggregatesJSON(t *testing.T) {
 		t.Fatalf("expected crew from rig-a and rig-b, got: %#v", rigs)
 	}
 }
+
+func TestSortCrewListItems(t *testing.T) {
+	now := time.Now()
+	base := func() []CrewListItem {
+		return []CrewListItem{
+			{Rig: "rig-b", Name: "alpha", DirtyFiles: 0, LastActivity: now.Add(-time.Hour)},
+			{Rig: "rig-a", Name: "zed", DirtyFiles: 3, LastActivity: now.Add(-time.Minute)},
+			{Rig: "rig-a", Name: "bob", DirtyFiles: 3},
+		}
+	}
+	names := func(items []CrewListItem) string {
+		var out []string
+		for _, item := range items {
+			out = append(out, item.Rig+"/"+item.Name)
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		by   string
+		want string
+	}{
+		{"name", "rig-a/bob,rig-a/zed,rig-b/alpha"},
+		{"activity", "rig-a/zed,rig-b/alpha,rig-a/bob"},
+		{"dirty", "rig-a/bob,rig-a/zed,rig-b/alpha"},
+	}
+	for _, tt := range tests {
+		items := base()
+		sortCrewListItems(items, tt.by)
+		if got := names(items); got != tt.want {
+			t.Errorf("sort by %s = %s, want %s", tt.by, got, tt.want)
+		}
+	}
+}
+
+func TestCrewListItemJSONOmitsUnknownActivity(t *testing.T) {
+	data, err := json.Marshal(CrewListItem{Rig: "gastown", Name: "joe"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "last_activity") {
+		t.Errorf("JSON = %s, want no last_activity without activity", data)
+	}
+}
@@ -2,13 +2,20 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 func runCrewRename(cmd *cobra.Command, args []string) error {
@@ -28,32 +35,162 @@ func runCrewRename(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Kill any running session for the old name.
-	// Use KillSessionWithProcesses to ensure all descendant processes are killed.
+	// Perform the rename (directory, state, crew/<name> branch)
+	if err := crewMgr.Rename(oldName, newName); err != nil {
+		if err == crew.ErrCrewNotFound {
+			return fmt.Errorf("crew workspace '%s': %w", oldName, err)
+		}
+		if err == crew.ErrCrewExists {
+			return fmt.Errorf("crew workspace '%s': %w", newName, err)
+		}
+		return fmt.Errorf("renaming crew workspace: %w", err)
+	}
+	fmt.Printf("%s Renamed crew workspace: %s/%s → %s/%s\n",
+		style.Bold.Render("✓"), r.Name, oldName, r.Name, newName)
+	if worker, err := crewMgr.Get(newName); err == nil {
+		fmt.Printf("  Branch: %s\n", worker.Branch)
+	}
+
+	townRoot, _ := workspace.Find(r.Path)
+	if townRoot == "" {
+		townRoot = filepath.Dir(r.Path)
+	}
+
+	// Migrate mail: beads mail is addressed by name, so reassign it.
+	// (Legacy JSONL inboxes live in the workspace and moved with it.)
+	oldAddress := fmt.Sprintf("%s/crew/%s", r.Name, oldName)
+	newAddress := fmt.Sprintf("%s/crew/%s", r.Name, newName)
+	if mailbox, err := mail.NewRouterWithTownRoot(r.Path, townRoot).GetMailbox(oldAddress); err == nil {
+		moved, err := mailbox.MoveTo(newAddress)
+		if err != nil {
+			style.PrintWarning("migrated %d message(s) before failing: %v", moved, err)
+		} else if moved > 0 {
+			fmt.Printf("  Mail: moved %d message(s) to %s\n", moved, newAddress)
+		}
+	}
+
+	// Rename a running session in place rather than killing it
 	t := tmux.NewTmux()
 	oldSessionID := crewSessionName(r.Name, oldName)
+	newSessionID := crewSessionName(r.Name, newName)
 	if hasSession, _ := t.HasSession(oldSessionID); hasSession {
-		if err := t.KillSessionWithProcesses(oldSessionID); err != nil {
-			return fmt.Errorf("killing old session: %w", err)
+		if err := t.RenameSession(oldSessionID, newSessionID); err != nil {
+			style.PrintWarning("could not rename session %s: %v", oldSessionID, err)
+			return nil
+		}
+		envVars := config.AgentEnv(config.AgentEnvConfig{
+			Role:          "crew",
+			Rig:           r.Name,
+			AgentName:     newName,
+			TownRoot:      townRoot,
+			BeadsNoDaemon: true,
+		})
+		for k, v := range envVars {
+			_ = t.SetEnvironment(newSessionID, k, v)
 		}
-		fmt.Printf("Killed session %s\n", oldSessionID)
+		_ = t.ConfigureGasTownSession(newSessionID, tmux.AssignTheme(r.Name), r.Name, newName, "crew")
+		fmt.Printf("  Session: %s → %s\n", oldSessionID, newSessionID)
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf(
+			"The running agent keeps its old identity until restarted: gt crew restart %s", newName)))
+	} else {
+		fmt.Printf("New session will be: %s\n", style.Dim.Render(newSessionID))
 	}
 
-	// Perform the rename
-	if err := crewMgr.Rename(oldName, newName); err != nil {
-		if err == crew.ErrCrewNotFound {
-			return fmt.Errorf("crew workspace '%s' not found", oldName)
+	return nil
+}
+
+func runCrewMove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if rigName, crewName, ok := parseRigSlashName(name); ok {
+		if crewRig == "" {
+			crewRig = rigName
 		}
-		if err == crew.ErrCrewExists {
-			return fmt.Errorf("crew workspace '%s' already exists", newName)
+		name = crewName
+	}
+
+	srcMgr, srcRig, err := getCrewManager(crewRig)
+	if err != nil {
+		return err
+	}
+	destMgr, destRig, err := getCrewManager(crewMoveToRig)
+	if err != nil {
+		return err
+	}
+
+	t := tmux.NewTmux()
+	if hasSession, _ := t.HasSession(crewSessionName(srcRig.Name, name)); hasSession {
+		return fmt.Errorf("%s/%s has a running session; stop it first with 'gt crew stop %s/%s'",
+			srcRig.Name, name, srcRig.Name, name)
+	}
+
+	fmt.Printf("Moving crew worker %s/%s → %s/%s...\n", srcRig.Name, name, destRig.Name, name)
+	res, err := srcMgr.Move(name, destMgr, crew.MoveOptions{
+		CherryPick: crewMoveCherry,
+		Keep:       crewMoveKeep,
+		Force:      crewForce,
+	})
+	if err != nil {
+		switch {
+		case err == crew.ErrCrewNotFound:
+			return fmt.Errorf("crew workspace '%s' in %s: %w", name, srcRig.Name, err)
+		case err == crew.ErrCrewExists:
+			return fmt.Errorf("crew workspace '%s' in %s: %w", name, destRig.Name, err)
+		case errors.Is(err, crew.ErrHasChanges):
+			return fmt.Errorf("%w\nCommit or discard it, or use --keep or --force", err)
 		}
-		return fmt.Errorf("renaming crew workspace: %w", err)
+		if res == nil {
+			return fmt.Errorf("moving crew worker: %w", err)
+		}
+		style.PrintWarning("%v", err)
+	}
+	for _, w := range res.Worker.Warnings {
+		style.PrintWarning("%s", w)
 	}
 
-	fmt.Printf("%s Renamed crew workspace: %s/%s → %s/%s\n",
-		style.Bold.Render("✓"), r.Name, oldName, r.Name, newName)
-	fmt.Printf("New session will be: %s\n", style.Dim.Render(crewSessionName(r.Name, newName)))
+	townRoot, _ := workspace.Find(destRig.Path)
+	if townRoot == "" {
+		townRoot = filepath.Dir(destRig.Path)
+	}
+	bd := beads.New(beads.ResolveBeadsDir(destRig.Path))
+	note := ensureCrewAgentBead(townRoot, bd, destRig.Name, name)
 
+	fmt.Printf("%s Moved crew worker to %s/%s\n", style.Bold.Render("✓"), destRig.Name, name)
+	fmt.Printf("  Path: %s\n", res.Worker.ClonePath)
+	fmt.Printf("  Branch: %s\n", res.Worker.Branch)
+	if len(res.Picked) > 0 {
+		fmt.Printf("  Cherry-picked: %d commit(s)\n", len(res.Picked))
+	}
+	if res.Dropped > 0 {
+		fmt.Printf("  %s %d commit(s) not carried over (use --cherry-pick)\n", style.Warning.Render("⚠"), res.Dropped)
+	}
+
+	// Beads mail is addressed by rig; reassign it to the new address.
+	oldAddress := fmt.Sprintf("%s/crew/%s", srcRig.Name, name)
+	newAddress := fmt.Sprintf("%s/crew/%s", destRig.Name, name)
+	if mailbox, err := mail.NewRouterWithTownRoot(destRig.Path, townRoot).GetMailbox(oldAddress); err == nil {
+		moved, err := mailbox.MoveTo(newAddress)
+		if err != nil {
+			style.PrintWarning("migrated %d message(s) before failing: %v", moved, err)
+		} else if moved > 0 {
+			fmt.Printf("  Mail: moved %d message(s) to %s\n", moved, newAddress)
+		}
+	}
+	if len(res.Mail) > 0 {
+		fmt.Printf("  Workspace mail: %s\n", strings.Join(res.Mail, ", "))
+	}
+	if len(res.Local) > 0 {
+		fmt.Printf("  Carried over: %s\n", strings.Join(res.Local, ", "))
+	}
+	if len(res.Skipped) > 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("Kept the target project's own: "+strings.Join(res.Skipped, ", ")))
+	}
+	if note != "" {
+		fmt.Printf("  %s\n", note)
+	}
+	if crewMoveKeep {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("Old workspace kept: %s/%s", srcRig.Name, name)))
+	}
+	fmt.Printf("\n%s\n", style.Dim.Render(fmt.Sprintf("Start it with: gt crew start %s/%s", destRig.Name, name)))
 	return nil
 }
 
@@ -75,7 +212,7 @@ func runCrewPristine(cmd *cobra.Command, args []string) error {
 		worker, err := crewMgr.Get(name)
 		if err != nil {
 			if err == crew.ErrCrewNotFound {
-				return fmt.Errorf("crew workspace '%s' not found", name)
+				return fmt.Errorf("crew workspace '%s': %w", name, err)
 			}
 			return fmt.Errorf("getting crew worker: %w", err)
 		}
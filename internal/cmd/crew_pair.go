@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/runtime"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var crewPairCmd = &cobra.Command{
+	Use:   "pair <a> <b>",
+	Short: "Run two crew workers side by side in one tmux window",
+	Long: `Start two crew workers in a shared tmux session, one pane each.
+
+Each pane runs in its worker's workspace with that worker's own identity
+(GT_ROLE, BD_ACTOR, ...), so you can shepherd two agents working on coupled
+changes side by side. Workers may be in different rigs (rig/name).
+
+Neither worker may already have its own session: two agents in one
+workspace would collide. Stop it first with 'gt crew stop <name>'.
+
+The pair session is named pair-<rig>-<a>-<b>. Running the command again
+attaches to the existing pair; kill it with 'tmux kill-session -t <name>'.
+
+Examples:
+  gt crew pair dave emma              # Pair two workers in the current rig
+  gt crew pair gastown/max beads/emma # Pair across rigs
+  gt crew pair dave emma --detached   # Start without attaching`,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runCrewPair,
+}
+
+func init() {
+	crewPairCmd.Flags().StringVar(&crewRig, "rig", "", "Rig to use for names without a rig/ prefix")
+	crewPairCmd.Flags().BoolVarP(&crewDetached, "detached", "d", false, "Start session without attaching")
+	crewPairCmd.Flags().StringVar(&crewAccount, "account", "", "Claude Code account handle to use (overrides default)")
+	crewPairCmd.Flags().StringVar(&crewAgentOverride, "agent", "", "Agent alias to run both workers with (overrides rig/town default)")
+
+	crewCmd.AddCommand(crewPairCmd)
+}
+
+// pairMember is one side of a crew pair.
+type pairMember struct {
+	rig    *rig.Rig
+	worker *crew.CrewWorker
+}
+
+func runCrewPair(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	t := tmux.NewTmux()
+	var members []pairMember
+	for _, target := range parseCrewTargets(args) {
+		crewMgr, r, err := getCrewManager(target.Rig)
+		if err != nil {
+			return err
+		}
+		worker, err := crewMgr.Get(target.Name)
+		if err != nil {
+			if err == crew.ErrCrewNotFound {
+				return fmt.Errorf("crew workspace '%s' in %s: %w", target.Name, r.Name, err)
+			}
+			return fmt.Errorf("getting crew worker %s: %w", target.Arg, err)
+		}
+		if running, _ := t.HasSession(crewSessionName(r.Name, worker.Name)); running {
+			return fmt.Errorf("%s/%s already has a session; stop it first with 'gt crew stop %s/%s'",
+				r.Name, worker.Name, r.Name, worker.Name)
+		}
+		members = append(members, pairMember{rig: r, worker: worker})
+	}
+	a, b := members[0], members[1]
+	if a.rig.Name == b.rig.Name && a.worker.Name == b.worker.Name {
+		return fmt.Errorf("cannot pair %s/%s with itself", a.rig.Name, a.worker.Name)
+	}
+
+	pairSession := session.CrewPairSessionName(a.rig.Name, a.worker.Name, b.rig.Name, b.worker.Name)
+	hasSession, err := t.HasSession(pairSession)
+	if err != nil {
+		return fmt.Errorf("checking session: %w", err)
+	}
+
+	if !hasSession {
+		accountsPath := constants.MayorAccountsPath(townRoot)
+		claudeConfigDir, accountHandle, err := config.ResolveAccountConfigDir(accountsPath, crewAccount)
+		if err != nil {
+			return fmt.Errorf("resolving account: %w", err)
+		}
+		if accountHandle != "" {
+			fmt.Printf("Using account: %s\n", accountHandle)
+		}
+
+		panes, err := t.NewWindowWithPanes(pairSession, []string{a.worker.ClonePath, b.worker.ClonePath})
+		if err != nil {
+			_ = t.KillSession(pairSession)
+			return fmt.Errorf("creating pair session: %w", err)
+		}
+		for i, m := range members {
+			if err := startPairPane(t, panes[i], townRoot, claudeConfigDir, m); err != nil {
+				_ = t.KillSession(pairSession)
+				return fmt.Errorf("starting %s/%s: %w", m.rig.Name, m.worker.Name, err)
+			}
+		}
+
+		// Theming is cosmetic (non-fatal); the pair takes the first worker's rig colors
+		_ = t.ApplyTheme(pairSession, getThemeForRig(a.rig.Name))
+		_ = t.EnableMouseMode(pairSession)
+
+		fmt.Printf("%s Paired %s/%s and %s/%s in session %s\n", style.Bold.Render("✓"),
+			a.rig.Name, a.worker.Name, b.rig.Name, b.worker.Name, pairSession)
+	}
+
+	if tmux.IsInsideTmux() {
+		fmt.Printf("Session %s ready. Use C-b s to switch.\n", pairSession)
+		return nil
+	}
+	if crewDetached {
+		fmt.Printf("Run '%s' to attach.\n", tmux.AttachHint(pairSession))
+		return nil
+	}
+	fmt.Printf("Attaching to %s...\n", pairSession)
+	return attachToTmuxSession(pairSession)
+}
+
+// startPairPane sets a worker's identity in its pane and launches its agent.
+// tmux session environment is shared by both panes, so each worker's
+// variables are exported into its own pane instead.
+func startPairPane(t *tmux.Tmux, pane, townRoot, claudeConfigDir string, m pairMember) error {
+	name := m.worker.Name
+	runtimeConfig := config.LoadRuntimeConfig(m.rig.Path)
+	if err := runtime.EnsureSettingsForRole(m.worker.ClonePath, "crew", runtimeConfig); err != nil {
+		// Non-fatal but log warning - missing settings can cause agents to start without hooks
+		style.PrintWarning("could not ensure settings for %s: %v", name, err)
+	}
+
+	if err := t.WaitForPaneShellReady(pane, constants.ShellReadyTimeout); err != nil {
+		return err
+	}
+
+	envVars := config.AgentEnv(config.AgentEnvConfig{
+		Role:             "crew",
+		Rig:              m.rig.Name,
+		AgentName:        name,
+		TownRoot:         townRoot,
+		RuntimeConfigDir: claudeConfigDir,
+		BeadsNoDaemon:    true,
+	})
+	keys := make([]string, 0, len(envVars))
+	for k := range envVars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := t.SetPaneEnvironment(pane, k, envVars[k]); err != nil {
+			return fmt.Errorf("setting %s: %w", k, err)
+		}
+	}
+
+	beacon := session.FormatStartupNudge(session.StartupNudgeConfig{
+		Recipient: fmt.Sprintf("%s/crew/%s", m.rig.Name, name),
+		Sender:    "human",
+		Topic:     "start",
+	})
+	startupCmd, err := config.BuildCrewStartupCommandWithAgentOverride(m.rig.Name, name, m.rig.Path, beacon, crewAgentOverride)
+	if err != nil {
+		return fmt.Errorf("building startup command: %w", err)
+	}
+	if runtimeConfig.Session != nil && runtimeConfig.Session.ConfigDirEnv != "" && claudeConfigDir != "" {
+		startupCmd = config.PrependEnv(startupCmd, map[string]string{runtimeConfig.Session.ConfigDirEnv: claudeConfigDir})
+	}
+	return t.SendKeysToPane(pane, startupCmd)
+}
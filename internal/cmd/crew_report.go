@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var crewReportSince string
+
+var crewReportCmd = &cobra.Command{
+	Use:   "report [<name>]",
+	Short: "Summarize crew worker activity",
+	Long: `Summarize what crew workers did over a time window.
+
+For each worker, reports:
+  - commits made in its workspace (fetched or pulled commits don't count)
+  - files those commits touched
+  - beads assigned to it that were closed
+  - mail received and sent
+
+Without a name, reports on every worker in the rig. Use --json for
+dashboards; it always emits an array of reports.
+
+Examples:
+  gt crew report                  # All workers, last 24 hours
+  gt crew report dave --since 7d  # One worker, last week
+  gt crew report --since 2h --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCrewReport,
+}
+
+func init() {
+	crewReportCmd.Flags().StringVar(&crewRig, "rig", "", "Rig to use")
+	crewReportCmd.Flags().StringVar(&crewReportSince, "since", "24h", "Time window (e.g., 2h, 24h, 7d)")
+	crewReportCmd.Flags().BoolVar(&crewJSON, "json", false, "Output as JSON")
+
+	crewCmd.AddCommand(crewReportCmd)
+}
+
+func runCrewReport(cmd *cobra.Command, args []string) error {
+	window, err := parseDuration(crewReportSince)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	since := time.Now().Add(-window)
+
+	var name string
+	if len(args) > 0 {
+		name = args[0]
+		if rigName, crewName, ok := parseRigSlashName(name); ok {
+			if crewRig == "" {
+				crewRig = rigName
+			}
+			name = crewName
+		}
+	}
+
+	crewMgr, r, err := getCrewManager(crewRig)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	if name != "" {
+		names = []string{name}
+	} else {
+		workers, err := crewMgr.List()
+		if err != nil {
+			return fmt.Errorf("listing crew workers: %w", err)
+		}
+		for _, w := range workers {
+			names = append(names, w.Name)
+		}
+	}
+
+	reports := []*crew.Report{}
+	for _, n := range names {
+		rep, err := crewMgr.Report(n, since)
+		if err != nil {
+			if err == crew.ErrCrewNotFound {
+				return fmt.Errorf("crew workspace '%s': %w", n, err)
+			}
+			return fmt.Errorf("reporting on %s: %w", n, err)
+		}
+		reports = append(reports, rep)
+	}
+
+	if crewJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(reports)
+	}
+
+	if len(reports) == 0 {
+		fmt.Printf("No crew workspaces in %s.\n", r.Name)
+		return nil
+	}
+	for i, rep := range reports {
+		if i > 0 {
+			fmt.Println()
+		}
+		printCrewReport(rep)
+	}
+	return nil
+}
+
+func printCrewReport(rep *crew.Report) {
+	fmt.Printf("%s %s\n", style.Bold.Render(rep.Rig+"/"+rep.Worker),
+		style.Dim.Render("since "+rep.Since.Local().Format("2006-01-02 15:04")))
+
+	fmt.Printf("  Commits:      %d\n", len(rep.Commits))
+	for _, c := range rep.Commits {
+		fmt.Printf("    %s %s\n", style.Dim.Render(c.Hash[:min(len(c.Hash), 8)]), c.Subject)
+	}
+	fmt.Printf("  Files:        %d touched\n", len(rep.FilesTouched))
+	fmt.Printf("  Beads closed: %d\n", len(rep.BeadsClosed))
+	for _, b := range rep.BeadsClosed {
+		fmt.Printf("    %s %s\n", style.Dim.Render(b.ID), b.Title)
+	}
+	fmt.Printf("  Mail:         %d received, %d sent\n", rep.MailReceived, rep.MailSent)
+	for _, e := range rep.Errors {
+		fmt.Printf("  %s %s\n", style.Warning.Render("⚠"), style.Dim.Render(e))
+	}
+}
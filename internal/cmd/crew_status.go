@@ -6,13 +6,16 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/crew"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/tui/feed"
 )
 
 // CrewStatusItem represents detailed status for a crew worker.
@@ -28,8 +31,15 @@ type CrewStatusItem struct {
 	GitUntracked []string `json:"git_untracked,omitempty"`
 	MailTotal    int      `json:"mail_total"`
 	MailUnread   int      `json:"mail_unread"`
+	MailRead     int      `json:"mail_read"`  // read, not yet acknowledged
+	MailAcked    int      `json:"mail_acked"` // acknowledged (e.g., handoff picked up)
 }
 
+var (
+	crewStatusWatch    bool
+	crewStatusInterval time.Duration
+)
+
 func runCrewStatus(cmd *cobra.Command, args []string) error {
 	// Parse rig/name format before getting manager (e.g., "beads/emma" -> rig=beads, name=emma)
 	var targetName string
@@ -55,24 +65,35 @@ func runCrewStatus(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	var workers []*crew.CrewWorker
+	if crewStatusWatch && crewJSON {
+		return fmt.Errorf("--watch and --json cannot be used together")
+	}
 
-	if targetName != "" {
-		// Specific worker
+	listWorkers := func() ([]*crew.CrewWorker, error) {
+		if targetName == "" {
+			workers, err := crewMgr.List()
+			if err != nil {
+				return nil, fmt.Errorf("listing crew workers: %w", err)
+			}
+			return workers, nil
+		}
 		worker, err := crewMgr.Get(targetName)
 		if err != nil {
 			if err == crew.ErrCrewNotFound {
-				return fmt.Errorf("crew workspace '%s' not found", targetName)
+				return nil, fmt.Errorf("crew workspace '%s': %w", targetName, err)
 			}
-			return fmt.Errorf("getting crew worker: %w", err)
-		}
-		workers = []*crew.CrewWorker{worker}
-	} else {
-		// All workers
-		workers, err = crewMgr.List()
-		if err != nil {
-			return fmt.Errorf("listing crew workers: %w", err)
+			return nil, fmt.Errorf("getting crew worker: %w", err)
 		}
+		return []*crew.CrewWorker{worker}, nil
+	}
+
+	workers, err := listWorkers()
+	if err != nil {
+		return err
+	}
+
+	if crewStatusWatch {
+		return runCrewStatusWatch(r.Name, listWorkers)
 	}
 
 	if len(workers) == 0 {
@@ -80,11 +101,62 @@ func runCrewStatus(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	t := tmux.NewTmux()
+	items := collectCrewStatus(tmux.NewTmux(), r.Name, workers)
+
+	if crewJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	}
+
+	// Text output
+	for i, item := range items {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		sessionStatus := style.Dim.Render("○ stopped")
+		if item.HasSession {
+			sessionStatus = style.Bold.Render("● running")
+		}
+
+		fmt.Printf("%s %s/%s\n", sessionStatus, item.Rig, item.Name)
+		fmt.Printf("  Path:   %s\n", item.Path)
+		fmt.Printf("  Branch: %s\n", item.Branch)
+
+		if item.GitClean {
+			fmt.Printf("  Git:    %s\n", style.Dim.Render("clean"))
+		} else {
+			fmt.Printf("  Git:    %s\n", style.Bold.Render("dirty"))
+			if len(item.GitModified) > 0 {
+				fmt.Printf("          Modified: %s\n", strings.Join(item.GitModified, ", "))
+			}
+			if len(item.GitUntracked) > 0 {
+				fmt.Printf("          Untracked: %s\n", strings.Join(item.GitUntracked, ", "))
+			}
+		}
+
+		switch {
+		case item.MailTotal == 0:
+			fmt.Printf("  Mail:   %s\n", style.Dim.Render("0 messages"))
+		case item.MailUnread > 0:
+			fmt.Printf("  Mail:   %d unread, %d read, %d acked / %d total\n",
+				item.MailUnread, item.MailRead, item.MailAcked, item.MailTotal)
+		default:
+			fmt.Printf("  Mail:   %s\n", style.Dim.Render(fmt.Sprintf("%d read, %d acked / %d total",
+				item.MailRead, item.MailAcked, item.MailTotal)))
+		}
+	}
+
+	return nil
+}
+
+// collectCrewStatus gathers session, git, and mail status for crew workers.
+func collectCrewStatus(t *tmux.Tmux, rigName string, workers []*crew.CrewWorker) []CrewStatusItem {
 	var items []CrewStatusItem
 
 	for _, w := range workers {
-		sessionID := crewSessionName(r.Name, w.Name)
+		sessionID := crewSessionName(rigName, w.Name)
 		hasSession, _ := t.HasSession(sessionID)
 
 		// Git status
@@ -103,23 +175,24 @@ func runCrewStatus(cmd *cobra.Command, args []string) error {
 
 		// Mail status (non-fatal: display defaults to 0 if count fails)
 		mailDir := filepath.Join(w.ClonePath, "mail")
-		mailTotal, mailUnread := 0, 0
+		var counts mail.MailCounts
 		if _, err := os.Stat(mailDir); err == nil {
-			mailbox := mail.NewMailbox(mailDir)
-			mailTotal, mailUnread, _ = mailbox.Count()
+			counts, _ = mail.NewMailbox(mailDir).Counts()
 		}
 
 		item := CrewStatusItem{
 			Name:         w.Name,
-			Rig:          r.Name,
+			Rig:          rigName,
 			Path:         w.ClonePath,
 			Branch:       branch,
 			HasSession:   hasSession,
 			GitClean:     gitClean,
 			GitModified:  modified,
 			GitUntracked: untracked,
-			MailTotal:    mailTotal,
-			MailUnread:   mailUnread,
+			MailTotal:    counts.Total,
+			MailUnread:   counts.Unread,
+			MailRead:     counts.Read,
+			MailAcked:    counts.Acked,
 		}
 		if hasSession {
 			item.SessionID = sessionID
@@ -128,45 +201,56 @@ func runCrewStatus(cmd *cobra.Command, args []string) error {
 		items = append(items, item)
 	}
 
-	if crewJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(items)
-	}
+	return items
+}
 
-	// Text output
-	for i, item := range items {
-		if i > 0 {
-			fmt.Println()
-		}
+// runCrewStatusWatch shows crew status in a live view that refreshes every
+// --interval until the user quits.
+func runCrewStatusWatch(rigName string, listWorkers func() ([]*crew.CrewWorker, error)) error {
+	if crewStatusInterval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
 
-		sessionStatus := style.Dim.Render("○ stopped")
-		if item.HasSession {
-			sessionStatus = style.Bold.Render("● running")
+	t := tmux.NewTmux()
+	load := func() ([]feed.CrewStatusRow, error) {
+		workers, err := listWorkers()
+		if err != nil {
+			return nil, err
 		}
-
-		fmt.Printf("%s %s/%s\n", sessionStatus, item.Rig, item.Name)
-		fmt.Printf("  Path:   %s\n", item.Path)
-		fmt.Printf("  Branch: %s\n", item.Branch)
-
-		if item.GitClean {
-			fmt.Printf("  Git:    %s\n", style.Dim.Render("clean"))
-		} else {
-			fmt.Printf("  Git:    %s\n", style.Bold.Render("dirty"))
-			if len(item.GitModified) > 0 {
-				fmt.Printf("          Modified: %s\n", strings.Join(item.GitModified, ", "))
+		var rows []feed.CrewStatusRow
+		for _, item := range collectCrewStatus(t, rigName, workers) {
+			row := feed.CrewStatusRow{
+				Rig:        item.Rig,
+				Name:       item.Name,
+				Running:    item.HasSession,
+				DirtyFiles: len(item.GitModified) + len(item.GitUntracked),
+				MailUnread: item.MailUnread,
 			}
-			if len(item.GitUntracked) > 0 {
-				fmt.Printf("          Untracked: %s\n", strings.Join(item.GitUntracked, ", "))
+			if item.HasSession {
+				row.LastLine = lastPaneLine(t, item.SessionID)
 			}
+			rows = append(rows, row)
 		}
-
-		if item.MailUnread > 0 {
-			fmt.Printf("  Mail:   %d unread / %d total\n", item.MailUnread, item.MailTotal)
-		} else {
-			fmt.Printf("  Mail:   %s\n", style.Dim.Render(fmt.Sprintf("%d messages", item.MailTotal)))
-		}
+		return rows, nil
 	}
 
+	p := tea.NewProgram(feed.NewCrewStatusModel(load, crewStatusInterval), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("running TUI: %w", err)
+	}
 	return nil
 }
+
+// lastPaneLine returns the last non-blank line shown in a session's pane.
+func lastPaneLine(t *tmux.Tmux, session string) string {
+	lines, err := t.CapturePaneLines(session, 50)
+	if err != nil {
+		return ""
+	}
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -24,6 +24,11 @@ The daemon is a simple Go process that:
 - Pokes agents periodically (heartbeat)
 - Processes lifecycle requests (cycle, restart, shutdown)
 - Restarts sessions when agents request cycling
+- Runs recurring jobs from settings/schedule.json (see 'gt schedule')
+- Answers CLI queries (rigs, tmux sessions, bd lists) from warm state over
+  daemon/daemon.sock, so status lines, gt status, gt agents and rig
+  lookups skip the config loads and subprocess spawns; without a daemon,
+  or with GT_NO_DAEMON=1, commands do the work themselves
 
 The daemon is a "dumb scheduler" - all intelligence is in agents.`,
 }
@@ -34,14 +39,16 @@ var daemonStartCmd = &cobra.Command{
 	Long: `Start the Gas Town daemon in the background.
 
 The daemon will run until stopped with 'gt daemon stop'.`,
-	RunE: runDaemonStart,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDaemonStart,
 }
 
 var daemonStopCmd = &cobra.Command{
-	Use:   "stop",
-	Short: "Stop the daemon",
-	Long:  `Stop the running Gas Town daemon.`,
-	RunE:  runDaemonStop,
+	Use:         "stop",
+	Short:       "Stop the daemon",
+	Long:        `Stop the running Gas Town daemon.`,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDaemonStop,
 }
 
 var daemonStatusCmd = &cobra.Command{
@@ -190,6 +197,11 @@ func runDaemonStatus(cmd *cobra.Command, args []string) error {
 					state.LastHeartbeat.Format("15:04:05"),
 					state.HeartbeatCount)
 			}
+			if _, err := daemon.Ping(townRoot); err == nil {
+				fmt.Printf("  State socket: %s\n", daemon.SocketPath(townRoot))
+			} else {
+				fmt.Printf("  State socket: %s\n", style.Dim.Render("not answering (commands run in direct mode)"))
+			}
 
 			// Check if binary is newer than process
 			if binaryModTime, err := getBinaryModTime(); err == nil {
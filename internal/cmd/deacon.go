@@ -59,7 +59,8 @@ var deaconStartCmd = &cobra.Command{
 
 Creates a new detached tmux session for the Deacon and launches Claude.
 The session runs in the workspace root directory.`,
-	RunE: runDeaconStart,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDeaconStart,
 }
 
 var deaconStopCmd = &cobra.Command{
@@ -68,7 +69,8 @@ var deaconStopCmd = &cobra.Command{
 	Long: `Stop the Deacon tmux session.
 
 Attempts graceful shutdown first (Ctrl-C), then kills the tmux session.`,
-	RunE: runDeaconStop,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDeaconStop,
 }
 
 var deaconAttachCmd = &cobra.Command{
@@ -79,7 +81,8 @@ var deaconAttachCmd = &cobra.Command{
 
 Attaches the current terminal to the Deacon's tmux session.
 Detach with Ctrl-B D.`,
-	RunE: runDeaconAttach,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDeaconAttach,
 }
 
 var deaconStatusCmd = &cobra.Command{
@@ -95,7 +98,8 @@ var deaconRestartCmd = &cobra.Command{
 	Long: `Restart the Deacon tmux session.
 
 Stops the current session (if running) and starts a fresh one.`,
-	RunE: runDeaconRestart,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDeaconRestart,
 }
 
 var deaconAgentOverride string
@@ -130,7 +134,8 @@ In steady-state, the Deacon should use AI-based observation instead:
   gt nudge <session>    # Trigger when AI determines ready
 
 This command is typically called by the daemon during cold startup.`,
-	RunE: runDeaconTriggerPending,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDeaconTriggerPending,
 }
 
 var deaconHealthCheckCmd = &cobra.Command{
@@ -182,8 +187,9 @@ This respects the cooldown period - won't kill if recently killed.
 Examples:
   gt deacon force-kill gastown/polecats/max
   gt deacon force-kill gastown/witness --reason="unresponsive for 90s"`,
-	Args: cobra.ExactArgs(1),
-	RunE: runDeaconForceKill,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDeaconForceKill,
 }
 
 var deaconHealthStateCmd = &cobra.Command{
@@ -211,7 +217,8 @@ Examples:
   gt deacon stale-hooks                 # Find and unhook stale beads
   gt deacon stale-hooks --dry-run       # Preview what would be unhooked
   gt deacon stale-hooks --max-age=30m   # Use 30 minute threshold`,
-	RunE: runDeaconStaleHooks,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDeaconStaleHooks,
 }
 
 var deaconPauseCmd = &cobra.Command{
@@ -231,7 +238,8 @@ to allow the Deacon to work again.
 Examples:
   gt deacon pause                           # Pause with no reason
   gt deacon pause --reason="testing"        # Pause with a reason`,
-	RunE: runDeaconPause,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDeaconPause,
 }
 
 var deaconResumeCmd = &cobra.Command{
@@ -240,7 +248,8 @@ var deaconResumeCmd = &cobra.Command{
 	Long: `Resume the Deacon so it can perform patrol actions again.
 
 This removes the pause file and allows the Deacon to work normally.`,
-	RunE: runDeaconResume,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDeaconResume,
 }
 
 var deaconCleanupOrphansCmd = &cobra.Command{
@@ -261,7 +270,8 @@ This is safe because:
 
 Example:
   gt deacon cleanup-orphans`,
-	RunE: runDeaconCleanupOrphans,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDeaconCleanupOrphans,
 }
 
 var deaconZombieScanCmd = &cobra.Command{
@@ -285,7 +295,8 @@ but are no longer part of any active Gas Town session.
 Examples:
   gt deacon zombie-scan           # Find and kill zombies
   gt deacon zombie-scan --dry-run # Just list zombies, don't kill`,
-	RunE: runDeaconZombieScan,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDeaconZombieScan,
 }
 
 var (
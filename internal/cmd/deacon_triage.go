@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	deaconTriageDryRun bool
+	deaconTriageJSON   bool
+)
+
+var deaconTriageCmd = &cobra.Command{
+	Use:   "triage",
+	Short: "Triage incoming work and dispatch it to rigs",
+	Long: `Run one intake and triage pass.
+
+Intake sources:
+  - Task mail sent to deacon/ (converted into beads)
+  - Open, unassigned beads not yet labeled "triaged" (including GitHub
+    issues imported by bd sync)
+
+Each bead is routed by the first matching rule in settings/triage.json, or
+the default rig. Routed beads are labeled "triaged" and "rig:<name>", and the
+rig's witness is mailed to dispatch them. Unmatched beads are flagged to the
+Mayor.
+
+The Deacon runs this during patrol; the daemon also runs it each heartbeat
+when the "triage" patrol is enabled in mayor/daemon.json.
+
+Example settings/triage.json:
+  {
+    "type": "triage",
+    "version": 1,
+    "rules": [
+      {"name": "beads-bugs", "labels": ["beads"], "type": "bug", "rig": "beads", "priority": 1},
+      {"name": "docs", "title_contains": ["docs", "readme"], "rig": "gastown"}
+    ],
+    "default_rig": "gastown"
+  }
+
+Examples:
+  gt deacon triage              # Triage and dispatch
+  gt deacon triage --dry-run    # Preview routing decisions`,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDeaconTriage,
+}
+
+func init() {
+	deaconTriageCmd.Flags().BoolVarP(&deaconTriageDryRun, "dry-run", "n", false, "Show routing decisions without dispatching")
+	deaconTriageCmd.Flags().BoolVar(&deaconTriageJSON, "json", false, "Output decisions as JSON")
+	deaconCmd.AddCommand(deaconTriageCmd)
+}
+
+func runDeaconTriage(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	decisions, err := deacon.Triage(townRoot, deacon.TriageOptions{DryRun: deaconTriageDryRun})
+	if err != nil {
+		return err
+	}
+
+	if deaconTriageJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(decisions)
+	}
+
+	if len(decisions) == 0 {
+		fmt.Println(style.Dim.Render("No incoming work to triage."))
+		return nil
+	}
+
+	if deaconTriageDryRun {
+		fmt.Printf("%s Would triage %d item(s):\n", style.Bold.Render("Dry run:"), len(decisions))
+	}
+
+	failed := 0
+	for _, d := range decisions {
+		target := style.Warning.Render("→ mayor (no rule matched)")
+		if d.Rig != "" {
+			target = fmt.Sprintf("→ %s %s", style.Bold.Render(d.Rig), style.Dim.Render("["+d.Rule+"]"))
+		}
+		mark := style.Success.Render("✓")
+		if d.Error != "" {
+			mark = style.Error.Render("✗")
+			failed++
+		}
+		fmt.Printf("  %s %s %s %s\n", mark, d.BeadID, d.Title, target)
+		if d.Error != "" {
+			fmt.Printf("      %s\n", style.Dim.Render(d.Error))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d item(s) failed to dispatch", failed, len(decisions))
+	}
+	if !deaconTriageDryRun {
+		fmt.Printf("\nRouting rules: %s\n", style.Dim.Render(config.TriageConfigPath(townRoot)))
+	}
+	return nil
+}
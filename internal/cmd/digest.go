@@ -0,0 +1,328 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// Digest labels.
+const (
+	digestLabel              = "digest"
+	digestRollupLabel        = "digest-rollup"
+	rolledUpIntoLabelPrefix  = "rolled_up_into:"
+	defaultDigestRollupSince = "7d"
+)
+
+var (
+	digestRollupSince  string
+	digestRollupDryRun bool
+	digestRollupDelete bool
+	digestRollupJSON   bool
+)
+
+var digestCmd = &cobra.Command{
+	Use:     "digest",
+	GroupID: GroupWork,
+	Short:   "Manage digest beads",
+	Long: `Manage digest beads.
+
+Squashed and cancelled molecules leave closed, digest-labeled beads
+behind. Squash aggressively and the digests themselves pile up; 'gt digest
+rollup' folds a window of them into one summary digest.
+
+Examples:
+  gt digest rollup --since 7d
+  gt digest rollup --since 30d --dry-run`,
+}
+
+var digestRollupCmd = &cobra.Command{
+	Use:   "rollup",
+	Short: "Fold recent digests into one summary digest",
+	Long: `Fold the digests created in a window into a single rollup digest.
+
+The rollup records how many molecules the digests cover, their steps,
+commits and wall time, counts per proto, and the list of digests it
+replaces. Each rolled-up digest loses its digest label and is labeled
+rolled_up_into:<rollup-id>, so it stays searchable but is not picked up
+again; with --delete it is deleted instead.
+
+Rollups are digests themselves but are never folded into later rollups.
+The command is safe to run on a schedule (e.g., weekly from Deacon patrol):
+a window with no new digests does nothing.
+
+Examples:
+  gt digest rollup                    # Digests from the last 7 days
+  gt digest rollup --since 30d
+  gt digest rollup --dry-run --json   # Show the rollup without writing it
+  gt digest rollup --delete           # Delete the rolled-up digests`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDigestRollup,
+}
+
+func init() {
+	digestRollupCmd.Flags().StringVar(&digestRollupSince, "since", defaultDigestRollupSince, "Roll up digests created within this duration (e.g., 24h, 7d)")
+	digestRollupCmd.Flags().BoolVar(&digestRollupDryRun, "dry-run", false, "Show the rollup without creating it")
+	digestRollupCmd.Flags().BoolVar(&digestRollupDelete, "delete", false, "Delete rolled-up digests instead of relabeling them")
+	digestRollupCmd.Flags().BoolVar(&digestRollupJSON, "json", false, "Output as JSON")
+
+	digestCmd.AddCommand(digestRollupCmd)
+	rootCmd.AddCommand(digestCmd)
+}
+
+// DigestRollup summarizes the digests of a window.
+type DigestRollup struct {
+	RollupID     string              `json:"rollup_id,omitempty"`
+	Since        time.Time           `json:"since"`
+	Until        time.Time           `json:"until"`
+	Molecules    int                 `json:"molecules"` // squash digests with a trace
+	Cancelled    int                 `json:"cancelled"`
+	Other        int                 `json:"other"` // digests without a trace
+	Steps        int                 `json:"steps"`
+	StepsDone    int                 `json:"steps_done"`
+	Commits      int                 `json:"commits"`
+	WallTimeSecs int64               `json:"wall_time_secs"`
+	ByProto      map[string]int      `json:"by_proto,omitempty"`
+	Digests      []DigestRollupEntry `json:"digests"`
+}
+
+// DigestRollupEntry is a digest folded into a rollup.
+type DigestRollupEntry struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	CreatedAt string `json:"created_at"`
+}
+
+func runDigestRollup(cmd *cobra.Command, args []string) error {
+	window, err := parseDuration(digestRollupSince)
+	if err != nil {
+		return fmt.Errorf("invalid --since value: %w", err)
+	}
+	until := time.Now()
+	since := until.Add(-window)
+
+	workDir, err := findLocalBeadsDir()
+	if err != nil {
+		return fmt.Errorf("not in a beads workspace: %w", err)
+	}
+	b := beads.New(workDir)
+
+	issues, err := b.List(beads.ListOptions{
+		Status:       "closed",
+		Label:        digestLabel,
+		CreatedAfter: since,
+		Priority:     -1,
+		Limit:        -1,
+	})
+	if err != nil {
+		return fmt.Errorf("listing digests: %w", err)
+	}
+
+	rollup := buildDigestRollup(issues, since, until)
+	if len(rollup.Digests) == 0 {
+		if digestRollupJSON {
+			return printDigestRollupJSON(rollup)
+		}
+		fmt.Printf("%s No digests to roll up since %s\n", style.Dim.Render("○"), since.Format("2006-01-02 15:04"))
+		return nil
+	}
+
+	if digestRollupDryRun {
+		if digestRollupJSON {
+			return printDigestRollupJSON(rollup)
+		}
+		fmt.Printf("%s [DRY RUN] Would roll up %d digest(s):\n", style.Bold.Render("📦"), len(rollup.Digests))
+		printDigestRollupSummary(rollup)
+		return nil
+	}
+
+	rollupIssue, err := createMoleculeDigest(b, digestRollupTitle(rollup), formatDigestRollup(rollup), detectCurrentAgent(), false)
+	if err != nil {
+		return err
+	}
+	rollup.RollupID = rollupIssue.ID
+	if err := b.Update(rollupIssue.ID, beads.UpdateOptions{AddLabels: []string{digestRollupLabel}}); err != nil {
+		style.PrintWarning("couldn't label %s as a rollup: %v", rollupIssue.ID, err)
+	}
+
+	archived, failed := archiveRolledUpDigests(b, rollup)
+	if failed > 0 {
+		style.PrintWarning("%d digest(s) could not be archived and will be rolled up again", failed)
+	}
+
+	if digestRollupJSON {
+		return printDigestRollupJSON(rollup)
+	}
+	fmt.Printf("%s Rolled up %d digest(s) → %s\n", style.Bold.Render("📦"), len(rollup.Digests), rollupIssue.ID)
+	printDigestRollupSummary(rollup)
+	if digestRollupDelete {
+		fmt.Printf("  Deleted %d digest(s)\n", archived)
+	} else {
+		fmt.Printf("  Relabeled %d digest(s) %s%s\n", archived, rolledUpIntoLabelPrefix, rollupIssue.ID)
+	}
+	return nil
+}
+
+// buildDigestRollup summarizes the digests created between since and until.
+// Earlier rollups are left out.
+func buildDigestRollup(issues []*beads.Issue, since, until time.Time) *DigestRollup {
+	rollup := &DigestRollup{
+		Since:   since,
+		Until:   until,
+		ByProto: make(map[string]int),
+		Digests: []DigestRollupEntry{},
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].CreatedAt < issues[j].CreatedAt
+	})
+	for _, issue := range issues {
+		if beads.HasLabel(issue, digestRollupLabel) {
+			continue
+		}
+		if created := parseBeadsTimestamp(issue.CreatedAt); !created.IsZero() && created.Before(since) {
+			continue
+		}
+		rollup.Digests = append(rollup.Digests, DigestRollupEntry{
+			ID:        issue.ID,
+			Title:     issue.Title,
+			CreatedAt: issue.CreatedAt,
+		})
+
+		if strings.HasSuffix(issue.Title, "(cancelled)") {
+			rollup.Cancelled++
+			continue
+		}
+		trace, err := beads.ParseDigestTrace(issue.Description)
+		if err != nil || trace == nil {
+			rollup.Other++
+			continue
+		}
+		rollup.Molecules++
+		rollup.Steps += len(trace.Steps)
+		for _, s := range trace.Steps {
+			if s.Status == "closed" {
+				rollup.StepsDone++
+			}
+		}
+		rollup.Commits += len(trace.Commits)
+		rollup.WallTimeSecs += trace.WallTimeSecs
+		if trace.InstantiatedFrom != "" {
+			rollup.ByProto[trace.InstantiatedFrom]++
+		}
+	}
+	return rollup
+}
+
+// digestRollupTitle names a rollup after its window.
+func digestRollupTitle(rollup *DigestRollup) string {
+	return fmt.Sprintf("Digest rollup: %s..%s",
+		rollup.Since.Format("2006-01-02"), rollup.Until.Format("2006-01-02"))
+}
+
+// formatDigestRollup describes a rollup for its digest bead.
+func formatDigestRollup(rollup *DigestRollup) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Rollup of %d digest(s).\n\n", len(rollup.Digests))
+	fmt.Fprintf(&sb, "since: %s\n", rollup.Since.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&sb, "until: %s\n", rollup.Until.UTC().Format(time.RFC3339))
+
+	sb.WriteString("\n## Summary\n")
+	fmt.Fprintf(&sb, "- Molecules: %d\n", rollup.Molecules)
+	if rollup.Steps > 0 {
+		fmt.Fprintf(&sb, "- Steps: %d/%d completed\n", rollup.StepsDone, rollup.Steps)
+	}
+	fmt.Fprintf(&sb, "- Commits: %d\n", rollup.Commits)
+	if rollup.WallTimeSecs > 0 {
+		fmt.Fprintf(&sb, "- Wall time: %s\n", formatDuration(time.Duration(rollup.WallTimeSecs)*time.Second))
+	}
+	if rollup.Cancelled > 0 {
+		fmt.Fprintf(&sb, "- Cancelled: %d\n", rollup.Cancelled)
+	}
+	if rollup.Other > 0 {
+		fmt.Fprintf(&sb, "- Other digests: %d\n", rollup.Other)
+	}
+
+	if len(rollup.ByProto) > 0 {
+		sb.WriteString("\n## By Proto\n")
+		for _, proto := range sortedProtos(rollup.ByProto) {
+			fmt.Fprintf(&sb, "- %s: %d\n", proto, rollup.ByProto[proto])
+		}
+	}
+
+	sb.WriteString("\n## Digests\n")
+	for _, d := range rollup.Digests {
+		fmt.Fprintf(&sb, "- %s: %s\n", d.ID, d.Title)
+	}
+	return sb.String()
+}
+
+// archiveRolledUpDigests deletes or relabels the digests folded into a
+// rollup, returning how many were archived and how many failed.
+func archiveRolledUpDigests(b *beads.Beads, rollup *DigestRollup) (archived, failed int) {
+	if digestRollupDelete {
+		ids := make([]string, 0, len(rollup.Digests))
+		for _, d := range rollup.Digests {
+			ids = append(ids, d.ID)
+		}
+		if _, err := b.Run(append([]string{"delete", "--force"}, ids...)...); err != nil {
+			return 0, len(ids)
+		}
+		return len(ids), 0
+	}
+
+	for _, d := range rollup.Digests {
+		err := b.Update(d.ID, beads.UpdateOptions{
+			AddLabels:    []string{rolledUpIntoLabelPrefix + rollup.RollupID},
+			RemoveLabels: []string{digestLabel},
+		})
+		if err != nil {
+			failed++
+			continue
+		}
+		archived++
+	}
+	return archived, failed
+}
+
+// sortedProtos returns the protos of a per-proto count, sorted.
+func sortedProtos(counts map[string]int) []string {
+	protos := make([]string, 0, len(counts))
+	for proto := range counts {
+		protos = append(protos, proto)
+	}
+	sort.Strings(protos)
+	return protos
+}
+
+func printDigestRollupSummary(rollup *DigestRollup) {
+	fmt.Printf("  Window: %s to %s\n", rollup.Since.Format("2006-01-02 15:04"), rollup.Until.Format("2006-01-02 15:04"))
+	fmt.Printf("  Molecules: %d", rollup.Molecules)
+	if rollup.Steps > 0 {
+		fmt.Printf(" (%d/%d steps, %d commits)", rollup.StepsDone, rollup.Steps, rollup.Commits)
+	}
+	fmt.Println()
+	if rollup.Cancelled > 0 {
+		fmt.Printf("  Cancelled: %d\n", rollup.Cancelled)
+	}
+	if rollup.Other > 0 {
+		fmt.Printf("  Other: %d\n", rollup.Other)
+	}
+	for _, proto := range sortedProtos(rollup.ByProto) {
+		fmt.Printf("    %s: %d\n", proto, rollup.ByProto[proto])
+	}
+}
+
+func printDigestRollupJSON(rollup *DigestRollup) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rollup)
+}
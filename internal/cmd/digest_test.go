@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestBuildDigestRollup(t *testing.T) {
+	until := time.Date(2025, 12, 22, 0, 0, 0, 0, time.UTC)
+	since := until.AddDate(0, 0, -7)
+
+	squash := func(id, proto string, done, steps, commits int, wall int64) *beads.Issue {
+		trace := &beads.DigestTrace{Molecule: id, InstantiatedFrom: proto, WallTimeSecs: wall}
+		for i := 0; i < steps; i++ {
+			status := "open"
+			if i < done {
+				status = "closed"
+			}
+			trace.Steps = append(trace.Steps, beads.DigestStep{ID: id + ".x", Status: status})
+		}
+		for i := 0; i < commits; i++ {
+			trace.Commits = append(trace.Commits, beads.DigestCommit{Hash: "abc"})
+		}
+		section, err := beads.FormatDigestTrace(trace)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &beads.Issue{
+			ID:          "gt-d" + id,
+			Title:       "Digest: " + id,
+			Description: "Squashed molecule execution.\n\n" + section,
+			CreatedAt:   "2025-12-20T10:00:00Z",
+			Labels:      []string{"digest"},
+		}
+	}
+
+	issues := []*beads.Issue{
+		squash("gt-b", "mol-review", 2, 3, 1, 600),
+		squash("gt-a", "mol-review", 2, 2, 2, 3600),
+		squash("gt-c", "mol-deploy", 1, 1, 0, 60),
+		{ID: "gt-dx", Title: "Digest: gt-x (cancelled)", CreatedAt: "2025-12-19T10:00:00Z", Labels: []string{"digest"}},
+		{ID: "gt-dold", Title: "Digest: gt-old", CreatedAt: "2025-12-19T11:00:00Z", Description: "Squashed molecule execution.\n\nmolecule: gt-old\n"},
+		{ID: "gt-r1", Title: "Digest rollup: 2025-12-08..2025-12-15", CreatedAt: "2025-12-20T00:00:00Z", Labels: []string{"digest", "digest-rollup"}},
+		{ID: "gt-early", Title: "Digest: gt-early", CreatedAt: "2025-12-01T00:00:00Z"},
+	}
+
+	rollup := buildDigestRollup(issues, since, until)
+
+	if len(rollup.Digests) != 5 {
+		t.Fatalf("rolled up %d digests, want 5: %+v", len(rollup.Digests), rollup.Digests)
+	}
+	if rollup.Digests[0].ID != "gt-dx" {
+		t.Errorf("first digest = %s, want oldest (gt-dx)", rollup.Digests[0].ID)
+	}
+	if rollup.Molecules != 3 || rollup.Cancelled != 1 || rollup.Other != 1 {
+		t.Errorf("molecules/cancelled/other = %d/%d/%d, want 3/1/1", rollup.Molecules, rollup.Cancelled, rollup.Other)
+	}
+	if rollup.StepsDone != 5 || rollup.Steps != 6 || rollup.Commits != 3 || rollup.WallTimeSecs != 4260 {
+		t.Errorf("steps %d/%d, commits %d, wall %d; want 5/6, 3, 4260",
+			rollup.StepsDone, rollup.Steps, rollup.Commits, rollup.WallTimeSecs)
+	}
+	if rollup.ByProto["mol-review"] != 2 || rollup.ByProto["mol-deploy"] != 1 {
+		t.Errorf("ByProto = %v", rollup.ByProto)
+	}
+
+	desc := formatDigestRollup(rollup)
+	for _, want := range []string{
+		"Rollup of 5 digest(s).\n",
+		"since: 2025-12-15T00:00:00Z\n",
+		"- Steps: 5/6 completed\n",
+		"- Wall time: 1h 11m\n",
+		"- Cancelled: 1\n",
+		"- mol-deploy: 1\n- mol-review: 2\n",
+		"- gt-dx: Digest: gt-x (cancelled)\n",
+	} {
+		if !strings.Contains(desc, want) {
+			t.Errorf("rollup description missing %q:\n%s", want, desc)
+		}
+	}
+	if got := digestRollupTitle(rollup); got != "Digest rollup: 2025-12-15..2025-12-22" {
+		t.Errorf("title = %q", got)
+	}
+}
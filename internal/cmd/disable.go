@@ -32,7 +32,8 @@ Flags:
 
 Environment overrides still work:
   GASTOWN_ENABLED=1   - Enable for current session only`,
-	RunE: runDisable,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDisable,
 }
 
 func init() {
@@ -27,8 +27,9 @@ Subcommands:
 Without arguments, toggles DND mode.
 
 Related: gt notify - for fine-grained notification level control`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runDnd,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDnd,
 }
 
 func init() {
@@ -32,6 +32,12 @@ Workspace checks:
   - rigs-registry-valid      Check registered rigs exist (fixable)
   - mayor-exists             Check mayor/ directory structure
 
+Town consistency checks (every registered rig):
+  - rigs-unregistered        Detect rig workspaces missing from rigs.json (fixable)
+  - rig-beads-dirs           Verify every rig has a .beads directory (fixable)
+  - rig-git-remotes          Verify rig repos have a valid origin remote (fixable)
+  - stale-witness-state      Detect witness loop state left by dead loops (fixable)
+
 Town root protection:
   - town-git                 Verify town root is under version control
   - town-root-branch         Verify town root is on main branch (fixable)
@@ -114,6 +120,9 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	// Register workspace-level checks first (fundamental)
 	d.RegisterAll(doctor.WorkspaceChecks()...)
 
+	// Town-wide consistency checks across all registered rigs
+	d.RegisterAll(doctor.TownChecks()...)
+
 	d.Register(doctor.NewGlobalStateCheck())
 
 	// Register built-in checks
@@ -69,8 +69,9 @@ The dog starts in idle state, ready to receive work from the Deacon.
 Example:
   gt dog add alpha
   gt dog add bravo`,
-	Args: cobra.ExactArgs(1),
-	RunE: runDogAdd,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDogAdd,
 }
 
 var dogRemoveCmd = &cobra.Command{
@@ -95,7 +96,8 @@ Examples:
 		}
 		return nil
 	},
-	RunE: runDogRemove,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDogRemove,
 }
 
 var dogListCmd = &cobra.Command{
@@ -129,7 +131,8 @@ Examples:
   gt dog call alpha
   gt dog call --all
   gt dog call`,
-	RunE: runDogCall,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDogCall,
 }
 
 var dogStatusCmd = &cobra.Command{
@@ -179,7 +182,8 @@ Examples:
   gt dog dispatch --plugin rebuild-gt --create
   gt dog dispatch --plugin rebuild-gt --dry-run
   gt dog dispatch --plugin rebuild-gt --json`,
-	RunE: runDogDispatch,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDogDispatch,
 }
 
 func init() {
@@ -50,7 +50,8 @@ Examples:
   gt done --status ESCALATED           # Signal blocker, skip MR
   gt done --status DEFERRED            # Pause work, skip MR
   gt done --phase-complete --gate g-x  # Phase done, waiting on gate g-x`,
-	RunE: runDone,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDone,
 }
 
 var (
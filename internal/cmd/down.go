@@ -55,7 +55,8 @@ Use cases:
   • Taking a break (stop token consumption)
   • Clean shutdown before system maintenance
   • Resetting the town to a clean state`,
-	RunE: runDown,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runDown,
 }
 
 var (
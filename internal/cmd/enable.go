@@ -27,7 +27,8 @@ Use 'gt disable' to turn off. Use 'gt status --global' to check state.
 Environment overrides:
   GASTOWN_DISABLED=1  - Disable for current session only
   GASTOWN_ENABLED=1   - Enable for current session only`,
-	RunE: runEnable,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runEnable,
 }
 
 func init() {
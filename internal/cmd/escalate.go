@@ -19,10 +19,11 @@ var (
 )
 
 var escalateCmd = &cobra.Command{
-	Use:     "escalate [description]",
-	GroupID: GroupComm,
-	Short:   "Escalation system for critical issues",
-	RunE:    runEscalate,
+	Use:         "escalate [description]",
+	GroupID:     GroupComm,
+	Short:       "Escalation system for critical issues",
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runEscalate,
 	Long: `Create and manage escalations for critical issues.
 
 The escalation system provides severity-based routing for issues that need
@@ -83,8 +84,9 @@ This stops the stale escalation warnings.
 
 Examples:
   gt escalate ack hq-abc123`,
-	Args: cobra.ExactArgs(1),
-	RunE: runEscalateAck,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runEscalateAck,
 }
 
 var escalateCloseCmd = &cobra.Command{
@@ -97,8 +99,9 @@ Records who closed it and the resolution reason.
 Examples:
   gt escalate close hq-abc123 --reason "Fixed in commit abc"
   gt escalate close hq-abc123 --reason "Not reproducible"`,
-	Args: cobra.ExactArgs(1),
-	RunE: runEscalateClose,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runEscalateClose,
 }
 
 var escalateStaleCmd = &cobra.Command{
@@ -120,7 +123,8 @@ Examples:
   gt escalate stale              # Re-escalate stale escalations
   gt escalate stale --dry-run    # Show what would be done
   gt escalate stale --json       # JSON output of results`,
-	RunE: runEscalateStale,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runEscalateStale,
 }
 
 var escalateShowCmd = &cobra.Command{
@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/notify"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -115,7 +118,13 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Process external notification actions (email:, sms:, slack)
-	executeExternalActions(actions, escalationConfig, issue.ID, severity, description)
+	executeExternalActions(townRoot, actions, escalationConfig, notify.Notification{
+		Event:    notify.EventEscalation,
+		Severity: severity,
+		Title:    description,
+		Body:     formatEscalationMailBody(issue.ID, severity, escalateReason, agentID, escalateRelatedBead),
+		Source:   agentID,
+	})
 
 	// Log to activity feed
 	payload := events.EscalationPayload(issue.ID, agentID, strings.Join(targets, ","), description)
@@ -539,16 +548,21 @@ func extractMailTargetsFromActions(actions []string) []string {
 }
 
 // executeExternalActions processes external notification actions (email:, sms:, slack).
-// For now, this logs warnings if contacts aren't configured - actual sending is future work.
-func executeExternalActions(actions []string, cfg *config.EscalationConfig, _, _, _ string) {
+// Slack posts go to contacts.slack_webhook; email goes through the email sinks
+// in settings/notifications.json. Failures are warnings: the escalation bead
+// and mail have already been created.
+func executeExternalActions(townRoot string, actions []string, cfg *config.EscalationConfig, n notify.Notification) {
 	for _, action := range actions {
 		switch {
 		case strings.HasPrefix(action, "email:"):
 			if cfg.Contacts.HumanEmail == "" {
 				style.PrintWarning("email action '%s' skipped: contacts.human_email not configured in settings/escalation.json", action)
+				continue
+			}
+			if err := sendEscalationEmail(townRoot, cfg.Contacts.HumanEmail, n); err != nil {
+				style.PrintWarning("email to %s failed: %v", cfg.Contacts.HumanEmail, err)
 			} else {
-				// TODO: Implement actual email sending
-				fmt.Printf("  📧 Would send email to %s (not yet implemented)\n", cfg.Contacts.HumanEmail)
+				fmt.Printf("  📧 Emailed %s\n", cfg.Contacts.HumanEmail)
 			}
 
 		case strings.HasPrefix(action, "sms:"):
@@ -562,9 +576,13 @@ func executeExternalActions(actions []string, cfg *config.EscalationConfig, _, _
 		case action == "slack":
 			if cfg.Contacts.SlackWebhook == "" {
 				style.PrintWarning("slack action skipped: contacts.slack_webhook not configured in settings/escalation.json")
+				continue
+			}
+			sink := &notify.SlackSink{URL: cfg.Contacts.SlackWebhook}
+			if err := sink.Send(context.Background(), n); err != nil {
+				style.PrintWarning("slack post failed: %v", err)
 			} else {
-				// TODO: Implement actual Slack webhook posting
-				fmt.Printf("  💬 Would post to Slack (not yet implemented)\n")
+				fmt.Printf("  💬 Posted to Slack\n")
 			}
 
 		case action == "log":
@@ -573,6 +591,36 @@ func executeExternalActions(actions []string, cfg *config.EscalationConfig, _, _
 			fmt.Printf("  📝 Logged to escalation log\n")
 		}
 	}
+
+	// Fan out to any sinks configured in settings/notifications.json
+	sendNotification(townRoot, n)
+}
+
+// sendEscalationEmail delivers n to address using the SMTP settings of the
+// first email sink in settings/notifications.json.
+func sendEscalationEmail(townRoot, address string, n notify.Notification) error {
+	ncfg, err := config.LoadOrCreateNotificationsConfig(config.NotificationsConfigPath(townRoot))
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(ncfg.Sinks))
+	for name, sink := range ncfg.Sinks {
+		if sink.Type == config.SinkEmail {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no email sink configured in %s", config.NotificationsConfigPath(townRoot))
+	}
+	sort.Strings(names)
+
+	smtpCfg := *ncfg.Sinks[names[0]]
+	smtpCfg.To = []string{address}
+	sink, err := notify.NewSink(&smtpCfg)
+	if err != nil {
+		return err
+	}
+	return sink.Send(context.Background(), n)
 }
 
 func formatEscalationMailBody(beadID, severity, reason, from, related string) string {
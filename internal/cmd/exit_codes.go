@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Exit codes. These are a public contract for scripts: never renumber an
+// existing code, only add new ones.
+const (
+	ExitOK                = 0
+	ExitError             = 1 // Any failure without a more specific code
+	ExitUsage             = 2 // Bad flags or arguments
+	ExitNotFound          = 3 // Rig, crew worker, polecat, message or bead does not exist
+	ExitDirty             = 4 // Workspace has uncommitted or unpushed work
+	ExitSessionRunning    = 5 // Session is already running
+	ExitSessionNotRunning = 6 // Session (or the tmux server) is not running
+	ExitExists            = 7 // Rig, crew worker or polecat already exists
+	ExitNotInWorkspace    = 8 // Not inside a Gas Town workspace
+	ExitMissingDependency = 9 // A required tool (bd, tmux, ...) is not installed
+)
+
+// ErrUsage marks errors caused by invalid flags or arguments.
+var ErrUsage = errors.New("usage error")
+
+// exitCodeClass maps a set of sentinel errors to an exit code.
+type exitCodeClass struct {
+	Code        int
+	Name        string
+	Description string
+	Errors      []error
+}
+
+// exitCodeClasses lists the classified exit codes in precedence order: an
+// error matching several classes gets the first one.
+var exitCodeClasses = []exitCodeClass{
+	{ExitUsage, "usage", "Invalid flags or arguments", []error{ErrUsage}},
+	{ExitNotInWorkspace, "not-in-workspace", "Not inside a Gas Town workspace (see --town)", []error{workspace.ErrNotFound}},
+	{ExitMissingDependency, "missing-dependency", "A required tool (bd, tmux, ...) is not installed", []error{beads.ErrNotInstalled, exec.ErrNotFound}},
+	{ExitDirty, "dirty", "Uncommitted or unpushed work; commit it or pass --force", []error{
+		crew.ErrHasChanges, polecat.ErrHasChanges, polecat.ErrHasUncommittedWork,
+	}},
+	{ExitSessionRunning, "session-running", "The session is already running", []error{
+		crew.ErrSessionRunning, polecat.ErrSessionRunning, tmux.ErrSessionExists,
+	}},
+	{ExitSessionNotRunning, "session-not-running", "The session or tmux server is not running", []error{
+		crew.ErrSessionNotFound, polecat.ErrSessionNotFound, tmux.ErrSessionNotFound, tmux.ErrNoServer,
+	}},
+	{ExitExists, "exists", "The rig, crew worker or polecat already exists", []error{
+		rig.ErrRigExists, crew.ErrCrewExists, polecat.ErrPolecatExists,
+	}},
+	{ExitNotFound, "not-found", "The rig, crew worker, polecat, message or bead does not exist", []error{
+		rig.ErrRigNotFound, crew.ErrCrewNotFound, polecat.ErrPolecatNotFound, polecat.ErrIssueInvalid,
+		mail.ErrMessageNotFound, beads.ErrNotFound,
+	}},
+}
+
+// ExitCodeFor returns the process exit code for a command error.
+func ExitCodeFor(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	if code, ok := IsSilentExit(err); ok {
+		return code
+	}
+	for _, class := range exitCodeClasses {
+		for _, sentinel := range class.Errors {
+			if errors.Is(err, sentinel) {
+				return class.Code
+			}
+		}
+	}
+	return ExitError
+}
+
+// classifiedError gives err the exit code of class without changing its
+// message.
+type classifiedError struct {
+	err   error
+	class error
+}
+
+func (e *classifiedError) Error() string   { return e.err.Error() }
+func (e *classifiedError) Unwrap() []error { return []error{e.err, e.class} }
+
+// withExitClass returns err, classified like class (a sentinel error) for
+// ExitCodeFor.
+func withExitClass(err, class error) error {
+	return &classifiedError{err: err, class: class}
+}
+
+// flagError classifies cobra flag parsing errors as usage errors.
+func flagError(_ *cobra.Command, err error) error {
+	return withExitClass(err, ErrUsage)
+}
+
+var exitCodesCmd = &cobra.Command{
+	Use:   "exit-codes",
+	Short: "Exit codes returned by gt commands",
+	Long:  exitCodesHelp(),
+}
+
+func exitCodesHelp() string {
+	var sb strings.Builder
+	sb.WriteString(`gt exits with a code that tells scripts why a command failed, so they can
+branch on the failure instead of parsing error text.
+
+`)
+	fmt.Fprintf(&sb, "  %-3d %-20s %s\n", ExitOK, "ok", "Success")
+	fmt.Fprintf(&sb, "  %-3d %-20s %s\n", ExitError, "error", "Any failure without a more specific code")
+	classes := slices.Clone(exitCodeClasses)
+	slices.SortFunc(classes, func(a, b exitCodeClass) int { return a.Code - b.Code })
+	for _, class := range classes {
+		fmt.Fprintf(&sb, "  %-3d %-20s %s\n", class.Code, class.Name, class.Description)
+	}
+	sb.WriteString(`
+Some scripting commands document their own codes (e.g. "gt mail check"
+exits 1 when there is no mail, "gt stale" exits 0 when stale).
+Commands that act on several workers exit with the failures' code when
+they all share one, and 1 otherwise.
+
+Example:
+  gt crew remove max
+  case $? in
+    0) echo removed ;;
+    3) echo "no such crew worker" ;;
+    4) echo "has uncommitted changes" ;;
+  esac`)
+	return sb.String()
+}
+
+func init() {
+	rootCmd.AddCommand(exitCodesCmd)
+	rootCmd.SetFlagErrorFunc(flagError)
+}
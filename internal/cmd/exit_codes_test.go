@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+func TestExitCodeFor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"plain", errors.New("boom"), ExitError},
+		{"silent", NewSilentExit(1), 1},
+		{"wrapped rig", fmt.Errorf("rig 'x': %w", rig.ErrRigNotFound), ExitNotFound},
+		{"crew dirty", fmt.Errorf("%w (use --force)", crew.ErrHasChanges), ExitDirty},
+		{"crew running", crew.ErrSessionRunning, ExitSessionRunning},
+		{"no tmux server", tmux.ErrNoServer, ExitSessionNotRunning},
+		{"crew exists", fmt.Errorf("crew workspace 'max': %w", crew.ErrCrewExists), ExitExists},
+		{"message", fmt.Errorf("getting message: %w", mail.ErrMessageNotFound), ExitNotFound},
+		{"workspace", fmt.Errorf("not in a Gas Town workspace: %w", workspace.ErrNotFound), ExitNotInWorkspace},
+		{"flag", flagError(nil, errors.New("unknown flag: --nope")), ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCodeFor(tt.err); got != tt.want {
+				t.Errorf("ExitCodeFor(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithExitClassKeepsMessage(t *testing.T) {
+	err := withExitClass(errors.New("1 of 1 crew operation(s) failed"), crew.ErrCrewNotFound)
+	if err.Error() != "1 of 1 crew operation(s) failed" {
+		t.Errorf("message changed: %q", err.Error())
+	}
+	if ExitCodeFor(err) != ExitNotFound {
+		t.Errorf("ExitCodeFor = %d, want %d", ExitCodeFor(err), ExitNotFound)
+	}
+}
+
+func TestCrewBulkCause(t *testing.T) {
+	notFound := crewOpFailure("gastown/a", fmt.Errorf("crew workspace 'a': %w", crew.ErrCrewNotFound))
+	dirty := crewOpFailure("gastown/b", fmt.Errorf("%w (use --force)", crew.ErrHasChanges))
+	ok := crewOpResult{Worker: "gastown/c", Status: crewOpOK}
+
+	if cause := crewBulkCause([]crewOpResult{ok, notFound}); ExitCodeFor(cause) != ExitNotFound {
+		t.Errorf("single failure: cause %v, want not-found", cause)
+	}
+	if cause := crewBulkCause([]crewOpResult{notFound, dirty}); cause != nil {
+		t.Errorf("mixed failures: cause %v, want nil", cause)
+	}
+}
+
+func TestExitCodesHelpListsEveryCode(t *testing.T) {
+	help := exitCodesHelp()
+	for _, class := range exitCodeClasses {
+		if !strings.Contains(help, class.Name) {
+			t.Errorf("gt help exit-codes is missing %q", class.Name)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/extension"
+	"github.com/steveyegge/gastown/internal/mayor"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var extensionsCmd = &cobra.Command{
+	Use:     "extensions",
+	Aliases: []string{"ext"},
+	GroupID: GroupConfig,
+	Short:   "Manage external gt-<name> subcommands",
+	Long: `Manage external subcommands installed on PATH.
+
+Any executable on PATH named gt-<name> runs as 'gt <name>'. Dashes map to
+nested subcommands: gt-team-report runs as 'gt team report'. Built-in
+commands always win; an extension with a built-in's name is never run.
+
+Extensions receive the town context in their environment:
+  GT_EXT_PROTOCOL   protocol version (currently 1)
+  GT_BIN            path to gt, for queries
+  GT_VERSION        gt version
+  GT_TOWN_ROOT      town root (unset outside a town)
+  GT_RIG            rig inferred from the working directory
+  GT_ACTOR          caller identity (e.g., gastown/crew/max)
+
+To read gt state, extensions run '$GT_BIN ext query <topic>', which prints a
+JSON envelope: {"protocol_version":1,"topic":...,"data":...}, or
+{"protocol_version":1,"topic":...,"error":...} with exit status 1.
+
+Not to be confused with 'gt plugin', which manages Deacon patrol plugins.`,
+	RunE: requireSubcommand,
+}
+
+var extensionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List extensions found on PATH",
+	RunE:  runExtensionsList,
+}
+
+var extensionsQueryCmd = &cobra.Command{
+	Use:   "query <topic>",
+	Short: "Query gt state as JSON (for extensions)",
+	Long: `Query gt state as JSON, for use by extensions.
+
+Topics:
+  context   the context passed to extensions (town, rig, role, actor)
+  rigs      names of registered rigs
+  town      agent status for every rig`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExtensionsQuery,
+}
+
+func init() {
+	extensionsCmd.AddCommand(extensionsListCmd)
+	extensionsCmd.AddCommand(extensionsQueryCmd)
+	rootCmd.AddCommand(extensionsCmd)
+}
+
+// runExtension dispatches to a gt-<name> extension when args don't name a
+// built-in command. It reports whether an extension ran, and its exit code.
+func runExtension(args []string) (int, bool) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") || isBuiltinCommand(args) {
+		return 0, false
+	}
+	ext, rest := extension.Lookup(args)
+	if ext == nil {
+		return 0, false
+	}
+
+	code, err := extension.Run(ext, rest, extensionContext())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s running extension %s: %v\n", style.Error.Render("Error:"), ext.Path, err)
+	}
+	return code, true
+}
+
+// isBuiltinCommand reports whether args resolve to a built-in command,
+// including cobra's help and completion commands.
+func isBuiltinCommand(args []string) bool {
+	rootCmd.InitDefaultHelpCmd()
+	rootCmd.InitDefaultCompletionCmd()
+	c, _, err := rootCmd.Find(args)
+	return err == nil && c != rootCmd
+}
+
+// extensionContext gathers the town context handed to extensions.
+// Everything except the binary and version is best-effort.
+func extensionContext() extension.Context {
+	ctx := extension.Context{
+		ProtocolVersion: extension.ProtocolVersion,
+		Version:         Version,
+	}
+	if bin, err := os.Executable(); err == nil {
+		ctx.Bin = bin
+	} else {
+		ctx.Bin = os.Args[0]
+	}
+	ctx.Cwd, _ = os.Getwd()
+
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return ctx
+	}
+	ctx.TownRoot = townRoot
+	ctx.Actor = detectSender()
+	if info, err := GetRoleWithContext(ctx.Cwd, townRoot); err == nil {
+		ctx.Role = string(info.Role)
+		ctx.Rig = info.Rig
+	}
+	if ctx.Rig == "" {
+		if r, err := inferRigFromCwd(townRoot); err == nil {
+			ctx.Rig = r
+		}
+	}
+	return ctx
+}
+
+func runExtensionsList(cmd *cobra.Command, args []string) error {
+	exts := extension.List()
+	if len(exts) == 0 {
+		fmt.Printf("%s No extensions found (install executables named %s<name> on PATH)\n",
+			style.Dim.Render("○"), extension.Prefix)
+		return nil
+	}
+
+	for _, ext := range exts {
+		fmt.Printf("  %-20s %s\n", style.Bold.Render(ext.Name), style.Dim.Render(ext.Path))
+		if isBuiltinCommand(strings.Fields(ext.Name)) {
+			style.PrintWarning("gt %s is a built-in command; %s will never run", ext.Name, ext.Path)
+		}
+	}
+	return nil
+}
+
+func runExtensionsQuery(cmd *cobra.Command, args []string) error {
+	topic := args[0]
+	resp := extension.Response{ProtocolVersion: extension.ProtocolVersion, Topic: topic}
+
+	data, err := queryExtensionTopic(topic)
+	if err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Data = data
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if encErr := enc.Encode(resp); encErr != nil {
+		return encErr
+	}
+	if err != nil {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+func queryExtensionTopic(topic string) (interface{}, error) {
+	if topic == "context" {
+		return extensionContext(), nil
+	}
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return nil, fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	switch topic {
+	case "rigs":
+		return mayor.NewManager(townRoot).RigNames()
+	case "town":
+		return mayor.NewManager(townRoot).TownStatus()
+	default:
+		return nil, fmt.Errorf("unknown topic %q (valid: context, rigs, town)", topic)
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
 	"syscall"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/tui/feed"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -25,6 +28,8 @@ var (
 	feedNoFollow bool
 	feedWindow   bool
 	feedPlain    bool
+	feedBackfill int
+	feedAllRigs  bool
 )
 
 func init() {
@@ -39,6 +44,8 @@ func init() {
 	feedCmd.Flags().StringVar(&feedRig, "rig", "", "Run from specific rig's beads directory")
 	feedCmd.Flags().BoolVarP(&feedWindow, "window", "w", false, "Open in dedicated tmux window (creates 'feed' window)")
 	feedCmd.Flags().BoolVar(&feedPlain, "plain", false, "Use plain text output (bd activity) instead of TUI")
+	feedCmd.Flags().IntVar(&feedBackfill, "backfill", 50, "Past events to load into the TUI on startup (0 to start empty)")
+	feedCmd.Flags().BoolVar(&feedAllRigs, "all-rigs", false, "Merge every rig's events into one TUI feed")
 }
 
 var feedCmd = &cobra.Command{
@@ -52,12 +59,24 @@ By default, launches an interactive TUI dashboard with:
   - Convoy panel (middle): Shows in-progress and recently landed convoys
   - Event stream (bottom): Chronological feed you can scroll through
   - Vim-style navigation: j/k to scroll, tab to switch panels, 1/2/3 for panels, q to quit
+  - / to fuzzy-search events, f to filter (e.g. "rig:gastown type:fail
+    actor:joe"; keys rig, role, actor, type), esc to clear
+  - p to pause the event stream and scroll back through it; p again resumes
+  - In the event stream, enter opens the selected event's details with the
+    bead it references (status, description, recent comments); a attaches
+    to the agent's tmux session, esc goes back
+  - With --all-rigs, every rig's events in one stream, tagged with a rig
+    column in the rig's color; m mutes or unmutes rigs by name
 
 The feed combines multiple event sources:
   - Beads activity: Issue creates, updates, completions (from bd activity)
   - GT events: Agent activity like patrol, sling, handoff (from .events.jsonl)
   - Convoy status: In-progress and recently-landed convoys (refreshes every 10s)
 
+On startup the TUI loads the last --backfill events (default 50) from both
+event sources, so the stream shows what already happened; a separator marks
+where history ends and live events begin.
+
 Use --plain for simple text output (wraps bd activity only).
 
 Tmux Integration:
@@ -86,6 +105,8 @@ Examples:
   gt feed --plain               # Plain text output (bd activity)
   gt feed --window              # Open in dedicated tmux window
   gt feed --since 1h            # Events from last hour
+  gt feed --backfill 200        # Open the TUI on the last 200 events
+  gt feed --all-rigs            # One feed for every rig in the town
   gt feed --rig greenplace         # Use gastown rig's beads`,
 	RunE: runFeed,
 }
@@ -105,24 +126,11 @@ func runFeed(cmd *cobra.Command, args []string) error {
 
 	// If --rig specified, find that rig's beads directory
 	if feedRig != "" {
-		// Try common beads locations for the rig
-		candidates := []string{
-			fmt.Sprintf("%s/%s/mayor/rig", townRoot, feedRig),
-			fmt.Sprintf("%s/%s", townRoot, feedRig),
-		}
-
-		found := false
-		for _, candidate := range candidates {
-			if _, err := os.Stat(candidate + "/.beads"); err == nil {
-				workDir = candidate
-				found = true
-				break
-			}
-		}
-
-		if !found {
+		dir, ok := findRigFeedDir(townRoot, feedRig)
+		if !ok {
 			return fmt.Errorf("rig '%s' not found or has no .beads directory", feedRig)
 		}
+		workDir = dir
 	}
 
 	// Build bd activity command (without argv[0] for buildFeedCommand)
@@ -136,14 +144,57 @@ func runFeed(cmd *cobra.Command, args []string) error {
 	// Use TUI by default if running in a terminal and not --plain
 	useTUI := !feedPlain && term.IsTerminal(int(os.Stdout.Fd()))
 
+	if feedAllRigs {
+		if feedRig != "" {
+			return fmt.Errorf("--all-rigs and --rig are mutually exclusive")
+		}
+		if !useTUI {
+			return fmt.Errorf("--all-rigs needs the TUI (not --plain, and a terminal)")
+		}
+		dirs := allRigFeedDirs(townRoot)
+		if len(dirs) == 0 {
+			return fmt.Errorf("no rigs with a .beads directory found")
+		}
+		return runFeedTUI(workDir, dirs)
+	}
+
 	if useTUI {
-		return runFeedTUI(workDir)
+		return runFeedTUI(workDir, nil)
 	}
 
 	// Plain mode: exec bd activity directly
 	return runFeedDirect(workDir, bdArgs)
 }
 
+// findRigFeedDir returns the directory to run bd activity in for a rig: the
+// first of its common beads locations that has a .beads directory.
+func findRigFeedDir(townRoot, rigName string) (string, bool) {
+	candidates := []string{
+		filepath.Join(townRoot, rigName, "mayor", "rig"),
+		filepath.Join(townRoot, rigName),
+	}
+	for _, candidate := range candidates {
+		if _, err := os.Stat(filepath.Join(candidate, ".beads")); err == nil {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// allRigFeedDirs returns the beads directory of every rig in the town that
+// has one, sorted by rig.
+func allRigFeedDirs(townRoot string) []feed.HistoryDir {
+	names := discoverRigs(townRoot)
+	sort.Strings(names)
+	var dirs []feed.HistoryDir
+	for _, name := range names {
+		if dir, ok := findRigFeedDir(townRoot, name); ok {
+			dirs = append(dirs, feed.HistoryDir{Rig: name, WorkDir: dir})
+		}
+	}
+	return dirs
+}
+
 // buildFeedArgs builds the bd activity arguments based on flags.
 func buildFeedArgs() []string {
 	var args []string
@@ -195,8 +246,10 @@ func runFeedDirect(workDir string, bdArgs []string) error {
 	return syscall.Exec(bdPath, fullArgs, os.Environ())
 }
 
-// runFeedTUI runs the interactive TUI feed.
-func runFeedTUI(workDir string) error {
+// runFeedTUI runs the interactive TUI feed. With rigDirs, it merges the
+// events of every listed rig (and the town's own beads) into one feed;
+// otherwise it follows the beads in workDir.
+func runFeedTUI(workDir string, rigDirs []feed.HistoryDir) error {
 	// Must be in a Gas Town workspace
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
@@ -204,18 +257,49 @@ func runFeedTUI(workDir string) error {
 	}
 
 	var sources []feed.EventSource
+	var historyDirs []feed.HistoryDir
 
-	// Create event source from bd activity
-	bdSource, err := feed.NewBdActivitySource(workDir)
-	if err != nil {
-		return fmt.Errorf("creating bd activity source: %w", err)
-	}
-	sources = append(sources, bdSource)
+	if len(rigDirs) == 0 {
+		// Create event source from bd activity
+		bdSource, err := feed.NewBdActivitySource(workDir)
+		if err != nil {
+			return fmt.Errorf("creating bd activity source: %w", err)
+		}
+		sources = append(sources, bdSource)
 
-	// Create MQ event source (optional - don't fail if not available)
-	mqSource, err := feed.NewMQEventSourceFromWorkDir(workDir)
-	if err == nil {
-		sources = append(sources, mqSource)
+		// Create MQ event source (optional - don't fail if not available)
+		mqSource, err := feed.NewMQEventSourceFromWorkDir(workDir)
+		if err == nil {
+			sources = append(sources, mqSource)
+		}
+		historyDirs = []feed.HistoryDir{{WorkDir: workDir}}
+	} else {
+		// One bd activity and MQ source per rig, tagged with the rig so
+		// events that don't name one still land in the right column. A rig
+		// whose source can't start is left out rather than failing the feed.
+		for _, dir := range rigDirs {
+			bdSource, err := feed.NewBdActivitySource(dir.WorkDir)
+			if err != nil {
+				style.PrintWarning("skipping rig %s: %v", dir.Rig, err)
+				continue
+			}
+			sources = append(sources, feed.NewRigSource(dir.Rig, bdSource))
+			if mqSource, err := feed.NewMQEventSourceFromWorkDir(dir.WorkDir); err == nil {
+				sources = append(sources, feed.NewRigSource(dir.Rig, mqSource))
+			}
+			historyDirs = append(historyDirs, dir)
+		}
+		if len(historyDirs) == 0 {
+			return fmt.Errorf("no rig's bd activity could be started")
+		}
+
+		// Town-level beads (mail, mayor and deacon work)
+		if _, err := os.Stat(filepath.Join(townRoot, ".beads")); err == nil {
+			if bdSource, err := feed.NewBdActivitySource(townRoot); err == nil {
+				sources = append(sources, bdSource)
+				historyDirs = append(historyDirs, feed.HistoryDir{WorkDir: townRoot})
+			}
+		}
 	}
 
 	// Create GT events source (optional - don't fail if not available)
@@ -232,6 +316,17 @@ func runFeedTUI(workDir string) error {
 	m := feed.NewModel()
 	m.SetEventChannel(multiSource.Events())
 	m.SetTownRoot(townRoot)
+	if len(rigDirs) > 0 {
+		var names []string
+		for _, dir := range rigDirs {
+			names = append(names, dir.Rig)
+		}
+		m.SetRigs(names)
+	}
+
+	// Backfill after the sources are tailing, so nothing written in between
+	// is missed
+	m.SetHistory(feed.LoadHistory(townRoot, historyDirs, feedBackfill))
 
 	// Run the TUI
 	p := tea.NewProgram(m, tea.WithAltScreen())
@@ -282,53 +377,23 @@ func runFeedInWindow(workDir string, bdArgs []string) error {
 
 	// Check if 'feed' window already exists
 	windowTarget := sessionName + ":feed"
-	exists, err := windowExists(t, sessionName, "feed")
+	win, err := t.FindWindow(sessionName, "feed")
 	if err != nil {
 		return fmt.Errorf("checking for feed window: %w", err)
 	}
 
-	if exists {
+	if win != nil {
 		// Window exists - just switch to it
 		fmt.Printf("Switching to existing feed window...\n")
-		return selectWindow(t, windowTarget)
+		return t.SelectWindowTarget(windowTarget)
 	}
 
 	// Create new window named 'feed' with the bd activity command
 	fmt.Printf("Creating feed window in session %s...\n", sessionName)
-	if err := createWindow(t, sessionName, "feed", workDir, feedCmd); err != nil {
+	if _, err := t.NewWindow(sessionName, "feed", workDir, feedCmd); err != nil {
 		return fmt.Errorf("creating feed window: %w", err)
 	}
 
 	// Switch to the new window
-	return selectWindow(t, windowTarget)
-}
-
-// windowExists checks if a window with the given name exists in the session.
-// Note: getCurrentTmuxSession is defined in handoff.go
-func windowExists(_ *tmux.Tmux, session, windowName string) (bool, error) { // t unused: direct exec for simplicity
-	cmd := exec.Command("tmux", "list-windows", "-t", session, "-F", "#{window_name}")
-	out, err := cmd.Output()
-	if err != nil {
-		return false, err
-	}
-
-	for _, line := range strings.Split(string(out), "\n") {
-		if strings.TrimSpace(line) == windowName {
-			return true, nil
-		}
-	}
-	return false, nil
-}
-
-// createWindow creates a new tmux window with the given name and command.
-func createWindow(_ *tmux.Tmux, session, windowName, workDir, command string) error { // t unused: direct exec for simplicity
-	args := []string{"new-window", "-t", session, "-n", windowName, "-c", workDir, command}
-	cmd := exec.Command("tmux", args...)
-	return cmd.Run()
-}
-
-// selectWindow switches to the specified window.
-func selectWindow(_ *tmux.Tmux, target string) error { // t unused: direct exec for simplicity
-	cmd := exec.Command("tmux", "select-window", "-t", target)
-	return cmd.Run()
+	return t.SelectWindowTarget(windowTarget)
 }
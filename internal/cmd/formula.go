@@ -119,8 +119,9 @@ Examples:
   gt formula run shiny --pr=123           # Run on PR #123
   gt formula run security-audit --rig=beads  # Run in specific rig
   gt formula run release --dry-run        # Preview execution`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runFormulaRun,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runFormulaRun,
 }
 
 var formulaCreateCmd = &cobra.Command{
@@ -140,8 +141,9 @@ Examples:
   gt formula create my-task                  # Create task formula
   gt formula create my-workflow --type=workflow
   gt formula create nightly-check --type=patrol`,
-	Args: cobra.ExactArgs(1),
-	RunE: runFormulaCreate,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runFormulaCreate,
 }
 
 func init() {
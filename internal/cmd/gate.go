@@ -55,8 +55,9 @@ Examples:
   for gate in $(bd gate eval --json | jq -r '.closed[]'); do
     gt gate wake $gate
   done`,
-	Args: cobra.ExactArgs(1),
-	RunE: runGateWake,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runGateWake,
 }
 
 var (
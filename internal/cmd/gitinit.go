@@ -42,7 +42,8 @@ Examples:
   gt git-init                             # Init git with .gitignore
   gt git-init --github=user/repo          # Create private GitHub repo (default)
   gt git-init --github=user/repo --public # Create public GitHub repo`,
-	RunE: runGitInit,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runGitInit,
 }
 
 func init() {
@@ -48,7 +48,8 @@ for the next session without manual summarization.
 
 Any molecule on the hook will be auto-continued by the new session.
 The SessionStart hook runs 'gt prime' to restore context.`,
-	RunE: runHandoff,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runHandoff,
 }
 
 var (
@@ -210,7 +211,7 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 
 // getCurrentTmuxSession returns the current tmux session name.
 func getCurrentTmuxSession() (string, error) {
-	out, err := exec.Command("tmux", "display-message", "-p", "#{session_name}").Output()
+	out, err := tmux.Command("display-message", "-p", "#{session_name}").Output()
 	if err != nil {
 		return "", err
 	}
@@ -552,7 +553,7 @@ func handoffRemoteSession(t *tmux.Tmux, targetSession, restartCmd string) error
 	if handoffWatch {
 		fmt.Printf("Switching to %s...\n", targetSession)
 		// Use tmux switch-client to move our view to the target session
-		if err := exec.Command("tmux", "switch-client", "-t", targetSession).Run(); err != nil {
+		if err := tmux.Command("switch-client", "-t", targetSession).Run(); err != nil {
 			// Non-fatal - they can manually switch
 			fmt.Printf("Note: Could not auto-switch (use: tmux switch-client -t %s)\n", targetSession)
 		}
@@ -563,16 +564,15 @@ func handoffRemoteSession(t *tmux.Tmux, targetSession, restartCmd string) error
 
 // getSessionPane returns the pane identifier for a session's main pane.
 func getSessionPane(sessionName string) (string, error) {
-	// Get the pane ID for the first pane in the session
-	out, err := exec.Command("tmux", "list-panes", "-t", sessionName, "-F", "#{pane_id}").Output()
+	// The first pane of the session's first window
+	panes, err := tmux.NewTmux().ListPanes(sessionName)
 	if err != nil {
 		return "", err
 	}
-	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
-	if len(lines) == 0 || lines[0] == "" {
+	if len(panes) == 0 {
 		return "", fmt.Errorf("no panes found in session")
 	}
-	return lines[0], nil
+	return panes[0].ID, nil
 }
 
 // sendHandoffMail sends a handoff mail to self and auto-hooks it.
@@ -38,8 +38,9 @@ Related commands:
   gt sling <bead>    # Hook + start now (keep context)
   gt handoff <bead>  # Hook + restart (fresh context)
   gt unsling         # Remove work from hook`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runHookOrStatus,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runHookOrStatus,
 }
 
 // hookStatusCmd shows hook status (alias for mol status)
@@ -14,28 +14,51 @@ import (
 	"github.com/steveyegge/gastown/internal/style"
 )
 
-var initForce bool
+var (
+	initForce bool
+	initHQ    bool
+)
 
 var initCmd = &cobra.Command{
-	Use:     "init",
+	Use:     "init [path]",
 	GroupID: GroupWorkspace,
-	Short:   "Initialize current directory as a Gas Town rig",
+	Short:   "Initialize a Gas Town rig, or a town with --hq",
 	Long: `Initialize the current directory for use as a Gas Town rig.
 
 This creates the standard agent directories (polecats/, witness/, refinery/,
 mayor/) and updates .git/info/exclude to ignore them.
 
 The current directory must be a git repository. Use --force to reinitialize
-an existing rig structure.`,
-	RunE: runInit,
+an existing rig structure.
+
+With --hq, creates a new town root at path (default: current directory)
+instead, exactly like 'gt install': the mayor/town.json marker that gt uses
+to find the town, the rig registry (mayor/rigs.json), settings/, and the
+town beads database that holds mail.
+
+Examples:
+  gt init                  # Initialize this git repo as a rig
+  gt init --hq ~/gt        # Create a town at ~/gt`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runInit,
 }
 
 func init() {
 	initCmd.Flags().BoolVarP(&initForce, "force", "f", false, "Reinitialize existing structure")
+	initCmd.Flags().BoolVar(&initHQ, "hq", false, "Create a town root (same as gt install)")
 	rootCmd.AddCommand(initCmd)
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
+	if initHQ {
+		installForce = initForce
+		return runInstall(cmd, args)
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("a path is only accepted with --hq; run gt init from inside the rig's repository")
+	}
+
 	cwd, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("getting current directory: %w", err)
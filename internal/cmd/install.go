@@ -62,8 +62,9 @@ Examples:
   gt install ~/gt --github=user/repo           # Create private GitHub repo (default)
   gt install ~/gt --github=user/repo --public  # Create public GitHub repo
   gt install ~/gt --shell                      # Install shell integration (sets GT_TOWN_ROOT/GT_RIG)`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runInstall,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runInstall,
 }
 
 func init() {
@@ -307,6 +308,13 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Register the town so it can be selected with --town from anywhere
+	if err := workspace.RegisterTown(townName, absPath); err != nil {
+		fmt.Printf("   %s Could not register town: %v\n", style.Dim.Render("⚠"), err)
+	} else {
+		fmt.Printf("   ✓ Registered town %s (gt --town %s ...)\n", townName, townName)
+	}
+
 	fmt.Printf("\n%s HQ created successfully!\n", style.Bold.Render("✓"))
 	fmt.Println()
 	fmt.Println("Next steps:")
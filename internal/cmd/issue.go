@@ -15,16 +15,18 @@ var issueCmd = &cobra.Command{
 }
 
 var issueSetCmd = &cobra.Command{
-	Use:   "set <issue-id>",
-	Short: "Set the current issue (shown in tmux status line)",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runIssueSet,
+	Use:         "set <issue-id>",
+	Short:       "Set the current issue (shown in tmux status line)",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runIssueSet,
 }
 
 var issueClearCmd = &cobra.Command{
-	Use:   "clear",
-	Short: "Clear the current issue from status line",
-	RunE:  runIssueClear,
+	Use:         "clear",
+	Short:       "Clear the current issue from status line",
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runIssueClear,
 }
 
 var issueShowCmd = &cobra.Command{
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/townlog"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -348,6 +350,21 @@ func runLogCrash(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("logging event: %w", err)
 	}
 
+	// Polecat agents (rig/name) also get a typed exit for 'gt polecat last-exit'
+	if parts := strings.Split(crashAgent, "/"); len(parts) == 2 {
+		rigPath := filepath.Join(townRoot, parts[0])
+		if _, err := os.Stat(filepath.Join(rigPath, "polecats", parts[1])); err == nil {
+			exit := &polecat.ExitRecord{Polecat: parts[1], Reason: polecat.ExitAgentExit}
+			if crashExitCode >= 0 {
+				code := crashExitCode
+				exit.ExitCode = &code
+			}
+			if err := polecat.RecordExit(rigPath, exit); err != nil {
+				return fmt.Errorf("recording exit: %w", err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mail"
 )
 
 // Mail command flags
@@ -18,6 +21,11 @@ var (
 	mailNotify        bool
 	mailSendSelf      bool
 	mailCC            []string // CC recipients
+	mailAttach        []string // files to attach
+	mailTemplate      string
+	mailTemplateVars  []string // template variables as name=value
+	mailBead          string   // bead to link the message to
+	mailNoBead        bool
 	mailInboxJSON     bool
 	mailReadJSON      bool
 	mailInboxUnread   bool
@@ -40,6 +48,25 @@ var (
 	// Announces flags
 	mailAnnouncesJSON bool
 
+	// Routes flags
+	mailRoutesJSON bool
+
+	// Watch flags
+	mailWatchInterval time.Duration
+	mailWatchJSON     bool
+
+	// Compact flags
+	mailCompactAll    bool
+	mailCompactDryRun bool
+	mailCompactJSON   bool
+
+	// Bridge flags
+	mailBridgeOnce bool
+	mailBridgeJSON bool
+
+	// Templates flags
+	mailTemplatesJSON bool
+
 	// Clear flags
 	mailClearAll bool
 )
@@ -96,11 +123,14 @@ Addresses:
   <rig>/refinery   - Send to a rig's Refinery
   <rig>/<polecat>  - Send to a specific polecat
   <rig>/           - Broadcast to a rig
+  <rig>/*          - Every agent in a rig
+  <rig>/crew/*     - Every crew worker in a rig (also polecats/*, */witness)
+  <group>          - A named group from config/messaging.json "groups"
   list:<name>      - Send to a mailing list (fans out to all members)
 
-Mailing lists are defined in ~/gt/config/messaging.json and allow
-sending to multiple recipients at once. Each recipient gets their
-own copy of the message.
+Mailing lists and groups are defined in ~/gt/config/messaging.json and
+allow sending to multiple recipients at once. Each recipient gets their
+own copy of the message; copies of one fan-out share a broadcast ID.
 
 Message types:
   task          - Required processing
@@ -117,6 +147,28 @@ Priority levels:
 
 Use --urgent as shortcut for --priority 0.
 
+Attachments:
+  --attach copies a file (a git patch, a log excerpt, a design doc) into
+  the town's content-addressed attachment store and sends a reference
+  with the message, so large payloads don't have to fit in the body.
+  'gt mail read' lists each attachment with the path of its payload.
+
+Templates:
+  --template fills a structured form (handoff, escalation, review-request,
+  status-report) from --var name=value pairs. The subject is generated
+  unless -s is given, and the body starts with one "Key: value" line per
+  variable, followed by any -m text. The template also sets the message
+  type and priority unless --type or --priority is given.
+  'gt mail templates' lists each template's variables.
+
+Work tracking:
+  High-priority and urgent mail to a single agent is tracked as work: it
+  is linked to an open bead assigned to the recipient, whose priority is
+  raised to the message's if lower. The bead is the one given with --bead,
+  else the template's issue, else the bead of the message being replied
+  to; without one, a task bead is created. The bead is labeled with the
+  message's thread, and 'gt mail read' shows the bead. --no-bead skips it.
+
 Examples:
   gt mail send greenplace/Toast -s "Status check" -m "How's that bug fix going?"
   gt mail send mayor/ -s "Work complete" -m "Finished gt-abc"
@@ -126,9 +178,29 @@ Examples:
   gt mail send mayor/ -s "Re: Status" -m "Done" --reply-to msg-abc123
   gt mail send --self -s "Handoff" -m "Context for next session"
   gt mail send greenplace/Toast -s "Update" -m "Progress report" --cc overseer
-  gt mail send list:oncall -s "Alert" -m "System down"`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runMailSend,
+  gt mail send list:oncall -s "Alert" -m "System down"
+  gt mail send gastown/crew/* -s "Rebase" -m "main moved"
+  gt mail send backend-team -s "Standup" -m "Post status by 10:00"
+  gt mail send gastown/crew/max -s "Patch" -m "Try this" --attach fix.patch
+  gt mail send --self --template handoff --var issue=gt-123 --var next="finish tests"`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMailSend,
+}
+
+var mailTemplatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List the message templates for 'gt mail send --template'",
+	Long: `List the structured message templates and their variables.
+
+Send a templated message with:
+  gt mail send <address> --template <name> --var <name>=<value> ...
+
+Examples:
+  gt mail templates
+  gt mail templates --json`,
+	Args: cobra.NoArgs,
+	RunE: runMailTemplates,
 }
 
 var mailInboxCmd = &cobra.Command{
@@ -154,14 +226,17 @@ Examples:
 }
 
 var mailReadCmd = &cobra.Command{
-	Use:   "read <message-id>",
+	Use:   "read [message-id]",
 	Short: "Read a message",
-	Long: `Read a specific message (does not mark as read).
+	Long: `Read a message (does not mark as read).
 
-The message ID can be found from 'gt mail inbox'.
-Use 'gt mail mark-read' to mark messages as read.`,
+Without a message ID, shows the oldest unread message in your inbox, so
+repeated 'gt mail read' / 'gt mail archive <id>' works through the inbox
+in order. The message ID can be found from 'gt mail inbox'.
+Use 'gt mail mark-read' to mark messages as read, and 'gt mail reply' to
+answer in the same thread.`,
 	Aliases: []string{"show"},
-	Args: cobra.ExactArgs(1),
+	Args: cobra.MaximumNArgs(1),
 	RunE: runMailRead,
 }
 
@@ -181,8 +256,9 @@ var mailDeleteCmd = &cobra.Command{
 	Long: `Delete (acknowledge) a message.
 
 This closes the message in beads.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runMailDelete,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMailDelete,
 }
 
 var mailArchiveCmd = &cobra.Command{
@@ -195,14 +271,14 @@ Removes the messages from your inbox by closing them in beads.
 Examples:
   gt mail archive hq-abc123
   gt mail archive hq-abc123 hq-def456 hq-ghi789`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runMailArchive,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMailArchive,
 }
 
 var mailMarkReadCmd = &cobra.Command{
-	Use:     "mark-read <message-id> [message-id...]",
-	Aliases: []string{"ack"},
-	Short:   "Mark messages as read without archiving",
+	Use:   "mark-read <message-id> [message-id...]",
+	Short: "Mark messages as read without archiving",
 	Long: `Mark one or more messages as read without removing them from inbox.
 
 This adds a 'read' label to the message, which is reflected in the inbox display.
@@ -214,8 +290,26 @@ for reference or follow-up.
 Examples:
   gt mail mark-read hq-abc123
   gt mail mark-read hq-abc123 hq-def456`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runMailMarkRead,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMailMarkRead,
+}
+
+var mailAckCmd = &cobra.Command{
+	Use:   "ack <message-id> [message-id...]",
+	Short: "Acknowledge messages",
+	Long: `Acknowledge one or more messages: you have read them and acted on them.
+
+Acknowledged messages stay in your inbox, marked as acked. Senders can tell
+a handoff was actually picked up rather than just read, and 'gt crew status'
+shows how many messages each worker has acknowledged.
+
+Examples:
+  gt mail ack hq-abc123
+  gt mail ack hq-abc123 hq-def456`,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMailAck,
 }
 
 var mailMarkUnreadCmd = &cobra.Command{
@@ -223,13 +317,15 @@ var mailMarkUnreadCmd = &cobra.Command{
 	Short: "Mark messages as unread",
 	Long: `Mark one or more messages as unread.
 
-This removes the 'read' label from the message.
+This removes the 'read' label from the message. Legacy inboxes also clear
+any acknowledgement.
 
 Examples:
   gt mail mark-unread hq-abc123
   gt mail mark-unread hq-abc123 hq-def456`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runMailMarkUnread,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMailMarkUnread,
 }
 
 var mailCheckCmd = &cobra.Command{
@@ -280,8 +376,9 @@ This is a convenience command that automatically:
 Examples:
   gt mail reply msg-abc123 -m "Thanks, working on it now"
   gt mail reply msg-abc123 -s "Custom subject" -m "Reply body"`,
-	Args: cobra.ExactArgs(1),
-	RunE: runMailReply,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMailReply,
 }
 
 var mailClaimCmd = &cobra.Command{
@@ -305,8 +402,9 @@ Pattern examples: "*" (anyone), "gastown/polecats/*" (specific rig crew).
 Examples:
   gt mail claim work-requests   # Claim from specific queue
   gt mail claim                 # Claim from any eligible queue`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runMailClaim,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMailClaim,
 }
 
 var mailReleaseCmd = &cobra.Command{
@@ -331,8 +429,9 @@ ERROR CASES:
 
 Examples:
   gt mail release hq-abc123    # Release a claimed message`,
-	Args: cobra.ExactArgs(1),
-	RunE: runMailRelease,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMailRelease,
 }
 
 var mailClearCmd = &cobra.Command{
@@ -355,8 +454,9 @@ Examples:
   gt mail clear                      # Clear your inbox
   gt mail clear gastown/polecats/joe # Clear joe's inbox
   gt mail clear mayor/               # Clear mayor's inbox`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runMailClear,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMailClear,
 }
 
 var mailSearchCmd = &cobra.Command{
@@ -420,9 +520,130 @@ Examples:
 	RunE: runMailAnnounces,
 }
 
+var mailRoutesCmd = &cobra.Command{
+	Use:   "routes [address]",
+	Short: "Show where mail to each agent is delivered",
+	Long: `Show the town's mail routing table.
+
+The table is built from mayor/rigs.json and each rig's crew and polecat
+directories: every agent address (mayor/, deacon/, <rig>/witness,
+<rig>/refinery, <rig>/crew/<name>, <rig>/polecats/<name>) maps to the
+workspace it lives in. The short form <rig>/<name> resolves to a polecat,
+or to a crew worker if no polecat has that name.
+
+Given an address, checks that it can be delivered to and shows its route.
+'gt mail send' runs the same check before sending.
+
+Examples:
+  gt mail routes
+  gt mail routes gastown/crew/max
+  gt mail routes --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMailRoutes,
+}
+
+var mailWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Announce new mail in recipients' tmux sessions",
+	Long: `Watch the town's mailboxes and tell agents when mail arrives.
+
+When a message lands in an agent's local inbox (e.g., a crew worker's
+mail/inbox.jsonl) and the agent has a running tmux session, a line like
+
+  📬 You have new mail from mayor/. Subject: ... Run 'gt mail inbox' to read.
+
+is injected into the session. Mail already in an inbox when the watcher
+starts is not announced. Beads-routed mail is announced by 'gt mail send'
+itself and is not repeated here.
+
+Mailboxes are polled every --interval; run the watcher in its own window
+or under the daemon, and stop it with Ctrl-C.
+
+Examples:
+  gt mail watch
+  gt mail watch --interval 10s --json`,
+	Args: cobra.NoArgs,
+	RunE: runMailWatch,
+}
+
+var mailCompactCmd = &cobra.Command{
+	Use:   "compact [address]",
+	Short: "Archive old read mail to keep inboxes small",
+	Long: `Move read mail beyond a mailbox's retention policy into its compressed
+archive (archive.jsonl.gz beside the mailbox).
+
+Read mail older than the policy's max_age is archived, then the oldest
+remaining read messages until the mailbox holds at most max_messages.
+Unread and pinned mail is always kept. Archived mail stays searchable with
+'gt mail search'.
+
+Policies are set per mailbox in ~/gt/config/messaging.json:
+
+  "retention": {
+    "default":        {"max_age": "720h"},
+    "gastown/crew/*": {"max_messages": 200, "max_age": "168h"}
+  }
+
+Keys are addresses or wildcard patterns; "default" covers the rest.
+Without any policy, mailboxes keep 1000 messages and 30 days of read mail.
+
+Compacts your own mailbox by default, the given address, or with --all
+every mailbox in the town (including crew workers' local inboxes).
+
+Examples:
+  gt mail compact
+  gt mail compact gastown/crew/max --dry-run
+  gt mail compact --all`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMailCompact,
+}
+
+var mailBridgeCmd = &cobra.Command{
+	Use:   "bridge",
+	Short: "Bridge the overseer's mailbox to a real email account",
+	Long: `Forward mail addressed to the overseer (or its alias, human) to the
+overseer's email, and deliver their email replies back into Gas Town.
+
+Each unread overseer message is sent once over SMTP. Replies are fetched
+over IMAP from the bridge's own mailbox; a reply to a forwarded message is
+delivered to that message's sender in the same thread, and any other email
+from the overseer goes to the mayor. Only mail from the overseer's address
+is ingested, and quoted history is stripped.
+
+Forwarded subjects carry a "[gastown #<token>]" tag; keep it when replying.
+A From address is easy to forge, so a reply is only accepted with the token
+of the message it answers, and any other email only if the receiving mail
+server reports a DMARC pass in Authentication-Results. Rejected emails are
+marked read and reported.
+
+Configure the bridge in ~/gt/settings/mail-bridge.json:
+
+  {
+    "type": "mail-bridge",
+    "version": 1,
+    "from": "gastown@example.com",
+    "smtp": {"host": "smtp.example.com", "username": "gastown@example.com",
+             "password_env": "GT_MAIL_PASSWORD"},
+    "imap": {"host": "imap.example.com", "username": "gastown@example.com",
+             "password_env": "GT_MAIL_PASSWORD"},
+    "poll_interval": "1m"
+  }
+
+The overseer's address defaults to the email in mayor/overseer.json.
+Without "imap" the bridge only forwards.
+
+Examples:
+  gt mail bridge
+  gt mail bridge --once --json`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMailBridge,
+}
+
 func init() {
 	// Send flags
-	mailSendCmd.Flags().StringVarP(&mailSubject, "subject", "s", "", "Message subject (required)")
+	mailSendCmd.Flags().StringVarP(&mailSubject, "subject", "s", "", "Message subject (required unless --template)")
 	mailSendCmd.Flags().StringVarP(&mailBody, "message", "m", "", "Message body")
 	mailSendCmd.Flags().IntVar(&mailPriority, "priority", 2, "Message priority (0=urgent, 1=high, 2=normal, 3=low, 4=backlog)")
 	mailSendCmd.Flags().BoolVar(&mailUrgent, "urgent", false, "Set priority=0 (urgent)")
@@ -434,7 +655,11 @@ func init() {
 	mailSendCmd.Flags().BoolVar(&mailPermanent, "permanent", false, "Send as permanent (not ephemeral, synced to remote)")
 	mailSendCmd.Flags().BoolVar(&mailSendSelf, "self", false, "Send to self (auto-detect from cwd)")
 	mailSendCmd.Flags().StringArrayVar(&mailCC, "cc", nil, "CC recipients (can be used multiple times)")
-	_ = mailSendCmd.MarkFlagRequired("subject") // cobra flags: error only at runtime if missing
+	mailSendCmd.Flags().StringArrayVar(&mailAttach, "attach", nil, "Attach a file (can be used multiple times)")
+	mailSendCmd.Flags().StringVar(&mailTemplate, "template", "", "Fill a message template (see 'gt mail templates')")
+	mailSendCmd.Flags().StringArrayVar(&mailTemplateVars, "var", nil, "Template variable as name=value (can be used multiple times)")
+	mailSendCmd.Flags().StringVar(&mailBead, "bead", "", "Link the message to this bead, raising its priority to the message's")
+	mailSendCmd.Flags().BoolVar(&mailNoBead, "no-bead", false, "Don't track high-priority mail as a bead")
 
 	// Inbox flags
 	mailInboxCmd.Flags().BoolVar(&mailInboxJSON, "json", false, "Output as JSON")
@@ -470,6 +695,25 @@ func init() {
 	// Announces flags
 	mailAnnouncesCmd.Flags().BoolVar(&mailAnnouncesJSON, "json", false, "Output as JSON")
 
+	// Routes flags
+	mailRoutesCmd.Flags().BoolVar(&mailRoutesJSON, "json", false, "Output as JSON")
+
+	// Watch flags
+	mailWatchCmd.Flags().DurationVar(&mailWatchInterval, "interval", mail.DefaultNotifyInterval, "How often to check mailboxes")
+	mailWatchCmd.Flags().BoolVar(&mailWatchJSON, "json", false, "Print each notification as JSON")
+
+	// Compact flags
+	mailCompactCmd.Flags().BoolVar(&mailCompactAll, "all", false, "Compact every mailbox in the town")
+	mailCompactCmd.Flags().BoolVar(&mailCompactDryRun, "dry-run", false, "Show what would be archived without changing anything")
+	mailCompactCmd.Flags().BoolVar(&mailCompactJSON, "json", false, "Output as JSON")
+
+	// Bridge flags
+	mailBridgeCmd.Flags().BoolVar(&mailBridgeOnce, "once", false, "Run a single pass and exit")
+	mailBridgeCmd.Flags().BoolVar(&mailBridgeJSON, "json", false, "Print each bridged message as JSON")
+
+	// Templates flags
+	mailTemplatesCmd.Flags().BoolVar(&mailTemplatesJSON, "json", false, "Output as JSON")
+
 	// Clear flags
 	mailClearCmd.Flags().BoolVar(&mailClearAll, "all", false, "Clear all messages (default behavior)")
 
@@ -481,6 +725,7 @@ func init() {
 	mailCmd.AddCommand(mailDeleteCmd)
 	mailCmd.AddCommand(mailArchiveCmd)
 	mailCmd.AddCommand(mailMarkReadCmd)
+	mailCmd.AddCommand(mailAckCmd)
 	mailCmd.AddCommand(mailMarkUnreadCmd)
 	mailCmd.AddCommand(mailCheckCmd)
 	mailCmd.AddCommand(mailThreadCmd)
@@ -490,6 +735,11 @@ func init() {
 	mailCmd.AddCommand(mailClearCmd)
 	mailCmd.AddCommand(mailSearchCmd)
 	mailCmd.AddCommand(mailAnnouncesCmd)
+	mailCmd.AddCommand(mailRoutesCmd)
+	mailCmd.AddCommand(mailWatchCmd)
+	mailCmd.AddCommand(mailCompactCmd)
+	mailCmd.AddCommand(mailBridgeCmd)
+	mailCmd.AddCommand(mailTemplatesCmd)
 
 	rootCmd.AddCommand(mailCmd)
 }
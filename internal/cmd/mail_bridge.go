@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/mailbridge"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// runMailBridge forwards overseer mail to email and ingests replies until
+// interrupted, or for one pass with --once.
+func runMailBridge(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	cfg, err := config.LoadMailBridgeConfig(config.MailBridgeConfigPath(townRoot))
+	if err != nil {
+		if errors.Is(err, config.ErrNotFound) {
+			return fmt.Errorf("mail bridge not configured: create %s (see 'gt mail bridge --help')", config.MailBridgeConfigPath(townRoot))
+		}
+		return err
+	}
+	bridge, err := mailbridge.New(townRoot, cfg)
+	if err != nil {
+		return err
+	}
+
+	onEvent := func(e mailbridge.Event) {
+		if mailBridgeJSON {
+			_ = json.NewEncoder(os.Stdout).Encode(e)
+			return
+		}
+		if e.Direction == "rejected" {
+			fmt.Printf("%s %s rejected unauthenticated email from %s: %s\n", style.Dim.Render(time.Now().Format("15:04:05")),
+				style.Warning.Render("⚠"), e.From, e.Subject)
+			return
+		}
+		arrow := "→"
+		if e.Direction == "in" {
+			arrow = "←"
+		}
+		fmt.Printf("%s %s %s %s: %s\n", style.Dim.Render(time.Now().Format("15:04:05")),
+			style.Success.Render("✉"), arrow, e.To, e.Subject)
+	}
+
+	if mailBridgeOnce {
+		events, err := bridge.Poll()
+		for _, e := range events {
+			onEvent(e)
+		}
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !mailBridgeJSON {
+		fmt.Printf("Bridging overseer mail to %s every %s (Ctrl-C to stop)\n", bridge.Email(), cfg.Interval())
+	}
+	onError := func(err error) {
+		fmt.Fprintf(os.Stderr, "%s %v\n", style.Warning.Render("⚠"), err)
+	}
+	if err := bridge.Run(ctx, onEvent, onError); err != nil && err != context.Canceled {
+		return err
+	}
+	return nil
+}
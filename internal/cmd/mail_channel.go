@@ -69,15 +69,17 @@ var channelCreateCmd = &cobra.Command{
 Retention policy:
   --retain-count=N  Keep only last N messages (0 = unlimited)
   --retain-hours=N  Delete messages older than N hours (0 = forever)`,
-	Args: cobra.ExactArgs(1),
-	RunE: runChannelCreate,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runChannelCreate,
 }
 
 var channelDeleteCmd = &cobra.Command{
-	Use:   "delete <name>",
-	Short: "Delete a channel",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runChannelDelete,
+	Use:         "delete <name>",
+	Short:       "Delete a channel",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runChannelDelete,
 }
 
 var channelSubscribeCmd = &cobra.Command{
@@ -86,16 +88,18 @@ var channelSubscribeCmd = &cobra.Command{
 	Long: `Subscribe the current identity (BD_ACTOR) to a channel.
 
 Subscribers receive messages broadcast to the channel.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runChannelSubscribe,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runChannelSubscribe,
 }
 
 var channelUnsubscribeCmd = &cobra.Command{
-	Use:   "unsubscribe <name>",
-	Short: "Unsubscribe from a channel",
-	Long:  `Unsubscribe the current identity (BD_ACTOR) from a channel.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runChannelUnsubscribe,
+	Use:         "unsubscribe <name>",
+	Short:       "Unsubscribe from a channel",
+	Long:        `Unsubscribe the current identity (BD_ACTOR) from a channel.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runChannelUnsubscribe,
 }
 
 var channelSubscribersCmd = &cobra.Command{
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// compactTarget is one mailbox to compact.
+type compactTarget struct {
+	Address string `json:"address"`
+	Local   bool   `json:"local,omitempty"` // legacy JSONL inbox rather than beads
+	mailbox *mail.Mailbox
+}
+
+// compactOutcome is the JSON form of one mailbox's compaction.
+type compactOutcome struct {
+	compactTarget
+	*mail.CompactResult
+	Error string `json:"error,omitempty"`
+}
+
+// runMailCompact archives read mail beyond each mailbox's retention policy.
+func runMailCompact(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	cfg, err := config.LoadOrCreateMessagingConfig(config.MessagingConfigPath(townRoot))
+	if err != nil {
+		return err
+	}
+	table, err := mail.LoadRoutingTable(townRoot)
+	if err != nil {
+		return err
+	}
+	router := mail.NewRouterWithTownRoot(townRoot, townRoot)
+
+	var routes []*mail.Route
+	switch {
+	case mailCompactAll:
+		if len(args) > 0 {
+			return fmt.Errorf("--all and an address are mutually exclusive")
+		}
+		routes = table.Routes()
+	default:
+		address := detectSender()
+		if len(args) > 0 {
+			address = args[0]
+		}
+		route, err := table.Lookup(address)
+		if err != nil {
+			route = &mail.Route{Address: address} // e.g. overseer: beads only
+		}
+		routes = []*mail.Route{route}
+	}
+
+	var targets []compactTarget
+	for _, r := range routes {
+		mailbox, err := router.GetMailbox(r.Address)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, compactTarget{Address: r.Address, mailbox: mailbox})
+		if r.WorkDir == "" {
+			continue
+		}
+		local := mail.NewMailbox(r.MailDir())
+		if _, err := os.Stat(local.Path()); err == nil {
+			targets = append(targets, compactTarget{Address: r.Address, Local: true, mailbox: local})
+		}
+	}
+
+	var outcomes []compactOutcome
+	failed := 0
+	for _, t := range targets {
+		result, err := t.mailbox.Compact(mail.RetentionFor(cfg, t.Address), mailCompactDryRun)
+		outcome := compactOutcome{compactTarget: t, CompactResult: result}
+		if err != nil {
+			outcome.Error = err.Error()
+			failed++
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	if mailCompactJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(outcomes); err != nil {
+			return err
+		}
+	} else {
+		printCompactOutcomes(outcomes)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d mailbox(es) failed to compact", failed)
+	}
+	return nil
+}
+
+func printCompactOutcomes(outcomes []compactOutcome) {
+	verb := "archived"
+	if mailCompactDryRun {
+		verb = "would archive"
+	}
+	for _, o := range outcomes {
+		name := o.Address
+		if o.Local {
+			name += " (local inbox)"
+		}
+		switch {
+		case o.Error != "":
+			fmt.Printf("%s %s: %s\n", style.Error.Render("✗"), name, o.Error)
+		case o.Archived == 0:
+			fmt.Printf("%s %s: nothing to compact (%d kept)\n", style.Dim.Render("○"), name, o.Kept)
+		default:
+			fmt.Printf("%s %s: %s %d, kept %d\n", style.Success.Render("✓"), name, verb, o.Archived, o.Kept)
+		}
+	}
+}
@@ -65,32 +65,36 @@ Members can be specified as positional arguments or with --member flags.
 Examples:
   gt mail group create ops-team gastown/witness gastown/crew/max
   gt mail group create ops-team --member gastown/witness --member gastown/crew/max`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runGroupCreate,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runGroupCreate,
 }
 
 var groupAddCmd = &cobra.Command{
-	Use:   "add <name> <member>",
-	Short: "Add member to group",
-	Long:  "Add a new member to an existing group.",
-	Args:  cobra.ExactArgs(2),
-	RunE:  runGroupAdd,
+	Use:         "add <name> <member>",
+	Short:       "Add member to group",
+	Long:        "Add a new member to an existing group.",
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runGroupAdd,
 }
 
 var groupRemoveCmd = &cobra.Command{
-	Use:   "remove <name> <member>",
-	Short: "Remove member from group",
-	Long:  "Remove a member from an existing group.",
-	Args:  cobra.ExactArgs(2),
-	RunE:  runGroupRemove,
+	Use:         "remove <name> <member>",
+	Short:       "Remove member from group",
+	Long:        "Remove a member from an existing group.",
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runGroupRemove,
 }
 
 var groupDeleteCmd = &cobra.Command{
-	Use:   "delete <name>",
-	Short: "Delete a group",
-	Long:  "Permanently delete a mail distribution group.",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runGroupDelete,
+	Use:         "delete <name>",
+	Short:       "Delete a group",
+	Long:        "Permanently delete a mail distribution group.",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runGroupDelete,
 }
 
 func init() {
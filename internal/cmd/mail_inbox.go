@@ -110,11 +110,6 @@ func runMailInbox(cmd *cobra.Command, args []string) error {
 }
 
 func runMailRead(cmd *cobra.Command, args []string) error {
-	if len(args) == 0 {
-		return errors.New("msgID argument required")
-	}
-	msgID := args[0]
-
 	// Determine which inbox
 	address := detectSender()
 
@@ -123,9 +118,21 @@ func runMailRead(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	msg, err := mailbox.Get(msgID)
-	if err != nil {
-		return fmt.Errorf("getting message: %w", err)
+	var msg *mail.Message
+	if len(args) == 0 {
+		msg, err = oldestUnread(mailbox)
+		if err != nil {
+			return err
+		}
+		if msg == nil {
+			fmt.Printf("%s No unread messages\n", style.Dim.Render("○"))
+			return nil
+		}
+	} else {
+		msg, err = mailbox.Get(args[0])
+		if err != nil {
+			return fmt.Errorf("getting message: %w", err)
+		}
 	}
 
 	// Note: We intentionally do NOT mark as read/ack on read.
@@ -157,21 +164,69 @@ func runMailRead(cmd *cobra.Command, args []string) error {
 	fmt.Printf("To: %s\n", msg.To)
 	fmt.Printf("Date: %s\n", msg.Timestamp.Format("2006-01-02 15:04:05"))
 	fmt.Printf("ID: %s\n", style.Dim.Render(msg.ID))
+	fmt.Printf("State: %s\n", msg.State())
 
 	if msg.ThreadID != "" {
 		fmt.Printf("Thread: %s\n", style.Dim.Render(msg.ThreadID))
 	}
 	if msg.ReplyTo != "" {
-		fmt.Printf("Reply-To: %s\n", style.Dim.Render(msg.ReplyTo))
+		fmt.Printf("In-Reply-To: %s\n", style.Dim.Render(msg.ReplyTo))
+	}
+	if len(msg.References) > 1 {
+		fmt.Printf("References: %s\n", style.Dim.Render(strings.Join(msg.References, " ")))
+	}
+	if msg.BroadcastID != "" {
+		fmt.Printf("Broadcast: %s\n", style.Dim.Render(msg.BroadcastID))
+	}
+	if msg.Bead != "" {
+		fmt.Printf("Bead: %s\n", msg.Bead)
 	}
 
 	if msg.Body != "" {
 		fmt.Printf("\n%s\n", msg.Body)
 	}
 
+	if len(msg.Attachments) > 0 {
+		store := mailbox.Attachments()
+		fmt.Printf("\n%s\n", style.Bold.Render("Attachments:"))
+		for _, a := range msg.Attachments {
+			path := store.Path(a)
+			if _, err := os.Stat(path); err != nil {
+				path = "(payload missing)"
+			}
+			fmt.Printf("  %s (%s) %s\n", a.Name, formatAttachmentSize(a.Size), style.Dim.Render(path))
+		}
+	}
+
 	return nil
 }
 
+// formatAttachmentSize renders a byte count for display.
+func formatAttachmentSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+// oldestUnread returns the oldest unread message in mailbox, or nil.
+func oldestUnread(mailbox *mail.Mailbox) (*mail.Message, error) {
+	unread, err := mailbox.ListUnread()
+	if err != nil {
+		return nil, fmt.Errorf("listing messages: %w", err)
+	}
+	var oldest *mail.Message
+	for _, msg := range unread {
+		if oldest == nil || msg.Timestamp.Before(oldest.Timestamp) {
+			oldest = msg
+		}
+	}
+	return oldest, nil
+}
+
 func runMailPeek(cmd *cobra.Command, args []string) error {
 	// Determine which inbox
 	address := detectSender()
@@ -321,6 +376,39 @@ func runMailMarkRead(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runMailAck(cmd *cobra.Command, args []string) error {
+	mailbox, err := getMailbox(detectSender())
+	if err != nil {
+		return err
+	}
+
+	acked := 0
+	var errors []string
+	for _, msgID := range args {
+		if err := mailbox.Ack(msgID); err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", msgID, err))
+		} else {
+			acked++
+		}
+	}
+
+	if len(errors) > 0 {
+		fmt.Printf("%s Acknowledged %d/%d messages\n",
+			style.Bold.Render("⚠"), acked, len(args))
+		for _, e := range errors {
+			fmt.Printf("  Error: %s\n", e)
+		}
+		return fmt.Errorf("failed to acknowledge %d messages", len(errors))
+	}
+
+	if len(args) == 1 {
+		fmt.Printf("%s Message acknowledged\n", style.Bold.Render("✓"))
+	} else {
+		fmt.Printf("%s Acknowledged %d messages\n", style.Bold.Render("✓"), acked)
+	}
+	return nil
+}
+
 func runMailMarkUnread(cmd *cobra.Command, args []string) error {
 	// Determine which inbox
 	address := detectSender()
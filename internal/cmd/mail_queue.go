@@ -457,8 +457,9 @@ Examples:
   gt mail queue create work --claimers 'gastown/polecats/*'
   gt mail queue create dispatch --claimers 'gastown/crew/*'
   gt mail queue create urgent --claimers '*'`,
-	Args: cobra.ExactArgs(1),
-	RunE: runMailQueueCreate,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMailQueueCreate,
 }
 
 var mailQueueShowCmd = &cobra.Command{
@@ -497,8 +498,9 @@ This permanently removes the queue bead. Messages in the queue are not affected.
 
 Examples:
   gt mail queue delete work`,
-	Args: cobra.ExactArgs(1),
-	RunE: runMailQueueDelete,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMailQueueDelete,
 }
 
 func init() {
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// runMailRoutes prints the town's mail routing table, or checks one address.
+func runMailRoutes(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	table, err := mail.LoadRoutingTable(townRoot)
+	if err != nil {
+		return err
+	}
+
+	routes := table.Routes()
+	if len(args) == 1 {
+		if err := table.ValidateAddress(args[0]); err != nil {
+			return err
+		}
+		route, err := table.Lookup(args[0])
+		if err != nil {
+			// Valid but not a single agent (a rig, group, list, ...)
+			fmt.Printf("%s %s is deliverable (expanded when sent)\n", style.Success.Render("✓"), args[0])
+			return nil
+		}
+		routes = []*mail.Route{route}
+	}
+
+	if mailRoutesJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(routes)
+	}
+
+	width := 0
+	for _, r := range routes {
+		width = max(width, len(r.Address))
+	}
+	for _, r := range routes {
+		fmt.Printf("%-*s  %-8s  %s\n", width, r.Address, r.Role, style.Dim.Render(r.WorkDir))
+	}
+	return nil
+}
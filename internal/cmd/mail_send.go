@@ -5,11 +5,11 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
-	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -72,13 +72,56 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 	} else {
 		msg.Priority = mail.PriorityFromInt(mailPriority)
 	}
-	if mailNotify && msg.Priority == mail.PriorityNormal {
-		msg.Priority = mail.PriorityHigh
-	}
 
 	// Set message type
 	msg.Type = mail.ParseMessageType(mailType)
 
+	// Fill a template; explicit flags win over its defaults
+	var workBead string // existing bead to track the message with
+	if mailTemplate != "" {
+		tmpl, err := mail.LookupTemplate(mailTemplate)
+		if err != nil {
+			return err
+		}
+		vars, err := mail.ParseTemplateVars(mailTemplateVars)
+		if err != nil {
+			return err
+		}
+		subject, body, err := tmpl.Render(vars, mailBody)
+		if err != nil {
+			return err
+		}
+		workBead = vars["issue"]
+		if msg.Subject == "" {
+			msg.Subject = subject
+		}
+		msg.Body = body
+		if !cmd.Flags().Changed("type") {
+			msg.Type = tmpl.Type
+		}
+		if !mailUrgent && !cmd.Flags().Changed("priority") {
+			msg.Priority = tmpl.Priority
+		}
+	} else if len(mailTemplateVars) > 0 {
+		return fmt.Errorf("--var requires --template")
+	}
+	if msg.Subject == "" {
+		return fmt.Errorf("subject required (use --subject or --template)")
+	}
+
+	// Decide on work tracking before --notify raises the priority
+	if mailBead != "" && mailNoBead {
+		return fmt.Errorf("--bead and --no-bead are mutually exclusive")
+	}
+	trackWork := mailBead != "" || (!mailNoBead && mail.TracksWork(msg.Priority))
+	if mailBead != "" {
+		workBead = mailBead
+	}
+
+	if mailNotify && msg.Priority == mail.PriorityNormal {
+		msg.Priority = mail.PriorityHigh
+	}
+
 	// Set pinned flag
 	msg.Pinned = mailPinned
 
@@ -88,6 +131,18 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 	// Set CC recipients
 	msg.CC = mailCC
 
+	// Store attachments before anything is sent
+	if len(mailAttach) > 0 {
+		store := mail.NewRouter(workDir).Attachments()
+		for _, path := range mailAttach {
+			a, err := store.Put(path)
+			if err != nil {
+				return fmt.Errorf("attaching %s: %w", path, err)
+			}
+			msg.Attachments = append(msg.Attachments, a)
+		}
+	}
+
 	// Handle reply-to: auto-set type to reply and look up thread
 	if mailReplyTo != "" {
 		msg.ReplyTo = mailReplyTo
@@ -101,6 +156,10 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 		if err == nil {
 			if original, err := mailbox.Get(mailReplyTo); err == nil {
 				msg.ThreadID = original.ThreadID
+				msg.References = mail.ReplyReferences(original)
+				if workBead == "" {
+					workBead = original.Bead
+				}
 			}
 		}
 	}
@@ -119,17 +178,62 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		// Fall back to legacy routing if resolver fails
 		router := mail.NewRouter(workDir)
+		if err := router.ValidateAddress(to); err != nil {
+			return err
+		}
 		if err := router.Send(msg); err != nil {
 			return fmt.Errorf("sending message: %w", err)
 		}
-		_ = events.LogFeed(events.TypeMail, from, events.MailPayload(to, mailSubject))
 		fmt.Printf("%s Message sent to %s\n", style.Bold.Render("✓"), to)
-		fmt.Printf("  Subject: %s\n", mailSubject)
+		fmt.Printf("  Subject: %s\n", msg.Subject)
 		return nil
 	}
 
-	// Route based on recipient type
+	// Route based on recipient type, after checking every recipient can be
+	// reached so a typo doesn't leave a partial fan-out behind
 	router := mail.NewRouter(workDir)
+	for _, rec := range recipients {
+		if rec.Type == mail.RecipientAgent {
+			if err := router.ValidateAddress(rec.Address); err != nil {
+				return err
+			}
+		}
+	}
+	for _, cc := range msg.CC {
+		if err := router.ValidateAddress(cc); err != nil {
+			return fmt.Errorf("cc: %w", err)
+		}
+	}
+	// One message fanned out to many agents shares a broadcast ID
+	agentCount := 0
+	for _, rec := range recipients {
+		if rec.Type == mail.RecipientAgent {
+			agentCount++
+		}
+	}
+	if agentCount > 1 {
+		msg.BroadcastID = generateBroadcastID()
+	}
+
+	// Track high-priority mail to one agent as a bead assigned to it
+	var work *mail.WorkLink
+	if trackWork && agentCount == 1 {
+		for _, rec := range recipients {
+			if rec.Type != mail.RecipientAgent {
+				continue
+			}
+			linked := *msg
+			linked.To = rec.Address
+			work, err = mail.LinkWork(workTrackerFor(townRoot, rec.Address), &linked, workBead)
+			if err != nil {
+				style.PrintWarning("could not track message as work: %v", err)
+			}
+			msg.Bead = linked.Bead
+		}
+	} else if mailBead != "" {
+		msg.Bead = mailBead
+	}
+
 	var recipientAddrs []string
 
 	for _, rec := range recipients {
@@ -161,20 +265,35 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Log mail event to activity feed
-	_ = events.LogFeed(events.TypeMail, from, events.MailPayload(to, mailSubject))
-
 	fmt.Printf("%s Message sent to %s\n", style.Bold.Render("✓"), to)
-	fmt.Printf("  Subject: %s\n", mailSubject)
+	fmt.Printf("  Subject: %s\n", msg.Subject)
 
 	// Show resolved recipients if fan-out occurred
 	if len(recipientAddrs) > 1 || (len(recipientAddrs) == 1 && recipientAddrs[0] != to) {
 		fmt.Printf("  Recipients: %s\n", strings.Join(recipientAddrs, ", "))
 	}
 
+	if msg.BroadcastID != "" {
+		fmt.Printf("  Broadcast: %s\n", msg.BroadcastID)
+	}
+	if work != nil {
+		switch {
+		case work.Created:
+			fmt.Printf("  Bead: %s (created)\n", work.BeadID)
+		case work.Bumped:
+			fmt.Printf("  Bead: %s (priority raised to %s)\n", work.BeadID, msg.Priority)
+		default:
+			fmt.Printf("  Bead: %s\n", work.BeadID)
+		}
+	} else if msg.Bead != "" {
+		fmt.Printf("  Bead: %s\n", msg.Bead)
+	}
 	if len(msg.CC) > 0 {
 		fmt.Printf("  CC: %s\n", strings.Join(msg.CC, ", "))
 	}
+	for _, a := range msg.Attachments {
+		fmt.Printf("  Attached: %s (%s)\n", a.Name, formatAttachmentSize(a.Size))
+	}
 	if msg.Type != mail.TypeNotification {
 		fmt.Printf("  Type: %s\n", msg.Type)
 	}
@@ -182,9 +301,36 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// workTrackerFor returns the beads database picker for tracking mail to
+// recipient: an existing bead lives in the database its prefix routes to,
+// and new beads go in the recipient's rig (or the town, for town agents).
+func workTrackerFor(townRoot, recipient string) func(beadID string) mail.WorkTracker {
+	return func(beadID string) mail.WorkTracker {
+		if beadID != "" {
+			if rigPath := beads.GetRigPathForPrefix(townRoot, beads.ExtractPrefix(beadID)); rigPath != "" {
+				return beads.New(rigPath)
+			}
+			return beads.New(townRoot)
+		}
+		rig, _, _ := strings.Cut(recipient, "/")
+		if rig == "" || rig == "mayor" || rig == "deacon" || rig == "overseer" {
+			return beads.New(townRoot)
+		}
+		return beads.New(filepath.Join(townRoot, rig))
+	}
+}
+
 // generateThreadID creates a random thread ID for new message threads.
 func generateThreadID() string {
 	b := make([]byte, 6)
 	_, _ = rand.Read(b) // crypto/rand.Read only fails on broken system
 	return "thread-" + hex.EncodeToString(b)
 }
+
+// generateBroadcastID creates the ID shared by the copies of a fanned-out
+// message.
+func generateBroadcastID() string {
+	b := make([]byte, 6)
+	_, _ = rand.Read(b) // crypto/rand.Read only fails on broken system
+	return "bcast-" + hex.EncodeToString(b)
+}
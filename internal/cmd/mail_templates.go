@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// runMailTemplates lists the message templates and their variables.
+func runMailTemplates(cmd *cobra.Command, args []string) error {
+	var list []*mail.Template
+	for _, name := range mail.TemplateNames() {
+		tmpl, err := mail.LookupTemplate(name)
+		if err != nil {
+			return err
+		}
+		list = append(list, tmpl)
+	}
+
+	if mailTemplatesJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	}
+
+	for i, tmpl := range list {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s  %s\n", style.Bold.Render(tmpl.Name), style.Dim.Render(tmpl.Description))
+		fmt.Printf("  type: %s, priority: %s\n", tmpl.Type, tmpl.Priority)
+		for _, f := range tmpl.Fields {
+			req := ""
+			if f.Required {
+				req = " (required)"
+			}
+			fmt.Printf("  --var %s=...%s  %s\n", f.Name, req, style.Dim.Render(f.Description))
+		}
+	}
+	return nil
+}
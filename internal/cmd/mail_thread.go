@@ -115,14 +115,15 @@ func runMailReply(cmd *cobra.Command, args []string) error {
 
 	// Create reply message
 	reply := &mail.Message{
-		From:     from,
-		To:       original.From, // Reply to sender
-		Subject:  subject,
-		Body:     mailReplyMessage,
-		Type:     mail.TypeReply,
-		Priority: mail.PriorityNormal,
-		ReplyTo:  msgID,
-		ThreadID: original.ThreadID,
+		From:       from,
+		To:         original.From, // Reply to sender
+		Subject:    subject,
+		Body:       mailReplyMessage,
+		Type:       mail.TypeReply,
+		Priority:   mail.PriorityNormal,
+		ReplyTo:    msgID,
+		ThreadID:   original.ThreadID,
+		References: mail.ReplyReferences(original),
 	}
 
 	// If original has no thread ID, create one
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// runMailWatch polls the town's mailboxes and announces new mail in the
+// recipients' sessions until interrupted.
+func runMailWatch(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !mailWatchJSON {
+		fmt.Printf("Watching mailboxes every %s (Ctrl-C to stop)\n", mailWatchInterval)
+	}
+	onNotice := func(n mail.Notice) {
+		if mailWatchJSON {
+			_ = json.NewEncoder(os.Stdout).Encode(n)
+			return
+		}
+		fmt.Printf("%s %s %s ← %s: %s\n", style.Dim.Render(time.Now().Format("15:04:05")),
+			style.Success.Render("📬"), n.Address, n.From, n.Subject)
+	}
+	onError := func(err error) {
+		fmt.Fprintf(os.Stderr, "%s %v\n", style.Warning.Render("⚠"), err)
+	}
+	if err := mail.NewNotifier(townRoot).Run(ctx, mailWatchInterval, onNotice, onError); err != nil && err != context.Canceled {
+		return err
+	}
+	return nil
+}
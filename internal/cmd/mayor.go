@@ -41,7 +41,8 @@ var mayorStartCmd = &cobra.Command{
 
 Creates a new detached tmux session for the Mayor and launches Claude.
 The session runs in the workspace root directory.`,
-	RunE: runMayorStart,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMayorStart,
 }
 
 var mayorStopCmd = &cobra.Command{
@@ -50,7 +51,8 @@ var mayorStopCmd = &cobra.Command{
 	Long: `Stop the Mayor tmux session.
 
 Attempts graceful shutdown first (Ctrl-C), then kills the tmux session.`,
-	RunE: runMayorStop,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMayorStop,
 }
 
 var mayorAttachCmd = &cobra.Command{
@@ -61,7 +63,8 @@ var mayorAttachCmd = &cobra.Command{
 
 Attaches the current terminal to the Mayor's tmux session.
 Detach with Ctrl-B D.`,
-	RunE: runMayorAttach,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMayorAttach,
 }
 
 var mayorStatusCmd = &cobra.Command{
@@ -77,7 +80,8 @@ var mayorRestartCmd = &cobra.Command{
 	Long: `Restart the Mayor tmux session.
 
 Stops the current session (if running) and starts a fresh one.`,
-	RunE: runMayorRestart,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMayorRestart,
 }
 
 func init() {
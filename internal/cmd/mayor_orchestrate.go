@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	mayorTownJSON       bool
+	mayorDirectiveBody  string
+	mayorDirectiveRigs  []string
+	mayorDirectivePrio  string
+	mayorReassignReason string
+	mayorReassignSling  bool
+	mayorRefreshMessage string
+)
+
+var mayorTownCmd = &cobra.Command{
+	Use:   "town",
+	Short: "Show town-wide status across all rigs",
+	Long: `Show a cross-rig summary for coordination.
+
+For each rig, lists whether the witness and refinery are running and which
+polecats and crew workers exist.
+
+Examples:
+  gt mayor town
+  gt mayor town --json`,
+	RunE: runMayorTown,
+}
+
+var mayorDirectiveCmd = &cobra.Command{
+	Use:   "directive <subject>",
+	Short: "Broadcast a directive to rigs as mail",
+	Long: `Mail a directive from the Mayor to every agent in one or more rigs.
+
+Unlike 'gt broadcast' (which nudges live sessions), directives are durable
+mail: agents that are not running will see them on their next session.
+Without --rig, the directive goes to all rigs.
+
+Examples:
+  gt mayor directive "Freeze merges" -m "Release branch cut at 17:00"
+  gt mayor directive "Prioritize auth bugs" --rig gastown --rig beads --priority high`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMayorDirective,
+}
+
+var mayorReassignCmd = &cobra.Command{
+	Use:   "reassign <bead-id> <rig>",
+	Short: "Move work from its current assignee to another rig",
+	Long: `Reassign a bead to another rig.
+
+The bead is released (status open, no assignee), the previous assignee is
+mailed to stop work, and the target rig's witness is mailed to pick it up.
+With --sling, the bead is dispatched to a polecat in the target rig right away.
+
+Examples:
+  gt mayor reassign gt-abc beads --reason "belongs to the beads repo"
+  gt mayor reassign gt-abc beads --sling`,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMayorReassign,
+}
+
+var mayorHandoffCmd = &cobra.Command{
+	Use:   "handoff",
+	Short: "Show the Mayor's handoff notes",
+	Long: `Show the contents of the Mayor's pinned handoff bead.
+
+Update the notes and cycle the session with 'gt mayor refresh -m <notes>'.`,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMayorHandoff,
+}
+
+var mayorRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Context cycle the Mayor with handoff notes",
+	Long: `Record handoff notes on the Mayor's handoff bead and restart the session.
+
+The fresh session picks up the notes from the handoff bead on startup.
+
+Examples:
+  gt mayor refresh
+  gt mayor refresh -m "Waiting on beads release; gt-123 blocked on review"`,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMayorRefresh,
+}
+
+func init() {
+	mayorTownCmd.Flags().BoolVar(&mayorTownJSON, "json", false, "Output as JSON")
+
+	mayorDirectiveCmd.Flags().StringVarP(&mayorDirectiveBody, "message", "m", "", "Directive body")
+	mayorDirectiveCmd.Flags().StringArrayVar(&mayorDirectiveRigs, "rig", nil, "Rig to address (repeatable, default all rigs)")
+	mayorDirectiveCmd.Flags().StringVar(&mayorDirectivePrio, "priority", "", "Mail priority (low, normal, high, urgent)")
+
+	mayorReassignCmd.Flags().StringVar(&mayorReassignReason, "reason", "", "Why the work is moving")
+	mayorReassignCmd.Flags().BoolVar(&mayorReassignSling, "sling", false, "Dispatch to a polecat in the target rig immediately")
+
+	mayorRefreshCmd.Flags().StringVarP(&mayorRefreshMessage, "message", "m", "", "Handoff notes for the next session")
+	mayorRefreshCmd.Flags().StringVar(&mayorAgentOverride, "agent", "", "Agent alias to run the Mayor with (overrides town default)")
+
+	mayorCmd.AddCommand(mayorTownCmd)
+	mayorCmd.AddCommand(mayorDirectiveCmd)
+	mayorCmd.AddCommand(mayorReassignCmd)
+	mayorCmd.AddCommand(mayorHandoffCmd)
+	mayorCmd.AddCommand(mayorRefreshCmd)
+}
+
+func runMayorTown(cmd *cobra.Command, args []string) error {
+	mgr, err := getMayorManager()
+	if err != nil {
+		return err
+	}
+
+	summary, err := mgr.TownStatus()
+	if err != nil {
+		return err
+	}
+
+	if mayorTownJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summary)
+	}
+
+	mayorState := style.Dim.Render("○ stopped")
+	if summary.MayorRunning {
+		mayorState = style.Success.Render("● running")
+	}
+	fmt.Printf("%s  %s\n\n", style.Bold.Render("Mayor"), mayorState)
+
+	if len(summary.Rigs) == 0 {
+		fmt.Println(style.Dim.Render("No rigs registered. Add one with: gt rig add <name> <git-url>"))
+		return nil
+	}
+
+	for _, rs := range summary.Rigs {
+		fmt.Printf("%s\n", style.Bold.Render(rs.Name))
+		fmt.Printf("  witness:  %s   refinery: %s\n", agentStateMark(rs.Witness), agentStateMark(rs.Refinery))
+		fmt.Printf("  polecats: %s\n", joinOrNone(rs.Polecats))
+		fmt.Printf("  crew:     %s\n", joinOrNone(rs.Crew))
+	}
+	return nil
+}
+
+// agentStateMark renders a running/stopped indicator.
+func agentStateMark(running bool) string {
+	if running {
+		return style.Success.Render("●")
+	}
+	return style.Dim.Render("○")
+}
+
+// joinOrNone joins names for display, or returns a dimmed placeholder.
+func joinOrNone(names []string) string {
+	if len(names) == 0 {
+		return style.Dim.Render("(none)")
+	}
+	return strings.Join(names, ", ")
+}
+
+func runMayorDirective(cmd *cobra.Command, args []string) error {
+	mgr, err := getMayorManager()
+	if err != nil {
+		return err
+	}
+
+	var priority mail.Priority
+	if mayorDirectivePrio != "" {
+		priority = mail.ParsePriority(mayorDirectivePrio)
+	}
+
+	results, err := mgr.Directive(args[0], mayorDirectiveBody, priority, mayorDirectiveRigs)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, res := range results {
+		if res.Error != nil {
+			failed++
+			fmt.Printf("%s %s: %v\n", style.Error.Render("✗"), res.Rig, res.Error)
+			continue
+		}
+		fmt.Printf("%s Directive sent to @rig/%s\n", style.Bold.Render("✓"), res.Rig)
+	}
+	if failed > 0 {
+		return fmt.Errorf("directive failed for %d of %d rig(s)", failed, len(results))
+	}
+	return nil
+}
+
+func runMayorReassign(cmd *cobra.Command, args []string) error {
+	mgr, err := getMayorManager()
+	if err != nil {
+		return err
+	}
+
+	beadID, toRig := args[0], args[1]
+	r, err := mgr.Reassign(beadID, toRig, mayorReassignReason)
+	if err != nil {
+		return err
+	}
+
+	from := r.PreviousAssignee
+	if from == "" {
+		from = "unassigned"
+	}
+	fmt.Printf("%s Reassigned %s (%s): %s → %s\n",
+		style.Bold.Render("✓"), r.BeadID, r.Title, from, r.ToRig)
+
+	if mayorReassignSling {
+		return runSling(cmd, []string{beadID, toRig})
+	}
+	fmt.Printf("Dispatch with: %s\n", style.Dim.Render(fmt.Sprintf("gt sling %s %s", beadID, toRig)))
+	return nil
+}
+
+func runMayorHandoff(cmd *cobra.Command, args []string) error {
+	mgr, err := getMayorManager()
+	if err != nil {
+		return err
+	}
+
+	notes, err := mgr.HandoffContent()
+	if err != nil {
+		return fmt.Errorf("reading handoff bead: %w", err)
+	}
+	if notes == "" {
+		fmt.Println(style.Dim.Render("No Mayor handoff notes."))
+		return nil
+	}
+	fmt.Println(notes)
+	return nil
+}
+
+func runMayorRefresh(cmd *cobra.Command, args []string) error {
+	mgr, err := getMayorManager()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Refreshing Mayor session...")
+	if err := mgr.Refresh(mayorRefreshMessage, mayorAgentOverride); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Mayor refreshed. Attach with: %s\n",
+		style.Bold.Render("✓"), style.Dim.Render("gt mayor attach"))
+	return nil
+}
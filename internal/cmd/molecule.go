@@ -6,7 +6,24 @@ import (
 
 // Molecule command flags
 var (
-	moleculeJSON bool
+	moleculeJSON      bool
+	moleculeNewFile   string
+	moleculeNewNoEdit bool
+
+	moleculeInstantiateVars   []string
+	moleculeInstantiateAssign bool
+	moleculeInstantiateRig    string
+	moleculeInstantiateDryRun bool
+
+	moleculeCancelReason  string
+	moleculeCancelCascade bool
+
+	moleculeGraphFormat string
+
+	moleculeInstancesSince string
+
+	moleculeAttachPush bool
+	moleculeTargetID   string // --molecule: a molecule on the attachment stack
 )
 
 var moleculeCmd = &cobra.Command{
@@ -24,6 +41,7 @@ VIEWING YOUR WORK:
   gt hook              Show what's on your hook
   gt mol current       Show what you should be working on
   gt mol progress      Show execution progress
+  gt mol graph <id>    Export the step graph as Mermaid or Graphviz
 
 WORKING ON STEPS:
   gt mol step done     Complete current step (auto-continues)
@@ -33,6 +51,15 @@ LIFECYCLE:
   gt mol detach        Detach molecule from your hook
   gt mol burn          Discard attached molecule (no record)
   gt mol squash        Compress to digest (permanent record)
+  gt mol cancel <id>   Abandon a half-executed molecule instance
+
+AUTHORING:
+  gt mol new <id>      Scaffold a proto and open it in $EDITOR
+  gt mol lint <file>   Check a proto before it hits the database
+
+  gt mol instantiate   Create a molecule's steps under a parent issue
+  gt mol instances     List the instances of a molecule proto
+  gt mol show <id>     Show a proto's context variables and steps
 
 TO DISPATCH WORK (with molecules):
   gt sling mol-xxx target   # Pour formula + sling to agent
@@ -47,10 +74,13 @@ var moleculeProgressCmd = &cobra.Command{
 
 Given a root issue (the parent of molecule steps), displays:
 - Total steps and completion status
-- Which steps are done, in-progress, ready, or blocked
-- Overall progress percentage
+- The step dependency graph, level by level, with each step's state
+  (done, in-progress, ready, or blocked) and what it needs
+- The critical path: the longest chain of unfinished steps (★)
+- Remaining work, estimated from how long finished steps took
 
 This is useful for the Witness to monitor molecule execution.
+Use --json for the full graph.
 
 Example:
   gt molecule progress gt-abc`,
@@ -69,11 +99,18 @@ is stored in the pinned bead's description and visible via 'bd show'.
 When called with a single argument from an agent working directory, the
 pinned bead ID is auto-detected from the current agent's hook.
 
+Attached molecules form a stack. By default attach replaces the top of the
+stack. With --push, the current molecule is suspended instead: the new one
+goes on top, and detaching, burning or squashing it resumes the suspended
+molecule. Use this when interrupted mid-molecule by higher-priority work.
+
 Examples:
-  gt molecule attach gt-abc mol-xyz  # Explicit pinned bead
-  gt molecule attach mol-xyz         # Auto-detect from cwd`,
-	Args: cobra.RangeArgs(1, 2),
-	RunE: runMoleculeAttach,
+  gt molecule attach gt-abc mol-xyz         # Explicit pinned bead
+  gt molecule attach mol-xyz                # Auto-detect from cwd
+  gt molecule attach --push mol-urgent      # Suspend current, work on mol-urgent`,
+	Args:        cobra.RangeArgs(1, 2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMoleculeAttach,
 }
 
 var moleculeDetachCmd = &cobra.Command{
@@ -81,18 +118,23 @@ var moleculeDetachCmd = &cobra.Command{
 	Short: "Detach molecule from a pinned bead",
 	Long: `Remove molecule attachment from a pinned/handoff bead.
 
-This clears the attached_molecule and attached_at fields from the bead.
+This detaches the molecule on top of the attachment stack; the most recently
+suspended molecule (if any) resumes. Use --molecule to remove a specific
+molecule from anywhere in the stack.
 
-Example:
-  gt molecule detach gt-abc`,
-	Args: cobra.ExactArgs(1),
-	RunE: runMoleculeDetach,
+Examples:
+  gt molecule detach gt-abc
+  gt molecule detach gt-abc --molecule mol-xyz`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMoleculeDetach,
 }
 
 var moleculeAttachmentCmd = &cobra.Command{
 	Use:   "attachment <pinned-bead-id>",
 	Short: "Show attachment status of a pinned bead",
-	Long: `Show which molecule is attached to a pinned bead.
+	Long: `Show which molecule is attached to a pinned bead, and any molecules
+suspended below it on the attachment stack.
 
 Example:
   gt molecule attachment gt-abc`,
@@ -120,8 +162,9 @@ Behavior:
 
 Example:
   gt mol attach-from-mail msg-abc123`,
-	Args: cobra.ExactArgs(1),
-	RunE: runMoleculeAttachFromMail,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMoleculeAttachFromMail,
 }
 
 var moleculeStatusCmd = &cobra.Command{
@@ -181,11 +224,14 @@ This discards the molecule without creating a permanent record. Use this
 when abandoning work or when a molecule doesn't need an audit trail.
 
 If no target is specified, burns the current agent's attached molecule.
+Burns the top of the attachment stack, resuming the molecule suspended below
+it; use --molecule to burn a specific attached molecule instead.
 
 For wisps, burning is the default completion action. For regular molecules,
 consider using 'squash' instead to preserve an audit trail.`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runMoleculeBurn,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMoleculeBurn,
 }
 
 var moleculeSquashCmd = &cobra.Command{
@@ -196,13 +242,21 @@ var moleculeSquashCmd = &cobra.Command{
 This condenses a completed molecule's execution into a compact record.
 The digest preserves:
 - What molecule was executed
-- When it ran
-- Summary of results
+- When it ran, and its total wall time
+- Its step beads, and which were completed
+- Commits made for it: commits whose message mentions the molecule or one
+  of its steps (e.g. "fix: thing (gt-abc.2)" or a "Refs: gt-abc" trailer),
+  and commits on a polecat branch named for one of them
+- A "## Trace" section with all of the above as JSON, for tooling
 
 Use this for patrol cycles and other operational work that should have
-a permanent (but compact) record.`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runMoleculeSquash,
+a permanent (but compact) record.
+
+Squashes the top of the attachment stack, resuming the molecule suspended
+below it; use --molecule to squash a specific attached molecule instead.`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMoleculeSquash,
 }
 
 var moleculeStepCmd = &cobra.Command{
@@ -223,6 +277,182 @@ IMPORTANT: Always use 'gt mol step done' to complete steps. Do not manually
 close steps with 'bd close' - that skips the auto-continuation logic.`,
 }
 
+var moleculeNewCmd = &cobra.Command{
+	Use:   "new <id>",
+	Short: "Scaffold a molecule proto and open it in your editor",
+	Long: `Create a molecule proto file from a starter template and open it in
+$VISUAL or $EDITOR (default vi).
+
+When the editor exits the proto is linted (see 'gt mol lint'). If there are
+problems you can re-open the editor to fix them.
+
+The proto is written to <id>.md in the current directory unless --file is
+given. It is a markdown molecule definition:
+
+  # Title
+  Var: <name>                      # declares a context variable
+  ## Step: <ref>
+  <instructions, may use {{name}}>
+  Needs: <ref>, <ref>              # optional
+  Tier: haiku|sonnet|opus          # optional
+  If: <expression>                 # optional, e.g. db == true
+
+Examples:
+  gt mol new mol-deploy
+  gt mol new mol-deploy --file protos/deploy.md
+  gt mol new mol-deploy --no-edit   # just write the scaffold`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMoleculeNew,
+}
+
+var moleculeLintCmd = &cobra.Command{
+	Use:   "lint <file>",
+	Short: "Check a molecule proto file for problems",
+	Long: `Check a molecule proto file without touching the database.
+
+Reports:
+  - Duplicate step refs and Needs: on unknown steps
+  - Dependency cycles
+  - Unknown tiers (valid: haiku, sonnet, opus)
+  - Invalid If: expressions
+  - Context variables used in {{placeholders}} or If: expressions but not
+    declared with a Var: line
+
+Exits non-zero if any problem is found.
+
+Examples:
+  gt mol lint mol-deploy.md
+  gt mol lint mol-deploy.md --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMoleculeLint,
+}
+
+var moleculeInstantiateCmd = &cobra.Command{
+	Use:   "instantiate <proto-id> <parent-id>",
+	Short: "Create a molecule's step beads under a parent issue",
+	Long: `Instantiate a molecule proto: create one child bead of the parent per
+step, with dependencies wired from the proto.
+
+The proto is looked up in the beads database, then in the molecule catalog
+(molecules.jsonl at town, rig, and project level). Context variables given
+with --var fill {{name}} placeholders and decide If: conditions.
+
+If the proto declares its context variables with Var: lines (see
+'gt mol show'), missing required variables are asked for when running in a
+terminal, and otherwise fail the command before anything is created.
+Defaults fill in for variables not given, values are checked against
+their declared types, and placeholders for undeclared variables are errors.
+
+With --assign, steps that are ready right away go to idle workers of the
+parent's rig: crew members and polecats whose session is running and who
+have nothing hooked or in progress. A step with a Tier: only goes to a
+worker running that tier of model (from crew.json or role_agents). The
+assignee is written onto the step bead and the worker gets a
+STEP_ASSIGNED mail. Steps no idle worker matches stay unassigned.
+
+With --dry-run, nothing is written: the steps that would be created are
+printed with their titles after context substitution, their dependencies,
+any {{variables}} the context leaves unresolved, and with --assign the
+worker each ready step would go to.
+
+Examples:
+  gt mol instantiate mol-deploy gt-abc --var env=prod --var db=true
+  gt mol instantiate mol-deploy gt-abc --assign
+  gt mol instantiate mol-deploy gt-abc --assign --rig gastown --json
+  gt mol instantiate mol-deploy gt-abc --var env=prod --assign --dry-run`,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMoleculeInstantiate,
+}
+
+var moleculeInstancesCmd = &cobra.Command{
+	Use:   "instances <proto-id>",
+	Short: "List the instances of a molecule proto",
+	Long: `List every instance of a molecule proto with its step progress and how
+long it has taken, then how long each proto step takes across instances,
+slowest first, to show where a workflow's time goes. Running steps past
+their SLA are flagged.
+
+A step's time runs from when it was picked up (started_at, recorded by
+'gt mol step done' when it pins the next step) to when it closed. Steps
+picked up some other way are timed from when they became ready.
+
+Step beads are labeled instantiated_from:<proto-id> when they are created,
+so instances are found with a single label query rather than a scan of
+every issue. Instances created before steps were labeled are not listed.
+
+Examples:
+  gt mol instances mol-deploy
+  gt mol instances mol-deploy --since 7d
+  gt mol instances mol-deploy --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMoleculeInstances,
+}
+
+var moleculeShowCmd = &cobra.Command{
+	Use:   "show <proto-id>",
+	Short: "Show a molecule proto's context variables and steps",
+	Long: `Show a molecule proto: the context variables it declares and its steps
+with their dependencies, tiers, and If: conditions.
+
+Context variables are declared above the first step, one per line:
+
+  Var: <name> [string|int|bool] [required|optional] [default=<value>] [-- <description>]
+
+The type defaults to string. A variable is required unless it has a
+default or is marked optional; optional variables that aren't given
+expand to the empty string. For example:
+
+  Var: env string required -- Environment to deploy to
+  Var: replicas int default=3 -- Pods to run
+  Var: migrate bool default=false
+
+Examples:
+  gt mol show mol-deploy
+  gt mol show mol-deploy --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMoleculeShow,
+}
+
+var moleculeGraphCmd = &cobra.Command{
+	Use:   "graph <root-issue-id>",
+	Short: "Export a molecule instance's step graph as Mermaid or Graphviz",
+	Long: `Print the step dependency graph of a molecule instance as a Mermaid
+flowchart or a Graphviz digraph, to embed in docs and PR descriptions.
+
+Steps are colored by state (done, in progress, ready, blocked), the
+critical path is drawn thick, and dependencies outside the molecule are
+dashed. Steps that can run in parallel sit side by side.
+
+Examples:
+  gt mol graph gt-abc                       # Mermaid (default)
+  gt mol graph gt-abc --format dot | dot -Tsvg > molecule.svg`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMoleculeGraph,
+}
+
+var moleculeCancelCmd = &cobra.Command{
+	Use:   "cancel <instance-root-id>",
+	Short: "Cancel a molecule instance and close its open steps",
+	Long: `Abandon a half-executed molecule instance.
+
+Closes every open step bead under the instance root with a cancellation
+reason, detaches the instance from any handoff or agent bead it is attached
+to (with an audit entry), and records a digest of what was done and what
+was cancelled. The root issue itself is left alone.
+
+Steps that host a nested molecule instance are left open unless --cascade
+is given, in which case the nested instances are cancelled too.
+
+Examples:
+  gt mol cancel gt-abc
+  gt mol cancel gt-abc --reason "requirements changed"
+  gt mol cancel gt-abc --cascade --json`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMoleculeCancel,
+}
 
 func init() {
 	// Progress flags
@@ -237,11 +467,44 @@ func init() {
 	// Current flags
 	moleculeCurrentCmd.Flags().BoolVar(&moleculeJSON, "json", false, "Output as JSON")
 
+	// Attach/detach flags
+	moleculeAttachCmd.Flags().BoolVar(&moleculeAttachPush, "push", false, "Suspend the attached molecule and push this one on top")
+	moleculeDetachCmd.Flags().StringVar(&moleculeTargetID, "molecule", "", "Molecule to detach (default: top of the stack)")
+
 	// Burn flags
 	moleculeBurnCmd.Flags().BoolVar(&moleculeJSON, "json", false, "Output as JSON")
+	moleculeBurnCmd.Flags().StringVar(&moleculeTargetID, "molecule", "", "Attached molecule to burn (default: top of the stack)")
 
 	// Squash flags
 	moleculeSquashCmd.Flags().BoolVar(&moleculeJSON, "json", false, "Output as JSON")
+	moleculeSquashCmd.Flags().StringVar(&moleculeTargetID, "molecule", "", "Attached molecule to squash (default: top of the stack)")
+
+	// Graph flags
+	moleculeGraphCmd.Flags().StringVar(&moleculeGraphFormat, "format", graphFormatMermaid, "Output format: mermaid or dot")
+
+	// Cancel flags
+	moleculeCancelCmd.Flags().StringVar(&moleculeCancelReason, "reason", "", "Why the molecule is being cancelled")
+	moleculeCancelCmd.Flags().BoolVar(&moleculeCancelCascade, "cascade", false, "Also cancel nested molecule instances")
+	moleculeCancelCmd.Flags().BoolVar(&moleculeJSON, "json", false, "Output as JSON")
+
+	// Authoring flags
+	moleculeNewCmd.Flags().StringVarP(&moleculeNewFile, "file", "f", "", "Path to write the proto (default <id>.md)")
+	moleculeNewCmd.Flags().BoolVar(&moleculeNewNoEdit, "no-edit", false, "Write the scaffold without opening an editor")
+	moleculeLintCmd.Flags().BoolVar(&moleculeJSON, "json", false, "Output as JSON")
+
+	// Instantiate flags
+	moleculeInstantiateCmd.Flags().StringArrayVar(&moleculeInstantiateVars, "var", nil, "Context variable as name=value (repeatable)")
+	moleculeInstantiateCmd.Flags().BoolVar(&moleculeInstantiateAssign, "assign", false, "Assign ready steps to idle workers matching their tier")
+	moleculeInstantiateCmd.Flags().StringVar(&moleculeInstantiateRig, "rig", "", "Rig whose workers take steps (default: the parent's rig)")
+	moleculeInstantiateCmd.Flags().BoolVar(&moleculeInstantiateDryRun, "dry-run", false, "Show the steps that would be created without creating them")
+	moleculeInstantiateCmd.Flags().BoolVar(&moleculeJSON, "json", false, "Output as JSON")
+
+	// Show flags
+	moleculeShowCmd.Flags().BoolVar(&moleculeJSON, "json", false, "Output as JSON")
+
+	// Instances flags
+	moleculeInstancesCmd.Flags().BoolVar(&moleculeJSON, "json", false, "Output as JSON")
+	moleculeInstancesCmd.Flags().StringVar(&moleculeInstancesSince, "since", "", "Only instances created within this duration (e.g., 24h, 7d)")
 
 	// Add step subcommand with its children
 	moleculeStepCmd.AddCommand(moleculeStepDoneCmd)
@@ -252,11 +515,18 @@ func init() {
 	moleculeCmd.AddCommand(moleculeCurrentCmd)
 	moleculeCmd.AddCommand(moleculeBurnCmd)
 	moleculeCmd.AddCommand(moleculeSquashCmd)
+	moleculeCmd.AddCommand(moleculeCancelCmd)
 	moleculeCmd.AddCommand(moleculeProgressCmd)
+	moleculeCmd.AddCommand(moleculeGraphCmd)
 	moleculeCmd.AddCommand(moleculeAttachCmd)
 	moleculeCmd.AddCommand(moleculeDetachCmd)
 	moleculeCmd.AddCommand(moleculeAttachmentCmd)
 	moleculeCmd.AddCommand(moleculeAttachFromMailCmd)
+	moleculeCmd.AddCommand(moleculeNewCmd)
+	moleculeCmd.AddCommand(moleculeLintCmd)
+	moleculeCmd.AddCommand(moleculeInstantiateCmd)
+	moleculeCmd.AddCommand(moleculeInstancesCmd)
+	moleculeCmd.AddCommand(moleculeShowCmd)
 
 	rootCmd.AddCommand(moleculeCmd)
 }
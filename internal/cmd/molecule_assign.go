@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// WorkerAvailability is one crew member or polecat as a candidate for a
+// molecule step.
+type WorkerAvailability struct {
+	Address string `json:"address"`        // assignee and mail address, e.g. "gastown/crew/max"
+	Kind    string `json:"kind"`           // "crew" or "polecat"
+	Tier    string `json:"tier,omitempty"` // haiku, sonnet, or opus, if its model says so
+	Running bool   `json:"running"`        // session is up
+	Busy    string `json:"busy,omitempty"` // bead the worker is already on
+}
+
+// Idle reports whether the worker can take a step now.
+func (w WorkerAvailability) Idle() bool {
+	return w.Running && w.Busy == ""
+}
+
+// StepAssignment pairs a ready step with the worker it goes to.
+type StepAssignment struct {
+	StepID  string `json:"step_id"`
+	Title   string `json:"title"`
+	Tier    string `json:"tier,omitempty"`
+	Address string `json:"assignee"`
+}
+
+// listWorkerAvailability combines crew and polecat session state with the
+// beads each worker is hooked to or working on.
+func listWorkerAvailability(townRoot string, r *rig.Rig, b *beads.Beads) ([]WorkerAvailability, error) {
+	var workers []WorkerAvailability
+
+	crewMgr := crew.NewManager(r, git.NewGit(r.Path))
+	crewWorkers, err := crewMgr.List()
+	if err != nil {
+		return nil, fmt.Errorf("listing crew: %w", err)
+	}
+	crewTier := roleAgentTier("crew", townRoot, r.Path)
+	for _, c := range crewWorkers {
+		w := WorkerAvailability{
+			Address: fmt.Sprintf("%s/crew/%s", r.Name, c.Name),
+			Kind:    "crew",
+			Tier:    crewTier,
+		}
+		if cfg, err := config.LoadCrewWorkerConfig(config.CrewWorkerConfigPath(c.ClonePath)); err == nil && cfg.Model != "" {
+			w.Tier = tierFromModel(cfg.Model)
+		}
+		w.Running, _ = crewMgr.IsRunning(c.Name)
+		w.Busy = workerBusyOn(b, w.Address)
+		workers = append(workers, w)
+	}
+
+	t := tmux.NewTmux()
+	polecatMgr := polecat.NewManager(r, git.NewGit(r.Path), t)
+	sessMgr := polecat.NewSessionManager(t, r)
+	polecats, err := polecatMgr.List()
+	if err != nil {
+		return nil, fmt.Errorf("listing polecats: %w", err)
+	}
+	polecatTier := roleAgentTier("polecat", townRoot, r.Path)
+	for _, p := range polecats {
+		w := WorkerAvailability{
+			Address: fmt.Sprintf("%s/%s", r.Name, p.Name),
+			Kind:    "polecat",
+			Tier:    polecatTier,
+			Busy:    p.Issue,
+		}
+		w.Running, _ = sessMgr.IsRunning(p.Name)
+		switch {
+		case p.State == polecat.StateDone:
+			w.Running = false // finished; the session is on its way out
+		case w.Busy == "":
+			w.Busy = workerBusyOn(b, w.Address)
+		}
+		workers = append(workers, w)
+	}
+
+	return workers, nil
+}
+
+// workerBusyOn returns a bead hooked to or in progress for the agent at
+// address, or "" if it has none.
+func workerBusyOn(b *beads.Beads, address string) string {
+	for _, status := range []string{beads.StatusHooked, "in_progress"} {
+		issues, err := b.List(beads.ListOptions{Status: status, Assignee: address, Priority: -1})
+		if err == nil && len(issues) > 0 {
+			return issues[0].ID
+		}
+	}
+	return ""
+}
+
+// roleAgentTier returns the tier of the agent a role runs, judged from the
+// agent name and its arguments (e.g. "claude-haiku", "--model opus").
+func roleAgentTier(role, townRoot, rigPath string) string {
+	name, _ := config.ResolveRoleAgentName(role, townRoot, rigPath)
+	if tier := tierFromModel(name); tier != "" {
+		return tier
+	}
+	if rc := config.ResolveRoleAgentConfig(role, townRoot, rigPath); rc != nil {
+		return tierFromModel(strings.Join(rc.Args, " "))
+	}
+	return ""
+}
+
+// tierFromModel maps a model or agent name to a molecule step tier.
+func tierFromModel(model string) string {
+	model = strings.ToLower(model)
+	for _, tier := range []string{"haiku", "sonnet", "opus"} {
+		if strings.Contains(model, tier) {
+			return tier
+		}
+	}
+	return ""
+}
+
+// planStepAssignments matches ready steps to idle workers, one step per
+// worker. A step with a tier only goes to a worker of that tier; tiered
+// steps are placed first so untiered ones don't take their workers.
+// Steps that can't be matched are left out.
+func planStepAssignments(steps []MoleculeStepNode, tiers map[string]string, workers []WorkerAvailability) []StepAssignment {
+	taken := make(map[string]bool)
+	pick := func(tier string) string {
+		for _, w := range workers {
+			if w.Idle() && !taken[w.Address] && (tier == "" || w.Tier == tier) {
+				taken[w.Address] = true
+				return w.Address
+			}
+		}
+		return ""
+	}
+
+	var plan []StepAssignment
+	for _, tiered := range []bool{true, false} {
+		for _, step := range steps {
+			tier := tiers[step.ID]
+			if step.State != stepReady || step.Assignee != "" || (tier != "") != tiered {
+				continue
+			}
+			if addr := pick(tier); addr != "" {
+				plan = append(plan, StepAssignment{StepID: step.ID, Title: step.Title, Tier: tier, Address: addr})
+			}
+		}
+	}
+	return plan
+}
+
+// applyStepAssignments writes each assignee onto its step bead and mails
+// the worker. It stops at the first bead update that fails; mail failures
+// are reported but don't undo the assignment.
+func applyStepAssignments(b *beads.Beads, townRoot, from string, root *beads.Issue, plan []StepAssignment) (mailErrs []error, err error) {
+	router := mail.NewRouter(townRoot)
+	for _, a := range plan {
+		assignee := a.Address
+		if err := b.Update(a.StepID, beads.UpdateOptions{Assignee: &assignee}); err != nil {
+			return mailErrs, fmt.Errorf("assigning %s to %s: %w", a.StepID, a.Address, err)
+		}
+
+		body := fmt.Sprintf("You have been assigned step %s of molecule %s (%s).\n\n"+
+			"Run 'bd show %s' for the instructions, and 'gt mol step done %s' when finished.",
+			a.StepID, root.ID, root.Title, a.StepID, a.StepID)
+		msg := mail.NewMessage(from, a.Address, "STEP_ASSIGNED: "+a.Title, body)
+		msg.Type = mail.TypeTask
+		if err := router.Send(msg); err != nil {
+			mailErrs = append(mailErrs, fmt.Errorf("mailing %s: %w", a.Address, err))
+		}
+	}
+	return mailErrs, nil
+}
+
+// extractStepTier returns the tier recorded in an instantiated step's
+// description ("tier: <tier>"), or "".
+func extractStepTier(description string) string {
+	for _, line := range strings.Split(description, "\n") {
+		if tier, ok := strings.CutPrefix(strings.TrimSpace(line), "tier:"); ok {
+			return strings.TrimSpace(tier)
+		}
+	}
+	return ""
+}
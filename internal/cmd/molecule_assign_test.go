@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestTierFromModel(t *testing.T) {
+	tests := map[string]string{
+		"claude-haiku":             "haiku",
+		"Sonnet":                   "sonnet",
+		"--model claude-opus-4-1":  "opus",
+		"claude":                   "",
+		"gemini":                   "",
+		"--dangerously-skip-perms": "",
+	}
+	for model, want := range tests {
+		if got := tierFromModel(model); got != want {
+			t.Errorf("tierFromModel(%q) = %q, want %q", model, got, want)
+		}
+	}
+}
+
+func TestExtractStepTier(t *testing.T) {
+	desc := "Do the thing.\n\ninstantiated_from: mol-x\nstep: build\ntier: haiku"
+	if got := extractStepTier(desc); got != "haiku" {
+		t.Errorf("extractStepTier = %q, want haiku", got)
+	}
+	if got := extractStepTier("No tier here."); got != "" {
+		t.Errorf("extractStepTier = %q, want empty", got)
+	}
+}
+
+func TestPlanStepAssignments(t *testing.T) {
+	steps := []MoleculeStepNode{
+		{ID: "gt-m.1", Title: "Triage", State: stepReady},
+		{ID: "gt-m.2", Title: "Design", State: stepReady},
+		{ID: "gt-m.3", Title: "Implement", State: stepBlocked},
+		{ID: "gt-m.4", Title: "Docs", State: stepReady},
+		{ID: "gt-m.5", Title: "Taken", State: stepReady, Assignee: "gastown/crew/joe"},
+		{ID: "gt-m.6", Title: "Summarize", State: stepReady},
+	}
+	tiers := map[string]string{"gt-m.2": "opus", "gt-m.3": "sonnet", "gt-m.4": "haiku"}
+	workers := []WorkerAvailability{
+		{Address: "gastown/crew/max", Kind: "crew", Tier: "haiku", Running: true},
+		{Address: "gastown/crew/ann", Kind: "crew", Tier: "opus", Running: true, Busy: "gt-99"},
+		{Address: "gastown/crew/bob", Kind: "crew", Tier: "opus", Running: false},
+		{Address: "gastown/Toast", Kind: "polecat", Tier: "sonnet", Running: true},
+	}
+
+	got := planStepAssignments(steps, tiers, workers)
+	want := []StepAssignment{
+		// Tiered steps first: docs gets the haiku worker; no idle opus worker for design.
+		{StepID: "gt-m.4", Title: "Docs", Tier: "haiku", Address: "gastown/crew/max"},
+		// Untiered steps take whoever is left.
+		{StepID: "gt-m.1", Title: "Triage", Address: "gastown/Toast"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("planStepAssignments =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseContextVars(t *testing.T) {
+	ctx, err := parseContextVars([]string{"env=prod", " db =true", "note=a=b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"env": "prod", "db": "true", "note": "a=b"}; !reflect.DeepEqual(ctx, want) {
+		t.Errorf("parseContextVars = %v, want %v", ctx, want)
+	}
+	if _, err := parseContextVars([]string{"novalue"}); err == nil {
+		t.Error("parseContextVars accepted a pair without '='")
+	}
+}
+
+func TestPlannedStepAssignments(t *testing.T) {
+	plan := []beads.PlannedStep{
+		{Ref: "build", Title: "Build", Tier: "sonnet"},
+		{Ref: "docs", Title: "Docs"},
+		{Ref: "release", Title: "Release", Needs: []string{"build"}},
+	}
+	workers := []WorkerAvailability{
+		{Address: "gastown/crew/max", Tier: "sonnet", Running: true},
+		{Address: "gastown/Toast", Running: true},
+	}
+
+	got := planStepAssignments(plannedStepNodes(plan), plannedStepTiers(plan), workers)
+	want := []StepAssignment{
+		{StepID: "build", Title: "Build", Tier: "sonnet", Address: "gastown/crew/max"},
+		{StepID: "docs", Title: "Docs", Address: "gastown/Toast"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("planStepAssignments() = %+v, want %+v", got, want)
+	}
+}
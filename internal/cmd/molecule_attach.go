@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -38,17 +40,25 @@ func runMoleculeAttach(cmd *cobra.Command, args []string) error {
 
 	b := beads.New(workDir)
 
-	// Attach the molecule
-	issue, err := b.AttachMolecule(pinnedBeadID, moleculeID)
+	// Attach the molecule, on top of the stack with --push
+	attach := b.AttachMolecule
+	if moleculeAttachPush {
+		attach = b.PushMolecule
+	}
+	issue, err := attach(pinnedBeadID, moleculeID)
 	if err != nil {
 		return fmt.Errorf("attaching molecule: %w", err)
 	}
+	publishMoleculeEvent(events.MoleculeEvent{Type: events.TypeMoleculeAttached, Molecule: moleculeID, Holder: pinnedBeadID})
 
 	attachment := beads.ParseAttachmentFields(issue)
 	fmt.Printf("%s Attached %s to %s\n", style.Bold.Render("✓"), moleculeID, pinnedBeadID)
 	if attachment != nil && attachment.AttachedAt != "" {
 		fmt.Printf("  attached_at: %s\n", attachment.AttachedAt)
 	}
+	if attachment != nil && len(attachment.Suspended) > 0 {
+		fmt.Printf("  suspended: %s\n", strings.Join(attachment.SuspendedMolecules(), ", "))
+	}
 
 	return nil
 }
@@ -116,18 +126,26 @@ func runMoleculeDetach(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	previousMolecule := attachment.AttachedMolecule
+	previousMolecule, err := selectAttachedMolecule(attachment, moleculeTargetID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", pinnedBeadID, err)
+	}
 
 	// Detach the molecule with audit logging
-	_, err = b.DetachMoleculeWithAudit(pinnedBeadID, beads.DetachOptions{
+	updated, err := b.DetachMoleculeWithAudit(pinnedBeadID, beads.DetachOptions{
 		Operation: "detach",
 		Agent:     detectCurrentAgent(),
+		Molecule:  previousMolecule,
 	})
 	if err != nil {
 		return fmt.Errorf("detaching molecule: %w", err)
 	}
+	publishMoleculeEvent(events.MoleculeEvent{Type: events.TypeMoleculeDetached, Molecule: previousMolecule, Holder: pinnedBeadID})
 
 	fmt.Printf("%s Detached %s from %s\n", style.Bold.Render("✓"), previousMolecule, pinnedBeadID)
+	if resumed := resumedMolecule(attachment, updated); resumed != "" {
+		fmt.Printf("  Resumed %s\n", resumed)
+	}
 
 	return nil
 }
@@ -152,11 +170,12 @@ func runMoleculeAttachment(cmd *cobra.Command, args []string) error {
 
 	if moleculeJSON {
 		type attachmentOutput struct {
-			IssueID          string `json:"issue_id"`
-			IssueTitle       string `json:"issue_title"`
-			Status           string `json:"status"`
-			AttachedMolecule string `json:"attached_molecule,omitempty"`
-			AttachedAt       string `json:"attached_at,omitempty"`
+			IssueID          string                      `json:"issue_id"`
+			IssueTitle       string                      `json:"issue_title"`
+			Status           string                      `json:"status"`
+			AttachedMolecule string                      `json:"attached_molecule,omitempty"`
+			AttachedAt       string                      `json:"attached_at,omitempty"`
+			Suspended        []beads.SuspendedAttachment `json:"suspended,omitempty"`
 		}
 		out := attachmentOutput{
 			IssueID:    issue.ID,
@@ -166,6 +185,7 @@ func runMoleculeAttachment(cmd *cobra.Command, args []string) error {
 		if attachment != nil {
 			out.AttachedMolecule = attachment.AttachedMolecule
 			out.AttachedAt = attachment.AttachedAt
+			out.Suspended = attachment.Suspended
 		}
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
@@ -186,6 +206,17 @@ func runMoleculeAttachment(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if attachment != nil && len(attachment.Suspended) > 0 {
+		fmt.Printf("\n%s\n", style.Bold.Render("Suspended (resumes in this order):"))
+		for _, s := range attachment.Suspended {
+			line := "  " + s.Molecule
+			if s.AttachedAt != "" {
+				line += style.Dim.Render(" (attached " + s.AttachedAt + ")")
+			}
+			fmt.Println(line)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+func runMoleculeNew(cmd *cobra.Command, args []string) error {
+	id := args[0]
+
+	path := moleculeNewFile
+	if path == "" {
+		path = id + ".md"
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists (edit it and run 'gt mol lint %s')", path, path)
+	}
+
+	if err := os.WriteFile(path, []byte(generateMoleculeProto(id)), 0644); err != nil {
+		return fmt.Errorf("writing proto: %w", err)
+	}
+	fmt.Printf("%s Created molecule proto: %s\n", style.Bold.Render("✓"), path)
+
+	if moleculeNewNoEdit {
+		fmt.Printf("\nNext steps:\n")
+		fmt.Printf("  1. Edit the proto: %s\n", path)
+		fmt.Printf("  2. Check it:       gt mol lint %s\n", path)
+		return nil
+	}
+
+	for {
+		if err := openInEditor(path); err != nil {
+			return err
+		}
+		issues, err := lintMoleculeFile(path)
+		if err != nil {
+			return err
+		}
+		if len(issues) == 0 {
+			fmt.Printf("%s %s is valid\n", style.Bold.Render("✓"), path)
+			return nil
+		}
+		printMoleculeLintIssues(path, issues)
+		if !promptYesNo("Re-open the editor to fix?") {
+			return fmt.Errorf("%s has %d problem(s)", path, len(issues))
+		}
+	}
+}
+
+func runMoleculeLint(cmd *cobra.Command, args []string) error {
+	path := args[0]
+
+	issues, err := lintMoleculeFile(path)
+	if err != nil {
+		return err
+	}
+
+	if moleculeJSON {
+		if issues == nil {
+			issues = []beads.MoleculeLintIssue{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(issues); err != nil {
+			return err
+		}
+	} else if len(issues) == 0 {
+		fmt.Printf("%s %s is valid\n", style.Bold.Render("✓"), path)
+	} else {
+		printMoleculeLintIssues(path, issues)
+	}
+
+	if len(issues) > 0 {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// lintMoleculeFile reads and lints a proto file.
+func lintMoleculeFile(path string) ([]beads.MoleculeLintIssue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading proto: %w", err)
+	}
+	return beads.LintMolecule(string(data)), nil
+}
+
+func printMoleculeLintIssues(path string, issues []beads.MoleculeLintIssue) {
+	fmt.Printf("%s %s: %d problem(s)\n", style.ErrorPrefix, path, len(issues))
+	for _, issue := range issues {
+		fmt.Printf("  %s\n", issue)
+	}
+}
+
+// openInEditor opens path in $VISUAL or $EDITOR (default vi) and waits for
+// it to exit. The variable may include arguments, e.g. "code --wait".
+func openInEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	parts := strings.Fields(editor)
+	c := exec.Command(parts[0], append(parts[1:], path)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("running editor %q: %w", editor, err)
+	}
+	return nil
+}
+
+// generateMoleculeProto returns a starter proto for the given molecule ID.
+func generateMoleculeProto(id string) string {
+	title := strings.ReplaceAll(strings.TrimPrefix(id, "mol-"), "-", " ")
+	title = cases.Title(language.English).String(title)
+
+	return fmt.Sprintf(`# %s
+
+<!--
+Molecule proto: %s
+Created by: gt mol new
+
+Each "## Step: <ref>" section becomes a step bead. The first line is its
+title; the rest are instructions. Optional lines in a step:
+  Needs: <ref>, <ref>       steps that must finish first
+  Tier: haiku|sonnet|opus   model tier hint
+  If: <expression>          only create the step when true, e.g. "db == true"
+  SLA: <duration>           escalate if the step runs longer, e.g. 30m
+Declare every context variable used in {{placeholders}} or If: above the
+first step:
+  Var: <name> [string|int|bool] [required|optional] [default=<value>] [-- <description>]
+Check with: gt mol lint <file>
+-->
+
+Describe what this molecule accomplishes.
+
+Var: issue string required -- Issue the change is for
+
+## Step: design
+Design the change for {{issue}}.
+Tier: opus
+
+## Step: implement
+Implement the design.
+Needs: design
+
+## Step: verify
+Run the tests and check the result.
+Needs: implement
+`, title, id)
+}
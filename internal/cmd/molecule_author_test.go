@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestGenerateMoleculeProtoLintsClean(t *testing.T) {
+	proto := generateMoleculeProto("mol-deploy-service")
+	if !strings.HasPrefix(proto, "# Deploy Service\n") {
+		t.Errorf("proto title line = %q", strings.SplitN(proto, "\n", 2)[0])
+	}
+	if issues := beads.LintMolecule(proto); len(issues) != 0 {
+		t.Errorf("scaffold has lint issues: %v", issues)
+	}
+	steps, err := beads.ParseMoleculeSteps(proto)
+	if err != nil || len(steps) != 3 {
+		t.Errorf("ParseMoleculeSteps = %d steps, %v; want 3", len(steps), err)
+	}
+}
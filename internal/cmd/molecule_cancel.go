@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MoleculeCancelResult is the JSON output of 'gt mol cancel'.
+type MoleculeCancelResult struct {
+	Instances []string `json:"instances"`                // instance roots cancelled, outermost first
+	Closed    []string `json:"steps_closed"`             // step beads closed
+	Detached  []string `json:"detached_from,omitempty"`  // handoff/agent beads the instances were attached to
+	Skipped   []string `json:"nested_skipped,omitempty"` // steps hosting nested instances, left open without --cascade
+	DigestID  string   `json:"digest_id,omitempty"`
+}
+
+// cancelledInstance is one molecule instance found under the cancel root.
+type cancelledInstance struct {
+	rootID string
+	done   int
+	open   []string // step IDs to close
+	total  int
+}
+
+func runMoleculeCancel(cmd *cobra.Command, args []string) error {
+	rootID := args[0]
+
+	workDir, err := findLocalBeadsDir()
+	if err != nil {
+		return fmt.Errorf("not in a beads workspace: %w", err)
+	}
+	b := beads.New(workDir)
+
+	root, err := b.Show(rootID)
+	if err != nil {
+		return fmt.Errorf("getting instance root: %w", err)
+	}
+
+	var result MoleculeCancelResult
+	instances, err := collectCancelledInstances(b, root.ID, moleculeCancelCascade, &result.Skipped)
+	if err != nil {
+		return err
+	}
+	if len(instances) == 0 || instances[0].total == 0 {
+		return fmt.Errorf("no steps found for %s (not a molecule instance?)", root.ID)
+	}
+
+	actor := detectSender()
+	reason := "cancelled"
+	if moleculeCancelReason != "" {
+		reason = "cancelled: " + moleculeCancelReason
+	}
+
+	// Close steps innermost first, so nothing is left open under a closed step
+	for i := len(instances) - 1; i >= 0; i-- {
+		inst := instances[i]
+		if err := b.CloseWithReason(reason, inst.open...); err != nil {
+			return fmt.Errorf("closing steps of %s: %w", inst.rootID, err)
+		}
+		result.Closed = append(result.Closed, inst.open...)
+	}
+	for _, inst := range instances {
+		result.Instances = append(result.Instances, inst.rootID)
+		publishMoleculeEvent(events.MoleculeEvent{Type: events.TypeMoleculeCancelled, Molecule: inst.rootID, Reason: moleculeCancelReason})
+	}
+
+	// Detach the instances from any handoff or agent bead they're attached to
+	cancelled := make(map[string]bool, len(instances))
+	for _, inst := range instances {
+		cancelled[inst.rootID] = true
+	}
+	for _, holder := range attachmentHolders(b) {
+		attachment := beads.ParseAttachmentFields(holder)
+		detached := false
+		for _, molID := range attachment.Molecules() {
+			if !cancelled[molID] {
+				continue
+			}
+			if _, err := b.DetachMoleculeWithAudit(holder.ID, beads.DetachOptions{
+				Operation: "cancel",
+				Agent:     actor,
+				Reason:    reason,
+				Molecule:  molID,
+			}); err != nil {
+				style.PrintWarning("could not detach %s from %s: %v", molID, holder.ID, err)
+				continue
+			}
+			detached = true
+		}
+		if detached {
+			result.Detached = append(result.Detached, holder.ID)
+		}
+	}
+
+	digest, err := createMoleculeDigest(b, fmt.Sprintf("Digest: %s (cancelled)", root.ID),
+		formatCancelDigest(root, instances, actor, reason, time.Now()), actor, false)
+	if err != nil {
+		style.PrintWarning("steps cancelled, but %v", err)
+	} else {
+		result.DigestID = digest.ID
+	}
+
+	if moleculeJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	fmt.Printf("%s Cancelled molecule %s: closed %d step(s)\n",
+		style.Bold.Render("✗"), root.ID, len(result.Closed))
+	if len(instances) > 1 {
+		fmt.Printf("  Nested instances: %s\n", strings.Join(result.Instances[1:], ", "))
+	}
+	for _, id := range result.Detached {
+		fmt.Printf("  Detached from %s\n", id)
+	}
+	if result.DigestID != "" {
+		fmt.Printf("  Digest: %s\n", result.DigestID)
+	}
+	if len(result.Skipped) > 0 {
+		style.PrintWarning("left open (nested molecules, use --cascade): %s", strings.Join(result.Skipped, ", "))
+	}
+	return nil
+}
+
+// collectCancelledInstances walks the instance under rootID and returns it
+// followed by its nested instances (steps that have molecule steps of their
+// own), outermost first. Without cascade, steps hosting a nested instance
+// are left open and added to skipped.
+func collectCancelledInstances(b *beads.Beads, rootID string, cascade bool, skipped *[]string) ([]*cancelledInstance, error) {
+	children, err := b.List(beads.ListOptions{Parent: rootID, Status: "all", Priority: -1})
+	if err != nil {
+		return nil, fmt.Errorf("listing steps of %s: %w", rootID, err)
+	}
+
+	inst := &cancelledInstance{rootID: rootID, total: len(children)}
+	var nested []*cancelledInstance
+	for _, child := range children {
+		if child.Status == "closed" {
+			inst.done++
+			continue
+		}
+
+		grandchildren, err := b.List(beads.ListOptions{Parent: child.ID, Status: "all", Priority: -1})
+		if err == nil && isMoleculeInstance(grandchildren) {
+			if !cascade {
+				*skipped = append(*skipped, child.ID)
+				continue
+			}
+			sub, err := collectCancelledInstances(b, child.ID, cascade, skipped)
+			if err != nil {
+				return nil, err
+			}
+			nested = append(nested, sub...)
+		}
+		inst.open = append(inst.open, child.ID)
+	}
+
+	return append([]*cancelledInstance{inst}, nested...), nil
+}
+
+// isMoleculeInstance reports whether issues are steps instantiated from a
+// molecule.
+func isMoleculeInstance(steps []*beads.Issue) bool {
+	for _, s := range steps {
+		if beads.StepMoleculeID(s) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// attachmentHolders returns the beads a molecule can be attached to:
+// pinned handoff beads and polecat agent beads.
+func attachmentHolders(b *beads.Beads) []*beads.Issue {
+	var holders []*beads.Issue
+	if pinned, err := b.List(beads.ListOptions{Status: beads.StatusPinned, Priority: -1}); err == nil {
+		holders = append(holders, pinned...)
+	}
+	if agents, err := b.List(beads.ListOptions{Status: "open", Label: "gt:agent", Priority: -1}); err == nil {
+		holders = append(holders, agents...)
+	}
+	return holders
+}
+
+// formatCancelDigest describes a cancellation for the digest bead.
+func formatCancelDigest(root *beads.Issue, instances []*cancelledInstance, actor, reason string, at time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cancelled molecule execution.\n\n")
+	fmt.Fprintf(&b, "molecule: %s\n", root.ID)
+	if molID := extractMoleculeID(root.Description); molID != "" {
+		fmt.Fprintf(&b, "instantiated_from: %s\n", molID)
+	}
+	fmt.Fprintf(&b, "agent: %s\n", actor)
+	fmt.Fprintf(&b, "cancelled_at: %s\n", at.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "reason: %s\n", reason)
+
+	b.WriteString("\n## Execution Summary\n")
+	for _, inst := range instances {
+		fmt.Fprintf(&b, "- %s: %d/%d steps completed, %d cancelled\n",
+			inst.rootID, inst.done, inst.total, len(inst.open))
+	}
+	return b.String()
+}
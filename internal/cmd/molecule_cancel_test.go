@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestIsMoleculeInstance(t *testing.T) {
+	steps := []*beads.Issue{
+		{ID: "gt-a.1", Description: "Plain child."},
+		{ID: "gt-a.2", Description: "Do it.\n\ninstantiated_from: mol-x\nstep: build"},
+	}
+	if !isMoleculeInstance(steps) {
+		t.Error("isMoleculeInstance = false, want true")
+	}
+	if isMoleculeInstance(steps[:1]) || isMoleculeInstance(nil) {
+		t.Error("isMoleculeInstance = true for plain children")
+	}
+}
+
+func TestFormatCancelDigest(t *testing.T) {
+	root := &beads.Issue{ID: "gt-abc", Description: "instantiated_from: mol-deploy"}
+	instances := []*cancelledInstance{
+		{rootID: "gt-abc", done: 2, total: 5, open: []string{"gt-abc.3", "gt-abc.4", "gt-abc.5"}},
+		{rootID: "gt-abc.3", done: 0, total: 2, open: []string{"gt-abc.3.1", "gt-abc.3.2"}},
+	}
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	got := formatCancelDigest(root, instances, "mayor/", "cancelled: scope cut", at)
+	for _, want := range []string{
+		"molecule: gt-abc\n",
+		"instantiated_from: mol-deploy\n",
+		"agent: mayor/\n",
+		"cancelled_at: 2026-03-01T12:00:00Z\n",
+		"reason: cancelled: scope cut\n",
+		"- gt-abc: 2/5 steps completed, 3 cancelled\n",
+		"- gt-abc.3: 0/2 steps completed, 2 cancelled\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("digest missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// Graph output formats for 'gt mol graph'.
+const (
+	graphFormatMermaid = "mermaid"
+	graphFormatDot     = "dot"
+)
+
+// stepStateColors are the fill and stroke colors of each step state.
+var stepStateColors = map[string][2]string{
+	stepDone:       {"#d4edda", "#28a745"},
+	stepInProgress: {"#fff3cd", "#e0a800"},
+	stepReady:      {"#d1ecf1", "#17a2b8"},
+	stepBlocked:    {"#f8d7da", "#dc3545"},
+}
+
+// stepStateOrder fixes the order class definitions are written in.
+var stepStateOrder = []string{stepDone, stepInProgress, stepReady, stepBlocked}
+
+func runMoleculeGraph(cmd *cobra.Command, args []string) error {
+	rootID := args[0]
+
+	var render func(root *beads.Issue, nodes []MoleculeStepNode) string
+	switch moleculeGraphFormat {
+	case graphFormatMermaid:
+		render = renderMoleculeMermaid
+	case graphFormatDot:
+		render = renderMoleculeDot
+	default:
+		return fmt.Errorf("unknown format %q (want %s or %s)", moleculeGraphFormat, graphFormatMermaid, graphFormatDot)
+	}
+
+	workDir, err := findLocalBeadsDir()
+	if err != nil {
+		return fmt.Errorf("not in a beads workspace: %w", err)
+	}
+	b := beads.New(workDir)
+
+	root, err := b.Show(rootID)
+	if err != nil {
+		return fmt.Errorf("getting root issue: %w", err)
+	}
+	children, err := b.List(beads.ListOptions{Parent: rootID, Status: "all", Priority: -1})
+	if err != nil {
+		return fmt.Errorf("listing children: %w", err)
+	}
+	if len(children) == 0 {
+		return fmt.Errorf("no steps found for %s (not a molecule root?)", rootID)
+	}
+
+	nodes, _, _ := buildMoleculeGraph(children)
+	fmt.Print(render(root, nodes))
+	return nil
+}
+
+// externalDeps returns the dependencies outside the molecule, sorted.
+func externalDeps(nodes []MoleculeStepNode) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, node := range nodes {
+		for _, dep := range node.External {
+			if !seen[dep] {
+				seen[dep] = true
+				ids = append(ids, dep)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// criticalEdge reports whether the edge dep -> node lies on the critical path.
+func criticalEdge(critical map[string]bool, dep string, node MoleculeStepNode) bool {
+	return node.Critical && critical[dep]
+}
+
+// renderMoleculeMermaid renders a molecule's step DAG as a Mermaid
+// flowchart. Steps are colored by state; the critical path is drawn with
+// thick edges and borders; dependencies outside the molecule are dashed.
+func renderMoleculeMermaid(root *beads.Issue, nodes []MoleculeStepNode) string {
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+	fmt.Fprintf(&sb, "  %%%% %s: %s\n", root.ID, mermaidText(root.Title))
+
+	// Mermaid node IDs can't contain dots, so steps are numbered
+	mid := make(map[string]string, len(nodes))
+	critical := make(map[string]bool)
+	for i, node := range nodes {
+		mid[node.ID] = fmt.Sprintf("s%d", i)
+		critical[node.ID] = node.Critical
+	}
+	externals := externalDeps(nodes)
+	for i, id := range externals {
+		mid[id] = fmt.Sprintf("x%d", i)
+	}
+
+	for _, node := range nodes {
+		fmt.Fprintf(&sb, "  %s[\"%s<br/>%s\"]:::%s\n", mid[node.ID], node.ID, mermaidText(node.Title), node.State)
+	}
+	for _, id := range externals {
+		fmt.Fprintf(&sb, "  %s([\"%s\"]):::external\n", mid[id], id)
+	}
+
+	for _, node := range nodes {
+		for _, dep := range node.Needs {
+			arrow := "-->"
+			if criticalEdge(critical, dep, node) {
+				arrow = "==>"
+			}
+			fmt.Fprintf(&sb, "  %s %s %s\n", mid[dep], arrow, mid[node.ID])
+		}
+		for _, dep := range node.External {
+			fmt.Fprintf(&sb, "  %s -.-> %s\n", mid[dep], mid[node.ID])
+		}
+	}
+
+	for _, state := range stepStateOrder {
+		c := stepStateColors[state]
+		fmt.Fprintf(&sb, "  classDef %s fill:%s,stroke:%s\n", state, c[0], c[1])
+	}
+	sb.WriteString("  classDef external fill:#f5f5f5,stroke:#999,stroke-dasharray:4 4\n")
+	sb.WriteString("  classDef critical stroke-width:3px\n")
+
+	var criticalIDs []string
+	for _, node := range nodes {
+		if node.Critical {
+			criticalIDs = append(criticalIDs, mid[node.ID])
+		}
+	}
+	if len(criticalIDs) > 0 {
+		fmt.Fprintf(&sb, "  class %s critical\n", strings.Join(criticalIDs, ","))
+	}
+	return sb.String()
+}
+
+// mermaidText escapes text for a quoted Mermaid label.
+func mermaidText(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(s)
+}
+
+// renderMoleculeDot renders a molecule's step DAG as a Graphviz digraph,
+// styled like the Mermaid output.
+func renderMoleculeDot(root *beads.Issue, nodes []MoleculeStepNode) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "digraph %s {\n", dotQuote(root.ID))
+	fmt.Fprintf(&sb, "  label=%s;\n", dotQuote(root.ID+": "+root.Title))
+	sb.WriteString("  labelloc=t;\n")
+	sb.WriteString("  rankdir=TB;\n")
+	sb.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	sb.WriteString("\n")
+
+	critical := make(map[string]bool)
+	for _, node := range nodes {
+		critical[node.ID] = node.Critical
+	}
+
+	for _, node := range nodes {
+		c := stepStateColors[node.State]
+		attrs := fmt.Sprintf("label=%s, fillcolor=%s, color=%s",
+			dotQuote(node.ID+"\n"+node.Title+"\n("+strings.ReplaceAll(node.State, "_", " ")+")"),
+			dotQuote(c[0]), dotQuote(c[1]))
+		if node.Critical {
+			attrs += ", penwidth=3"
+		}
+		fmt.Fprintf(&sb, "  %s [%s];\n", dotQuote(node.ID), attrs)
+	}
+	for _, id := range externalDeps(nodes) {
+		fmt.Fprintf(&sb, "  %s [style=\"rounded,dashed\", color=\"#999999\"];\n", dotQuote(id))
+	}
+
+	sb.WriteString("\n")
+	for _, node := range nodes {
+		for _, dep := range node.Needs {
+			attrs := ""
+			if criticalEdge(critical, dep, node) {
+				attrs = " [penwidth=3]"
+			}
+			fmt.Fprintf(&sb, "  %s -> %s%s;\n", dotQuote(dep), dotQuote(node.ID), attrs)
+		}
+		for _, dep := range node.External {
+			fmt.Fprintf(&sb, "  %s -> %s [style=dashed];\n", dotQuote(dep), dotQuote(node.ID))
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// dotQuote quotes s as a Graphviz string; newlines become line breaks.
+func dotQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
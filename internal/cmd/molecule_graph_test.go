@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func testMoleculeGraph() (*beads.Issue, []MoleculeStepNode) {
+	root := &beads.Issue{ID: "gt-abc", Title: `Review "auth" change`}
+	children := []*beads.Issue{
+		{ID: "gt-abc.1", Title: "Read the diff", Status: "closed"},
+		{ID: "gt-abc.2", Title: "Run tests", Status: "in_progress", DependsOn: []string{"gt-abc.1"}},
+		{ID: "gt-abc.3", Title: "Check docs", Status: "open", DependsOn: []string{"gt-abc.1", "gt-ext"}},
+		{ID: "gt-abc.4", Title: "Approve", Status: "open", DependsOn: []string{"gt-abc.2", "gt-abc.3"}},
+	}
+	nodes, _, _ := buildMoleculeGraph(children)
+	return root, nodes
+}
+
+func TestRenderMoleculeMermaid(t *testing.T) {
+	root, nodes := testMoleculeGraph()
+	out := renderMoleculeMermaid(root, nodes)
+
+	index := make(map[string]string)
+	for i, n := range nodes {
+		index[n.ID] = fmt.Sprintf("s%d", i)
+	}
+	for _, want := range []string{
+		"flowchart TD\n",
+		"%% gt-abc: Review #quot;auth#quot; change\n",
+		index["gt-abc.1"] + "[\"gt-abc.1<br/>Read the diff\"]:::done\n",
+		index["gt-abc.2"] + "[\"gt-abc.2<br/>Run tests\"]:::in_progress\n",
+		index["gt-abc.3"] + "[\"gt-abc.3<br/>Check docs\"]:::blocked\n",
+		"x0([\"gt-ext\"]):::external\n",
+		"x0 -.-> " + index["gt-abc.3"] + "\n",
+		index["gt-abc.1"] + " --> " + index["gt-abc.2"] + "\n", // done step: not critical
+		"classDef blocked fill:#f8d7da,stroke:#dc3545\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("mermaid output missing %q:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(out, "==> "+index["gt-abc.4"]+"\n") {
+		t.Errorf("critical edge into gt-abc.4 not thick:\n%s", out)
+	}
+}
+
+func TestRenderMoleculeDot(t *testing.T) {
+	root, nodes := testMoleculeGraph()
+	out := renderMoleculeDot(root, nodes)
+
+	for _, want := range []string{
+		"digraph \"gt-abc\" {\n",
+		"label=\"gt-abc: Review \\\"auth\\\" change\";\n",
+		"\"gt-abc.2\" [label=\"gt-abc.2\\nRun tests\\n(in progress)\", fillcolor=\"#fff3cd\", color=\"#e0a800\"",
+		"\"gt-abc.1\" -> \"gt-abc.2\";\n",
+		"\"gt-ext\" -> \"gt-abc.3\" [style=dashed];\n",
+		"\"gt-ext\" [style=\"rounded,dashed\"",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dot output missing %q:\n%s", want, out)
+		}
+	}
+	if !strings.HasSuffix(out, "}\n") {
+		t.Errorf("dot output not closed:\n%s", out)
+	}
+}
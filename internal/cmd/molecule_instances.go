@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MoleculeInstancesResult is the JSON output of 'gt mol instances'.
+type MoleculeInstancesResult struct {
+	ProtoID       string                 `json:"proto_id"`
+	Instances     []MoleculeInstanceInfo `json:"instances"`
+	StepDurations []StepDurationStats    `json:"step_durations,omitempty"`
+}
+
+// MoleculeInstanceInfo is the progress and timing of one instance.
+type MoleculeInstanceInfo struct {
+	*MoleculeProgressInfo
+	Timing *beads.MoleculeTiming `json:"timing"`
+}
+
+// StepDurationStats summarizes how long one proto step takes across
+// instances, to find the bottleneck steps of a workflow.
+type StepDurationStats struct {
+	Ref     string `json:"ref"`
+	Title   string `json:"title"`
+	Count   int    `json:"count"` // instances that finished this step
+	AvgSecs int64  `json:"avg_secs"`
+	MaxSecs int64  `json:"max_secs"`
+	OverSLA int    `json:"over_sla,omitempty"` // finished past its SLA
+}
+
+func runMoleculeInstances(cmd *cobra.Command, args []string) error {
+	protoID := args[0]
+
+	workDir, err := findLocalBeadsDir()
+	if err != nil {
+		return fmt.Errorf("not in a beads workspace: %w", err)
+	}
+	b := beads.New(workDir)
+
+	var since time.Time
+	if moleculeInstancesSince != "" {
+		d, err := parseDuration(moleculeInstancesSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since value: %w", err)
+		}
+		since = time.Now().Add(-d)
+	}
+
+	instances, err := b.FindMoleculeInstances(protoID, since)
+	if err != nil {
+		return err
+	}
+
+	// One bd call for all instance roots, for their titles
+	rootIDs := make([]string, 0, len(instances))
+	for _, inst := range instances {
+		rootIDs = append(rootIDs, inst.RootID)
+	}
+	roots, err := b.ShowMultiple(rootIDs)
+	if err != nil {
+		roots = nil // titles are cosmetic
+	}
+
+	now := time.Now()
+	result := MoleculeInstancesResult{ProtoID: protoID}
+	for _, inst := range instances {
+		root := roots[inst.RootID]
+		if root == nil {
+			root = &beads.Issue{ID: inst.RootID}
+		}
+		result.Instances = append(result.Instances, MoleculeInstanceInfo{
+			MoleculeProgressInfo: summarizeMoleculeProgress(root, inst.Steps),
+			Timing:               beads.TimeMoleculeSteps(inst.Steps, now),
+		})
+	}
+	result.StepDurations = aggregateStepDurations(instances, now)
+
+	if moleculeJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	if len(result.Instances) == 0 {
+		fmt.Printf("%s No instances of %s\n", style.Dim.Render("ℹ"), protoID)
+		return nil
+	}
+
+	fmt.Printf("%s Instances of %s (%d)\n\n", style.Bold.Render("🧬"), protoID, len(result.Instances))
+	for _, info := range result.Instances {
+		state := fmt.Sprintf("%d/%d steps", info.DoneSteps, info.TotalSteps)
+		if info.Complete {
+			state = style.Success.Render("complete")
+		} else if info.InProgress > 0 {
+			state += fmt.Sprintf(", %d in progress", info.InProgress)
+		}
+		line := fmt.Sprintf("  %s  %s  %s", info.RootID, info.RootTitle, style.Dim.Render("["+state+"]"))
+		if info.Timing.StartedAt != "" {
+			line += "  " + formatDuration(time.Duration(info.Timing.DurationSecs)*time.Second)
+		}
+		fmt.Println(line)
+		for _, st := range info.Timing.Steps {
+			if st.Running && st.OverSLA {
+				fmt.Printf("      %s %s over SLA: running %s (SLA %s)\n", style.Warning.Render("⚠"),
+					st.ID, formatDuration(st.Duration()), formatDuration(st.SLA()))
+			}
+		}
+	}
+
+	if len(result.StepDurations) > 0 {
+		fmt.Printf("\n%s\n", style.Bold.Render("Step durations (slowest first):"))
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, s := range result.StepDurations {
+			line := fmt.Sprintf("  %s\t%s\tavg %s\tmax %s\t%d run(s)", s.Ref, s.Title,
+				formatDuration(time.Duration(s.AvgSecs)*time.Second),
+				formatDuration(time.Duration(s.MaxSecs)*time.Second), s.Count)
+			if s.OverSLA > 0 {
+				line += fmt.Sprintf("\t%d over SLA", s.OverSLA)
+			}
+			fmt.Fprintln(tw, line)
+		}
+		_ = tw.Flush()
+	}
+	return nil
+}
+
+// aggregateStepDurations groups finished steps of all instances by their
+// proto step and returns duration stats, slowest average first.
+func aggregateStepDurations(instances []*beads.MoleculeInstance, now time.Time) []StepDurationStats {
+	byRef := make(map[string]*StepDurationStats)
+	var order []string
+	for _, inst := range instances {
+		refs := make(map[string]string, len(inst.Steps))
+		for _, s := range inst.Steps {
+			refs[s.ID] = moleculeStepRef(s)
+		}
+		for _, st := range beads.TimeMoleculeSteps(inst.Steps, now).Steps {
+			if st.ClosedAt == "" {
+				continue
+			}
+			ref := refs[st.ID]
+			stats := byRef[ref]
+			if stats == nil {
+				stats = &StepDurationStats{Ref: ref, Title: st.Title}
+				byRef[ref] = stats
+				order = append(order, ref)
+			}
+			stats.Count++
+			stats.AvgSecs += st.DurationSecs // summed here, divided below
+			if st.DurationSecs > stats.MaxSecs {
+				stats.MaxSecs = st.DurationSecs
+			}
+			if st.OverSLA {
+				stats.OverSLA++
+			}
+		}
+	}
+
+	out := make([]StepDurationStats, 0, len(order))
+	for _, ref := range order {
+		stats := byRef[ref]
+		stats.AvgSecs /= int64(stats.Count)
+		out = append(out, *stats)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].AvgSecs > out[j].AvgSecs })
+	return out
+}
+
+// moleculeStepRef returns the proto step an instantiated step came from:
+// its markdown step ref or template step ID, falling back to its title.
+func moleculeStepRef(step *beads.Issue) string {
+	if ref := extractStepRef(step.Description); ref != "" {
+		return ref
+	}
+	for _, line := range strings.Split(step.Description, "\n") {
+		if ref, ok := strings.CutPrefix(strings.TrimSpace(line), "template_step:"); ok {
+			return strings.TrimSpace(ref)
+		}
+	}
+	return step.Title
+}
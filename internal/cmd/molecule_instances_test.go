@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestAggregateStepDurations(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	step := func(id, ref, started, closed string) *beads.Issue {
+		return &beads.Issue{
+			ID:          id,
+			Title:       ref,
+			Status:      "closed",
+			CreatedAt:   started,
+			ClosedAt:    closed,
+			Description: "instantiated_from: mol-x\nstep: " + ref + "\nsla: 30m\nstarted_at: " + started,
+		}
+	}
+	instances := []*beads.MoleculeInstance{
+		{RootID: "gt-a", Steps: []*beads.Issue{
+			step("gt-a.1", "build", "2026-01-02T08:00:00Z", "2026-01-02T08:10:00Z"),
+			step("gt-a.2", "review", "2026-01-02T08:10:00Z", "2026-01-02T09:10:00Z"),
+		}},
+		{RootID: "gt-b", Steps: []*beads.Issue{
+			step("gt-b.1", "build", "2026-01-02T09:00:00Z", "2026-01-02T09:20:00Z"),
+			step("gt-b.2", "review", "2026-01-02T09:20:00Z", "2026-01-02T09:40:00Z"),
+			{ID: "gt-b.3", Status: "open", CreatedAt: "2026-01-02T09:00:00Z", Description: "step: ship"},
+		}},
+	}
+
+	got := aggregateStepDurations(instances, now)
+	if len(got) != 2 {
+		t.Fatalf("got %d refs, want 2: %+v", len(got), got)
+	}
+	review, build := got[0], got[1]
+	if review.Ref != "review" || review.Count != 2 || review.AvgSecs != 40*60 || review.MaxSecs != 60*60 || review.OverSLA != 1 {
+		t.Errorf("review = %+v", review)
+	}
+	if build.Ref != "build" || build.Count != 2 || build.AvgSecs != 15*60 || build.MaxSecs != 20*60 || build.OverSLA != 0 {
+		t.Errorf("build = %+v", build)
+	}
+}
@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+	"golang.org/x/term"
+)
+
+// MoleculeInstantiateResult is the JSON output of 'gt mol instantiate'.
+type MoleculeInstantiateResult struct {
+	ProtoID     string               `json:"proto_id"`
+	ParentID    string               `json:"parent_id"`
+	DryRun      bool                 `json:"dry_run,omitempty"`
+	Steps       []*beads.Issue       `json:"steps,omitempty"`
+	Plan        []beads.PlannedStep  `json:"plan,omitempty"` // with --dry-run, the steps that would be created
+	Workers     []WorkerAvailability `json:"workers,omitempty"`
+	Assignments []StepAssignment     `json:"assignments,omitempty"`
+}
+
+func runMoleculeInstantiate(cmd *cobra.Command, args []string) error {
+	protoID, parentID := args[0], args[1]
+
+	ctx, err := parseContextVars(moleculeInstantiateVars)
+	if err != nil {
+		return err
+	}
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	workDir, err := findLocalBeadsDir()
+	if err != nil {
+		return fmt.Errorf("not in a beads workspace: %w", err)
+	}
+	b := beads.New(workDir)
+
+	proto, err := loadMoleculeProto(b, townRoot, protoID)
+	if err != nil {
+		return err
+	}
+	parent, err := b.Show(parentID)
+	if err != nil {
+		return fmt.Errorf("getting parent issue: %w", err)
+	}
+
+	// Ask for required variables that weren't given, when someone can answer
+	if !moleculeJSON && term.IsTerminal(int(os.Stdin.Fd())) {
+		if schema, err := beads.ParseMoleculeVarSchema(proto.Description); err == nil {
+			promptMissingVars(schema, ctx)
+		}
+	}
+
+	if moleculeInstantiateDryRun {
+		return previewMoleculeInstantiation(b, townRoot, proto, parent, ctx)
+	}
+
+	steps, err := b.InstantiateMolecule(proto, parent, beads.InstantiateOptions{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("instantiating %s: %w", protoID, err)
+	}
+	result := MoleculeInstantiateResult{ProtoID: proto.ID, ParentID: parent.ID, Steps: steps}
+
+	var mailErrs []error
+	if moleculeInstantiateAssign {
+		result.Workers, err = rigWorkers(townRoot, parent.ID, b)
+		if err != nil {
+			return fmt.Errorf("steps created, but can't assign them: %w", err)
+		}
+
+		// Re-read the steps to see their dependencies and tiers
+		children, err := b.List(beads.ListOptions{Parent: parent.ID, Status: "all", Priority: -1})
+		if err != nil {
+			return fmt.Errorf("steps created, but can't assign them: listing steps: %w", err)
+		}
+		created := make(map[string]bool, len(steps))
+		for _, s := range steps {
+			created[s.ID] = true
+		}
+		tiers := make(map[string]string)
+		for _, c := range children {
+			tiers[c.ID] = extractStepTier(c.Description)
+		}
+		nodes, _, _ := buildMoleculeGraph(children)
+		var stepNodes []MoleculeStepNode
+		for _, n := range nodes {
+			if created[n.ID] {
+				stepNodes = append(stepNodes, n)
+			}
+		}
+
+		result.Assignments = planStepAssignments(stepNodes, tiers, result.Workers)
+		mailErrs, err = applyStepAssignments(b, townRoot, detectSender(), parent, result.Assignments)
+		if err != nil {
+			return fmt.Errorf("steps created, but assignment failed: %w", err)
+		}
+	}
+
+	if moleculeJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("%s Instantiated %s under %s: %d step(s)\n", style.Bold.Render("✓"), proto.ID, parent.ID, len(steps))
+		assigned := make(map[string]string)
+		for _, a := range result.Assignments {
+			assigned[a.StepID] = a.Address
+		}
+		for _, s := range steps {
+			line := fmt.Sprintf("  %s  %s", s.ID, s.Title)
+			if addr := assigned[s.ID]; addr != "" {
+				line += style.Dim.Render(" → " + addr)
+			}
+			fmt.Println(line)
+		}
+		if moleculeInstantiateAssign {
+			idle := 0
+			for _, w := range result.Workers {
+				if w.Idle() {
+					idle++
+				}
+			}
+			fmt.Printf("\n  Assigned %d ready step(s) (%d idle of %d workers)\n",
+				len(result.Assignments), idle, len(result.Workers))
+		}
+	}
+
+	for _, err := range mailErrs {
+		style.PrintWarning("%v", err)
+	}
+	return nil
+}
+
+// previewMoleculeInstantiation prints the step beads 'gt mol instantiate'
+// would create, and with --assign who they would go to, without writing
+// anything.
+func previewMoleculeInstantiation(b *beads.Beads, townRoot string, proto, parent *beads.Issue, ctx map[string]string) error {
+	plan, err := b.PlanMolecule(proto, parent, beads.InstantiateOptions{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("planning %s: %w", proto.ID, err)
+	}
+	result := MoleculeInstantiateResult{ProtoID: proto.ID, ParentID: parent.ID, DryRun: true, Plan: plan}
+
+	if moleculeInstantiateAssign {
+		result.Workers, err = rigWorkers(townRoot, parent.ID, b)
+		if err != nil {
+			return err
+		}
+		result.Assignments = planStepAssignments(plannedStepNodes(plan), plannedStepTiers(plan), result.Workers)
+	}
+
+	if moleculeJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	fmt.Printf("%s Dry run: %s under %s would create %d step(s)\n\n",
+		style.Bold.Render("○"), proto.ID, parent.ID, len(plan))
+	assigned := make(map[string]string)
+	for _, a := range result.Assignments {
+		assigned[a.StepID] = a.Address
+	}
+	for _, step := range plan {
+		line := fmt.Sprintf("  %s  %s", step.Ref, step.Title)
+		if step.Tier != "" {
+			line += style.Dim.Render(" [" + step.Tier + "]")
+		}
+		if addr := assigned[step.Ref]; addr != "" {
+			line += style.Dim.Render(" → " + addr)
+		}
+		fmt.Println(line)
+		if len(step.Needs) > 0 {
+			fmt.Printf("      %s\n", style.Dim.Render("needs: "+strings.Join(step.Needs, ", ")))
+		}
+		if len(step.Unresolved) > 0 {
+			fmt.Printf("      %s unresolved: {{%s}}\n", style.Warning.Render("⚠"), strings.Join(step.Unresolved, "}}, {{"))
+		}
+	}
+	if moleculeInstantiateAssign {
+		fmt.Printf("\n  Would assign %d ready step(s)\n", len(result.Assignments))
+	}
+	fmt.Printf("\n%s\n", style.Dim.Render("Nothing was created. Run without --dry-run to instantiate."))
+	return nil
+}
+
+// plannedStepNodes turns planned steps into graph nodes for
+// planStepAssignments: steps with no dependencies are ready.
+func plannedStepNodes(plan []beads.PlannedStep) []MoleculeStepNode {
+	nodes := make([]MoleculeStepNode, 0, len(plan))
+	for _, step := range plan {
+		state := stepReady
+		if len(step.Needs) > 0 {
+			state = stepBlocked
+		}
+		nodes = append(nodes, MoleculeStepNode{ID: step.Ref, Title: step.Title, Ref: step.Ref, State: state, Needs: step.Needs})
+	}
+	return nodes
+}
+
+// plannedStepTiers maps planned step refs to their tiers.
+func plannedStepTiers(plan []beads.PlannedStep) map[string]string {
+	tiers := make(map[string]string, len(plan))
+	for _, step := range plan {
+		tiers[step.Ref] = step.Tier
+	}
+	return tiers
+}
+
+// parseContextVars parses "name=value" context variables as given to --var.
+func parseContextVars(pairs []string) (map[string]string, error) {
+	ctx := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid --var %q: want name=value", pair)
+		}
+		ctx[strings.TrimSpace(name)] = value
+	}
+	return ctx, nil
+}
+
+// promptMissingVars asks on stdin for each required variable ctx doesn't
+// set. An empty answer leaves the variable unset.
+func promptMissingVars(schema []beads.MoleculeVar, ctx map[string]string) {
+	missing := make(map[string]bool)
+	for _, name := range beads.MissingMoleculeVars(schema, ctx) {
+		missing[name] = true
+	}
+	reader := bufio.NewReader(os.Stdin)
+	for _, v := range schema {
+		if !missing[v.Name] {
+			continue
+		}
+		prompt := fmt.Sprintf("%s (%s)", v.Name, v.Type)
+		if v.Description != "" {
+			prompt += " - " + v.Description
+		}
+		fmt.Printf("%s: ", prompt)
+		answer, _ := reader.ReadString('\n')
+		if answer = strings.TrimSpace(answer); answer != "" {
+			ctx[v.Name] = answer
+		}
+	}
+}
+
+// loadMoleculeProto finds a molecule proto by ID in the beads database, then
+// in the molecule catalog (town, rig, and project molecules.jsonl).
+func loadMoleculeProto(b *beads.Beads, townRoot, protoID string) (*beads.Issue, error) {
+	if issue, err := b.Show(protoID); err == nil {
+		return issue, nil
+	}
+
+	cwd, _ := os.Getwd()
+	var rigPath string
+	if rigName, err := inferRigFromCwd(townRoot); err == nil {
+		rigPath = filepath.Join(townRoot, rigName)
+	}
+	catalog, err := beads.LoadCatalog(townRoot, rigPath, cwd)
+	if err != nil {
+		return nil, fmt.Errorf("loading molecule catalog: %w", err)
+	}
+	if mol := catalog.Get(protoID); mol != nil {
+		return mol.ToIssue(), nil
+	}
+	return nil, fmt.Errorf("molecule proto %s not found in beads or the molecule catalog", protoID)
+}
+
+// rigWorkers lists the workers of the rig that takes steps of the molecule
+// under parentID.
+func rigWorkers(townRoot, parentID string, b *beads.Beads) ([]WorkerAvailability, error) {
+	r, err := moleculeRig(townRoot, parentID)
+	if err != nil {
+		return nil, err
+	}
+	return listWorkerAvailability(townRoot, r, b)
+}
+
+// moleculeRig returns the rig whose workers can take steps of the molecule
+// under parentID: --rig if given, else the rig owning the parent's prefix,
+// else the rig containing the current directory.
+func moleculeRig(townRoot, parentID string) (*rig.Rig, error) {
+	rigName := moleculeInstantiateRig
+	if rigName == "" {
+		// Routes point into the rig, e.g. "gastown/mayor/rig"
+		if rigPath := beads.GetRigPathForPrefix(townRoot, beads.ExtractPrefix(parentID)); rigPath != "" && rigPath != townRoot {
+			if rel, err := filepath.Rel(townRoot, rigPath); err == nil {
+				rigName = strings.Split(filepath.ToSlash(rel), "/")[0]
+			}
+		}
+	}
+	if rigName == "" {
+		var err error
+		if rigName, err = inferRigFromCwd(townRoot); err != nil {
+			return nil, fmt.Errorf("could not determine rig (use --rig): %w", err)
+		}
+	}
+	_, r, err := getRig(rigName)
+	return r, err
+}
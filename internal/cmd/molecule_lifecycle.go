@@ -9,6 +9,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -72,7 +74,7 @@ func runMoleculeBurn(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no handoff bead found for %s", target)
 	}
 
-	// Check for attached molecule
+	// Check for attached molecule: the top of the stack, or --molecule
 	attachment := beads.ParseAttachmentFields(handoff)
 	if attachment == nil || attachment.AttachedMolecule == "" {
 		fmt.Printf("%s No molecule attached to %s - nothing to burn\n",
@@ -80,21 +82,27 @@ func runMoleculeBurn(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	moleculeID := attachment.AttachedMolecule
+	moleculeID, err := selectAttachedMolecule(attachment, moleculeTargetID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", handoff.ID, err)
+	}
 
 	// Recursively close all descendant step issues before detaching
 	// This prevents orphaned step issues from accumulating (gt-psj76.1)
 	childrenClosed := closeDescendants(b, moleculeID)
 
 	// Detach the molecule with audit logging (this "burns" it by removing the attachment)
-	_, err = b.DetachMoleculeWithAudit(handoff.ID, beads.DetachOptions{
+	updated, err := b.DetachMoleculeWithAudit(handoff.ID, beads.DetachOptions{
 		Operation: "burn",
 		Agent:     target,
 		Reason:    "molecule burned by agent",
+		Molecule:  moleculeID,
 	})
 	if err != nil {
 		return fmt.Errorf("detaching molecule: %w", err)
 	}
+	resumed := resumedMolecule(attachment, updated)
+	publishMoleculeEvent(events.MoleculeEvent{Type: events.TypeMoleculeBurned, Molecule: moleculeID, Holder: handoff.ID})
 
 	if moleculeJSON {
 		result := map[string]interface{}{
@@ -103,6 +111,9 @@ func runMoleculeBurn(cmd *cobra.Command, args []string) error {
 			"handoff_id":      handoff.ID,
 			"children_closed": childrenClosed,
 		}
+		if resumed != "" {
+			result["resumed"] = resumed
+		}
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
 		return enc.Encode(result)
@@ -113,6 +124,9 @@ func runMoleculeBurn(cmd *cobra.Command, args []string) error {
 	if childrenClosed > 0 {
 		fmt.Printf("  Closed %d step issues\n", childrenClosed)
 	}
+	if resumed != "" {
+		fmt.Printf("  Resumed %s\n", resumed)
+	}
 
 	return nil
 }
@@ -176,7 +190,7 @@ func runMoleculeSquash(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no handoff bead found for %s", target)
 	}
 
-	// Check for attached molecule
+	// Check for attached molecule: the top of the stack, or --molecule
 	attachment := beads.ParseAttachmentFields(handoff)
 	if attachment == nil || attachment.AttachedMolecule == "" {
 		fmt.Printf("%s No molecule attached to %s - nothing to squash\n",
@@ -184,74 +198,46 @@ func runMoleculeSquash(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	moleculeID := attachment.AttachedMolecule
+	moleculeID, err := selectAttachedMolecule(attachment, moleculeTargetID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", handoff.ID, err)
+	}
+
+	// Trace the steps before closing them, so the digest records how far
+	// the molecule got
+	now := time.Now()
+	trace := traceMolecule(b, moleculeID, now)
 
 	// Recursively close all descendant step issues before squashing
 	// This prevents orphaned step issues from accumulating (gt-psj76.1)
 	childrenClosed := closeDescendants(b, moleculeID)
 
-	// Get progress info for the digest
-	progress, _ := getMoleculeProgressInfo(b, moleculeID)
-
 	// Create a digest issue
 	digestTitle := fmt.Sprintf("Digest: %s", moleculeID)
-	digestDesc := fmt.Sprintf(`Squashed molecule execution.
-
-molecule: %s
-agent: %s
-squashed_at: %s
-`, moleculeID, target, time.Now().UTC().Format(time.RFC3339))
-
-	if progress != nil {
-		digestDesc += fmt.Sprintf(`
-## Execution Summary
-- Steps: %d/%d completed
-- Status: %s
-`, progress.DoneSteps, progress.TotalSteps, func() string {
-			if progress.Complete {
-				return "complete"
-			}
-			return "partial"
-		}())
+	digestDesc, err := formatSquashDigest(trace, target, now)
+	if err != nil {
+		return err
 	}
 
 	// Create the digest bead (ephemeral to avoid JSONL pollution)
 	// Per-cycle digests are aggregated daily by 'gt patrol digest'
-	digestIssue, err := b.Create(beads.CreateOptions{
-		Title:       digestTitle,
-		Description: digestDesc,
-		Type:        "task",
-		Priority:    4,       // P4 - backlog priority for digests
-		Actor:       target,
-		Ephemeral:   true,    // Don't export to JSONL - daily aggregation handles permanent record
-	})
-	if err != nil {
-		return fmt.Errorf("creating digest: %w", err)
-	}
-
-	// Add the digest label (non-fatal: digest works without label)
-	_ = b.Update(digestIssue.ID, beads.UpdateOptions{
-		AddLabels: []string{"digest"},
-	})
-
-	// Close the digest immediately
-	closedStatus := "closed"
-	err = b.Update(digestIssue.ID, beads.UpdateOptions{
-		Status: &closedStatus,
-	})
+	digestIssue, err := createMoleculeDigest(b, digestTitle, digestDesc, target, true)
 	if err != nil {
-		style.PrintWarning("Created digest but couldn't close it: %v", err)
+		return err
 	}
 
 	// Detach the molecule from the handoff bead with audit logging
-	_, err = b.DetachMoleculeWithAudit(handoff.ID, beads.DetachOptions{
+	updated, err := b.DetachMoleculeWithAudit(handoff.ID, beads.DetachOptions{
 		Operation: "squash",
 		Agent:     target,
 		Reason:    fmt.Sprintf("molecule squashed to digest %s", digestIssue.ID),
+		Molecule:  moleculeID,
 	})
 	if err != nil {
 		return fmt.Errorf("detaching molecule: %w", err)
 	}
+	resumed := resumedMolecule(attachment, updated)
+	publishMoleculeEvent(events.MoleculeEvent{Type: events.TypeMoleculeSquashed, Molecule: moleculeID, Holder: handoff.ID})
 
 	if moleculeJSON {
 		result := map[string]interface{}{
@@ -260,6 +246,11 @@ squashed_at: %s
 			"from":            target,
 			"handoff_id":      handoff.ID,
 			"children_closed": childrenClosed,
+			"wall_time_secs":  trace.WallTimeSecs,
+			"commits":         len(trace.Commits),
+		}
+		if resumed != "" {
+			result["resumed"] = resumed
 		}
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
@@ -271,10 +262,179 @@ squashed_at: %s
 	if childrenClosed > 0 {
 		fmt.Printf("  Closed %d step issues\n", childrenClosed)
 	}
+	if len(trace.Commits) > 0 {
+		fmt.Printf("  Traced %d commit(s)\n", len(trace.Commits))
+	}
+	if resumed != "" {
+		fmt.Printf("  Resumed %s\n", resumed)
+	}
 
 	return nil
 }
 
+// publishMoleculeEvent records a molecule lifecycle change in the feed.
+func publishMoleculeEvent(e events.MoleculeEvent) {
+	_ = events.Publish(detectActor(), e)
+}
+
+// traceMolecule records a molecule's step beads, timing, and the commits
+// made for it, as of now. Lookups that fail leave their part of the trace
+// empty: a squash shouldn't fail for want of traceability.
+func traceMolecule(b *beads.Beads, moleculeID string, now time.Time) *beads.DigestTrace {
+	trace := &beads.DigestTrace{Molecule: moleculeID, EndedAt: now.UTC().Format(time.RFC3339)}
+
+	root, err := b.Show(moleculeID)
+	if err == nil {
+		trace.InstantiatedFrom = extractMoleculeID(root.Description)
+	}
+	steps, err := b.List(beads.ListOptions{Parent: moleculeID, Status: "all", Priority: -1})
+	if err != nil {
+		style.PrintWarning("could not list steps of %s: %v", moleculeID, err)
+	}
+
+	timing := beads.TimeMoleculeSteps(steps, now)
+	trace.StartedAt = timing.StartedAt
+	trace.WallTimeSecs = timing.DurationSecs
+	if timing.ClosedAt != "" {
+		trace.EndedAt = timing.ClosedAt
+	}
+	durations := make(map[string]int64, len(timing.Steps))
+	for _, st := range timing.Steps {
+		durations[st.ID] = st.DurationSecs
+	}
+	ids := []string{moleculeID}
+	for _, step := range steps {
+		trace.Steps = append(trace.Steps, beads.DigestStep{
+			ID:           step.ID,
+			Title:        step.Title,
+			Status:       step.Status,
+			DurationSecs: durations[step.ID],
+		})
+		ids = append(ids, step.ID)
+	}
+
+	// Commits are found in the clone the squash runs from
+	cwd, err := os.Getwd()
+	if err != nil {
+		return trace
+	}
+	g := git.NewGit(cwd)
+	if !g.IsRepo() {
+		return trace
+	}
+	var since time.Time
+	if root != nil {
+		since, _ = time.Parse(time.RFC3339, root.CreatedAt)
+	}
+	if started, err := time.Parse(time.RFC3339, trace.StartedAt); err == nil && (since.IsZero() || started.Before(since)) {
+		since = started
+	}
+	commits, err := g.CommitsReferencing(ids, since)
+	if err != nil {
+		style.PrintWarning("could not trace commits of %s: %v", moleculeID, err)
+		return trace
+	}
+	for _, c := range commits {
+		trace.Commits = append(trace.Commits, beads.DigestCommit{
+			Hash:    c.Hash,
+			Subject: c.Subject,
+			Time:    c.Time.UTC().Format(time.RFC3339),
+			Ref:     c.Ref,
+			Via:     c.Via,
+		})
+	}
+	return trace
+}
+
+// formatSquashDigest describes a squashed molecule for the digest bead: a
+// readable summary of its steps and commits, then the trace as JSON.
+func formatSquashDigest(trace *beads.DigestTrace, agent string, at time.Time) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("Squashed molecule execution.\n\n")
+	fmt.Fprintf(&sb, "molecule: %s\n", trace.Molecule)
+	if trace.InstantiatedFrom != "" {
+		fmt.Fprintf(&sb, "instantiated_from: %s\n", trace.InstantiatedFrom)
+	}
+	fmt.Fprintf(&sb, "agent: %s\n", agent)
+	fmt.Fprintf(&sb, "squashed_at: %s\n", at.UTC().Format(time.RFC3339))
+
+	if len(trace.Steps) > 0 {
+		done := 0
+		for _, s := range trace.Steps {
+			if s.Status == "closed" {
+				done++
+			}
+		}
+		status := "partial"
+		if done == len(trace.Steps) {
+			status = "complete"
+		}
+		sb.WriteString("\n## Execution Summary\n")
+		fmt.Fprintf(&sb, "- Steps: %d/%d completed\n", done, len(trace.Steps))
+		fmt.Fprintf(&sb, "- Status: %s\n", status)
+		if trace.StartedAt != "" {
+			fmt.Fprintf(&sb, "- Wall time: %s\n", formatDuration(time.Duration(trace.WallTimeSecs)*time.Second))
+		}
+		fmt.Fprintf(&sb, "- Commits: %d\n", len(trace.Commits))
+
+		sb.WriteString("\n## Steps\n")
+		for _, s := range trace.Steps {
+			mark := " "
+			if s.Status == "closed" {
+				mark = "x"
+			}
+			line := fmt.Sprintf("- [%s] %s: %s", mark, s.ID, s.Title)
+			if s.Status != "closed" {
+				line += " (" + s.Status + ")"
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	if len(trace.Commits) > 0 {
+		sb.WriteString("\n## Commits\n")
+		for _, c := range trace.Commits {
+			hash := c.Hash
+			if len(hash) > 8 {
+				hash = hash[:8]
+			}
+			fmt.Fprintf(&sb, "- %s %s (%s)\n", hash, c.Subject, c.Ref)
+		}
+	}
+
+	section, err := beads.FormatDigestTrace(trace)
+	if err != nil {
+		return "", err
+	}
+	sb.WriteString("\n" + section)
+	return sb.String(), nil
+}
+
+// selectAttachedMolecule returns the molecule to operate on: moleculeID if
+// it is on the attachment stack, or the top of the stack if it's empty.
+func selectAttachedMolecule(attachment *beads.AttachmentFields, moleculeID string) (string, error) {
+	if moleculeID == "" {
+		return attachment.AttachedMolecule, nil
+	}
+	for _, id := range attachment.Molecules() {
+		if id == moleculeID {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("molecule %s is not attached (attached: %s)",
+		moleculeID, strings.Join(attachment.Molecules(), ", "))
+}
+
+// resumedMolecule returns the molecule that became the top of the
+// attachment stack after a detach, or "" if the top didn't change hands.
+func resumedMolecule(before *beads.AttachmentFields, updated *beads.Issue) string {
+	after := beads.ParseAttachmentFields(updated)
+	if after == nil || after.AttachedMolecule == before.AttachedMolecule {
+		return ""
+	}
+	return after.AttachedMolecule
+}
+
 // closeDescendants recursively closes all descendant issues of a parent.
 // Returns the count of issues closed. Logs warnings on errors but doesn't fail.
 func closeDescendants(b *beads.Beads, parentID string) int {
@@ -315,3 +475,32 @@ func closeDescendants(b *beads.Beads, parentID string) int {
 
 	return totalClosed
 }
+
+// createMoleculeDigest records a closed, digest-labeled bead summarizing a
+// molecule's execution. Ephemeral digests are not exported to JSONL.
+func createMoleculeDigest(b *beads.Beads, title, description, actor string, ephemeral bool) (*beads.Issue, error) {
+	digestIssue, err := b.Create(beads.CreateOptions{
+		Title:       title,
+		Description: description,
+		Type:        "task",
+		Priority:    4, // P4 - backlog priority for digests
+		Actor:       actor,
+		Ephemeral:   ephemeral,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating digest: %w", err)
+	}
+
+	// Add the digest label (non-fatal: digest works without label)
+	_ = b.Update(digestIssue.ID, beads.UpdateOptions{
+		AddLabels: []string{"digest"},
+	})
+
+	// Close the digest immediately
+	closedStatus := "closed"
+	if err := b.Update(digestIssue.ID, beads.UpdateOptions{Status: &closedStatus}); err != nil {
+		style.PrintWarning("Created digest but couldn't close it: %v", err)
+	}
+
+	return digestIssue, nil
+}
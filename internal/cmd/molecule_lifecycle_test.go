@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestFormatSquashDigest(t *testing.T) {
+	trace := &beads.DigestTrace{
+		Molecule:         "gt-abc",
+		InstantiatedFrom: "mol-review",
+		StartedAt:        "2025-12-21T10:00:00Z",
+		EndedAt:          "2025-12-21T11:30:00Z",
+		WallTimeSecs:     5400,
+		Steps: []beads.DigestStep{
+			{ID: "gt-abc.1", Title: "Read the diff", Status: "closed", DurationSecs: 1800},
+			{ID: "gt-abc.2", Title: "Write comments", Status: "in_progress", DurationSecs: 3600},
+		},
+		Commits: []beads.DigestCommit{
+			{Hash: "0123456789abcdef", Subject: "fix: typo (gt-abc.2)", Time: "2025-12-21T11:00:00Z", Ref: "gt-abc.2", Via: "message"},
+		},
+	}
+	at := time.Date(2025, 12, 21, 11, 30, 0, 0, time.UTC)
+
+	desc, err := formatSquashDigest(trace, "gastown/polecats/nux", at)
+	if err != nil {
+		t.Fatalf("formatSquashDigest: %v", err)
+	}
+	for _, want := range []string{
+		"molecule: gt-abc\n",
+		"instantiated_from: mol-review\n",
+		"squashed_at: 2025-12-21T11:30:00Z\n",
+		"- Steps: 1/2 completed\n",
+		"- Status: partial\n",
+		"- Wall time: 1h 30m\n",
+		"- [x] gt-abc.1: Read the diff\n",
+		"- [ ] gt-abc.2: Write comments (in_progress)\n",
+		"- 01234567 fix: typo (gt-abc.2) (gt-abc.2)\n",
+	} {
+		if !strings.Contains(desc, want) {
+			t.Errorf("digest missing %q:\n%s", want, desc)
+		}
+	}
+
+	// The trace section parses back to what was written
+	parsed, err := beads.ParseDigestTrace(desc)
+	if err != nil {
+		t.Fatalf("ParseDigestTrace: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, trace) {
+		t.Errorf("trace round-trip mismatch:\ngot  %+v\nwant %+v", parsed, trace)
+	}
+
+	if parsed, err := beads.ParseDigestTrace("Squashed molecule execution.\n\nmolecule: gt-old\n"); parsed != nil || err != nil {
+		t.Errorf("ParseDigestTrace(old digest) = %v, %v; want nil, nil", parsed, err)
+	}
+}
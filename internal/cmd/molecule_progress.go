@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// Step states in a molecule progress graph.
+const (
+	stepDone       = "done"
+	stepInProgress = "in_progress"
+	stepReady      = "ready"
+	stepBlocked    = "blocked"
+)
+
+// MoleculeStepNode is one step of a molecule instance's dependency graph.
+type MoleculeStepNode struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Ref      string   `json:"ref,omitempty"` // step ref from the proto, if recorded
+	State    string   `json:"state"`         // done, in_progress, ready, blocked
+	Assignee string   `json:"assignee,omitempty"`
+	Needs    []string `json:"needs,omitempty"`    // steps of this molecule it depends on
+	External []string `json:"external,omitempty"` // dependencies outside the molecule
+	Level    int      `json:"level"`              // longest chain of steps above this one
+	Critical bool     `json:"critical,omitempty"` // on the critical path
+}
+
+// MoleculeRemaining estimates the work left in a molecule instance.
+type MoleculeRemaining struct {
+	Steps         int   `json:"steps"`                      // steps not yet done
+	CriticalSteps int   `json:"critical_steps"`             // steps on the critical path
+	AvgStepSecs   int64 `json:"avg_step_seconds,omitempty"` // observed from done steps
+	WorkSecs      int64 `json:"work_seconds,omitempty"`     // Steps × average
+	FinishSecs    int64 `json:"finish_seconds,omitempty"`   // CriticalSteps × average
+}
+
+func runMoleculeProgress(cmd *cobra.Command, args []string) error {
+	rootID := args[0]
+
+	workDir, err := findLocalBeadsDir()
+	if err != nil {
+		return fmt.Errorf("not in a beads workspace: %w", err)
+	}
+
+	b := beads.New(workDir)
+
+	// Get the root issue
+	root, err := b.Show(rootID)
+	if err != nil {
+		return fmt.Errorf("getting root issue: %w", err)
+	}
+
+	// Find all children of the root issue
+	children, err := b.List(beads.ListOptions{
+		Parent:   rootID,
+		Status:   "all",
+		Priority: -1,
+	})
+	if err != nil {
+		return fmt.Errorf("listing children: %w", err)
+	}
+
+	if len(children) == 0 {
+		return fmt.Errorf("no steps found for %s (not a molecule root?)", rootID)
+	}
+
+	progress := summarizeMoleculeProgress(root, children)
+	progress.Steps, progress.CriticalPath, progress.Remaining = buildMoleculeGraph(children)
+
+	// JSON output
+	if moleculeJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(progress)
+	}
+
+	// Human-readable output
+	fmt.Printf("\n%s %s\n\n", style.Bold.Render("🧬 Molecule Progress:"), root.Title)
+	fmt.Printf("  Root: %s\n", progress.RootID)
+	if progress.MoleculeID != "" {
+		fmt.Printf("  Molecule: %s\n", progress.MoleculeID)
+	}
+	fmt.Println()
+
+	// Progress bar
+	barWidth := 20
+	filled := (progress.Percent * barWidth) / 100
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+	fmt.Printf("  [%s] %d%% (%d/%d)\n\n", bar, progress.Percent, progress.DoneSteps, progress.TotalSteps)
+
+	// Step graph, one level at a time: a step's level is the length of the
+	// longest chain of steps it waits on.
+	level := -1
+	for _, step := range progress.Steps {
+		if step.Level != level {
+			level = step.Level
+			fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("── level %d ──", level)))
+		}
+		line := fmt.Sprintf("    %s %s  %s", stepStateIcon(step.State), step.ID, step.Title)
+		if step.Critical {
+			line = style.Bold.Render(line) + " " + style.Warning.Render("★")
+		}
+		fmt.Println(line)
+
+		var details []string
+		details = append(details, strings.ReplaceAll(step.State, "_", " "))
+		if step.Assignee != "" {
+			details = append(details, step.Assignee)
+		}
+		if len(step.Needs) > 0 {
+			details = append(details, "needs "+strings.Join(step.Needs, ", "))
+		}
+		if len(step.External) > 0 {
+			details = append(details, "external "+strings.Join(step.External, ", "))
+		}
+		fmt.Printf("        %s\n", style.Dim.Render(strings.Join(details, " · ")))
+	}
+
+	if progress.Complete {
+		fmt.Printf("\n  %s\n", style.Bold.Render("✓ Molecule complete!"))
+		return nil
+	}
+
+	rem := progress.Remaining
+	fmt.Println()
+	fmt.Printf("  Critical path: %s %s\n", strings.Join(progress.CriticalPath, " → "),
+		style.Dim.Render(fmt.Sprintf("(%d steps, ★)", rem.CriticalSteps)))
+	fmt.Printf("  Remaining:     %d of %d steps", rem.Steps, progress.TotalSteps)
+	if rem.AvgStepSecs > 0 {
+		fmt.Printf(", ~%s of work, ~%s to finish %s",
+			formatDuration(time.Duration(rem.WorkSecs)*time.Second), formatDuration(time.Duration(rem.FinishSecs)*time.Second),
+			style.Dim.Render(fmt.Sprintf("(at %s/step)", formatDuration(time.Duration(rem.AvgStepSecs)*time.Second))))
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// buildMoleculeGraph lays out a molecule instance's steps as a dependency
+// graph. Steps are returned ordered by level. The critical path is the
+// longest chain of steps not yet done, which bounds how soon the molecule
+// can finish; the estimate scales it by the average time done steps took.
+func buildMoleculeGraph(children []*beads.Issue) ([]MoleculeStepNode, []string, *MoleculeRemaining) {
+	byID := make(map[string]*beads.Issue, len(children))
+	for _, child := range children {
+		byID[child.ID] = child
+	}
+
+	nodes := make([]MoleculeStepNode, len(children))
+	index := make(map[string]int, len(children))
+	for i, child := range children {
+		node := MoleculeStepNode{
+			ID:       child.ID,
+			Title:    child.Title,
+			Ref:      extractStepRef(child.Description),
+			Assignee: child.Assignee,
+		}
+		for _, dep := range child.DependsOn {
+			if _, ok := byID[dep]; ok {
+				node.Needs = append(node.Needs, dep)
+			} else {
+				node.External = append(node.External, dep)
+			}
+		}
+		nodes[i] = node
+		index[child.ID] = i
+	}
+
+	// State: open steps are ready once every dependency is closed
+	for i, child := range children {
+		switch child.Status {
+		case "closed":
+			nodes[i].State = stepDone
+		case "in_progress", "hooked":
+			nodes[i].State = stepInProgress
+		default:
+			nodes[i].State = stepReady
+			for _, dep := range child.DependsOn {
+				if d, ok := byID[dep]; !ok || d.Status != "closed" {
+					nodes[i].State = stepBlocked
+					break
+				}
+			}
+		}
+	}
+
+	// Level and remaining chain length, memoized over the DAG. A cycle
+	// (which validation should have rejected) is cut where it closes.
+	level := make([]int, len(nodes))
+	chain := make([]int, len(nodes)) // not-done steps on the longest chain ending here
+	prev := make([]int, len(nodes))  // predecessor on that chain, or -1
+	visited := make([]int, len(nodes))
+	var visit func(i int)
+	visit = func(i int) {
+		if visited[i] != 0 {
+			return
+		}
+		visited[i] = 1
+		prev[i] = -1
+		for _, dep := range nodes[i].Needs {
+			j := index[dep]
+			if visited[j] == 1 {
+				continue
+			}
+			visit(j)
+			if level[j]+1 > level[i] {
+				level[i] = level[j] + 1
+			}
+			if chain[j] > chain[i] {
+				chain[i] = chain[j]
+				prev[i] = j
+			}
+		}
+		if nodes[i].State != stepDone {
+			chain[i]++
+		}
+		visited[i] = 2
+	}
+	for i := range nodes {
+		visit(i)
+		nodes[i].Level = level[i]
+	}
+
+	// Critical path: walk back from the end of the longest chain
+	end := -1
+	for i := range nodes {
+		if chain[i] > 0 && (end < 0 || chain[i] > chain[end]) {
+			end = i
+		}
+	}
+	var critical []string
+	for i := end; i >= 0; i = prev[i] {
+		if nodes[i].State != stepDone {
+			nodes[i].Critical = true
+			critical = append(critical, nodes[i].ID)
+		}
+	}
+	for l, r := 0, len(critical)-1; l < r; l, r = l+1, r-1 {
+		critical[l], critical[r] = critical[r], critical[l]
+	}
+
+	rem := &MoleculeRemaining{CriticalSteps: len(critical)}
+	for _, node := range nodes {
+		if node.State != stepDone {
+			rem.Steps++
+		}
+	}
+	if avg := averageStepDuration(children); avg > 0 {
+		rem.AvgStepSecs = int64(avg / time.Second)
+		rem.WorkSecs = rem.AvgStepSecs * int64(rem.Steps)
+		rem.FinishSecs = rem.AvgStepSecs * int64(rem.CriticalSteps)
+	}
+
+	sort.SliceStable(nodes, func(a, b int) bool { return nodes[a].Level < nodes[b].Level })
+	return nodes, critical, rem
+}
+
+// averageStepDuration returns how long done steps took on average, from
+// their started_at or, without one, from when they became ready (see
+// beads.TimeMoleculeSteps). Returns 0 if no step has usable timestamps.
+func averageStepDuration(children []*beads.Issue) time.Duration {
+	var total time.Duration
+	var n int
+	for _, st := range beads.TimeMoleculeSteps(children, time.Now()).Steps {
+		if st.ClosedAt != "" && st.DurationSecs > 0 {
+			total += st.Duration()
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return total / time.Duration(n)
+}
+
+// extractStepRef returns the proto step ref recorded in an instantiated
+// step's description ("step: <ref>"), or "".
+func extractStepRef(description string) string {
+	for _, line := range strings.Split(description, "\n") {
+		if ref, ok := strings.CutPrefix(strings.TrimSpace(line), "step:"); ok {
+			return strings.TrimSpace(ref)
+		}
+	}
+	return ""
+}
+
+func stepStateIcon(state string) string {
+	switch state {
+	case stepDone:
+		return style.Success.Render("✓")
+	case stepInProgress:
+		return style.Warning.Render("▶")
+	case stepReady:
+		return "○"
+	default:
+		return style.Dim.Render("◌")
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestBuildMoleculeGraph(t *testing.T) {
+	// setup ─┬─ implement ── test ── review
+	//        └─ docs ───────────────┘
+	children := []*beads.Issue{
+		{ID: "gt-m.5", Title: "Review", Status: "open", DependsOn: []string{"gt-m.4", "gt-m.3"}},
+		{ID: "gt-m.1", Title: "Setup", Status: "closed", Description: "Prepare.\n\nstep: setup",
+			CreatedAt: "2026-01-01T10:00:00Z", ClosedAt: "2026-01-01T10:10:00Z"},
+		{ID: "gt-m.2", Title: "Implement", Status: "in_progress", Assignee: "gastown/nux", DependsOn: []string{"gt-m.1"}},
+		{ID: "gt-m.3", Title: "Docs", Status: "closed", DependsOn: []string{"gt-m.1"},
+			CreatedAt: "2026-01-01T10:00:00Z", ClosedAt: "2026-01-01T10:30:00Z"},
+		{ID: "gt-m.4", Title: "Test", Status: "open", DependsOn: []string{"gt-m.2", "gt-x"}},
+	}
+
+	steps, critical, rem := buildMoleculeGraph(children)
+
+	byID := make(map[string]MoleculeStepNode)
+	var order []string
+	for _, s := range steps {
+		byID[s.ID] = s
+		order = append(order, s.ID)
+	}
+	if want := []string{"gt-m.1", "gt-m.2", "gt-m.3", "gt-m.4", "gt-m.5"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+
+	tests := []struct {
+		id       string
+		state    string
+		level    int
+		critical bool
+	}{
+		{"gt-m.1", stepDone, 0, false},
+		{"gt-m.2", stepInProgress, 1, true},
+		{"gt-m.3", stepDone, 1, false},
+		{"gt-m.4", stepBlocked, 2, true},
+		{"gt-m.5", stepBlocked, 3, true},
+	}
+	for _, tt := range tests {
+		s := byID[tt.id]
+		if s.State != tt.state || s.Level != tt.level || s.Critical != tt.critical {
+			t.Errorf("%s = {state %s, level %d, critical %v}, want {%s, %d, %v}",
+				tt.id, s.State, s.Level, s.Critical, tt.state, tt.level, tt.critical)
+		}
+	}
+	if s := byID["gt-m.4"]; !reflect.DeepEqual(s.Needs, []string{"gt-m.2"}) || !reflect.DeepEqual(s.External, []string{"gt-x"}) {
+		t.Errorf("gt-m.4 needs = %v, external = %v", s.Needs, s.External)
+	}
+	if byID["gt-m.1"].Ref != "setup" {
+		t.Errorf("gt-m.1 ref = %q, want setup", byID["gt-m.1"].Ref)
+	}
+
+	if want := []string{"gt-m.2", "gt-m.4", "gt-m.5"}; !reflect.DeepEqual(critical, want) {
+		t.Errorf("critical path = %v, want %v", critical, want)
+	}
+
+	// Setup took 10m; docs started when setup closed and took 20m.
+	want := &MoleculeRemaining{Steps: 3, CriticalSteps: 3, AvgStepSecs: 900, WorkSecs: 2700, FinishSecs: 2700}
+	if !reflect.DeepEqual(rem, want) {
+		t.Errorf("remaining = %+v, want %+v", rem, want)
+	}
+}
+
+func TestBuildMoleculeGraphComplete(t *testing.T) {
+	children := []*beads.Issue{
+		{ID: "gt-m.1", Status: "closed"},
+		{ID: "gt-m.2", Status: "closed", DependsOn: []string{"gt-m.1"}},
+	}
+	_, critical, rem := buildMoleculeGraph(children)
+	if len(critical) != 0 || rem.Steps != 0 || rem.AvgStepSecs != 0 {
+		t.Errorf("critical = %v, remaining = %+v, want none", critical, rem)
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// MoleculeShowInfo is the JSON output of 'gt mol show'.
+type MoleculeShowInfo struct {
+	ID    string              `json:"id"`
+	Title string              `json:"title"`
+	Vars  []beads.MoleculeVar `json:"vars,omitempty"`
+	Steps []MoleculeShowStep  `json:"steps"`
+}
+
+// MoleculeShowStep is one step of a molecule proto.
+type MoleculeShowStep struct {
+	Ref       string   `json:"ref"`
+	Title     string   `json:"title"`
+	Needs     []string `json:"needs,omitempty"`
+	Tier      string   `json:"tier,omitempty"`
+	Condition string   `json:"if,omitempty"`
+}
+
+func runMoleculeShow(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	workDir, err := findLocalBeadsDir()
+	if err != nil {
+		return fmt.Errorf("not in a beads workspace: %w", err)
+	}
+	b := beads.New(workDir)
+
+	proto, err := loadMoleculeProto(b, townRoot, args[0])
+	if err != nil {
+		return err
+	}
+
+	info := MoleculeShowInfo{ID: proto.ID, Title: proto.Title}
+	if info.Vars, err = beads.ParseMoleculeVarSchema(proto.Description); err != nil {
+		return fmt.Errorf("%s: context variables: %w", proto.ID, err)
+	}
+
+	// Child-issue protos keep their steps as children; markdown protos in
+	// the description
+	templates, _ := b.List(beads.ListOptions{Parent: proto.ID, Status: "all", Priority: -1})
+	if len(templates) > 0 {
+		for _, tmpl := range templates {
+			info.Steps = append(info.Steps, MoleculeShowStep{
+				Ref:       tmpl.ID,
+				Title:     tmpl.Title,
+				Needs:     tmpl.DependsOn,
+				Condition: beads.TemplateStepCondition(tmpl.Description),
+			})
+		}
+	} else {
+		steps, err := beads.ParseMoleculeSteps(proto.Description)
+		if err != nil {
+			return fmt.Errorf("%s: %w", proto.ID, err)
+		}
+		for _, s := range steps {
+			info.Steps = append(info.Steps, MoleculeShowStep{
+				Ref:       s.Ref,
+				Title:     s.Title,
+				Needs:     s.Needs,
+				Tier:      s.Tier,
+				Condition: s.Condition,
+			})
+		}
+	}
+
+	if moleculeJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	}
+
+	fmt.Printf("%s %s: %s\n", style.Bold.Render("🧬"), info.ID, info.Title)
+
+	fmt.Printf("\n%s\n", style.Bold.Render("Context variables:"))
+	if len(info.Vars) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(none declared; unknown {{placeholders}} are left as-is)"))
+	} else {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, v := range info.Vars {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", v.Name, v.Type, varRequirement(v), v.Description)
+		}
+		_ = tw.Flush()
+	}
+
+	fmt.Printf("\n%s\n", style.Bold.Render(fmt.Sprintf("Steps (%d):", len(info.Steps))))
+	for _, s := range info.Steps {
+		line := fmt.Sprintf("  %s  %s", s.Ref, s.Title)
+		if s.Tier != "" {
+			line += style.Dim.Render(" [" + s.Tier + "]")
+		}
+		fmt.Println(line)
+		if len(s.Needs) > 0 {
+			fmt.Printf("      %s\n", style.Dim.Render("needs: "+strings.Join(s.Needs, ", ")))
+		}
+		if s.Condition != "" {
+			fmt.Printf("      %s\n", style.Dim.Render("if: "+s.Condition))
+		}
+	}
+	return nil
+}
+
+// varRequirement describes whether a context variable must be given.
+func varRequirement(v beads.MoleculeVar) string {
+	switch {
+	case v.Required:
+		return "required"
+	case v.HasDefault:
+		return fmt.Sprintf("default: %q", v.Default)
+	default:
+		return "optional"
+	}
+}
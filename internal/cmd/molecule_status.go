@@ -109,6 +109,11 @@ type MoleculeProgressInfo struct {
 	BlockedSteps []string `json:"blocked_steps"`
 	Percent      int      `json:"percent_complete"`
 	Complete     bool     `json:"complete"`
+
+	// Step graph, filled in by 'gt mol progress' (see buildMoleculeGraph)
+	Steps        []MoleculeStepNode `json:"steps,omitempty"`
+	CriticalPath []string           `json:"critical_path,omitempty"`
+	Remaining    *MoleculeRemaining `json:"remaining,omitempty"`
 }
 
 // MoleculeStatusInfo contains status information for an agent's work.
@@ -121,6 +126,7 @@ type MoleculeStatusInfo struct {
 	AttachedMolecule string                `json:"attached_molecule,omitempty"`
 	AttachedAt       string                `json:"attached_at,omitempty"`
 	AttachedArgs     string                `json:"attached_args,omitempty"`
+	Suspended        []string              `json:"suspended_molecules,omitempty"` // Below the attached molecule, resumed first to last
 	IsWisp           bool                  `json:"is_wisp"`
 	Progress         *MoleculeProgressInfo `json:"progress,omitempty"`
 	NextAction       string                `json:"next_action,omitempty"`
@@ -140,129 +146,6 @@ type MoleculeCurrentInfo struct {
 	Status        string `json:"status"` // "working", "naked", "complete", "blocked"
 }
 
-func runMoleculeProgress(cmd *cobra.Command, args []string) error {
-	rootID := args[0]
-
-	workDir, err := findLocalBeadsDir()
-	if err != nil {
-		return fmt.Errorf("not in a beads workspace: %w", err)
-	}
-
-	b := beads.New(workDir)
-
-	// Get the root issue
-	root, err := b.Show(rootID)
-	if err != nil {
-		return fmt.Errorf("getting root issue: %w", err)
-	}
-
-	// Find all children of the root issue
-	children, err := b.List(beads.ListOptions{
-		Parent:   rootID,
-		Status:   "all",
-		Priority: -1,
-	})
-	if err != nil {
-		return fmt.Errorf("listing children: %w", err)
-	}
-
-	if len(children) == 0 {
-		return fmt.Errorf("no steps found for %s (not a molecule root?)", rootID)
-	}
-
-	// Build progress info
-	progress := MoleculeProgressInfo{
-		RootID:    rootID,
-		RootTitle: root.Title,
-	}
-
-	// Try to find molecule ID from first child's description
-	for _, child := range children {
-		if molID := extractMoleculeID(child.Description); molID != "" {
-			progress.MoleculeID = molID
-			break
-		}
-	}
-
-	// Build set of closed issue IDs for dependency checking
-	closedIDs := make(map[string]bool)
-	for _, child := range children {
-		if child.Status == "closed" {
-			closedIDs[child.ID] = true
-		}
-	}
-
-	// Categorize steps
-	for _, child := range children {
-		progress.TotalSteps++
-
-		switch child.Status {
-		case "closed":
-			progress.DoneSteps++
-		case "in_progress":
-			progress.InProgress++
-		case "open":
-			// Check if all dependencies are closed
-			allDepsClosed := true
-			for _, depID := range child.DependsOn {
-				if !closedIDs[depID] {
-					allDepsClosed = false
-					break
-				}
-			}
-
-			if len(child.DependsOn) == 0 || allDepsClosed {
-				progress.ReadySteps = append(progress.ReadySteps, child.ID)
-			} else {
-				progress.BlockedSteps = append(progress.BlockedSteps, child.ID)
-			}
-		}
-	}
-
-	// Calculate completion percentage
-	if progress.TotalSteps > 0 {
-		progress.Percent = (progress.DoneSteps * 100) / progress.TotalSteps
-	}
-	progress.Complete = progress.DoneSteps == progress.TotalSteps
-
-	// JSON output
-	if moleculeJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(progress)
-	}
-
-	// Human-readable output
-	fmt.Printf("\n%s %s\n\n", style.Bold.Render("🧬 Molecule Progress:"), root.Title)
-	fmt.Printf("  Root: %s\n", rootID)
-	if progress.MoleculeID != "" {
-		fmt.Printf("  Molecule: %s\n", progress.MoleculeID)
-	}
-	fmt.Println()
-
-	// Progress bar
-	barWidth := 20
-	filled := (progress.Percent * barWidth) / 100
-	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
-	fmt.Printf("  [%s] %d%% (%d/%d)\n\n", bar, progress.Percent, progress.DoneSteps, progress.TotalSteps)
-
-	// Step status
-	fmt.Printf("  Done:        %d\n", progress.DoneSteps)
-	fmt.Printf("  In Progress: %d\n", progress.InProgress)
-	fmt.Printf("  Ready:       %d", len(progress.ReadySteps))
-	if len(progress.ReadySteps) > 0 {
-		fmt.Printf(" (%s)", strings.Join(progress.ReadySteps, ", "))
-	}
-	fmt.Println()
-	fmt.Printf("  Blocked:     %d\n", len(progress.BlockedSteps))
-
-	if progress.Complete {
-		fmt.Printf("\n  %s\n", style.Bold.Render("✓ Molecule complete!"))
-	}
-
-	return nil
-}
-
 // extractMoleculeID extracts the molecule ID from an issue's description.
 func extractMoleculeID(description string) string {
 	lines := strings.Split(description, "\n")
@@ -361,6 +244,7 @@ func runMoleculeStatus(cmd *cobra.Command, args []string) error {
 			status.AttachedMolecule = attachment.AttachedMolecule
 			status.AttachedAt = attachment.AttachedAt
 			status.AttachedArgs = attachment.AttachedArgs
+			status.Suspended = attachment.SuspendedMolecules()
 
 			// Check if it's a wisp
 			status.IsWisp = strings.Contains(hookBead.Description, "wisp: true") ||
@@ -417,6 +301,7 @@ func runMoleculeStatus(cmd *cobra.Command, args []string) error {
 				status.AttachedMolecule = attachment.AttachedMolecule
 				status.AttachedAt = attachment.AttachedAt
 				status.AttachedArgs = attachment.AttachedArgs
+				status.Suspended = attachment.SuspendedMolecules()
 
 				// Check if it's a wisp
 				status.IsWisp = strings.Contains(hookedBeads[0].Description, "wisp: true") ||
@@ -495,15 +380,19 @@ func getMoleculeProgressInfo(b *beads.Beads, moleculeRootID string) (*MoleculePr
 		return nil, nil
 	}
 
-	// Build progress info
+	return summarizeMoleculeProgress(root, children), nil
+}
+
+// summarizeMoleculeProgress counts a molecule instance's steps by status.
+func summarizeMoleculeProgress(root *beads.Issue, children []*beads.Issue) *MoleculeProgressInfo {
 	progress := &MoleculeProgressInfo{
-		RootID:    moleculeRootID,
+		RootID:    root.ID,
 		RootTitle: root.Title,
 	}
 
-	// Try to find molecule ID from first child's description
+	// Try to find molecule ID from the first child's provenance
 	for _, child := range children {
-		if molID := extractMoleculeID(child.Description); molID != "" {
+		if molID := beads.StepMoleculeID(child); molID != "" {
 			progress.MoleculeID = molID
 			break
 		}
@@ -550,7 +439,7 @@ func getMoleculeProgressInfo(b *beads.Beads, moleculeRootID string) (*MoleculePr
 	}
 	progress.Complete = progress.DoneSteps == progress.TotalSteps
 
-	return progress, nil
+	return progress
 }
 
 // determineNextAction suggests the next action based on status.
@@ -638,6 +527,9 @@ func outputMoleculeStatus(status MoleculeStatusInfo) error {
 		if status.AttachedArgs != "" {
 			fmt.Printf("   %s %s\n", style.Bold.Render("Args:"), status.AttachedArgs)
 		}
+		if len(status.Suspended) > 0 {
+			fmt.Printf("   Suspended: %s\n", strings.Join(status.Suspended, ", "))
+		}
 	} else {
 		fmt.Printf("%s\n", style.Dim.Render("No molecule attached (hooked bead still triggers autonomous work)"))
 	}
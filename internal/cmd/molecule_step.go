@@ -6,9 +6,11 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -38,8 +40,9 @@ close steps with 'bd close' - it skips the auto-continuation logic.
 
 Example:
   gt mol step done gt-abc.1    # Complete step 1 of molecule gt-abc`,
-	Args: cobra.ExactArgs(1),
-	RunE: runMoleculeStepDone,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMoleculeStepDone,
 }
 
 var (
@@ -114,6 +117,7 @@ func runMoleculeStepDone(cmd *cobra.Command, args []string) error {
 		}
 		result.StepClosed = true
 		fmt.Printf("%s Closed step %s: %s\n", style.Bold.Render("✓"), stepID, step.Title)
+		publishMoleculeEvent(events.MoleculeEvent{Type: events.TypeStepDone, Molecule: moleculeID, Step: stepID})
 	}
 
 	// Step 4: Find the next ready step
@@ -125,6 +129,9 @@ func runMoleculeStepDone(cmd *cobra.Command, args []string) error {
 	if allComplete {
 		result.Complete = true
 		result.Action = "done"
+		if !moleculeStepDryRun {
+			publishMoleculeEvent(events.MoleculeEvent{Type: events.TypeMoleculeCompleted, Molecule: moleculeID})
+		}
 	} else if nextStep != nil {
 		result.NextStepID = nextStep.ID
 		result.NextStepTitle = nextStep.Title
@@ -294,6 +301,11 @@ func handleStepContinue(cwd, townRoot, _ string, nextStep *beads.Issue, dryRun b
 
 	fmt.Printf("%s Next step pinned: %s\n", style.Bold.Render("📌"), nextStep.ID)
 
+	// Record when the step was picked up, for step durations and SLAs
+	if err := beads.New(gitRoot).MarkStepStarted(nextStep.ID, time.Now()); err != nil {
+		style.PrintWarning("could not record step start: %v", err)
+	}
+
 	// Respawn the pane
 	if !tmux.IsInsideTmux() {
 		// Not in tmux - just print next action
@@ -102,7 +102,8 @@ Examples:
   gt mq submit --epic gt-xyz             # Target integration branch explicitly
   gt mq submit --priority 0              # Override priority (P0)
   gt mq submit --no-cleanup              # Submit without auto-cleanup`,
-	RunE: runMqSubmit,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMqSubmit,
 }
 
 var mqRetryCmd = &cobra.Command{
@@ -116,8 +117,9 @@ The MR must be in a failed state (open with an error).
 Examples:
   gt mq retry greenplace gp-mr-abc123
   gt mq retry greenplace gp-mr-abc123 --now`,
-	Args: cobra.ExactArgs(2),
-	RunE: runMQRetry,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMQRetry,
 }
 
 var mqListCmd = &cobra.Command{
@@ -154,8 +156,9 @@ The source issue is NOT closed (work is not done).
 Examples:
   gt mq reject greenplace polecat/Nux/gp-xyz --reason "Does not meet requirements"
   gt mq reject greenplace mr-Nux-12345 --reason "Superseded by other work" --notify`,
-	Args: cobra.ExactArgs(2),
-	RunE: runMQReject,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMQReject,
 }
 
 var mqStatusCmd = &cobra.Command{
@@ -218,8 +221,9 @@ Examples:
 
   gt mq integration create RA-123 --branch "klauern/PROJ-1234/{epic}"
   # Creates klauern/PROJ-1234/RA-123`,
-	Args: cobra.ExactArgs(1),
-	RunE: runMqIntegrationCreate,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMqIntegrationCreate,
 }
 
 var mqIntegrationLandCmd = &cobra.Command{
@@ -248,8 +252,9 @@ Examples:
   gt mq integration land gt-auth-epic
   gt mq integration land gt-auth-epic --dry-run
   gt mq integration land gt-auth-epic --force --skip-tests`,
-	Args: cobra.ExactArgs(1),
-	RunE: runMqIntegrationLand,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runMqIntegrationLand,
 }
 
 var mqIntegrationStatusCmd = &cobra.Command{
@@ -43,23 +43,26 @@ var namepoolThemesCmd = &cobra.Command{
 }
 
 var namepoolSetCmd = &cobra.Command{
-	Use:   "set <theme>",
-	Short: "Set the namepool theme for this rig",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runNamepoolSet,
+	Use:         "set <theme>",
+	Short:       "Set the namepool theme for this rig",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runNamepoolSet,
 }
 
 var namepoolAddCmd = &cobra.Command{
-	Use:   "add <name>",
-	Short: "Add a custom name to the pool",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runNamepoolAdd,
+	Use:         "add <name>",
+	Short:       "Add a custom name to the pool",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runNamepoolAdd,
 }
 
 var namepoolResetCmd = &cobra.Command{
-	Use:   "reset",
-	Short: "Reset the pool state (release all names)",
-	RunE:  runNamepoolReset,
+	Use:         "reset",
+	Short:       "Reset the pool state (release all names)",
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runNamepoolReset,
 }
 
 func init() {
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/notify"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var notificationsTestSeverity string
+
+var notificationsCmd = &cobra.Command{
+	Use:     "notifications",
+	GroupID: GroupComm,
+	Short:   "Manage notification sinks (Slack, Discord, webhook, email, desktop, bell)",
+	RunE:    requireSubcommand,
+	Long: `Manage notification sinks.
+
+Escalations, cost budget alerts, and refinery merge results are delivered to
+the sinks configured in settings/notifications.json. Each sink can filter by
+event type and minimum severity, and is rate limited per hour.
+
+Desktop sinks (osascript on macOS, notify-send elsewhere) and bell sinks
+(the terminal bell of attached gt tmux clients) are local: while the daemon
+runs, they also get mail arrival, escalations, and molecule completions from
+the event log. Mail to the overseer is high severity, to the mayor medium,
+and between other agents low.
+
+Event types: escalation, cost_alert, merged, merge_failed, mail,
+molecule_completed, test
+
+Example settings/notifications.json:
+  {
+    "type": "notifications",
+    "version": 1,
+    "sinks": {
+      "ops-slack": {"type": "slack", "url": "https://hooks.slack.com/...",
+                    "min_severity": "high", "max_per_hour": 10},
+      "merges":    {"type": "discord", "url": "https://discord.com/api/webhooks/...",
+                    "events": ["merged", "merge_failed"]},
+      "pager":     {"type": "webhook", "url": "https://example.com/hook",
+                    "headers": {"Authorization": "Bearer ..."}},
+      "oncall":    {"type": "email", "to": ["oncall@example.com"],
+                    "smtp_host": "smtp.example.com", "username": "gt",
+                    "password_env": "GT_SMTP_PASSWORD"},
+      "desktop":   {"type": "desktop", "min_severity": "medium"},
+      "bell":      {"type": "bell", "events": ["escalation"]}
+    }
+  }
+
+Not to be confused with 'gt notify', which sets an agent's in-session
+notification level.`,
+}
+
+var notificationsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured notification sinks",
+	RunE:  runNotificationsList,
+}
+
+var notificationsTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Send a test notification to all matching sinks",
+	Long: `Send a test notification through the configured sinks.
+
+The test event counts against each sink's rate limit.
+
+Examples:
+  gt notifications test
+  gt notifications test --severity critical`,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runNotificationsTest,
+}
+
+func init() {
+	notificationsTestCmd.Flags().StringVar(&notificationsTestSeverity, "severity", config.SeverityHigh, "Severity of the test notification")
+
+	notificationsCmd.AddCommand(notificationsListCmd)
+	notificationsCmd.AddCommand(notificationsTestCmd)
+	rootCmd.AddCommand(notificationsCmd)
+}
+
+// sendNotification delivers n to the town's notification sinks, printing
+// a warning for each failed or rate-limited sink. Delivery never fails the
+// calling command.
+func sendNotification(townRoot string, n notify.Notification) {
+	results, err := notify.Send(townRoot, n)
+	if err != nil {
+		style.PrintWarning("notifications: %v", err)
+		return
+	}
+	for _, r := range results {
+		switch {
+		case r.RateLimited:
+			style.PrintWarning("notification to %s skipped: rate limit reached", r.Sink)
+		case r.Error != nil:
+			style.PrintWarning("notification to %s failed: %v", r.Sink, r.Error)
+		}
+	}
+}
+
+func runNotificationsList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	cfg, err := config.LoadOrCreateNotificationsConfig(config.NotificationsConfigPath(townRoot))
+	if err != nil {
+		return err
+	}
+	if len(cfg.Sinks) == 0 {
+		fmt.Printf("%s No sinks configured. Add them to %s\n",
+			style.Dim.Render("○"), config.NotificationsConfigPath(townRoot))
+		return nil
+	}
+
+	names := make([]string, 0, len(cfg.Sinks))
+	for name := range cfg.Sinks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		sink := cfg.Sinks[name]
+		mark := style.Success.Render("●")
+		if sink.Disabled {
+			mark = style.Dim.Render("○")
+		}
+		events := "all events"
+		if len(sink.Events) > 0 {
+			events = strings.Join(sink.Events, ", ")
+		}
+		minSev := sink.MinSeverity
+		if minSev == "" {
+			minSev = config.SeverityLow
+		}
+		limit := "unlimited"
+		if sink.MaxPerHour > 0 {
+			limit = fmt.Sprintf("%d/hour", sink.MaxPerHour)
+		}
+		fmt.Printf("%s %s %s\n", mark, style.Bold.Render(name), style.Dim.Render("("+sink.Type+")"))
+		fmt.Printf("    %s, severity ≥ %s, %s\n", events, minSev, limit)
+	}
+	return nil
+}
+
+func runNotificationsTest(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	if !config.IsValidSeverity(notificationsTestSeverity) {
+		return fmt.Errorf("invalid severity '%s': must be critical, high, medium, or low", notificationsTestSeverity)
+	}
+
+	results, err := notify.Send(townRoot, notify.Notification{
+		Event:    notify.EventTest,
+		Severity: notificationsTestSeverity,
+		Title:    "Test notification",
+		Body:     "If you can read this, gastown notifications are working.",
+		Source:   detectSender(),
+	})
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		fmt.Printf("%s No sinks matched (check settings/notifications.json)\n", style.Dim.Render("○"))
+		return nil
+	}
+
+	failed := 0
+	for _, r := range results {
+		switch {
+		case r.RateLimited:
+			fmt.Printf("  %s %s: rate limited\n", style.Warning.Render("⚠"), r.Sink)
+		case r.Error != nil:
+			failed++
+			fmt.Printf("  %s %s: %v\n", style.Error.Render("✗"), r.Sink, r.Error)
+		default:
+			fmt.Printf("  %s %s\n", style.Success.Render("✓"), r.Sink)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d sink(s) failed", failed)
+	}
+	return nil
+}
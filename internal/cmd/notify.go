@@ -30,8 +30,9 @@ Examples:
   gt notify muted     # Enable DND mode
 
 Related: gt dnd - quick toggle for DND mode`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runNotify,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runNotify,
 }
 
 func init() {
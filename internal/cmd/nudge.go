@@ -35,8 +35,8 @@ witness, refinery, mayor, or deacon. Use this for real-time coordination when
 you need immediate attention from another worker.
 
 Uses a reliable delivery pattern:
-1. Sends text in literal mode (-l flag)
-2. Waits 500ms for paste to complete
+1. Pastes the text as a bracketed paste, in chunks for long messages
+2. Waits for the pane to show it, then 500ms more
 3. Sends Enter as a separate command
 
 This is the ONLY way to send messages to Claude sessions.
@@ -64,8 +64,9 @@ Examples:
   gt nudge witness "Check polecat health"
   gt nudge deacon session-started
   gt nudge channel:workers "New priority work available"`,
-	Args: cobra.RangeArgs(1, 2),
-	RunE: runNudge,
+	Args:        cobra.RangeArgs(1, 2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runNudge,
 }
 
 func runNudge(cmd *cobra.Command, args []string) error {
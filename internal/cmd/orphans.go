@@ -79,7 +79,8 @@ Examples:
   gt orphans kill --all        # Kill all orphans
   gt orphans kill --dry-run    # Preview without deleting
   gt orphans kill --force      # Skip confirmation prompt`,
-	RunE: runOrphansKill,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runOrphansKill,
 }
 
 // Process orphan commands
@@ -139,7 +140,8 @@ Examples:
   gt orphans procs kill             # Kill with confirmation
   gt orphans procs kill -f          # Force kill without confirmation
   gt orphans procs kill --aggressive # Kill ALL orphans (tmux verification)`,
-	RunE: runOrphansKillProcesses,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runOrphansKillProcesses,
 }
 
 func init() {
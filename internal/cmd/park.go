@@ -46,8 +46,9 @@ Examples:
   # Park on a GitHub Actions gate
   bd gate create --await gh:run:123456789
   gt park <gate-id> -m "Waiting for CI to complete"`,
-	Args: cobra.ExactArgs(1),
-	RunE: runPark,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runPark,
 }
 
 var (
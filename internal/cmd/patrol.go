@@ -52,7 +52,8 @@ Examples:
   gt patrol digest --yesterday   # Digest yesterday's patrols (for daily patrol)
   gt patrol digest --date 2026-01-15
   gt patrol digest --yesterday --dry-run`,
-	RunE: runPatrolDigest,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runPatrolDigest,
 }
 
 func init() {
@@ -182,9 +183,15 @@ func runPatrolDigest(cmd *cobra.Command, args []string) error {
 func queryPatrolDigests(targetDate time.Time) ([]PatrolCycleEntry, error) {
 	// List closed issues with "digest" label that are ephemeral
 	// Patrol digests have titles like "Digest: mol-deacon-patrol", "Digest: mol-witness-patrol"
+	// bd narrows by title and creation window; the window has a day of slack
+	// either side because digests are bucketed by their own timezone below.
+	day := time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), 0, 0, 0, 0, targetDate.Location())
 	listCmd := exec.Command("bd", "list",
 		"--status=closed",
 		"--label=digest",
+		"--title-contains=Digest: mol-",
+		"--created-after="+day.AddDate(0, 0, -1).UTC().Format(time.RFC3339),
+		"--created-before="+day.AddDate(0, 0, 2).UTC().Format(time.RFC3339),
 		"--json",
 		"--limit=0", // Get all
 	)
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// ErrPermissionDenied is returned when the current identity may not run a command.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// guardedCommands maps full command paths to the operation they perform.
+// Commands not listed here are not subject to permission checks.
+var guardedCommands = map[string]string{
+	"gt rig add":                 config.OpRigAdd,
+	"gt rig quick-add":           config.OpRigAdd,
+	"gt rig remove":              config.OpRigRemove,
+	"gt rig reset":               config.OpRigRemove,
+	"gt rig park":                config.OpRigDecommission,
+	"gt rig dock":                config.OpRigDecommission,
+	"gt rig shutdown":            config.OpRigDecommission,
+	"gt crew add":                config.OpCrewAdd,
+	"gt crew remove":             config.OpCrewRemove,
+	"gt crew rename":             config.OpCrewRename,
+	"gt crew start":              config.OpSessionStart,
+	"gt crew stop":               config.OpSessionStop,
+	"gt crew restart":            config.OpSessionRestart,
+	"gt polecat add":             config.OpPolecatAdd,
+	"gt polecat remove":          config.OpPolecatRemove,
+	"gt polecat nuke":            config.OpPolecatNuke,
+	"gt polecat gc":              config.OpPolecatRemove,
+	"gt polecat identity remove": config.OpPolecatRemove,
+	"gt dog remove":              config.OpDogRemove,
+	"gt orphans kill":            config.OpSessionKill,
+	"gt orphans procs kill":      config.OpSessionKill,
+	"gt session start":           config.OpSessionStart,
+	"gt session stop":            config.OpSessionStop,
+	"gt session restart":         config.OpSessionRestart,
+	"gt session inject":          config.OpSessionInject,
+	"gt witness start":           config.OpSessionStart,
+	"gt witness stop":            config.OpSessionStop,
+	"gt witness restart":         config.OpSessionRestart,
+	"gt refinery start":          config.OpSessionStart,
+	"gt refinery stop":           config.OpSessionStop,
+	"gt refinery restart":        config.OpSessionRestart,
+	"gt mayor start":             config.OpSessionStart,
+	"gt mayor stop":              config.OpSessionStop,
+	"gt mayor restart":           config.OpSessionRestart,
+	"gt mayor refresh":           config.OpSessionRestart,
+	"gt mayor directive":         config.OpMailSend,
+	"gt deacon start":            config.OpSessionStart,
+	"gt deacon stop":             config.OpSessionStop,
+	"gt deacon restart":          config.OpSessionRestart,
+	"gt deacon force-kill":       config.OpSessionKill,
+	"gt nudge":                   config.OpSessionInject,
+	"gt mail send":               config.OpMailSend,
+	"gt down":                    config.OpTownShutdown,
+	"gt shutdown":                config.OpTownShutdown,
+	"gt uninstall":               config.OpTownUninstall,
+	"gt serve token issue":       config.OpAPIToken,
+	"gt serve token revoke":      config.OpAPIToken,
+}
+
+// currentPermissionIdentity returns the identity and role used for
+// permission checks. Agents run with GT_ROLE set, and inside their tmux
+// session even if it was cleared; anyone else is the overseer.
+func currentPermissionIdentity() (identity, role string) {
+	if os.Getenv(EnvGTRole) == "" {
+		if identity = agentSessionAddress(); identity == "" {
+			return config.RoleOverseer, config.RoleOverseer
+		}
+		return identity, config.RoleForAddress(identity)
+	}
+	identity = detectSender()
+	return identity, config.RoleForAddress(identity)
+}
+
+// agentSessionAddress returns the address of the agent whose gt tmux
+// session this process runs in, or "" outside one.
+func agentSessionAddress() string {
+	if !tmux.IsInsideTmux() {
+		return ""
+	}
+	out, err := tmux.Command("display-message", "-p", "#{session_name}").Output()
+	if err != nil {
+		return ""
+	}
+	identity, err := session.ParseSessionName(strings.TrimSpace(string(out)))
+	if err != nil {
+		return ""
+	}
+	return identity.Address()
+}
+
+// forced reports whether cmd was run with --force.
+func forced(cmd *cobra.Command) bool {
+	flag := cmd.Flags().Lookup("force")
+	return flag != nil && flag.Changed && flag.Value.String() == "true"
+}
+
+// checkCommandPermission enforces the town permission policy for cmd.
+// Commands outside the guarded set, and invocations outside a town, are allowed.
+func checkCommandPermission(cmd *cobra.Command) error {
+	op, guarded := guardedCommands[buildCommandPath(cmd)]
+	if !guarded {
+		return nil
+	}
+
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return nil
+	}
+
+	policy, err := config.LoadOrCreatePermissionsConfig(config.PermissionsConfigPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading permissions: %w", err)
+	}
+
+	identity, role := currentPermissionIdentity()
+	ops := []string{op}
+	if forced(cmd) {
+		ops = append(ops, config.OpForce)
+	}
+	for _, op := range ops {
+		if !policy.Allows(identity, role, op) {
+			return fmt.Errorf("%w: %s (role %s) may not perform %s (%s)\n\nPermissions are configured in %s",
+				ErrPermissionDenied, identity, policy.EffectiveRole(identity, role), op,
+				buildCommandPath(cmd), config.PermissionsConfigPath(townRoot))
+		}
+	}
+	return nil
+}
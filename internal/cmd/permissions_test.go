@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+func TestCheckCommandPermissionForce(t *testing.T) {
+	townRoot := t.TempDir()
+	marker := filepath.Join(townRoot, workspace.PrimaryMarker)
+	if err := os.MkdirAll(filepath.Dir(marker), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(marker, []byte(`{"type":"town","version":2,"name":"test"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(townRoot)
+	t.Setenv("TMUX", "")
+
+	gt := &cobra.Command{Use: "gt"}
+	crew := &cobra.Command{Use: "crew"}
+	stop := &cobra.Command{Use: "stop"}
+	stop.Flags().Bool("force", false, "")
+	gt.AddCommand(crew)
+	crew.AddCommand(stop)
+
+	// Crew may stop sessions, but not with --force
+	t.Setenv(EnvGTRole, "gastown/crew/joe")
+	if err := checkCommandPermission(stop); err != nil {
+		t.Errorf("crew stop as crew: %v", err)
+	}
+	_ = stop.Flags().Set("force", "true")
+	if err := checkCommandPermission(stop); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("crew stop --force as crew: error = %v, want ErrPermissionDenied", err)
+	}
+
+	// The witness may force
+	t.Setenv(EnvGTRole, "gastown/witness")
+	if err := checkCommandPermission(stop); err != nil {
+		t.Errorf("crew stop --force as witness: %v", err)
+	}
+}
@@ -94,8 +94,9 @@ Examples:
   gt plugin run rebuild-gt              # Run if gate allows
   gt plugin run rebuild-gt --force      # Bypass gate check
   gt plugin run rebuild-gt --dry-run    # Show what would happen`,
-	Args: cobra.ExactArgs(1),
-	RunE: runPluginRun,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runPluginRun,
 }
 
 var pluginHistoryCmd = &cobra.Command{
@@ -22,10 +22,11 @@ import (
 
 // Polecat command flags
 var (
-	polecatListJSON  bool
-	polecatListAll   bool
-	polecatForce     bool
-	polecatRemoveAll bool
+	polecatListJSON      bool
+	polecatListAll       bool
+	polecatListResources bool
+	polecatForce         bool
+	polecatRemoveAll     bool
 )
 
 var polecatCmd = &cobra.Command{
@@ -64,10 +65,14 @@ all currently active polecats with their states:
   - done: Completed work, waiting for cleanup
   - stuck: Needs assistance
 
+With --resources, running sessions also show the CPU and memory used by
+their processes, to find a polecat that's pegging the machine.
+
 Examples:
   gt polecat list greenplace
   gt polecat list --all
-  gt polecat list greenplace --json`,
+  gt polecat list greenplace --json
+  gt polecat list --all --resources`,
 	RunE: runPolecatList,
 }
 
@@ -85,8 +90,9 @@ and initializes state.
 Example:
   gt polecat identity add greenplace Toast  # Preferred
   gt polecat add greenplace Toast           # Deprecated`,
-	Args: cobra.ExactArgs(2),
-	RunE: runPolecatAdd,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runPolecatAdd,
 }
 
 var polecatRemoveCmd = &cobra.Command{
@@ -103,8 +109,9 @@ Examples:
   gt polecat remove greenplace/Toast greenplace/Furiosa
   gt polecat remove greenplace --all
   gt polecat remove greenplace --all --force`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runPolecatRemove,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runPolecatRemove,
 }
 
 var polecatSyncCmd = &cobra.Command{
@@ -122,8 +129,9 @@ Examples:
   gt polecat sync greenplace/Toast
   gt polecat sync greenplace --all
   gt polecat sync greenplace/Toast --from-main`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runPolecatSync,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runPolecatSync,
 }
 
 var polecatStatusCmd = &cobra.Command{
@@ -173,8 +181,9 @@ This command removes orphaned branches:
 Examples:
   gt polecat gc greenplace
   gt polecat gc greenplace --dry-run`,
-	Args: cobra.ExactArgs(1),
-	RunE: runPolecatGC,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runPolecatGC,
 }
 
 var polecatNukeCmd = &cobra.Command{
@@ -202,8 +211,9 @@ Examples:
   gt polecat nuke greenplace --all
   gt polecat nuke greenplace --all --dry-run
   gt polecat nuke greenplace/Toast --force  # bypass safety checks`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runPolecatNuke,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runPolecatNuke,
 }
 
 var polecatGitStateCmd = &cobra.Command{
@@ -280,6 +290,7 @@ func init() {
 	// List flags
 	polecatListCmd.Flags().BoolVar(&polecatListJSON, "json", false, "Output as JSON")
 	polecatListCmd.Flags().BoolVar(&polecatListAll, "all", false, "List polecats in all rigs")
+	polecatListCmd.Flags().BoolVar(&polecatListResources, "resources", false, "Show CPU and memory use of running sessions")
 
 	// Remove flags
 	polecatRemoveCmd.Flags().BoolVarP(&polecatForce, "force", "f", false, "Force removal, bypassing checks")
@@ -333,6 +344,22 @@ type PolecatListItem struct {
 	State          polecat.State `json:"state"`
 	Issue          string        `json:"issue,omitempty"`
 	SessionRunning bool          `json:"session_running"`
+
+	// Resources is set with --resources for running sessions.
+	Resources *tmux.ProcessUsage `json:"resources,omitempty"`
+}
+
+// formatProcessUsage renders session resource usage as "cpu 12.5% mem 512MB (7 procs)".
+func formatProcessUsage(u *tmux.ProcessUsage) string {
+	mem := fmt.Sprintf("%dMB", u.RSSBytes/(1024*1024))
+	if u.RSSBytes >= 1024*1024*1024 {
+		mem = fmt.Sprintf("%.1fGB", float64(u.RSSBytes)/(1024*1024*1024))
+	}
+	out := fmt.Sprintf("cpu %.1f%% mem %s (%d procs)", u.CPUPercent, mem, u.Processes)
+	if u.CPUPercent >= 90 {
+		return style.Warning.Render(out)
+	}
+	return style.Dim.Render(out)
 }
 
 // getPolecatManager creates a polecat manager for the given rig.
@@ -388,13 +415,17 @@ func runPolecatList(cmd *cobra.Command, args []string) error {
 
 		for _, p := range polecats {
 			running, _ := polecatMgr.IsRunning(p.Name)
-			allPolecats = append(allPolecats, PolecatListItem{
+			item := PolecatListItem{
 				Rig:            r.Name,
 				Name:           p.Name,
 				State:          p.State,
 				Issue:          p.Issue,
 				SessionRunning: running,
-			})
+			}
+			if polecatListResources && running {
+				item.Resources, _ = polecatMgr.Resources(p.Name)
+			}
+			allPolecats = append(allPolecats, item)
 		}
 	}
 
@@ -434,7 +465,11 @@ func runPolecatList(cmd *cobra.Command, args []string) error {
 			stateStr = style.Dim.Render(stateStr)
 		}
 
-		fmt.Printf("  %s %s/%s  %s\n", sessionStatus, p.Rig, p.Name, stateStr)
+		fmt.Printf("  %s %s/%s  %s", sessionStatus, p.Rig, p.Name, stateStr)
+		if p.Resources != nil {
+			fmt.Printf("  %s", formatProcessUsage(p.Resources))
+		}
+		fmt.Println()
 		if p.Issue != "" {
 			fmt.Printf("    %s\n", style.Dim.Render(p.Issue))
 		}
@@ -631,6 +666,8 @@ type PolecatStatus struct {
 	Windows        int           `json:"windows,omitempty"`
 	CreatedAt      string        `json:"created_at,omitempty"`
 	LastActivity   string        `json:"last_activity,omitempty"`
+
+	Resources *tmux.ProcessUsage `json:"resources,omitempty"`
 }
 
 func runPolecatStatus(cmd *cobra.Command, args []string) error {
@@ -675,6 +712,7 @@ func runPolecatStatus(cmd *cobra.Command, args []string) error {
 			SessionID:      sessInfo.SessionID,
 			Attached:       sessInfo.Attached,
 			Windows:        sessInfo.Windows,
+			Resources:      sessInfo.Resources,
 		}
 		if !sessInfo.Created.IsZero() {
 			status.CreatedAt = sessInfo.Created.Format("2006-01-02 15:04:05")
@@ -744,6 +782,10 @@ func runPolecatStatus(cmd *cobra.Command, args []string) error {
 				sessInfo.LastActivity.Format("15:04:05"),
 				style.Dim.Render(ago))
 		}
+
+		if sessInfo.Resources != nil {
+			fmt.Printf("  Resources:     %s\n", formatProcessUsage(sessInfo.Resources))
+		}
 	} else {
 		fmt.Printf("  Status:        %s\n", style.Dim.Render("not running"))
 	}
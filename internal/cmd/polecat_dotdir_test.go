@@ -173,6 +173,9 @@ func setupTestTownForDotDir(t *testing.T) string {
 	if err := os.MkdirAll(mayorDir, 0755); err != nil {
 		t.Fatalf("mkdir mayor: %v", err)
 	}
+	if err := os.WriteFile(filepath.Join(mayorDir, "town.json"), []byte(`{"type":"town"}`), 0644); err != nil {
+		t.Fatalf("write town.json: %v", err)
+	}
 
 	rigsPath := filepath.Join(mayorDir, "rigs.json")
 	rigsConfig := &config.RigsConfig{
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// Polecat health command flags
+var (
+	polecatHealthJSON        bool
+	polecatHealthWedgedAfter time.Duration
+)
+
+var polecatHealthCmd = &cobra.Command{
+	Use:   "health <rig>/<polecat> | <rig>",
+	Short: "Check whether polecat agents are alive, wedged, or crashed",
+	Long: `Check the liveness of polecat sessions.
+
+Each running session is classified from the agent process inside the pane
+and the rig's liveness checks (by default: pane output, commits and file
+edits in the worktree, and updates to the hooked issue):
+
+  running  - agent process alive and the checks saw recent activity
+  wedged   - agent process alive but the checks saw none for too long
+  crashed  - tmux session exists but the agent process has exited
+
+Checks can be enabled, disabled, and weighted per rig under "liveness" in
+the rig's settings/config.json; --json shows each check's reading.
+
+Given a rig, checks every running polecat session in it.
+
+Examples:
+  gt polecat health greenplace/Toast
+  gt polecat health greenplace
+  gt polecat health greenplace --wedged-after 10m --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPolecatHealth,
+}
+
+func init() {
+	polecatHealthCmd.Flags().BoolVar(&polecatHealthJSON, "json", false, "Output as JSON")
+	polecatHealthCmd.Flags().DurationVar(&polecatHealthWedgedAfter, "wedged-after", polecat.DefaultWedgedAfter,
+		"Silence after which a live agent counts as wedged")
+
+	polecatCmd.AddCommand(polecatHealthCmd)
+}
+
+// PolecatHealthItem is a polecat's health in 'gt polecat health' output.
+type PolecatHealthItem struct {
+	Rig string `json:"rig"`
+	*polecat.HealthInfo
+	IdleSeconds int64 `json:"idle_seconds"`
+}
+
+func runPolecatHealth(cmd *cobra.Command, args []string) error {
+	var rigName string
+	var names []string
+	if strings.Contains(args[0], "/") {
+		r, name, err := parseAddress(args[0])
+		if err != nil {
+			return err
+		}
+		rigName, names = r, []string{name}
+	} else {
+		rigName = args[0]
+	}
+
+	sessMgr, _, err := getSessionManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	if names == nil {
+		infos, err := sessMgr.List()
+		if err != nil {
+			return fmt.Errorf("listing sessions: %w", err)
+		}
+		for _, info := range infos {
+			names = append(names, info.Polecat)
+		}
+	}
+
+	items := []PolecatHealthItem{}
+	for _, name := range names {
+		health, err := sessMgr.Health(name, polecatHealthWedgedAfter)
+		if err != nil {
+			if err == polecat.ErrSessionNotFound {
+				return fmt.Errorf("no session for %s/%s: %w", rigName, name, err)
+			}
+			return fmt.Errorf("checking %s/%s: %w", rigName, name, err)
+		}
+		items = append(items, PolecatHealthItem{
+			Rig:         rigName,
+			HealthInfo:  health,
+			IdleSeconds: int64(health.Idle.Seconds()),
+		})
+	}
+
+	if polecatHealthJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	}
+
+	if len(items) == 0 {
+		fmt.Printf("No polecat sessions running in %s.\n", rigName)
+		return nil
+	}
+	for _, item := range items {
+		var icon string
+		switch item.Health {
+		case polecat.HealthRunning:
+			icon = style.Success.Render("●")
+		case polecat.HealthWedged:
+			icon = style.Warning.Render("◐")
+		default:
+			icon = style.Error.Render("✗")
+		}
+		fmt.Printf("%s %s/%s  %s  %s\n", icon, item.Rig, item.Polecat,
+			style.Bold.Render(string(item.Health)), style.Dim.Render(item.Reason))
+	}
+	return nil
+}
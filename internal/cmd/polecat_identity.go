@@ -55,8 +55,9 @@ The identity bead tracks:
 Example:
   gt polecat identity add gastown Toast
   gt polecat identity add gastown  # auto-generate name`,
-	Args: cobra.RangeArgs(1, 2),
-	RunE: runPolecatIdentityAdd,
+	Args:        cobra.RangeArgs(1, 2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runPolecatIdentityAdd,
 }
 
 var polecatIdentityListCmd = &cobra.Command{
@@ -114,8 +115,9 @@ Safety checks:
 
 Example:
   gt polecat identity rename gastown Toast Imperator`,
-	Args: cobra.ExactArgs(3),
-	RunE: runPolecatIdentityRename,
+	Args:        cobra.ExactArgs(3),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runPolecatIdentityRename,
 }
 
 var polecatIdentityRemoveCmd = &cobra.Command{
@@ -133,8 +135,9 @@ Use --force to bypass safety checks.
 Example:
   gt polecat identity remove gastown Toast
   gt polecat identity remove gastown Toast --force`,
-	Args: cobra.ExactArgs(2),
-	RunE: runPolecatIdentityRemove,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runPolecatIdentityRemove,
 }
 
 func init() {
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var polecatLastExitJSON bool
+
+var polecatLastExitCmd = &cobra.Command{
+	Use:   "last-exit <rig>/<polecat>",
+	Short: "Show why a polecat's last session ended",
+	Long: `Show why a polecat's most recent session ended.
+
+Session terminations are recorded in <rig>/.runtime/terminations.jsonl
+with one of these reasons:
+
+  user-stop     - stopped with gt session stop, restart, or nuke
+  witness-kill  - killed by the witness (e.g., nuking a finished polecat)
+  schedule-stop - stopped when the rig's session window closed
+  agent-exit    - the agent process exited (with its exit code, if known)
+  server-death  - the tmux server went away
+
+Sessions that vanished without anyone stopping them are detected and
+recorded when this command runs.
+
+Examples:
+  gt polecat last-exit greenplace/Toast
+  gt polecat last-exit greenplace/Toast --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPolecatLastExit,
+}
+
+func init() {
+	polecatLastExitCmd.Flags().BoolVar(&polecatLastExitJSON, "json", false, "Output as JSON")
+
+	polecatCmd.AddCommand(polecatLastExitCmd)
+}
+
+func runPolecatLastExit(cmd *cobra.Command, args []string) error {
+	rigName, polecatName, err := parseAddress(args[0])
+	if err != nil {
+		return err
+	}
+
+	sessMgr, r, err := getSessionManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := sessMgr.DetectExits(); err != nil {
+		style.PrintWarning("could not check for lost sessions: %v", err)
+	}
+
+	exit, err := polecat.LastExit(r.Path, polecatName)
+	if err != nil {
+		return err
+	}
+
+	if polecatLastExitJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(exit)
+	}
+
+	if exit == nil {
+		fmt.Printf("No recorded session exits for %s/%s.\n", rigName, polecatName)
+		return nil
+	}
+
+	reason := string(exit.Reason)
+	if exit.ExitCode != nil {
+		reason += fmt.Sprintf(" (exit code %d)", *exit.ExitCode)
+	}
+	var icon string
+	switch {
+	case exit.Reason == polecat.ExitUserStop, exit.Reason == polecat.ExitScheduleStop, exit.ExitCode != nil && *exit.ExitCode == 0:
+		icon = style.Dim.Render("○")
+	case exit.Reason == polecat.ExitWitnessKill:
+		icon = style.Warning.Render("◐")
+	default:
+		icon = style.Error.Render("✗")
+	}
+
+	fmt.Printf("%s %s/%s  %s\n", icon, rigName, polecatName, style.Bold.Render(reason))
+	ago := formatDurationAgo(time.Since(exit.At))
+	if ago != "just now" {
+		ago += " ago"
+	}
+	fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("%s (%s)", exit.At.Format("2006-01-02 15:04:05"), ago)))
+	if exit.Detail != "" {
+		fmt.Printf("  %s\n", style.Dim.Render(exit.Detail))
+	}
+	return nil
+}
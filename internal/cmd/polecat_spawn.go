@@ -61,7 +61,7 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
 	r, err := rigMgr.GetRig(rigName)
 	if err != nil {
-		return nil, fmt.Errorf("rig '%s' not found", rigName)
+		return nil, fmt.Errorf("rig '%s': %w", rigName, err)
 	}
 
 	// Get polecat manager (with tmux for session-aware allocation)
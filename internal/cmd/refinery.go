@@ -60,8 +60,9 @@ Examples:
   gt refinery start greenplace
   gt refinery start greenplace --foreground
   gt refinery start              # infer rig from cwd`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runRefineryStart,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRefineryStart,
 }
 
 var refineryStopCmd = &cobra.Command{
@@ -71,8 +72,9 @@ var refineryStopCmd = &cobra.Command{
 
 Gracefully stops the refinery, completing any in-progress merge first.
 If rig is not specified, infers it from the current directory.`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runRefineryStop,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRefineryStop,
 }
 
 var refineryStatusCmd = &cobra.Command{
@@ -110,8 +112,9 @@ If rig is not specified, infers it from the current directory.
 Examples:
   gt refinery attach greenplace
   gt refinery attach          # infer rig from cwd`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runRefineryAttach,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRefineryAttach,
 }
 
 var refineryRestartCmd = &cobra.Command{
@@ -125,8 +128,9 @@ If rig is not specified, infers it from the current directory.
 Examples:
   gt refinery restart greenplace
   gt refinery restart          # infer rig from cwd`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runRefineryRestart,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRefineryRestart,
 }
 
 var refineryClaimCmd = &cobra.Command{
@@ -144,8 +148,9 @@ environment variable, or defaults to "refinery-1".
 Examples:
   gt refinery claim gt-abc123
   GT_REFINERY_WORKER=refinery-2 gt refinery claim gt-abc123`,
-	Args: cobra.ExactArgs(1),
-	RunE: runRefineryClaim,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRefineryClaim,
 }
 
 var refineryReleaseCmd = &cobra.Command{
@@ -158,8 +163,9 @@ This clears the claim so other workers can pick up the MR.
 
 Examples:
   gt refinery release gt-abc123`,
-	Args: cobra.ExactArgs(1),
-	RunE: runRefineryRelease,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRefineryRelease,
 }
 
 var refineryUnclaimedCmd = &cobra.Command{
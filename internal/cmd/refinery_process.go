@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	refineryProcessLimit  int
+	refineryProcessDryRun bool
+)
+
+var refineryProcessCmd = &cobra.Command{
+	Use:   "process [rig]",
+	Short: "Process the merge queue deterministically",
+	Long: `Drain the rig's ready merge queue without an agent session.
+
+MRs are processed one at a time in queue order (highest score first). For each:
+  1. Claim the MR
+  2. Rebase the branch onto the latest target, if merge_queue.rebase is set
+  3. Run the rig's test command (merge_queue.test_command)
+  4. Merge and push, closing the MR and its source issue
+
+Failures never stop the queue:
+  - Conflicts create a resolution task and block the MR on it
+  - Test/build failures bounce the MR back to its author, with the test log
+    mailed to them; requeue with 'gt refinery release <mr-id>'
+
+Examples:
+  gt refinery process gastown
+  gt refinery process --limit 1
+  gt refinery process --dry-run`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRefineryProcess,
+}
+
+func init() {
+	refineryProcessCmd.Flags().IntVar(&refineryProcessLimit, "limit", 0, "Process at most N MRs (0 = all ready)")
+	refineryProcessCmd.Flags().BoolVarP(&refineryProcessDryRun, "dry-run", "n", false, "Show processing order without merging")
+	refineryCmd.AddCommand(refineryProcessCmd)
+}
+
+func runRefineryProcess(cmd *cobra.Command, args []string) error {
+	rigName := ""
+	if len(args) > 0 {
+		rigName = args[0]
+	}
+
+	_, r, rigName, err := getRefineryManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	if !eng.Config().Enabled {
+		return fmt.Errorf("merge queue is disabled for rig '%s' (merge_queue.enabled in config.json)", rigName)
+	}
+
+	if refineryProcessDryRun {
+		queue, err := eng.OrderedReadyMRs()
+		if err != nil {
+			return fmt.Errorf("listing ready MRs: %w", err)
+		}
+		fmt.Printf("%s Processing order for '%s':\n\n", style.Bold.Render("🏭"), rigName)
+		if len(queue) == 0 {
+			fmt.Printf("  %s\n", style.Dim.Render("(queue empty)"))
+			return nil
+		}
+		for i, mr := range queue {
+			if refineryProcessLimit > 0 && i >= refineryProcessLimit {
+				break
+			}
+			fmt.Printf("  %d. [P%d] %s → %s\n", i+1, mr.Priority, mr.Branch, mr.Target)
+			fmt.Printf("     ID: %s  Worker: %s\n", mr.ID, mr.Worker)
+		}
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	results, err := eng.ProcessQueue(ctx, getWorkerID(), refineryProcessLimit)
+	if err != nil && len(results) == 0 {
+		return err
+	}
+
+	merged, bounced := 0, 0
+	fmt.Println()
+	for _, qr := range results {
+		if qr.Result.Success {
+			merged++
+			fmt.Printf("  %s %s %s\n", style.Success.Render("✓"), qr.MR.ID, style.Dim.Render(qr.MR.Branch))
+			continue
+		}
+		bounced++
+		fmt.Printf("  %s %s %s: %s\n", style.Error.Render("✗"), qr.MR.ID, style.Dim.Render(qr.MR.Branch), qr.Result.Error)
+	}
+
+	if len(results) == 0 {
+		fmt.Printf("%s No ready MRs for '%s'\n", style.Dim.Render("○"), rigName)
+		return nil
+	}
+	fmt.Printf("\n%s %d merged, %d failed\n", style.Bold.Render("Refinery:"), merged, bounced)
+	return err
+}
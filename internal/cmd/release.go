@@ -28,8 +28,9 @@ Examples:
 
 This implements nondeterministic idempotence - work can be safely
 retried by releasing and reclaiming stuck steps.`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runRelease,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRelease,
 }
 
 func init() {
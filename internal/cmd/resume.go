@@ -35,7 +35,8 @@ Examples:
   gt resume              # Check for and resume parked work
   gt resume --status     # Just show parked work status without resuming
   gt resume --handoff    # Check inbox for handoff messages`,
-	RunE: runResume,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runResume,
 }
 
 var (
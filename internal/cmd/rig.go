@@ -54,17 +54,24 @@ This creates a rig container with:
   - crew/                 Empty crew directory (add members with 'gt crew add')
   - witness/              Witness agent directory
   - polecats/             Worker directory (empty)
+  - .runtime/             Runtime state (witness loop, patrol history)
 
 The command also:
+  - Registers the rig in mayor/rigs.json and routes.jsonl
+  - Installs role CLAUDE.md files and Claude settings
   - Seeds patrol molecules (Deacon, Witness, Refinery)
   - Creates ~/gt/plugins/ (town-level) if it doesn't exist
   - Creates <rig>/plugins/ (rig-level)
 
+With --start-witness, the rig's witness is started once the rig is set up.
+
 Example:
   gt rig add gastown https://github.com/steveyegge/gastown
-  gt rig add my-project git@github.com:user/repo.git --prefix mp`,
-	Args: cobra.ExactArgs(2),
-	RunE: runRigAdd,
+  gt rig add my-project git@github.com:user/repo.git --prefix mp
+  gt rig add my-project git@github.com:user/repo.git --start-witness`,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigAdd,
 }
 
 var rigListCmd = &cobra.Command{
@@ -73,13 +80,6 @@ var rigListCmd = &cobra.Command{
 	RunE:  runRigList,
 }
 
-var rigRemoveCmd = &cobra.Command{
-	Use:   "remove <name>",
-	Short: "Remove a rig from the registry (does not delete files)",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runRigRemove,
-}
-
 var rigResetCmd = &cobra.Command{
 	Use:   "reset",
 	Short: "Reset rig state (handoff content, mail, stale issues)",
@@ -93,7 +93,8 @@ Examples:
   gt rig reset --mail       # Clear stale mail messages only
   gt rig reset --stale      # Reset orphaned in_progress issues
   gt rig reset --stale --dry-run  # Preview what would be reset`,
-	RunE: runRigReset,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigReset,
 }
 
 var rigBootCmd = &cobra.Command{
@@ -110,8 +111,9 @@ on demand when work is assigned.
 
 Examples:
   gt rig boot greenplace`,
-	Args: cobra.ExactArgs(1),
-	RunE: runRigBoot,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigBoot,
 }
 
 var rigStartCmd = &cobra.Command{
@@ -131,8 +133,9 @@ Examples:
   gt rig start gastown
   gt rig start gastown beads
   gt rig start gastown beads myproject`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runRigStart,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigStart,
 }
 
 var rigRebootCmd = &cobra.Command{
@@ -146,8 +149,9 @@ Useful after polecats complete work and land their changes.
 Examples:
   gt rig reboot greenplace
   gt rig reboot beads --force`,
-	Args: cobra.ExactArgs(1),
-	RunE: runRigReboot,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigReboot,
 }
 
 var rigShutdownCmd = &cobra.Command{
@@ -172,8 +176,9 @@ Examples:
   gt rig shutdown greenplace
   gt rig shutdown greenplace --force
   gt rig shutdown greenplace --nuclear  # DANGER: loses uncommitted work`,
-	Args: cobra.ExactArgs(1),
-	RunE: runRigShutdown,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigShutdown,
 }
 
 var rigStatusCmd = &cobra.Command{
@@ -222,8 +227,9 @@ Examples:
   gt rig stop gastown beads
   gt rig stop --force gastown beads
   gt rig stop --nuclear gastown  # DANGER: loses uncommitted work`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runRigStop,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigStop,
 }
 
 var rigRestartCmd = &cobra.Command{
@@ -247,8 +253,9 @@ Examples:
   gt rig restart gastown beads
   gt rig restart --force gastown beads
   gt rig restart --nuclear gastown  # DANGER: loses uncommitted work`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runRigRestart,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigRestart,
 }
 
 // Flags
@@ -256,6 +263,7 @@ var (
 	rigAddPrefix       string
 	rigAddLocalRepo    string
 	rigAddBranch       string
+	rigAddStartWitness bool
 	rigResetHandoff    bool
 	rigResetMail       bool
 	rigResetStale      bool
@@ -275,7 +283,6 @@ func init() {
 	rigCmd.AddCommand(rigBootCmd)
 	rigCmd.AddCommand(rigListCmd)
 	rigCmd.AddCommand(rigRebootCmd)
-	rigCmd.AddCommand(rigRemoveCmd)
 	rigCmd.AddCommand(rigResetCmd)
 	rigCmd.AddCommand(rigRestartCmd)
 	rigCmd.AddCommand(rigShutdownCmd)
@@ -286,6 +293,7 @@ func init() {
 	rigAddCmd.Flags().StringVar(&rigAddPrefix, "prefix", "", "Beads issue prefix (default: derived from name)")
 	rigAddCmd.Flags().StringVar(&rigAddLocalRepo, "local-repo", "", "Local repo path to share git objects (optional)")
 	rigAddCmd.Flags().StringVar(&rigAddBranch, "branch", "", "Default branch name (default: auto-detected from remote)")
+	rigAddCmd.Flags().BoolVar(&rigAddStartWitness, "start-witness", false, "Start the rig's witness after setup")
 
 	rigResetCmd.Flags().BoolVar(&rigResetHandoff, "handoff", false, "Clear handoff content")
 	rigResetCmd.Flags().BoolVar(&rigResetMail, "mail", false, "Clear stale mail messages")
@@ -423,7 +431,19 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  ├── refinery/rig/     (worktree: %s, sees polecat branches)\n", defaultBranch)
 	fmt.Printf("  ├── crew/             (empty - add crew with 'gt crew add')\n")
 	fmt.Printf("  ├── witness/\n")
-	fmt.Printf("  └── polecats/\n")
+	fmt.Printf("  ├── polecats/\n")
+	fmt.Printf("  └── .runtime/\n")
+
+	if rigAddStartWitness {
+		fmt.Printf("\nStarting witness...\n")
+		if err := witness.NewManager(newRig).Start(false, "", nil); err != nil && err != witness.ErrAlreadyRunning {
+			// Non-fatal: the rig is set up, the witness can be started later
+			fmt.Printf("  %s Could not start witness: %v\n", style.Warning.Render("!"), err)
+			fmt.Printf("  Start it later with: gt witness start %s\n", name)
+		} else {
+			fmt.Printf("  %s Witness started\n", style.Success.Render("✓"))
+		}
+	}
 
 	fmt.Printf("\nNext steps:\n")
 	fmt.Printf("  gt crew add <name> --rig %s   # Create your personal workspace\n", name)
@@ -489,42 +509,6 @@ func runRigList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func runRigRemove(cmd *cobra.Command, args []string) error {
-	name := args[0]
-
-	// Find workspace
-	townRoot, err := workspace.FindFromCwdOrError()
-	if err != nil {
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
-	}
-
-	// Load rigs config
-	rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
-	rigsConfig, err := config.LoadRigsConfig(rigsPath)
-	if err != nil {
-		return fmt.Errorf("loading rigs config: %w", err)
-	}
-
-	// Create rig manager
-	g := git.NewGit(townRoot)
-	mgr := rig.NewManager(townRoot, rigsConfig, g)
-
-	if err := mgr.RemoveRig(name); err != nil {
-		return fmt.Errorf("removing rig: %w", err)
-	}
-
-	// Save updated config
-	if err := config.SaveRigsConfig(rigsPath, rigsConfig); err != nil {
-		return fmt.Errorf("saving rigs config: %w", err)
-	}
-
-	fmt.Printf("%s Rig %s removed from registry\n", style.Success.Render("✓"), name)
-	fmt.Printf("\nNote: Files at %s were NOT deleted.\n", filepath.Join(townRoot, name))
-	fmt.Printf("To delete: %s\n", style.Dim.Render(fmt.Sprintf("rm -rf %s", filepath.Join(townRoot, name))))
-
-	return nil
-}
-
 func runRigReset(cmd *cobra.Command, args []string) error {
 	// Find workspace
 	townRoot, err := workspace.FindFromCwdOrError()
@@ -740,7 +724,7 @@ func runRigBoot(cmd *cobra.Command, args []string) error {
 	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
 	r, err := rigMgr.GetRig(rigName)
 	if err != nil {
-		return fmt.Errorf("rig '%s' not found", rigName)
+		return fmt.Errorf("rig '%s': %w", rigName, err)
 	}
 
 	fmt.Printf("Booting rig %s...\n", style.Bold.Render(rigName))
@@ -924,7 +908,7 @@ func runRigShutdown(cmd *cobra.Command, args []string) error {
 	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
 	r, err := rigMgr.GetRig(rigName)
 	if err != nil {
-		return fmt.Errorf("rig '%s' not found", rigName)
+		return fmt.Errorf("rig '%s': %w", rigName, err)
 	}
 
 	// Check all polecats for uncommitted work (unless nuclear)
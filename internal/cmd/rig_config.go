@@ -62,8 +62,9 @@ Examples:
   gt rig config set gastown status parked           # Wisp layer
   gt rig config set gastown status docked --global  # Bead layer
   gt rig config set gastown auto_restart --block    # Block inheritance`,
-	Args: cobra.RangeArgs(2, 3),
-	RunE: runRigConfigSet,
+	Args:        cobra.RangeArgs(2, 3),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigConfigSet,
 }
 
 var rigConfigUnsetCmd = &cobra.Command{
@@ -76,8 +77,9 @@ Values set in the bead layer remain unchanged.
 
 Example:
   gt rig config unset gastown status`,
-	Args: cobra.ExactArgs(2),
-	RunE: runRigConfigUnset,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigConfigUnset,
 }
 
 // Flags
@@ -79,29 +79,11 @@ func runRigDetect(cmd *cobra.Command, args []string) error {
 }
 
 func detectRigFromPath(townRoot, absPath string) string {
-	rel, err := filepath.Rel(townRoot, absPath)
-	if err != nil || strings.HasPrefix(rel, "..") {
-		return ""
-	}
-
-	parts := strings.Split(rel, string(filepath.Separator))
-	if len(parts) == 0 || parts[0] == "." {
-		return ""
-	}
-
-	candidateRig := parts[0]
-
-	switch candidateRig {
-	case "mayor", "deacon", ".beads", ".claude", ".git", "plugins":
+	ctx, err := inferRigContext(townRoot, absPath)
+	if err != nil {
 		return ""
 	}
-
-	rigConfigPath := filepath.Join(townRoot, candidateRig, "config.json")
-	if _, err := os.Stat(rigConfigPath); err == nil {
-		return candidateRig
-	}
-
-	return ""
+	return ctx.Rig
 }
 
 func outputNotInRig() error {
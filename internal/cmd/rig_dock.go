@@ -36,8 +36,9 @@ Use 'gt rig undock' to resume normal operation.
 Examples:
   gt rig dock gastown
   gt rig dock beads`,
-	Args: cobra.ExactArgs(1),
-	RunE: runRigDock,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigDock,
 }
 
 var rigUndockCmd = &cobra.Command{
@@ -54,8 +55,9 @@ Undocking a rig:
 Examples:
   gt rig undock gastown
   gt rig undock beads`,
-	Args: cobra.ExactArgs(1),
-	RunE: runRigUndock,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigUndock,
 }
 
 func init() {
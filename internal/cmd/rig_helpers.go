@@ -4,7 +4,7 @@ import (
 	"fmt"
 
 	"github.com/steveyegge/gastown/internal/config"
-	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/daemon"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -19,8 +19,7 @@ func getRig(rigName string) (string, *rig.Rig, error) {
 		return "", nil, fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
-	rigsConfigPath := constants.MayorRigsPath(townRoot)
-	rigsConfig, err := config.LoadRigsConfig(rigsConfigPath)
+	rigsConfig, err := daemon.LoadRigsConfig(townRoot)
 	if err != nil {
 		rigsConfig = &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
 	}
@@ -29,7 +28,7 @@ func getRig(rigName string) (string, *rig.Rig, error) {
 	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
 	r, err := rigMgr.GetRig(rigName)
 	if err != nil {
-		return "", nil, fmt.Errorf("rig '%s' not found", rigName)
+		return "", nil, fmt.Errorf("rig '%s': %w", rigName, err)
 	}
 
 	return townRoot, r, nil
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/daemon"
+)
+
+// errNotInRig means a directory is in the town but not inside a rig.
+var errNotInRig = errors.New("could not infer rig from current directory")
+
+// townLevelDirs are top-level town directories that are never rigs.
+var townLevelDirs = map[string]bool{
+	"mayor":   true,
+	"deacon":  true,
+	"plugins": true,
+	"docs":    true,
+}
+
+// rigContext is where a directory sits within a rig.
+type rigContext struct {
+	Rig    string // rig name
+	Role   Role   // role of the subtree (crew, polecat, witness, refinery, mayor); RoleUnknown elsewhere in the rig
+	Worker string // crew member or polecat name, for RoleCrew and RolePolecat
+}
+
+// inferRigFromCwd determines the rig from the current directory.
+func inferRigFromCwd(townRoot string) (string, error) {
+	ctx, err := inferRigContextFromCwd(townRoot)
+	if err != nil {
+		return "", err
+	}
+	return ctx.Rig, nil
+}
+
+// inferRigContextFromCwd determines the rig and role context from the
+// current directory.
+func inferRigContextFromCwd(townRoot string) (rigContext, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return rigContext{}, fmt.Errorf("getting current directory: %w", err)
+	}
+	return inferRigContext(townRoot, cwd)
+}
+
+// inferRigContext determines the rig containing dir, and which part of the
+// rig (crew/<name>, polecats/<name>, witness, ...) it is in. Symlinks in
+// both paths are resolved first, so a workspace reached through a symlinked
+// home still maps to its rig. The top-level directory only counts as a rig
+// if it is registered in rigs.json or has a rig config.json.
+func inferRigContext(townRoot, dir string) (rigContext, error) {
+	town := resolvePath(townRoot)
+	rel, err := filepath.Rel(town, resolvePath(dir))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return rigContext{}, fmt.Errorf("%s is not in the town at %s", dir, townRoot)
+	}
+	if rel == "." {
+		return rigContext{}, errNotInRig
+	}
+
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	rigName := parts[0]
+	if townLevelDirs[rigName] || strings.HasPrefix(rigName, ".") || !isRigDir(townRoot, rigName) {
+		return rigContext{}, errNotInRig
+	}
+
+	ctx := rigContext{Rig: rigName, Role: RoleUnknown}
+	if len(parts) < 2 {
+		return ctx, nil
+	}
+	switch parts[1] {
+	case "crew":
+		if len(parts) >= 3 {
+			ctx.Role, ctx.Worker = RoleCrew, parts[2]
+		}
+	case "polecats":
+		if len(parts) >= 3 {
+			ctx.Role, ctx.Worker = RolePolecat, parts[2]
+		}
+	case "witness":
+		ctx.Role = RoleWitness
+	case "refinery":
+		ctx.Role = RoleRefinery
+	case "mayor":
+		ctx.Role = RoleMayor
+	}
+	return ctx, nil
+}
+
+// isRigDir reports whether name is a rig of the town.
+func isRigDir(townRoot, name string) bool {
+	if _, err := os.Stat(filepath.Join(townRoot, name, "config.json")); err == nil {
+		return true
+	}
+	rigsConfig, err := daemon.LoadRigsConfig(townRoot)
+	if err != nil {
+		return false
+	}
+	_, ok := rigsConfig.Rigs[name]
+	return ok
+}
+
+// resolvePath returns the absolute path with symlinks resolved, or just the
+// absolute path if it cannot be resolved (e.g., it does not exist).
+func resolvePath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved
+	}
+	return abs
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInferRigContext(t *testing.T) {
+	base := t.TempDir()
+	townRoot := filepath.Join(base, "gt")
+	for _, dir := range []string{
+		"mayor",
+		"deacon",
+		"gastown/crew/max/internal/cmd",
+		"gastown/polecats/toast",
+		"gastown/refinery/rig",
+		"beads/witness",
+		"notarig/sub",
+	} {
+		if err := os.MkdirAll(filepath.Join(townRoot, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{
+		"mayor/town.json":     `{"type":"town","version":2,"name":"test"}`,
+		"mayor/rigs.json":     `{"version":1,"rigs":{"beads":{}}}`,
+		"gastown/config.json": `{"type":"rig","name":"gastown"}`,
+	}
+	for path, content := range files {
+		if err := os.WriteFile(filepath.Join(townRoot, path), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A symlinked home pointing deep into a crew workspace
+	link := filepath.Join(base, "home-max")
+	if err := os.Symlink(filepath.Join(townRoot, "gastown/crew/max"), link); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		dir     string
+		want    rigContext
+		wantErr bool
+	}{
+		{"gastown", rigContext{Rig: "gastown", Role: RoleUnknown}, false},
+		{"gastown/crew/max/internal/cmd", rigContext{Rig: "gastown", Role: RoleCrew, Worker: "max"}, false},
+		{"gastown/polecats/toast", rigContext{Rig: "gastown", Role: RolePolecat, Worker: "toast"}, false},
+		{"gastown/refinery/rig", rigContext{Rig: "gastown", Role: RoleRefinery}, false},
+		{"beads/witness", rigContext{Rig: "beads", Role: RoleWitness}, false}, // registered in rigs.json only
+		{filepath.Join(link, "internal"), rigContext{Rig: "gastown", Role: RoleCrew, Worker: "max"}, false},
+		{".", rigContext{}, true},
+		{"mayor", rigContext{}, true},
+		{"deacon", rigContext{}, true},
+		{"notarig/sub", rigContext{}, true},
+		{base, rigContext{}, true}, // outside the town
+	}
+	for _, tt := range tests {
+		dir := tt.dir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(townRoot, dir)
+		}
+		got, err := inferRigContext(townRoot, dir)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("inferRigContext(%s) = %+v, want error", tt.dir, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("inferRigContext(%s) = %+v, %v; want %+v", tt.dir, got, err, tt.want)
+		}
+	}
+}
@@ -36,8 +36,9 @@ This is a Level 1 (local/ephemeral) operation:
 Examples:
   gt rig park gastown
   gt rig park beads gastown mayor`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runRigPark,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigPark,
 }
 
 var rigUnparkCmd = &cobra.Command{
@@ -53,8 +54,9 @@ Unparking a rig:
 Examples:
   gt rig unpark gastown
   gt rig unpark beads gastown mayor`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runRigUnpark,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigUnpark,
 }
 
 func init() {
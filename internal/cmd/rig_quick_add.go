@@ -34,8 +34,9 @@ Examples:
   gt rig quick-add                    # Add current directory
   gt rig quick-add ~/Repos/myproject  # Add specific path
   gt rig quick-add --yes              # Non-interactive`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runRigQuickAdd,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigQuickAdd,
 }
 
 func init() {
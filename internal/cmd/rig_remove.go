@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/witness"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// rigArchiveDir is where removed rigs are moved, relative to the town root.
+// It is hidden so it is never mistaken for a rig.
+const rigArchiveDir = ".archive/rigs"
+
+var (
+	rigRemoveForce        bool
+	rigRemoveRegistryOnly bool
+)
+
+var rigRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Decommission a rig (unregister and archive its directory)",
+	Long: `Decommission a rig.
+
+Removing a rig:
+  - Refuses while any of the rig's tmux sessions are running
+  - Refuses while crew or polecat workspaces have uncommitted work
+  - Marks the rig's witness loop as stopped
+  - Removes the rig from mayor/rigs.json and its routes from routes.jsonl
+  - Moves the rig directory to .archive/rigs/<name>-<timestamp>/
+
+With --force, running sessions are killed and dirty workspaces are archived
+as they are. Nothing is deleted: the archived directory keeps all work.
+
+Use --registry-only to only unregister the rig and leave its directory
+in place.
+
+Examples:
+  gt rig remove gastown
+  gt rig remove gastown --force
+  gt rig remove gastown --registry-only`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigRemove,
+}
+
+func init() {
+	rigRemoveCmd.Flags().BoolVarP(&rigRemoveForce, "force", "f", false, "Kill running sessions and archive dirty workspaces")
+	rigRemoveCmd.Flags().BoolVar(&rigRemoveRegistryOnly, "registry-only", false, "Only unregister the rig; leave its directory in place")
+
+	rigCmd.AddCommand(rigRemoveCmd)
+}
+
+func runRigRemove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	// Find workspace
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	// Load rigs config
+	rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
+	rigsConfig, err := config.LoadRigsConfig(rigsPath)
+	if err != nil {
+		return fmt.Errorf("loading rigs config: %w", err)
+	}
+
+	g := git.NewGit(townRoot)
+	mgr := rig.NewManager(townRoot, rigsConfig, g)
+
+	if rigRemoveRegistryOnly {
+		if err := unregisterRig(townRoot, rigsPath, rigsConfig, mgr, name); err != nil {
+			return err
+		}
+		fmt.Printf("%s Rig %s removed from registry\n", style.Success.Render("✓"), name)
+		fmt.Printf("\nNote: Files at %s were NOT moved.\n", filepath.Join(townRoot, name))
+		return nil
+	}
+
+	r, err := mgr.GetRig(name)
+	if err != nil {
+		return fmt.Errorf("rig '%s': %w", name, err)
+	}
+
+	// Dependency checks: live sessions and unsaved work
+	t := tmux.NewTmux()
+	allSessions, _ := t.ListSessions()
+	sessions := rigSessionNames(allSessions, name)
+	dirty := dirtyRigWorkspaces(r)
+
+	if !rigRemoveForce && (len(sessions) > 0 || len(dirty) > 0) {
+		fmt.Printf("%s Cannot remove rig %s:\n\n", style.Warning.Render("⚠"), style.Bold.Render(name))
+		for _, sess := range sessions {
+			fmt.Printf("  session running: %s\n", sess)
+		}
+		for _, d := range dirty {
+			fmt.Printf("  uncommitted work: %s\n", d)
+		}
+		fmt.Printf("\nStop the rig with %s, or use %s\n",
+			style.Bold.Render("gt rig shutdown "+name), style.Bold.Render("--force"))
+		return fmt.Errorf("refusing to remove rig with running sessions or uncommitted work")
+	}
+
+	fmt.Printf("Removing rig %s...\n", style.Bold.Render(name))
+
+	for _, sess := range sessions {
+		if err := t.KillSessionWithProcesses(sess); err != nil {
+			style.PrintWarning("could not kill session %s: %v", sess, err)
+		} else {
+			fmt.Printf("  Killed session %s\n", sess)
+		}
+	}
+	if len(dirty) > 0 {
+		style.PrintWarning("archiving %d workspace(s) with uncommitted work", len(dirty))
+	}
+
+	// Kill witness state so nothing treats the loop as running
+	if st, err := witness.LoadSupervisorState(r.Path); err == nil && st != nil && st.Running {
+		if err := witness.RecordLoopExit(r.Path, nil); err != nil {
+			style.PrintWarning("could not clear witness state: %v", err)
+		}
+	}
+
+	if err := unregisterRig(townRoot, rigsPath, rigsConfig, mgr, name); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(r.Path); os.IsNotExist(err) {
+		fmt.Printf("%s Rig %s removed (directory was already gone)\n", style.Success.Render("✓"), name)
+		return nil
+	}
+
+	archivePath := filepath.Join(townRoot, rigArchiveDir, fmt.Sprintf("%s-%s", name, time.Now().Format("20060102-150405")))
+	if err := os.MkdirAll(filepath.Dir(archivePath), 0755); err != nil {
+		return fmt.Errorf("creating archive directory: %w", err)
+	}
+	if err := os.Rename(r.Path, archivePath); err != nil {
+		return fmt.Errorf("archiving rig directory (rig is already unregistered): %w", err)
+	}
+
+	fmt.Printf("%s Rig %s removed\n", style.Success.Render("✓"), name)
+	fmt.Printf("  Archived to %s\n", archivePath)
+	return nil
+}
+
+// unregisterRig removes a rig from rigs.json and drops its routes.
+func unregisterRig(townRoot, rigsPath string, rigsConfig *config.RigsConfig, mgr *rig.Manager, name string) error {
+	if err := mgr.RemoveRig(name); err != nil {
+		return fmt.Errorf("removing rig: %w", err)
+	}
+	if err := config.SaveRigsConfig(rigsPath, rigsConfig); err != nil {
+		return fmt.Errorf("saving rigs config: %w", err)
+	}
+
+	beadsDir := filepath.Join(townRoot, ".beads")
+	routes, err := beads.LoadRoutes(beadsDir)
+	if err != nil {
+		style.PrintWarning("could not load routes.jsonl: %v", err)
+		return nil
+	}
+	kept := routesOutsideRig(routes, name)
+	if len(kept) != len(routes) {
+		if err := beads.WriteRoutes(beadsDir, kept); err != nil {
+			style.PrintWarning("could not update routes.jsonl: %v", err)
+		}
+	}
+	return nil
+}
+
+// routesOutsideRig drops the routes that point into a rig.
+func routesOutsideRig(routes []beads.Route, rigName string) []beads.Route {
+	var kept []beads.Route
+	for _, r := range routes {
+		if r.Path == rigName || strings.HasPrefix(r.Path, rigName+"/") {
+			continue
+		}
+		kept = append(kept, r)
+	}
+	return kept
+}
+
+// rigSessionNames returns the sessions that belong to a rig
+// (gt-<rig>-witness, gt-<rig>-crew-<name>, ...).
+func rigSessionNames(sessions []string, rigName string) []string {
+	prefix := session.Prefix + rigName + "-"
+	var names []string
+	for _, sess := range sessions {
+		if strings.HasPrefix(sess, prefix) {
+			names = append(names, sess)
+		}
+	}
+	return names
+}
+
+// dirtyRigWorkspaces describes the crew and polecat workspaces of a rig
+// that have uncommitted, stashed or unpushed work.
+func dirtyRigWorkspaces(r *rig.Rig) []string {
+	var dirty []string
+	check := func(label, path string) {
+		status, err := git.NewGit(path).CheckUncommittedWork()
+		if err == nil && !status.Clean() {
+			dirty = append(dirty, fmt.Sprintf("%s (%s)", label, status.String()))
+		}
+	}
+
+	if entries, err := os.ReadDir(filepath.Join(r.Path, "crew")); err == nil {
+		for _, e := range entries {
+			if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+				check("crew/"+e.Name(), filepath.Join(r.Path, "crew", e.Name()))
+			}
+		}
+	}
+
+	polecatMgr := polecat.NewManager(r, git.NewGit(r.Path), nil) // nil tmux: just listing
+	if polecats, err := polecatMgr.List(); err == nil {
+		for _, p := range polecats {
+			check("polecats/"+p.Name, p.ClonePath)
+		}
+	}
+	return dirty
+}
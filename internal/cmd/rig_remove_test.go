@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestRigSessionNames(t *testing.T) {
+	sessions := []string{"gt-gastown-witness", "gt-gastown-crew-max", "gt-gastownx-witness", "hq-mayor", "gt-beads-refinery"}
+	got := rigSessionNames(sessions, "gastown")
+	if want := []string{"gt-gastown-witness", "gt-gastown-crew-max"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rigSessionNames = %v, want %v", got, want)
+	}
+}
+
+func TestRoutesOutsideRig(t *testing.T) {
+	routes := []beads.Route{
+		{Prefix: "hq-", Path: "."},
+		{Prefix: "gt-", Path: "gastown/mayor/rig"},
+		{Prefix: "gx-", Path: "gastown"},
+		{Prefix: "gy-", Path: "gastownx"},
+	}
+	got := routesOutsideRig(routes, "gastown")
+	want := []beads.Route{{Prefix: "hq-", Path: "."}, {Prefix: "gy-", Path: "gastownx"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("routesOutsideRig = %v, want %v", got, want)
+	}
+}
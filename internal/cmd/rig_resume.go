@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/wisp"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var rigResumeConcurrency int
+
+var rigResumeCmd = &cobra.Command{
+	Use:   "resume <rig>...",
+	Short: "Recreate polecat sessions lost to a reboot",
+	Long: `Recreate polecat sessions that were running when tmux went away.
+
+Every polecat session start is recorded in <rig>/.runtime/sessions.json
+with its working directory, command, environment, and issue. After a
+machine reboot, this command starts each polecat that was still running,
+the same way it was started before. Sessions stopped on purpose, sessions
+still alive, and polecats that no longer exist are skipped.
+
+Witness and refinery are not resumed here; use 'gt rig start' for those.
+
+Examples:
+  gt rig resume gastown
+  gt rig resume gastown beads --concurrency 2`,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigResume,
+}
+
+func init() {
+	rigResumeCmd.Flags().IntVar(&rigResumeConcurrency, "concurrency", polecat.DefaultStartConcurrency, "Sessions to start at once")
+
+	rigCmd.AddCommand(rigResumeCmd)
+}
+
+func runRigResume(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	t := tmux.NewTmux()
+	var failed int
+	for _, rigName := range args {
+		_, r, err := getRig(rigName)
+		if err != nil {
+			fmt.Printf("%s Rig '%s' not found\n", style.Warning.Render("⚠"), rigName)
+			failed++
+			continue
+		}
+		if status := wisp.NewConfig(townRoot, rigName).GetString("status"); status == "parked" || status == "docked" {
+			fmt.Printf("%s Rig '%s' is %s - skipping\n", style.Warning.Render("⚠"), rigName, status)
+			continue
+		}
+
+		fmt.Printf("Resuming polecat sessions in %s...\n", style.Bold.Render(rigName))
+		results, err := polecat.NewSessionManager(t, r).Resume(polecat.StartAllOptions{
+			Concurrency: rigResumeConcurrency,
+		})
+		if err != nil {
+			fmt.Printf("  %s %v\n", style.Warning.Render("⚠"), err)
+			failed++
+			continue
+		}
+		if len(results) == 0 {
+			fmt.Printf("  %s\n", style.Dim.Render("nothing to resume"))
+			continue
+		}
+		for _, res := range results {
+			if res.Err != nil {
+				fmt.Printf("  %s %s: %v\n", style.Warning.Render("⚠"), res.Polecat, res.Err)
+				failed++
+			} else {
+				fmt.Printf("  %s %s\n", style.Success.Render("✓"), res.Polecat)
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d session(s) or rig(s) could not be resumed", failed)
+	}
+	return nil
+}
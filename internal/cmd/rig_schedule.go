@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/schedule"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var rigScheduleNotify string
+
+var rigScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Limit the hours a rig's polecats run",
+	Long: `Limit the hours a rig's polecat sessions run.
+
+A rig can declare a daily active window in local time, for example
+22:00-06:00 for cheap off-peak runs. The daemon stops the rig's running
+polecat sessions when the window closes and starts the same polecats
+again when it reopens, mailing the rig's witness (or --notify) each time.
+
+The window is stored in <rig>/settings/config.json. It only acts at the
+boundaries: setting a schedule doesn't stop sessions already running, and
+polecats slung while the window is closed still start.`,
+	RunE: requireSubcommand,
+}
+
+var rigScheduleSetCmd = &cobra.Command{
+	Use:   "set <rig> <HH:MM-HH:MM>",
+	Short: "Set a rig's active session window",
+	Long: `Set a rig's daily active session window (local time).
+
+Examples:
+  gt rig schedule set gastown 22:00-06:00
+  gt rig schedule set gastown 09:00-17:30 --notify mayor/`,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigScheduleSet,
+}
+
+var rigScheduleShowCmd = &cobra.Command{
+	Use:   "show [rig]",
+	Short: "Show session windows",
+	Long:  `Show a rig's session window and whether it's open now, or every rig's.`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runRigScheduleShow,
+}
+
+var rigScheduleClearCmd = &cobra.Command{
+	Use:   "clear <rig>",
+	Short: "Let a rig's polecats run around the clock",
+	Long: `Remove a rig's session window. If the window is closed, the polecats it
+stopped are started again on the daemon's next scheduler tick.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runRigScheduleClear,
+}
+
+func init() {
+	rigScheduleSetCmd.Flags().StringVar(&rigScheduleNotify, "notify", "", "Mail address told about each boundary (default: <rig>/witness)")
+
+	rigScheduleCmd.AddCommand(rigScheduleSetCmd)
+	rigScheduleCmd.AddCommand(rigScheduleShowCmd)
+	rigScheduleCmd.AddCommand(rigScheduleClearCmd)
+	rigCmd.AddCommand(rigScheduleCmd)
+}
+
+// loadRigSettingsOrNew loads a rig's settings/config.json, or returns fresh
+// settings if the rig has none yet.
+func loadRigSettingsOrNew(rigPath string) (*config.RigSettings, error) {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+	if err != nil {
+		if os.IsNotExist(err) || strings.Contains(err.Error(), "not found") {
+			return config.NewRigSettings(), nil
+		}
+		return nil, fmt.Errorf("loading settings: %w", err)
+	}
+	return settings, nil
+}
+
+func runRigScheduleSet(cmd *cobra.Command, args []string) error {
+	_, r, err := getRig(args[0])
+	if err != nil {
+		return err
+	}
+	w, err := schedule.ParseWindow(args[1])
+	if err != nil {
+		return err
+	}
+
+	settings, err := loadRigSettingsOrNew(r.Path)
+	if err != nil {
+		return err
+	}
+	settings.Schedule = &config.RigScheduleConfig{Active: w.String(), Notify: rigScheduleNotify}
+	if err := config.SaveRigSettings(config.RigSettingsPath(r.Path), settings); err != nil {
+		return fmt.Errorf("saving settings: %w", err)
+	}
+
+	fmt.Printf("%s %s polecats run %s\n", style.Success.Render("✓"), r.Name, w)
+	fmt.Printf("  %s\n", style.Dim.Render("The daemon acts at the next boundary, "+w.Next(time.Now()).Format("Mon 15:04")))
+	return nil
+}
+
+func runRigScheduleShow(cmd *cobra.Command, args []string) error {
+	var rigs []*rig.Rig
+	if len(args) == 1 {
+		_, r, err := getRig(args[0])
+		if err != nil {
+			return err
+		}
+		rigs = []*rig.Rig{r}
+	} else {
+		all, _, err := getAllRigs()
+		if err != nil {
+			return err
+		}
+		rigs = all
+	}
+
+	now := time.Now()
+	for _, r := range rigs {
+		w, cfg, err := schedule.RigWindow(r.Path)
+		switch {
+		case err != nil:
+			fmt.Printf("%s %s  %s\n", style.Error.Render("✗"), style.Bold.Render(r.Name), err)
+		case w == nil:
+			fmt.Printf("%s %s  %s\n", style.Dim.Render("○"), style.Bold.Render(r.Name), style.Dim.Render("always on"))
+		default:
+			state, icon := "closed", style.Dim.Render("◐")
+			if w.Contains(now) {
+				state, icon = "open", style.Success.Render("●")
+			}
+			notify := cfg.Notify
+			if notify == "" {
+				notify = r.Name + "/witness"
+			}
+			fmt.Printf("%s %s  %s  %s until %s  %s\n", icon, style.Bold.Render(r.Name), w, state,
+				w.Next(now).Format("Mon 15:04"), style.Dim.Render("notify "+notify))
+		}
+	}
+	return nil
+}
+
+func runRigScheduleClear(cmd *cobra.Command, args []string) error {
+	_, r, err := getRig(args[0])
+	if err != nil {
+		return err
+	}
+	settings, err := loadRigSettingsOrNew(r.Path)
+	if err != nil {
+		return err
+	}
+	if settings.Schedule == nil {
+		fmt.Printf("%s has no session window\n", r.Name)
+		return nil
+	}
+	settings.Schedule = nil
+	if err := config.SaveRigSettings(config.RigSettingsPath(r.Path), settings); err != nil {
+		return fmt.Errorf("saving settings: %w", err)
+	}
+	fmt.Printf("%s %s polecats run around the clock\n", style.Success.Render("✓"), r.Name)
+	return nil
+}
@@ -6,9 +6,13 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/logging"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/version"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -27,32 +31,67 @@ across distributed teams of AI agents working on shared codebases.`,
 // Commands that don't require beads to be installed/checked.
 // These are basic utility commands that should work without beads.
 var beadsExemptCommands = map[string]bool{
-	"version":    true,
-	"help":       true,
-	"completion": true,
+	"version":          true,
+	"help":             true,
+	"completion":       true,
+	"context":          true, // identity detection only, used by agents at startup
+	"__complete":       true, // shell completion callbacks must stay fast and quiet
+	"__completeNoDesc": true,
 }
 
 // Commands exempt from the town root branch warning.
 // These are commands that help fix the problem or are diagnostic.
 var branchCheckExemptCommands = map[string]bool{
-	"version":    true,
-	"help":       true,
-	"completion": true,
-	"doctor":     true, // Used to fix the problem
-	"install":    true, // Initial setup
-	"git-init":   true, // Git setup
+	"version":          true,
+	"help":             true,
+	"completion":       true,
+	"__complete":       true,
+	"__completeNoDesc": true,
+	"doctor":           true, // Used to fix the problem
+	"install":          true, // Initial setup
+	"git-init":         true, // Git setup
 }
 
+// townFlag selects a registered town by name (see gt town list).
+var townFlag string
+
+// Output and logging flags (see configureOutput).
+var (
+	quietFlag   bool
+	verboseFlag bool
+	logJSONFlag bool
+)
+
 // persistentPreRun runs before every command.
 func persistentPreRun(cmd *cobra.Command, args []string) error {
 	// Get the root command name being run
 	cmdName := cmd.Name()
 
+	if err := configureOutput(); err != nil {
+		return err
+	}
+
+	// Select the town before anything looks for it. Exporting GT_TOWN
+	// carries the selection into bd, tmux and nested gt invocations.
+	if townFlag != "" {
+		if _, err := workspace.LookupTown(townFlag); err != nil {
+			return err
+		}
+		_ = os.Setenv(workspace.TownEnv, townFlag)
+	}
+
+	configureTmuxSocket()
+
 	// Check town root branch (warning only, non-blocking)
 	if !branchCheckExemptCommands[cmdName] {
 		warnIfTownRootOffMain()
 	}
 
+	// Enforce role-based permissions for guarded operations
+	if err := checkCommandPermission(cmd); err != nil {
+		return err
+	}
+
 	// Skip beads check for exempt commands
 	if beadsExemptCommands[cmdName] {
 		return nil
@@ -62,6 +101,39 @@ func persistentPreRun(cmd *cobra.Command, args []string) error {
 	return CheckBeadsVersion()
 }
 
+// configureOutput applies the global --quiet, --verbose and --log-json
+// flags. Commands that define their own --quiet or --verbose keep their
+// meaning; the global flags only apply where they are not shadowed.
+func configureOutput() error {
+	if quietFlag && verboseFlag {
+		return fmt.Errorf("--quiet and --verbose are mutually exclusive")
+	}
+	logging.Configure(logging.Options{
+		Quiet:   quietFlag,
+		Verbose: verboseFlag,
+		JSON:    logJSONFlag,
+	})
+
+	// --quiet is for scripts: drop normal output, keep errors (stderr) and
+	// the exit code.
+	if quietFlag {
+		devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", os.DevNull, err)
+		}
+		os.Stdout = devNull
+	}
+	return nil
+}
+
+// configureTmuxSocket selects the town's tmux server for this process and
+// everything it starts (see tmux.SocketEnv).
+func configureTmuxSocket() {
+	townRoot, _ := workspace.FindFromCwd()
+	socket := config.ResolveString(config.Scope{TownRoot: townRoot}, "tmux_socket")
+	_ = os.Setenv(tmux.SocketEnv, socket)
+}
+
 // warnIfTownRootOffMain prints a warning if the town root is not on main branch.
 // This is a non-blocking warning to help catch accidental branch switches.
 func warnIfTownRootOffMain() {
@@ -158,15 +230,21 @@ func checkStaleBinaryWarning() {
 // Execute runs the root command and returns an exit code.
 // The caller (main) should call os.Exit with this code.
 func Execute() int {
-	if err := rootCmd.Execute(); err != nil {
-		// Check for silent exit (scripting commands that signal status via exit code)
-		if code, ok := IsSilentExit(err); ok {
-			return code
-		}
-		// Other errors already printed by cobra
-		return 1
+	if code, ok := runExtension(os.Args[1:]); ok {
+		return code
 	}
-	return 0
+
+	registerCompletions(rootCmd)
+
+	started := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	recordInvocation(cmd, os.Args[1:], started, err)
+	if cmd != nil {
+		logCommandResult(cmd, started, err)
+	}
+	// Errors were already printed by cobra (silent exits print nothing);
+	// the exit code classifies them (see gt help exit-codes).
+	return ExitCodeFor(err)
 }
 
 // Command group IDs - used by subcommands to organize help output
@@ -199,8 +277,35 @@ func init() {
 	rootCmd.SetHelpCommandGroupID(GroupDiag)
 	rootCmd.SetCompletionCommandGroupID(GroupConfig)
 
-	// Global flags can be added here
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file")
+	// Global flags
+	rootCmd.PersistentFlags().StringVar(&townFlag, "town", "", "Run against a registered town by name (or set GT_TOWN)")
+	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress normal output; only errors are printed")
+	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "Log git/tmux/bd invocations with timing to stderr")
+	rootCmd.PersistentFlags().BoolVar(&logJSONFlag, "log-json", false, "Write diagnostic logs to stderr as JSON")
+}
+
+// logCommandResult records how the command ended. Failures are logged at
+// error level only with --log-json, since cobra already prints them for
+// humans.
+func logCommandResult(cmd *cobra.Command, started time.Time, err error) {
+	attrs := []any{
+		"command", buildCommandPath(cmd),
+		"duration", time.Since(started).Round(time.Millisecond).String(),
+	}
+	if err == nil {
+		logging.Debug("command finished", attrs...)
+		return
+	}
+	if _, silent := IsSilentExit(err); silent {
+		logging.Debug("command exited", attrs...)
+		return
+	}
+	attrs = append(attrs, "error", err.Error())
+	if logJSONFlag {
+		logging.Error("command failed", attrs...)
+	} else {
+		logging.Debug("command failed", attrs...)
+	}
 }
 
 // buildCommandPath walks the command hierarchy to build the full command path.
@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/daemon"
+	"github.com/steveyegge/gastown/internal/schedule"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var scheduleListJSON bool
+
+var scheduleCmd = &cobra.Command{
+	Use:     "schedule",
+	GroupID: GroupServices,
+	Short:   "Recurring jobs run by the daemon",
+	RunE:    requireSubcommand,
+	Long: `Recurring maintenance jobs, run by the daemon.
+
+Jobs are defined in settings/schedule.json. Each job runs a shell command
+from the town root on a cron schedule (local time) or a fixed interval.
+Every run emits a feed event (schedule_run / schedule_failed) and its
+result is recorded for 'gt schedule status'.
+
+Example settings/schedule.json:
+  {
+    "type": "schedule",
+    "version": 1,
+    "jobs": {
+      "nightly-pristine": {
+        "description": "Sync crew workspaces with remote",
+        "cron": "0 3 * * *",
+        "command": "gt crew pristine --rig gastown"
+      },
+      "fetch-prune": {
+        "every": "30m",
+        "command": "git fetch --prune",
+        "dir": "gastown/mayor/rig"
+      },
+      "polecat-gc": {
+        "cron": "@daily",
+        "command": "gt polecat gc gastown",
+        "timeout": "10m"
+      },
+      "morning-assignments": {
+        "cron": "0 8 * * 1-5",
+        "command": "gt mail send gastown/crew/max -s 'Ready work' -m \"$(bd ready)\""
+      }
+    }
+  }
+
+Cron fields are: minute hour day-of-month month day-of-week, with *, a-b,
+a,b, and */n. Macros: @hourly, @daily, @weekly, @monthly.
+
+Jobs run with GT_TOWN_ROOT and GT_SCHEDULE_JOB set. A new or changed job
+first runs at its next scheduled time; a run missed while the daemon was
+down happens once when it comes back.`,
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scheduled jobs and their next run",
+	RunE:  runScheduleList,
+}
+
+var scheduleStatusCmd = &cobra.Command{
+	Use:   "status [job]",
+	Short: "Show scheduler status and last run results",
+	Long: `Show whether the daemon is running the scheduler, and the last run of
+each job (or one job, with its captured output).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runScheduleStatus,
+}
+
+var scheduleRunCmd = &cobra.Command{
+	Use:   "run <job>",
+	Short: "Run a scheduled job now",
+	Long: `Run a scheduled job immediately, in the foreground.
+
+The run is recorded like a scheduled run and reschedules the job's next run.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runScheduleRun,
+}
+
+func init() {
+	scheduleListCmd.Flags().BoolVar(&scheduleListJSON, "json", false, "Output as JSON")
+
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleStatusCmd)
+	scheduleCmd.AddCommand(scheduleRunCmd)
+	rootCmd.AddCommand(scheduleCmd)
+}
+
+// scheduleEntry is one job as shown by 'gt schedule list'.
+type scheduleEntry struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Schedule    string             `json:"schedule"`
+	Command     string             `json:"command"`
+	Disabled    bool               `json:"disabled,omitempty"`
+	Error       string             `json:"error,omitempty"`
+	NextRun     *time.Time         `json:"next_run,omitempty"`
+	State       *schedule.JobState `json:"state,omitempty"`
+}
+
+// loadScheduleEntries joins the job config with recorded run state.
+func loadScheduleEntries(townRoot string) ([]scheduleEntry, error) {
+	cfg, err := config.LoadOrCreateScheduleConfig(config.ScheduleConfigPath(townRoot))
+	if err != nil {
+		return nil, err
+	}
+	st, err := schedule.LoadState(townRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var entries []scheduleEntry
+	for name, job := range cfg.Jobs {
+		e := scheduleEntry{
+			Name:        name,
+			Description: job.Description,
+			Schedule:    job.Spec(),
+			Command:     job.Command,
+			Disabled:    job.Disabled,
+		}
+		sched, err := schedule.Parse(job)
+		if err != nil {
+			e.Error = err.Error()
+		} else if !job.Disabled {
+			next := sched.Next(now)
+			if js := st.Jobs[name]; js != nil && js.Spec == job.Spec() && !js.NextRun.IsZero() {
+				next = js.NextRun
+			}
+			e.NextRun = &next
+		}
+		e.State = st.Jobs[name]
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// scheduleStatusMark renders a job's last run status.
+func scheduleStatusMark(js *schedule.JobState) string {
+	if js == nil || js.LastStatus == "" {
+		return style.Dim.Render("○")
+	}
+	if js.LastStatus == schedule.StatusOK {
+		return style.Success.Render("✓")
+	}
+	return style.Error.Render("✗")
+}
+
+// formatNextRun renders a next-run time relative to now.
+func formatNextRun(t time.Time) string {
+	d := time.Until(t)
+	if d <= 0 {
+		return "due now"
+	}
+	return fmt.Sprintf("%s (in %s)", t.Format("Mon 15:04"), formatDuration(d))
+}
+
+func runScheduleList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	entries, err := loadScheduleEntries(townRoot)
+	if err != nil {
+		return err
+	}
+
+	if scheduleListJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("%s No scheduled jobs. Define them in %s\n",
+			style.Dim.Render("○"), config.ScheduleConfigPath(townRoot))
+		return nil
+	}
+
+	for _, e := range entries {
+		fmt.Printf("%s %s  %s\n", scheduleStatusMark(e.State), style.Bold.Render(e.Name), style.Dim.Render(e.Schedule))
+		if e.Description != "" {
+			fmt.Printf("    %s\n", e.Description)
+		}
+		fmt.Printf("    $ %s\n", e.Command)
+		switch {
+		case e.Error != "":
+			fmt.Printf("    %s %s\n", style.Error.Render("invalid schedule:"), e.Error)
+		case e.Disabled:
+			fmt.Printf("    %s\n", style.Dim.Render("disabled"))
+		case e.NextRun != nil:
+			fmt.Printf("    next: %s\n", formatNextRun(*e.NextRun))
+		}
+	}
+	return nil
+}
+
+func runScheduleStatus(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	entries, err := loadScheduleEntries(townRoot)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		for _, e := range entries {
+			if e.Name == args[0] {
+				printScheduleJobStatus(e)
+				return nil
+			}
+		}
+		return fmt.Errorf("no scheduled job named %q", args[0])
+	}
+
+	running, pid, _ := daemon.IsRunning(townRoot)
+	if running {
+		fmt.Printf("%s Scheduler running in daemon (PID %d)\n", style.Success.Render("●"), pid)
+	} else {
+		fmt.Printf("%s Daemon not running; scheduled jobs are paused (start with 'gt daemon start')\n", style.Warning.Render("○"))
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	fmt.Println()
+
+	for _, e := range entries {
+		last := style.Dim.Render("never run")
+		if js := e.State; js != nil && !js.LastRun.IsZero() {
+			last = fmt.Sprintf("%s %s, took %s", js.LastStatus, formatAge(js.LastRun), js.Duration)
+			if js.Failures > 0 {
+				last += fmt.Sprintf(" (%d/%d runs failed)", js.Failures, js.Runs)
+			}
+		}
+		fmt.Printf("%s %-24s %s\n", scheduleStatusMark(e.State), e.Name, last)
+	}
+	return nil
+}
+
+func printScheduleJobStatus(e scheduleEntry) {
+	fmt.Printf("%s %s\n", scheduleStatusMark(e.State), style.Bold.Render(e.Name))
+	fmt.Printf("  Schedule: %s\n", e.Schedule)
+	fmt.Printf("  Command:  %s\n", e.Command)
+	switch {
+	case e.Error != "":
+		fmt.Printf("  Next run: %s\n", style.Error.Render(e.Error))
+	case e.Disabled:
+		fmt.Printf("  Next run: %s\n", style.Dim.Render("disabled"))
+	case e.NextRun != nil:
+		fmt.Printf("  Next run: %s\n", formatNextRun(*e.NextRun))
+	}
+
+	js := e.State
+	if js == nil || js.LastRun.IsZero() {
+		fmt.Printf("  Last run: %s\n", style.Dim.Render("never"))
+		return
+	}
+	fmt.Printf("  Last run: %s (%s), %s, took %s\n",
+		js.LastRun.Format("2006-01-02 15:04:05"), formatAge(js.LastRun), js.LastStatus, js.Duration)
+	fmt.Printf("  Runs:     %d (%d failed)\n", js.Runs, js.Failures)
+	if js.LastError != "" {
+		fmt.Printf("  Error:    %s\n", js.LastError)
+	}
+	if js.LastOutput != "" {
+		fmt.Printf("\n  %s\n", style.Dim.Render("Output (tail):"))
+		for _, line := range strings.Split(js.LastOutput, "\n") {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+}
+
+func runScheduleRun(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("%s Running %s...\n", style.Bold.Render("⏱"), args[0])
+	res, err := schedule.NewScheduler(townRoot, nil).RunNow(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if res.Output != "" {
+		fmt.Println(res.Output)
+	}
+	if res.Error != nil {
+		return fmt.Errorf("%s %s after %s: %w", res.Job, res.Status, res.Duration, res.Error)
+	}
+	fmt.Printf("%s %s completed in %s\n", style.Success.Render("✓"), res.Job, res.Duration)
+	return nil
+}
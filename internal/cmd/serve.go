@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/api"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	servePort int
+	serveBind string
+)
+
+var serveCmd = &cobra.Command{
+	Use:     "serve",
+	GroupID: GroupServices,
+	Short:   "Start the local HTTP API server and web dashboard",
+	Long: `Start a localhost HTTP/JSON API for programmatic control of the town,
+with a web dashboard for monitoring it from a browser or phone.
+
+The API exposes rigs, crew, agent sessions, witness status, mail, beads, and
+the live event stream so editors, bots, and remote UIs can drive Gas Town
+without shelling out to the CLI and scraping text.
+
+Every request must carry a bearer token:
+
+  Authorization: Bearer <token>
+
+or, for browsers and EventSource, a ?token=<token> query parameter. Each
+token is issued to one identity, the overseer or an agent address, and
+requests are checked against the town permission policy as that identity
+(see 'gt serve token'). Only token hashes are stored, so a token is shown
+once: on the first run, gt serve issues and prints an overseer token.
+
+The dashboard is served at / and asks for the token once; open the URL
+printed at startup to skip that.
+
+Endpoints (all under /api/v1):
+  GET  /rigs                              List rigs
+  GET  /rigs/{rig}                        Rig details
+  GET  /rigs/{rig}/crew                   Crew workers with session state
+  GET  /rigs/{rig}/polecats               Polecats with session state
+  GET  /rigs/{rig}/polecats/{name}/session  Polecat session info
+  POST /rigs/{rig}/polecats/{name}/inject   Inject a message {"message": "..."}
+  GET  /rigs/{rig}/witness                Witness status
+  GET  /sessions                          Running agent sessions
+  GET  /mail?address=<addr>[&unread=true] List a mailbox (another's needs mail.read)
+  POST /mail                              Send mail {"from","to","subject","body"}
+  GET  /beads[?status=&assignee=&label=&limit=]  Bead summaries (default: open)
+  GET  /beads/{id}                        Show a bead
+  GET  /events[?tail=N&types=a,b]         Event stream (server-sent events)
+
+Examples:
+  gt serve                    # Listen on 127.0.0.1:7777
+  gt serve --port 9000        # Custom port
+  gt serve token issue        # Issue another overseer token`,
+	RunE: runServe,
+}
+
+func init() {
+	serveCmd.Flags().IntVar(&servePort, "port", 7777, "HTTP port to listen on")
+	serveCmd.Flags().StringVar(&serveBind, "bind", "127.0.0.1", "Address to bind (keep on localhost unless fronted by a proxy)")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	// Older versions kept a plaintext token here, readable by every agent
+	_ = os.Remove(filepath.Join(constants.TownRuntimePath(townRoot), "api-token"))
+
+	tokens := api.OpenTokens(townRoot)
+	handler, err := api.NewServer(townRoot, tokens)
+	if err != nil {
+		return fmt.Errorf("creating API server: %w", err)
+	}
+
+	addr := fmt.Sprintf("%s:%d", serveBind, servePort)
+	fmt.Printf("%s Gas Town API listening on http://%s%s\n", style.Bold.Render("⚙"), addr, api.APIPrefix)
+
+	// First run: issue the overseer a token, if it's the overseer asking
+	dashboard := fmt.Sprintf("http://%s/", addr)
+	if existing, err := tokens.List(); err == nil && len(existing) == 0 {
+		if _, role := currentPermissionIdentity(); role == config.RoleOverseer {
+			token, err := tokens.Issue(config.RoleOverseer)
+			if err != nil {
+				return fmt.Errorf("issuing API token: %w", err)
+			}
+			dashboard += "#token=" + token
+			fmt.Printf("   Overseer token (shown once): %s\n", token)
+		}
+	}
+	fmt.Printf("   Dashboard: %s\n", dashboard)
+	fmt.Printf("   Press Ctrl+C to stop\n")
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      60 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+	return server.ListenAndServe()
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/api"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var serveTokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage API tokens",
+	Long: `Manage the tokens that authenticate 'gt serve' API requests.
+
+Each token is issued to one identity: the overseer, or an agent address
+such as gastown/crew/joe. API requests are checked against the town
+permission policy as that identity. Only a hash of each token is stored
+(in <town>/.runtime/api-tokens.json), so a token is printed once, when it
+is issued; issue a new one if it is lost.
+
+Issuing and revoking tokens needs the api.token permission, which by
+default only the overseer has.`,
+	RunE: requireSubcommand,
+}
+
+var serveTokenIssueCmd = &cobra.Command{
+	Use:   "issue [identity]",
+	Short: "Issue an API token",
+	Long: `Issue an API token for an identity (default: overseer) and print it.
+
+Examples:
+  gt serve token issue                   # Token for the overseer
+  gt serve token issue gastown/crew/joe  # Token acting as a crew member`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runServeTokenIssue,
+}
+
+var serveTokenRevokeCmd = &cobra.Command{
+	Use:         "revoke <identity>",
+	Short:       "Revoke an identity's API tokens",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runServeTokenRevoke,
+}
+
+var serveTokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List issued API tokens",
+	Args:  cobra.NoArgs,
+	RunE:  runServeTokenList,
+}
+
+func init() {
+	serveTokenCmd.AddCommand(serveTokenIssueCmd)
+	serveTokenCmd.AddCommand(serveTokenRevokeCmd)
+	serveTokenCmd.AddCommand(serveTokenListCmd)
+	serveCmd.AddCommand(serveTokenCmd)
+}
+
+// townTokens returns the API token store of the current town.
+func townTokens() (*api.Tokens, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return nil, fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	return api.OpenTokens(townRoot), nil
+}
+
+func runServeTokenIssue(cmd *cobra.Command, args []string) error {
+	tokens, err := townTokens()
+	if err != nil {
+		return err
+	}
+	identity := config.RoleOverseer
+	if len(args) == 1 {
+		identity = args[0]
+	}
+	token, err := tokens.Issue(identity)
+	if err != nil {
+		return fmt.Errorf("issuing token: %w", err)
+	}
+	fmt.Println(token)
+	return nil
+}
+
+func runServeTokenRevoke(cmd *cobra.Command, args []string) error {
+	tokens, err := townTokens()
+	if err != nil {
+		return err
+	}
+	n, err := tokens.Revoke(args[0])
+	if err != nil {
+		return fmt.Errorf("revoking tokens: %w", err)
+	}
+	if n == 0 {
+		fmt.Printf("%s No tokens issued to %s\n", style.Dim.Render("○"), args[0])
+		return nil
+	}
+	fmt.Printf("%s Revoked %d token(s) of %s\n", style.SuccessPrefix, n, args[0])
+	return nil
+}
+
+func runServeTokenList(cmd *cobra.Command, args []string) error {
+	tokens, err := townTokens()
+	if err != nil {
+		return err
+	}
+	entries, err := tokens.List()
+	if err != nil {
+		return fmt.Errorf("reading tokens: %w", err)
+	}
+	if len(entries) == 0 {
+		fmt.Printf("%s No API tokens issued\n", style.Dim.Render("○"))
+		return nil
+	}
+	for _, e := range entries {
+		hash := e.Hash
+		if len(hash) > 12 {
+			hash = hash[:12]
+		}
+		fmt.Printf("%-30s %s  %s\n", e.Identity, style.Dim.Render(hash), e.Created.Local().Format("2006-01-02 15:04"))
+	}
+	return nil
+}
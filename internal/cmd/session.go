@@ -30,6 +30,14 @@ var (
 	sessionFile      string
 	sessionRigFilter string
 	sessionListJSON  bool
+
+	sessionResumeLines int
+
+	sessionDrain        bool
+	sessionDrainTimeout time.Duration
+
+	sessionInjectWait     time.Duration
+	sessionInjectPriority bool
 )
 
 var sessionCmd = &cobra.Command{
@@ -58,8 +66,9 @@ and launches claude. Optionally inject an initial issue to work on.
 Examples:
   gt session start wyvern/Toast
   gt session start wyvern/Toast --issue gt-123`,
-	Args: cobra.ExactArgs(1),
-	RunE: runSessionStart,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runSessionStart,
 }
 
 var sessionStopCmd = &cobra.Command{
@@ -68,9 +77,19 @@ var sessionStopCmd = &cobra.Command{
 	Long: `Stop a running polecat session.
 
 Attempts graceful shutdown first (Ctrl-C), then kills the tmux session.
-Use --force to skip graceful shutdown.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runSessionStop,
+Use --force to skip graceful shutdown.
+
+With --drain, the polecat is first asked to wrap up and commit, and the stop
+waits until its worktree is clean (or --drain-timeout passes) so it isn't
+killed mid-edit.
+
+Examples:
+  gt session stop wyvern/Toast
+  gt session stop wyvern/Toast --drain
+  gt session stop wyvern/Toast --drain --drain-timeout 10m`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runSessionStop,
 }
 
 var sessionAtCmd = &cobra.Command{
@@ -114,17 +133,29 @@ var sessionInjectCmd = &cobra.Command{
 	Long: `Send a message to a polecat session.
 
 NOTE: For sending messages to Claude sessions, use 'gt nudge' instead.
-It uses reliable delivery (literal mode + timing) that works correctly
+It uses reliable delivery (verified paste + timing) that works correctly
 with Claude Code's input handling.
 
 This command is a low-level primitive for file-based injection or
 cases where you need raw tmux send-keys behavior.
 
+With --wait, the agent is asked to delimit its answer and the command
+blocks until the reply appears in the pane (or the wait runs out), then
+prints just the reply. This allows synchronous Q&A with a polecat.
+
+Input to the same session is rate limited (a short burst, then one every
+few seconds) and queued in order across every gt process, nudges and mail
+notifications included, so bursts of automated messages don't garble the
+agent's input line. --priority skips the queue.
+
 Examples:
   gt nudge greenplace/furiosa "Check your mail"     # Preferred
-  gt session inject wyvern/Toast -f prompt.txt   # For file injection`,
-	Args: cobra.ExactArgs(1),
-	RunE: runSessionInject,
+  gt session inject wyvern/Toast -f prompt.txt   # For file injection
+  gt session inject wyvern/Toast -m "Are you blocked?" --wait 2m
+  gt session inject wyvern/Toast -m "Stop, wrong branch" --priority`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runSessionInject,
 }
 
 var sessionRestartCmd = &cobra.Command{
@@ -132,10 +163,15 @@ var sessionRestartCmd = &cobra.Command{
 	Short: "Restart a polecat session",
 	Long: `Restart a polecat session (stop + start).
 
-Gracefully stops the current session and starts a fresh one.
+Gracefully stops the current session and starts a fresh one. The last
+lines of the old session's output are saved to .runtime/resume/<name>.md
+and the new session is told to read it, so the polecat picks up where it
+left off instead of starting cold.
+
 Use --force to skip graceful shutdown.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runSessionRestart,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runSessionRestart,
 }
 
 var sessionStatusCmd = &cobra.Command{
@@ -172,6 +208,8 @@ func init() {
 
 	// Stop flags
 	sessionStopCmd.Flags().BoolVarP(&sessionForce, "force", "f", false, "Force immediate shutdown")
+	sessionStopCmd.Flags().BoolVar(&sessionDrain, "drain", false, "Ask the polecat to commit and wait for a clean worktree before stopping")
+	sessionStopCmd.Flags().DurationVar(&sessionDrainTimeout, "drain-timeout", polecat.DefaultDrainTimeout, "How long to wait for a clean worktree with --drain")
 
 	// List flags
 	sessionListCmd.Flags().StringVar(&sessionRigFilter, "rig", "", "Filter by rig name")
@@ -183,9 +221,12 @@ func init() {
 	// Inject flags
 	sessionInjectCmd.Flags().StringVarP(&sessionMessage, "message", "m", "", "Message to inject")
 	sessionInjectCmd.Flags().StringVarP(&sessionFile, "file", "f", "", "File to read message from")
+	sessionInjectCmd.Flags().DurationVar(&sessionInjectWait, "wait", 0, "Wait up to this long for the agent's reply and print it")
+	sessionInjectCmd.Flags().BoolVar(&sessionInjectPriority, "priority", false, "Skip the inject rate limit and queue")
 
 	// Restart flags
 	sessionRestartCmd.Flags().BoolVarP(&sessionForce, "force", "f", false, "Force immediate shutdown")
+	sessionRestartCmd.Flags().IntVarP(&sessionResumeLines, "lines", "n", polecat.DefaultResumeLines, "Lines of output to preserve for the new session")
 
 	// Add subcommands
 	sessionCmd.AddCommand(sessionStartCmd)
@@ -295,12 +336,23 @@ func runSessionStop(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if sessionForce {
+	if sessionDrain && sessionForce {
+		return fmt.Errorf("--drain and --force cannot be used together")
+	}
+
+	switch {
+	case sessionForce:
 		fmt.Printf("Force stopping session for %s/%s...\n", rigName, polecatName)
-	} else {
+	case sessionDrain:
+		fmt.Printf("Draining session for %s/%s (up to %s)...\n", rigName, polecatName, sessionDrainTimeout)
+	default:
 		fmt.Printf("Stopping session for %s/%s...\n", rigName, polecatName)
 	}
-	if err := polecatMgr.Stop(polecatName, sessionForce); err != nil {
+	if err := polecatMgr.StopWithOptions(polecatName, polecat.SessionStopOptions{
+		Force:        sessionForce,
+		Drain:        sessionDrain,
+		DrainTimeout: sessionDrainTimeout,
+	}); err != nil {
 		return fmt.Errorf("stopping session: %w", err)
 	}
 
@@ -480,7 +532,21 @@ func runSessionInject(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := polecatMgr.Inject(polecatName, message); err != nil {
+	if sessionInjectWait > 0 {
+		if sessionInjectPriority {
+			return fmt.Errorf("--priority cannot be combined with --wait")
+		}
+		reply, err := polecatMgr.InjectAndCapture(polecatName, message, sessionInjectWait)
+		if err != nil {
+			return fmt.Errorf("waiting for reply: %w", err)
+		}
+		fmt.Println(reply)
+		return nil
+	}
+
+	if err := polecatMgr.InjectWithOptions(polecatName, message, polecat.InjectOptions{
+		Priority: sessionInjectPriority,
+	}); err != nil {
 		return fmt.Errorf("injecting message: %w", err)
 	}
 
@@ -507,22 +573,25 @@ func runSessionRestart(cmd *cobra.Command, args []string) error {
 	}
 
 	if running {
-		// Stop first
 		if sessionForce {
-			fmt.Printf("Force stopping session for %s/%s...\n", rigName, polecatName)
+			fmt.Printf("Force restarting session for %s/%s...\n", rigName, polecatName)
 		} else {
-			fmt.Printf("Stopping session for %s/%s...\n", rigName, polecatName)
+			fmt.Printf("Restarting session for %s/%s...\n", rigName, polecatName)
 		}
-		if err := polecatMgr.Stop(polecatName, sessionForce); err != nil {
-			return fmt.Errorf("stopping session: %w", err)
+		resumePath, err := polecatMgr.Restart(polecatName, polecat.SessionRestartOptions{
+			Lines: sessionResumeLines,
+			Force: sessionForce,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  Previous output saved to %s\n", style.Dim.Render(resumePath))
+	} else {
+		// Nothing to preserve - start fresh
+		fmt.Printf("Starting session for %s/%s...\n", rigName, polecatName)
+		if err := polecatMgr.Start(polecatName, polecat.SessionStartOptions{}); err != nil {
+			return fmt.Errorf("starting session: %w", err)
 		}
-	}
-
-	// Start fresh session
-	fmt.Printf("Starting session for %s/%s...\n", rigName, polecatName)
-	opts := polecat.SessionStartOptions{}
-	if err := polecatMgr.Start(polecatName, opts); err != nil {
-		return fmt.Errorf("starting session: %w", err)
 	}
 
 	fmt.Printf("%s Session restarted. Attach with: %s\n",
@@ -29,13 +29,15 @@ This adds a hook to your shell RC file that:
   - Offers to add new git repos to Gas Town on first visit
 
 Run this after upgrading gt to get the latest shell hook features.`,
-	RunE: runShellInstall,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runShellInstall,
 }
 
 var shellRemoveCmd = &cobra.Command{
-	Use:   "remove",
-	Short: "Remove shell integration",
-	RunE:  runShellRemove,
+	Use:         "remove",
+	Short:       "Remove shell integration",
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runShellRemove,
 }
 
 var shellStatusCmd = &cobra.Command{
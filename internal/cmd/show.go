@@ -7,6 +7,8 @@ import (
 	"syscall"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 func init() {
@@ -23,10 +25,14 @@ Delegates to 'bd show' - all bd show flags are supported.
 Works with any bead prefix (gt-, bd-, hq-, etc.) and routes
 to the correct beads database automatically.
 
+When two rigs share a prefix, qualify the ID with the rig name
+(<rig>:<bead-id>) to pick the rig's database.
+
 Examples:
   gt show gt-abc123          # Show a gastown issue
   gt show hq-xyz789          # Show a town-level bead (convoy, mail, etc.)
   gt show bd-def456          # Show a beads issue
+  gt show beads:bd-def456    # Show a bead of the beads rig
   gt show gt-abc123 --json   # Output as JSON
   gt show gt-abc123 -v       # Verbose output`,
 	DisableFlagParsing: true, // Pass all flags through to bd show
@@ -38,9 +44,37 @@ func runShow(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("bead ID required\n\nUsage: gt show <bead-id> [flags]")
 	}
 
+	if beads.IsCrossRigRef(args[0]) {
+		return execRoutedBdShow(args[0], args[1:])
+	}
 	return execBdShow(args)
 }
 
+// execRoutedBdShow runs 'bd show' for a rig-qualified bead reference in the
+// database of the rig it names.
+func execRoutedBdShow(ref string, flags []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	router, err := beads.NewRouter(townRoot)
+	if err != nil {
+		return err
+	}
+	resolved, err := router.Resolve(ref)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Chdir(resolved.WorkDir); err != nil {
+		return fmt.Errorf("entering %s: %w", resolved.WorkDir, err)
+	}
+	if err := os.Setenv("BEADS_DIR", beads.ResolveBeadsDir(resolved.WorkDir)); err != nil {
+		return err
+	}
+	return execBdShow(append([]string{resolved.ID}, flags...))
+}
+
 // execBdShow replaces the current process with 'bd show'.
 func execBdShow(args []string) error {
 	bdPath, err := exec.LookPath("bd")
@@ -78,8 +78,9 @@ Batch Slinging:
 
   When multiple beads are provided with a rig target, each bead gets its own
   polecat. This parallelizes work dispatch without running gt sling N times.`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runSling,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runSling,
 }
 
 var (
@@ -225,6 +225,9 @@ func TestSlingFormulaOnBeadRoutesBDCommandsToTargetRig(t *testing.T) {
 	if err := os.MkdirAll(filepath.Join(townRoot, "mayor", "rig"), 0755); err != nil {
 		t.Fatalf("mkdir mayor/rig: %v", err)
 	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"type":"town"}`), 0644); err != nil {
+		t.Fatalf("write mayor/town.json: %v", err)
+	}
 
 	// Create a rig path that owns gt-* beads, and a routes.jsonl pointing to it.
 	rigDir := filepath.Join(townRoot, "gastown", "mayor", "rig")
@@ -415,6 +418,9 @@ func TestSlingFormulaOnBeadPassesFeatureAndIssueVars(t *testing.T) {
 	if err := os.MkdirAll(filepath.Join(townRoot, "mayor", "rig"), 0755); err != nil {
 		t.Fatalf("mkdir mayor/rig: %v", err)
 	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"type":"town"}`), 0644); err != nil {
+		t.Fatalf("write mayor/town.json: %v", err)
+	}
 
 	// Create a rig path that owns gt-* beads, and a routes.jsonl pointing to it.
 	rigDir := filepath.Join(townRoot, "gastown", "mayor", "rig")
@@ -589,6 +595,9 @@ func TestVerifyBeadExistsAllowStale(t *testing.T) {
 	if err := os.MkdirAll(filepath.Join(townRoot, "mayor", "rig"), 0755); err != nil {
 		t.Fatalf("mkdir mayor/rig: %v", err)
 	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"type":"town"}`), 0644); err != nil {
+		t.Fatalf("write mayor/town.json: %v", err)
+	}
 
 	// Create a stub bd that simulates the sync issue:
 	// - --no-daemon without --allow-stale fails (database out of sync)
@@ -668,6 +677,9 @@ func TestSlingWithAllowStale(t *testing.T) {
 	if err := os.MkdirAll(filepath.Join(townRoot, "mayor", "rig"), 0755); err != nil {
 		t.Fatalf("mkdir mayor/rig: %v", err)
 	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"type":"town"}`), 0644); err != nil {
+		t.Fatalf("write mayor/town.json: %v", err)
+	}
 
 	// Create stub bd that respects --allow-stale
 	binDir := filepath.Join(townRoot, "bin")
@@ -848,6 +860,9 @@ func TestSlingFormulaOnBeadSetsAttachedMolecule(t *testing.T) {
 	if err := os.MkdirAll(filepath.Join(townRoot, "mayor", "rig"), 0755); err != nil {
 		t.Fatalf("mkdir mayor/rig: %v", err)
 	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"type":"town"}`), 0644); err != nil {
+		t.Fatalf("write mayor/town.json: %v", err)
+	}
 
 	// Create a rig path that owns gt-* beads, and a routes.jsonl pointing to it.
 	rigDir := filepath.Join(townRoot, "gastown", "mayor", "rig")
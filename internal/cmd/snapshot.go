@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/snapshot"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	snapshotOutput     string
+	snapshotLines      int
+	snapshotRestoreTo  string
+	snapshotForce      bool
+	snapshotSkipMail   bool
+	snapshotTranscript bool
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:     "snapshot <rig>/<worker>",
+	GroupID: GroupWorkspace,
+	Short:   "Capture a worker's full state into a restorable artifact",
+	Long: `Capture a worker's full state into a single snapshot artifact.
+
+The snapshot holds:
+  - commits on the branch not yet pushed (as a git bundle)
+  - uncommitted and untracked work
+  - the worker's mail inbox
+  - the worker's handoff note
+  - recent terminal output from the worker's session
+
+Use it to checkpoint before risky operations, or to move a worker to
+another machine ('gt snapshot restore' on the other side).
+
+Workers are addressed like 'gt peek':
+  - Polecats: rig/name (e.g., gastown/Toast)
+  - Crew: rig/crew/name (e.g., gastown/crew/max)
+
+Snapshots are written to <town>/snapshots/ unless -o is given.
+
+Examples:
+  gt snapshot gastown/crew/max
+  gt snapshot gastown/Toast -o /tmp/toast.tar.gz
+  gt snapshot restore snapshots/gastown-max-20260114-101500.tar.gz
+  gt snapshot restore toast.tar.gz --to gastown/crew/max`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runSnapshot,
+}
+
+var snapshotRestoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "Restore a worker from a snapshot",
+	Long: `Restore a snapshot into a worker's workspace.
+
+The snapshot's branch is checked out at the captured commit, uncommitted and
+untracked work is reapplied, and the handoff note and any missing mail are
+restored. The target defaults to the snapshotted worker; use --to to restore
+into a different worker (e.g., on another machine). The target workspace must
+already exist (create it with 'gt crew add' if needed).
+
+A dirty target is refused unless --force, which stashes its changes first.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runSnapshotRestore,
+}
+
+var snapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List snapshots in the town",
+	RunE:  runSnapshotList,
+}
+
+var snapshotShowCmd = &cobra.Command{
+	Use:   "show <file>",
+	Short: "Show a snapshot's contents",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSnapshotShow,
+}
+
+func init() {
+	snapshotCmd.Flags().StringVarP(&snapshotOutput, "output", "o", "", "Write the snapshot to this path")
+	snapshotCmd.Flags().IntVarP(&snapshotLines, "lines", "n", snapshot.DefaultTranscriptLines, "Lines of session output to capture")
+
+	snapshotRestoreCmd.Flags().StringVar(&snapshotRestoreTo, "to", "", "Restore into this worker instead (rig/name or rig/crew/name)")
+	snapshotRestoreCmd.Flags().BoolVarP(&snapshotForce, "force", "f", false, "Stash the target's uncommitted changes and restore anyway")
+	snapshotRestoreCmd.Flags().BoolVar(&snapshotSkipMail, "skip-mail", false, "Don't restore mail")
+
+	snapshotShowCmd.Flags().BoolVar(&snapshotTranscript, "transcript", false, "Print the captured session output")
+
+	snapshotCmd.AddCommand(snapshotRestoreCmd)
+	snapshotCmd.AddCommand(snapshotListCmd)
+	snapshotCmd.AddCommand(snapshotShowCmd)
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+// resolveSnapshotTarget maps a worker address to its workspace, session,
+// and mail identity.
+func resolveSnapshotTarget(address string) (snapshot.Target, error) {
+	rigName, name, err := parseAddress(address)
+	if err != nil {
+		return snapshot.Target{}, err
+	}
+
+	if crewName, ok := strings.CutPrefix(name, "crew/"); ok {
+		crewMgr, r, err := getCrewManager(rigName)
+		if err != nil {
+			return snapshot.Target{}, err
+		}
+		worker, err := crewMgr.Get(crewName)
+		if err != nil {
+			return snapshot.Target{}, fmt.Errorf("crew worker %s/crew/%s: %w", rigName, crewName, err)
+		}
+		return snapshot.Target{
+			Rig:     r.Name,
+			Kind:    "crew",
+			Name:    crewName,
+			Address: fmt.Sprintf("%s/crew/%s", r.Name, crewName),
+			WorkDir: worker.ClonePath,
+			Session: session.CrewSessionName(r.Name, crewName),
+		}, nil
+	}
+
+	polecatMgr, r, err := getPolecatManager(rigName)
+	if err != nil {
+		return snapshot.Target{}, err
+	}
+	p, err := polecatMgr.Get(name)
+	if err != nil {
+		return snapshot.Target{}, fmt.Errorf("polecat %s/%s: %w (crew workers are addressed as rig/crew/name)", rigName, name, err)
+	}
+	return snapshot.Target{
+		Rig:     r.Name,
+		Kind:    "polecat",
+		Name:    name,
+		Address: fmt.Sprintf("%s/%s", r.Name, name),
+		WorkDir: p.ClonePath,
+		Session: session.PolecatSessionName(r.Name, name),
+	}, nil
+}
+
+func runSnapshot(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	target, err := resolveSnapshotTarget(args[0])
+	if err != nil {
+		return err
+	}
+
+	path, m, err := snapshot.Create(townRoot, target, snapshot.CreateOptions{
+		Output:          snapshotOutput,
+		TranscriptLines: snapshotLines,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Snapshot of %s: %s\n", style.Success.Render("✓"), target.Address, path)
+	printSnapshotManifest(m)
+	return nil
+}
+
+func runSnapshotRestore(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	address := snapshotRestoreTo
+	if address == "" {
+		m, _, err := snapshot.Inspect(args[0])
+		if err != nil {
+			return err
+		}
+		address = m.Address
+	}
+	target, err := resolveSnapshotTarget(address)
+	if err != nil {
+		return err
+	}
+
+	res, err := snapshot.Restore(townRoot, args[0], target, snapshot.RestoreOptions{
+		Force:    snapshotForce,
+		SkipMail: snapshotSkipMail,
+	})
+	if res != nil {
+		for _, w := range res.Warnings {
+			style.PrintWarning("%s", w)
+		}
+		if res.Stashed {
+			fmt.Printf("%s Previous changes stashed (git stash list in %s)\n", style.Dim.Render("○"), target.WorkDir)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	m := res.Manifest
+	ref := m.Head[:min(len(m.Head), 8)]
+	if m.Branch != "" {
+		ref = m.Branch + " @ " + ref
+	}
+	fmt.Printf("%s Restored %s into %s\n", style.Success.Render("✓"), filepath.Base(args[0]), target.Address)
+	fmt.Printf("  Checked out: %s\n", ref)
+	if m.HasPatch || len(m.Untracked) > 0 {
+		fmt.Printf("  Reapplied uncommitted work (%d untracked files)\n", len(m.Untracked))
+	}
+	if res.MailRestored > 0 {
+		fmt.Printf("  Restored %d message(s)\n", res.MailRestored)
+	}
+	if res.HandoffRestored {
+		fmt.Printf("  Restored handoff note\n")
+	}
+	if res.Transcript != "" {
+		fmt.Printf("  %s\n", style.Dim.Render("Session transcript: gt snapshot show "+args[0]+" --transcript"))
+	}
+	return nil
+}
+
+func runSnapshotList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	paths, err := snapshot.List(snapshot.Dir(townRoot))
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		fmt.Printf("%s No snapshots in %s\n", style.Dim.Render("○"), snapshot.Dir(townRoot))
+		return nil
+	}
+	for _, p := range paths {
+		fmt.Println(p)
+	}
+	return nil
+}
+
+func runSnapshotShow(cmd *cobra.Command, args []string) error {
+	m, transcript, err := snapshot.Inspect(args[0])
+	if err != nil {
+		return err
+	}
+
+	if snapshotTranscript {
+		if transcript == "" {
+			return fmt.Errorf("snapshot has no session transcript")
+		}
+		fmt.Print(transcript)
+		return nil
+	}
+
+	fmt.Printf("%s %s\n", style.Bold.Render("Snapshot of"), m.Address)
+	fmt.Printf("  Taken: %s on %s\n", m.CreatedAt.Local().Format("2006-01-02 15:04:05"), m.Host)
+	printSnapshotManifest(m)
+	return nil
+}
+
+func printSnapshotManifest(m *snapshot.Manifest) {
+	branch := m.Branch
+	if branch == "" {
+		branch = "(detached)"
+	}
+	commits := "all pushed"
+	if m.HasBundle {
+		commits = "unpushed commits bundled"
+	}
+	fmt.Printf("  Branch: %s @ %s (%s)\n", branch, m.Head[:min(len(m.Head), 8)], commits)
+	wip := "clean"
+	if m.HasPatch || len(m.Untracked) > 0 {
+		wip = fmt.Sprintf("uncommitted changes: %v, untracked files: %d", m.HasPatch, len(m.Untracked))
+	}
+	fmt.Printf("  Work:   %s\n", wip)
+	fmt.Printf("  Mail:   %d message(s)\n", m.MailCount)
+	if m.HandoffBead != "" {
+		fmt.Printf("  Handoff: %s\n", m.HandoffBead)
+	}
+	if m.HasTranscript {
+		fmt.Printf("  Transcript: captured\n")
+	}
+	for _, w := range m.Warnings {
+		fmt.Printf("  %s %s\n", style.Warning.Render("⚠"), w)
+	}
+}
@@ -63,8 +63,9 @@ Crew shortcut:
   This is equivalent to 'gt start crew rig/name'.
 
 To stop Gas Town, use 'gt shutdown'.`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runStart,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runStart,
 }
 
 var shutdownCmd = &cobra.Command{
@@ -95,7 +96,8 @@ Use --graceful to allow agents time to save state before killing.
 Use --nuclear to force cleanup even if polecats have uncommitted work (DANGER).
 Use --cleanup-orphans to kill orphaned Claude processes (TTY-less, older than 60s).
 Use --cleanup-orphans-grace-secs to set the grace period (default 60s).`,
-	RunE: runShutdown,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runShutdown,
 }
 
 var startCrewCmd = &cobra.Command{
@@ -113,8 +115,9 @@ Examples:
   gt start crew joe                    # Start joe in current rig
   gt start crew greenplace/joe            # Start joe in gastown rig
   gt start crew joe --rig beads        # Start joe in beads rig`,
-	Args: cobra.ExactArgs(1),
-	RunE: runStartCrew,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runStartCrew,
 }
 
 func init() {
@@ -888,7 +891,7 @@ func runStartCrew(cmd *cobra.Command, args []string) error {
 	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
 	r, err := rigMgr.GetRig(rigName)
 	if err != nil {
-		return fmt.Errorf("rig '%s' not found", rigName)
+		return fmt.Errorf("rig '%s': %w", rigName, err)
 	}
 
 	// Create crew manager
@@ -988,7 +991,7 @@ func startCrewMember(rigName, crewName, townRoot string) error {
 	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
 	r, err := rigMgr.GetRig(rigName)
 	if err != nil {
-		return fmt.Errorf("rig '%s' not found", rigName)
+		return fmt.Errorf("rig '%s': %w", rigName, err)
 	}
 
 	// Create crew manager and use Start() method
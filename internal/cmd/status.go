@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -16,11 +17,14 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/daemon"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/witness"
 	"github.com/steveyegge/gastown/internal/workspace"
 	"golang.org/x/term"
 )
@@ -31,6 +35,9 @@ var statusWatch bool
 var statusInterval int
 var statusVerbose bool
 
+// statusRecentEvents is how many activity events gt status shows.
+const statusRecentEvents = 5
+
 var statusCmd = &cobra.Command{
 	Use:     "status",
 	Aliases: []string{"stat"},
@@ -38,16 +45,19 @@ var statusCmd = &cobra.Command{
 	Short:   "Show overall town status",
 	Long: `Display the current status of the Gas Town workspace.
 
-Shows town name, registered rigs, active polecats, and witness status.
+Shows town name, registered rigs, active polecats, and witness status,
+aggregated across every rig: witness loop health, crew workspaces with
+uncommitted changes, unread mail, open molecule instances, and the most
+recent activity events.
 
-Use --fast to skip mail lookups for faster execution.
+Use --fast to skip mail and molecule lookups for faster execution.
 Use --watch to continuously refresh status at regular intervals.`,
 	RunE: runStatus,
 }
 
 func init() {
 	statusCmd.Flags().BoolVar(&statusJSON, "json", false, "Output as JSON")
-	statusCmd.Flags().BoolVar(&statusFast, "fast", false, "Skip mail lookups for faster execution")
+	statusCmd.Flags().BoolVar(&statusFast, "fast", false, "Skip mail and molecule lookups for faster execution")
 	statusCmd.Flags().BoolVarP(&statusWatch, "watch", "w", false, "Watch mode: refresh status continuously")
 	statusCmd.Flags().IntVarP(&statusInterval, "interval", "n", 2, "Refresh interval in seconds")
 	statusCmd.Flags().BoolVarP(&statusVerbose, "verbose", "v", false, "Show detailed multi-line output per agent")
@@ -62,6 +72,8 @@ type TownStatus struct {
 	Agents   []AgentRuntime `json:"agents"`             // Global agents (Mayor, Deacon)
 	Rigs     []RigStatus    `json:"rigs"`
 	Summary  StatusSum      `json:"summary"`
+
+	RecentEvents []events.Event `json:"recent_events,omitempty"` // Latest feed events, oldest first
 }
 
 // OverseerInfo represents the human operator's identity and status.
@@ -100,6 +112,11 @@ type RigStatus struct {
 	Hooks        []AgentHookInfo `json:"hooks,omitempty"`
 	Agents       []AgentRuntime  `json:"agents,omitempty"` // Runtime state of all agents in rig
 	MQ           *MQSummary      `json:"mq,omitempty"`     // Merge queue summary
+
+	WitnessLoop   string   `json:"witness_loop,omitempty"` // alive, stale, or stopped (supervised loops only)
+	DirtyCrew     []string `json:"dirty_crew,omitempty"`   // Crew with uncommitted changes
+	UnreadMail    int      `json:"unread_mail"`            // Unread mail across the rig's agents
+	OpenMolecules int      `json:"open_molecules"`         // Molecule instances with open steps
 }
 
 // MQSummary represents the merge queue status for a rig.
@@ -128,6 +145,9 @@ type StatusSum struct {
 	WitnessCount  int `json:"witness_count"`
 	RefineryCount int `json:"refinery_count"`
 	ActiveHooks   int `json:"active_hooks"`
+	DirtyCrew     int `json:"dirty_crew"`
+	UnreadMail    int `json:"unread_mail"`
+	OpenMolecules int `json:"open_molecules"`
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
@@ -202,8 +222,7 @@ func runStatusOnce(_ *cobra.Command, _ []string) error {
 	}
 
 	// Load rigs config
-	rigsConfigPath := constants.MayorRigsPath(townRoot)
-	rigsConfig, err := config.LoadRigsConfig(rigsConfigPath)
+	rigsConfig, err := daemon.LoadRigsConfig(townRoot)
 	if err != nil {
 		// Empty config if file doesn't exist
 		rigsConfig = &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
@@ -218,7 +237,7 @@ func runStatusOnce(_ *cobra.Command, _ []string) error {
 
 	// Pre-fetch all tmux sessions for O(1) lookup
 	allSessions := make(map[string]bool)
-	if sessions, err := t.ListSessions(); err == nil {
+	if sessions, err := daemon.ListSessions(townRoot, t); err == nil {
 		for _, s := range sessions {
 			allSessions[s] = true
 		}
@@ -356,6 +375,9 @@ func runStatusOnce(_ *cobra.Command, _ []string) error {
 			if workers, err := crewMgr.List(); err == nil {
 				for _, w := range workers {
 					rs.Crews = append(rs.Crews, w.Name)
+					if dirty, err := git.NewGit(w.ClonePath).HasUncommittedChanges(); err == nil && dirty {
+						rs.DirtyCrew = append(rs.DirtyCrew, w.Name)
+					}
 				}
 				rs.CrewCount = len(workers)
 			}
@@ -372,6 +394,17 @@ func runStatusOnce(_ *cobra.Command, _ []string) error {
 
 			// Discover runtime state for all agents in this rig
 			rs.Agents = discoverRigAgents(allSessions, r, rs.Crews, allAgentBeads, allHookBeads, mailRouter, statusFast)
+			for _, agent := range rs.Agents {
+				rs.UnreadMail += agent.UnreadMail
+			}
+
+			rs.WitnessLoop = witnessLoopState(r.Path)
+			if !statusFast {
+				rigBeads := beads.New(filepath.Join(r.Path, "mayor", "rig"))
+				if instances, err := rigBeads.OpenMoleculeInstances(); err == nil {
+					rs.OpenMolecules = len(instances)
+				}
+			}
 
 			// Get MQ summary if rig has a refinery
 			rs.MQ = getMQSummary(r)
@@ -387,6 +420,9 @@ func runStatusOnce(_ *cobra.Command, _ []string) error {
 		status.Summary.PolecatCount += rs.PolecatCount
 		status.Summary.CrewCount += rs.CrewCount
 		status.Summary.ActiveHooks += rigActiveHooks[i]
+		status.Summary.DirtyCrew += len(rs.DirtyCrew)
+		status.Summary.UnreadMail += rs.UnreadMail
+		status.Summary.OpenMolecules += rs.OpenMolecules
 		if rs.HasWitness {
 			status.Summary.WitnessCount++
 		}
@@ -395,6 +431,11 @@ func runStatusOnce(_ *cobra.Command, _ []string) error {
 		}
 	}
 	status.Summary.RigCount = len(rigs)
+	for _, agent := range status.Agents {
+		status.Summary.UnreadMail += agent.UnreadMail
+	}
+
+	status.RecentEvents = recentFeedEvents(townRoot, statusRecentEvents)
 
 	// Output
 	if statusJSON {
@@ -473,6 +514,7 @@ func outputStatusText(status TownStatus) error {
 
 	if len(status.Rigs) == 0 {
 		fmt.Printf("%s\n", style.Dim.Render("No rigs registered. Use 'gt rig add' to add one."))
+		printRecentEvents(status.RecentEvents)
 		return nil
 	}
 
@@ -480,6 +522,9 @@ func outputStatusText(status TownStatus) error {
 	for _, r := range status.Rigs {
 		// Rig header with separator
 		fmt.Printf("─── %s ───────────────────────────────────────────\n\n", style.Bold.Render(r.Name+"/"))
+		if line := rigHealthLine(r); line != "" {
+			fmt.Printf("   %s\n", line)
+		}
 
 		// Group agents by role
 		var witnesses, refineries, crews, polecats []AgentRuntime
@@ -580,9 +625,84 @@ func outputStatusText(status TownStatus) error {
 		fmt.Println()
 	}
 
+	printRecentEvents(status.RecentEvents)
 	return nil
 }
 
+// rigHealthLine summarizes what needs attention in a rig: witness loop
+// health, dirty crew, open molecules and unread mail.
+func rigHealthLine(r RigStatus) string {
+	var parts []string
+	switch r.WitnessLoop {
+	case "stale":
+		parts = append(parts, style.Warning.Render("witness loop stale"))
+	case "stopped":
+		parts = append(parts, style.Dim.Render("witness loop stopped"))
+	}
+	if len(r.DirtyCrew) > 0 {
+		parts = append(parts, fmt.Sprintf("%d dirty crew (%s)", len(r.DirtyCrew), strings.Join(r.DirtyCrew, ", ")))
+	}
+	if r.OpenMolecules > 0 {
+		parts = append(parts, fmt.Sprintf("%d open molecule(s)", r.OpenMolecules))
+	}
+	if r.UnreadMail > 0 {
+		parts = append(parts, fmt.Sprintf("📬 %d unread", r.UnreadMail))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func printRecentEvents(recent []events.Event) {
+	if len(recent) == 0 {
+		return
+	}
+	fmt.Printf("%s\n", style.Bold.Render("Recent events"))
+	for _, e := range recent {
+		fmt.Printf("   %s  %-18s %s\n", style.Dim.Render(formatEventTime(e.Timestamp)), e.Type, e.Actor)
+	}
+	fmt.Println()
+}
+
+// witnessLoopState reports a rig's supervised witness loop as alive, stale
+// or stopped, or "" if the daemon has never supervised one.
+func witnessLoopState(rigPath string) string {
+	st, err := witness.LoadSupervisorState(rigPath)
+	if err != nil || st == nil {
+		return ""
+	}
+	if !st.Running {
+		return "stopped"
+	}
+	if alive, _, err := witness.LoopHealth(rigPath, st, time.Now()); err == nil && !alive {
+		return "stale"
+	}
+	return "alive"
+}
+
+// recentFeedEvents returns the last n feed-visible events of the town's
+// event log, oldest first.
+func recentFeedEvents(townRoot string, n int) []events.Event {
+	file, err := os.Open(filepath.Join(townRoot, events.EventsFile))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var recent []events.Event
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e events.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.Visibility == events.VisibilityAudit {
+			continue
+		}
+		recent = append(recent, e)
+		if len(recent) > n {
+			recent = recent[1:]
+		}
+	}
+	return recent
+}
+
 // renderAgentDetails renders full agent bead details
 func renderAgentDetails(agent AgentRuntime, indent string, hooks []AgentHookInfo, townRoot string) { //nolint:unparam // indent kept for future customization
 	// Line 1: Agent bead ID + status
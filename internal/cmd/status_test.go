@@ -158,3 +158,37 @@ func TestRunStatusWatch_RejectsJSONCombo(t *testing.T) {
 		t.Errorf("error %q should mention 'cannot be used together'", err.Error())
 	}
 }
+
+func TestRecentFeedEvents(t *testing.T) {
+	townRoot := t.TempDir()
+	lines := []string{
+		`{"ts":"2025-12-20T10:00:00Z","type":"sling","actor":"mayor","visibility":"feed"}`,
+		`{"ts":"2025-12-20T10:01:00Z","type":"hook","actor":"gastown/max","visibility":"both"}`,
+		`not json`,
+		`{"ts":"2025-12-20T10:02:00Z","type":"mail","actor":"mayor","visibility":"audit"}`,
+		`{"ts":"2025-12-20T10:03:00Z","type":"done","actor":"gastown/polecats/toast","visibility":"feed"}`,
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, ".events.jsonl"), []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	recent := recentFeedEvents(townRoot, 2)
+	if len(recent) != 2 || recent[0].Type != "hook" || recent[1].Type != "done" {
+		t.Errorf("recentFeedEvents = %+v; want hook, done", recent)
+	}
+	if got := recentFeedEvents(t.TempDir(), 2); got != nil {
+		t.Errorf("no events file: got %+v", got)
+	}
+}
+
+func TestRigHealthLine(t *testing.T) {
+	if got := rigHealthLine(RigStatus{WitnessLoop: "alive"}); got != "" {
+		t.Errorf("healthy rig: got %q", got)
+	}
+	got := rigHealthLine(RigStatus{DirtyCrew: []string{"max", "joe"}, OpenMolecules: 3, UnreadMail: 2})
+	for _, want := range []string{"2 dirty crew (max, joe)", "3 open molecule(s)", "2 unread"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rigHealthLine = %q, missing %q", got, want)
+		}
+	}
+}
@@ -9,7 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
-	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/daemon"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -158,7 +158,7 @@ func runWorkerStatusLine(t *tmux.Tmux, session, rigName, polecat, crew, issue st
 
 func runMayorStatusLine(t *tmux.Tmux) error {
 	// Count active sessions by listing tmux sessions
-	sessions, err := t.ListSessions()
+	sessions, err := daemon.ListSessions("", t)
 	if err != nil {
 		return nil // Silent fail
 	}
@@ -174,8 +174,7 @@ func runMayorStatusLine(t *tmux.Tmux) error {
 	// Load registered rigs to validate against
 	registeredRigs := make(map[string]bool)
 	if townRoot != "" {
-		rigsConfigPath := filepath.Join(townRoot, "mayor", "rigs.json")
-		if rigsConfig, err := config.LoadRigsConfig(rigsConfigPath); err == nil {
+		if rigsConfig, err := daemon.LoadRigsConfig(townRoot); err == nil {
 			for rigName := range rigsConfig.Rigs {
 				registeredRigs[rigName] = true
 			}
@@ -383,7 +382,7 @@ func runMayorStatusLine(t *tmux.Tmux) error {
 // Shows: active rigs, polecat count, hook or mail preview
 func runDeaconStatusLine(t *tmux.Tmux) error {
 	// Count active rigs and polecats
-	sessions, err := t.ListSessions()
+	sessions, err := daemon.ListSessions("", t)
 	if err != nil {
 		return nil // Silent fail
 	}
@@ -399,8 +398,7 @@ func runDeaconStatusLine(t *tmux.Tmux) error {
 	// Load registered rigs to validate against
 	registeredRigs := make(map[string]bool)
 	if townRoot != "" {
-		rigsConfigPath := filepath.Join(townRoot, "mayor", "rigs.json")
-		if rigsConfig, err := config.LoadRigsConfig(rigsConfigPath); err == nil {
+		if rigsConfig, err := daemon.LoadRigsConfig(townRoot); err == nil {
 			for rigName := range rigsConfig.Rigs {
 				registeredRigs[rigName] = true
 			}
@@ -468,7 +466,7 @@ func runWitnessStatusLine(t *tmux.Tmux, rigName string) error {
 	}
 
 	// Count crew in this rig (crew are persistent, worth tracking)
-	sessions, err := t.ListSessions()
+	sessions, err := daemon.ListSessions("", t)
 	if err != nil {
 		return nil // Silent fail
 	}
@@ -708,10 +706,10 @@ func getHookedWork(identity string, maxLen int, beadsDir string) string {
 		}
 	}
 
-	b := beads.New(beadsDir)
-
-	// Query for hooked beads assigned to this agent
-	hookedBeads, err := b.List(beads.ListOptions{
+	// Query for hooked beads assigned to this agent. The daemon answers
+	// from its cache when running; status lines refresh every few seconds
+	// in every session.
+	hookedBeads, err := daemon.ListBeads(beadsDir, beads.ListOptions{
 		Status:   beads.StatusHooked,
 		Assignee: identity,
 		Priority: -1,
@@ -745,8 +743,7 @@ func getCurrentWork(t *tmux.Tmux, session string, maxLen int) string {
 	}
 
 	// Query beads for in_progress issues
-	b := beads.New(workDir)
-	issues, err := b.List(beads.ListOptions{
+	issues, err := daemon.ListBeads(workDir, beads.ListOptions{
 		Status:   "in_progress",
 		Priority: -1,
 	})
@@ -69,8 +69,9 @@ a beads epic. All workers branch from the same base commit.
 Examples:
   gt swarm create greenplace --epic gp-abc --worker Toast --worker Nux
   gt swarm create greenplace --epic gp-abc --worker Toast --start`,
-	Args: cobra.ExactArgs(1),
-	RunE: runSwarmCreate,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runSwarmCreate,
 }
 
 var swarmStatusCmd = &cobra.Command{
@@ -105,8 +106,9 @@ var swarmLandCmd = &cobra.Command{
 
 Merges the integration branch to the target branch (usually main).
 Normally this is done automatically by the Refinery.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runSwarmLand,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runSwarmLand,
 }
 
 var swarmCancelCmd = &cobra.Command{
@@ -115,8 +117,9 @@ var swarmCancelCmd = &cobra.Command{
 	Long: `Cancel an active swarm.
 
 Marks the swarm as canceled and optionally cleans up branches.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runSwarmCancel,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runSwarmCancel,
 }
 
 var swarmStartCmd = &cobra.Command{
@@ -125,8 +128,9 @@ var swarmStartCmd = &cobra.Command{
 	Long: `Start a swarm that was created without --start.
 
 Transitions the swarm from 'created' to 'active' state.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runSwarmStart,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runSwarmStart,
 }
 
 var swarmDispatchCmd = &cobra.Command{
@@ -141,8 +145,9 @@ fresh - there are no idle polecats to reuse.
 Examples:
   gt swarm dispatch gt-abc         # Dispatch next task from epic gt-abc
   gt swarm dispatch gt-abc --rig greenplace  # Dispatch in specific rig`,
-	Args: cobra.ExactArgs(1),
-	RunE: runSwarmDispatch,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runSwarmDispatch,
 }
 
 var swarmDispatchRig string
@@ -194,7 +199,7 @@ func getSwarmRig(rigName string) (*rig.Rig, string, error) {
 	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
 	r, err := rigMgr.GetRig(rigName)
 	if err != nil {
-		return nil, "", fmt.Errorf("rig '%s' not found", rigName)
+		return nil, "", fmt.Errorf("rig '%s': %w", rigName, err)
 	}
 
 	return r, townRoot, nil
@@ -604,7 +609,7 @@ func runSwarmList(cmd *cobra.Command, args []string) error {
 			}
 		}
 		if len(filtered) == 0 {
-			return fmt.Errorf("rig '%s' not found", rigName)
+			return fmt.Errorf("rig '%s': %w", rigName, rig.ErrRigNotFound)
 		}
 		rigs = filtered
 	}
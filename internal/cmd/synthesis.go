@@ -62,8 +62,9 @@ Options:
   --review-id=ID  Override review ID for output paths
   --force         Start synthesis even if some legs incomplete
   --dry-run       Show what would happen without executing`,
-	Args: cobra.ExactArgs(1),
-	RunE: runSynthesisStart,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runSynthesisStart,
 }
 
 var synthesisStatusCmd = &cobra.Command{
@@ -86,8 +87,9 @@ var synthesisCloseCmd = &cobra.Command{
 	Long: `Close a convoy after synthesis is complete.
 
 This marks the convoy as complete and triggers any configured notifications.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runSynthesisClose,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runSynthesisClose,
 }
 
 func init() {
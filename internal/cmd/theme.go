@@ -33,7 +33,8 @@ Examples:
   gt theme --list       # List available themes
   gt theme forest       # Set theme to 'forest'
   gt theme apply        # Apply theme to all running sessions in this rig`,
-	RunE: runTheme,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runTheme,
 }
 
 var themeApplyCmd = &cobra.Command{
@@ -43,7 +44,8 @@ var themeApplyCmd = &cobra.Command{
 
 By default, only applies to sessions in the current rig.
 Use --all to apply to sessions across all rigs.`,
-	RunE:  runThemeApply,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runThemeApply,
 }
 
 func init() {
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var townListJSON bool
+
+var townListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the towns registered on this machine",
+	Long: `List the towns registered in ~/.config/gastown/towns.json.
+
+Any command can run against a registered town from anywhere with
+--town <name>, or by setting GT_TOWN=<name>. The current town (found
+from the working directory) is marked with *.
+
+Examples:
+  gt town list
+  gt town list --json`,
+	RunE: runTownList,
+}
+
+var townAddCmd = &cobra.Command{
+	Use:   "add <name> [path]",
+	Short: "Register a town under a name",
+	Long: `Register a town root (default: the current town) under a name.
+
+gt install registers new towns automatically; use this for towns created
+elsewhere or to give a town a second name.
+
+Examples:
+  gt town add acme ~/clients/acme/gt
+  gt town add home`,
+	Args:        cobra.RangeArgs(1, 2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runTownAdd,
+}
+
+var townRemoveCmd = &cobra.Command{
+	Use:         "remove <name>",
+	Short:       "Unregister a town (its files are not touched)",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runTownRemove,
+}
+
+func init() {
+	townListCmd.Flags().BoolVar(&townListJSON, "json", false, "Output as JSON")
+
+	townCmd.AddCommand(townListCmd)
+	townCmd.AddCommand(townAddCmd)
+	townCmd.AddCommand(townRemoveCmd)
+}
+
+func runTownList(cmd *cobra.Command, args []string) error {
+	reg, err := workspace.LoadTownsRegistry()
+	if err != nil {
+		return err
+	}
+
+	if townListJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(reg.Towns)
+	}
+
+	if len(reg.Towns) == 0 {
+		fmt.Println("No towns registered. Register one with: gt town add <name> [path]")
+		return nil
+	}
+
+	current, _ := workspace.FindFromCwd()
+	for _, name := range reg.Names() {
+		entry := reg.Towns[name]
+		marker := " "
+		if entry.Path == current {
+			marker = style.Success.Render("*")
+		}
+		line := fmt.Sprintf("%s %-16s %s", marker, name, style.Dim.Render(entry.Path))
+		if is, _ := workspace.IsWorkspace(entry.Path); !is {
+			line += " " + style.Warning.Render("(missing)")
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func runTownAdd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	var townRoot string
+	if len(args) == 2 {
+		path, err := expandHome(args[1])
+		if err != nil {
+			return err
+		}
+		townRoot = path
+	} else {
+		root, err := workspace.FindFromCwdOrError()
+		if err != nil {
+			return fmt.Errorf("not in a Gas Town workspace (pass the town path): %w", err)
+		}
+		townRoot = root
+	}
+
+	if err := workspace.RegisterTown(name, townRoot); err != nil {
+		return err
+	}
+	abs, _ := filepath.Abs(townRoot)
+	fmt.Printf("%s Registered town %s at %s\n", style.Success.Render("✓"), style.Bold.Render(name), abs)
+	return nil
+}
+
+func runTownRemove(cmd *cobra.Command, args []string) error {
+	if err := workspace.UnregisterTown(args[0]); err != nil {
+		return err
+	}
+	fmt.Printf("%s Unregistered town %s\n", style.Success.Render("✓"), args[0])
+	return nil
+}
+
+// expandHome expands a leading ~ in a path.
+func expandHome(path string) (string, error) {
+	if path == "" || path[0] != '~' {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("getting home directory: %w", err)
+	}
+	return filepath.Join(home, path[1:]), nil
+}
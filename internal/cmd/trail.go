@@ -11,7 +11,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/style"
-	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 var (
@@ -123,12 +122,8 @@ type CommitEntry struct {
 func runTrailCommits(cmd *cobra.Command, args []string) error {
 	// Get email domain for agent filtering
 	domain := DefaultAgentEmailDomain
-	townRoot, err := workspace.FindFromCwd()
-	if err == nil && townRoot != "" {
-		settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
-		if err == nil && settings.AgentEmailDomain != "" {
-			domain = settings.AgentEmailDomain
-		}
+	if scope, err := cwdConfigScope(); err == nil {
+		domain = config.ResolveString(scope, "agent_email_domain")
 	}
 
 	// Build git log command
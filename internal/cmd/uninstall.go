@@ -43,7 +43,8 @@ Examples:
   gt uninstall                    # Remove Gas Town, keep workspace
   gt uninstall --workspace        # Also remove workspace directory
   gt uninstall --force            # Skip confirmation`,
-	RunE: runUninstall,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runUninstall,
 }
 
 func init() {
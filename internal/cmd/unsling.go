@@ -35,8 +35,9 @@ Related commands:
   gt sling <bead>    # Hook + start (inverse of unsling)
   gt hook <bead>     # Hook without starting
   gt hook      # See what's on your hook`,
-	Args: cobra.MaximumNArgs(2),
-	RunE: runUnsling,
+	Args:        cobra.MaximumNArgs(2),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runUnsling,
 }
 
 var (
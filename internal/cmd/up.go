@@ -59,19 +59,25 @@ Use --restore to also start:
   • Crew       - Per rig settings (settings/config.json crew.startup)
   • Polecats   - Those with pinned beads (work attached)
 
+Restored polecats start a few at a time (--concurrency) so a large rig
+doesn't hit the API all at once.
+
 Running 'gt up' multiple times is safe - it only starts services that
 aren't already running.`,
-	RunE: runUp,
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runUp,
 }
 
 var (
-	upQuiet   bool
-	upRestore bool
+	upQuiet       bool
+	upRestore     bool
+	upConcurrency int
 )
 
 func init() {
 	upCmd.Flags().BoolVarP(&upQuiet, "quiet", "q", false, "Only show errors")
 	upCmd.Flags().BoolVar(&upRestore, "restore", false, "Also restore crew (from settings) and polecats (from hooks)")
+	upCmd.Flags().IntVar(&upConcurrency, "concurrency", polecat.DefaultStartConcurrency, "Polecat sessions to start at once with --restore")
 	rootCmd.AddCommand(upCmd)
 }
 
@@ -675,6 +681,7 @@ func startPolecatsWithWork(townRoot, rigName string) ([]string, map[string]error
 	t := tmux.NewTmux()
 	polecatMgr := polecat.NewSessionManager(t, r)
 
+	var withWork []string
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			continue
@@ -699,15 +706,17 @@ func startPolecatsWithWork(townRoot, rigName string) ([]string, map[string]error
 			continue
 		}
 
-		// This polecat has work - start it using SessionManager
-		if err := polecatMgr.Start(polecatName, polecat.SessionStartOptions{}); err != nil {
-			if err == polecat.ErrSessionRunning {
-				started = append(started, polecatName)
-			} else {
-				errors[polecatName] = err
-			}
+		withWork = append(withWork, polecatName)
+	}
+
+	// Start them in parallel, staggered to avoid a rate-limit stampede
+	for _, res := range polecatMgr.StartAll(withWork, polecat.StartAllOptions{
+		Concurrency: upConcurrency,
+	}) {
+		if res.Err != nil && res.Err != polecat.ErrSessionRunning {
+			errors[res.Polecat] = res.Err
 		} else {
-			started = append(started, polecatName)
+			started = append(started, res.Polecat)
 		}
 	}
 
@@ -40,6 +40,9 @@ failures and edge cases only.
 
 One Witness per rig. The Deacon monitors all Witnesses.
 
+'gt witness run' runs a deterministic patrol loop instead of an agent,
+for rigs that don't need the Witness's judgment.
+
 Role shortcuts: "witness" in mail/nudge addresses resolves to this rig's Witness.`,
 }
 
@@ -61,8 +64,9 @@ Examples:
   gt witness start greenplace --agent codex
   gt witness start greenplace --env ANTHROPIC_MODEL=claude-3-haiku
   gt witness start greenplace --foreground`,
-	Args: cobra.ExactArgs(1),
-	RunE: runWitnessStart,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runWitnessStart,
 }
 
 var witnessStopCmd = &cobra.Command{
@@ -71,8 +75,9 @@ var witnessStopCmd = &cobra.Command{
 	Long: `Stop a running Witness.
 
 Gracefully stops the witness monitoring agent.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runWitnessStop,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runWitnessStop,
 }
 
 var witnessStatusCmd = &cobra.Command{
@@ -100,8 +105,9 @@ If rig is not specified, infers it from the current directory.
 Examples:
   gt witness attach greenplace
   gt witness attach          # infer rig from cwd`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runWitnessAttach,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runWitnessAttach,
 }
 
 var witnessRestartCmd = &cobra.Command{
@@ -115,8 +121,9 @@ Examples:
   gt witness restart greenplace
   gt witness restart greenplace --agent codex
   gt witness restart greenplace --env ANTHROPIC_MODEL=claude-3-haiku`,
-	Args: cobra.ExactArgs(1),
-	RunE: runWitnessRestart,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runWitnessRestart,
 }
 
 func init() {
@@ -324,16 +331,11 @@ func runWitnessAttach(cmd *cobra.Command, args []string) error {
 	}
 
 	// Attach to the session
-	tmuxPath, err := exec.LookPath("tmux")
-	if err != nil {
+	if _, err := exec.LookPath("tmux"); err != nil {
 		return fmt.Errorf("tmux not found: %w", err)
 	}
 
-	attachCmd := exec.Command(tmuxPath, "attach-session", "-t", sessionName)
-	attachCmd.Stdin = os.Stdin
-	attachCmd.Stdout = os.Stdout
-	attachCmd.Stderr = os.Stderr
-	return attachCmd.Run()
+	return tmux.AttachCommand(sessionName).Run()
 }
 
 func runWitnessRestart(cmd *cobra.Command, args []string) error {
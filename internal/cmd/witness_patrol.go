@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+// Witness patrol flags
+var (
+	witnessPatrolDryRun      bool
+	witnessPatrolWedgedAfter time.Duration
+	witnessPatrolNoGC        bool
+	witnessPatrolNoRecycle   bool
+	witnessPatrolMaxAge      time.Duration
+	witnessPatrolJSON        bool
+)
+
+var witnessPatrolCmd = &cobra.Command{
+	Use:   "patrol <rig>",
+	Short: "Run a single witness patrol pass",
+	Long: `Run one witness patrol pass for a rig and print its findings.
+
+This is the same pass 'gt witness run' repeats every interval. With
+--dry-run, nothing is changed: wedged agents aren't nudged, exhausted
+sessions aren't recycled, finished polecats aren't nuked, lost sessions
+aren't recorded, and nothing is written to the events feed or the
+witness history. The findings then describe what the patrol would do.
+
+Examples:
+  gt witness patrol greenplace
+  gt witness patrol greenplace --dry-run
+  gt witness patrol greenplace --dry-run --json`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runWitnessPatrol,
+}
+
+func init() {
+	witnessPatrolCmd.Flags().BoolVar(&witnessPatrolDryRun, "dry-run", false, "Show what the patrol would do without doing it")
+	witnessPatrolCmd.Flags().DurationVar(&witnessPatrolWedgedAfter, "wedged-after", polecat.DefaultWedgedAfter,
+		"Silence after which a live agent is nudged")
+	witnessPatrolCmd.Flags().BoolVar(&witnessPatrolNoGC, "no-gc", false, "Don't nuke finished polecats")
+	witnessPatrolCmd.Flags().BoolVar(&witnessPatrolNoRecycle, "no-recycle", false, "Don't recycle sessions whose context looks exhausted")
+	witnessPatrolCmd.Flags().DurationVar(&witnessPatrolMaxAge, "max-age", 0, "Recycle sessions running longer than this (0 = no limit)")
+	witnessPatrolCmd.Flags().BoolVar(&witnessPatrolJSON, "json", false, "Output the patrol report as JSON")
+
+	witnessCmd.AddCommand(witnessPatrolCmd)
+}
+
+func runWitnessPatrol(cmd *cobra.Command, args []string) error {
+	mgr, err := getWitnessManager(args[0])
+	if err != nil {
+		return err
+	}
+	patroller := mgr.NewPatroller(witness.PatrolOptions{
+		WedgedAfter:   witnessPatrolWedgedAfter,
+		NoGC:          witnessPatrolNoGC,
+		NoRecycle:     witnessPatrolNoRecycle,
+		MaxSessionAge: witnessPatrolMaxAge,
+		DryRun:        witnessPatrolDryRun,
+	})
+	printPatrolReport(patroller.Once(), witnessPatrolJSON)
+	return nil
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+// Witness report flags
+var (
+	witnessReportSince time.Duration
+	witnessReportJSON  bool
+)
+
+var witnessReportCmd = &cobra.Command{
+	Use:   "report <rig>",
+	Short: "Summarize what witness patrols observed and did",
+	Long: `Summarize the witness patrol history for a rig.
+
+Every 'gt witness run' pass records the health of each polecat session and
+the actions it took in <rig>/.runtime/witness-history.jsonl. This command
+totals them per polecat: how often each was running, wedged, or crashed,
+and how many times it was nudged, found exited, or nuked.
+
+Examples:
+  gt witness report greenplace
+  gt witness report greenplace --since 1h
+  gt witness report greenplace --since 0 --json   # whole history`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWitnessReport,
+}
+
+func init() {
+	witnessReportCmd.Flags().DurationVar(&witnessReportSince, "since", 24*time.Hour, "Only include patrols this recent (0 for all)")
+	witnessReportCmd.Flags().BoolVar(&witnessReportJSON, "json", false, "Output as JSON")
+
+	witnessCmd.AddCommand(witnessReportCmd)
+}
+
+func runWitnessReport(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+	_, r, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+
+	var since time.Time
+	if witnessReportSince > 0 {
+		since = time.Now().Add(-witnessReportSince)
+	}
+	reports, err := witness.LoadHistory(r.Path, since)
+	if err != nil {
+		return err
+	}
+	sum := witness.Summarize(rigName, reports)
+
+	if witnessReportJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(sum)
+	}
+
+	if sum.Passes == 0 {
+		fmt.Printf("No witness patrols recorded for %s", rigName)
+		if witnessReportSince > 0 {
+			fmt.Printf(" in the last %s", witnessReportSince)
+		}
+		fmt.Printf(".\n  %s\n", style.Dim.Render("Patrols are recorded by 'gt witness run'"))
+		return nil
+	}
+
+	fmt.Printf("%s  %d patrol(s), %s - %s\n", style.Bold.Render(rigName), sum.Passes,
+		sum.First.Format("2006-01-02 15:04"), sum.Last.Format("2006-01-02 15:04"))
+	if len(sum.Actions) > 0 {
+		fmt.Printf("  %s\n", style.Dim.Render(formatCounts(sum.Actions)))
+	}
+	fmt.Println()
+
+	for _, ps := range sum.Polecats {
+		icon := style.Success.Render("●")
+		switch ps.LastHealth {
+		case polecat.HealthWedged:
+			icon = style.Warning.Render("◐")
+		case polecat.HealthCrashed:
+			icon = style.Error.Render("✗")
+		case "":
+			icon = style.Dim.Render("○") // only seen through findings
+		}
+		health := make(map[string]int, len(ps.Health))
+		for h, n := range ps.Health {
+			health[string(h)] = n
+		}
+		line := fmt.Sprintf("%s %s", icon, style.Bold.Render(ps.Polecat))
+		if ps.Checks > 0 {
+			line += fmt.Sprintf("  %d check(s): %s", ps.Checks, formatCounts(health))
+		}
+		if len(ps.Actions) > 0 {
+			line += "  " + style.Warning.Render(formatCounts(ps.Actions))
+		}
+		fmt.Println(line)
+	}
+
+	if len(sum.Errors) > 0 {
+		fmt.Printf("\n%s\n", style.Bold.Render("Recent errors:"))
+		for _, e := range sum.Errors {
+			fmt.Printf("  %s %s\n", style.Error.Render("✗"), e)
+		}
+	}
+	return nil
+}
+
+// formatCounts renders counts as "a 2, b 1", sorted by key.
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s %d", k, counts[k])
+	}
+	return strings.Join(parts, ", ")
+}
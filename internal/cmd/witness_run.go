@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+// Witness run flags
+var (
+	witnessRunInterval    time.Duration
+	witnessRunWedgedAfter time.Duration
+	witnessRunNoGC        bool
+	witnessRunNoRecycle   bool
+	witnessRunMaxAge      time.Duration
+	witnessRunOnce        bool
+	witnessRunJSON        bool
+)
+
+var witnessRunCmd = &cobra.Command{
+	Use:   "run <rig>",
+	Short: "Run the witness patrol loop in the foreground",
+	Long: `Run a deterministic witness patrol loop for a rig, without an agent.
+
+Every interval the patrol:
+  - records polecat sessions that vanished without being stopped
+  - checks each running session's health (see 'gt polecat health')
+  - nudges wedged agents (at most once per --wedged-after)
+  - recycles sessions whose output shows the agent's context is running
+    out ("compacting", "context low"), or that are older than --max-age:
+    the end of the session's output is saved to a resume file and a fresh
+    agent is started and pointed at it (unless --no-recycle)
+  - reports sessions whose agent process crashed
+  - nukes finished polecats whose work is safely pushed (unless --no-gc)
+
+Each finding is printed and appended to the events feed. Runs until
+interrupted. To have the daemon run and supervise it for every rig,
+restarting it if it dies, enable the patrol in mayor/daemon.json:
+
+  "patrols": { "witness_loop": { "enabled": true, "interval": "60s" } }
+
+See 'gt witness uptime' for the supervised loop's restarts and crashes.
+
+Examples:
+  gt witness run greenplace
+  gt witness run greenplace --interval 30s --wedged-after 15m
+  gt witness run greenplace --max-age 4h
+  gt witness run greenplace --once --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWitnessRun,
+}
+
+func init() {
+	witnessRunCmd.Flags().DurationVar(&witnessRunInterval, "interval", witness.DefaultPatrolInterval, "Time between patrols")
+	witnessRunCmd.Flags().DurationVar(&witnessRunWedgedAfter, "wedged-after", polecat.DefaultWedgedAfter,
+		"Silence after which a live agent is nudged")
+	witnessRunCmd.Flags().BoolVar(&witnessRunNoGC, "no-gc", false, "Don't nuke finished polecats")
+	witnessRunCmd.Flags().BoolVar(&witnessRunNoRecycle, "no-recycle", false, "Don't recycle sessions whose context looks exhausted")
+	witnessRunCmd.Flags().DurationVar(&witnessRunMaxAge, "max-age", 0, "Recycle sessions running longer than this (0 = no limit)")
+	witnessRunCmd.Flags().BoolVar(&witnessRunOnce, "once", false, "Patrol once and exit")
+	witnessRunCmd.Flags().BoolVar(&witnessRunJSON, "json", false, "Print each patrol report as JSON")
+
+	witnessCmd.AddCommand(witnessRunCmd)
+}
+
+func runWitnessRun(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+
+	mgr, err := getWitnessManager(rigName)
+	if err != nil {
+		return err
+	}
+	patroller := mgr.NewPatroller(witness.PatrolOptions{
+		Interval:      witnessRunInterval,
+		WedgedAfter:   witnessRunWedgedAfter,
+		NoGC:          witnessRunNoGC,
+		NoRecycle:     witnessRunNoRecycle,
+		MaxSessionAge: witnessRunMaxAge,
+	})
+
+	if witnessRunOnce {
+		printPatrolReport(patroller.Once(), witnessRunJSON)
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !witnessRunJSON {
+		fmt.Printf("Patrolling %s every %s (Ctrl-C to stop)\n", style.Bold.Render(rigName), witnessRunInterval)
+	}
+	onReport := func(report *witness.PatrolReport) { printPatrolReport(report, witnessRunJSON) }
+	if err := patroller.Run(ctx, onReport); err != nil && err != context.Canceled {
+		return err
+	}
+	return nil
+}
+
+// printPatrolReport prints one patrol pass's findings. Findings of a dry run
+// are worded as the actions the patrol would have taken.
+func printPatrolReport(report *witness.PatrolReport, asJSON bool) {
+	if asJSON {
+		_ = json.NewEncoder(os.Stdout).Encode(report)
+		return
+	}
+
+	stamp := style.Dim.Render(report.At.Format("15:04:05"))
+	if len(report.Findings) == 0 {
+		fmt.Printf("%s %s %d session(s) healthy\n", stamp, style.Success.Render("✓"), report.Checked)
+		return
+	}
+	for _, f := range report.Findings {
+		var icon string
+		switch f.Kind {
+		case witness.FindingNudged, witness.FindingRecycled, witness.FindingSkipped, witness.FindingSLA:
+			icon = style.Warning.Render("◐")
+		case witness.FindingNuked, witness.FindingExited:
+			icon = style.Dim.Render("○")
+		default:
+			icon = style.Error.Render("✗")
+		}
+		target := report.Rig
+		if f.Polecat != "" {
+			target += "/" + f.Polecat
+		}
+		kind := f.Kind
+		if report.DryRun {
+			switch f.Kind {
+			case witness.FindingNudged:
+				kind = "would nudge"
+			case witness.FindingRecycled:
+				kind = "would recycle"
+			case witness.FindingNuked:
+				kind = "would nuke"
+			case witness.FindingSLA:
+				kind = "would escalate sla"
+			}
+		}
+		fmt.Printf("%s %s %s  %s  %s\n", stamp, icon, target, style.Bold.Render(kind), style.Dim.Render(f.Detail))
+	}
+}
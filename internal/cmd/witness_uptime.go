@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+var witnessUptimeJSON bool
+
+var witnessUptimeCmd = &cobra.Command{
+	Use:   "uptime <rig>",
+	Short: "Show how long the witness has been up and how often it crashed",
+	Long: `Show the uptime of a rig's witness.
+
+For the agent session, shows when its tmux session was created. For the
+patrol loop supervised by the daemon ('witness_loop' in mayor/daemon.json),
+shows when it started, how many times it was restarted, and its recent
+crashes. The loop counts as up only while it keeps completing patrols, so
+a loop killed without a trace (e.g., by the OOM killer) shows as stale.
+
+Examples:
+  gt witness uptime greenplace
+  gt witness uptime greenplace --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWitnessUptime,
+}
+
+func init() {
+	witnessUptimeCmd.Flags().BoolVar(&witnessUptimeJSON, "json", false, "Output as JSON")
+
+	witnessCmd.AddCommand(witnessUptimeCmd)
+}
+
+// WitnessUptime is the output of 'gt witness uptime'.
+type WitnessUptime struct {
+	Rig            string                   `json:"rig"`
+	SessionRunning bool                     `json:"session_running"`
+	SessionCreated string                   `json:"session_created,omitempty"`
+	LoopAlive      bool                     `json:"loop_alive"`
+	LastPatrol     *time.Time               `json:"last_patrol,omitempty"`
+	Loop           *witness.SupervisorState `json:"loop,omitempty"`
+}
+
+func runWitnessUptime(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+	_, r, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+	mgr := witness.NewManager(r)
+
+	out := WitnessUptime{Rig: rigName}
+	if info, err := mgr.Status(); err == nil {
+		out.SessionRunning = true
+		out.SessionCreated = info.Created
+	}
+
+	out.Loop, err = witness.LoadSupervisorState(r.Path)
+	if err != nil {
+		return fmt.Errorf("reading witness loop state: %w", err)
+	}
+	alive, lastPatrol, err := witness.LoopHealth(r.Path, out.Loop, time.Now())
+	if err != nil {
+		return fmt.Errorf("reading witness history: %w", err)
+	}
+	out.LoopAlive = alive
+	if !lastPatrol.IsZero() {
+		out.LastPatrol = &lastPatrol
+	}
+
+	if witnessUptimeJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	fmt.Printf("%s\n", style.Bold.Render(rigName+"/witness"))
+	if out.SessionRunning {
+		fmt.Printf("  %s agent session up since %s\n", style.Success.Render("●"), out.SessionCreated)
+	} else {
+		fmt.Printf("  %s agent session not running\n", style.Dim.Render("○"))
+	}
+
+	loop := out.Loop
+	switch {
+	case loop == nil:
+		fmt.Printf("  %s patrol loop never supervised %s\n", style.Dim.Render("○"),
+			style.Dim.Render("(enable witness_loop in mayor/daemon.json)"))
+		return nil
+	case !loop.Running:
+		fmt.Printf("  %s patrol loop stopped\n", style.Dim.Render("○"))
+	case alive:
+		fmt.Printf("  %s patrol loop up %s (PID %d)\n", style.Success.Render("●"),
+			time.Since(loop.StartedAt).Round(time.Second), loop.PID)
+	default:
+		since := "since it started"
+		if out.LastPatrol != nil {
+			since = "since " + out.LastPatrol.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("  %s patrol loop stale: no patrol %s\n", style.Error.Render("✗"), since)
+	}
+	fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("%d start(s), %d restart(s) after a crash", loop.Starts, loop.Restarts)))
+	for i := len(loop.Crashes) - 1; i >= 0 && i >= len(loop.Crashes)-3; i-- {
+		c := loop.Crashes[i]
+		fmt.Printf("  %s crashed %s after %s: %s\n", style.Warning.Render("⚠"),
+			c.At.Format("2006-01-02 15:04:05"), c.Uptime, c.Error)
+	}
+	return nil
+}
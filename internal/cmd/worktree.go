@@ -42,8 +42,9 @@ Examples:
   gt worktree beads         # Create worktree in beads rig
   gt worktree gastown       # Create worktree in gastown rig (from another rig)
   gt worktree beads --no-cd # Just print the path`,
-	Args: cobra.ExactArgs(1),
-	RunE: runWorktree,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runWorktree,
 }
 
 var worktreeListCmd = &cobra.Command{
@@ -79,8 +80,9 @@ It will refuse to remove a worktree with uncommitted changes unless --force is u
 Examples:
   gt worktree remove beads         # Remove beads worktree
   gt worktree remove beads --force # Force remove even with uncommitted changes`,
-	Args: cobra.ExactArgs(1),
-	RunE: runWorktreeRemove,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationAudit: auditMutating},
+	RunE:        runWorktreeRemove,
 }
 
 func init() {
@@ -113,7 +115,7 @@ func runWorktree(cmd *cobra.Command, args []string) error {
 	// Verify target rig exists
 	_, targetRigInfo, err := getRig(targetRig)
 	if err != nil {
-		return fmt.Errorf("rig '%s' not found - run 'gt rig list' to see available rigs", targetRig)
+		return fmt.Errorf("%w - run 'gt rig list' to see available rigs", err)
 	}
 
 	// Compute worktree path: ~/gt/<target-rig>/crew/<source-rig>-<name>/
@@ -305,7 +307,7 @@ func runWorktreeRemove(cmd *cobra.Command, args []string) error {
 	// Verify target rig exists
 	_, targetRigInfo, err := getRig(targetRig)
 	if err != nil {
-		return fmt.Errorf("rig '%s' not found - run 'gt rig list' to see available rigs", targetRig)
+		return fmt.Errorf("%w - run 'gt rig list' to see available rigs", err)
 	}
 
 	// Compute worktree path: ~/gt/<target-rig>/crew/<source-rig>-<name>/
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// CurrentCrewWorkerConfigVersion is the current schema version for CrewWorkerConfig.
+const CurrentCrewWorkerConfigVersion = 1
+
+// Claude permission modes accepted by CrewWorkerConfig.PermissionMode.
+var validPermissionModes = map[string]bool{
+	"default":           true,
+	"acceptEdits":       true,
+	"plan":              true,
+	"bypassPermissions": true,
+}
+
+// CrewWorkerConfig holds per-worker launch settings (crew.json in the crew
+// workspace). It layers on top of the agent resolved for the crew role, so
+// one worker can run a cheaper model for docs while another runs a stronger
+// one for refactoring.
+type CrewWorkerConfig struct {
+	Type    string `json:"type"`    // "crew"
+	Version int    `json:"version"` // schema version
+
+	// Agent selects an agent alias (as for 'gt crew at --agent').
+	// An explicit --agent flag still wins.
+	Agent string `json:"agent,omitempty"`
+
+	// Model is passed as --model (e.g., "sonnet", "opus", or a full model name).
+	Model string `json:"model,omitempty"`
+
+	// MaxTurns is passed as --max-turns (0 = runtime default).
+	MaxTurns int `json:"max_turns,omitempty"`
+
+	// PermissionMode is passed as --permission-mode
+	// (default, acceptEdits, plan, bypassPermissions). Setting it replaces
+	// --dangerously-skip-permissions.
+	PermissionMode string `json:"permission_mode,omitempty"`
+
+	// AllowedTools and DisallowedTools are passed as --allowedTools and
+	// --disallowedTools.
+	AllowedTools    []string `json:"allowed_tools,omitempty"`
+	DisallowedTools []string `json:"disallowed_tools,omitempty"`
+
+	// Args are extra runtime arguments, appended verbatim.
+	Args []string `json:"args,omitempty"`
+}
+
+// CrewWorkerConfigPath returns the crew.json path for a crew workspace.
+func CrewWorkerConfigPath(crewPath string) string {
+	return filepath.Join(crewPath, "crew.json")
+}
+
+// LoadCrewWorkerConfig loads and validates a crew worker's crew.json.
+func LoadCrewWorkerConfig(path string) (*CrewWorkerConfig, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+		return nil, fmt.Errorf("reading crew config: %w", err)
+	}
+
+	var config CrewWorkerConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing crew config %s: %w", path, err)
+	}
+
+	if err := validateCrewWorkerConfig(&config); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return &config, nil
+}
+
+// SaveCrewWorkerConfig saves a crew worker's crew.json.
+func SaveCrewWorkerConfig(path string, config *CrewWorkerConfig) error {
+	if err := validateCrewWorkerConfig(config); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding crew config: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: crew config is not sensitive
+		return fmt.Errorf("writing crew config: %w", err)
+	}
+
+	return nil
+}
+
+// validateCrewWorkerConfig validates a CrewWorkerConfig.
+func validateCrewWorkerConfig(c *CrewWorkerConfig) error {
+	if c.Type != "crew" && c.Type != "" {
+		return fmt.Errorf("%w: expected type 'crew', got '%s'", ErrInvalidType, c.Type)
+	}
+	if c.Version > CurrentCrewWorkerConfigVersion {
+		return fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, c.Version, CurrentCrewWorkerConfigVersion)
+	}
+	if c.MaxTurns < 0 {
+		return fmt.Errorf("max_turns must be positive, got %d", c.MaxTurns)
+	}
+	if c.PermissionMode != "" && !validPermissionModes[c.PermissionMode] {
+		return fmt.Errorf("invalid permission_mode %q (valid: default, acceptEdits, plan, bypassPermissions)", c.PermissionMode)
+	}
+	return nil
+}
+
+// hasClaudeFlags reports whether the config sets any claude-specific flag.
+func (c *CrewWorkerConfig) hasClaudeFlags() bool {
+	return c.Model != "" || c.MaxTurns > 0 || c.PermissionMode != "" ||
+		len(c.AllowedTools) > 0 || len(c.DisallowedTools) > 0
+}
+
+// Apply layers the crew settings onto a resolved runtime config, returning
+// a new config. Model, turn, permission, and tool settings are claude flags
+// and are rejected for other providers; use Args there.
+func (c *CrewWorkerConfig) Apply(rc *RuntimeConfig) (*RuntimeConfig, error) {
+	out := *normalizeRuntimeConfig(rc)
+	args := append([]string(nil), out.Args...)
+
+	if c.hasClaudeFlags() && out.Provider != "claude" {
+		return nil, fmt.Errorf("crew config sets claude options but the agent provider is %q (use args instead)", out.Provider)
+	}
+
+	if c.PermissionMode != "" {
+		args = removeArg(args, "--dangerously-skip-permissions")
+		args = append(args, "--permission-mode", c.PermissionMode)
+	}
+	if c.Model != "" {
+		args = append(args, "--model", c.Model)
+	}
+	if c.MaxTurns > 0 {
+		args = append(args, "--max-turns", strconv.Itoa(c.MaxTurns))
+	}
+	for _, tool := range c.AllowedTools {
+		args = append(args, "--allowedTools", quoteForShell(tool))
+	}
+	for _, tool := range c.DisallowedTools {
+		args = append(args, "--disallowedTools", quoteForShell(tool))
+	}
+	args = append(args, c.Args...)
+
+	out.Args = args
+	return &out, nil
+}
+
+func removeArg(args []string, arg string) []string {
+	out := args[:0]
+	for _, a := range args {
+		if a != arg {
+			out = append(out, a)
+		}
+	}
+	return out
+}
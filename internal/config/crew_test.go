@@ -0,0 +1,104 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCrewWorkerConfigApply(t *testing.T) {
+	t.Parallel()
+	cfg := &CrewWorkerConfig{
+		Model:          "haiku",
+		MaxTurns:       40,
+		PermissionMode: "acceptEdits",
+		AllowedTools:   []string{"Bash(git:*)"},
+		Args:           []string{"--verbose"},
+	}
+
+	rc, err := cfg.Apply(&RuntimeConfig{Command: "claude", Args: []string{"--dangerously-skip-permissions"}})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	got := rc.BuildCommand()
+	want := `claude --permission-mode acceptEdits --model haiku --max-turns 40 --allowedTools "Bash(git:*)" --verbose`
+	if got != want {
+		t.Errorf("BuildCommand() = %q, want %q", got, want)
+	}
+
+	if _, err := cfg.Apply(&RuntimeConfig{Provider: "codex"}); err == nil {
+		t.Error("expected claude options to be rejected for codex")
+	}
+	argsOnly := &CrewWorkerConfig{Args: []string{"--foo"}}
+	rc, err = argsOnly.Apply(&RuntimeConfig{Provider: "codex", Command: "codex", Args: []string{}})
+	if err != nil {
+		t.Fatalf("Apply args to codex: %v", err)
+	}
+	if got := rc.BuildCommand(); got != "codex --foo" {
+		t.Errorf("BuildCommand() = %q, want %q", got, "codex --foo")
+	}
+}
+
+func TestLoadCrewWorkerConfigValidation(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := CrewWorkerConfigPath(dir)
+
+	if _, err := LoadCrewWorkerConfig(path); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing file error = %v, want ErrNotFound", err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"type":"crew","version":1,"permission_mode":"yolo"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCrewWorkerConfig(path); err == nil {
+		t.Error("expected invalid permission_mode to be rejected")
+	}
+
+	want := &CrewWorkerConfig{Type: "crew", Version: 1, Model: "opus", MaxTurns: 10}
+	if err := SaveCrewWorkerConfig(path, want); err != nil {
+		t.Fatalf("SaveCrewWorkerConfig: %v", err)
+	}
+	got, err := LoadCrewWorkerConfig(path)
+	if err != nil {
+		t.Fatalf("LoadCrewWorkerConfig: %v", err)
+	}
+	if got.Model != "opus" || got.MaxTurns != 10 {
+		t.Errorf("round trip = %+v, want model opus, max_turns 10", got)
+	}
+}
+
+func TestBuildCrewStartupCommandUsesCrewWorkerConfig(t *testing.T) {
+	t.Parallel()
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "testrig")
+	crewPath := filepath.Join(rigPath, "crew", "docs")
+	if err := os.MkdirAll(crewPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveTownSettings(TownSettingsPath(townRoot), NewTownSettings()); err != nil {
+		t.Fatalf("SaveTownSettings: %v", err)
+	}
+	if err := SaveRigSettings(RigSettingsPath(rigPath), NewRigSettings()); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+	if err := SaveCrewWorkerConfig(CrewWorkerConfigPath(crewPath), &CrewWorkerConfig{Model: "haiku"}); err != nil {
+		t.Fatalf("SaveCrewWorkerConfig: %v", err)
+	}
+
+	cmd, err := BuildCrewStartupCommandWithAgentOverride("testrig", "docs", rigPath, "", "")
+	if err != nil {
+		t.Fatalf("BuildCrewStartupCommandWithAgentOverride: %v", err)
+	}
+	if !strings.Contains(cmd, "--model haiku") {
+		t.Errorf("expected --model haiku in command: %q", cmd)
+	}
+
+	// Other workers are unaffected
+	cmd = BuildCrewStartupCommand("testrig", "max", rigPath, "")
+	if strings.Contains(cmd, "--model") {
+		t.Errorf("unexpected --model for worker without crew.json: %q", cmd)
+	}
+}
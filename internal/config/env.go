@@ -165,6 +165,20 @@ func MergeEnv(maps ...map[string]string) map[string]string {
 	return result
 }
 
+// RigEnv returns the rig-declared variables (rigs.json "env") that an agent
+// session should get in addition to agentEnv. Variables Gas Town sets itself
+// are dropped so a rig can't override an agent's identity.
+func RigEnv(rigEnv, agentEnv map[string]string) map[string]string {
+	if len(rigEnv) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(agentEnv))
+	for k := range agentEnv {
+		keys = append(keys, k)
+	}
+	return WithoutEnv(rigEnv, keys...)
+}
+
 // FilterEnv returns a new map with only the specified keys.
 func FilterEnv(env map[string]string, keys ...string) map[string]string {
 	result := make(map[string]string)
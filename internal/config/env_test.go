@@ -309,6 +309,25 @@ func TestEnvToSlice(t *testing.T) {
 	}
 }
 
+func TestRigEnv(t *testing.T) {
+	t.Parallel()
+	agentEnv := AgentEnvSimple("polecat", "myrig", "Toast")
+	rigEnv := map[string]string{
+		"GOFLAGS":  "-mod=mod",
+		"NODE_ENV": "development",
+		"GT_ROLE":  "mayor", // can't override identity
+	}
+
+	got := RigEnv(rigEnv, agentEnv)
+	assertEnv(t, got, "GOFLAGS", "-mod=mod")
+	assertEnv(t, got, "NODE_ENV", "development")
+	assertNotSet(t, got, "GT_ROLE")
+
+	if got := RigEnv(nil, agentEnv); got != nil {
+		t.Errorf("RigEnv(nil) = %v, want nil", got)
+	}
+}
+
 // Helper functions
 
 func assertEnv(t *testing.T, env map[string]string, key, expected string) {
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// CurrentEventHooksVersion is the current schema version for EventHooksConfig.
+const CurrentEventHooksVersion = 1
+
+// EventHooksConfig maps events to actions (settings/event-hooks.json). The
+// daemon runs every enabled hook whose match rules accept an event written
+// to the town's events log.
+type EventHooksConfig struct {
+	Type    string `json:"type"`    // "event-hooks"
+	Version int    `json:"version"` // schema version
+
+	Hooks []*EventHook `json:"hooks"`
+}
+
+// EventHook runs actions for the events it matches. A hook needs at least
+// one action; with several, all run.
+type EventHook struct {
+	// Name identifies the hook in logs and in GT_EVENT_HOOK.
+	Name string `json:"name"`
+
+	// Match selects the events the hook runs for.
+	Match EventMatch `json:"match"`
+
+	// Command is run with sh -c in the town root, with the event as JSON on
+	// stdin and GT_EVENT_TYPE, GT_EVENT_ACTOR, GT_EVENT_RIG and GT_EVENT_TS
+	// set.
+	Command string `json:"command,omitempty"`
+
+	// URL receives the event as a JSON POST.
+	URL string `json:"url,omitempty"`
+
+	// Headers are extra HTTP headers for URL (e.g., auth).
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Sink names a sink in settings/notifications.json (e.g., a Slack
+	// channel) to notify, subject to that sink's rate limit.
+	Sink string `json:"sink,omitempty"`
+
+	// Severity is the severity of notifications sent to Sink (default medium).
+	Severity string `json:"severity,omitempty"`
+
+	// Timeout bounds each action (Go duration, default 30s).
+	Timeout string `json:"timeout,omitempty"`
+
+	// Disabled turns the hook off without removing it.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// EventMatch selects events. Every rule that is set must match; an empty
+// match accepts every event. Types and Actor are glob patterns, so
+// "*/witness" matches every rig's witness.
+type EventMatch struct {
+	Types []string `json:"types,omitempty"` // any of these event types
+	Actor string   `json:"actor,omitempty"` // actor address
+	Rig   string   `json:"rig,omitempty"`   // rig the event concerns
+}
+
+// DefaultEventHookTimeout bounds a hook action without a timeout.
+const DefaultEventHookTimeout = 30 * time.Second
+
+// TimeoutDuration returns the hook's action timeout.
+func (h *EventHook) TimeoutDuration() time.Duration {
+	if d, err := time.ParseDuration(h.Timeout); err == nil && d > 0 {
+		return d
+	}
+	return DefaultEventHookTimeout
+}
+
+// Matches reports whether an event with the given type, actor and rig passes
+// the match rules.
+func (m EventMatch) Matches(eventType, actor, rig string) bool {
+	if len(m.Types) > 0 {
+		matched := false
+		for _, pattern := range m.Types {
+			if ok, _ := path.Match(pattern, eventType); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if m.Actor != "" {
+		if ok, _ := path.Match(m.Actor, actor); !ok {
+			return false
+		}
+	}
+	if m.Rig != "" && m.Rig != rig {
+		return false
+	}
+	return true
+}
+
+// NewEventHooksConfig creates a configuration with no hooks.
+func NewEventHooksConfig() *EventHooksConfig {
+	return &EventHooksConfig{
+		Type:    "event-hooks",
+		Version: CurrentEventHooksVersion,
+	}
+}
+
+// EventHooksConfigPath returns the standard path for the event hooks config in a town.
+func EventHooksConfigPath(townRoot string) string {
+	return filepath.Join(townRoot, "settings", "event-hooks.json")
+}
+
+// LoadEventHooksConfig loads and validates an event hooks configuration file.
+func LoadEventHooksConfig(path string) (*EventHooksConfig, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+		return nil, fmt.Errorf("reading event hooks config: %w", err)
+	}
+
+	var config EventHooksConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing event hooks config: %w", err)
+	}
+
+	if err := validateEventHooksConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// LoadOrCreateEventHooksConfig loads the event hooks config, returning an empty config if not found.
+func LoadOrCreateEventHooksConfig(path string) (*EventHooksConfig, error) {
+	config, err := LoadEventHooksConfig(path)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return NewEventHooksConfig(), nil
+		}
+		return nil, err
+	}
+	return config, nil
+}
+
+// validateEventHooksConfig validates an EventHooksConfig.
+func validateEventHooksConfig(c *EventHooksConfig) error {
+	if c.Type != "event-hooks" && c.Type != "" {
+		return fmt.Errorf("%w: expected type 'event-hooks', got '%s'", ErrInvalidType, c.Type)
+	}
+	if c.Version > CurrentEventHooksVersion {
+		return fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, c.Version, CurrentEventHooksVersion)
+	}
+
+	names := make(map[string]bool, len(c.Hooks))
+	for i, hook := range c.Hooks {
+		field := fmt.Sprintf("hooks[%d]", i)
+		if hook == nil {
+			return fmt.Errorf("%w: %s", ErrMissingField, field)
+		}
+		if hook.Name == "" {
+			return fmt.Errorf("%w: %s.name", ErrMissingField, field)
+		}
+		if names[hook.Name] {
+			return fmt.Errorf("%s: duplicate hook name %q", field, hook.Name)
+		}
+		names[hook.Name] = true
+		if hook.Command == "" && hook.URL == "" && hook.Sink == "" {
+			return fmt.Errorf("%w: %s needs a command, url, or sink", ErrMissingField, field)
+		}
+		for _, pattern := range append([]string{hook.Match.Actor}, hook.Match.Types...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("%s.match: invalid pattern %q", field, pattern)
+			}
+		}
+		if hook.Timeout != "" {
+			if d, err := time.ParseDuration(hook.Timeout); err != nil || d <= 0 {
+				return fmt.Errorf("%s: invalid timeout %q", field, hook.Timeout)
+			}
+		}
+		if hook.Severity != "" && !IsValidSeverity(hook.Severity) {
+			return fmt.Errorf("%s: invalid severity %q", field, hook.Severity)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadEventHooksConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings", "event-hooks.json")
+
+	cfg, err := LoadOrCreateEventHooksConfig(path)
+	if err != nil {
+		t.Fatalf("LoadOrCreateEventHooksConfig() error = %v", err)
+	}
+	if len(cfg.Hooks) != 0 {
+		t.Errorf("default config has %d hooks, want 0", len(cfg.Hooks))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"type": "event-hooks", "version": 1, "hooks": [
+		{"name": "failures", "match": {"types": ["merge_failed", "*_death"]}, "command": "notify-send failed", "timeout": "5s"},
+		{"name": "kills", "match": {"actor": "*/witness", "types": ["kill"]}, "sink": "ops"}
+	]}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadEventHooksConfig(path)
+	if err != nil {
+		t.Fatalf("LoadEventHooksConfig() error = %v", err)
+	}
+	if len(loaded.Hooks) != 2 || loaded.Hooks[1].Sink != "ops" {
+		t.Fatalf("loaded hooks = %+v", loaded.Hooks)
+	}
+	if got := loaded.Hooks[0].TimeoutDuration().String(); got != "5s" {
+		t.Errorf("timeout = %s, want 5s", got)
+	}
+	if got := loaded.Hooks[1].TimeoutDuration(); got != DefaultEventHookTimeout {
+		t.Errorf("default timeout = %s, want %s", got, DefaultEventHookTimeout)
+	}
+}
+
+func TestEventMatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		match EventMatch
+		typ   string
+		actor string
+		rig   string
+		want  bool
+	}{
+		{"empty matches all", EventMatch{}, "sling", "mayor", "", true},
+		{"type", EventMatch{Types: []string{"kill"}}, "kill", "gastown/witness", "gastown", true},
+		{"type mismatch", EventMatch{Types: []string{"kill"}}, "sling", "gastown/witness", "gastown", false},
+		{"type glob", EventMatch{Types: []string{"merge_*"}}, "merge_failed", "gastown/refinery", "gastown", true},
+		{"actor glob", EventMatch{Actor: "*/witness", Types: []string{"kill"}}, "kill", "beads/witness", "beads", true},
+		{"actor mismatch", EventMatch{Actor: "*/witness"}, "kill", "gastown/polecats/Toast", "gastown", false},
+		{"rig", EventMatch{Rig: "gastown"}, "done", "gastown/polecats/Toast", "gastown", true},
+		{"rig mismatch", EventMatch{Rig: "gastown"}, "done", "beads/polecats/Toast", "beads", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.match.Matches(tt.typ, tt.actor, tt.rig); got != tt.want {
+				t.Errorf("Matches(%q, %q, %q) = %v, want %v", tt.typ, tt.actor, tt.rig, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventHooksConfigValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		hook *EventHook
+		want error
+	}{
+		{"no name", &EventHook{Command: "true"}, ErrMissingField},
+		{"no action", &EventHook{Name: "h"}, ErrMissingField},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewEventHooksConfig()
+			cfg.Hooks = []*EventHook{tt.hook}
+			if err := validateEventHooksConfig(cfg); !errors.Is(err, tt.want) {
+				t.Errorf("validate error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	invalid := []*EventHook{
+		{Name: "h", Command: "true", Timeout: "soon"},
+		{Name: "h", Command: "true", Severity: "urgent"},
+		{Name: "h", Command: "true", Match: EventMatch{Actor: "[bad"}},
+	}
+	for _, hook := range invalid {
+		cfg := NewEventHooksConfig()
+		cfg.Hooks = []*EventHook{hook}
+		if err := validateEventHooksConfig(cfg); err == nil {
+			t.Errorf("expected error for %+v", hook)
+		}
+	}
+
+	cfg := NewEventHooksConfig()
+	cfg.Hooks = []*EventHook{{Name: "h", Command: "true"}, {Name: "h", URL: "https://example.com"}}
+	if err := validateEventHooksConfig(cfg); err == nil {
+		t.Error("expected error for duplicate hook names")
+	}
+}
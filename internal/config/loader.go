@@ -7,11 +7,14 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/container"
 )
 
 var (
@@ -30,17 +33,14 @@ var (
 
 // LoadTownConfig loads and validates a town configuration file.
 func LoadTownConfig(path string) (*TownConfig, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from trusted config location
+	data, err := readVersioned(path, TownConfigSchema)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
-		}
-		return nil, fmt.Errorf("reading config: %w", err)
+		return nil, err
 	}
 
 	var config TownConfig
 	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("parsing config: %w", err)
+		return nil, describeConfigError(path, data, err)
 	}
 
 	if err := validateTownConfig(&config); err != nil {
@@ -74,17 +74,14 @@ func SaveTownConfig(path string, config *TownConfig) error {
 
 // LoadRigsConfig loads and validates a rigs registry file.
 func LoadRigsConfig(path string) (*RigsConfig, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	data, err := readVersioned(path, RigsConfigSchema)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
-		}
-		return nil, fmt.Errorf("reading config: %w", err)
+		return nil, err
 	}
 
 	var config RigsConfig
 	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("parsing config: %w", err)
+		return nil, describeConfigError(path, data, err)
 	}
 
 	if err := validateRigsConfig(&config); err != nil {
@@ -138,22 +135,29 @@ func validateRigsConfig(c *RigsConfig) error {
 	if c.Rigs == nil {
 		c.Rigs = make(map[string]RigEntry)
 	}
+	for name, entry := range c.Rigs {
+		for k := range entry.Env {
+			if !envNameRe.MatchString(k) {
+				return fmt.Errorf("rig %s: invalid env variable name %q", name, k)
+			}
+		}
+	}
 	return nil
 }
 
+// envNameRe matches valid environment variable names.
+var envNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 // LoadRigConfig loads and validates a rig configuration file.
 func LoadRigConfig(path string) (*RigConfig, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	data, err := readVersioned(path, RigConfigSchema)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
-		}
-		return nil, fmt.Errorf("reading config: %w", err)
+		return nil, err
 	}
 
 	var config RigConfig
 	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("parsing config: %w", err)
+		return nil, describeConfigError(path, data, err)
 	}
 
 	if err := validateRigConfig(&config); err != nil {
@@ -212,6 +216,122 @@ func validateRigSettings(c *RigSettings) error {
 			return err
 		}
 	}
+	if c.Liveness != nil {
+		if err := validateLivenessConfig(c.Liveness); err != nil {
+			return err
+		}
+	}
+	if c.Mail != nil {
+		if err := validateRigMailConfig(c.Mail); err != nil {
+			return err
+		}
+	}
+	if c.Container != nil {
+		if err := validateContainerConfig(c.Container); err != nil {
+			return err
+		}
+	}
+	if c.Checkout != nil {
+		if err := validateCheckoutConfig(c.Checkout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCheckoutConfig validates a CheckoutConfig.
+func validateCheckoutConfig(c *CheckoutConfig) error {
+	switch c.Mode {
+	case "", CheckoutClone, CheckoutWorktree:
+	default:
+		return fmt.Errorf("checkout: invalid mode %q (want clone or worktree)", c.Mode)
+	}
+	if c.Depth < 0 {
+		return fmt.Errorf("checkout: invalid depth %d", c.Depth)
+	}
+	if c.Filter != "" && (!strings.Contains(c.Filter, ":") || strings.ContainsAny(c.Filter, " \t\n")) {
+		return fmt.Errorf("checkout: invalid filter %q (e.g. blob:none)", c.Filter)
+	}
+	if c.Mode == CheckoutWorktree && (c.Depth > 0 || c.Filter != "") {
+		return fmt.Errorf("checkout: depth and filter apply to crew clones only, not worktree mode")
+	}
+	for _, p := range c.Sparse {
+		if strings.TrimSpace(p) == "" || strings.Contains(p, "\n") {
+			return fmt.Errorf("checkout: invalid sparse pattern %q", p)
+		}
+	}
+	return nil
+}
+
+// validateContainerConfig validates a ContainerConfig.
+func validateContainerConfig(c *ContainerConfig) error {
+	if c.Image == "" {
+		return fmt.Errorf("%w: container.image", ErrMissingField)
+	}
+	switch c.Runtime {
+	case "", "docker", "podman":
+	default:
+		return fmt.Errorf("container: invalid runtime %q (want docker or podman)", c.Runtime)
+	}
+	for _, m := range c.Mounts {
+		if _, err := container.ParseMount(m); err != nil {
+			return fmt.Errorf("container: %w", err)
+		}
+	}
+	if c.PidsLimit < 0 {
+		return fmt.Errorf("container: invalid pids_limit %d", c.PidsLimit)
+	}
+	return nil
+}
+
+// validateRigMailConfig validates a RigMailConfig.
+func validateRigMailConfig(c *RigMailConfig) error {
+	seen := make(map[string]bool)
+	for i, rule := range c.Mirrors {
+		if rule == nil || rule.Name == "" {
+			return fmt.Errorf("%w: mail.mirrors[%d].name", ErrMissingField, i)
+		}
+		if seen[rule.Name] {
+			return fmt.Errorf("mail.mirrors: duplicate rule name %q", rule.Name)
+		}
+		seen[rule.Name] = true
+		switch rule.MinPriority {
+		case "", "low", "normal", "high", "urgent":
+		default:
+			return fmt.Errorf("mail.mirrors.%s: invalid min_priority %q", rule.Name, rule.MinPriority)
+		}
+		if rule.Sink == nil {
+			return fmt.Errorf("%w: mail.mirrors.%s.sink", ErrMissingField, rule.Name)
+		}
+		if err := validateNotificationSink("mail.mirrors."+rule.Name+".sink", rule.Sink); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// livenessCheckNames are the liveness checks a rig can configure.
+var livenessCheckNames = []string{"session", "pane", "git", "beads", "script"}
+
+// validateLivenessConfig validates a LivenessConfig.
+func validateLivenessConfig(c *LivenessConfig) error {
+	if c.Threshold < 0 || c.Threshold > 1 {
+		return fmt.Errorf("invalid liveness threshold %g: must be between 0 and 1", c.Threshold)
+	}
+	for name, check := range c.Checks {
+		if !slices.Contains(livenessCheckNames, name) {
+			return fmt.Errorf("unknown liveness check %q (want one of %s)", name, strings.Join(livenessCheckNames, ", "))
+		}
+		if check == nil {
+			continue
+		}
+		if check.Weight < 0 {
+			return fmt.Errorf("invalid weight %g for liveness check %q", check.Weight, name)
+		}
+		if name == "script" && check.Enabled != nil && *check.Enabled && check.Command == "" {
+			return errors.New(`liveness check "script" is enabled but has no command`)
+		}
+	}
 	return nil
 }
 
@@ -266,17 +386,14 @@ func NewRigSettings() *RigSettings {
 
 // LoadRigSettings loads and validates a rig settings file.
 func LoadRigSettings(path string) (*RigSettings, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	data, err := readVersioned(path, RigSettingsSchema)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
-		}
-		return nil, fmt.Errorf("reading settings: %w", err)
+		return nil, err
 	}
 
 	var settings RigSettings
 	if err := json.Unmarshal(data, &settings); err != nil {
-		return nil, fmt.Errorf("parsing settings: %w", err)
+		return nil, describeConfigError(path, data, err)
 	}
 
 	if err := validateRigSettings(&settings); err != nil {
@@ -308,6 +425,64 @@ func SaveRigSettings(path string, settings *RigSettings) error {
 	return nil
 }
 
+// WorkspaceSettingsPath returns the path to a workspace's settings file.
+// It lives under .runtime/, which is ignored by git in every workspace.
+func WorkspaceSettingsPath(workspacePath string) string {
+	return filepath.Join(workspacePath, ".runtime", "settings.json")
+}
+
+// LoadWorkspaceSettings loads and validates a workspace settings file.
+func LoadWorkspaceSettings(path string) (*WorkspaceSettings, error) {
+	data, err := readVersioned(path, WorkspaceSettingsSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings WorkspaceSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, describeConfigError(path, data, err)
+	}
+
+	if err := validateWorkspaceSettings(&settings); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// SaveWorkspaceSettings saves workspace settings to a file.
+func SaveWorkspaceSettings(path string, settings *WorkspaceSettings) error {
+	if err := validateWorkspaceSettings(settings); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding settings: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: settings files don't contain secrets
+		return fmt.Errorf("writing settings: %w", err)
+	}
+
+	return nil
+}
+
+// validateWorkspaceSettings validates a WorkspaceSettings.
+func validateWorkspaceSettings(c *WorkspaceSettings) error {
+	if c.Type != "workspace-settings" && c.Type != "" {
+		return fmt.Errorf("%w: expected type 'workspace-settings', got '%s'", ErrInvalidType, c.Type)
+	}
+	if c.Version > CurrentWorkspaceSettingsVersion {
+		return fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, c.Version, CurrentWorkspaceSettingsVersion)
+	}
+	return nil
+}
+
 // LoadMayorConfig loads and validates a mayor config file.
 func LoadMayorConfig(path string) (*MayorConfig, error) {
 	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
@@ -646,6 +821,9 @@ func validateMessagingConfig(c *MessagingConfig) error {
 	if c.Lists == nil {
 		c.Lists = make(map[string][]string)
 	}
+	if c.Groups == nil {
+		c.Groups = make(map[string][]string)
+	}
 	if c.Queues == nil {
 		c.Queues = make(map[string]QueueConfig)
 	}
@@ -663,6 +841,13 @@ func validateMessagingConfig(c *MessagingConfig) error {
 		}
 	}
 
+	// Validate groups have at least one member
+	for name, members := range c.Groups {
+		if len(members) == 0 {
+			return fmt.Errorf("%w: group '%s' has no members", ErrMissingField, name)
+		}
+	}
+
 	// Validate queues have at least one worker
 	for name, queue := range c.Queues {
 		if len(queue.Workers) == 0 {
@@ -683,6 +868,18 @@ func validateMessagingConfig(c *MessagingConfig) error {
 		}
 	}
 
+	// Validate retention policies
+	for name, policy := range c.Retention {
+		if policy.MaxMessages < 0 {
+			return fmt.Errorf("%w: retention '%s' max_messages must be non-negative", ErrMissingField, name)
+		}
+		if policy.MaxAge != "" {
+			if d, err := time.ParseDuration(policy.MaxAge); err != nil || d <= 0 {
+				return fmt.Errorf("%w: retention '%s' max_age must be a positive duration, got %q", ErrMissingField, name, policy.MaxAge)
+			}
+		}
+	}
+
 	// Validate nudge channels have non-empty names and at least one recipient
 	for name, recipients := range c.NudgeChannels {
 		if name == "" {
@@ -742,9 +939,9 @@ func RigSettingsPath(rigPath string) string {
 
 // LoadOrCreateTownSettings loads town settings or creates defaults if missing.
 func LoadOrCreateTownSettings(path string) (*TownSettings, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally
+	data, err := readVersioned(path, TownSettingsSchema)
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, ErrNotFound) {
 			return NewTownSettings(), nil
 		}
 		return nil, err
@@ -752,19 +949,27 @@ func LoadOrCreateTownSettings(path string) (*TownSettings, error) {
 
 	var settings TownSettings
 	if err := json.Unmarshal(data, &settings); err != nil {
-		return nil, err
+		return nil, describeConfigError(path, data, err)
 	}
 	return &settings, nil
 }
 
-// SaveTownSettings saves town settings to a file.
-func SaveTownSettings(path string, settings *TownSettings) error {
+// validateTownSettings validates a TownSettings.
+func validateTownSettings(settings *TownSettings) error {
 	if settings.Type != "town-settings" && settings.Type != "" {
 		return fmt.Errorf("%w: expected type 'town-settings', got '%s'", ErrInvalidType, settings.Type)
 	}
 	if settings.Version > CurrentTownSettingsVersion {
 		return fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, settings.Version, CurrentTownSettingsVersion)
 	}
+	return nil
+}
+
+// SaveTownSettings saves town settings to a file.
+func SaveTownSettings(path string, settings *TownSettings) error {
+	if err := validateTownSettings(settings); err != nil {
+		return err
+	}
 
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("creating directory: %w", err)
@@ -790,7 +995,8 @@ func SaveTownSettings(path string, settings *TownSettings) error {
 //  2. If rig has Agent set, look it up in:
 //     a. Town's custom agents (from TownSettings.Agents)
 //     b. Built-in presets (claude, gemini, codex)
-//  3. If rig has no Agent set, use town's default_agent
+//  3. If rig has no Agent set, use the resolved default_agent
+//     (GT_DEFAULT_AGENT, rig/town overrides, town's default_agent)
 //  4. Fall back to claude defaults
 //
 // townRoot is the path to the town directory (e.g., ~/gt).
@@ -820,15 +1026,8 @@ func ResolveAgentConfig(townRoot, rigPath string) *RuntimeConfig {
 	// Load rig-level custom agent registry if it exists (for per-rig custom agents)
 	_ = LoadRigAgentRegistry(RigAgentRegistryPath(rigPath))
 
-	// Determine which agent name to use
-	agentName := ""
-	if rigSettings != nil && rigSettings.Agent != "" {
-		agentName = rigSettings.Agent
-	} else if townSettings.DefaultAgent != "" {
-		agentName = townSettings.DefaultAgent
-	} else {
-		agentName = "claude" // ultimate fallback
-	}
+	// Determine which agent name to use (GT_DEFAULT_AGENT → rig → town → "claude")
+	agentName := settingDefaultAgent(townRoot, rigPath, townSettings, rigSettings)
 
 	return lookupAgentConfig(agentName, townSettings, rigSettings)
 }
@@ -863,15 +1062,9 @@ func ResolveAgentConfigWithOverride(townRoot, rigPath, agentOverride string) (*R
 	_ = LoadRigAgentRegistry(RigAgentRegistryPath(rigPath))
 
 	// Determine which agent name to use
-	agentName := ""
-	if agentOverride != "" {
-		agentName = agentOverride
-	} else if rigSettings != nil && rigSettings.Agent != "" {
-		agentName = rigSettings.Agent
-	} else if townSettings.DefaultAgent != "" {
-		agentName = townSettings.DefaultAgent
-	} else {
-		agentName = "claude" // ultimate fallback
+	agentName := agentOverride
+	if agentName == "" {
+		agentName = settingDefaultAgent(townRoot, rigPath, townSettings, rigSettings)
 	}
 
 	// If an override is requested, validate it exists
@@ -1039,13 +1232,7 @@ func ResolveRoleAgentName(role, townRoot, rigPath string) (agentName string, isR
 	}
 
 	// Fall back to existing resolution
-	if rigSettings != nil && rigSettings.Agent != "" {
-		return rigSettings.Agent, false
-	}
-	if townSettings.DefaultAgent != "" {
-		return townSettings.DefaultAgent, false
-	}
-	return "claude", false
+	return settingDefaultAgent(townRoot, rigPath, townSettings, rigSettings), false
 }
 
 // lookupAgentConfig looks up an agent by name.
@@ -1285,13 +1472,24 @@ func PrependEnv(command string, envVars map[string]string) string {
 
 	var exports []string
 	for k, v := range envVars {
-		exports = append(exports, fmt.Sprintf("%s=%s", k, v))
+		exports = append(exports, fmt.Sprintf("%s=%s", k, shellQuoteEnvValue(v)))
 	}
 
 	sort.Strings(exports)
 	return "export " + strings.Join(exports, " ") + " && " + command
 }
 
+// shellSafeRe matches values that need no quoting in a shell export.
+var shellSafeRe = regexp.MustCompile(`^[A-Za-z0-9_./:@%+=,~-]*$`)
+
+// shellQuoteEnvValue single-quotes v for a shell export unless it's plainly safe.
+func shellQuoteEnvValue(v string) string {
+	if shellSafeRe.MatchString(v) {
+		return v
+	}
+	return "'" + strings.ReplaceAll(v, "'", `'\''`) + "'"
+}
+
 // BuildStartupCommandWithAgentOverride builds a startup command like BuildStartupCommand,
 // but uses agentOverride if non-empty.
 //
@@ -1300,6 +1498,13 @@ func PrependEnv(command string, envVars map[string]string) string {
 //  2. role_agents[GT_ROLE] (if GT_ROLE is in envVars)
 //  3. Default agent resolution (rig's Agent → town's DefaultAgent → "claude")
 func BuildStartupCommandWithAgentOverride(envVars map[string]string, rigPath, prompt, agentOverride string) (string, error) {
+	return buildStartupCommand(envVars, rigPath, prompt, agentOverride, nil)
+}
+
+// buildStartupCommand resolves the runtime (honoring agentOverride), layers
+// per-worker crew settings on top if crewCfg is non-nil, and builds the
+// command line.
+func buildStartupCommand(envVars map[string]string, rigPath, prompt, agentOverride string, crewCfg *CrewWorkerConfig) (string, error) {
 	var rc *RuntimeConfig
 	var townRoot string
 
@@ -1358,6 +1563,13 @@ func BuildStartupCommandWithAgentOverride(envVars map[string]string, rigPath, pr
 		resolvedEnv["GT_AGENT"] = agentOverride
 	}
 
+	if crewCfg != nil {
+		var err error
+		if rc, err = crewCfg.Apply(rc); err != nil {
+			return "", err
+		}
+	}
+
 	// Build environment export prefix
 	var exports []string
 	for k, v := range resolvedEnv {
@@ -1434,8 +1646,13 @@ func BuildPolecatStartupCommandWithAgentOverride(rigName, polecatName, rigPath,
 }
 
 // BuildCrewStartupCommand builds the startup command for a crew member.
-// Sets GT_ROLE, GT_RIG, GT_CREW, BD_ACTOR, GIT_AUTHOR_NAME, and GT_ROOT.
+// Per-worker settings from the workspace's crew.json are applied; if they
+// are invalid, the worker starts with the rig's defaults.
 func BuildCrewStartupCommand(rigName, crewName, rigPath, prompt string) string {
+	cmd, err := BuildCrewStartupCommandWithAgentOverride(rigName, crewName, rigPath, prompt, "")
+	if err == nil {
+		return cmd
+	}
 	var townRoot string
 	if rigPath != "" {
 		townRoot = filepath.Dir(rigPath)
@@ -1450,6 +1667,7 @@ func BuildCrewStartupCommand(rigName, crewName, rigPath, prompt string) string {
 }
 
 // BuildCrewStartupCommandWithAgentOverride is like BuildCrewStartupCommand, but uses agentOverride if non-empty.
+// Unlike BuildCrewStartupCommand, an invalid crew.json is an error.
 func BuildCrewStartupCommandWithAgentOverride(rigName, crewName, rigPath, prompt, agentOverride string) (string, error) {
 	var townRoot string
 	if rigPath != "" {
@@ -1461,7 +1679,19 @@ func BuildCrewStartupCommandWithAgentOverride(rigName, crewName, rigPath, prompt
 		AgentName: crewName,
 		TownRoot:  townRoot,
 	})
-	return BuildStartupCommandWithAgentOverride(envVars, rigPath, prompt, agentOverride)
+
+	var crewCfg *CrewWorkerConfig
+	if rigPath != "" {
+		cfg, err := LoadCrewWorkerConfig(CrewWorkerConfigPath(filepath.Join(rigPath, "crew", crewName)))
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return "", err
+		}
+		crewCfg = cfg
+	}
+	if agentOverride == "" && crewCfg != nil {
+		agentOverride = crewCfg.Agent
+	}
+	return buildStartupCommand(envVars, rigPath, prompt, agentOverride, crewCfg)
 }
 
 // ExpectedPaneCommands returns tmux pane command names that indicate the runtime is running.
@@ -341,6 +341,166 @@ func TestRigSettingsValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid liveness checks",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				Liveness: &LivenessConfig{
+					Threshold: 0.5,
+					Checks: map[string]*LivenessCheckConfig{
+						"git":    {Weight: 2},
+						"script": {Command: "./still-working.sh"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown liveness check",
+			settings: &RigSettings{
+				Type:     "rig-settings",
+				Version:  1,
+				Liveness: &LivenessConfig{Checks: map[string]*LivenessCheckConfig{"cpu": {}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "liveness threshold out of range",
+			settings: &RigSettings{
+				Type:     "rig-settings",
+				Version:  1,
+				Liveness: &LivenessConfig{Threshold: 1.5},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid container",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				Container: &ContainerConfig{
+					Image:  "gastown/agent:latest",
+					Mounts: []string{"/opt/tools:/opt/tools:ro"},
+					Memory: "4g",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "container without image",
+			settings: &RigSettings{
+				Type:      "rig-settings",
+				Version:   1,
+				Container: &ContainerConfig{Memory: "4g"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "container with bad mount",
+			settings: &RigSettings{
+				Type:      "rig-settings",
+				Version:   1,
+				Container: &ContainerConfig{Image: "agent", Mounts: []string{"/opt/tools"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "worktree checkout",
+			settings: &RigSettings{
+				Type:     "rig-settings",
+				Version:  1,
+				Checkout: &CheckoutConfig{Mode: CheckoutWorktree},
+			},
+			wantErr: false,
+		},
+		{
+			name: "shallow sparse checkout",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				Checkout: &CheckoutConfig{
+					Depth:  1,
+					Filter: "blob:none",
+					Sparse: []string{"/services/api/", "/libs/"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "shallow worktree checkout",
+			settings: &RigSettings{
+				Type:     "rig-settings",
+				Version:  1,
+				Checkout: &CheckoutConfig{Mode: CheckoutWorktree, Depth: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid checkout filter",
+			settings: &RigSettings{
+				Type:     "rig-settings",
+				Version:  1,
+				Checkout: &CheckoutConfig{Filter: "none"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty sparse pattern",
+			settings: &RigSettings{
+				Type:     "rig-settings",
+				Version:  1,
+				Checkout: &CheckoutConfig{Sparse: []string{" "}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid checkout mode",
+			settings: &RigSettings{
+				Type:     "rig-settings",
+				Version:  1,
+				Checkout: &CheckoutConfig{Mode: "symlink"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid mail mirror",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				Mail: &RigMailConfig{Mirrors: []*MailMirrorRule{{
+					Name:            "escalations",
+					SubjectContains: "ESCALATION",
+					Sink:            &NotificationSink{Type: SinkSlack, URL: "https://hooks.example.com/x"},
+				}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "mail mirror without sink url",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				Mail: &RigMailConfig{Mirrors: []*MailMirrorRule{{
+					Name: "escalations",
+					Sink: &NotificationSink{Type: SinkWebhook},
+				}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "mail mirror with invalid min_priority",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				Mail: &RigMailConfig{Mirrors: []*MailMirrorRule{{
+					Name:        "escalations",
+					MinPriority: "critical",
+					Sink:        &NotificationSink{Type: SinkSlack, URL: "https://hooks.example.com/x"},
+				}}},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -744,6 +904,37 @@ func TestMessagingConfigValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid config with retention",
+			config: &MessagingConfig{
+				Version: 1,
+				Retention: map[string]RetentionPolicy{
+					"default":        {MaxAge: "720h"},
+					"gastown/crew/*": {MaxMessages: 200},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "retention with bad max_age",
+			config: &MessagingConfig{
+				Version: 1,
+				Retention: map[string]RetentionPolicy{
+					"default": {MaxAge: "30 days"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "retention with negative max_messages",
+			config: &MessagingConfig{
+				Version: 1,
+				Retention: map[string]RetentionPolicy{
+					"default": {MaxMessages: -1},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -2777,3 +2968,47 @@ func TestBuildStartupCommandWithAgentOverride_NoGTAgentWhenNoOverride(t *testing
 		t.Errorf("expected no GT_AGENT in command when no override, got: %q", cmd)
 	}
 }
+
+func TestRigsConfigEnv(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mayor", "rigs.json")
+
+	cfg := &RigsConfig{
+		Version: 1,
+		Rigs: map[string]RigEntry{
+			"gastown": {
+				GitURL: "git@github.com:steveyegge/gastown.git",
+				Env:    map[string]string{"GOFLAGS": "-mod=mod", "NODE_ENV": "test"},
+			},
+		},
+	}
+	if err := SaveRigsConfig(path, cfg); err != nil {
+		t.Fatalf("SaveRigsConfig: %v", err)
+	}
+	loaded, err := LoadRigsConfig(path)
+	if err != nil {
+		t.Fatalf("LoadRigsConfig: %v", err)
+	}
+	if got := loaded.Rigs["gastown"].Env["GOFLAGS"]; got != "-mod=mod" {
+		t.Errorf("Env[GOFLAGS] = %q, want -mod=mod", got)
+	}
+
+	cfg.Rigs["gastown"] = RigEntry{Env: map[string]string{"NOT VALID": "x"}}
+	if err := SaveRigsConfig(path, cfg); err == nil {
+		t.Error("SaveRigsConfig accepted an invalid env variable name")
+	}
+}
+
+func TestPrependEnvQuoting(t *testing.T) {
+	t.Parallel()
+	got := PrependEnv("claude", map[string]string{
+		"GOFLAGS": "-mod=mod",
+		"OPTS":    "a b",
+		"QUOTE":   "it's",
+	})
+	want := `export GOFLAGS=-mod=mod OPTS='a b' QUOTE='it'\''s' && claude`
+	if got != want {
+		t.Errorf("PrependEnv = %q, want %q", got, want)
+	}
+}
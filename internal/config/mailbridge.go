@@ -0,0 +1,117 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CurrentMailBridgeVersion is the current schema version for MailBridgeConfig.
+const CurrentMailBridgeVersion = 1
+
+// MailBridgeConfig configures the email bridge for the human overseer
+// (settings/mail-bridge.json). Mail addressed to the overseer is forwarded
+// to Email over SMTP; replies fetched over IMAP are delivered back into the
+// town as mail from the overseer.
+type MailBridgeConfig struct {
+	Type    string `json:"type"`    // "mail-bridge"
+	Version int    `json:"version"` // schema version
+
+	// Email is the overseer's real address. Defaults to the email in
+	// mayor/overseer.json. Only authenticated replies from this address
+	// are ingested.
+	Email string `json:"email,omitempty"`
+
+	// From is the address the bridge sends as; replies come back to it.
+	From string `json:"from"`
+
+	// SMTP is the outgoing server.
+	SMTP MailServerConfig `json:"smtp"`
+
+	// IMAP is the incoming server. Without it the bridge only forwards.
+	IMAP *MailServerConfig `json:"imap,omitempty"`
+
+	// IMAPFolder is the folder replies arrive in (default "INBOX").
+	IMAPFolder string `json:"imap_folder,omitempty"`
+
+	// PollInterval is how often the bridge checks both directions (default "1m").
+	PollInterval string `json:"poll_interval,omitempty"`
+}
+
+// MailServerConfig is one mail server endpoint.
+type MailServerConfig struct {
+	Host        string `json:"host"`
+	Port        int    `json:"port,omitempty"` // default 587 for SMTP, 993 for IMAP
+	Username    string `json:"username,omitempty"`
+	PasswordEnv string `json:"password_env,omitempty"` // env var holding the password
+}
+
+// Password returns the server password from its environment variable.
+func (s *MailServerConfig) Password() string {
+	if s.PasswordEnv == "" {
+		return ""
+	}
+	return os.Getenv(s.PasswordEnv)
+}
+
+// Interval returns the poll interval, defaulting to one minute.
+func (c *MailBridgeConfig) Interval() time.Duration {
+	if d, err := time.ParseDuration(c.PollInterval); err == nil && d > 0 {
+		return d
+	}
+	return time.Minute
+}
+
+// MailBridgeConfigPath returns the standard path for the mail bridge config in a town.
+func MailBridgeConfigPath(townRoot string) string {
+	return filepath.Join(townRoot, "settings", "mail-bridge.json")
+}
+
+// LoadMailBridgeConfig loads and validates a mail bridge configuration file.
+func LoadMailBridgeConfig(path string) (*MailBridgeConfig, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+		return nil, fmt.Errorf("reading mail bridge config: %w", err)
+	}
+
+	var config MailBridgeConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing mail bridge config: %w", err)
+	}
+
+	if err := validateMailBridgeConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// validateMailBridgeConfig validates a MailBridgeConfig.
+func validateMailBridgeConfig(c *MailBridgeConfig) error {
+	if c.Type != "mail-bridge" && c.Type != "" {
+		return fmt.Errorf("%w: expected type 'mail-bridge', got '%s'", ErrInvalidType, c.Type)
+	}
+	if c.Version > CurrentMailBridgeVersion {
+		return fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, c.Version, CurrentMailBridgeVersion)
+	}
+	if c.From == "" {
+		return fmt.Errorf("%w: from", ErrMissingField)
+	}
+	if c.SMTP.Host == "" {
+		return fmt.Errorf("%w: smtp.host", ErrMissingField)
+	}
+	if c.IMAP != nil && c.IMAP.Host == "" {
+		return fmt.Errorf("%w: imap.host", ErrMissingField)
+	}
+	if c.PollInterval != "" {
+		if _, err := time.ParseDuration(c.PollInterval); err != nil {
+			return fmt.Errorf("invalid poll_interval: %w", err)
+		}
+	}
+	return nil
+}
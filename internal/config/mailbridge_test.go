@@ -0,0 +1,53 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadMailBridgeConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail-bridge.json")
+	data := `{"type": "mail-bridge", "version": 1, "from": "bridge@example.com",
+		"smtp": {"host": "smtp.example.com", "password_env": "GT_TEST_SMTP_PASSWORD"},
+		"imap": {"host": "imap.example.com"}, "poll_interval": "30s"}`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GT_TEST_SMTP_PASSWORD", "hunter2")
+
+	cfg, err := LoadMailBridgeConfig(path)
+	if err != nil {
+		t.Fatalf("LoadMailBridgeConfig() error = %v", err)
+	}
+	if cfg.SMTP.Password() != "hunter2" || cfg.IMAP == nil || cfg.Interval() != 30*time.Second {
+		t.Errorf("loaded config = %+v", cfg)
+	}
+
+	if _, err := LoadMailBridgeConfig(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing file error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMailBridgeConfigValidation(t *testing.T) {
+	smtp := MailServerConfig{Host: "smtp.example.com"}
+	tests := []struct {
+		name string
+		cfg  MailBridgeConfig
+	}{
+		{"missing from", MailBridgeConfig{SMTP: smtp}},
+		{"missing smtp host", MailBridgeConfig{From: "b@example.com"}},
+		{"imap without host", MailBridgeConfig{From: "b@example.com", SMTP: smtp, IMAP: &MailServerConfig{}}},
+		{"bad poll interval", MailBridgeConfig{From: "b@example.com", SMTP: smtp, PollInterval: "soon"}},
+		{"wrong type", MailBridgeConfig{Type: "notifications", From: "b@example.com", SMTP: smtp}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateMailBridgeConfig(&tt.cfg); err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// CurrentNotificationsVersion is the current schema version for NotificationsConfig.
+const CurrentNotificationsVersion = 1
+
+// Notification sink types.
+const (
+	SinkSlack   = "slack"
+	SinkDiscord = "discord"
+	SinkWebhook = "webhook"
+	SinkEmail   = "email"
+	SinkDesktop = "desktop" // macOS Notification Center or notify-send
+	SinkBell    = "bell"    // terminal bell in attached gt tmux clients
+)
+
+// IsLocalSink reports whether a sink type notifies the person at this
+// machine rather than an external service. Local sinks also receive
+// notifications the daemon raises from the event log.
+func IsLocalSink(sinkType string) bool {
+	return sinkType == SinkDesktop || sinkType == SinkBell
+}
+
+// NotificationsConfig configures external notification sinks
+// (settings/notifications.json). Escalations, cost alerts, and refinery
+// results are delivered to every sink whose routing rules match. Desktop
+// and bell sinks also get mail, escalations, and molecule completions seen
+// in the event log.
+type NotificationsConfig struct {
+	Type    string `json:"type"`    // "notifications"
+	Version int    `json:"version"` // schema version
+
+	// Sinks maps a sink name to its configuration.
+	Sinks map[string]*NotificationSink `json:"sinks"`
+}
+
+// NotificationSink is a single external destination.
+type NotificationSink struct {
+	// Type is one of "slack", "discord", "webhook", "email", "desktop", "bell".
+	Type string `json:"type"`
+
+	// URL is the incoming-webhook URL (slack, discord, webhook).
+	URL string `json:"url,omitempty"`
+
+	// Headers are extra HTTP headers for generic webhooks (e.g., auth).
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Email settings (type "email").
+	To          []string `json:"to,omitempty"`
+	From        string   `json:"from,omitempty"`
+	SMTPHost    string   `json:"smtp_host,omitempty"`
+	SMTPPort    int      `json:"smtp_port,omitempty"`
+	Username    string   `json:"username,omitempty"`
+	PasswordEnv string   `json:"password_env,omitempty"` // env var holding the SMTP password
+
+	// Events limits the sink to these event types (empty = all).
+	Events []string `json:"events,omitempty"`
+
+	// MinSeverity drops notifications below this severity (low, medium, high, critical).
+	MinSeverity string `json:"min_severity,omitempty"`
+
+	// MaxPerHour caps deliveries to this sink (0 = unlimited).
+	MaxPerHour int `json:"max_per_hour,omitempty"`
+
+	// Disabled turns the sink off without removing it.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// NewNotificationsConfig creates a configuration with no sinks.
+func NewNotificationsConfig() *NotificationsConfig {
+	return &NotificationsConfig{
+		Type:    "notifications",
+		Version: CurrentNotificationsVersion,
+		Sinks:   make(map[string]*NotificationSink),
+	}
+}
+
+// NotificationsConfigPath returns the standard path for the notifications config in a town.
+func NotificationsConfigPath(townRoot string) string {
+	return filepath.Join(townRoot, "settings", "notifications.json")
+}
+
+// LoadNotificationsConfig loads and validates a notifications configuration file.
+func LoadNotificationsConfig(path string) (*NotificationsConfig, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+		return nil, fmt.Errorf("reading notifications config: %w", err)
+	}
+
+	var config NotificationsConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing notifications config: %w", err)
+	}
+
+	if err := validateNotificationsConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// LoadOrCreateNotificationsConfig loads the notifications config, returning an empty config if not found.
+func LoadOrCreateNotificationsConfig(path string) (*NotificationsConfig, error) {
+	config, err := LoadNotificationsConfig(path)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return NewNotificationsConfig(), nil
+		}
+		return nil, err
+	}
+	return config, nil
+}
+
+// SaveNotificationsConfig saves a notifications configuration to a file.
+// The file may contain webhook secrets, so it is written owner-only.
+func SaveNotificationsConfig(path string, config *NotificationsConfig) error {
+	if err := validateNotificationsConfig(config); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding notifications config: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("writing notifications config: %w", err)
+	}
+
+	return nil
+}
+
+// validateNotificationsConfig validates a NotificationsConfig.
+func validateNotificationsConfig(c *NotificationsConfig) error {
+	if c.Type != "notifications" && c.Type != "" {
+		return fmt.Errorf("%w: expected type 'notifications', got '%s'", ErrInvalidType, c.Type)
+	}
+	if c.Version > CurrentNotificationsVersion {
+		return fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, c.Version, CurrentNotificationsVersion)
+	}
+
+	if c.Sinks == nil {
+		c.Sinks = make(map[string]*NotificationSink)
+	}
+
+	for name, sink := range c.Sinks {
+		if sink == nil {
+			return fmt.Errorf("%w: sinks.%s", ErrMissingField, name)
+		}
+		if err := validateNotificationSink("sinks."+name, sink); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateNotificationSink validates one sink; field is its path in the
+// config file, used in error messages.
+func validateNotificationSink(field string, sink *NotificationSink) error {
+	switch sink.Type {
+	case SinkSlack, SinkDiscord, SinkWebhook:
+		if sink.URL == "" {
+			return fmt.Errorf("%w: %s.url", ErrMissingField, field)
+		}
+	case SinkEmail:
+		if len(sink.To) == 0 {
+			return fmt.Errorf("%w: %s.to", ErrMissingField, field)
+		}
+		if sink.SMTPHost == "" {
+			return fmt.Errorf("%w: %s.smtp_host", ErrMissingField, field)
+		}
+	case SinkDesktop, SinkBell:
+		// Nothing to configure
+	default:
+		return fmt.Errorf("%s: unknown sink type %q (want slack, discord, webhook, email, desktop, or bell)", field, sink.Type)
+	}
+	if sink.MinSeverity != "" && !IsValidSeverity(sink.MinSeverity) {
+		return fmt.Errorf("%s: invalid min_severity %q", field, sink.MinSeverity)
+	}
+	return nil
+}
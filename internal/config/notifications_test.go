@@ -0,0 +1,75 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNotificationsConfigRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings", "notifications.json")
+
+	cfg, err := LoadOrCreateNotificationsConfig(path)
+	if err != nil {
+		t.Fatalf("LoadOrCreateNotificationsConfig() error = %v", err)
+	}
+	if len(cfg.Sinks) != 0 {
+		t.Errorf("default config has %d sinks, want 0", len(cfg.Sinks))
+	}
+
+	cfg.Sinks["ops"] = &NotificationSink{Type: SinkSlack, URL: "https://hooks.example.com/x", MinSeverity: SeverityHigh}
+	if err := SaveNotificationsConfig(path, cfg); err != nil {
+		t.Fatalf("SaveNotificationsConfig() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("config mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	loaded, err := LoadNotificationsConfig(path)
+	if err != nil {
+		t.Fatalf("LoadNotificationsConfig() error = %v", err)
+	}
+	if sink := loaded.Sinks["ops"]; sink == nil || sink.MinSeverity != SeverityHigh {
+		t.Errorf("loaded sinks = %+v", loaded.Sinks)
+	}
+}
+
+func TestNotificationsConfigValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		sink *NotificationSink
+		want error
+	}{
+		{"slack without url", &NotificationSink{Type: SinkSlack}, ErrMissingField},
+		{"email without smtp host", &NotificationSink{Type: SinkEmail, To: []string{"a@example.com"}}, ErrMissingField},
+		{"email without recipients", &NotificationSink{Type: SinkEmail, SMTPHost: "smtp.example.com"}, ErrMissingField},
+		{"desktop", &NotificationSink{Type: SinkDesktop, MinSeverity: SeverityMedium}, nil},
+		{"bell", &NotificationSink{Type: SinkBell}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewNotificationsConfig()
+			cfg.Sinks["s"] = tt.sink
+			if err := validateNotificationsConfig(cfg); !errors.Is(err, tt.want) {
+				t.Errorf("validate error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	cfg := NewNotificationsConfig()
+	cfg.Sinks["s"] = &NotificationSink{Type: "pager"}
+	if err := validateNotificationsConfig(cfg); err == nil {
+		t.Error("expected error for unknown sink type")
+	}
+
+	cfg.Sinks["s"] = &NotificationSink{Type: SinkWebhook, URL: "https://example.com", MinSeverity: "urgent"}
+	if err := validateNotificationsConfig(cfg); err == nil {
+		t.Error("expected error for invalid min_severity")
+	}
+}
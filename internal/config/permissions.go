@@ -0,0 +1,259 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// CurrentPermissionsVersion is the current schema version for PermissionsConfig.
+const CurrentPermissionsVersion = 1
+
+// Guarded operations. Commands and API endpoints map onto these names;
+// permission rules match them with glob patterns (e.g. "session.*").
+const (
+	OpRigAdd          = "rig.add"
+	OpRigRemove       = "rig.remove"
+	OpRigDecommission = "rig.decommission" // park, dock, or shut down a rig
+	OpCrewAdd         = "crew.add"
+	OpCrewRemove      = "crew.remove"
+	OpCrewRename      = "crew.rename"
+	OpPolecatAdd      = "polecat.add"
+	OpPolecatRemove   = "polecat.remove"
+	OpPolecatNuke     = "polecat.nuke"
+	OpDogRemove       = "dog.remove"
+	OpSessionStart    = "session.start"
+	OpSessionStop     = "session.stop"
+	OpSessionRestart  = "session.restart"
+	OpSessionKill     = "session.kill"
+	OpSessionInject   = "session.inject"
+	OpMailSend        = "mail.send"
+	OpMailRead        = "mail.read" // read another identity's mailbox
+	OpTownShutdown    = "town.shutdown"
+	OpTownUninstall   = "town.uninstall"
+	OpAPIToken        = "api.token" // issue or revoke gt serve API tokens
+
+	// OpForce is needed, on top of a guarded command's own operation, to
+	// run it with --force and skip its safety checks.
+	OpForce = "force"
+)
+
+// RoleOverseer is the permission role for humans (no GT_ROLE in the environment).
+const RoleOverseer = "overseer"
+
+// PermissionsConfig maps identities and roles to allowed operations
+// (settings/permissions.json).
+type PermissionsConfig struct {
+	Type    string `json:"type"`    // "permissions"
+	Version int    `json:"version"` // schema version
+
+	// Roles maps role names (overseer, mayor, deacon, witness, refinery,
+	// polecat, crew) to their rules.
+	Roles map[string]*RolePermissions `json:"roles"`
+
+	// Identities maps agent addresses (e.g. "gastown/crew/joe") to a role
+	// whose rules apply instead of the agent's own role.
+	Identities map[string]string `json:"identities,omitempty"`
+}
+
+// RolePermissions lists operation patterns a role may and may not perform.
+// Deny rules take precedence over allow rules.
+type RolePermissions struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// NewPermissionsConfig returns the default permission policy:
+// the overseer can do anything, only the overseer can remove rigs or tear
+// down the town or issue API tokens, agents cannot delete each other's
+// workspaces or read each other's mail, and the witness can restart
+// sessions and clean up polecats but not delete crew.
+// Only the overseer, mayor, deacon, and witness may --force.
+func NewPermissionsConfig() *PermissionsConfig {
+	return &PermissionsConfig{
+		Type:    "permissions",
+		Version: CurrentPermissionsVersion,
+		Roles: map[string]*RolePermissions{
+			RoleOverseer: {Allow: []string{"*"}},
+			"mayor": {
+				Allow: []string{"*"},
+				Deny:  []string{OpRigRemove, "town.*", OpAPIToken},
+			},
+			"deacon": {
+				Allow: []string{"session.*", "mail.*", OpPolecatNuke, OpDogRemove, OpForce},
+				Deny:  []string{OpCrewRemove, "rig.*", "town.*"},
+			},
+			"witness": {
+				Allow: []string{"session.*", "mail.*", OpPolecatNuke, OpForce},
+				Deny:  []string{OpCrewRemove, "rig.*", "town.*"},
+			},
+			"refinery": {
+				Allow: []string{"mail.*", OpSessionInject},
+				Deny:  []string{OpMailRead},
+			},
+			"crew": {
+				Allow: []string{"session.*", "mail.*", OpPolecatAdd, OpCrewAdd},
+				Deny:  []string{OpSessionKill, OpMailRead},
+			},
+			"polecat": {
+				Allow: []string{"mail.*", OpSessionInject},
+				Deny:  []string{OpMailRead},
+			},
+		},
+		Identities: make(map[string]string),
+	}
+}
+
+// PermissionsConfigPath returns the standard path for the permissions config in a town.
+func PermissionsConfigPath(townRoot string) string {
+	return filepath.Join(townRoot, "settings", "permissions.json")
+}
+
+// LoadPermissionsConfig loads and validates a permissions configuration file.
+func LoadPermissionsConfig(path string) (*PermissionsConfig, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+		return nil, fmt.Errorf("reading permissions config: %w", err)
+	}
+
+	var config PermissionsConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing permissions config: %w", err)
+	}
+
+	if err := validatePermissionsConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// LoadOrCreatePermissionsConfig loads the permissions config, returning the default policy if not found.
+func LoadOrCreatePermissionsConfig(path string) (*PermissionsConfig, error) {
+	config, err := LoadPermissionsConfig(path)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return NewPermissionsConfig(), nil
+		}
+		return nil, err
+	}
+	return config, nil
+}
+
+// SavePermissionsConfig saves a permissions configuration to a file.
+func SavePermissionsConfig(path string, config *PermissionsConfig) error {
+	if err := validatePermissionsConfig(config); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding permissions config: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: permissions config doesn't contain secrets
+		return fmt.Errorf("writing permissions config: %w", err)
+	}
+
+	return nil
+}
+
+// validatePermissionsConfig validates a PermissionsConfig.
+func validatePermissionsConfig(c *PermissionsConfig) error {
+	if c.Type != "permissions" && c.Type != "" {
+		return fmt.Errorf("%w: expected type 'permissions', got '%s'", ErrInvalidType, c.Type)
+	}
+	if c.Version > CurrentPermissionsVersion {
+		return fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, c.Version, CurrentPermissionsVersion)
+	}
+
+	if c.Roles == nil {
+		c.Roles = make(map[string]*RolePermissions)
+	}
+	if c.Identities == nil {
+		c.Identities = make(map[string]string)
+	}
+
+	for role, rules := range c.Roles {
+		if rules == nil {
+			continue
+		}
+		for _, pattern := range append(append([]string{}, rules.Allow...), rules.Deny...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid pattern %q for role %s: %w", pattern, role, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// EffectiveRole returns the role whose rules apply to an identity,
+// honoring per-identity overrides.
+func (c *PermissionsConfig) EffectiveRole(identity, role string) string {
+	if override, ok := c.Identities[identity]; ok && override != "" {
+		return override
+	}
+	return role
+}
+
+// Allows reports whether the identity (acting in role) may perform op.
+// Roles without rules are denied everything.
+func (c *PermissionsConfig) Allows(identity, role, op string) bool {
+	rules, ok := c.Roles[c.EffectiveRole(identity, role)]
+	if !ok || rules == nil {
+		return false
+	}
+	for _, pattern := range rules.Deny {
+		if matchOperation(pattern, op) {
+			return false
+		}
+	}
+	for _, pattern := range rules.Allow {
+		if matchOperation(pattern, op) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchOperation reports whether an operation matches a rule pattern.
+func matchOperation(pattern, op string) bool {
+	ok, err := path.Match(pattern, op)
+	return err == nil && ok
+}
+
+// RoleForAddress derives the permission role from an agent address
+// ("mayor/", "gastown/witness", "gastown/crew/joe", "gastown/polecats/toast").
+// Empty addresses and "overseer" map to the overseer role.
+func RoleForAddress(address string) string {
+	addr := strings.TrimSuffix(address, "/")
+	switch addr {
+	case "", RoleOverseer, "human":
+		return RoleOverseer
+	case "mayor", "deacon":
+		return addr
+	}
+
+	parts := strings.Split(addr, "/")
+	if len(parts) < 2 {
+		return addr
+	}
+	switch parts[1] {
+	case "witness", "refinery", "crew":
+		return parts[1]
+	default:
+		return "polecat"
+	}
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultPermissions(t *testing.T) {
+	c := NewPermissionsConfig()
+
+	tests := []struct {
+		identity string
+		op       string
+		want     bool
+	}{
+		{"overseer", OpRigRemove, true},
+		{"overseer", OpTownUninstall, true},
+		{"mayor/", OpCrewRemove, true},
+		{"mayor/", OpRigRemove, false},
+		{"gastown/witness", OpSessionRestart, true},
+		{"gastown/witness", OpPolecatNuke, true},
+		{"gastown/witness", OpCrewRemove, false},
+		{"gastown/crew/joe", OpSessionRestart, true},
+		{"gastown/crew/joe", OpSessionKill, false},
+		{"gastown/crew/joe", OpRigRemove, false},
+		{"gastown/polecats/toast", OpCrewRemove, false},
+		{"gastown/polecats/toast", OpMailSend, true},
+		{"gastown/polecats/toast", OpSessionInject, true},
+		{"mayor/", OpRigDecommission, true},
+		{"gastown/witness", OpRigDecommission, false},
+		{"gastown/witness", OpForce, true},
+		{"deacon/", OpDogRemove, true},
+		{"gastown/crew/joe", OpForce, false},
+		{"gastown/polecats/toast", OpForce, false},
+	}
+	for _, tt := range tests {
+		if got := c.Allows(tt.identity, RoleForAddress(tt.identity), tt.op); got != tt.want {
+			t.Errorf("Allows(%q, %q) = %v, want %v", tt.identity, tt.op, got, tt.want)
+		}
+	}
+}
+
+func TestPermissionsIdentityOverride(t *testing.T) {
+	c := NewPermissionsConfig()
+	c.Identities["gastown/crew/lead"] = RoleOverseer
+
+	if !c.Allows("gastown/crew/lead", "crew", OpRigRemove) {
+		t.Error("identity override to overseer should allow rig.remove")
+	}
+	if c.Allows("gastown/crew/joe", "crew", OpRigRemove) {
+		t.Error("crew without override should not allow rig.remove")
+	}
+	if c.Allows("x", "unknown-role", OpMailSend) {
+		t.Error("unknown role should be denied")
+	}
+}
+
+func TestRoleForAddress(t *testing.T) {
+	tests := map[string]string{
+		"":                       RoleOverseer,
+		"overseer":               RoleOverseer,
+		"mayor/":                 "mayor",
+		"deacon":                 "deacon",
+		"gastown/witness":        "witness",
+		"gastown/refinery":       "refinery",
+		"gastown/crew/joe":       "crew",
+		"gastown/polecats/toast": "polecat",
+		"gastown/toast":          "polecat",
+	}
+	for addr, want := range tests {
+		if got := RoleForAddress(addr); got != want {
+			t.Errorf("RoleForAddress(%q) = %q, want %q", addr, got, want)
+		}
+	}
+}
+
+func TestPermissionsConfigRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings", "permissions.json")
+
+	if _, err := LoadPermissionsConfig(path); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("LoadPermissionsConfig() on missing file error = %v, want ErrNotFound", err)
+	}
+
+	c := NewPermissionsConfig()
+	c.Roles["polecat"].Deny = []string{"mail.*"}
+	if err := SavePermissionsConfig(path, c); err != nil {
+		t.Fatalf("SavePermissionsConfig() error = %v", err)
+	}
+
+	loaded, err := LoadPermissionsConfig(path)
+	if err != nil {
+		t.Fatalf("LoadPermissionsConfig() error = %v", err)
+	}
+	if loaded.Allows("gastown/polecats/toast", "polecat", OpMailSend) {
+		t.Error("loaded config should deny mail.send for polecats")
+	}
+
+	c.Roles["bad"] = &RolePermissions{Allow: []string{"["}}
+	if err := SavePermissionsConfig(path, c); err == nil {
+		t.Error("SavePermissionsConfig() should reject malformed patterns")
+	}
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultAgentEmailDomain is the default domain for agent git emails.
+const DefaultAgentEmailDomain = "gastown.local"
+
+// Layer names where a resolved setting came from.
+type Layer string
+
+// Config layers, lowest precedence first.
+const (
+	LayerDefault   Layer = "default"
+	LayerTown      Layer = "town"
+	LayerRig       Layer = "rig"
+	LayerWorkspace Layer = "workspace"
+	LayerEnv       Layer = "env"
+)
+
+// Scope locates the config layers that apply to a command.
+type Scope struct {
+	TownRoot      string
+	RigPath       string // "" for town-level commands
+	WorkspacePath string // crew or polecat workspace, "" if none
+}
+
+// ScopeFromPath derives the scope for a path inside a town: the rig is the
+// first directory under the town root that has a rig config.json, and the
+// workspace is a crew/<name> or polecats/<name> directory within it.
+func ScopeFromPath(townRoot, path string) Scope {
+	scope := Scope{TownRoot: townRoot}
+
+	rel, err := filepath.Rel(townRoot, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return scope
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+
+	rigPath := filepath.Join(townRoot, parts[0])
+	if _, err := os.Stat(filepath.Join(rigPath, "config.json")); err != nil {
+		return scope
+	}
+	scope.RigPath = rigPath
+
+	if len(parts) >= 3 && (parts[1] == "crew" || parts[1] == "polecats") {
+		scope.WorkspacePath = filepath.Join(rigPath, parts[1], parts[2])
+	}
+	return scope
+}
+
+// Setting is a value that can be set at any config layer. Each layer may
+// set it in its "overrides" map; town and rig settings may also have a
+// dedicated field for it, which the overrides map takes precedence over.
+type Setting struct {
+	Key         string
+	Default     string
+	Description string
+
+	town func(*TownSettings) string // dedicated town field, if any
+	rig  func(*RigSettings) string  // dedicated rig field, if any
+}
+
+// Env returns the environment variable that overrides the setting,
+// e.g. GT_DEFAULT_AGENT for default_agent.
+func (s *Setting) Env() string {
+	return "GT_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(s.Key))
+}
+
+// Settings lists the keys Resolve understands.
+var Settings = []*Setting{
+	{
+		Key:         "default_agent",
+		Default:     "claude",
+		Description: "Agent preset used when no role-specific agent is configured",
+		town:        func(s *TownSettings) string { return s.DefaultAgent },
+		rig:         func(s *RigSettings) string { return s.Agent },
+	},
+	{
+		Key:         "agent_email_domain",
+		Default:     DefaultAgentEmailDomain,
+		Description: "Domain used for agent git commit emails",
+		town:        func(s *TownSettings) string { return s.AgentEmailDomain },
+	},
+	{
+		Key:         "tmux_socket",
+		Default:     "gastown",
+		Description: `tmux socket (tmux -L) for the town's sessions; "default" for tmux's default server`,
+		town:        func(s *TownSettings) string { return s.TmuxSocket },
+	},
+}
+
+// LookupSetting returns the setting for key, or nil if there is none.
+func LookupSetting(key string) *Setting {
+	for _, s := range Settings {
+		if s.Key == key {
+			return s
+		}
+	}
+	return nil
+}
+
+// ResolvedValue is the effective value of a setting and where it was set.
+type ResolvedValue struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Layer  Layer  `json:"layer"`
+	Source string `json:"source,omitempty"` // File or environment variable that set it
+}
+
+// layers holds the loaded config layers of a scope. Missing or unreadable
+// layers are nil.
+type layers struct {
+	scope     Scope
+	town      *TownSettings
+	rig       *RigSettings
+	workspace *WorkspaceSettings
+}
+
+func loadLayers(scope Scope) *layers {
+	l := &layers{scope: scope}
+	if scope.TownRoot != "" {
+		// LoadOrCreateTownSettings fills in defaults for a missing file,
+		// which would shadow the setting defaults.
+		if _, err := os.Stat(TownSettingsPath(scope.TownRoot)); err == nil {
+			l.town, _ = LoadOrCreateTownSettings(TownSettingsPath(scope.TownRoot))
+		}
+	}
+	if scope.RigPath != "" {
+		l.rig, _ = LoadRigSettings(RigSettingsPath(scope.RigPath))
+	}
+	if scope.WorkspacePath != "" {
+		l.workspace, _ = LoadWorkspaceSettings(WorkspaceSettingsPath(scope.WorkspacePath))
+	}
+	return l
+}
+
+// resolve applies the precedence chain to one setting:
+// environment > workspace > rig > town > default.
+func (l *layers) resolve(s *Setting) ResolvedValue {
+	v := ResolvedValue{Key: s.Key}
+
+	if env := os.Getenv(s.Env()); env != "" {
+		v.Value, v.Layer, v.Source = env, LayerEnv, s.Env()
+		return v
+	}
+	if l.workspace != nil {
+		if val := l.workspace.Overrides[s.Key]; val != "" {
+			v.Value, v.Layer, v.Source = val, LayerWorkspace, WorkspaceSettingsPath(l.scope.WorkspacePath)
+			return v
+		}
+	}
+	if l.rig != nil {
+		if val := layerValue(l.rig.Overrides, s.Key, s.rig, l.rig); val != "" {
+			v.Value, v.Layer, v.Source = val, LayerRig, RigSettingsPath(l.scope.RigPath)
+			return v
+		}
+	}
+	if l.town != nil {
+		if val := layerValue(l.town.Overrides, s.Key, s.town, l.town); val != "" {
+			v.Value, v.Layer, v.Source = val, LayerTown, TownSettingsPath(l.scope.TownRoot)
+			return v
+		}
+	}
+
+	v.Value, v.Layer = s.Default, LayerDefault
+	return v
+}
+
+// layerValue returns a layer's value for key: its overrides entry, else
+// its dedicated field.
+func layerValue[T any](overrides map[string]string, key string, field func(*T) string, settings *T) string {
+	if val := overrides[key]; val != "" {
+		return val
+	}
+	if field != nil {
+		return field(settings)
+	}
+	return ""
+}
+
+// Resolve returns the effective value of a setting for a scope, applying
+// GT_* environment variables, then workspace, rig and town settings, then
+// the built-in default.
+func Resolve(scope Scope, key string) (ResolvedValue, error) {
+	s := LookupSetting(key)
+	if s == nil {
+		return ResolvedValue{}, fmt.Errorf("unknown setting %q", key)
+	}
+	return loadLayers(scope).resolve(s), nil
+}
+
+// ResolveString returns the effective value of a setting, or "" if the
+// key is unknown.
+func ResolveString(scope Scope, key string) string {
+	v, err := Resolve(scope, key)
+	if err != nil {
+		return ""
+	}
+	return v.Value
+}
+
+// ResolveAll returns the effective value of every setting, sorted by key.
+func ResolveAll(scope Scope) []ResolvedValue {
+	l := loadLayers(scope)
+	values := make([]ResolvedValue, 0, len(Settings))
+	for _, s := range Settings {
+		values = append(values, l.resolve(s))
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Key < values[j].Key })
+	return values
+}
+
+// settingDefaultAgent resolves default_agent from already-loaded town and
+// rig settings, for the agent resolvers that load them anyway.
+func settingDefaultAgent(townRoot, rigPath string, townSettings *TownSettings, rigSettings *RigSettings) string {
+	l := &layers{scope: Scope{TownRoot: townRoot, RigPath: rigPath}, town: townSettings, rig: rigSettings}
+	return l.resolve(LookupSetting("default_agent")).Value
+}
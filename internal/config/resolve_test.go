@@ -0,0 +1,116 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setupResolveTown creates a town with a gastown rig and a crew workspace.
+func setupResolveTown(t *testing.T) (townRoot, rigPath, crewPath string) {
+	t.Helper()
+	townRoot = t.TempDir()
+	rigPath = filepath.Join(townRoot, "gastown")
+	crewPath = filepath.Join(rigPath, "crew", "max")
+	if err := os.MkdirAll(crewPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveRigConfig(filepath.Join(rigPath, "config.json"), NewRigConfig("gastown", "")); err != nil {
+		t.Fatal(err)
+	}
+	return townRoot, rigPath, crewPath
+}
+
+func TestScopeFromPath(t *testing.T) {
+	t.Parallel()
+	townRoot, rigPath, crewPath := setupResolveTown(t)
+
+	tests := []struct {
+		path string
+		want Scope
+	}{
+		{townRoot, Scope{TownRoot: townRoot}},
+		{filepath.Join(townRoot, "mayor"), Scope{TownRoot: townRoot}},
+		{filepath.Join(rigPath, "refinery", "rig"), Scope{TownRoot: townRoot, RigPath: rigPath}},
+		{filepath.Join(crewPath, "src", "pkg"), Scope{TownRoot: townRoot, RigPath: rigPath, WorkspacePath: crewPath}},
+	}
+	for _, tt := range tests {
+		if got := ScopeFromPath(townRoot, tt.path); got != tt.want {
+			t.Errorf("ScopeFromPath(%s) = %+v, want %+v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestResolvePrecedence(t *testing.T) {
+	// Not parallel: sets GT_AGENT_EMAIL_DOMAIN
+	townRoot, rigPath, crewPath := setupResolveTown(t)
+	scope := Scope{TownRoot: townRoot, RigPath: rigPath, WorkspacePath: crewPath}
+
+	check := func(key, wantValue string, wantLayer Layer) {
+		t.Helper()
+		got, err := Resolve(scope, key)
+		if err != nil {
+			t.Fatalf("Resolve(%s): %v", key, err)
+		}
+		if got.Value != wantValue || got.Layer != wantLayer {
+			t.Errorf("Resolve(%s) = %q from %s, want %q from %s", key, got.Value, got.Layer, wantValue, wantLayer)
+		}
+	}
+
+	check("agent_email_domain", DefaultAgentEmailDomain, LayerDefault)
+
+	town := NewTownSettings()
+	town.AgentEmailDomain = "town.example"
+	if err := SaveTownSettings(TownSettingsPath(townRoot), town); err != nil {
+		t.Fatal(err)
+	}
+	check("agent_email_domain", "town.example", LayerTown)
+
+	rig := NewRigSettings()
+	rig.Overrides = map[string]string{"agent_email_domain": "rig.example"}
+	rig.Agent = "gemini"
+	if err := SaveRigSettings(RigSettingsPath(rigPath), rig); err != nil {
+		t.Fatal(err)
+	}
+	check("agent_email_domain", "rig.example", LayerRig)
+	check("default_agent", "gemini", LayerRig)
+
+	ws := &WorkspaceSettings{Overrides: map[string]string{"agent_email_domain": "crew.example"}}
+	if err := SaveWorkspaceSettings(WorkspaceSettingsPath(crewPath), ws); err != nil {
+		t.Fatal(err)
+	}
+	check("agent_email_domain", "crew.example", LayerWorkspace)
+
+	t.Setenv("GT_AGENT_EMAIL_DOMAIN", "env.example")
+	check("agent_email_domain", "env.example", LayerEnv)
+
+	// Town-level scopes skip the rig and workspace layers
+	if got := ResolveString(Scope{TownRoot: townRoot}, "default_agent"); got != "claude" {
+		t.Errorf("town-level default_agent = %q, want town's claude", got)
+	}
+
+	if _, err := Resolve(scope, "no_such_key"); err == nil {
+		t.Error("Resolve(unknown key) succeeded")
+	}
+}
+
+func TestResolveAgentConfigEnvOverride(t *testing.T) {
+	townRoot, rigPath, _ := setupResolveTown(t)
+	rig := NewRigSettings()
+	rig.Agent = "gemini"
+	if err := SaveRigSettings(RigSettingsPath(rigPath), rig); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ResolveAgentConfig(townRoot, rigPath).Command; got != "gemini" {
+		t.Errorf("rig agent command = %q, want gemini", got)
+	}
+
+	t.Setenv("GT_DEFAULT_AGENT", "codex")
+	if got := ResolveAgentConfig(townRoot, rigPath).Command; got != "codex" {
+		t.Errorf("GT_DEFAULT_AGENT command = %q, want codex", got)
+	}
+	if name, _ := ResolveRoleAgentName("polecat", townRoot, rigPath); name != "codex" {
+		t.Errorf("ResolveRoleAgentName = %q, want codex", name)
+	}
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CurrentScheduleVersion is the current schema version for ScheduleConfig.
+const CurrentScheduleVersion = 1
+
+// DefaultScheduleJobTimeout bounds a scheduled job that sets no timeout.
+const DefaultScheduleJobTimeout = 30 * time.Minute
+
+// ScheduleConfig defines recurring jobs run by the daemon
+// (settings/schedule.json).
+type ScheduleConfig struct {
+	Type    string `json:"type"`    // "schedule"
+	Version int    `json:"version"` // schema version
+
+	// Jobs maps a job name to its definition.
+	Jobs map[string]*ScheduledJob `json:"jobs"`
+}
+
+// ScheduledJob is a single recurring task. Exactly one of Cron or Every
+// must be set.
+type ScheduledJob struct {
+	// Description is shown by 'gt schedule list'.
+	Description string `json:"description,omitempty"`
+
+	// Cron is a 5-field cron expression ("0 3 * * *") or a macro
+	// (@hourly, @daily, @weekly, @monthly), in local time.
+	Cron string `json:"cron,omitempty"`
+
+	// Every is a fixed interval (Go duration, e.g. "30m", "6h").
+	Every string `json:"every,omitempty"`
+
+	// Command is run with 'sh -c' from the town root (or Dir).
+	Command string `json:"command"`
+
+	// Dir is the working directory, relative to the town root.
+	Dir string `json:"dir,omitempty"`
+
+	// Timeout kills the job after this long (Go duration, default 30m).
+	Timeout string `json:"timeout,omitempty"`
+
+	// Disabled pauses the job without removing it.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// Spec returns the job's schedule expression for display.
+func (j *ScheduledJob) Spec() string {
+	if j.Cron != "" {
+		return j.Cron
+	}
+	return "every " + j.Every
+}
+
+// TimeoutDuration returns the job timeout, falling back to the default.
+func (j *ScheduledJob) TimeoutDuration() time.Duration {
+	if d, err := time.ParseDuration(j.Timeout); err == nil && d > 0 {
+		return d
+	}
+	return DefaultScheduleJobTimeout
+}
+
+// NewScheduleConfig creates a configuration with no jobs.
+func NewScheduleConfig() *ScheduleConfig {
+	return &ScheduleConfig{
+		Type:    "schedule",
+		Version: CurrentScheduleVersion,
+		Jobs:    make(map[string]*ScheduledJob),
+	}
+}
+
+// ScheduleConfigPath returns the standard path for the schedule config in a town.
+func ScheduleConfigPath(townRoot string) string {
+	return filepath.Join(townRoot, "settings", "schedule.json")
+}
+
+// LoadScheduleConfig loads and validates a schedule configuration file.
+func LoadScheduleConfig(path string) (*ScheduleConfig, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+		return nil, fmt.Errorf("reading schedule config: %w", err)
+	}
+
+	var config ScheduleConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing schedule config: %w", err)
+	}
+
+	if err := validateScheduleConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// LoadOrCreateScheduleConfig loads the schedule config, returning an empty config if not found.
+func LoadOrCreateScheduleConfig(path string) (*ScheduleConfig, error) {
+	config, err := LoadScheduleConfig(path)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return NewScheduleConfig(), nil
+		}
+		return nil, err
+	}
+	return config, nil
+}
+
+// SaveScheduleConfig saves a schedule configuration to a file.
+func SaveScheduleConfig(path string, config *ScheduleConfig) error {
+	if err := validateScheduleConfig(config); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding schedule config: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: schedule config is non-sensitive
+		return fmt.Errorf("writing schedule config: %w", err)
+	}
+
+	return nil
+}
+
+// validateScheduleConfig validates a ScheduleConfig. Cron expressions are
+// parsed by the schedule package; here we only check structure.
+func validateScheduleConfig(c *ScheduleConfig) error {
+	if c.Type != "schedule" && c.Type != "" {
+		return fmt.Errorf("%w: expected type 'schedule', got '%s'", ErrInvalidType, c.Type)
+	}
+	if c.Version > CurrentScheduleVersion {
+		return fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, c.Version, CurrentScheduleVersion)
+	}
+
+	if c.Jobs == nil {
+		c.Jobs = make(map[string]*ScheduledJob)
+	}
+
+	for name, job := range c.Jobs {
+		if job == nil {
+			return fmt.Errorf("%w: jobs.%s", ErrMissingField, name)
+		}
+		if job.Command == "" {
+			return fmt.Errorf("%w: jobs.%s.command", ErrMissingField, name)
+		}
+		switch {
+		case job.Cron == "" && job.Every == "":
+			return fmt.Errorf("%w: jobs.%s.cron or jobs.%s.every", ErrMissingField, name, name)
+		case job.Cron != "" && job.Every != "":
+			return fmt.Errorf("jobs.%s: set only one of cron or every", name)
+		}
+		if job.Every != "" {
+			d, err := time.ParseDuration(job.Every)
+			if err != nil {
+				return fmt.Errorf("jobs.%s.every: %w", name, err)
+			}
+			if d < time.Minute {
+				return fmt.Errorf("jobs.%s.every: interval must be at least 1m", name)
+			}
+		}
+		if job.Timeout != "" {
+			if _, err := time.ParseDuration(job.Timeout); err != nil {
+				return fmt.Errorf("jobs.%s.timeout: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
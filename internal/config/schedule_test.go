@@ -0,0 +1,62 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScheduleConfigRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings", "schedule.json")
+
+	cfg, err := LoadOrCreateScheduleConfig(path)
+	if err != nil {
+		t.Fatalf("LoadOrCreateScheduleConfig() error = %v", err)
+	}
+	cfg.Jobs["nightly"] = &ScheduledJob{Cron: "0 3 * * *", Command: "gt crew pristine", Timeout: "5m"}
+	if err := SaveScheduleConfig(path, cfg); err != nil {
+		t.Fatalf("SaveScheduleConfig() error = %v", err)
+	}
+
+	loaded, err := LoadScheduleConfig(path)
+	if err != nil {
+		t.Fatalf("LoadScheduleConfig() error = %v", err)
+	}
+	job := loaded.Jobs["nightly"]
+	if job == nil || job.Spec() != "0 3 * * *" || job.TimeoutDuration() != 5*time.Minute {
+		t.Errorf("loaded job = %+v", job)
+	}
+}
+
+func TestScheduleConfigValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		job  *ScheduledJob
+	}{
+		{"no command", &ScheduledJob{Every: "1h"}},
+		{"no schedule", &ScheduledJob{Command: "true"}},
+		{"both schedules", &ScheduledJob{Cron: "@daily", Every: "1h", Command: "true"}},
+		{"bad interval", &ScheduledJob{Every: "often", Command: "true"}},
+		{"interval too short", &ScheduledJob{Every: "10s", Command: "true"}},
+		{"bad timeout", &ScheduledJob{Every: "1h", Command: "true", Timeout: "soon"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewScheduleConfig()
+			cfg.Jobs["j"] = tt.job
+			if err := validateScheduleConfig(cfg); err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+
+	cfg := NewScheduleConfig()
+	cfg.Jobs["j"] = &ScheduledJob{Every: "1h"}
+	if err := validateScheduleConfig(cfg); !errors.Is(err, ErrMissingField) {
+		t.Errorf("missing command error = %v, want ErrMissingField", err)
+	}
+	if d := (&ScheduledJob{}).TimeoutDuration(); d != DefaultScheduleJobTimeout {
+		t.Errorf("default timeout = %v", d)
+	}
+}
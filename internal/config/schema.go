@@ -0,0 +1,328 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Migration upgrades a decoded config document by one schema version, in
+// place. The document's version field is bumped after it runs.
+type Migration func(doc map[string]interface{}) error
+
+// Schema describes a versioned config file: the version the code writes
+// and the migrations that bring older files up to it.
+type Schema struct {
+	Name       string            // Human-readable name, e.g. "rigs registry"
+	Type       string            // Value of the file's "type" field, "" if it has none
+	Current    int               // Version written by this code
+	Migrations map[int]Migration // Keyed by the version they upgrade from
+
+	// decode parses an upgraded document into its struct and validates it.
+	decode func(data []byte, strict bool) error
+}
+
+// Config file schemas.
+var (
+	TownConfigSchema = &Schema{
+		Name:    "town config",
+		Type:    "town",
+		Current: CurrentTownVersion,
+		Migrations: map[int]Migration{
+			0: stampType("town"),
+			1: noChange, // v2 added the optional owner and public_name fields
+		},
+		decode: decodeWith(validateTownConfig),
+	}
+	RigsConfigSchema = &Schema{
+		Name:       "rigs registry",
+		Current:    CurrentRigsVersion,
+		Migrations: map[int]Migration{0: noChange},
+		decode:     decodeWith(validateRigsConfig),
+	}
+	RigConfigSchema = &Schema{
+		Name:       "rig config",
+		Type:       "rig",
+		Current:    CurrentRigConfigVersion,
+		Migrations: map[int]Migration{0: stampType("rig")},
+		decode:     decodeWith(validateRigConfig),
+	}
+	RigSettingsSchema = &Schema{
+		Name:       "rig settings",
+		Type:       "rig-settings",
+		Current:    CurrentRigSettingsVersion,
+		Migrations: map[int]Migration{0: stampType("rig-settings")},
+		decode:     decodeWith(validateRigSettings),
+	}
+	TownSettingsSchema = &Schema{
+		Name:       "town settings",
+		Type:       "town-settings",
+		Current:    CurrentTownSettingsVersion,
+		Migrations: map[int]Migration{0: stampType("town-settings")},
+		decode:     decodeWith(validateTownSettings),
+	}
+	WorkspaceSettingsSchema = &Schema{
+		Name:       "workspace settings",
+		Type:       "workspace-settings",
+		Current:    CurrentWorkspaceSettingsVersion,
+		Migrations: map[int]Migration{0: stampType("workspace-settings")},
+		decode:     decodeWith(validateWorkspaceSettings),
+	}
+)
+
+// noChange is a migration for versions that only added optional fields.
+func noChange(map[string]interface{}) error { return nil }
+
+// stampType returns a migration that fills in a missing type field.
+func stampType(typ string) Migration {
+	return func(doc map[string]interface{}) error {
+		if t, ok := doc["type"].(string); !ok || t == "" {
+			doc["type"] = typ
+		}
+		return nil
+	}
+}
+
+// decodeWith returns a decoder for the config struct T checked by validate.
+// Strict decoding rejects fields T does not know.
+func decodeWith[T any](validate func(*T) error) func([]byte, bool) error {
+	return func(data []byte, strict bool) error {
+		var v T
+		dec := json.NewDecoder(bytes.NewReader(data))
+		if strict {
+			dec.DisallowUnknownFields()
+		}
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		return validate(&v)
+	}
+}
+
+// Migrate upgrades a config document to the schema's current version.
+// It returns the upgraded document, or nil if data is already current,
+// along with the version data was at.
+func (s *Schema) Migrate(data []byte) (upgraded []byte, from int, err error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, 0, err
+	}
+	if doc == nil {
+		return nil, 0, fmt.Errorf("%s is not a JSON object", s.Name)
+	}
+
+	if v, ok := doc["version"]; ok {
+		f, isNum := v.(float64)
+		if !isNum || f != float64(int(f)) {
+			return nil, 0, fmt.Errorf("%w: version must be an integer, got %v", ErrInvalidVersion, v)
+		}
+		from = int(f)
+	}
+	if from > s.Current {
+		return nil, from, fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, from, s.Current)
+	}
+	if from == s.Current {
+		return nil, from, nil
+	}
+
+	for v := from; v < s.Current; v++ {
+		migrate, ok := s.Migrations[v]
+		if !ok {
+			return nil, from, fmt.Errorf("%w: no migration from %s version %d", ErrInvalidVersion, s.Name, v)
+		}
+		if err := migrate(doc); err != nil {
+			return nil, from, fmt.Errorf("migrating %s from version %d: %w", s.Name, v, err)
+		}
+		doc["version"] = v + 1
+	}
+
+	upgraded, err = json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, from, fmt.Errorf("encoding %s: %w", s.Name, err)
+	}
+	return upgraded, from, nil
+}
+
+// readVersioned reads a config file and upgrades it to the schema's
+// current version. An upgraded file that validates is written back
+// atomically; if that fails (e.g., read-only town) the upgraded document
+// is still returned.
+func readVersioned(path string, s *Schema) ([]byte, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: config paths are constructed internally
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	upgraded, _, err := s.Migrate(data)
+	if err != nil {
+		return nil, describeConfigError(path, data, err)
+	}
+	if upgraded == nil {
+		return data, nil
+	}
+	if err := s.decode(upgraded, false); err != nil {
+		return nil, describeConfigError(path, upgraded, err)
+	}
+
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	_ = util.AtomicWriteFile(path, upgraded, perm)
+	return upgraded, nil
+}
+
+// ConfigError locates a problem in a config file.
+type ConfigError struct {
+	Path   string
+	Line   int    // 1-based; 0 if unknown
+	Column int    // 1-based; 0 if unknown
+	Field  string // Dotted path of the offending field, if known
+	Err    error
+}
+
+func (e *ConfigError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.Path)
+	if e.Line > 0 {
+		fmt.Fprintf(&sb, ":%d:%d", e.Line, e.Column)
+	}
+	sb.WriteString(": ")
+	if e.Field != "" {
+		fmt.Fprintf(&sb, "field %s: ", e.Field)
+	}
+	sb.WriteString(e.Err.Error())
+	return sb.String()
+}
+
+func (e *ConfigError) Unwrap() error { return e.Err }
+
+// describeConfigError turns JSON decoding errors into a ConfigError that
+// points at the offending line and field instead of a byte offset.
+func describeConfigError(path string, data []byte, err error) error {
+	ce := &ConfigError{Path: path, Err: err}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		ce.Line, ce.Column = lineColumn(data, syntaxErr.Offset)
+		ce.Err = errors.New(syntaxErr.Error())
+	case errors.As(err, &typeErr):
+		ce.Line, ce.Column = lineColumn(data, typeErr.Offset)
+		ce.Field = typeErr.Field
+		ce.Err = fmt.Errorf("expected %s, got JSON %s", typeErr.Type, typeErr.Value)
+	}
+	return ce
+}
+
+// lineColumn converts a byte offset into a 1-based line and column.
+func lineColumn(data []byte, offset int64) (line, column int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+// ValidationReport is the result of validating one config file.
+type ValidationReport struct {
+	Path     string   `json:"path"`
+	Schema   string   `json:"schema"`
+	Version  int      `json:"version"`
+	Current  int      `json:"current"`
+	Missing  bool     `json:"missing,omitempty"`
+	Error    string   `json:"error,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// NeedsMigration reports whether the file is older than the schema.
+func (r *ValidationReport) NeedsMigration() bool {
+	return !r.Missing && r.Error == "" && r.Version < r.Current
+}
+
+// ValidateFile checks a config file against its schema without modifying
+// it. Unknown fields are reported as warnings.
+func ValidateFile(path string, s *Schema) *ValidationReport {
+	report := &ValidationReport{Path: path, Schema: s.Name, Current: s.Current}
+
+	data, err := os.ReadFile(path) //nolint:gosec // G304: config paths are constructed internally
+	if err != nil {
+		if os.IsNotExist(err) {
+			report.Missing = true
+		} else {
+			report.Error = err.Error()
+		}
+		return report
+	}
+
+	upgraded, from, err := s.Migrate(data)
+	report.Version = from
+	if err != nil {
+		report.Error = describeConfigError(path, data, err).Error()
+		return report
+	}
+	if upgraded != nil {
+		data = upgraded
+	}
+	if err := s.decode(data, false); err != nil {
+		report.Error = describeConfigError(path, data, err).Error()
+		return report
+	}
+	if err := s.decode(data, true); err != nil {
+		report.Warnings = append(report.Warnings, describeConfigError(path, data, err).Error())
+	}
+	return report
+}
+
+// TownConfigFiles returns the config files of a town and its rigs, mapped
+// to their schemas, in a stable order.
+func TownConfigFiles(townRoot string) []ConfigFile {
+	files := []ConfigFile{
+		{Path: constants.MayorTownPath(townRoot), Schema: TownConfigSchema},
+		{Path: constants.MayorRigsPath(townRoot), Schema: RigsConfigSchema},
+		{Path: TownSettingsPath(townRoot), Schema: TownSettingsSchema},
+	}
+
+	// Read the registry directly: loading it would migrate it on disk.
+	data, err := os.ReadFile(constants.MayorRigsPath(townRoot)) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		return files
+	}
+	var rigsConfig RigsConfig
+	if err := json.Unmarshal(data, &rigsConfig); err != nil {
+		return files
+	}
+	names := make([]string, 0, len(rigsConfig.Rigs))
+	for name := range rigsConfig.Rigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rigPath := filepath.Join(townRoot, name)
+		files = append(files,
+			ConfigFile{Path: filepath.Join(rigPath, "config.json"), Schema: RigConfigSchema},
+			ConfigFile{Path: RigSettingsPath(rigPath), Schema: RigSettingsSchema},
+		)
+	}
+	return files
+}
+
+// ConfigFile pairs a config file path with its schema.
+type ConfigFile struct {
+	Path   string
+	Schema *Schema
+}
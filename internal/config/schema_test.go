@@ -0,0 +1,137 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+func TestSchemaMigrate(t *testing.T) {
+	t.Parallel()
+
+	upgraded, from, err := RigConfigSchema.Migrate([]byte(`{"name": "gastown", "extra": true}`))
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if from != 0 {
+		t.Errorf("from = %d, want 0", from)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(upgraded, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["type"] != "rig" || doc["version"] != float64(CurrentRigConfigVersion) || doc["extra"] != true {
+		t.Errorf("upgraded = %v", doc)
+	}
+
+	// Already current: nothing to do
+	upgraded, from, err = RigConfigSchema.Migrate([]byte(`{"type": "rig", "version": 1, "name": "gastown"}`))
+	if err != nil || upgraded != nil || from != 1 {
+		t.Errorf("Migrate(current) = %s, %d, %v; want nil, 1, nil", upgraded, from, err)
+	}
+
+	// Newer than this code understands
+	_, _, err = RigsConfigSchema.Migrate([]byte(`{"version": 99, "rigs": {}}`))
+	if !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("Migrate(future) err = %v, want ErrInvalidVersion", err)
+	}
+}
+
+func TestLoadRigConfigMigratesAndWritesBack(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"name": "gastown", "git_url": "https://example.com/gastown.git"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadRigConfig(path)
+	if err != nil {
+		t.Fatalf("LoadRigConfig: %v", err)
+	}
+	if cfg.Type != "rig" || cfg.Version != CurrentRigConfigVersion || cfg.Name != "gastown" {
+		t.Errorf("loaded = %+v", cfg)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"version": 1`) || !strings.Contains(string(data), `"type": "rig"`) {
+		t.Errorf("file not upgraded on disk:\n%s", data)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("file mode = %v, want 0600 preserved", info.Mode().Perm())
+	}
+}
+
+func TestLoadRigsConfigReportsLocation(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "rigs.json")
+	data := "{\n  \"version\": 1,\n  \"rigs\": {\n    \"gastown\": {\"git_url\": 42}\n  }\n}\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadRigsConfig(path)
+	var ce *ConfigError
+	if !errors.As(err, &ce) {
+		t.Fatalf("err = %v, want *ConfigError", err)
+	}
+	if ce.Line != 4 || !strings.Contains(ce.Field, "git_url") {
+		t.Errorf("ConfigError = %+v, want line 4 field git_url", ce)
+	}
+
+	if err := os.WriteFile(path, []byte("{\n  \"version\": 1,\n  \"rigs\": {,}\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadRigsConfig(path)
+	if !errors.As(err, &ce) || ce.Line != 3 {
+		t.Errorf("syntax err = %v, want *ConfigError at line 3", err)
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	t.Parallel()
+	townRoot := t.TempDir()
+	rigsPath := constants.MayorRigsPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(rigsPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(rigsPath, []byte(`{"rigs": {"gastown": {"git_url": "x", "colour": "red"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report := ValidateFile(rigsPath, RigsConfigSchema)
+	if report.Error != "" {
+		t.Fatalf("Error = %s", report.Error)
+	}
+	if !report.NeedsMigration() {
+		t.Errorf("NeedsMigration = false for version %d", report.Version)
+	}
+	if len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0], "colour") {
+		t.Errorf("Warnings = %v, want unknown field colour", report.Warnings)
+	}
+
+	// Validation never rewrites the file
+	data, _ := os.ReadFile(rigsPath)
+	if strings.Contains(string(data), "version") {
+		t.Errorf("ValidateFile modified the file:\n%s", data)
+	}
+
+	// The town's rigs are discovered from the registry
+	files := TownConfigFiles(townRoot)
+	if len(files) != 5 || files[3].Path != filepath.Join(townRoot, "gastown", "config.json") {
+		t.Errorf("TownConfigFiles = %v", files)
+	}
+	if report := ValidateFile(files[3].Path, files[3].Schema); !report.Missing {
+		t.Errorf("missing rig config not reported: %+v", report)
+	}
+	if data, _ := os.ReadFile(rigsPath); strings.Contains(string(data), "version") {
+		t.Errorf("TownConfigFiles modified the registry:\n%s", data)
+	}
+}
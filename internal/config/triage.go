@@ -0,0 +1,176 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CurrentTriageVersion is the current schema version for TriageConfig.
+const CurrentTriageVersion = 1
+
+// TriageConfig holds the Deacon's routing rules for incoming work
+// (settings/triage.json). Rules are evaluated in order; the first match wins.
+type TriageConfig struct {
+	Type    string `json:"type"`    // "triage"
+	Version int    `json:"version"` // schema version
+
+	// Rules route matching beads to a rig.
+	Rules []TriageRule `json:"rules"`
+
+	// DefaultRig receives work that matches no rule.
+	// Empty means unmatched work is left for the Mayor.
+	DefaultRig string `json:"default_rig,omitempty"`
+}
+
+// TriageRule routes beads that match all of its non-empty criteria.
+type TriageRule struct {
+	// Name identifies the rule in triage output.
+	Name string `json:"name"`
+
+	// Labels the bead must all carry.
+	Labels []string `json:"labels,omitempty"`
+
+	// TitleContains matches if any keyword appears in the title or
+	// description (case-insensitive).
+	TitleContains []string `json:"title_contains,omitempty"`
+
+	// Type matches the bead's issue type (task, bug, feature).
+	Type string `json:"type,omitempty"`
+
+	// Rig is the destination rig for matching work.
+	Rig string `json:"rig"`
+
+	// Priority, when set, overrides the bead's priority (0-4).
+	Priority *int `json:"priority,omitempty"`
+}
+
+// NewTriageConfig creates an empty triage configuration.
+func NewTriageConfig() *TriageConfig {
+	return &TriageConfig{
+		Type:    "triage",
+		Version: CurrentTriageVersion,
+	}
+}
+
+// TriageConfigPath returns the standard path for the triage config in a town.
+func TriageConfigPath(townRoot string) string {
+	return filepath.Join(townRoot, "settings", "triage.json")
+}
+
+// LoadTriageConfig loads and validates a triage configuration file.
+func LoadTriageConfig(path string) (*TriageConfig, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+		return nil, fmt.Errorf("reading triage config: %w", err)
+	}
+
+	var config TriageConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing triage config: %w", err)
+	}
+
+	if err := validateTriageConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// LoadOrCreateTriageConfig loads the triage config, returning an empty config if not found.
+func LoadOrCreateTriageConfig(path string) (*TriageConfig, error) {
+	config, err := LoadTriageConfig(path)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return NewTriageConfig(), nil
+		}
+		return nil, err
+	}
+	return config, nil
+}
+
+// SaveTriageConfig saves a triage configuration to a file.
+func SaveTriageConfig(path string, config *TriageConfig) error {
+	if err := validateTriageConfig(config); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding triage config: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: triage config doesn't contain secrets
+		return fmt.Errorf("writing triage config: %w", err)
+	}
+
+	return nil
+}
+
+// validateTriageConfig validates a TriageConfig.
+func validateTriageConfig(c *TriageConfig) error {
+	if c.Type != "triage" && c.Type != "" {
+		return fmt.Errorf("%w: expected type 'triage', got '%s'", ErrInvalidType, c.Type)
+	}
+	if c.Version > CurrentTriageVersion {
+		return fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, c.Version, CurrentTriageVersion)
+	}
+
+	for i, rule := range c.Rules {
+		if rule.Rig == "" {
+			return fmt.Errorf("%w: rules[%d].rig", ErrMissingField, i)
+		}
+		if rule.Priority != nil && (*rule.Priority < 0 || *rule.Priority > 4) {
+			return fmt.Errorf("rules[%d]: priority must be 0-4, got %d", i, *rule.Priority)
+		}
+	}
+
+	return nil
+}
+
+// Match reports whether a bead with the given attributes satisfies the rule.
+// A rule with no criteria matches everything.
+func (r *TriageRule) Match(title, description, issueType string, labels []string) bool {
+	if r.Type != "" && !strings.EqualFold(r.Type, issueType) {
+		return false
+	}
+
+	for _, want := range r.Labels {
+		found := false
+		for _, have := range labels {
+			if have == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(r.TitleContains) > 0 {
+		text := strings.ToLower(title + "\n" + description)
+		matched := false
+		for _, kw := range r.TitleContains {
+			if strings.Contains(text, strings.ToLower(kw)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
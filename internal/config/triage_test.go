@@ -0,0 +1,66 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestTriageConfigRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings", "triage.json")
+
+	cfg, err := LoadOrCreateTriageConfig(path)
+	if err != nil {
+		t.Fatalf("LoadOrCreateTriageConfig() error = %v", err)
+	}
+	if len(cfg.Rules) != 0 {
+		t.Errorf("default config has %d rules, want 0", len(cfg.Rules))
+	}
+
+	cfg.Rules = append(cfg.Rules, TriageRule{Name: "docs", TitleContains: []string{"docs"}, Rig: "gastown"})
+	if err := SaveTriageConfig(path, cfg); err != nil {
+		t.Fatalf("SaveTriageConfig() error = %v", err)
+	}
+
+	loaded, err := LoadTriageConfig(path)
+	if err != nil {
+		t.Fatalf("LoadTriageConfig() error = %v", err)
+	}
+	if len(loaded.Rules) != 1 || loaded.Rules[0].Rig != "gastown" {
+		t.Errorf("loaded rules = %+v", loaded.Rules)
+	}
+}
+
+func TestTriageConfigValidation(t *testing.T) {
+	cfg := NewTriageConfig()
+	cfg.Rules = []TriageRule{{Name: "no-rig"}}
+	if err := validateTriageConfig(cfg); !errors.Is(err, ErrMissingField) {
+		t.Errorf("missing rig error = %v, want ErrMissingField", err)
+	}
+
+	bad := 7
+	cfg.Rules = []TriageRule{{Rig: "gastown", Priority: &bad}}
+	if err := validateTriageConfig(cfg); err == nil {
+		t.Error("expected error for out-of-range priority")
+	}
+}
+
+func TestTriageRuleMatch(t *testing.T) {
+	rule := TriageRule{Labels: []string{"beads"}, TitleContains: []string{"Sync"}, Type: "bug"}
+
+	if !rule.Match("bd sync fails", "", "bug", []string{"beads", "p1"}) {
+		t.Error("expected match on label, keyword, and type")
+	}
+	if rule.Match("bd sync fails", "", "task", []string{"beads"}) {
+		t.Error("type mismatch should not match")
+	}
+	if rule.Match("bd sync fails", "", "bug", nil) {
+		t.Error("missing label should not match")
+	}
+	if !rule.Match("crash", "happens during sync", "bug", []string{"beads"}) {
+		t.Error("keyword in description should match")
+	}
+	if !(&TriageRule{}).Match("anything", "", "", nil) {
+		t.Error("empty rule should match everything")
+	}
+}
@@ -62,6 +62,32 @@ type TownSettings struct {
 	// Agent addresses like "gastown/crew/jack" become "gastown.crew.jack@{domain}".
 	// Default: "gastown.local"
 	AgentEmailDomain string `json:"agent_email_domain,omitempty"`
+
+	// TmuxSocket is the tmux socket name (tmux -L) the town's sessions run
+	// on. "default" uses tmux's default server.
+	// Default: "gastown"
+	TmuxSocket string `json:"tmux_socket,omitempty"`
+
+	// CostBudget configures daily spend limits checked by 'gt costs report'.
+	// Exceeded budgets are routed through the escalation config.
+	CostBudget *CostBudgetConfig `json:"cost_budget,omitempty"`
+
+	// Overrides sets resolvable settings by key (see Settings).
+	// Rig and workspace overrides and GT_* environment variables take precedence.
+	Overrides map[string]string `json:"overrides,omitempty"`
+}
+
+// CostBudgetConfig defines daily agent spend limits in USD.
+type CostBudgetConfig struct {
+	// DailyUSD is the town-wide daily limit (0 = no limit).
+	DailyUSD float64 `json:"daily_usd,omitempty"`
+
+	// RigDailyUSD maps rig names to per-rig daily limits.
+	RigDailyUSD map[string]float64 `json:"rig_daily_usd,omitempty"`
+
+	// Severity is the escalation severity used for budget alerts.
+	// Default: "high"
+	Severity string `json:"severity,omitempty"`
 }
 
 // NewTownSettings creates a new TownSettings with defaults.
@@ -161,6 +187,10 @@ type RigEntry struct {
 	LocalRepo   string       `json:"local_repo,omitempty"`
 	AddedAt     time.Time    `json:"added_at"`
 	BeadsConfig *BeadsConfig `json:"beads,omitempty"`
+
+	// Env holds extra environment variables (e.g., GOFLAGS, NODE_ENV) for
+	// the rig's polecat and crew sessions. Gas Town's own variables win.
+	Env map[string]string `json:"env,omitempty"`
 }
 
 // BeadsConfig represents beads configuration for a rig.
@@ -185,13 +215,14 @@ const CurrentRigSettingsVersion = 1
 // RigConfig represents per-rig identity (rig/config.json).
 // This contains only identity - behavioral config is in settings/config.json.
 type RigConfig struct {
-	Type      string       `json:"type"`    // "rig"
-	Version   int          `json:"version"` // schema version
-	Name      string       `json:"name"`    // rig name
-	GitURL    string       `json:"git_url"` // git repository URL
-	LocalRepo string       `json:"local_repo,omitempty"`
-	CreatedAt time.Time    `json:"created_at"` // when the rig was created
-	Beads     *BeadsConfig `json:"beads,omitempty"`
+	Type          string       `json:"type"`    // "rig"
+	Version       int          `json:"version"` // schema version
+	Name          string       `json:"name"`    // rig name
+	GitURL        string       `json:"git_url"` // git repository URL
+	LocalRepo     string       `json:"local_repo,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`               // when the rig was created
+	DefaultBranch string       `json:"default_branch,omitempty"` // main, master, etc.
+	Beads         *BeadsConfig `json:"beads,omitempty"`
 }
 
 // WorkflowConfig represents workflow settings for a rig.
@@ -203,14 +234,19 @@ type WorkflowConfig struct {
 
 // RigSettings represents per-rig behavioral configuration (settings/config.json).
 type RigSettings struct {
-	Type       string            `json:"type"`                  // "rig-settings"
-	Version    int               `json:"version"`               // schema version
-	MergeQueue *MergeQueueConfig `json:"merge_queue,omitempty"` // merge queue settings
-	Theme      *ThemeConfig      `json:"theme,omitempty"`       // tmux theme settings
-	Namepool   *NamepoolConfig   `json:"namepool,omitempty"`    // polecat name pool settings
-	Crew       *CrewConfig       `json:"crew,omitempty"`        // crew startup settings
-	Workflow   *WorkflowConfig   `json:"workflow,omitempty"`    // workflow settings
-	Runtime    *RuntimeConfig    `json:"runtime,omitempty"`     // LLM runtime settings (deprecated: use Agent)
+	Type       string             `json:"type"`                  // "rig-settings"
+	Version    int                `json:"version"`               // schema version
+	MergeQueue *MergeQueueConfig  `json:"merge_queue,omitempty"` // merge queue settings
+	Theme      *ThemeConfig       `json:"theme,omitempty"`       // tmux theme settings
+	Namepool   *NamepoolConfig    `json:"namepool,omitempty"`    // polecat name pool settings
+	Crew       *CrewConfig        `json:"crew,omitempty"`        // crew startup settings
+	Schedule   *RigScheduleConfig `json:"schedule,omitempty"`    // polecat session hours
+	Liveness   *LivenessConfig    `json:"liveness,omitempty"`    // polecat liveness checks
+	Container  *ContainerConfig   `json:"container,omitempty"`   // run polecats in containers
+	Checkout   *CheckoutConfig    `json:"checkout,omitempty"`    // crew/polecat checkout mode
+	Workflow   *WorkflowConfig    `json:"workflow,omitempty"`    // workflow settings
+	Mail       *RigMailConfig     `json:"mail,omitempty"`        // mail mirroring rules
+	Runtime    *RuntimeConfig     `json:"runtime,omitempty"`     // LLM runtime settings (deprecated: use Agent)
 
 	// Agent selects which agent preset to use for this rig.
 	// Can be a built-in preset ("claude", "gemini", "codex", "cursor", "auggie", "amp")
@@ -230,6 +266,24 @@ type RigSettings struct {
 	// Overrides TownSettings.RoleAgents for this specific rig.
 	// Example: {"witness": "claude-haiku", "polecat": "claude-sonnet"}
 	RoleAgents map[string]string `json:"role_agents,omitempty"`
+
+	// Overrides sets resolvable settings by key for this rig (see Settings).
+	// Takes precedence over town settings.
+	Overrides map[string]string `json:"overrides,omitempty"`
+}
+
+// CurrentWorkspaceSettingsVersion is the current schema version for WorkspaceSettings.
+const CurrentWorkspaceSettingsVersion = 1
+
+// WorkspaceSettings represents per-workspace overrides for a crew member or
+// polecat (<workspace>/.runtime/settings.json).
+type WorkspaceSettings struct {
+	Type    string `json:"type"`    // "workspace-settings"
+	Version int    `json:"version"` // schema version
+
+	// Overrides sets resolvable settings by key for this workspace.
+	// Takes precedence over rig and town settings.
+	Overrides map[string]string `json:"overrides,omitempty"`
 }
 
 // CrewConfig represents crew workspace settings for a rig.
@@ -246,6 +300,143 @@ type CrewConfig struct {
 	Startup string `json:"startup,omitempty"`
 }
 
+// RigScheduleConfig limits the hours a rig's polecat sessions run.
+// The daemon stops running polecat sessions when the window closes and
+// starts them again when it reopens.
+type RigScheduleConfig struct {
+	// Active is the daily window in local time, "HH:MM-HH:MM".
+	// It may wrap past midnight (e.g., "22:00-06:00" for off-peak runs).
+	Active string `json:"active"`
+
+	// Notify is the mail address told about each boundary.
+	// Default: the rig's witness.
+	Notify string `json:"notify,omitempty"`
+}
+
+// LivenessConfig tunes how the witness decides that a polecat whose agent
+// process is alive is still working rather than wedged.
+type LivenessConfig struct {
+	// Checks configures liveness checks by name: "session", "pane", "git",
+	// "beads", or "script". Checks not listed keep their defaults: pane, git
+	// and beads on, session off, and script on once it has a command, each
+	// with weight 1.
+	Checks map[string]*LivenessCheckConfig `json:"checks,omitempty"`
+
+	// Threshold is the share (0-1) of the enabled checks' total weight that
+	// must report recent activity for the agent to count as running.
+	// Zero means any active check is enough.
+	Threshold float64 `json:"threshold,omitempty"`
+}
+
+// LivenessCheckConfig enables, disables, or weights one liveness check.
+type LivenessCheckConfig struct {
+	// Enabled overrides the check's default.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Weight is the check's share of the vote. Default: 1.
+	Weight float64 `json:"weight,omitempty"`
+
+	// Command is the script check's shell command. It runs in the polecat's
+	// worktree and exits 0 if the agent is working, 1 if not.
+	Command string `json:"command,omitempty"`
+}
+
+// ContainerConfig runs a rig's polecats in containers instead of directly on
+// the host. Each polecat gets its own container, with its worktree, the
+// rig's git and beads directories, and Mounts bind-mounted at their host
+// paths; its tmux session execs the agent inside it. The image must provide
+// the agent CLI, gt and bd.
+type ContainerConfig struct {
+	// Image is the container image. Required.
+	Image string `json:"image"`
+
+	// Runtime is the container CLI: "docker" or "podman". Default: "docker".
+	Runtime string `json:"runtime,omitempty"`
+
+	// Mounts are extra bind mounts, "host:container" with an optional ":ro".
+	Mounts []string `json:"mounts,omitempty"`
+
+	// CPUs limits CPU use, e.g. "2" or "0.5" (--cpus).
+	CPUs string `json:"cpus,omitempty"`
+
+	// Memory limits memory, e.g. "4g" (--memory).
+	Memory string `json:"memory,omitempty"`
+
+	// PidsLimit caps the number of processes (--pids-limit). Zero: no limit.
+	PidsLimit int `json:"pids_limit,omitempty"`
+
+	// Network is the container network (--network), e.g. "none".
+	// Default: the runtime's default network.
+	Network string `json:"network,omitempty"`
+}
+
+// Checkout modes for crew workspaces.
+const (
+	// CheckoutClone gives each crew member a full clone of the rig's repo.
+	CheckoutClone = "clone"
+
+	// CheckoutWorktree makes each crew member a git worktree of the rig's
+	// shared repo (.repo.git, or mayor/rig in older rigs), so the object
+	// store is on disk once however many workers the rig has.
+	CheckoutWorktree = "worktree"
+)
+
+// CheckoutConfig controls how a rig's crew and polecat workspaces are
+// checked out. Polecats are always worktrees of the shared repo; Mode
+// decides whether crew are too.
+//
+// Depth and Filter only shape crew clones. The shared repo is cloned in
+// full when the rig is added, before it has settings, and the refinery
+// merges in it, so worktrees (polecats, and crew in worktree mode) always
+// have the whole history.
+type CheckoutConfig struct {
+	// Mode is CheckoutClone or CheckoutWorktree. Default: CheckoutClone.
+	Mode string `json:"mode,omitempty"`
+
+	// Depth makes crew clones shallow, with this many commits of history
+	// (--depth). Zero: full history.
+	Depth int `json:"depth,omitempty"`
+
+	// Filter makes crew clones partial (--filter), e.g. "blob:none" to
+	// fetch file contents on demand.
+	Filter string `json:"filter,omitempty"`
+
+	// Sparse are sparse-checkout patterns (gitignore syntax) limiting the
+	// paths checked out in crew and polecat workspaces. Top-level files are
+	// always checked out. Empty: the whole tree.
+	Sparse []string `json:"sparse,omitempty"`
+}
+
+// RigMailConfig holds a rig's mail settings.
+type RigMailConfig struct {
+	// Mirrors copy matching mail sent to or from the rig's agents to an
+	// external endpoint, in addition to normal delivery.
+	Mirrors []*MailMirrorRule `json:"mirrors,omitempty"`
+}
+
+// MailMirrorRule copies mail matching its filter to a notification sink.
+// All set filter fields must match; a rule without filters mirrors
+// everything.
+type MailMirrorRule struct {
+	// Name identifies the rule in rate limiting and error output.
+	Name string `json:"name"`
+
+	// SubjectContains matches subjects containing this text (case-insensitive).
+	SubjectContains string `json:"subject_contains,omitempty"`
+
+	// From and To match sender and recipient addresses; '*' matches one
+	// path segment (e.g., "*/witness", "gastown/polecats/*").
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+
+	// MinPriority drops mail below this priority (low, normal, high, urgent).
+	MinPriority string `json:"min_priority,omitempty"`
+
+	// Sink is where matching mail goes: a slack, discord, or webhook
+	// endpoint, or email.
+	Sink *NotificationSink `json:"sink"`
+}
+
 // RuntimeConfig represents LLM runtime configuration for agent sessions.
 // This allows switching between different LLM backends (claude, aider, etc.)
 // without modifying startup code.
@@ -776,6 +967,12 @@ type MessagingConfig struct {
 	// Example: {"oncall": ["mayor/", "gastown/witness"]}
 	Lists map[string][]string `json:"lists,omitempty"`
 
+	// Groups are named recipient sets addressed by bare name ("backend-team").
+	// Members may be addresses, wildcard patterns, or other groups; a send
+	// fans out to every member, deduplicated, under one broadcast ID.
+	// Example: {"backend-team": ["gastown/crew/*", "gastown/witness"]}
+	Groups map[string][]string `json:"groups,omitempty"`
+
 	// Queues are shared work queues. Only one copy exists; workers claim messages.
 	// Messages sit in the queue until explicitly claimed by a worker.
 	// Example: {"work/gastown": ["gastown/polecats/*"]}
@@ -790,6 +987,12 @@ type MessagingConfig struct {
 	// Like mailing lists but for tmux send-keys instead of durable mail.
 	// Example: {"workers": ["gastown/polecats/*", "gastown/crew/*"], "witnesses": ["*/witness"]}
 	NudgeChannels map[string][]string `json:"nudge_channels,omitempty"`
+
+	// Retention limits how much read mail a mailbox keeps before
+	// 'gt mail compact' moves it to the compressed archive. Keys are
+	// addresses or wildcard patterns; "default" covers every other mailbox.
+	// Example: {"default": {"max_age": "720h"}, "gastown/crew/*": {"max_messages": 200}}
+	Retention map[string]RetentionPolicy `json:"retention,omitempty"`
 }
 
 // QueueConfig represents a work queue configuration.
@@ -812,6 +1015,16 @@ type AnnounceConfig struct {
 	RetainCount int `json:"retain_count,omitempty"`
 }
 
+// RetentionPolicy bounds one mailbox. Unread and pinned mail is always kept.
+type RetentionPolicy struct {
+	// MaxMessages is the most messages to keep; the oldest read ones beyond
+	// it are archived (0 = unlimited).
+	MaxMessages int `json:"max_messages,omitempty"`
+
+	// MaxAge archives read mail older than this duration (e.g., "720h").
+	MaxAge string `json:"max_age,omitempty"`
+}
+
 // CurrentMessagingVersion is the current schema version for MessagingConfig.
 const CurrentMessagingVersion = 1
 
@@ -821,6 +1034,7 @@ func NewMessagingConfig() *MessagingConfig {
 		Type:          "messaging",
 		Version:       CurrentMessagingVersion,
 		Lists:         make(map[string][]string),
+		Groups:        make(map[string][]string),
 		Queues:        make(map[string]QueueConfig),
 		Announces:     make(map[string]AnnounceConfig),
 		NudgeChannels: make(map[string][]string),
@@ -0,0 +1,215 @@
+// Package container runs agents inside containers through the docker (or
+// podman) CLI.
+//
+// A container is started detached with a long-lived idle process, and agent
+// commands are run in it with exec, so the agent's tmux session stays on the
+// host and everything that drives sessions (nudges, capture, liveness)
+// works unchanged. Removing the container ends anything still running in it.
+package container
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/logging"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// DefaultRuntime is the container CLI used when none is configured.
+const DefaultRuntime = "docker"
+
+// Label marks containers started by gt, so they can be told apart from the
+// user's own.
+const Label = "io.gastown.agent"
+
+// ErrRuntimeNotFound means the container CLI is not installed.
+var ErrRuntimeNotFound = errors.New("container runtime not found")
+
+// Mount is a bind mount.
+type Mount struct {
+	Source   string // host path
+	Target   string // path in the container
+	ReadOnly bool
+}
+
+// ParseMount parses "host:container" with an optional ":ro" or ":rw".
+func ParseMount(s string) (Mount, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return Mount{}, fmt.Errorf("invalid mount %q (want host:container[:ro])", s)
+	}
+	m := Mount{Source: parts[0], Target: parts[1]}
+	if len(parts) == 3 {
+		switch parts[2] {
+		case "ro":
+			m.ReadOnly = true
+		case "rw":
+		default:
+			return Mount{}, fmt.Errorf("invalid mount %q: unknown option %q", s, parts[2])
+		}
+	}
+	return m, nil
+}
+
+// Spec describes a container to start.
+type Spec struct {
+	Name      string
+	Image     string
+	Agent     string // agent address, recorded in the gt label
+	WorkDir   string
+	Mounts    []Mount
+	CPUs      string
+	Memory    string
+	PidsLimit int
+	Network   string
+}
+
+// Runtime drives a container CLI.
+type Runtime struct {
+	Binary string // "docker" or "podman"
+}
+
+// New returns a Runtime for binary, or the default runtime if it is empty.
+func New(binary string) *Runtime {
+	if binary == "" {
+		binary = DefaultRuntime
+	}
+	return &Runtime{Binary: binary}
+}
+
+// RunArgs returns the arguments that start spec's container detached.
+func (r *Runtime) RunArgs(spec Spec) []string {
+	args := []string{"run", "--detach", "--init", "--name", spec.Name,
+		"--label", Label + "=" + spec.Agent}
+	if spec.WorkDir != "" {
+		args = append(args, "--workdir", spec.WorkDir)
+	}
+	for _, m := range spec.Mounts {
+		v := m.Source + ":" + m.Target
+		if m.ReadOnly {
+			v += ":ro"
+		}
+		args = append(args, "--volume", v)
+	}
+	if spec.CPUs != "" {
+		args = append(args, "--cpus", spec.CPUs)
+	}
+	if spec.Memory != "" {
+		args = append(args, "--memory", spec.Memory)
+	}
+	if spec.PidsLimit > 0 {
+		args = append(args, "--pids-limit", fmt.Sprint(spec.PidsLimit))
+	}
+	if spec.Network != "" {
+		args = append(args, "--network", spec.Network)
+	}
+	// Keep the container up between agent runs; agents are started with exec
+	return append(args, spec.Image, "sleep", "infinity")
+}
+
+// Start starts spec's container, replacing a leftover one with the same name.
+func (r *Runtime) Start(spec Spec) error {
+	if _, err := exec.LookPath(r.Binary); err != nil {
+		return fmt.Errorf("%w: %s", ErrRuntimeNotFound, r.Binary)
+	}
+	_ = r.Remove(spec.Name)
+	_, err := r.run(r.RunArgs(spec)...)
+	return err
+}
+
+// Remove stops and deletes a container. A missing container is not an error.
+func (r *Runtime) Remove(name string) error {
+	_, err := r.run("rm", "--force", name)
+	if err != nil && strings.Contains(strings.ToLower(err.Error()), "no such container") {
+		return nil
+	}
+	return err
+}
+
+// Running reports whether a container is running.
+func (r *Runtime) Running(name string) bool {
+	out, err := r.run("inspect", "--format", "{{.State.Running}}", name)
+	return err == nil && strings.TrimSpace(out) == "true"
+}
+
+// ExecCommand returns a shell command line that runs command inside a
+// container, in workDir with the environment in envFile (see WriteEnvFile),
+// attached to the caller's terminal. It is meant to be a tmux session's
+// command, so the environment is never on it: the command line shows up in
+// ps and in tmux.
+func (r *Runtime) ExecCommand(name, workDir, envFile, command string) string {
+	args := []string{r.Binary, "exec", "--interactive", "--tty"}
+	if workDir != "" {
+		args = append(args, "--workdir", workDir)
+	}
+	if envFile != "" {
+		args = append(args, "--env-file", envFile)
+	}
+	args = append(args, name, "sh", "-c", command)
+
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = shellQuote(a)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// WriteEnvFile writes env to path in the --env-file format, readable only
+// by the owner. The format has no quoting, so values can't span lines.
+func WriteEnvFile(path string, env map[string]string) error {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		if k == "" || strings.ContainsAny(k, "=\n") {
+			return fmt.Errorf("invalid env var name %q", k)
+		}
+		if strings.ContainsAny(env[k], "\r\n") {
+			return fmt.Errorf("env var %s: value contains a line break", k)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, k := range keys {
+		fmt.Fprintf(&buf, "%s=%s\n", k, env[k])
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return util.AtomicWriteFile(path, buf.Bytes(), 0600)
+}
+
+// run executes the container CLI and returns its output.
+func (r *Runtime) run(args ...string) (string, error) {
+	cmd := exec.Command(r.Binary, args...) //nolint:gosec // G204: args are constructed internally
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	start := time.Now()
+	err := cmd.Run()
+	logging.Exec(r.Binary, args, "", start, err, stderr.String())
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), fmt.Errorf("%s %s: %s", r.Binary, args[0], msg)
+		}
+		return stdout.String(), fmt.Errorf("%s %s: %w", r.Binary, args[0], err)
+	}
+	return stdout.String(), nil
+}
+
+// shellQuote single-quotes s unless it is plainly safe.
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("_./:@%+=,-", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
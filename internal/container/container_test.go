@@ -0,0 +1,104 @@
+package container
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseMount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Mount
+		wantErr bool
+	}{
+		{in: "/src:/dst", want: Mount{Source: "/src", Target: "/dst"}},
+		{in: "/src:/dst:ro", want: Mount{Source: "/src", Target: "/dst", ReadOnly: true}},
+		{in: "/src:/dst:rw", want: Mount{Source: "/src", Target: "/dst"}},
+		{in: "/src", wantErr: true},
+		{in: ":/dst", wantErr: true},
+		{in: "/src:/dst:z", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMount(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMount(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMount(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRunArgs(t *testing.T) {
+	got := New("").RunArgs(Spec{
+		Name:      "gt-gastown-toast",
+		Image:     "agent:latest",
+		Agent:     "gastown/polecats/toast",
+		WorkDir:   "/town/gastown/polecats/toast/gastown",
+		Mounts:    []Mount{{Source: "/town/gastown/polecats/toast", Target: "/town/gastown/polecats/toast"}, {Source: "/town/mayor", Target: "/town/mayor", ReadOnly: true}},
+		Memory:    "4g",
+		PidsLimit: 512,
+		Network:   "none",
+	})
+	want := []string{
+		"run", "--detach", "--init", "--name", "gt-gastown-toast",
+		"--label", "io.gastown.agent=gastown/polecats/toast",
+		"--workdir", "/town/gastown/polecats/toast/gastown",
+		"--volume", "/town/gastown/polecats/toast:/town/gastown/polecats/toast",
+		"--volume", "/town/mayor:/town/mayor:ro",
+		"--memory", "4g",
+		"--pids-limit", "512",
+		"--network", "none",
+		"agent:latest", "sleep", "infinity",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RunArgs =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestExecCommand(t *testing.T) {
+	got := New("podman").ExecCommand("gt-gastown-toast", "/work dir", "/town/gastown/.runtime/containers/gt-gastown-toast.env", "export A='b c' && claude")
+	want := `podman exec --interactive --tty --workdir '/work dir' --env-file /town/gastown/.runtime/containers/gt-gastown-toast.env gt-gastown-toast sh -c 'export A='\''b c'\'' && claude'`
+	if got != want {
+		t.Errorf("ExecCommand =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "containers", "gt-gastown-toast.env")
+	err := WriteEnvFile(path, map[string]string{
+		"GT_ROLE":   "polecat",
+		"API_TOKEN": "s3cret with spaces",
+	})
+	if err != nil {
+		t.Fatalf("WriteEnvFile: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "API_TOKEN=s3cret with spaces\nGT_ROLE=polecat\n"; string(data) != want {
+		t.Errorf("env file =\n%s\nwant\n%s", data, want)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("env file mode = %o, want 600", perm)
+	}
+
+	for _, env := range []map[string]string{
+		{"A": "two\nlines"},
+		{"A=B": "x"},
+		{"": "x"},
+	} {
+		if err := WriteEnvFile(path, env); err == nil {
+			t.Errorf("WriteEnvFile(%q) succeeded, want an error", env)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package cost
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleTranscript = `{"type":"user","timestamp":"2026-01-10T10:00:00Z","sessionId":"s1","cwd":"/town/gastown/polecats/toast/gastown"}
+{"type":"assistant","timestamp":"2026-01-10T10:00:05Z","sessionId":"s1","cwd":"/town/gastown/polecats/toast/gastown","gitBranch":"polecat/toast/gt-abc@mk123","message":{"id":"m1","model":"claude-sonnet-4-5","usage":{"input_tokens":100,"output_tokens":10,"cache_creation_input_tokens":0,"cache_read_input_tokens":0}}}
+{"type":"assistant","timestamp":"2026-01-10T10:00:06Z","sessionId":"s1","cwd":"/town/gastown/polecats/toast/gastown","gitBranch":"polecat/toast/gt-abc@mk123","message":{"id":"m1","model":"claude-sonnet-4-5","usage":{"input_tokens":1000,"output_tokens":500,"cache_creation_input_tokens":0,"cache_read_input_tokens":0}}}
+not json
+{"type":"assistant","timestamp":"2026-01-10T10:30:00Z","sessionId":"s1","cwd":"/town/gastown/polecats/toast/gastown","gitBranch":"polecat/toast/gt-abc@mk123","message":{"id":"m2","model":"claude-sonnet-4-5","usage":{"input_tokens":2000,"output_tokens":0,"cache_creation_input_tokens":0,"cache_read_input_tokens":0}}}
+`
+
+func TestParseTranscript(t *testing.T) {
+	records, err := ParseTranscript(strings.NewReader(sampleTranscript))
+	if err != nil {
+		t.Fatalf("ParseTranscript() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2 (duplicate message ID collapsed)", len(records))
+	}
+	if records[0].Usage.InputTokens != 1000 || records[0].Usage.OutputTokens != 500 {
+		t.Errorf("first record usage = %+v, want last streamed values", records[0].Usage)
+	}
+	want := (1000*3.0 + 500*15.0) / 1_000_000
+	if math.Abs(records[0].Usage.CostUSD-want) > 1e-9 {
+		t.Errorf("CostUSD = %v, want %v", records[0].Usage.CostUSD, want)
+	}
+}
+
+func TestAttribute(t *testing.T) {
+	tests := []struct {
+		cwd, branch          string
+		ok                   bool
+		wantRig, actor, bead string
+	}{
+		{"/town/gastown/polecats/toast/gastown", "polecat/toast/gt-abc@mk1", true, "gastown", "gastown/polecats/toast", "gt-abc"},
+		{"/town/gastown/crew/max", "main", true, "gastown", "gastown/crew/max", ""},
+		{"/town/gastown/witness", "", true, "gastown", "gastown/witness", ""},
+		{"/town/mayor", "", true, "", "mayor", ""},
+		{"/town", "", true, "", "mayor", ""},
+		{"/elsewhere/project", "", false, "", "", ""},
+	}
+	for _, tt := range tests {
+		rec := Record{Cwd: tt.cwd, Branch: tt.branch}
+		ok := Attribute(&rec, "/town")
+		if ok != tt.ok {
+			t.Errorf("Attribute(%q) ok = %v, want %v", tt.cwd, ok, tt.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if rec.Rig != tt.wantRig || rec.Actor != tt.actor || rec.Bead != tt.bead {
+			t.Errorf("Attribute(%q) = rig %q actor %q bead %q, want %q %q %q",
+				tt.cwd, rec.Rig, rec.Actor, rec.Bead, tt.wantRig, tt.actor, tt.bead)
+		}
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	base := time.Date(2026, 1, 10, 10, 0, 0, 0, time.UTC)
+	records := []Record{
+		{Timestamp: base, SessionID: "s1", Rig: "a", Usage: Usage{CostUSD: 1}},
+		{Timestamp: base.Add(time.Hour), SessionID: "s1", Rig: "a", Usage: Usage{CostUSD: 2}},
+		{Timestamp: base, SessionID: "s2", Rig: "b", Usage: Usage{CostUSD: 5}},
+	}
+
+	report := Aggregate(records, GroupByRig, time.Time{})
+	if len(report.Rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(report.Rows))
+	}
+	if report.Rows[0].Key != "b" {
+		t.Errorf("rows should be sorted by cost desc, got first %q", report.Rows[0].Key)
+	}
+	if report.Rows[1].WallClock != time.Hour {
+		t.Errorf("rig a wall clock = %v, want 1h", report.Rows[1].WallClock)
+	}
+	if report.Total.CostUSD != 8 {
+		t.Errorf("total = %v, want 8", report.Total.CostUSD)
+	}
+}
+
+func TestCheckDailyBudget(t *testing.T) {
+	now := time.Date(2026, 1, 10, 18, 0, 0, 0, time.UTC)
+	records := []Record{
+		{Timestamp: now.Add(-time.Hour), Rig: "a", Usage: Usage{CostUSD: 60}},
+		{Timestamp: now.Add(-2 * time.Hour), Rig: "b", Usage: Usage{CostUSD: 50}},
+		{Timestamp: now.Add(-24 * time.Hour), Rig: "a", Usage: Usage{CostUSD: 500}}, // yesterday
+	}
+
+	alerts := CheckDailyBudget(records, now, 100, map[string]float64{"a": 50, "b": 80})
+	if len(alerts) != 2 {
+		t.Fatalf("got %d alerts, want 2: %v", len(alerts), alerts)
+	}
+	if alerts[0].Scope != "town" || alerts[1].Scope != "a" {
+		t.Errorf("unexpected alerts: %v", alerts)
+	}
+}
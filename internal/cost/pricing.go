@@ -0,0 +1,49 @@
+package cost
+
+import "strings"
+
+// Price is the USD cost per million tokens for a model family.
+type Price struct {
+	Input      float64
+	Output     float64
+	CacheWrite float64
+	CacheRead  float64
+}
+
+// prices maps a model family substring to its list price.
+// Matched in order, so more specific families come first.
+var prices = []struct {
+	family string
+	price  Price
+}{
+	{"opus-4-1", Price{Input: 15, Output: 75, CacheWrite: 18.75, CacheRead: 1.5}},
+	{"opus-4-0", Price{Input: 15, Output: 75, CacheWrite: 18.75, CacheRead: 1.5}},
+	{"opus-4-2025", Price{Input: 15, Output: 75, CacheWrite: 18.75, CacheRead: 1.5}},
+	{"opus", Price{Input: 5, Output: 25, CacheWrite: 6.25, CacheRead: 0.5}},
+	{"sonnet", Price{Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.3}},
+	{"haiku-3", Price{Input: 0.25, Output: 1.25, CacheWrite: 0.3, CacheRead: 0.03}},
+	{"haiku", Price{Input: 1, Output: 5, CacheWrite: 1.25, CacheRead: 0.1}},
+}
+
+// defaultPrice is used for unrecognized models (Sonnet pricing).
+var defaultPrice = Price{Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.3}
+
+// PriceFor returns the per-million-token price for a model ID.
+func PriceFor(model string) Price {
+	m := strings.ToLower(model)
+	for _, p := range prices {
+		if strings.Contains(m, p.family) {
+			return p.price
+		}
+	}
+	return defaultPrice
+}
+
+// EstimateCost returns the estimated USD cost of usage at list prices.
+func EstimateCost(model string, u Usage) float64 {
+	p := PriceFor(model)
+	return (float64(u.InputTokens)*p.Input +
+		float64(u.OutputTokens)*p.Output +
+		float64(u.CacheCreationTokens)*p.CacheWrite +
+		float64(u.CacheReadTokens)*p.CacheRead) / 1_000_000
+}
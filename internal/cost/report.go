@@ -0,0 +1,174 @@
+package cost
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// GroupBy selects the dimension used to aggregate records.
+type GroupBy string
+
+const (
+	GroupByRig     GroupBy = "rig"
+	GroupByActor   GroupBy = "actor"
+	GroupByDay     GroupBy = "day"
+	GroupBySession GroupBy = "session"
+	GroupByBead    GroupBy = "bead"
+	GroupByModel   GroupBy = "model"
+)
+
+// ValidGroupBy reports whether g is a supported grouping.
+func ValidGroupBy(g GroupBy) bool {
+	switch g {
+	case GroupByRig, GroupByActor, GroupByDay, GroupBySession, GroupByBead, GroupByModel:
+		return true
+	}
+	return false
+}
+
+// Row is one aggregated line of a cost report.
+type Row struct {
+	Key       string        `json:"key"`
+	Turns     int           `json:"turns"`
+	Sessions  int           `json:"sessions"`
+	WallClock time.Duration `json:"wall_clock_ns"`
+	Usage     Usage         `json:"usage"`
+}
+
+// Report is an aggregated view of usage records.
+type Report struct {
+	GroupBy GroupBy   `json:"group_by"`
+	Since   time.Time `json:"since,omitempty"`
+	Rows    []Row     `json:"rows"`
+	Total   Usage     `json:"total"`
+}
+
+// keyFor returns the grouping key of a record.
+func keyFor(rec Record, g GroupBy) string {
+	var key string
+	switch g {
+	case GroupByRig:
+		key = rec.Rig
+		if key == "" {
+			key = "(town)"
+		}
+		return key
+	case GroupByActor:
+		key = rec.Actor
+	case GroupByDay:
+		key = rec.Timestamp.Local().Format("2006-01-02")
+	case GroupBySession:
+		key = rec.SessionID
+	case GroupByBead:
+		key = rec.Bead
+	case GroupByModel:
+		key = rec.Model
+	}
+	if key == "" {
+		key = "(unknown)"
+	}
+	return key
+}
+
+// Aggregate groups records and returns a report sorted by descending cost
+// (or chronologically when grouping by day).
+//
+// Wall-clock time per row is the sum, across sessions, of the span between
+// each session's first and last recorded turn.
+func Aggregate(records []Record, g GroupBy, since time.Time) *Report {
+	rows := make(map[string]*Row)
+	type span struct{ first, last time.Time }
+	sessions := make(map[string]map[string]*span)
+
+	report := &Report{GroupBy: g, Since: since}
+	for _, rec := range records {
+		key := keyFor(rec, g)
+		row, ok := rows[key]
+		if !ok {
+			row = &Row{Key: key}
+			rows[key] = row
+			sessions[key] = make(map[string]*span)
+		}
+		row.Turns++
+		row.Usage.Add(rec.Usage)
+		report.Total.Add(rec.Usage)
+
+		sp, ok := sessions[key][rec.SessionID]
+		if !ok {
+			sessions[key][rec.SessionID] = &span{first: rec.Timestamp, last: rec.Timestamp}
+			continue
+		}
+		if rec.Timestamp.Before(sp.first) {
+			sp.first = rec.Timestamp
+		}
+		if rec.Timestamp.After(sp.last) {
+			sp.last = rec.Timestamp
+		}
+	}
+
+	for key, row := range rows {
+		row.Sessions = len(sessions[key])
+		for _, sp := range sessions[key] {
+			row.WallClock += sp.last.Sub(sp.first)
+		}
+		report.Rows = append(report.Rows, *row)
+	}
+
+	sort.Slice(report.Rows, func(i, j int) bool {
+		if g == GroupByDay {
+			return report.Rows[i].Key < report.Rows[j].Key
+		}
+		if report.Rows[i].Usage.CostUSD != report.Rows[j].Usage.CostUSD {
+			return report.Rows[i].Usage.CostUSD > report.Rows[j].Usage.CostUSD
+		}
+		return report.Rows[i].Key < report.Rows[j].Key
+	})
+	return report
+}
+
+// BudgetAlert describes a budget that has been exceeded.
+type BudgetAlert struct {
+	Scope    string  `json:"scope"` // "town" or rig name
+	LimitUSD float64 `json:"limit_usd"`
+	SpentUSD float64 `json:"spent_usd"`
+}
+
+// String returns a human-readable description of the alert.
+func (a BudgetAlert) String() string {
+	return fmt.Sprintf("%s spent $%.2f today (budget $%.2f)", a.Scope, a.SpentUSD, a.LimitUSD)
+}
+
+// CheckDailyBudget compares today's spend against a town-wide daily limit and
+// optional per-rig limits. Limits of zero are ignored.
+func CheckDailyBudget(records []Record, now time.Time, townLimit float64, rigLimits map[string]float64) []BudgetAlert {
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var town float64
+	byRig := make(map[string]float64)
+	for _, rec := range records {
+		if rec.Timestamp.Before(dayStart) {
+			continue
+		}
+		town += rec.Usage.CostUSD
+		byRig[rec.Rig] += rec.Usage.CostUSD
+	}
+
+	var alerts []BudgetAlert
+	if townLimit > 0 && town > townLimit {
+		alerts = append(alerts, BudgetAlert{Scope: "town", LimitUSD: townLimit, SpentUSD: town})
+	}
+
+	rigs := make([]string, 0, len(rigLimits))
+	for rigName := range rigLimits {
+		rigs = append(rigs, rigName)
+	}
+	sort.Strings(rigs)
+	for _, rigName := range rigs {
+		limit := rigLimits[rigName]
+		if limit > 0 && byRig[rigName] > limit {
+			alerts = append(alerts, BudgetAlert{Scope: rigName, LimitUSD: limit, SpentUSD: byRig[rigName]})
+		}
+	}
+	return alerts
+}
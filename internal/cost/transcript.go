@@ -0,0 +1,241 @@
+// Package cost tracks agent token usage and estimated API cost.
+//
+// Usage is recovered by parsing the agent's own session transcripts
+// (Claude Code writes one JSONL file per session under
+// ~/.claude/projects/), so no wrapping of the agent process is needed.
+// Records are attributed to a rig, actor, and bead using the working
+// directory and git branch recorded alongside each assistant turn.
+package cost
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Usage holds token counts and estimated cost for one or more agent turns.
+type Usage struct {
+	InputTokens         int64   `json:"input_tokens"`
+	OutputTokens        int64   `json:"output_tokens"`
+	CacheCreationTokens int64   `json:"cache_creation_tokens"`
+	CacheReadTokens     int64   `json:"cache_read_tokens"`
+	CostUSD             float64 `json:"cost_usd"`
+}
+
+// Add accumulates other into u.
+func (u *Usage) Add(other Usage) {
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.CacheCreationTokens += other.CacheCreationTokens
+	u.CacheReadTokens += other.CacheReadTokens
+	u.CostUSD += other.CostUSD
+}
+
+// TotalTokens returns the sum of all token counts.
+func (u Usage) TotalTokens() int64 {
+	return u.InputTokens + u.OutputTokens + u.CacheCreationTokens + u.CacheReadTokens
+}
+
+// Record is a single assistant turn with usage, attributed to a Gas Town agent.
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	SessionID string    `json:"session_id"`
+	Model     string    `json:"model"`
+	Cwd       string    `json:"cwd"`
+	Branch    string    `json:"branch,omitempty"`
+	Rig       string    `json:"rig,omitempty"`
+	Actor     string    `json:"actor,omitempty"`
+	Bead      string    `json:"bead,omitempty"`
+	Usage     Usage     `json:"usage"`
+}
+
+// transcriptLine is the subset of a Claude Code transcript line we use.
+type transcriptLine struct {
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
+	SessionID string `json:"sessionId"`
+	Cwd       string `json:"cwd"`
+	GitBranch string `json:"gitBranch"`
+	Message   struct {
+		ID    string `json:"id"`
+		Model string `json:"model"`
+		Usage *struct {
+			InputTokens              int64 `json:"input_tokens"`
+			OutputTokens             int64 `json:"output_tokens"`
+			CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+			CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+}
+
+// ParseTranscript reads a Claude Code JSONL transcript and returns one record
+// per assistant message that carries usage data. Malformed lines are skipped.
+// Streaming transcripts may repeat a message ID; only the last entry for each
+// ID is kept so usage is not double-counted.
+func ParseTranscript(r io.Reader) ([]Record, error) {
+	var records []Record
+	byMessage := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var line transcriptLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue
+		}
+		if line.Type != "assistant" || line.Message.Usage == nil {
+			continue
+		}
+
+		ts, _ := time.Parse(time.RFC3339Nano, line.Timestamp)
+		u := line.Message.Usage
+		rec := Record{
+			Timestamp: ts,
+			SessionID: line.SessionID,
+			Model:     line.Message.Model,
+			Cwd:       line.Cwd,
+			Branch:    line.GitBranch,
+			Usage: Usage{
+				InputTokens:         u.InputTokens,
+				OutputTokens:        u.OutputTokens,
+				CacheCreationTokens: u.CacheCreationInputTokens,
+				CacheReadTokens:     u.CacheReadInputTokens,
+			},
+		}
+		rec.Usage.CostUSD = EstimateCost(rec.Model, rec.Usage)
+
+		if id := line.Message.ID; id != "" {
+			if idx, ok := byMessage[id]; ok {
+				records[idx] = rec
+				continue
+			}
+			byMessage[id] = len(records)
+		}
+		records = append(records, rec)
+	}
+	return records, scanner.Err()
+}
+
+// DefaultTranscriptDirs returns the directories that may contain agent
+// transcripts: $CLAUDE_CONFIG_DIR/projects and ~/.claude/projects.
+func DefaultTranscriptDirs() []string {
+	var dirs []string
+	if dir := os.Getenv("CLAUDE_CONFIG_DIR"); dir != "" {
+		dirs = append(dirs, filepath.Join(dir, "projects"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".claude", "projects"))
+	}
+	return dirs
+}
+
+// ScanOptions controls which transcript records are collected.
+type ScanOptions struct {
+	// TownRoot restricts records to sessions whose cwd is inside the town.
+	TownRoot string
+
+	// Since drops records older than this time (zero means no limit).
+	Since time.Time
+
+	// Dirs are the transcript directories to scan.
+	// Defaults to DefaultTranscriptDirs().
+	Dirs []string
+}
+
+// Scan walks the transcript directories and returns attributed records for
+// agents running inside the town.
+func Scan(opts ScanOptions) ([]Record, error) {
+	dirs := opts.Dirs
+	if len(dirs) == 0 {
+		dirs = DefaultTranscriptDirs()
+	}
+
+	var records []Record
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".jsonl") {
+				return nil
+			}
+			if seen[path] {
+				return nil
+			}
+			seen[path] = true
+
+			// Skip files not modified since the window started.
+			if !opts.Since.IsZero() {
+				if info, err := d.Info(); err == nil && info.ModTime().Before(opts.Since) {
+					return nil
+				}
+			}
+
+			f, err := os.Open(path) //nolint:gosec // G304: path comes from walking the transcript dir
+			if err != nil {
+				return nil
+			}
+			defer f.Close()
+
+			fileRecords, _ := ParseTranscript(f)
+			for _, rec := range fileRecords {
+				if !opts.Since.IsZero() && rec.Timestamp.Before(opts.Since) {
+					continue
+				}
+				if opts.TownRoot != "" && !Attribute(&rec, opts.TownRoot) {
+					continue
+				}
+				records = append(records, rec)
+			}
+			return nil
+		})
+	}
+	return records, nil
+}
+
+// Attribute fills in Rig, Actor, and Bead for a record based on its cwd and
+// branch. Returns false if the cwd is not inside townRoot.
+//
+// Actor uses the same address form as mail ("rig/polecats/name",
+// "rig/crew/name", "rig/witness", "mayor", "deacon").
+func Attribute(rec *Record, townRoot string) bool {
+	rel, err := filepath.Rel(townRoot, rec.Cwd)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+
+	switch {
+	case parts[0] == "." || parts[0] == "":
+		rec.Actor = "mayor"
+	case parts[0] == "mayor" || parts[0] == "deacon":
+		rec.Actor = parts[0]
+	default:
+		rec.Rig = parts[0]
+		rec.Actor = parts[0]
+		if len(parts) >= 3 && (parts[1] == "polecats" || parts[1] == "crew") {
+			rec.Actor = parts[0] + "/" + parts[1] + "/" + parts[2]
+		} else if len(parts) >= 2 {
+			rec.Actor = parts[0] + "/" + parts[1]
+		}
+	}
+
+	rec.Bead = BeadFromBranch(rec.Branch)
+	return true
+}
+
+// BeadFromBranch extracts the hooked bead ID from a polecat branch name of
+// the form "polecat/<name>/<bead>@<timestamp>". Returns "" if none.
+func BeadFromBranch(branch string) string {
+	parts := strings.Split(branch, "/")
+	if len(parts) != 3 || parts[0] != "polecat" {
+		return ""
+	}
+	bead := parts[2]
+	if idx := strings.Index(bead, "@"); idx >= 0 {
+		bead = bead[:idx]
+	}
+	return bead
+}
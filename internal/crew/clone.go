@@ -0,0 +1,197 @@
+package crew
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// cloneLocalFiles are per-workspace agent customizations that are usually
+// gitignored, so they don't travel with the branch or the uncommitted patch.
+var cloneLocalFiles = []string{
+	"CLAUDE.md",
+	"CLAUDE.local.md",
+	filepath.Join(".claude", "settings.local.json"),
+}
+
+// CloneResult reports what Clone copied.
+type CloneResult struct {
+	Worker    *CrewWorker
+	Head      string   // commit the new workspace was checked out at
+	HasPatch  bool     // uncommitted changes were applied
+	Untracked []string // untracked files copied
+	Mail      []string // mail files copied (inbox and archive)
+	Local     []string // agent customization files copied
+}
+
+// Clone creates a new crew workspace destName from srcName's current state:
+// its branch and unpushed commits, uncommitted and untracked work, the
+// workspace mail directory (inbox and archive), and CLAUDE.md customizations.
+//
+// A crew/<src> feature branch becomes crew/<dest>; any other branch keeps its
+// name. The source workspace is not modified. If copying fails, the new
+// workspace is removed.
+func (m *Manager) Clone(srcName, destName string) (*CloneResult, error) {
+	src, err := m.Get(srcName)
+	if err != nil {
+		return nil, err
+	}
+	srcGit := git.NewGit(src.ClonePath)
+	head, err := srcGit.Rev("HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("reading %s HEAD: %w", srcName, err)
+	}
+	srcBranch, err := srcGit.CurrentBranch()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s branch: %w", srcName, err)
+	}
+
+	// Capture uncommitted work before creating anything
+	stage, err := os.MkdirTemp("", "gt-crew-clone-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(stage)
+	patch := filepath.Join(stage, "wip.patch")
+	if err := srcGit.DiffToFile(patch, "HEAD"); err != nil {
+		return nil, fmt.Errorf("saving %s uncommitted changes: %w", srcName, err)
+	}
+	untracked, err := srcGit.UntrackedFiles()
+	if err != nil {
+		return nil, fmt.Errorf("listing %s untracked files: %w", srcName, err)
+	}
+
+	worker, err := m.Add(destName, false)
+	if err != nil {
+		return nil, err
+	}
+	res, err := m.cloneInto(src, worker, head, srcBranch, patch, untracked)
+	if err != nil {
+		_ = m.removeWorkspace(worker.ClonePath) // best-effort cleanup
+		return nil, err
+	}
+	return res, nil
+}
+
+func (m *Manager) cloneInto(src, worker *CrewWorker, head, srcBranch, patch string, untracked []string) (*CloneResult, error) {
+	res := &CloneResult{Worker: worker, Head: head}
+	g := git.NewGit(worker.ClonePath)
+
+	// Add() touches .gitignore; start from a clean tree so checkout and the
+	// source patch apply cleanly.
+	if err := g.ResetHard("HEAD"); err != nil {
+		return nil, fmt.Errorf("resetting new workspace: %w", err)
+	}
+
+	// Fetch straight from the source clone: it may hold unpushed commits.
+	ref := "HEAD"
+	if srcBranch != "HEAD" {
+		ref = "refs/heads/" + srcBranch
+	}
+	if err := g.FetchRef(src.ClonePath, ref); err != nil {
+		return nil, fmt.Errorf("fetching from %s: %w", src.Name, err)
+	}
+	branch := srcBranch
+	if branch == "crew/"+src.Name {
+		branch = "crew/" + worker.Name
+	}
+	if branch == "HEAD" {
+		if err := g.Checkout(head); err != nil {
+			return nil, fmt.Errorf("checking out %s: %w", head, err)
+		}
+	} else if err := g.CheckoutBranchAt(branch, head); err != nil {
+		return nil, fmt.Errorf("checking out %s: %w", branch, err)
+	}
+	worker.Branch = branch
+
+	if info, err := os.Stat(patch); err == nil && info.Size() > 0 {
+		if err := g.ApplyPatch(patch); err != nil {
+			return nil, fmt.Errorf("applying uncommitted changes: %w", err)
+		}
+		res.HasPatch = true
+	}
+	if err := rig.EnsureGitignorePatterns(worker.ClonePath); err != nil {
+		worker.Warnings = append(worker.Warnings, fmt.Sprintf("could not update .gitignore: %v", err))
+	}
+
+	for _, rel := range untracked {
+		// Worker state and mail live in the workspace but are per-identity.
+		if rel == "state.json" || strings.HasPrefix(filepath.ToSlash(rel), "mail/") {
+			continue
+		}
+		copied, err := copyIfRegular(filepath.Join(src.ClonePath, rel), filepath.Join(worker.ClonePath, rel), true)
+		if err != nil {
+			return nil, fmt.Errorf("copying untracked file %s: %w", rel, err)
+		}
+		if copied {
+			res.Untracked = append(res.Untracked, rel)
+		}
+	}
+
+	// Workspace mail directory: the add left an empty one.
+	if entries, err := os.ReadDir(m.mailDir(src.Name)); err == nil {
+		for _, e := range entries {
+			copied, err := copyIfRegular(filepath.Join(m.mailDir(src.Name), e.Name()), filepath.Join(m.mailDir(worker.Name), e.Name()), true)
+			if err != nil {
+				return nil, fmt.Errorf("copying mail %s: %w", e.Name(), err)
+			}
+			if copied {
+				res.Mail = append(res.Mail, e.Name())
+			}
+		}
+	}
+
+	// Tracked or untracked customizations were handled above; only fill gaps.
+	for _, rel := range cloneLocalFiles {
+		copied, err := copyIfRegular(filepath.Join(src.ClonePath, rel), filepath.Join(worker.ClonePath, rel), false)
+		if err != nil {
+			return nil, fmt.Errorf("copying %s: %w", rel, err)
+		}
+		if copied {
+			res.Local = append(res.Local, rel)
+		}
+	}
+
+	if err := m.saveState(worker); err != nil {
+		return nil, fmt.Errorf("saving state: %w", err)
+	}
+	return res, nil
+}
+
+// copyIfRegular copies src to dst if src is a regular file, preserving its
+// mode. Unless overwrite is set, an existing dst is left alone.
+// Reports whether a copy was made.
+func copyIfRegular(src, dst string, overwrite bool) (bool, error) {
+	info, err := os.Lstat(src)
+	if err != nil || !info.Mode().IsRegular() {
+		return false, nil
+	}
+	if !overwrite {
+		if _, err := os.Lstat(dst); err == nil {
+			return false, nil
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return false, err
+	}
+
+	in, err := os.Open(src) //nolint:gosec // G304: path within a crew workspace
+	if err != nil {
+		return false, err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm()) //nolint:gosec // G304: path within a crew workspace
+	if err != nil {
+		return false, err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return false, err
+	}
+	return true, out.Close()
+}
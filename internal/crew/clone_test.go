@@ -0,0 +1,111 @@
+package crew
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestManagerClone(t *testing.T) {
+	for _, kv := range [][2]string{
+		{"GIT_AUTHOR_NAME", "Test"}, {"GIT_AUTHOR_EMAIL", "test@test.com"},
+		{"GIT_COMMITTER_NAME", "Test"}, {"GIT_COMMITTER_EMAIL", "test@test.com"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+	tmpDir := t.TempDir()
+
+	rigPath := filepath.Join(tmpDir, "test-rig")
+	if err := os.MkdirAll(rigPath, 0755); err != nil {
+		t.Fatalf("failed to create rig dir: %v", err)
+	}
+	sourceRepoPath := filepath.Join(tmpDir, "source-repo")
+	cmds := [][]string{
+		{"git", "init", sourceRepoPath},
+		{"git", "-C", sourceRepoPath, "commit", "--allow-empty", "-m", "Initial commit"},
+	}
+	for _, cmd := range cmds {
+		if err := runCmd(cmd[0], cmd[1:]...); err != nil {
+			t.Fatalf("failed to run %v: %v", cmd, err)
+		}
+	}
+
+	r := &rig.Rig{Name: "test-rig", Path: rigPath, GitURL: sourceRepoPath}
+	mgr := NewManager(r, git.NewGit(rigPath))
+
+	src, err := mgr.Add("dave", true)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	// Unpushed commit, uncommitted change, untracked file, mail, customization
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(src.ClonePath, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("committed.txt", "v1\n")
+	for _, cmd := range [][]string{
+		{"git", "-C", src.ClonePath, "add", "committed.txt"},
+		{"git", "-C", src.ClonePath, "commit", "-m", "unpushed work"},
+	} {
+		if err := runCmd(cmd[0], cmd[1:]...); err != nil {
+			t.Fatalf("failed to run %v: %v", cmd, err)
+		}
+	}
+	write("committed.txt", "v2\n")
+	write("notes/todo.txt", "todo\n")
+	write(filepath.Join("mail", "inbox.jsonl.archive"), "{}\n")
+	write(filepath.Join(".claude", "settings.local.json"), "{}\n")
+
+	res, err := mgr.Clone("dave", "dave2")
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+
+	if res.Worker.Branch != "crew/dave2" {
+		t.Errorf("branch = %q, want crew/dave2", res.Worker.Branch)
+	}
+	if !res.HasPatch {
+		t.Error("expected uncommitted changes to be applied")
+	}
+	dest := res.Worker.ClonePath
+	for rel, want := range map[string]string{
+		"committed.txt":  "v2\n",
+		"notes/todo.txt": "todo\n",
+		filepath.Join("mail", "inbox.jsonl.archive"):    "{}\n",
+		filepath.Join(".claude", "settings.local.json"): "{}\n",
+	} {
+		got, err := os.ReadFile(filepath.Join(dest, rel))
+		if err != nil {
+			t.Errorf("%s not copied: %v", rel, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", rel, got, want)
+		}
+	}
+
+	worker, err := mgr.Get("dave2")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if worker.Name != "dave2" || worker.Branch != "crew/dave2" {
+		t.Errorf("state = %+v, want name dave2 on crew/dave2", worker)
+	}
+
+	if _, err := mgr.Clone("dave", "dave2"); err != ErrCrewExists {
+		t.Errorf("second Clone error = %v, want ErrCrewExists", err)
+	}
+	if _, err := mgr.Clone("nobody", "x"); err != ErrCrewNotFound {
+		t.Errorf("Clone of missing source error = %v, want ErrCrewNotFound", err)
+	}
+}
@@ -0,0 +1,115 @@
+package crew
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrHandoffNotFound is returned when a handoff sequence number isn't in the log.
+var ErrHandoffNotFound = errors.New("handoff not found")
+
+// HandoffRecord is one entry in a crew worker's handoff log.
+type HandoffRecord struct {
+	Seq       int       `json:"seq"` // 1-based, per worker
+	Timestamp time.Time `json:"timestamp"`
+	Worker    string    `json:"worker"` // rig/crew/name
+	Subject   string    `json:"subject"`
+	Message   string    `json:"message"`
+	ReplayOf  int       `json:"replay_of,omitempty"` // seq of the replayed handoff
+}
+
+// handoffLogPath returns the handoff log for a crew worker. It lives under
+// .runtime/ so it stays out of git but moves with the workspace.
+func (m *Manager) handoffLogPath(name string) string {
+	return filepath.Join(m.crewDir(name), ".runtime", "handoffs.jsonl")
+}
+
+// RecordHandoff appends a handoff to the worker's log and returns the record.
+func (m *Manager) RecordHandoff(name, subject, message string, replayOf int) (*HandoffRecord, error) {
+	existing, err := m.Handoffs(name)
+	if err != nil {
+		return nil, err
+	}
+	rec := &HandoffRecord{
+		Seq:       len(existing) + 1,
+		Timestamp: time.Now().UTC(),
+		Worker:    fmt.Sprintf("%s/crew/%s", m.rig.Name, name),
+		Subject:   subject,
+		Message:   message,
+		ReplayOf:  replayOf,
+	}
+	if n := len(existing); n > 0 && existing[n-1].Seq >= rec.Seq {
+		rec.Seq = existing[n-1].Seq + 1
+	}
+
+	path := m.handoffLogPath(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("creating handoff log dir: %w", err)
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		return nil, fmt.Errorf("opening handoff log: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("writing handoff log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("writing handoff log: %w", err)
+	}
+	return rec, nil
+}
+
+// Handoffs returns the worker's handoff log, oldest first.
+// Malformed lines are skipped.
+func (m *Manager) Handoffs(name string) ([]*HandoffRecord, error) {
+	if !m.exists(name) {
+		return nil, ErrCrewNotFound
+	}
+	f, err := os.Open(m.handoffLogPath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading handoff log: %w", err)
+	}
+	defer f.Close()
+
+	var records []*HandoffRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var rec HandoffRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			continue
+		}
+		records = append(records, &rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading handoff log: %w", err)
+	}
+	return records, nil
+}
+
+// Handoff returns the handoff with the given sequence number.
+func (m *Manager) Handoff(name string, seq int) (*HandoffRecord, error) {
+	records, err := m.Handoffs(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		if rec.Seq == seq {
+			return rec, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: #%d for %s", ErrHandoffNotFound, seq, name)
+}
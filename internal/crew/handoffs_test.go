@@ -0,0 +1,51 @@
+package crew
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestHandoffLog(t *testing.T) {
+	rigPath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(rigPath, "crew", "dave"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mgr := NewManager(&rig.Rig{Name: "gastown", Path: rigPath}, nil)
+
+	if records, err := mgr.Handoffs("dave"); err != nil || len(records) != 0 {
+		t.Fatalf("Handoffs on empty log = %v, %v; want none", records, err)
+	}
+
+	first, err := mgr.RecordHandoff("dave", "HANDOFF", "working on gt-123\nnext: tests", 0)
+	if err != nil {
+		t.Fatalf("RecordHandoff: %v", err)
+	}
+	second, err := mgr.RecordHandoff("dave", "HANDOFF", first.Message, first.Seq)
+	if err != nil {
+		t.Fatalf("RecordHandoff replay: %v", err)
+	}
+	if first.Seq != 1 || second.Seq != 2 || second.ReplayOf != 1 {
+		t.Errorf("seqs = %d, %d (replay of %d); want 1, 2 (replay of 1)", first.Seq, second.Seq, second.ReplayOf)
+	}
+	if first.Worker != "gastown/crew/dave" {
+		t.Errorf("Worker = %q, want gastown/crew/dave", first.Worker)
+	}
+
+	got, err := mgr.Handoff("dave", 1)
+	if err != nil {
+		t.Fatalf("Handoff(1): %v", err)
+	}
+	if got.Message != "working on gt-123\nnext: tests" {
+		t.Errorf("Message = %q", got.Message)
+	}
+	if _, err := mgr.Handoff("dave", 9); !errors.Is(err, ErrHandoffNotFound) {
+		t.Errorf("Handoff(9) error = %v, want ErrHandoffNotFound", err)
+	}
+	if _, err := mgr.Handoffs("nobody"); !errors.Is(err, ErrCrewNotFound) {
+		t.Errorf("Handoffs(nobody) error = %v, want ErrCrewNotFound", err)
+	}
+}
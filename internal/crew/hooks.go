@@ -0,0 +1,54 @@
+package crew
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// Crew hooks are rig-level executables in <rig>/.runtime/hooks/ that run in
+// a worker's workspace at points in its session lifecycle.
+const (
+	// HookPostCreate runs after 'gt crew at' creates a worker's session,
+	// before the agent starts (e.g., to install project dependencies).
+	HookPostCreate = "crew-post-create"
+
+	// HookPreAttach runs before 'gt crew at' hands a session to the user
+	// (e.g., to make sure a dev server is up).
+	HookPreAttach = "crew-pre-attach"
+)
+
+// HooksDir returns the directory holding a rig's crew hooks.
+func HooksDir(rigPath string) string {
+	return filepath.Join(rigPath, ".runtime", "hooks")
+}
+
+// RunHook runs the named rig hook in worker name's workspace, with GT_RIG,
+// GT_CREW, GT_ROOT, GT_SESSION, and GT_HOOK set and output passed through.
+// Hooks run to completion, so long-running processes such as dev servers
+// must background themselves. Returns false if the rig has no executable
+// hook by that name.
+func (m *Manager) RunHook(hook, name string) (bool, error) {
+	path := filepath.Join(HooksDir(m.rig.Path), hook)
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.Mode().Perm()&0111 == 0 {
+		return false, nil
+	}
+
+	cmd := exec.Command(path) //nolint:gosec // G204: hooks are installed by the rig owner
+	cmd.Dir = m.crewDir(name)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"GT_RIG="+m.rig.Name,
+		"GT_CREW="+name,
+		"GT_ROOT="+filepath.Dir(m.rig.Path),
+		"GT_SESSION="+m.SessionName(name),
+		"GT_HOOK="+hook,
+	)
+	if err := cmd.Run(); err != nil {
+		return true, fmt.Errorf("%s hook: %w", hook, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,60 @@
+package crew
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestManagerRunHook(t *testing.T) {
+	tmpDir := t.TempDir()
+	rigPath := filepath.Join(tmpDir, "test-rig")
+	workspace := filepath.Join(rigPath, "crew", "dave")
+	if err := os.MkdirAll(workspace, 0755); err != nil {
+		t.Fatal(err)
+	}
+	mgr := NewManager(&rig.Rig{Name: "test-rig", Path: rigPath}, git.NewGit(rigPath))
+
+	// No hooks directory: nothing runs
+	if ran, err := mgr.RunHook(HookPostCreate, "dave"); ran || err != nil {
+		t.Fatalf("RunHook without hook = %v, %v; want false, nil", ran, err)
+	}
+
+	hooksDir := HooksDir(rigPath)
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\necho \"$GT_RIG $GT_CREW $GT_HOOK $(pwd)\" > hook.out\n"
+	if err := os.WriteFile(filepath.Join(hooksDir, HookPostCreate), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ran, err := mgr.RunHook(HookPostCreate, "dave")
+	if !ran || err != nil {
+		t.Fatalf("RunHook = %v, %v; want true, nil", ran, err)
+	}
+	out, err := os.ReadFile(filepath.Join(workspace, "hook.out"))
+	if err != nil {
+		t.Fatalf("hook did not run in the workspace: %v", err)
+	}
+	if got := strings.Fields(string(out)); len(got) != 4 || got[0] != "test-rig" || got[1] != "dave" || got[2] != HookPostCreate {
+		t.Errorf("hook saw %q, want rig, crew, and hook name", out)
+	}
+
+	// Non-executable files are ignored; failing hooks report an error
+	if err := os.WriteFile(filepath.Join(hooksDir, HookPreAttach), []byte("#!/bin/sh\nexit 3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if ran, _ := mgr.RunHook(HookPreAttach, "dave"); ran {
+		t.Error("non-executable hook ran")
+	}
+	if err := os.Chmod(filepath.Join(hooksDir, HookPreAttach), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if ran, err := mgr.RunHook(HookPreAttach, "dave"); !ran || err == nil {
+		t.Errorf("failing hook = %v, %v; want true and an error", ran, err)
+	}
+}
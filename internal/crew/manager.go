@@ -13,6 +13,7 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/claude"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
@@ -54,6 +55,16 @@ type StartOptions struct {
 	AgentOverride string
 }
 
+// AddOptions configures crew workspace creation.
+type AddOptions struct {
+	// CreateBranch puts the workspace on a crew/<name> feature branch.
+	CreateBranch bool
+
+	// Sparse are sparse-checkout patterns for the workspace, used instead
+	// of the rig's checkout.sparse. Empty: the rig's patterns.
+	Sparse []string
+}
+
 // validateCrewName checks that a crew name is safe and valid.
 // Rejects path traversal attempts and characters that break agent ID parsing.
 func validateCrewName(name string) error {
@@ -113,8 +124,14 @@ func (m *Manager) exists(name string) bool {
 	return err == nil
 }
 
-// Add creates a new crew worker with a clone of the rig.
+// Add creates a new crew worker with a clone of the rig, or a worktree of
+// its shared repo when the rig's checkout mode is worktree.
 func (m *Manager) Add(name string, createBranch bool) (*CrewWorker, error) {
+	return m.AddWithOptions(name, AddOptions{CreateBranch: createBranch})
+}
+
+// AddWithOptions creates a new crew worker with the given options.
+func (m *Manager) AddWithOptions(name string, opts AddOptions) (*CrewWorker, error) {
 	if err := validateCrewName(name); err != nil {
 		return nil, err
 	}
@@ -130,32 +147,42 @@ func (m *Manager) Add(name string, createBranch bool) (*CrewWorker, error) {
 		return nil, fmt.Errorf("creating crew dir: %w", err)
 	}
 
-	// Clone the rig repo
-	if m.rig.LocalRepo != "" {
-		if err := m.git.CloneWithReference(m.rig.GitURL, crewPath, m.rig.LocalRepo); err != nil {
-			fmt.Printf("Warning: could not clone with local repo reference: %v\n", err)
-			if err := m.git.Clone(m.rig.GitURL, crewPath); err != nil {
-				return nil, fmt.Errorf("cloning rig: %w", err)
-			}
-		}
-	} else {
-		if err := m.git.Clone(m.rig.GitURL, crewPath); err != nil {
-			return nil, fmt.Errorf("cloning rig: %w", err)
+	// Non-fatal problems go back to the caller with the worker: bulk adds
+	// run concurrently and report per worker.
+	var warnings []string
+	warn := func(format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}
+
+	checkout := m.checkout()
+	if len(opts.Sparse) > 0 {
+		checkout.Sparse = opts.Sparse
+	}
+
+	branchName := m.rig.DefaultBranch()
+	worktree := checkout.Mode == config.CheckoutWorktree
+	if worktree {
+		// Check out from the rig's shared repo instead of cloning
+		branch, err := m.addWorktree(name, crewPath, opts.CreateBranch, checkout.Sparse, warn)
+		if err != nil {
+			return nil, err
 		}
+		branchName = branch
+	} else if err := m.cloneRig(crewPath, checkout, warn); err != nil {
+		return nil, err
 	}
 
 	crewGit := git.NewGit(crewPath)
-	branchName := m.rig.DefaultBranch()
 
-	// Optionally create a working branch
-	if createBranch {
+	// Optionally create a working branch (a worktree is already on one)
+	if opts.CreateBranch && !worktree {
 		branchName = fmt.Sprintf("crew/%s", name)
 		if err := crewGit.CreateBranch(branchName); err != nil {
-			_ = os.RemoveAll(crewPath) // best-effort cleanup
+			_ = m.removeWorkspace(crewPath) // best-effort cleanup
 			return nil, fmt.Errorf("creating branch: %w", err)
 		}
 		if err := crewGit.Checkout(branchName); err != nil {
-			_ = os.RemoveAll(crewPath) // best-effort cleanup
+			_ = m.removeWorkspace(crewPath) // best-effort cleanup
 			return nil, fmt.Errorf("checking out branch: %w", err)
 		}
 	}
@@ -163,14 +190,14 @@ func (m *Manager) Add(name string, createBranch bool) (*CrewWorker, error) {
 	// Create mail directory for mail delivery
 	mailPath := m.mailDir(name)
 	if err := os.MkdirAll(mailPath, 0755); err != nil {
-		_ = os.RemoveAll(crewPath) // best-effort cleanup
+		_ = m.removeWorkspace(crewPath) // best-effort cleanup
 		return nil, fmt.Errorf("creating mail dir: %w", err)
 	}
 
 	// Set up shared beads: crew uses rig's shared beads via redirect file
 	if err := m.setupSharedBeads(crewPath); err != nil {
 		// Non-fatal - crew can still work, warn but don't fail
-		fmt.Printf("Warning: could not set up shared beads: %v\n", err)
+		warn("could not set up shared beads: %v", err)
 	}
 
 	// Provision PRIME.md with Gas Town context for this worker.
@@ -178,20 +205,20 @@ func (m *Manager) Add(name string, createBranch bool) (*CrewWorker, error) {
 	// always have GUPP and essential Gas Town context.
 	if err := beads.ProvisionPrimeMDForWorktree(crewPath); err != nil {
 		// Non-fatal - crew can still work via hook, warn but don't fail
-		fmt.Printf("Warning: could not provision PRIME.md: %v\n", err)
+		warn("could not provision PRIME.md: %v", err)
 	}
 
 	// Copy overlay files from .runtime/overlay/ to crew root.
 	// This allows services to have .env and other config files at their root.
 	if err := rig.CopyOverlay(m.rig.Path, crewPath); err != nil {
 		// Non-fatal - log warning but continue
-		fmt.Printf("Warning: could not copy overlay files: %v\n", err)
+		warn("could not copy overlay files: %v", err)
 	}
 
 	// Ensure .gitignore has required Gas Town patterns
 	if err := rig.EnsureGitignorePatterns(crewPath); err != nil {
 		// Non-fatal - log warning but continue
-		fmt.Printf("Warning: could not update .gitignore: %v\n", err)
+		warn("could not update .gitignore: %v", err)
 	}
 
 	// NOTE: Slash commands (.claude/commands/) are provisioned at town level by gt install.
@@ -211,17 +238,44 @@ func (m *Manager) Add(name string, createBranch bool) (*CrewWorker, error) {
 		Branch:    branchName,
 		CreatedAt: now,
 		UpdatedAt: now,
+		Warnings:  warnings,
 	}
 
 	// Save state
 	if err := m.saveState(crew); err != nil {
-		_ = os.RemoveAll(crewPath) // best-effort cleanup
+		_ = m.removeWorkspace(crewPath) // best-effort cleanup
 		return nil, fmt.Errorf("saving state: %w", err)
 	}
 
+	m.publish(name, events.CrewEvent{Type: events.TypeCrewAdded, Rig: m.rig.Name, Name: name})
 	return crew, nil
 }
 
+// cloneRig clones the rig repo to crewPath as the rig's checkout settings
+// say, borrowing objects from the rig's local repo when one is configured.
+func (m *Manager) cloneRig(crewPath string, checkout config.CheckoutConfig, warn func(string, ...interface{})) error {
+	opts := git.CloneOptions{
+		Reference: m.rig.LocalRepo,
+		Depth:     checkout.Depth,
+		Filter:    checkout.Filter,
+		Sparse:    checkout.Sparse,
+	}
+	if opts.Reference != "" {
+		err := m.git.CloneWithOptions(m.rig.GitURL, crewPath, opts)
+		if err == nil {
+			return nil
+		}
+		warn("could not clone with local repo reference: %v", err)
+		_ = os.RemoveAll(crewPath) // a clone that failed after checkout
+		opts.Reference = ""
+	}
+	if err := m.git.CloneWithOptions(m.rig.GitURL, crewPath, opts); err != nil {
+		_ = os.RemoveAll(crewPath) // best-effort cleanup
+		return fmt.Errorf("cloning rig: %w", err)
+	}
+	return nil
+}
+
 // Remove deletes a crew worker.
 func (m *Manager) Remove(name string, force bool) error {
 	if err := validateCrewName(name); err != nil {
@@ -241,11 +295,12 @@ func (m *Manager) Remove(name string, force bool) error {
 		}
 	}
 
-	// Remove directory
-	if err := os.RemoveAll(crewPath); err != nil {
+	// Remove directory (and its worktree registration, if any)
+	if err := m.removeWorkspace(crewPath); err != nil {
 		return fmt.Errorf("removing crew dir: %w", err)
 	}
 
+	m.publish(name, events.CrewEvent{Type: events.TypeCrewRemoved, Rig: m.rig.Name, Name: name})
 	return nil
 }
 
@@ -334,8 +389,13 @@ func (m *Manager) loadState(name string) (*CrewWorker, error) {
 	return &crew, nil
 }
 
-// Rename renames a crew worker from oldName to newName.
+// Rename renames a crew worker from oldName to newName, moving its
+// directory and renaming a crew/<old> feature branch to crew/<new>.
+// Sessions and mail are the caller's concern.
 func (m *Manager) Rename(oldName, newName string) error {
+	if err := validateCrewName(newName); err != nil {
+		return err
+	}
 	if !m.exists(oldName) {
 		return ErrCrewNotFound
 	}
@@ -347,7 +407,7 @@ func (m *Manager) Rename(oldName, newName string) error {
 	newPath := m.crewDir(newName)
 
 	// Rename directory
-	if err := os.Rename(oldPath, newPath); err != nil {
+	if err := m.moveWorkspace(oldPath, newPath); err != nil {
 		return fmt.Errorf("renaming crew dir: %w", err)
 	}
 
@@ -355,7 +415,7 @@ func (m *Manager) Rename(oldName, newName string) error {
 	crew, err := m.loadState(newName)
 	if err != nil {
 		// Rollback on error (best-effort)
-		_ = os.Rename(newPath, oldPath)
+		_ = m.moveWorkspace(newPath, oldPath)
 		return fmt.Errorf("loading state: %w", err)
 	}
 
@@ -363,12 +423,24 @@ func (m *Manager) Rename(oldName, newName string) error {
 	crew.ClonePath = newPath
 	crew.UpdatedAt = time.Now()
 
+	// Follow the crew/<name> feature branch convention (see Add).
+	// Non-fatal: the workspace is usable on the old branch name.
+	if crew.Branch == "crew/"+oldName {
+		newBranch := "crew/" + newName
+		if err := git.NewGit(newPath).RenameBranch(crew.Branch, newBranch); err != nil {
+			fmt.Printf("Warning: could not rename branch %s: %v\n", crew.Branch, err)
+		} else {
+			crew.Branch = newBranch
+		}
+	}
+
 	if err := m.saveState(crew); err != nil {
 		// Rollback on error (best-effort)
-		_ = os.Rename(newPath, oldPath)
+		_ = m.moveWorkspace(newPath, oldPath)
 		return fmt.Errorf("saving state: %w", err)
 	}
 
+	m.publish(newName, events.CrewEvent{Type: events.TypeCrewRenamed, Rig: m.rig.Name, Name: oldName, NewName: newName})
 	return nil
 }
 
@@ -456,6 +528,9 @@ func (m *Manager) Start(name string, opts StartOptions) error {
 		if err != nil {
 			return fmt.Errorf("creating crew workspace: %w", err)
 		}
+		for _, w := range worker.Warnings {
+			fmt.Printf("Warning: %s\n", w)
+		}
 	} else if err != nil {
 		return fmt.Errorf("getting crew worker: %w", err)
 	}
@@ -521,14 +596,8 @@ func (m *Manager) Start(name string, opts StartOptions) error {
 		claudeCmd = strings.Replace(claudeCmd, " --dangerously-skip-permissions", "", 1)
 	}
 
-	// Create session with command directly to avoid send-keys race condition.
-	// See: https://github.com/anthropics/gastown/issues/280
-	if err := t.NewSessionWithCommand(sessionID, worker.ClonePath, claudeCmd); err != nil {
-		return fmt.Errorf("creating session: %w", err)
-	}
-
-	// Set environment variables (non-fatal: session works without these)
-	// Use centralized AgentEnv for consistency across all role startup paths
+	// Use centralized AgentEnv for consistency across all role startup paths,
+	// plus any extra env the rig declares in rigs.json
 	townRoot := filepath.Dir(m.rig.Path)
 	envVars := config.AgentEnv(config.AgentEnvConfig{
 		Role:             "crew",
@@ -538,7 +607,17 @@ func (m *Manager) Start(name string, opts StartOptions) error {
 		RuntimeConfigDir: opts.ClaudeConfigDir,
 		BeadsNoDaemon:    true,
 	})
-	for k, v := range envVars {
+	rigEnv := config.RigEnv(m.rig.Env, envVars)
+	claudeCmd = config.PrependEnv(claudeCmd, rigEnv)
+
+	// Create session with command directly to avoid send-keys race condition.
+	// See: https://github.com/anthropics/gastown/issues/280
+	if err := t.NewSessionWithCommand(sessionID, worker.ClonePath, claudeCmd); err != nil {
+		return fmt.Errorf("creating session: %w", err)
+	}
+
+	// Set environment variables (non-fatal: session works without these)
+	for k, v := range config.MergeEnv(rigEnv, envVars) {
 		_ = t.SetEnvironment(sessionID, k, v)
 	}
 
@@ -554,6 +633,7 @@ func (m *Manager) Start(name string, opts StartOptions) error {
 	// serves no purpose. If the caller needs to know when Claude is ready,
 	// they can check with IsClaudeRunning().
 
+	m.publish(name, events.SessionEvent{Type: events.TypeSessionCreated, Session: sessionID, Agent: m.address(name), Rig: m.rig.Name})
 	return nil
 }
 
@@ -582,9 +662,20 @@ func (m *Manager) Stop(name string) error {
 		return fmt.Errorf("killing session: %w", err)
 	}
 
+	m.publish(name, events.SessionEvent{Type: events.TypeSessionStopped, Session: sessionID, Agent: m.address(name), Rig: m.rig.Name})
 	return nil
 }
 
+// address returns a crew member's agent address, e.g. "gastown/crew/joe".
+func (m *Manager) address(name string) string {
+	return fmt.Sprintf("%s/crew/%s", m.rig.Name, name)
+}
+
+// publish records a lifecycle event for a crew member in the feed.
+func (m *Manager) publish(name string, e events.Typed) {
+	_ = events.Publish(m.address(name), e)
+}
+
 // IsRunning checks if a crew member's session is active.
 func (m *Manager) IsRunning(name string) (bool, error) {
 	t := tmux.NewTmux()
@@ -4,8 +4,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 )
@@ -229,6 +231,64 @@ func TestManagerAddWithBranch(t *testing.T) {
 	}
 }
 
+func TestManagerRenameWithBranch(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	rigPath := filepath.Join(tmpDir, "test-rig")
+	if err := os.MkdirAll(rigPath, 0755); err != nil {
+		t.Fatalf("failed to create rig dir: %v", err)
+	}
+
+	// Source repo with a commit so a feature branch can be created
+	sourceRepoPath := filepath.Join(tmpDir, "source-repo")
+	cmds := [][]string{
+		{"git", "init", sourceRepoPath},
+		{"git", "-C", sourceRepoPath, "config", "user.email", "test@test.com"},
+		{"git", "-C", sourceRepoPath, "config", "user.name", "Test"},
+		{"git", "-C", sourceRepoPath, "commit", "--allow-empty", "-m", "Initial commit"},
+	}
+	for _, cmd := range cmds {
+		if err := runCmd(cmd[0], cmd[1:]...); err != nil {
+			t.Fatalf("failed to run %v: %v", cmd, err)
+		}
+	}
+
+	r := &rig.Rig{
+		Name:   "test-rig",
+		Path:   rigPath,
+		GitURL: sourceRepoPath,
+	}
+	mgr := NewManager(r, git.NewGit(rigPath))
+
+	if _, err := mgr.Add("emma", true); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := mgr.Rename("emma", "bad/name"); err == nil {
+		t.Error("expected Rename to reject an invalid name")
+	}
+	if err := mgr.Rename("emma", "emily"); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+
+	worker, err := mgr.Get("emily")
+	if err != nil {
+		t.Fatalf("Get after rename failed: %v", err)
+	}
+	if worker.Branch != "crew/emily" {
+		t.Errorf("expected branch 'crew/emily', got '%s'", worker.Branch)
+	}
+	branch, err := git.NewGit(worker.ClonePath).CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch failed: %v", err)
+	}
+	if branch != "crew/emily" {
+		t.Errorf("expected checked-out branch 'crew/emily', got '%s'", branch)
+	}
+	if _, err := mgr.Get("emma"); err != ErrCrewNotFound {
+		t.Errorf("expected ErrCrewNotFound for old name, got %v", err)
+	}
+}
+
 func TestManagerList(t *testing.T) {
 	// Create temp directory for test
 	tmpDir, err := os.MkdirTemp("", "crew-test-list-*")
@@ -392,8 +452,156 @@ func TestManagerGetWithStaleStateName(t *testing.T) {
 	}
 }
 
+func TestManagerWorktreeMode(t *testing.T) {
+	tmpDir := t.TempDir()
+	rigPath := filepath.Join(tmpDir, "test-rig")
+	if err := os.MkdirAll(rigPath, 0755); err != nil {
+		t.Fatalf("failed to create rig dir: %v", err)
+	}
+
+	// Remote with one commit on main
+	remoteRepoPath := filepath.Join(tmpDir, "remote.git")
+	if err := runCmd("git", "init", "--bare", "-b", "main", remoteRepoPath); err != nil {
+		t.Fatalf("failed to create bare repo: %v", err)
+	}
+	seedPath := filepath.Join(tmpDir, "seed")
+	for _, args := range [][]string{
+		{"init", "-b", "main", seedPath},
+		{"-C", seedPath, "config", "user.email", "test@test.com"},
+		{"-C", seedPath, "config", "user.name", "Test"},
+		{"-C", seedPath, "commit", "--allow-empty", "-m", "initial"},
+		{"-C", seedPath, "push", remoteRepoPath, "main"},
+	} {
+		if err := runCmd("git", args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+
+	// The rig's shared repo, as gt rig add creates it
+	g := git.NewGit(rigPath)
+	if err := g.CloneBare(remoteRepoPath, filepath.Join(rigPath, ".repo.git")); err != nil {
+		t.Fatalf("CloneBare failed: %v", err)
+	}
+	settings := &config.RigSettings{
+		Type:     "rig-settings",
+		Version:  config.CurrentRigSettingsVersion,
+		Checkout: &config.CheckoutConfig{Mode: config.CheckoutWorktree},
+	}
+	if err := config.SaveRigSettings(config.RigSettingsPath(rigPath), settings); err != nil {
+		t.Fatalf("SaveRigSettings failed: %v", err)
+	}
+
+	r := &rig.Rig{
+		Name:   "test-rig",
+		Path:   rigPath,
+		GitURL: remoteRepoPath,
+	}
+	mgr := NewManager(r, g)
+	repoGit := git.NewGitWithDir(filepath.Join(rigPath, ".repo.git"), "")
+
+	worker, err := mgr.Add("dave", false)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if !isWorktree(worker.ClonePath) {
+		t.Fatalf("expected %s to be a worktree", worker.ClonePath)
+	}
+	if worker.Branch != "crew/dave" {
+		t.Errorf("expected branch crew/dave, got %q", worker.Branch)
+	}
+	upstream, err := git.NewGit(worker.ClonePath).Rev("@{upstream}")
+	if err != nil {
+		t.Fatalf("reading upstream: %v", err)
+	}
+	if main, _ := repoGit.Rev("origin/main"); upstream != main {
+		t.Errorf("expected crew/dave to track origin/main")
+	}
+
+	// Plain pushes go upstream from the crew worktree only, not from
+	// polecat worktrees of the same repo
+	out, err := exec.Command("git", "-C", worker.ClonePath, "config", "push.default").Output()
+	if err != nil || strings.TrimSpace(string(out)) != "upstream" {
+		t.Errorf("crew push.default = %q, %v; want upstream", out, err)
+	}
+	polecatPath := filepath.Join(tmpDir, "polecat")
+	if err := repoGit.WorktreeAddFromRef(polecatPath, "polecat/toast", "origin/main"); err != nil {
+		t.Fatalf("adding polecat worktree: %v", err)
+	}
+	if out, _ := exec.Command("git", "-C", polecatPath, "config", "push.default").Output(); len(out) != 0 {
+		t.Errorf("polecat push.default = %q, want unset", out)
+	}
+	if out, err := exec.Command("git", "-C", polecatPath, "rev-parse", "--is-inside-work-tree").Output(); err != nil || strings.TrimSpace(string(out)) != "true" {
+		t.Errorf("polecat worktree should still be a work tree: %q, %v", out, err)
+	}
+
+	// Rename moves the worktree with git
+	if err := mgr.Rename("dave", "emma"); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	worktreePaths := func() map[string]bool {
+		worktrees, err := repoGit.WorktreeList()
+		if err != nil {
+			t.Fatalf("WorktreeList failed: %v", err)
+		}
+		paths := make(map[string]bool)
+		for _, wt := range worktrees {
+			paths[filepath.Base(wt.Path)] = true
+		}
+		return paths
+	}
+	if paths := worktreePaths(); !paths["emma"] || paths["dave"] {
+		t.Errorf("expected worktree emma and not dave, got %v", paths)
+	}
+
+	// Remove prunes the worktree
+	if err := mgr.Remove("emma", true); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if paths := worktreePaths(); paths["emma"] {
+		t.Errorf("expected worktree emma to be pruned, got %v", paths)
+	}
+}
+
 // Helper to run commands
 func runCmd(name string, args ...string) error {
 	cmd := exec.Command(name, args...)
 	return cmd.Run()
 }
+
+func TestManagerAddReturnsWarnings(t *testing.T) {
+	tmpDir := t.TempDir()
+	rigPath := filepath.Join(tmpDir, "test-rig")
+	if err := os.MkdirAll(rigPath, 0755); err != nil {
+		t.Fatalf("failed to create rig dir: %v", err)
+	}
+	bareRepoPath := filepath.Join(tmpDir, "bare-repo.git")
+	if err := runCmd("git", "init", "--bare", bareRepoPath); err != nil {
+		t.Fatalf("failed to create bare repo: %v", err)
+	}
+
+	// The rig has no beads, so Add can't redirect the workspace to them;
+	// that's non-fatal and reported with the worker.
+	r := &rig.Rig{
+		Name:   "test-rig",
+		Path:   rigPath,
+		GitURL: bareRepoPath,
+	}
+	mgr := NewManager(r, git.NewGit(rigPath))
+
+	worker, err := mgr.Add("dave", false)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if len(worker.Warnings) == 0 || !strings.Contains(worker.Warnings[0], "shared beads") {
+		t.Errorf("Warnings = %q, want the shared beads failure", worker.Warnings)
+	}
+
+	// Warnings are for the caller, not the saved state
+	saved, err := mgr.Get("dave")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if len(saved.Warnings) != 0 {
+		t.Errorf("saved Warnings = %q, want none", saved.Warnings)
+	}
+}
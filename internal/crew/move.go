@@ -0,0 +1,211 @@
+package crew
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// MoveOptions configures Move.
+type MoveOptions struct {
+	// CherryPick replays the worker's commits that aren't on the source
+	// rig's default branch onto the new workspace.
+	CherryPick bool
+
+	// Keep leaves the source workspace in place.
+	Keep bool
+
+	// Force removes the source workspace even if it holds uncommitted
+	// changes or commits that aren't carried over.
+	Force bool
+}
+
+// MoveResult reports what Move carried over.
+type MoveResult struct {
+	Worker  *CrewWorker
+	Picked  []string // commits cherry-picked, oldest first
+	Dropped int      // unpushed source commits not carried over
+	Mail    []string // workspace mail files copied
+	Local   []string // agent customization and identity files copied
+	Skipped []string // customization files the target repo already has
+}
+
+// Move redeploys crew worker name from this rig into dest: dest's repo is
+// cloned into a new workspace of the same name, and the worker's mail
+// directory, CLAUDE.md customizations, launch settings, and handoff log are
+// carried over. With CherryPick, the worker's branch commits are replayed
+// on the new clone. The source workspace is removed unless Keep is set.
+//
+// Without Force, Move refuses to remove a source workspace that holds
+// uncommitted changes or unpushed commits it would leave behind. If anything
+// fails, the new workspace is removed and the source is left untouched.
+func (m *Manager) Move(name string, dest *Manager, opts MoveOptions) (*MoveResult, error) {
+	src, err := m.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if dest.rig.Name == m.rig.Name {
+		return nil, fmt.Errorf("%s is already in rig %s", name, m.rig.Name)
+	}
+	if dest.exists(name) {
+		return nil, ErrCrewExists
+	}
+
+	srcGit := git.NewGit(src.ClonePath)
+	srcBranch, err := srcGit.CurrentBranch()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s branch: %w", name, err)
+	}
+	base := "origin/" + m.rig.DefaultBranch()
+	commits, err := srcGit.CommitsBetween(base, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("listing %s commits not on %s: %w", name, base, err)
+	}
+
+	res := &MoveResult{}
+	if !opts.CherryPick {
+		res.Dropped = len(commits)
+	}
+	if !opts.Keep && !opts.Force {
+		if work, err := uncommittedWork(srcGit); err != nil {
+			return nil, fmt.Errorf("checking %s for uncommitted work: %w", name, err)
+		} else if len(work) > 0 {
+			return nil, fmt.Errorf("%w: %s", ErrHasChanges, strings.Join(work, ", "))
+		}
+		if res.Dropped > 0 {
+			return nil, fmt.Errorf("%s has %d commit(s) not on %s that would be lost (use cherry-pick, keep, or force)",
+				name, res.Dropped, base)
+		}
+	}
+
+	worker, err := dest.Add(name, srcBranch == "crew/"+name)
+	if err != nil {
+		return nil, err
+	}
+	res.Worker = worker
+	if err := m.moveInto(src, worker, dest, commits, opts, res); err != nil {
+		_ = dest.removeWorkspace(worker.ClonePath) // best-effort cleanup
+		return nil, err
+	}
+
+	if !opts.Keep {
+		if err := m.Remove(name, true); err != nil {
+			return res, fmt.Errorf("removing old workspace: %w", err)
+		}
+	}
+	return res, nil
+}
+
+// uncommittedWork lists changed and untracked files in a workspace that Move
+// would leave behind, ignoring the bookkeeping it recreates or carries over.
+func uncommittedWork(g *git.Git) ([]string, error) {
+	changed, err := g.ChangedFiles()
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := g.UntrackedFiles()
+	if err != nil {
+		return nil, err
+	}
+	var work []string
+	for _, rel := range append(changed, untracked...) {
+		if !isMoveBookkeeping(rel) {
+			work = append(work, rel)
+		}
+	}
+	return work, nil
+}
+
+// isMoveBookkeeping reports whether a workspace path is managed by Gas Town
+// or carried over by Move, rather than the worker's own changes.
+func isMoveBookkeeping(rel string) bool {
+	rel = filepath.ToSlash(rel)
+	switch rel {
+	case "state.json", "crew.json", ".gitignore":
+		return true
+	}
+	if strings.HasPrefix(rel, "mail/") {
+		return true
+	}
+	for _, f := range cloneLocalFiles {
+		if rel == filepath.ToSlash(f) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Manager) moveInto(src, worker *CrewWorker, dest *Manager, commits []string, opts MoveOptions, res *MoveResult) error {
+	if opts.CherryPick && len(commits) > 0 {
+		g := git.NewGit(worker.ClonePath)
+		// Add() touches .gitignore; cherry-picks need a clean tree.
+		if err := g.ResetHard("HEAD"); err != nil {
+			return fmt.Errorf("resetting new workspace: %w", err)
+		}
+		if err := g.FetchRef(src.ClonePath, "HEAD"); err != nil {
+			return fmt.Errorf("fetching from %s: %w", src.Name, err)
+		}
+		for _, c := range commits {
+			if err := g.CherryPick(c); err != nil {
+				_ = g.AbortCherryPick()
+				return fmt.Errorf("cherry-picking %.8s (after %d applied): %w", c, len(res.Picked), err)
+			}
+			res.Picked = append(res.Picked, c)
+		}
+	}
+
+	if entries, err := os.ReadDir(m.mailDir(src.Name)); err == nil {
+		for _, e := range entries {
+			copied, err := copyIfRegular(filepath.Join(m.mailDir(src.Name), e.Name()), filepath.Join(dest.mailDir(worker.Name), e.Name()), true)
+			if err != nil {
+				return fmt.Errorf("copying mail %s: %w", e.Name(), err)
+			}
+			if copied {
+				res.Mail = append(res.Mail, e.Name())
+			}
+		}
+	}
+
+	// Customizations never overwrite the target project's own files.
+	for _, rel := range cloneLocalFiles {
+		srcPath := filepath.Join(src.ClonePath, rel)
+		if _, err := os.Lstat(srcPath); err != nil {
+			continue
+		}
+		copied, err := copyIfRegular(srcPath, filepath.Join(worker.ClonePath, rel), false)
+		if err != nil {
+			return fmt.Errorf("copying %s: %w", rel, err)
+		}
+		if copied {
+			res.Local = append(res.Local, rel)
+		} else {
+			res.Skipped = append(res.Skipped, rel)
+		}
+	}
+
+	// Per-worker launch settings and handoff log belong to the identity.
+	identity := []struct{ from, to string }{
+		{config.CrewWorkerConfigPath(src.ClonePath), config.CrewWorkerConfigPath(worker.ClonePath)},
+		{m.handoffLogPath(src.Name), dest.handoffLogPath(worker.Name)},
+	}
+	for _, f := range identity {
+		copied, err := copyIfRegular(f.from, f.to, true)
+		if err != nil {
+			return fmt.Errorf("copying %s: %w", filepath.Base(f.from), err)
+		}
+		if copied {
+			rel, _ := filepath.Rel(worker.ClonePath, f.to)
+			res.Local = append(res.Local, rel)
+		}
+	}
+
+	if err := rig.EnsureGitignorePatterns(worker.ClonePath); err != nil {
+		worker.Warnings = append(worker.Warnings, fmt.Sprintf("could not update .gitignore: %v", err))
+	}
+	return nil
+}
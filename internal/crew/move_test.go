@@ -0,0 +1,121 @@
+package crew
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestManagerMove(t *testing.T) {
+	for _, kv := range [][2]string{
+		{"GIT_AUTHOR_NAME", "Test"}, {"GIT_AUTHOR_EMAIL", "test@test.com"},
+		{"GIT_COMMITTER_NAME", "Test"}, {"GIT_COMMITTER_EMAIL", "test@test.com"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+	tmpDir := t.TempDir()
+
+	newRig := func(name string) *Manager {
+		t.Helper()
+		rigPath := filepath.Join(tmpDir, name)
+		repo := filepath.Join(tmpDir, name+"-repo")
+		if err := os.MkdirAll(rigPath, 0755); err != nil {
+			t.Fatal(err)
+		}
+		for _, cmd := range [][]string{
+			{"git", "init", "-b", "main", repo},
+			{"git", "-C", repo, "commit", "--allow-empty", "-m", "Initial commit"},
+		} {
+			if err := runCmd(cmd[0], cmd[1:]...); err != nil {
+				t.Fatalf("failed to run %v: %v", cmd, err)
+			}
+		}
+		r := &rig.Rig{Name: name, Path: rigPath, GitURL: repo}
+		return NewManager(r, git.NewGit(rigPath))
+	}
+	srcMgr, destMgr := newRig("rig-a"), newRig("rig-b")
+
+	src, err := srcMgr.Add("dave", true)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(src.ClonePath, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("feature.txt", "work\n")
+	for _, cmd := range [][]string{
+		{"git", "-C", src.ClonePath, "add", "feature.txt"},
+		{"git", "-C", src.ClonePath, "commit", "-m", "branch work"},
+	} {
+		if err := runCmd(cmd[0], cmd[1:]...); err != nil {
+			t.Fatalf("failed to run %v: %v", cmd, err)
+		}
+	}
+	write(filepath.Join("mail", "inbox.jsonl"), "{}\n")
+	write("CLAUDE.local.md", "be terse\n")
+	write("crew.json", `{"type":"crew","version":1}`+"\n")
+
+	// The branch commit would be lost without cherry-pick, keep, or force
+	if _, err := srcMgr.Move("dave", destMgr, MoveOptions{}); err == nil || !strings.Contains(err.Error(), "would be lost") {
+		t.Fatalf("Move without cherry-pick error = %v, want lost-commits refusal", err)
+	}
+	if destMgr.exists("dave") {
+		t.Fatal("refused move left a workspace in the target rig")
+	}
+
+	// Untracked work that isn't carried over also blocks the move
+	write("scratch.txt", "wip\n")
+	if _, err := srcMgr.Move("dave", destMgr, MoveOptions{CherryPick: true}); !errors.Is(err, ErrHasChanges) {
+		t.Fatalf("Move with untracked work error = %v, want ErrHasChanges", err)
+	}
+	if err := os.Remove(filepath.Join(src.ClonePath, "scratch.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := srcMgr.Move("dave", destMgr, MoveOptions{CherryPick: true})
+	if err != nil {
+		t.Fatalf("Move failed: %v", err)
+	}
+	if len(res.Picked) != 1 {
+		t.Errorf("picked %d commits, want 1", len(res.Picked))
+	}
+	if res.Worker.Rig != "rig-b" || res.Worker.Branch != "crew/dave" {
+		t.Errorf("worker = %+v, want rig-b on crew/dave", res.Worker)
+	}
+
+	dest := res.Worker.ClonePath
+	for rel, want := range map[string]string{
+		"feature.txt":                        "work\n",
+		filepath.Join("mail", "inbox.jsonl"): "{}\n",
+		"CLAUDE.local.md":                    "be terse\n",
+		"crew.json":                          `{"type":"crew","version":1}` + "\n",
+	} {
+		got, err := os.ReadFile(filepath.Join(dest, rel))
+		if err != nil {
+			t.Errorf("%s not carried over: %v", rel, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", rel, got, want)
+		}
+	}
+
+	if _, err := srcMgr.Get("dave"); err != ErrCrewNotFound {
+		t.Errorf("source Get error = %v, want ErrCrewNotFound after move", err)
+	}
+	if _, err := destMgr.Move("dave", destMgr, MoveOptions{}); err == nil {
+		t.Error("Move into the same rig succeeded")
+	}
+}
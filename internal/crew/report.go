@@ -0,0 +1,110 @@
+package crew
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// Report summarizes a crew worker's activity since a point in time.
+type Report struct {
+	Worker       string            `json:"worker"`
+	Rig          string            `json:"rig"`
+	Since        time.Time         `json:"since"`
+	Commits      []git.LocalCommit `json:"commits"`
+	FilesTouched []string          `json:"files_touched"`
+	BeadsClosed  []ClosedBead      `json:"beads_closed"`
+	MailReceived int               `json:"mail_received"`
+	MailSent     int               `json:"mail_sent"`
+
+	// Errors lists data sources that couldn't be read; the rest of the
+	// report is still valid.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ClosedBead is an issue the worker closed.
+type ClosedBead struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	ClosedAt time.Time `json:"closed_at"`
+}
+
+// Report stitches together a worker's activity since the given time: commits
+// created in its workspace and the files they touched (from git), beads
+// assigned to it that were closed (from the rig's beads), and mail it
+// received and sent (from town mail).
+func (m *Manager) Report(name string, since time.Time) (*Report, error) {
+	worker, err := m.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	address := fmt.Sprintf("%s/crew/%s", m.rig.Name, name)
+	rep := &Report{
+		Worker:       name,
+		Rig:          m.rig.Name,
+		Since:        since,
+		Commits:      []git.LocalCommit{},
+		FilesTouched: []string{},
+		BeadsClosed:  []ClosedBead{},
+	}
+
+	if commits, err := git.NewGit(worker.ClonePath).LocalCommitsSince(since); err != nil {
+		rep.Errors = append(rep.Errors, fmt.Sprintf("git: %v", err))
+	} else if len(commits) > 0 {
+		rep.Commits = commits
+		files := make(map[string]bool)
+		for _, c := range commits {
+			for _, f := range c.Files {
+				if !files[f] {
+					files[f] = true
+					rep.FilesTouched = append(rep.FilesTouched, f)
+				}
+			}
+		}
+		sort.Strings(rep.FilesTouched)
+	}
+
+	bd := beads.New(beads.ResolveBeadsDir(m.rig.Path))
+	issues, err := bd.List(beads.ListOptions{
+		Status:   "closed",
+		Assignee: address,
+		Priority: -1,
+		Limit:    -1,
+	})
+	if err != nil {
+		rep.Errors = append(rep.Errors, fmt.Sprintf("beads: %v", err))
+	}
+	for _, issue := range issues {
+		closedAt, err := time.Parse(time.RFC3339, issue.ClosedAt)
+		if err != nil || closedAt.Before(since) {
+			continue
+		}
+		rep.BeadsClosed = append(rep.BeadsClosed, ClosedBead{ID: issue.ID, Title: issue.Title, ClosedAt: closedAt})
+	}
+	sort.Slice(rep.BeadsClosed, func(i, j int) bool {
+		return rep.BeadsClosed[i].ClosedAt.Before(rep.BeadsClosed[j].ClosedAt)
+	})
+
+	townRoot := filepath.Dir(m.rig.Path)
+	if mailbox, err := mail.NewRouterWithTownRoot(worker.ClonePath, townRoot).GetMailbox(address); err != nil {
+		rep.Errors = append(rep.Errors, fmt.Sprintf("mail: %v", err))
+	} else {
+		if received, err := mailbox.ReceivedSince(since); err != nil {
+			rep.Errors = append(rep.Errors, fmt.Sprintf("mail received: %v", err))
+		} else {
+			rep.MailReceived = len(received)
+		}
+		if sent, err := mailbox.SentSince(since); err != nil {
+			rep.Errors = append(rep.Errors, fmt.Sprintf("mail sent: %v", err))
+		} else {
+			rep.MailSent = len(sent)
+		}
+	}
+
+	return rep, nil
+}
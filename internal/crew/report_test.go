@@ -0,0 +1,80 @@
+package crew
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestManagerReport(t *testing.T) {
+	for _, kv := range [][2]string{
+		{"GIT_AUTHOR_NAME", "Test"}, {"GIT_AUTHOR_EMAIL", "test@test.com"},
+		{"GIT_COMMITTER_NAME", "Test"}, {"GIT_COMMITTER_EMAIL", "test@test.com"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+	tmpDir := t.TempDir()
+
+	rigPath := filepath.Join(tmpDir, "test-rig")
+	if err := os.MkdirAll(rigPath, 0755); err != nil {
+		t.Fatalf("failed to create rig dir: %v", err)
+	}
+	sourceRepoPath := filepath.Join(tmpDir, "source-repo")
+	for _, cmd := range [][]string{
+		{"git", "init", sourceRepoPath},
+		{"git", "-C", sourceRepoPath, "commit", "--allow-empty", "-m", "Initial commit"},
+	} {
+		if err := runCmd(cmd[0], cmd[1:]...); err != nil {
+			t.Fatalf("failed to run %v: %v", cmd, err)
+		}
+	}
+
+	r := &rig.Rig{Name: "test-rig", Path: rigPath, GitURL: sourceRepoPath}
+	mgr := NewManager(r, git.NewGit(rigPath))
+	worker, err := mgr.Add("dave", true)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	for _, f := range []string{"b.txt", "a.txt"} {
+		if err := os.WriteFile(filepath.Join(worker.ClonePath, f), []byte(f), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, cmd := range [][]string{
+		{"git", "-C", worker.ClonePath, "add", "a.txt", "b.txt"},
+		{"git", "-C", worker.ClonePath, "commit", "-m", "dave's work"},
+	} {
+		if err := runCmd(cmd[0], cmd[1:]...); err != nil {
+			t.Fatalf("failed to run %v: %v", cmd, err)
+		}
+	}
+
+	rep, err := mgr.Report("dave", time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	// The cloned initial commit was fetched, not made here
+	if len(rep.Commits) != 1 || rep.Commits[0].Subject != "dave's work" {
+		t.Errorf("commits = %+v, want just dave's work", rep.Commits)
+	}
+	if len(rep.FilesTouched) != 2 || rep.FilesTouched[0] != "a.txt" || rep.FilesTouched[1] != "b.txt" {
+		t.Errorf("files touched = %v, want [a.txt b.txt]", rep.FilesTouched)
+	}
+
+	rep, err = mgr.Report("dave", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if len(rep.Commits) != 0 || len(rep.FilesTouched) != 0 {
+		t.Errorf("report from the future = %+v, want no commits", rep)
+	}
+
+	if _, err := mgr.Report("nobody", time.Time{}); err != ErrCrewNotFound {
+		t.Errorf("Report of missing worker error = %v, want ErrCrewNotFound", err)
+	}
+}
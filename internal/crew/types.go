@@ -22,6 +22,10 @@ type CrewWorker struct {
 
 	// UpdatedAt is when the crew worker was last updated.
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Warnings are non-fatal problems hit while creating the workspace, for
+	// the caller to report. Not saved.
+	Warnings []string `json:"-"`
 }
 
 // Summary provides a concise view of crew worker status.
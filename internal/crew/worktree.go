@@ -0,0 +1,150 @@
+package crew
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// worktreeMu serializes worktree creation: gt crew add creates workspaces
+// concurrently, and git locks the shared repo's config while adding one.
+var worktreeMu sync.Mutex
+
+// checkout returns the rig's checkout settings. The zero value, for rigs
+// without any, means full clones of the whole tree.
+func (m *Manager) checkout() config.CheckoutConfig {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(m.rig.Path))
+	if err != nil || settings.Checkout == nil {
+		return config.CheckoutConfig{}
+	}
+	return *settings.Checkout
+}
+
+// repoBase returns the repository crew worktrees are added to: the rig's
+// shared bare repo, or mayor/rig in rigs that predate it. Polecat worktrees
+// use the same one.
+func (m *Manager) repoBase() (*git.Git, error) {
+	bareRepoPath := filepath.Join(m.rig.Path, ".repo.git")
+	if info, err := os.Stat(bareRepoPath); err == nil && info.IsDir() {
+		return git.NewGitWithDir(bareRepoPath, ""), nil
+	}
+
+	mayorPath := filepath.Join(m.rig.Path, "mayor", "rig")
+	if _, err := os.Stat(mayorPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("no repo base found (neither .repo.git nor mayor/rig exists)")
+	}
+	return git.NewGit(mayorPath), nil
+}
+
+// isWorktree reports whether the workspace at path is a git worktree: its
+// .git is a file pointing into another repository, not a directory.
+func isWorktree(path string) bool {
+	info, err := os.Lstat(filepath.Join(path, ".git"))
+	return err == nil && info.Mode().IsRegular()
+}
+
+// addWorktree checks out crew member name as a worktree of the shared repo
+// at crewPath, limited to the sparse patterns if any, and returns its
+// branch.
+//
+// Git checks a branch out in one worktree at a time and the refinery holds
+// the default branch, so a crew worktree is always on crew/<name>. Without
+// createBranch that branch tracks origin/<default> and pushes go there, so
+// pull and push work as in a clone on the default branch. An existing
+// crew/<name> branch, left by an earlier crew member of that name, is
+// checked out as is.
+func (m *Manager) addWorktree(name, crewPath string, createBranch bool, sparse []string, warn func(string, ...interface{})) (string, error) {
+	repoGit, err := m.repoBase()
+	if err != nil {
+		return "", err
+	}
+
+	worktreeMu.Lock()
+	defer worktreeMu.Unlock()
+
+	// Non-fatal: may be offline, the worktree starts from what we have
+	if err := repoGit.Fetch("origin"); err != nil {
+		warn("could not fetch origin: %v", err)
+	}
+
+	branchName := fmt.Sprintf("crew/%s", name)
+	exists, err := repoGit.BranchExists(branchName)
+	if err != nil {
+		return "", fmt.Errorf("checking branch %s: %w", branchName, err)
+	}
+	if exists {
+		if err := repoGit.WorktreeAddExisting(crewPath, branchName); err != nil {
+			return "", fmt.Errorf("creating worktree on %s: %w", branchName, err)
+		}
+		if len(sparse) > 0 {
+			if err := git.ConfigureSparseCheckoutPatterns(crewPath, sparse); err != nil {
+				_ = m.removeWorkspace(crewPath) // best-effort cleanup
+				return "", fmt.Errorf("configuring sparse checkout: %w", err)
+			}
+		}
+		// Push settings went with the old worktree
+		if _, err := git.NewGit(crewPath).Rev("@{upstream}"); err == nil {
+			pushToUpstream(repoGit, crewPath, warn)
+		}
+		return branchName, nil
+	}
+
+	startPoint := fmt.Sprintf("origin/%s", m.rig.DefaultBranch())
+	if err := repoGit.WorktreeAddFromRefSparse(crewPath, branchName, startPoint, sparse); err != nil {
+		_ = m.removeWorkspace(crewPath) // best-effort cleanup
+		return "", fmt.Errorf("creating worktree from %s: %w", startPoint, err)
+	}
+	if !createBranch {
+		if err := repoGit.SetUpstream(branchName, startPoint); err != nil {
+			warn("could not set upstream of %s: %v", branchName, err)
+		} else {
+			pushToUpstream(repoGit, crewPath, warn)
+		}
+	}
+	return branchName, nil
+}
+
+// pushToUpstream makes a plain `git push` from the crew worktree at
+// crewPath go to its branch's upstream, the default branch, by setting
+// push.default=upstream for that worktree only: polecat branches track the
+// default branch too, and their work must go through the refinery.
+// Failures are warnings; the crew member can still push explicitly.
+func pushToUpstream(repoGit *git.Git, crewPath string, warn func(string, ...interface{})) {
+	if err := repoGit.EnableWorktreeConfig(); err != nil {
+		warn("could not enable per-worktree config: %v", err)
+	} else if err := git.NewGit(crewPath).SetWorktreeConfig("push.default", "upstream"); err != nil {
+		warn("could not set push.default: %v", err)
+	}
+}
+
+// removeWorkspace deletes a crew workspace. A worktree's registration in
+// the shared repo is pruned with it; its branch is kept.
+func (m *Manager) removeWorkspace(crewPath string) error {
+	worktree := isWorktree(crewPath)
+	if err := os.RemoveAll(crewPath); err != nil {
+		return err
+	}
+	if worktree {
+		if repoGit, err := m.repoBase(); err == nil {
+			_ = repoGit.WorktreePrune()
+		}
+	}
+	return nil
+}
+
+// moveWorkspace moves a crew workspace from oldPath to newPath. Worktrees
+// are moved with git so the shared repo keeps track of them.
+func (m *Manager) moveWorkspace(oldPath, newPath string) error {
+	if !isWorktree(oldPath) {
+		return os.Rename(oldPath, newPath)
+	}
+	repoGit, err := m.repoBase()
+	if err != nil {
+		return err
+	}
+	return repoGit.WorktreeMove(oldPath, newPath)
+}
@@ -0,0 +1,138 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Client timeouts. Dialing is quick or the daemon isn't there; a call can
+// wait on bd.
+const (
+	dialTimeout = 200 * time.Millisecond
+	callTimeout = 30 * time.Second
+)
+
+// ErrNotRunning means no daemon answers on the town's socket, or direct mode
+// was forced with GT_NO_DAEMON.
+var ErrNotRunning = errors.New("daemon not running")
+
+// RemoteError is an error the daemon returned for a request, as opposed to
+// a failure to reach it.
+type RemoteError struct {
+	Method  string
+	Message string
+}
+
+func (e *RemoteError) Error() string {
+	return fmt.Sprintf("daemon %s: %s", e.Method, e.Message)
+}
+
+// Call sends one request to the town's daemon and decodes its result into
+// result. It fails with ErrNotRunning when there is no daemon to ask.
+func Call(townRoot, method string, params, result interface{}) error {
+	if townRoot == "" || os.Getenv("GT_NO_DAEMON") != "" {
+		return ErrNotRunning
+	}
+	conn, err := net.DialTimeout("unix", SocketPath(townRoot), dialTimeout)
+	if err != nil {
+		return ErrNotRunning
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(callTimeout))
+
+	req := rpcRequest{Method: method}
+	if params != nil {
+		if req.Params, err = json.Marshal(params); err != nil {
+			return fmt.Errorf("encoding params: %w", err)
+		}
+	}
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+	var resp rpcResponse
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	if resp.Error != "" {
+		return &RemoteError{Method: method, Message: resp.Error}
+	}
+	if result != nil {
+		return json.Unmarshal(resp.Result, result)
+	}
+	return nil
+}
+
+// Ping reports whether the town's daemon answers on its socket.
+func Ping(townRoot string) (*PingResult, error) {
+	var result PingResult
+	if err := Call(townRoot, MethodPing, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// The helpers below ask the daemon and fall back to doing the work
+// directly when it can't be reached. Errors the daemon reports are
+// returned as is: running the query here would fail the same way.
+
+// LoadRigsConfig returns the town's rigs config.
+func LoadRigsConfig(townRoot string) (*config.RigsConfig, error) {
+	var result config.RigsConfig
+	err := Call(townRoot, MethodRigs, nil, &result)
+	if useDirect(err) {
+		return config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListSessions returns the gt tmux server's sessions. An empty townRoot
+// means the town containing the working directory, if any.
+func ListSessions(townRoot string, t *tmux.Tmux) ([]string, error) {
+	if townRoot == "" {
+		townRoot, _ = workspace.FindFromCwd()
+	}
+	var result []string
+	err := Call(townRoot, MethodSessions, nil, &result)
+	if useDirect(err) {
+		return t.ListSessions()
+	}
+	return result, err
+}
+
+// ListBeads runs bd list in dir with opts.
+func ListBeads(dir string, opts beads.ListOptions) ([]*beads.Issue, error) {
+	townRoot, _ := workspace.Find(dir)
+	var result []*beads.Issue
+	err := Call(townRoot, MethodBeadsList, BeadsListParams{Dir: dir, Options: opts}, &result)
+	if useDirect(err) {
+		return beads.New(dir).List(opts)
+	}
+	return result, err
+}
+
+// useDirect reports whether a Call error means the caller should do the
+// work itself.
+func useDirect(err error) bool {
+	var remote *RemoteError
+	return err != nil && !errors.As(err, &remote)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/schedule"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/util"
@@ -45,12 +46,18 @@ type Daemon struct {
 	ctx          context.Context
 	cancel       context.CancelFunc
 	curator      *feed.Curator
+	hookRunner   *events.HookRunner
 	convoyWatcher *ConvoyWatcher
+	desktopNotifier *DesktopNotifier
+	stateServer     *StateServer
 
 	// Mass death detection: track recent session deaths
 	deathsMu     sync.Mutex
 	recentDeaths []sessionDeath
 
+	// Supervised 'gt witness run' loops (witness_loop patrol)
+	witnessLoops witnessLoops
+
 	// Deacon startup tracking: prevents race condition where newly started
 	// sessions are immediately killed by the heartbeat check.
 	// See: https://github.com/steveyegge/gastown/issues/567
@@ -159,6 +166,15 @@ func (d *Daemon) Run() error {
 		d.logger.Println("Feed curator started")
 	}
 
+	// Start event hook runner (settings/event-hooks.json)
+	d.hookRunner = events.NewHookRunner(d.config.TownRoot, d.logger.Printf)
+	if err := d.hookRunner.Start(); err != nil {
+		d.logger.Printf("Warning: failed to start event hook runner: %v", err)
+		d.hookRunner = nil
+	} else {
+		d.logger.Println("Event hook runner started")
+	}
+
 	// Start convoy watcher for event-driven convoy completion
 	d.convoyWatcher = NewConvoyWatcher(d.config.TownRoot, d.logger.Printf)
 	if err := d.convoyWatcher.Start(); err != nil {
@@ -167,6 +183,28 @@ func (d *Daemon) Run() error {
 		d.logger.Println("Convoy watcher started")
 	}
 
+	// Start desktop notifier (desktop and bell sinks in settings/notifications.json)
+	d.desktopNotifier = NewDesktopNotifier(d.config.TownRoot, d.logger.Printf)
+	if err := d.desktopNotifier.Start(); err != nil {
+		d.logger.Printf("Warning: failed to start desktop notifier: %v", err)
+		d.desktopNotifier = nil
+	} else {
+		d.logger.Println("Desktop notifier started")
+	}
+
+	// Start state server so CLI commands can skip reloading state
+	d.stateServer = NewStateServer(d.config.TownRoot, d.tmux, d.logger.Printf)
+	if err := d.stateServer.Start(); err != nil {
+		d.logger.Printf("Warning: failed to start state server: %v", err)
+		d.stateServer = nil
+	} else {
+		d.logger.Printf("State server listening on %s", SocketPath(d.config.TownRoot))
+	}
+
+	// Start scheduler for recurring jobs (settings/schedule.json)
+	go d.runScheduler()
+	d.logger.Println("Scheduler started")
+
 	// Initial heartbeat
 	d.heartbeat(state)
 
@@ -249,6 +287,12 @@ func (d *Daemon) heartbeat(state *State) {
 		d.logger.Printf("Refinery patrol disabled in config, skipping")
 	}
 
+	// 5b. Supervise deterministic witness patrol loops (opt-in via mayor/daemon.json)
+	// Each loop is restarted with backoff if it dies; crashes are recorded for 'gt witness uptime'.
+	if IsPatrolEnabled(d.patrolConfig, "witness_loop") {
+		d.ensureWitnessLoops()
+	}
+
 	// 6. Trigger pending polecat spawns (bootstrap mode - ZFC violation acceptable)
 	// This ensures polecats get nudged even when Deacon isn't in a patrol cycle.
 	// Uses regex-based WaitForRuntimeReady, which is acceptable for daemon bootstrap.
@@ -269,7 +313,13 @@ func (d *Daemon) heartbeat(state *State) {
 	// This validates tmux sessions are still alive for polecats with work-on-hook
 	d.checkPolecatSessionHealth()
 
-	// 12. Clean up orphaned claude subagent processes (memory leak prevention)
+	// 12. Deterministic intake/triage (opt-in via mayor/daemon.json)
+	// Routes new work by settings/triage.json when no Deacon agent is doing it.
+	if IsPatrolEnabled(d.patrolConfig, "triage") {
+		d.runTriage()
+	}
+
+	// 13. Clean up orphaned claude subagent processes (memory leak prevention)
 	// These are Task tool subagents that didn't clean up after completion.
 	// This is a safety net - Deacon patrol also does this more frequently.
 	d.cleanupOrphanedProcesses()
@@ -635,6 +685,54 @@ func (d *Daemon) triggerPendingSpawns() {
 	}
 }
 
+// runTriage performs one deterministic Deacon intake/triage pass.
+func (d *Daemon) runTriage() {
+	decisions, err := deacon.Triage(d.config.TownRoot, deacon.TriageOptions{})
+	if err != nil {
+		d.logger.Printf("Error running triage: %v", err)
+		return
+	}
+
+	for _, dec := range decisions {
+		switch {
+		case dec.Error != "":
+			d.logger.Printf("Triage %s failed: %s", dec.BeadID, dec.Error)
+		case dec.Rig == "":
+			d.logger.Printf("Triage %s: no rule matched, flagged to mayor", dec.BeadID)
+		default:
+			d.logger.Printf("Triage %s → %s (%s)", dec.BeadID, dec.Rig, dec.Rule)
+		}
+	}
+}
+
+// schedulerTickInterval is how often the scheduler checks for due jobs.
+// Cron schedules have minute granularity, so this keeps runs within ~30s
+// of their scheduled time.
+const schedulerTickInterval = 30 * time.Second
+
+// runScheduler runs due scheduled jobs, and stops and starts polecat
+// sessions at rig session window boundaries, until the daemon stops.
+// Jobs run sequentially in this goroutine, independent of the heartbeat.
+func (d *Daemon) runScheduler() {
+	s := schedule.NewScheduler(d.config.TownRoot, d.logger.Printf)
+	ticker := time.NewTicker(schedulerTickInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Tick(d.ctx, time.Now()); err != nil {
+			d.logger.Printf("Scheduler error: %v", err)
+		}
+		if _, err := s.TickWindows(time.Now()); err != nil {
+			d.logger.Printf("Session window error: %v", err)
+		}
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // processLifecycleRequests checks for and processes lifecycle requests.
 func (d *Daemon) processLifecycleRequests() {
 	d.ProcessLifecycleRequests()
@@ -650,12 +748,33 @@ func (d *Daemon) shutdown(state *State) error { //nolint:unparam // error return
 		d.logger.Println("Feed curator stopped")
 	}
 
+	// Stop event hook runner (waits for running hooks)
+	if d.hookRunner != nil {
+		d.hookRunner.Stop()
+		d.logger.Println("Event hook runner stopped")
+	}
+
 	// Stop convoy watcher
 	if d.convoyWatcher != nil {
 		d.convoyWatcher.Stop()
 		d.logger.Println("Convoy watcher stopped")
 	}
 
+	// Stop desktop notifier
+	if d.desktopNotifier != nil {
+		d.desktopNotifier.Stop()
+		d.logger.Println("Desktop notifier stopped")
+	}
+
+	// Stop state server (clients fall back to direct mode)
+	if d.stateServer != nil {
+		d.stateServer.Stop()
+		d.logger.Println("State server stopped")
+	}
+
+	// Stop scheduler (kills any job still running)
+	d.cancel()
+
 	state.Running = false
 	if err := SaveState(d.config.TownRoot, state); err != nil {
 		d.logger.Printf("Warning: failed to save final state: %v", err)
@@ -0,0 +1,164 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/notify"
+)
+
+// DesktopNotifier follows the town's events log and raises desktop and
+// terminal bell notifications (the local sinks in settings/notifications.json)
+// for mail arrival, escalations, and molecule completion. External sinks keep
+// receiving only what gt commands send them directly.
+type DesktopNotifier struct {
+	townRoot string
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	logger   func(format string, args ...interface{})
+}
+
+// NewDesktopNotifier creates a new desktop notifier.
+func NewDesktopNotifier(townRoot string, logger func(format string, args ...interface{})) *DesktopNotifier {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &DesktopNotifier{
+		townRoot: townRoot,
+		ctx:      ctx,
+		cancel:   cancel,
+		logger:   logger,
+	}
+}
+
+// Start begins following the events log. Only events written after Start
+// raise notifications.
+func (n *DesktopNotifier) Start() error {
+	tailer, err := events.NewTailer(filepath.Join(n.townRoot, events.EventsFile), false)
+	if err != nil {
+		return fmt.Errorf("tailing events file: %w", err)
+	}
+
+	n.wg.Add(1)
+	go n.run(tailer)
+	return nil
+}
+
+// Stop gracefully stops the desktop notifier.
+func (n *DesktopNotifier) Stop() {
+	n.cancel()
+	n.wg.Wait()
+}
+
+// run notifies for each new event in turn.
+func (n *DesktopNotifier) run(tailer *events.Tailer) {
+	defer n.wg.Done()
+	defer tailer.Close()
+
+	lines := tailer.Lines()
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case line, ok := <-lines:
+			if !ok {
+				return
+			}
+			var e events.Event
+			if err := json.Unmarshal([]byte(line), &e); err != nil {
+				continue // Skip malformed lines
+			}
+			n.notify(e)
+		}
+	}
+}
+
+// notify sends e to the local sinks whose routing rules match it. The config
+// is read per event, so sinks can be added without restarting the daemon.
+func (n *DesktopNotifier) notify(e events.Event) {
+	notification, ok := notificationForEvent(e)
+	if !ok {
+		return
+	}
+
+	cfg, err := config.LoadOrCreateNotificationsConfig(config.NotificationsConfigPath(n.townRoot))
+	if err != nil {
+		n.logger("desktop notifier: %v", err)
+		return
+	}
+	local := config.NewNotificationsConfig()
+	for name, sink := range cfg.Sinks {
+		if config.IsLocalSink(sink.Type) {
+			local.Sinks[name] = sink
+		}
+	}
+	if len(local.Sinks) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(n.ctx, 10*time.Second)
+	defer cancel()
+	for _, res := range notify.NewDispatcher(n.townRoot, local).Dispatch(ctx, notification) {
+		if res.Error != nil {
+			n.logger("desktop notifier: sink %s (%s): %v", res.Sink, e.Type, res.Error)
+		}
+	}
+}
+
+// notificationForEvent maps an events log entry to a local notification.
+// Events that aren't worth interrupting a human for map to false.
+func notificationForEvent(e events.Event) (notify.Notification, bool) {
+	str := func(key string) string {
+		s, _ := e.Payload[key].(string)
+		return s
+	}
+
+	n := notify.Notification{Source: e.Actor}
+	if t, err := time.Parse(time.RFC3339, e.Timestamp); err == nil {
+		n.Time = t
+	}
+
+	switch e.Type {
+	case events.TypeMail:
+		to := str("to")
+		n.Event = notify.EventMail
+		n.Title = fmt.Sprintf("Mail to %s: %s", to, str("subject"))
+		// Mail for the human is the point; agent-to-agent chatter is low
+		// severity so a min_severity filter can drop it.
+		switch strings.TrimSuffix(to, "/") {
+		case "overseer", "human", "@overseer":
+			n.Severity = config.SeverityHigh
+		case "mayor":
+			n.Severity = config.SeverityMedium
+		default:
+			n.Severity = config.SeverityLow
+		}
+
+	case events.TypeEscalationSent:
+		n.Event = notify.EventEscalation
+		n.Severity = str("severity")
+		if !config.IsValidSeverity(n.Severity) {
+			n.Severity = config.SeverityHigh
+		}
+		n.Title = str("reason")
+		if n.Title == "" {
+			n.Title = "Escalation from " + e.Actor
+		}
+		n.Body = str("target")
+
+	case events.TypeMoleculeCompleted:
+		n.Event = notify.EventMoleculeCompleted
+		n.Severity = config.SeverityMedium
+		n.Title = fmt.Sprintf("Molecule %s completed", str("molecule"))
+
+	default:
+		return notify.Notification{}, false
+	}
+	return n, true
+}
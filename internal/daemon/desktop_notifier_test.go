@@ -0,0 +1,80 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/notify"
+)
+
+func TestNotificationForEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    events.Event
+		want     bool
+		kind     string
+		severity string
+		title    string
+	}{
+		{
+			name:     "mail to overseer",
+			event:    events.Event{Type: events.TypeMail, Actor: "mayor", Payload: events.MailPayload("overseer", "Convoy landed")},
+			want:     true,
+			kind:     notify.EventMail,
+			severity: config.SeverityHigh,
+			title:    "Mail to overseer: Convoy landed",
+		},
+		{
+			name:     "agent mail",
+			event:    events.Event{Type: events.TypeMail, Actor: "mayor", Payload: events.MailPayload("gastown/witness", "Check Toast")},
+			want:     true,
+			kind:     notify.EventMail,
+			severity: config.SeverityLow,
+			title:    "Mail to gastown/witness: Check Toast",
+		},
+		{
+			name: "escalation",
+			event: events.Event{Type: events.TypeEscalationSent, Actor: "gastown/witness",
+				Payload: map[string]interface{}{"reason": "Toast is stuck", "severity": "critical"}},
+			want:     true,
+			kind:     notify.EventEscalation,
+			severity: config.SeverityCritical,
+			title:    "Toast is stuck",
+		},
+		{
+			name:     "escalation without severity",
+			event:    events.Event{Type: events.TypeEscalationSent, Actor: "gastown/witness"},
+			want:     true,
+			kind:     notify.EventEscalation,
+			severity: config.SeverityHigh,
+			title:    "Escalation from gastown/witness",
+		},
+		{
+			name:     "molecule completed",
+			event:    events.Event{Type: events.TypeMoleculeCompleted, Actor: "gastown/polecats/Toast", Payload: map[string]interface{}{"molecule": "gt-mol1"}},
+			want:     true,
+			kind:     notify.EventMoleculeCompleted,
+			severity: config.SeverityMedium,
+			title:    "Molecule gt-mol1 completed",
+		},
+		{
+			name:  "other events",
+			event: events.Event{Type: events.TypeSling, Actor: "mayor"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, ok := notificationForEvent(tt.event)
+			if ok != tt.want {
+				t.Fatalf("ok = %v, want %v", ok, tt.want)
+			}
+			if !ok {
+				return
+			}
+			if n.Event != tt.kind || n.Severity != tt.severity || n.Title != tt.title || n.Source != tt.event.Actor {
+				t.Errorf("notification = %+v, want %s/%s %q", n, tt.kind, tt.severity, tt.title)
+			}
+		})
+	}
+}
@@ -51,3 +51,35 @@ func TestIsPatrolEnabled_NilConfig(t *testing.T) {
 		t.Error("expected default to be enabled")
 	}
 }
+
+func TestIsPatrolEnabled_TriageOptIn(t *testing.T) {
+	if IsPatrolEnabled(nil, "triage") {
+		t.Error("expected triage to be disabled by default")
+	}
+
+	config := &DaemonPatrolConfig{Patrols: &PatrolsConfig{}}
+	if IsPatrolEnabled(config, "triage") {
+		t.Error("expected triage to be disabled when not configured")
+	}
+
+	config.Patrols.Triage = &PatrolConfig{Enabled: true}
+	if !IsPatrolEnabled(config, "triage") {
+		t.Error("expected triage to be enabled when configured")
+	}
+}
+
+func TestIsPatrolEnabled_WitnessLoopOptIn(t *testing.T) {
+	if IsPatrolEnabled(nil, "witness_loop") {
+		t.Error("expected witness_loop to be disabled by default")
+	}
+
+	config := &DaemonPatrolConfig{Patrols: &PatrolsConfig{}}
+	if IsPatrolEnabled(config, "witness_loop") {
+		t.Error("expected witness_loop to be disabled when not configured")
+	}
+
+	config.Patrols.WitnessLoop = &PatrolConfig{Enabled: true, Interval: "30s"}
+	if !IsPatrolEnabled(config, "witness_loop") {
+		t.Error("expected witness_loop to be enabled when configured")
+	}
+}
@@ -0,0 +1,315 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// Methods served on the daemon socket.
+const (
+	MethodPing      = "ping"       // → PingResult
+	MethodRigs      = "rigs"       // → config.RigsConfig
+	MethodSessions  = "sessions"   // → []string, the gt tmux server's sessions
+	MethodBeadsList = "beads.list" // BeadsListParams → []*beads.Issue
+)
+
+// stateCacheTTL bounds how stale a cached tmux or bd answer can be. Events
+// that change sessions or beads clear the cache sooner.
+const stateCacheTTL = 2 * time.Second
+
+// connIdleTimeout closes a connection that sends no request for this long,
+// so a stuck client can't hold a goroutine forever.
+const connIdleTimeout = 30 * time.Second
+
+// SocketPath returns the path of the daemon's state socket for a town.
+func SocketPath(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "daemon.sock")
+}
+
+// PingResult is the answer to MethodPing.
+type PingResult struct {
+	PID       int       `json:"pid"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// BeadsListParams are the parameters of MethodBeadsList: a bd list run in
+// Dir with Options.
+type BeadsListParams struct {
+	Dir     string            `json:"dir"`
+	Options beads.ListOptions `json:"options"`
+}
+
+// rpcRequest is one request line on the socket.
+type rpcRequest struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is the reply line to a request.
+type rpcResponse struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// cachedBeads is a bd list result and when it was fetched.
+type cachedBeads struct {
+	issues []*beads.Issue
+	at     time.Time
+}
+
+// StateServer answers CLI queries over a unix socket from state the daemon
+// keeps warm: the rigs config, the tmux session list, and recent bd list
+// results. A gt invocation then costs one socket round trip instead of a
+// config load and several tmux and bd spawns. Clients fall back to direct
+// mode when the socket is absent (see Call).
+type StateServer struct {
+	townRoot  string
+	tmux      *tmux.Tmux
+	logger    func(format string, args ...interface{})
+	listener  net.Listener
+	tailer    *events.Tailer
+	startedAt time.Time
+	wg        sync.WaitGroup
+
+	mu         sync.Mutex
+	conns      map[net.Conn]struct{} // open connections, nil once stopped
+	rigs       *config.RigsConfig
+	rigsTime   time.Time
+	sessions   []string
+	sessionsAt time.Time
+	beads      map[string]cachedBeads
+}
+
+// NewStateServer creates a state server for a town.
+func NewStateServer(townRoot string, t *tmux.Tmux, logger func(format string, args ...interface{})) *StateServer {
+	return &StateServer{
+		townRoot: townRoot,
+		tmux:     t,
+		logger:   logger,
+		conns:    make(map[net.Conn]struct{}),
+		beads:    make(map[string]cachedBeads),
+	}
+}
+
+// Start listens on the town's daemon socket, replacing one left behind by
+// a daemon that died.
+func (s *StateServer) Start() error {
+	path := SocketPath(s.townRoot)
+	_ = os.Remove(path)
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", path, err)
+	}
+	s.listener = listener
+	s.startedAt = time.Now()
+
+	// Events are how the rest of the town says state changed
+	if tailer, err := events.NewTailer(filepath.Join(s.townRoot, events.EventsFile), false); err == nil {
+		s.tailer = tailer
+		s.wg.Add(1)
+		go s.invalidate()
+	}
+
+	s.wg.Add(1)
+	go s.accept()
+	return nil
+}
+
+// Stop closes the socket and open connections and waits for in-flight
+// requests.
+func (s *StateServer) Stop() {
+	if s.listener != nil {
+		_ = s.listener.Close()
+		_ = os.Remove(SocketPath(s.townRoot))
+	}
+	if s.tailer != nil {
+		_ = s.tailer.Close()
+	}
+	s.mu.Lock()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.conns = nil
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+// accept serves each connection until Stop.
+func (s *StateServer) accept() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		if s.conns == nil { // accepted as Stop ran
+			s.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serve(conn)
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+// serve answers the requests on one connection, one JSON line each.
+func (s *StateServer) serve(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	enc := json.NewEncoder(conn)
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(connIdleTimeout))
+		if !scanner.Scan() {
+			return
+		}
+		var req rpcRequest
+		var resp rpcResponse
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			resp.Error = fmt.Sprintf("invalid request: %v", err)
+		} else if result, err := s.handle(req); err != nil {
+			resp.Error = err.Error()
+		} else if resp.Result, err = json.Marshal(result); err != nil {
+			resp.Error = err.Error()
+		}
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// handle runs one request.
+func (s *StateServer) handle(req rpcRequest) (interface{}, error) {
+	switch req.Method {
+	case MethodPing:
+		return PingResult{PID: os.Getpid(), StartedAt: s.startedAt}, nil
+	case MethodRigs:
+		return s.rigsConfig()
+	case MethodSessions:
+		return s.listSessions()
+	case MethodBeadsList:
+		var params BeadsListParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, fmt.Errorf("invalid params: %w", err)
+		}
+		if params.Dir == "" {
+			return nil, errors.New("dir is required")
+		}
+		return s.listBeads(params)
+	default:
+		return nil, fmt.Errorf("unknown method %q", req.Method)
+	}
+}
+
+// rigsConfig returns the rigs config, reloading it if rigs.json changed.
+func (s *StateServer) rigsConfig() (*config.RigsConfig, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := constants.MayorRigsPath(s.townRoot)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if s.rigs == nil || !info.ModTime().Equal(s.rigsTime) {
+		rigs, err := config.LoadRigsConfig(path)
+		if err != nil {
+			return nil, err
+		}
+		s.rigs, s.rigsTime = rigs, info.ModTime()
+	}
+	return s.rigs, nil
+}
+
+// listSessions returns the tmux sessions, cached for stateCacheTTL.
+func (s *StateServer) listSessions() ([]string, error) {
+	s.mu.Lock()
+	if s.sessions != nil && time.Since(s.sessionsAt) < stateCacheTTL {
+		sessions := s.sessions
+		s.mu.Unlock()
+		return sessions, nil
+	}
+	s.mu.Unlock()
+
+	sessions, err := s.tmux.ListSessions()
+	if err != nil {
+		return nil, err
+	}
+	if sessions == nil {
+		sessions = []string{}
+	}
+	s.mu.Lock()
+	s.sessions, s.sessionsAt = sessions, time.Now()
+	s.mu.Unlock()
+	return sessions, nil
+}
+
+// listBeads runs bd list, cached per directory and options for
+// stateCacheTTL. Expired entries are dropped when a new one is stored.
+func (s *StateServer) listBeads(params BeadsListParams) ([]*beads.Issue, error) {
+	keyData, _ := json.Marshal(params)
+	key := string(keyData)
+
+	s.mu.Lock()
+	if c, ok := s.beads[key]; ok && time.Since(c.at) < stateCacheTTL {
+		s.mu.Unlock()
+		return c.issues, nil
+	}
+	s.mu.Unlock()
+
+	issues, err := beads.New(params.Dir).List(params.Options)
+	if err != nil {
+		return nil, err
+	}
+	if issues == nil {
+		issues = []*beads.Issue{}
+	}
+	s.mu.Lock()
+	for k, c := range s.beads {
+		if time.Since(c.at) >= stateCacheTTL {
+			delete(s.beads, k)
+		}
+	}
+	s.beads[key] = cachedBeads{issues: issues, at: time.Now()}
+	s.mu.Unlock()
+	return issues, nil
+}
+
+// invalidate drops cached answers that an event may have made stale. Any
+// event can follow a bead change or a session starting or stopping (spawn,
+// kill, boot, halt, done, session_*...), so every event clears both.
+func (s *StateServer) invalidate() {
+	defer s.wg.Done()
+	for line := range s.tailer.Lines() {
+		var e events.Event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			continue
+		}
+		s.mu.Lock()
+		clear(s.beads)
+		s.sessions = nil
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,150 @@
+package daemon
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func setupStateTown(t *testing.T) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	for _, dir := range []string{"daemon", "mayor"} {
+		if err := os.MkdirAll(filepath.Join(townRoot, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rigs := `{"version":1,"rigs":{"gastown":{"git_url":"https://example.com/gastown.git"}}}`
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "rigs.json"), []byte(rigs), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return townRoot
+}
+
+func TestStateServer(t *testing.T) {
+	townRoot := setupStateTown(t)
+	s := NewStateServer(townRoot, tmux.NewTmux(), t.Logf)
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer s.Stop()
+
+	ping, err := Ping(townRoot)
+	if err != nil || ping.PID != os.Getpid() {
+		t.Fatalf("Ping() = %+v, %v", ping, err)
+	}
+
+	rigs, err := LoadRigsConfig(townRoot)
+	if err != nil {
+		t.Fatalf("LoadRigsConfig: %v", err)
+	}
+	if _, ok := rigs.Rigs["gastown"]; !ok {
+		t.Errorf("rigs = %+v, want gastown", rigs.Rigs)
+	}
+
+	var remote *RemoteError
+	if err := Call(townRoot, "nope", nil, nil); !errors.As(err, &remote) {
+		t.Errorf("unknown method error = %v, want RemoteError", err)
+	}
+	if err := Call(townRoot, MethodBeadsList, BeadsListParams{}, nil); !errors.As(err, &remote) {
+		t.Errorf("beads.list without dir error = %v, want RemoteError", err)
+	}
+}
+
+func TestClientFallsBackWithoutDaemon(t *testing.T) {
+	townRoot := setupStateTown(t)
+
+	if _, err := Ping(townRoot); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("Ping() error = %v, want ErrNotRunning", err)
+	}
+	rigs, err := LoadRigsConfig(townRoot)
+	if err != nil || len(rigs.Rigs) != 1 {
+		t.Errorf("LoadRigsConfig() = %+v, %v; want the rigs read directly", rigs, err)
+	}
+
+	// GT_NO_DAEMON forces direct mode even with a daemon listening
+	s := NewStateServer(townRoot, tmux.NewTmux(), t.Logf)
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer s.Stop()
+	t.Setenv("GT_NO_DAEMON", "1")
+	if _, err := Ping(townRoot); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("Ping() with GT_NO_DAEMON error = %v, want ErrNotRunning", err)
+	}
+}
+
+func TestStateServerStopClosesIdleConnections(t *testing.T) {
+	townRoot := setupStateTown(t)
+	s := NewStateServer(townRoot, tmux.NewTmux(), t.Logf)
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	// A client that connects and never sends a request
+	conn, err := net.Dial("unix", SocketPath(townRoot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := Ping(townRoot); err != nil { // the idle conn is being served
+		t.Fatalf("Ping: %v", err)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop blocked on an idle connection")
+	}
+}
+
+func TestStateServerEventClearsSessions(t *testing.T) {
+	townRoot := setupStateTown(t)
+	eventsPath := filepath.Join(townRoot, events.EventsFile)
+	if err := os.WriteFile(eventsPath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	s := NewStateServer(townRoot, tmux.NewTmux(), t.Logf)
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer s.Stop()
+
+	s.mu.Lock()
+	s.sessions, s.sessionsAt = []string{"gt-gastown-Toast"}, time.Now()
+	s.mu.Unlock()
+
+	// A polecat spawn is not a session_* event but changes the session list
+	f, err := os.OpenFile(eventsPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.WriteString(`{"ts":"2026-01-01T00:00:00Z","source":"gt","type":"spawn","actor":"gastown/witness"}` + "\n")
+	_ = f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		cleared := s.sessions == nil
+		s.mu.Unlock()
+		if cleared {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Error("spawn event did not clear the cached session list")
+}
@@ -113,6 +113,14 @@ type PatrolsConfig struct {
 	Refinery *PatrolConfig `json:"refinery,omitempty"`
 	Witness  *PatrolConfig `json:"witness,omitempty"`
 	Deacon   *PatrolConfig `json:"deacon,omitempty"`
+
+	// Triage runs deterministic Deacon intake/triage each heartbeat.
+	// Unlike the other patrols it is opt-in.
+	Triage *PatrolConfig `json:"triage,omitempty"`
+
+	// WitnessLoop supervises a 'gt witness run' patrol loop per rig,
+	// restarting it if it dies. Opt-in; Interval sets the patrol interval.
+	WitnessLoop *PatrolConfig `json:"witness_loop,omitempty"`
 }
 
 // DaemonPatrolConfig is the structure of mayor/daemon.json.
@@ -148,7 +156,7 @@ func LoadPatrolConfig(townRoot string) *DaemonPatrolConfig {
 // Returns true if the config doesn't exist (default enabled for backwards compatibility).
 func IsPatrolEnabled(config *DaemonPatrolConfig, patrol string) bool {
 	if config == nil || config.Patrols == nil {
-		return true // Default: enabled
+		return patrol != "triage" && patrol != "witness_loop" // Default: enabled (triage and witness_loop are opt-in)
 	}
 
 	switch patrol {
@@ -164,6 +172,10 @@ func IsPatrolEnabled(config *DaemonPatrolConfig, patrol string) bool {
 		if config.Patrols.Deacon != nil {
 			return config.Patrols.Deacon.Enabled
 		}
+	case "triage":
+		return config.Patrols.Triage != nil && config.Patrols.Triage.Enabled
+	case "witness_loop":
+		return config.Patrols.WitnessLoop != nil && config.Patrols.WitnessLoop.Enabled
 	}
 	return true // Default: enabled
 }
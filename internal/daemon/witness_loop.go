@@ -0,0 +1,115 @@
+package daemon
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+// Restart backoff for supervised witness loops: doubles from the minimum
+// on each quick crash, and resets once a loop stays up long enough.
+const (
+	witnessLoopMinBackoff = 5 * time.Second
+	witnessLoopMaxBackoff = 5 * time.Minute
+	witnessLoopStableRun  = 10 * time.Minute
+)
+
+// witnessLoops tracks which rigs have a supervised 'gt witness run'.
+type witnessLoops struct {
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+// ensureWitnessLoops starts a supervisor for each operational rig's witness
+// patrol loop that doesn't have one yet. Supervisors live until the daemon
+// stops.
+func (d *Daemon) ensureWitnessLoops() {
+	interval := witness.DefaultPatrolInterval
+	if cfg := d.patrolConfig; cfg != nil && cfg.Patrols != nil && cfg.Patrols.WitnessLoop != nil && cfg.Patrols.WitnessLoop.Interval != "" {
+		if iv, err := time.ParseDuration(cfg.Patrols.WitnessLoop.Interval); err == nil && iv > 0 {
+			interval = iv
+		} else {
+			d.logger.Printf("Invalid witness_loop interval %q, using %s", cfg.Patrols.WitnessLoop.Interval, interval)
+		}
+	}
+
+	d.witnessLoops.mu.Lock()
+	defer d.witnessLoops.mu.Unlock()
+	if d.witnessLoops.running == nil {
+		d.witnessLoops.running = make(map[string]bool)
+	}
+	for _, rigName := range d.getKnownRigs() {
+		if d.witnessLoops.running[rigName] {
+			continue
+		}
+		if operational, reason := d.isRigOperational(rigName); !operational {
+			d.logger.Printf("Skipping witness loop for %s: %s", rigName, reason)
+			continue
+		}
+		d.witnessLoops.running[rigName] = true
+		go d.superviseWitnessLoop(rigName, interval)
+	}
+}
+
+// superviseWitnessLoop runs 'gt witness run <rig>' and restarts it with
+// backoff whenever it exits, recording each crash in the rig's supervisor
+// state for 'gt witness uptime'. Returns when the daemon stops.
+func (d *Daemon) superviseWitnessLoop(rigName string, interval time.Duration) {
+	rigPath := filepath.Join(d.config.TownRoot, rigName)
+	logPath := filepath.Join(d.config.TownRoot, "daemon", "witness-"+rigName+".log")
+	backoff := witnessLoopMinBackoff
+	afterCrash := false
+
+	for {
+		cmd := exec.CommandContext(d.ctx, "gt", "witness", "run", rigName, "--interval", interval.String()) //nolint:gosec // G204: args are constructed internally
+		cmd.Dir = d.config.TownRoot
+		logFile, logErr := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600) //nolint:gosec // G304: path is under the daemon dir
+		if logErr == nil {
+			cmd.Stdout = logFile
+			cmd.Stderr = logFile
+		}
+
+		started := time.Now()
+		err := cmd.Start()
+		if err == nil {
+			d.logger.Printf("Witness loop for %s started (PID %d)", rigName, cmd.Process.Pid)
+			if recErr := witness.RecordLoopStart(rigPath, cmd.Process.Pid, interval, afterCrash); recErr != nil {
+				d.logger.Printf("Warning: recording witness loop start for %s: %v", rigName, recErr)
+			}
+			err = cmd.Wait()
+		}
+		if logErr == nil {
+			_ = logFile.Close()
+		}
+
+		if d.ctx.Err() != nil {
+			_ = witness.RecordLoopExit(rigPath, nil)
+			d.logger.Printf("Witness loop for %s stopped", rigName)
+			return
+		}
+		if err == nil {
+			err = errors.New("exited without error")
+		}
+		d.logger.Printf("Witness loop for %s died after %s: %v", rigName, time.Since(started).Round(time.Second), err)
+		if recErr := witness.RecordLoopExit(rigPath, err); recErr != nil {
+			d.logger.Printf("Warning: recording witness loop crash for %s: %v", rigName, recErr)
+		}
+
+		if time.Since(started) >= witnessLoopStableRun {
+			backoff = witnessLoopMinBackoff
+		}
+		select {
+		case <-d.ctx.Done():
+			_ = witness.RecordLoopExit(rigPath, nil)
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, witnessLoopMaxBackoff)
+		afterCrash = true
+	}
+}
@@ -0,0 +1,228 @@
+package deacon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// TriagedLabel marks beads the Deacon has already routed, so each piece of
+// incoming work is triaged exactly once.
+const TriagedLabel = "triaged"
+
+// deaconAddress is the Deacon's mail address; task mail sent here is intake.
+const deaconAddress = "deacon/"
+
+// workTypes are the bead types (or gt: labels) that count as incoming work.
+var workTypes = map[string]bool{"task": true, "bug": true, "feature": true, "chore": true}
+
+// Decision is the outcome of triaging one bead.
+type Decision struct {
+	BeadID string `json:"bead_id"`
+	Title  string `json:"title"`
+	Source string `json:"source"`         // "bead" or "mail"
+	Rule   string `json:"rule,omitempty"` // matching rule name; empty for default/unrouted
+	Rig    string `json:"rig,omitempty"`  // destination rig; empty when unrouted
+	Error  string `json:"error,omitempty"`
+}
+
+// TriageOptions controls a triage pass.
+type TriageOptions struct {
+	// DryRun computes decisions without creating, labeling, or mailing anything.
+	DryRun bool
+}
+
+// Route picks the destination for a bead: the first matching rule, then the
+// config's default rig. Returns the rule name ("default" for the fallback),
+// the rig, and any priority override; rule and rig are empty if the bead
+// should be left for the Mayor.
+func Route(cfg *config.TriageConfig, issue *beads.Issue) (rule, rig string, priority *int) {
+	issueType := workType(issue)
+	for i := range cfg.Rules {
+		r := &cfg.Rules[i]
+		if r.Match(issue.Title, issue.Description, issueType, issue.Labels) {
+			name := r.Name
+			if name == "" {
+				name = fmt.Sprintf("rule-%d", i+1)
+			}
+			return name, r.Rig, r.Priority
+		}
+	}
+	if cfg.DefaultRig != "" {
+		return "default", cfg.DefaultRig, nil
+	}
+	return "", "", nil
+}
+
+// workType returns the bead's work type from its type field or gt: label,
+// or "" if the bead is not a work item (messages, agents, molecules, ...).
+func workType(issue *beads.Issue) string {
+	if workTypes[issue.Type] {
+		return issue.Type
+	}
+	for _, label := range issue.Labels {
+		if t := strings.TrimPrefix(label, "gt:"); t != label && workTypes[t] {
+			return t
+		}
+	}
+	return ""
+}
+
+// needsTriage reports whether a bead is untriaged, unassigned, open work.
+func needsTriage(issue *beads.Issue) bool {
+	if issue.Status != "open" || issue.Assignee != "" || workType(issue) == "" {
+		return false
+	}
+	for _, label := range issue.Labels {
+		if label == TriagedLabel {
+			return false
+		}
+	}
+	return true
+}
+
+// Triage runs one intake pass for the town: task mail addressed to the
+// Deacon is turned into beads, then every untriaged open bead is routed by
+// the rules in settings/triage.json. Routed beads are labeled "triaged" and
+// "rig:<name>", and the destination rig's witness is mailed to dispatch them.
+// Unrouted beads are reported to the Mayor once and labeled as triaged.
+func Triage(townRoot string, opts TriageOptions) ([]Decision, error) {
+	cfg, err := config.LoadOrCreateTriageConfig(config.TriageConfigPath(townRoot))
+	if err != nil {
+		return nil, err
+	}
+
+	b := beads.New(townRoot)
+	router := mail.NewRouterWithTownRoot(townRoot, townRoot)
+
+	var decisions []Decision
+
+	// Intake: convert task mail into beads.
+	mailIssues, err := intakeMail(b, router, opts.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := b.List(beads.ListOptions{Status: "open", NoAssignee: true, Priority: -1})
+	if err != nil {
+		return nil, fmt.Errorf("listing open beads: %w", err)
+	}
+
+	candidates := make([]*beads.Issue, 0, len(issues)+len(mailIssues))
+	candidates = append(candidates, mailIssues...)
+	candidates = append(candidates, issues...)
+
+	seen := make(map[string]bool)
+	for _, issue := range candidates {
+		if seen[issue.ID] || !needsTriage(issue) {
+			continue
+		}
+		seen[issue.ID] = true
+
+		d := Decision{BeadID: issue.ID, Title: issue.Title, Source: "bead"}
+		if strings.HasPrefix(issue.ID, "mail:") {
+			d.Source = "mail"
+		}
+
+		var priority *int
+		d.Rule, d.Rig, priority = Route(cfg, issue)
+
+		if !opts.DryRun {
+			if err := dispatch(b, router, issue, d.Rig, priority); err != nil {
+				d.Error = err.Error()
+			}
+		}
+		decisions = append(decisions, d)
+	}
+
+	return decisions, nil
+}
+
+// intakeMail converts unread task mail in the Deacon's inbox into beads.
+// In dry-run mode the beads are not created; placeholders with "mail:" IDs
+// are returned instead so the caller can preview routing.
+func intakeMail(b *beads.Beads, router *mail.Router, dryRun bool) ([]*beads.Issue, error) {
+	mailbox, err := router.GetMailbox(deaconAddress)
+	if err != nil {
+		return nil, nil // No inbox yet - nothing to take in
+	}
+	messages, err := mailbox.ListUnread()
+	if err != nil {
+		return nil, fmt.Errorf("reading deacon inbox: %w", err)
+	}
+
+	var issues []*beads.Issue
+	for _, msg := range messages {
+		if msg.Type != mail.TypeTask {
+			continue
+		}
+
+		desc := fmt.Sprintf("%s\n\nRequested by %s via mail (%s).", msg.Body, msg.From, msg.ID)
+		if dryRun {
+			issues = append(issues, &beads.Issue{
+				ID: "mail:" + msg.ID, Title: msg.Subject, Description: desc,
+				Status: "open", Type: "task",
+			})
+			continue
+		}
+
+		issue, err := b.Create(beads.CreateOptions{
+			Title:       msg.Subject,
+			Type:        "task",
+			Priority:    mailPriority(msg.Priority),
+			Description: desc,
+			Actor:       "deacon",
+		})
+		if err != nil {
+			return issues, fmt.Errorf("creating bead from mail %s: %w", msg.ID, err)
+		}
+		_ = mailbox.MarkRead(msg.ID)
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// mailPriority maps mail priority to bead priority (0 = highest).
+func mailPriority(p mail.Priority) int {
+	switch p {
+	case mail.PriorityUrgent:
+		return 0
+	case mail.PriorityHigh:
+		return 1
+	case mail.PriorityLow:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// dispatch applies a routing decision: labels the bead and mails the
+// destination rig's witness, or flags unrouted work to the Mayor.
+func dispatch(b *beads.Beads, router *mail.Router, issue *beads.Issue, rig string, priority *int) error {
+	opts := beads.UpdateOptions{AddLabels: []string{TriagedLabel}}
+	if rig != "" {
+		opts.AddLabels = append(opts.AddLabels, "rig:"+rig)
+		opts.Priority = priority
+	}
+	if err := b.Update(issue.ID, opts); err != nil {
+		return fmt.Errorf("labeling %s: %w", issue.ID, err)
+	}
+
+	body := fmt.Sprintf("Bead: %s\nTitle: %s\n", issue.ID, issue.Title)
+	var msg *mail.Message
+	if rig == "" {
+		msg = mail.NewMessage(deaconAddress, "mayor/", "Untriaged work: "+issue.ID,
+			body+"\nNo triage rule matched. Route it with: gt mayor reassign "+issue.ID+" <rig>")
+	} else {
+		msg = mail.NewMessage(deaconAddress, rig+"/witness", "Work assigned to rig: "+issue.ID,
+			body+"\nDispatch this bead to a polecat: gt sling "+issue.ID+" "+rig)
+		msg.Type = mail.TypeTask
+	}
+	if err := router.Send(msg); err != nil {
+		return fmt.Errorf("notifying %s: %w", msg.To, err)
+	}
+	return nil
+}
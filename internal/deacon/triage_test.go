@@ -0,0 +1,61 @@
+package deacon
+
+import (
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestRoute(t *testing.T) {
+	high := 1
+	cfg := &config.TriageConfig{
+		Rules: []config.TriageRule{
+			{Name: "beads-bugs", Labels: []string{"beads"}, Type: "bug", Rig: "beads", Priority: &high},
+			{Name: "docs", TitleContains: []string{"README"}, Rig: "gastown"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		issue    *beads.Issue
+		wantRule string
+		wantRig  string
+	}{
+		{"label and type", &beads.Issue{Title: "crash", Type: "bug", Labels: []string{"beads"}}, "beads-bugs", "beads"},
+		{"type via gt label", &beads.Issue{Title: "crash", Labels: []string{"beads", "gt:bug"}}, "beads-bugs", "beads"},
+		{"keyword", &beads.Issue{Title: "Update readme", Type: "task"}, "docs", "gastown"},
+		{"no match", &beads.Issue{Title: "other", Type: "task"}, "", ""},
+	}
+	for _, tt := range tests {
+		rule, rig, _ := Route(cfg, tt.issue)
+		if rule != tt.wantRule || rig != tt.wantRig {
+			t.Errorf("%s: Route() = (%q, %q), want (%q, %q)", tt.name, rule, rig, tt.wantRule, tt.wantRig)
+		}
+	}
+
+	cfg.DefaultRig = "gastown"
+	if rule, rig, _ := Route(cfg, &beads.Issue{Title: "other", Type: "task"}); rule != "default" || rig != "gastown" {
+		t.Errorf("Route() with default rig = (%q, %q), want (default, gastown)", rule, rig)
+	}
+}
+
+func TestNeedsTriage(t *testing.T) {
+	tests := []struct {
+		name  string
+		issue *beads.Issue
+		want  bool
+	}{
+		{"open task", &beads.Issue{Status: "open", Type: "task"}, true},
+		{"gt label", &beads.Issue{Status: "open", Labels: []string{"gt:feature"}}, true},
+		{"already triaged", &beads.Issue{Status: "open", Type: "task", Labels: []string{TriagedLabel}}, false},
+		{"assigned", &beads.Issue{Status: "open", Type: "task", Assignee: "gastown/Toast"}, false},
+		{"message", &beads.Issue{Status: "open", Labels: []string{"gt:message"}}, false},
+		{"hooked", &beads.Issue{Status: "hooked", Type: "task"}, false},
+	}
+	for _, tt := range tests {
+		if got := needsTriage(tt.issue); got != tt.want {
+			t.Errorf("%s: needsTriage() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
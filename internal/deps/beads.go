@@ -118,6 +118,13 @@ func parseBeadsVersion(output string) string {
 	return ""
 }
 
+// BeadsVersionAtLeast reports whether a bd version (as returned by
+// CheckBeads) is min or newer. Callers use it to gate bd features added
+// after MinBeadsVersion.
+func BeadsVersionAtLeast(version, min string) bool {
+	return version != "" && compareVersions(version, min) >= 0
+}
+
 // compareVersions compares two semver strings.
 // Returns -1 if a < b, 0 if a == b, 1 if a > b.
 func compareVersions(a, b string) int {
@@ -44,6 +44,18 @@ func TestCompareVersions(t *testing.T) {
 	}
 }
 
+func TestBeadsVersionAtLeast(t *testing.T) {
+	if !BeadsVersionAtLeast("0.47.1", "0.47.0") || !BeadsVersionAtLeast("0.47.0", "0.47.0") {
+		t.Error("newer or equal version should pass")
+	}
+	if BeadsVersionAtLeast("0.46.9", "0.47.0") {
+		t.Error("older version should not pass")
+	}
+	if BeadsVersionAtLeast("", "0.47.0") {
+		t.Error("unknown version should not pass")
+	}
+}
+
 func TestCheckBeads(t *testing.T) {
 	// This test depends on whether bd is installed in the test environment
 	status, version := CheckBeads()
@@ -80,15 +80,8 @@ func (c *AgentBeadsCheck) Run(ctx *CheckContext) *CheckResult {
 	deaconID := beads.DeaconBeadIDTown()
 	mayorID := beads.MayorBeadIDTown()
 
-	if _, err := townBd.Show(deaconID); err != nil {
-		missing = append(missing, deaconID)
-	}
-	checked++
-
-	if _, err := townBd.Show(mayorID); err != nil {
-		missing = append(missing, mayorID)
-	}
-	checked++
+	missing = append(missing, missingBeads(townBd, []string{deaconID, mayorID})...)
+	checked += 2
 
 	if len(prefixToRig) == 0 {
 		// No rigs to check, but we still checked global agents
@@ -119,25 +112,16 @@ func (c *AgentBeadsCheck) Run(ctx *CheckContext) *CheckResult {
 		witnessID := beads.WitnessBeadIDWithPrefix(prefix, rigName)
 		refineryID := beads.RefineryBeadIDWithPrefix(prefix, rigName)
 
-		if _, err := bd.Show(witnessID); err != nil {
-			missing = append(missing, witnessID)
-		}
-		checked++
-
-		if _, err := bd.Show(refineryID); err != nil {
-			missing = append(missing, refineryID)
-		}
-		checked++
+		ids := []string{witnessID, refineryID}
 
 		// Check crew worker agents
 		crewWorkers := listCrewWorkers(ctx.TownRoot, rigName)
 		for _, workerName := range crewWorkers {
-			crewID := beads.CrewBeadIDWithPrefix(prefix, rigName, workerName)
-			if _, err := bd.Show(crewID); err != nil {
-				missing = append(missing, crewID)
-			}
-			checked++
+			ids = append(ids, beads.CrewBeadIDWithPrefix(prefix, rigName, workerName))
 		}
+
+		missing = append(missing, missingBeads(bd, ids)...)
+		checked += len(ids)
 	}
 
 	if len(missing) == 0 {
@@ -157,6 +141,22 @@ func (c *AgentBeadsCheck) Run(ctx *CheckContext) *CheckResult {
 	}
 }
 
+// missingBeads returns the ids that don't exist, looked up in one batch.
+// If the lookup fails, every id is reported missing.
+func missingBeads(bd *beads.Beads, ids []string) []string {
+	found, err := bd.ShowMultiple(ids)
+	if err != nil {
+		return ids
+	}
+	var missing []string
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
 // Fix creates missing agent beads.
 func (c *AgentBeadsCheck) Fix(ctx *CheckContext) error {
 	// Create global agents (Mayor, Deacon) in town beads
@@ -96,16 +96,35 @@ func (c *HookAttachmentValidCheck) checkBeadsDir(beadsDir, _ string) []invalidAt
 		return nil
 	}
 
+	// Fetch every attached molecule in one batch rather than one bd call each
+	attachments := make(map[string]*beads.AttachmentFields, len(pinnedBeads))
+	var moleculeIDs []string
 	for _, pinnedBead := range pinnedBeads {
-		// Parse attachment fields from the pinned bead
 		attachment := beads.ParseAttachmentFields(pinnedBead)
 		if attachment == nil || attachment.AttachedMolecule == "" {
 			continue // No attachment, skip
 		}
+		attachments[pinnedBead.ID] = attachment
+		moleculeIDs = append(moleculeIDs, attachment.AttachedMolecule)
+	}
+	if len(moleculeIDs) == 0 {
+		return nil
+	}
+	molecules, err := b.ShowMultiple(moleculeIDs)
+	if err != nil {
+		// Can't look molecules up - silently skip this directory
+		return nil
+	}
+
+	for _, pinnedBead := range pinnedBeads {
+		attachment := attachments[pinnedBead.ID]
+		if attachment == nil {
+			continue
+		}
 
 		// Verify the attached molecule exists and is not closed
-		molecule, err := b.Show(attachment.AttachedMolecule)
-		if err != nil {
+		molecule, ok := molecules[attachment.AttachedMolecule]
+		if !ok {
 			// Molecule not found
 			invalid = append(invalid, invalidAttachment{
 				pinnedBeadID:  pinnedBead.ID,
@@ -0,0 +1,464 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+// TownChecks returns the town-wide consistency checks: they compare the
+// rig registry (mayor/rigs.json) with what is actually on disk across
+// every rig, unlike RigChecks which need --rig.
+func TownChecks() []Check {
+	return []Check{
+		NewUnregisteredRigCheck(),
+		NewRigBeadsDirCheck(),
+		NewRigRemoteCheck(),
+		NewStaleWitnessStateCheck(),
+	}
+}
+
+// loadRegisteredRigs reads mayor/rigs.json. A town without a registry has
+// no registered rigs.
+func loadRegisteredRigs(townRoot string) (map[string]config.RigEntry, error) {
+	rigsConfig, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		if errors.Is(err, config.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rigsConfig.Rigs, nil
+}
+
+// presentRegisteredRigs returns the registered rigs whose directory exists,
+// sorted by name. Missing directories are RigsRegistryValidCheck's concern.
+func presentRegisteredRigs(townRoot string, rigs map[string]config.RigEntry) []string {
+	var names []string
+	for name := range rigs {
+		if info, err := os.Stat(filepath.Join(townRoot, name)); err == nil && info.IsDir() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// registryErrorResult reports an unreadable rigs.json.
+func registryErrorResult(name string, err error) *CheckResult {
+	return &CheckResult{
+		Name:    name,
+		Status:  StatusWarning,
+		Message: "Cannot load mayor/rigs.json",
+		Details: []string{err.Error()},
+	}
+}
+
+// UnregisteredRigCheck finds rig workspaces at the town root that have no
+// entry in mayor/rigs.json.
+type UnregisteredRigCheck struct {
+	FixableCheck
+	fixable map[string]*config.RigConfig // Cached for Fix: workspaces with a rig config.json
+}
+
+// NewUnregisteredRigCheck creates a new unregistered rig check.
+func NewUnregisteredRigCheck() *UnregisteredRigCheck {
+	return &UnregisteredRigCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "rigs-unregistered",
+				CheckDescription: "Check that rig workspaces are registered in rigs.json",
+				CheckCategory:    CategoryCore,
+			},
+		},
+	}
+}
+
+// Run scans the town root for rig workspaces missing from the registry.
+// A directory is a rig workspace if it has a rig config.json, or polecats/
+// or crew/ directories.
+func (c *UnregisteredRigCheck) Run(ctx *CheckContext) *CheckResult {
+	c.fixable = nil
+
+	rigs, err := loadRegisteredRigs(ctx.TownRoot)
+	if err != nil {
+		return registryErrorResult(c.Name(), err)
+	}
+
+	entries, err := os.ReadDir(ctx.TownRoot)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "Cannot read town root",
+			Details: []string{err.Error()},
+		}
+	}
+
+	var details []string
+	fixable := make(map[string]*config.RigConfig)
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || strings.HasPrefix(name, ".") || name == "mayor" || name == "deacon" {
+			continue
+		}
+		if _, ok := rigs[name]; ok {
+			continue
+		}
+
+		rigPath := filepath.Join(ctx.TownRoot, name)
+		rigConfig, err := config.LoadRigConfig(filepath.Join(rigPath, "config.json"))
+		if err == nil && rigConfig.Type == "rig" {
+			fixable[name] = rigConfig
+			details = append(details, fmt.Sprintf("%s/ (config.json: %s)", name, rigConfig.GitURL))
+			continue
+		}
+		if dirExists(filepath.Join(rigPath, "polecats")) || dirExists(filepath.Join(rigPath, "crew")) {
+			details = append(details, fmt.Sprintf("%s/ (no rig config.json, cannot re-register)", name))
+		}
+	}
+
+	if len(details) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "All rig workspaces are registered",
+		}
+	}
+
+	c.fixable = fixable
+	hint := "Register the rig with 'gt rig add' or remove the directory"
+	if len(fixable) > 0 {
+		hint = "Run 'gt doctor --fix' to register rigs from their config.json"
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d rig workspace(s) not in rigs.json", len(details)),
+		Details: details,
+		FixHint: hint,
+	}
+}
+
+// Fix registers the unregistered workspaces that carry a rig config.json.
+func (c *UnregisteredRigCheck) Fix(ctx *CheckContext) error {
+	if len(c.fixable) == 0 {
+		return nil
+	}
+
+	rigsPath := filepath.Join(ctx.TownRoot, "mayor", "rigs.json")
+	rigsConfig, err := config.LoadRigsConfig(rigsPath)
+	if err != nil {
+		if !errors.Is(err, config.ErrNotFound) {
+			return fmt.Errorf("loading rigs.json: %w", err)
+		}
+		rigsConfig = &config.RigsConfig{Version: config.CurrentRigsVersion, Rigs: make(map[string]config.RigEntry)}
+	}
+
+	for name, rigConfig := range c.fixable {
+		if _, ok := rigsConfig.Rigs[name]; ok {
+			continue
+		}
+		addedAt := rigConfig.CreatedAt
+		if addedAt.IsZero() {
+			addedAt = time.Now()
+		}
+		rigsConfig.Rigs[name] = config.RigEntry{
+			GitURL:      rigConfig.GitURL,
+			LocalRepo:   rigConfig.LocalRepo,
+			AddedAt:     addedAt,
+			BeadsConfig: rigConfig.Beads,
+		}
+	}
+
+	return config.SaveRigsConfig(rigsPath, rigsConfig)
+}
+
+// RigBeadsDirCheck verifies every registered rig has a .beads directory.
+type RigBeadsDirCheck struct {
+	FixableCheck
+	missing []string // Cached for Fix
+}
+
+// NewRigBeadsDirCheck creates a new rig .beads directory check.
+func NewRigBeadsDirCheck() *RigBeadsDirCheck {
+	return &RigBeadsDirCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "rig-beads-dirs",
+				CheckDescription: "Check that every registered rig has a .beads directory",
+				CheckCategory:    CategoryRig,
+			},
+		},
+	}
+}
+
+// Run checks each registered rig for a .beads directory.
+func (c *RigBeadsDirCheck) Run(ctx *CheckContext) *CheckResult {
+	c.missing = nil
+
+	rigs, err := loadRegisteredRigs(ctx.TownRoot)
+	if err != nil {
+		return registryErrorResult(c.Name(), err)
+	}
+
+	names := presentRegisteredRigs(ctx.TownRoot, rigs)
+	var missing []string
+	for _, name := range names {
+		if !dirExists(filepath.Join(ctx.TownRoot, name, ".beads")) {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("All %d rig(s) have a .beads directory", len(names)),
+		}
+	}
+
+	c.missing = missing
+	details := make([]string, len(missing))
+	for i, name := range missing {
+		details[i] = fmt.Sprintf("Missing: %s/.beads/", name)
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusError,
+		Message: fmt.Sprintf("%d rig(s) missing a .beads directory", len(missing)),
+		Details: details,
+		FixHint: "Run 'gt doctor --fix' to redirect to tracked beads or initialize them",
+	}
+}
+
+// Fix repairs each rig the way 'gt doctor --fix --rig <name>' would: a
+// redirect to tracked beads in mayor/rig, or a fresh beads database.
+func (c *RigBeadsDirCheck) Fix(ctx *CheckContext) error {
+	redirect := NewBeadsRedirectCheck()
+	var errs []string
+	for _, name := range c.missing {
+		rigCtx := *ctx
+		rigCtx.RigName = name
+		if err := redirect.Fix(&rigCtx); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// RigRemoteCheck verifies each registered rig's repository has an origin
+// remote matching the git_url in rigs.json. It does not touch the network.
+type RigRemoteCheck struct {
+	FixableCheck
+	noOrigin map[string]string // Cached for Fix: repo dir -> registered git URL
+}
+
+// NewRigRemoteCheck creates a new rig git remote check.
+func NewRigRemoteCheck() *RigRemoteCheck {
+	return &RigRemoteCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "rig-git-remotes",
+				CheckDescription: "Check that rig repositories have a valid origin remote",
+				CheckCategory:    CategoryRig,
+			},
+		},
+	}
+}
+
+// rigRepoDir returns the rig's shared repository: the bare .repo.git if it
+// has one, else the mayor/rig clone. Empty if neither exists.
+func rigRepoDir(rigPath string) string {
+	if dirExists(filepath.Join(rigPath, ".repo.git")) {
+		return filepath.Join(rigPath, ".repo.git")
+	}
+	mayorRig := filepath.Join(rigPath, "mayor", "rig")
+	if _, err := os.Stat(filepath.Join(mayorRig, ".git")); err == nil {
+		return mayorRig
+	}
+	return ""
+}
+
+// Run checks the origin remote of every registered rig.
+func (c *RigRemoteCheck) Run(ctx *CheckContext) *CheckResult {
+	c.noOrigin = nil
+
+	rigs, err := loadRegisteredRigs(ctx.TownRoot)
+	if err != nil {
+		return registryErrorResult(c.Name(), err)
+	}
+
+	var details []string
+	noOrigin := make(map[string]string)
+	names := presentRegisteredRigs(ctx.TownRoot, rigs)
+	for _, name := range names {
+		repoDir := rigRepoDir(filepath.Join(ctx.TownRoot, name))
+		if repoDir == "" {
+			continue // RigIsGitRepoCheck reports missing clones
+		}
+		registered := rigs[name].GitURL
+
+		url, err := git.NewGit(repoDir).RemoteURL("origin")
+		if err != nil || url == "" {
+			details = append(details, fmt.Sprintf("%s: no origin remote", name))
+			if registered != "" {
+				noOrigin[repoDir] = registered
+			}
+			continue
+		}
+		if _, err := os.Stat(localRemotePath(url)); isLocalRemote(url) && err != nil {
+			details = append(details, fmt.Sprintf("%s: origin points to missing path %s", name, url))
+			continue
+		}
+		if registered != "" && url != registered {
+			details = append(details, fmt.Sprintf("%s: origin is %s, rigs.json has %s", name, url, registered))
+		}
+	}
+
+	if len(details) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("All %d rig(s) have a valid origin remote", len(names)),
+		}
+	}
+
+	c.noOrigin = noOrigin
+	hint := "Fix with 'git remote set-url origin <url>' in the rig repository"
+	if len(noOrigin) > 0 {
+		hint = "Run 'gt doctor --fix' to restore missing origins from rigs.json"
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d rig(s) with a broken git remote", len(details)),
+		Details: details,
+		FixHint: hint,
+	}
+}
+
+// Fix adds the registered git URL as origin where origin is missing.
+// Mismatched origins are left alone: they may be deliberate.
+func (c *RigRemoteCheck) Fix(ctx *CheckContext) error {
+	var errs []string
+	for repoDir, url := range c.noOrigin {
+		cmd := exec.Command("git", "-C", repoDir, "remote", "add", "origin", url)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", repoDir, strings.TrimSpace(string(output))))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("adding origin: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// isLocalRemote reports whether a remote URL is a filesystem path.
+func isLocalRemote(url string) bool {
+	return strings.HasPrefix(url, "/") || strings.HasPrefix(url, "file://")
+}
+
+func localRemotePath(url string) string {
+	return strings.TrimPrefix(url, "file://")
+}
+
+// StaleWitnessStateCheck finds witness supervisor state that claims a
+// patrol loop is running when the loop has stopped patrolling.
+type StaleWitnessStateCheck struct {
+	FixableCheck
+	stale []string // Cached for Fix: rig paths
+}
+
+// NewStaleWitnessStateCheck creates a new stale witness state check.
+func NewStaleWitnessStateCheck() *StaleWitnessStateCheck {
+	return &StaleWitnessStateCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "stale-witness-state",
+				CheckDescription: "Check for witness loop state left behind by dead loops",
+				CheckCategory:    CategoryCleanup,
+			},
+		},
+	}
+}
+
+// Run checks each registered rig's witness supervisor state against its
+// patrol history (see witness.LoopHealth).
+func (c *StaleWitnessStateCheck) Run(ctx *CheckContext) *CheckResult {
+	c.stale = nil
+
+	rigs, err := loadRegisteredRigs(ctx.TownRoot)
+	if err != nil {
+		return registryErrorResult(c.Name(), err)
+	}
+
+	now := time.Now()
+	var details []string
+	var stale []string
+	for _, name := range presentRegisteredRigs(ctx.TownRoot, rigs) {
+		rigPath := filepath.Join(ctx.TownRoot, name)
+		st, err := witness.LoadSupervisorState(rigPath)
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: unreadable %s: %v", name, witness.SupervisorStatePath(rigPath), err))
+			continue
+		}
+		if st == nil || !st.Running {
+			continue
+		}
+		alive, lastPatrol, err := witness.LoopHealth(rigPath, st, now)
+		if err != nil || alive {
+			continue
+		}
+		since := "never patrolled"
+		if !lastPatrol.IsZero() {
+			since = fmt.Sprintf("last patrol %s ago", now.Sub(lastPatrol).Round(time.Second))
+		}
+		details = append(details, fmt.Sprintf("%s: loop recorded as running (pid %d), %s", name, st.PID, since))
+		stale = append(stale, rigPath)
+	}
+
+	if len(details) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No stale witness state",
+		}
+	}
+
+	c.stale = stale
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d stale witness state file(s)", len(details)),
+		Details: details,
+		FixHint: "Run 'gt doctor --fix' to mark dead witness loops as stopped",
+	}
+}
+
+// Fix marks the dead loops as stopped. The daemon rewrites the state when
+// it next starts a loop, so nothing else is lost.
+func (c *StaleWitnessStateCheck) Fix(ctx *CheckContext) error {
+	var errs []string
+	for _, rigPath := range c.stale {
+		if err := witness.RecordLoopExit(rigPath, nil); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", filepath.Base(rigPath), err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("updating witness state: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
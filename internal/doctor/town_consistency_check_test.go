@@ -0,0 +1,199 @@
+package doctor
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+// setupTownRigs creates a town whose rigs.json registers the given rigs,
+// each with a directory.
+func setupTownRigs(t *testing.T, rigs map[string]string) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	rigsConfig := &config.RigsConfig{Version: config.CurrentRigsVersion, Rigs: make(map[string]config.RigEntry)}
+	for name, url := range rigs {
+		rigsConfig.Rigs[name] = config.RigEntry{GitURL: url}
+		if err := os.MkdirAll(filepath.Join(townRoot, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := config.SaveRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"), rigsConfig); err != nil {
+		t.Fatal(err)
+	}
+	return townRoot
+}
+
+func TestUnregisteredRigCheck(t *testing.T) {
+	townRoot := setupTownRigs(t, map[string]string{"gastown": "https://example.com/gastown.git"})
+
+	// A rig workspace with its config.json, and a bare-looking one
+	stray := filepath.Join(townRoot, "beads")
+	if err := os.MkdirAll(stray, 0755); err != nil {
+		t.Fatal(err)
+	}
+	err := config.SaveRigConfig(filepath.Join(stray, "config.json"), &config.RigConfig{
+		Type: "rig", Version: 1, Name: "beads", GitURL: "https://example.com/beads.git",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(townRoot, "leftover", "polecats"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(townRoot, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	check := NewUnregisteredRigCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+	result := check.Run(ctx)
+	if result.Status != StatusWarning || len(result.Details) != 2 {
+		t.Fatalf("Run = %v %v, want a warning for beads and leftover", result.Status, result.Details)
+	}
+
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	rigsConfig, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rigsConfig.Rigs["beads"].GitURL; got != "https://example.com/beads.git" {
+		t.Errorf("registered beads git_url = %q", got)
+	}
+	if _, ok := rigsConfig.Rigs["leftover"]; ok {
+		t.Error("Fix registered a workspace without a rig config.json")
+	}
+
+	result = check.Run(ctx)
+	if len(result.Details) != 1 || !strings.HasPrefix(result.Details[0], "leftover/") {
+		t.Errorf("after fix, details = %v; want only leftover", result.Details)
+	}
+}
+
+func TestRigBeadsDirCheck(t *testing.T) {
+	townRoot := setupTownRigs(t, map[string]string{"gastown": "", "beads": ""})
+	if err := os.MkdirAll(filepath.Join(townRoot, "beads", ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// gastown has tracked beads but no rig-level redirect
+	if err := os.MkdirAll(filepath.Join(townRoot, "gastown", "mayor", "rig", ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	check := NewRigBeadsDirCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+	result := check.Run(ctx)
+	if result.Status != StatusError || len(result.Details) != 1 || !strings.Contains(result.Details[0], "gastown") {
+		t.Fatalf("Run = %v %v, want gastown missing", result.Status, result.Details)
+	}
+
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	redirect, err := os.ReadFile(filepath.Join(townRoot, "gastown", ".beads", "redirect"))
+	if err != nil {
+		t.Fatalf("reading redirect: %v", err)
+	}
+	if strings.TrimSpace(string(redirect)) != "mayor/rig/.beads" {
+		t.Errorf("redirect = %q", redirect)
+	}
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Errorf("after fix, status = %v %v", result.Status, result.Details)
+	}
+}
+
+func TestRigRemoteCheck(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	townRoot := setupTownRigs(t, map[string]string{
+		"gastown": "https://example.com/gastown.git",
+		"beads":   "https://example.com/beads.git",
+		"local":   "/nonexistent/repo.git",
+	})
+	initRepo := func(rig, origin string) {
+		dir := filepath.Join(townRoot, rig, "mayor", "rig")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		args := [][]string{{"init", "-q"}}
+		if origin != "" {
+			args = append(args, []string{"remote", "add", "origin", origin})
+		}
+		for _, a := range args {
+			if out, err := exec.Command("git", append([]string{"-C", dir}, a...)...).CombinedOutput(); err != nil {
+				t.Fatalf("git %v: %v\n%s", a, err, out)
+			}
+		}
+	}
+	initRepo("gastown", "https://example.com/gastown.git")
+	initRepo("beads", "")
+	initRepo("local", "/nonexistent/repo.git")
+
+	check := NewRigRemoteCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+	result := check.Run(ctx)
+	if result.Status != StatusWarning || len(result.Details) != 2 {
+		t.Fatalf("Run = %v %v, want beads and local flagged", result.Status, result.Details)
+	}
+
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	result = check.Run(ctx)
+	if len(result.Details) != 1 || !strings.HasPrefix(result.Details[0], "local:") {
+		t.Errorf("after fix, details = %v; want only local", result.Details)
+	}
+}
+
+func TestStaleWitnessStateCheck(t *testing.T) {
+	townRoot := setupTownRigs(t, map[string]string{"gastown": "", "beads": ""})
+
+	// gastown's loop started a day ago and never patrolled
+	gastown := filepath.Join(townRoot, "gastown")
+	if err := witness.RecordLoopStart(gastown, 12345, time.Minute, false); err != nil {
+		t.Fatal(err)
+	}
+	st, _ := witness.LoadSupervisorState(gastown)
+	st.StartedAt = time.Now().Add(-24 * time.Hour)
+	data, _ := json.Marshal(st)
+	if err := os.WriteFile(witness.SupervisorStatePath(gastown), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// beads' loop was stopped on purpose
+	beadsRig := filepath.Join(townRoot, "beads")
+	if err := witness.RecordLoopStart(beadsRig, 999, time.Minute, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := witness.RecordLoopExit(beadsRig, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	check := NewStaleWitnessStateCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+	result := check.Run(ctx)
+	if result.Status != StatusWarning || len(result.Details) != 1 || !strings.HasPrefix(result.Details[0], "gastown:") {
+		t.Fatalf("Run = %v %v, want gastown stale", result.Status, result.Details)
+	}
+
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	st, _ = witness.LoadSupervisorState(gastown)
+	if st.Running || st.PID != 0 {
+		t.Errorf("after fix, state = %+v; want stopped", st)
+	}
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Errorf("after fix, status = %v %v", result.Status, result.Details)
+	}
+}
@@ -1,7 +1,8 @@
 // Package events provides event logging for the gt activity feed.
 //
 // Events are written to ~/gt/.events.jsonl (raw audit log) and later
-// curated by the feed daemon into ~/.feed.jsonl (user-facing).
+// curated by the feed daemon into ~/.feed.jsonl (user-facing). A process
+// can also Subscribe to receive them as they are written.
 package events
 
 import (
@@ -58,6 +59,7 @@ const (
 	TypePatrolStarted   = "patrol_started"
 	TypePolecatChecked  = "polecat_checked"
 	TypePolecatNudged   = "polecat_nudged"
+	TypePolecatRecycled = "polecat_recycled"
 	TypeEscalationSent   = "escalation_sent"
 	TypeEscalationAcked  = "escalation_acked"
 	TypeEscalationClosed = "escalation_closed"
@@ -68,6 +70,10 @@ const (
 	TypeMerged       = "merged"
 	TypeMergeFailed  = "merge_failed"
 	TypeMergeSkipped = "merge_skipped"
+
+	// Scheduled job events (emitted by the daemon scheduler)
+	TypeScheduleRun    = "schedule_run"
+	TypeScheduleFailed = "schedule_failed"
 )
 
 // EventsFile is the name of the raw events log.
@@ -88,6 +94,11 @@ func Log(eventType, actor string, payload map[string]interface{}, visibility str
 		Payload:    payload,
 		Visibility: visibility,
 	}
+	// Events raised from inside an event hook are marked so the hook
+	// runner doesn't feed them back to hooks
+	if hook := os.Getenv("GT_EVENT_HOOK"); hook != "" {
+		event.Source = hookSourcePrefix + hook
+	}
 	return write(event)
 }
 
@@ -133,6 +144,7 @@ func write(event Event) error {
 		return fmt.Errorf("writing event: %w", err)
 	}
 
+	broadcast(townRoot, data)
 	return nil
 }
 
@@ -247,6 +259,15 @@ func NudgePayload(rig, target, reason string) map[string]interface{} {
 	}
 }
 
+// RecyclePayload creates a payload for polecat_recycled events.
+func RecyclePayload(rig, polecat, reason string) map[string]interface{} {
+	return map[string]interface{}{
+		"rig":     rig,
+		"polecat": polecat,
+		"reason":  reason,
+	}
+}
+
 // EscalationPayload creates a payload for escalation events.
 func EscalationPayload(rig, target, to, reason string) map[string]interface{} {
 	return map[string]interface{}{
@@ -280,6 +301,22 @@ func HaltPayload(services []string) map[string]interface{} {
 	}
 }
 
+// SchedulePayload creates a payload for scheduled job events.
+// status: ok, failed, or timeout
+// errMsg: failure detail (empty on success)
+func SchedulePayload(job, command, status, duration, errMsg string) map[string]interface{} {
+	p := map[string]interface{}{
+		"job":      job,
+		"command":  command,
+		"status":   status,
+		"duration": duration,
+	}
+	if errMsg != "" {
+		p["error"] = errMsg
+	}
+	return p
+}
+
 // SessionDeathPayload creates a payload for session death events.
 // session: tmux session name that died
 // agent: Gas Town agent identity (e.g., "gastown/polecats/Toast")
@@ -0,0 +1,293 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/notify"
+)
+
+// hookSourcePrefix marks events logged by a hook command (see Log). The
+// runner ignores them, so a hook can't trigger itself in a loop.
+const hookSourcePrefix = "hook:"
+
+// hookWorkers bounds how many events have their hooks running at once.
+// Further events wait in the tailer until a worker is free.
+const hookWorkers = 4
+
+// HookResult is the outcome of running one hook for an event.
+type HookResult struct {
+	Hook  string
+	Error error
+}
+
+// HookRunner runs the hooks in settings/event-hooks.json for each event
+// written to the town's events log. The config is reloaded when it changes,
+// so hooks can be edited without restarting the daemon.
+type HookRunner struct {
+	townRoot string
+	logf     func(format string, args ...interface{})
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+
+	mu      sync.Mutex
+	cfg     *config.EventHooksConfig
+	cfgTime time.Time
+}
+
+// NewHookRunner creates a hook runner for a town. logf receives hook
+// failures; it may be nil.
+func NewHookRunner(townRoot string, logf func(format string, args ...interface{})) *HookRunner {
+	if logf == nil {
+		logf = func(string, ...interface{}) {}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &HookRunner{
+		townRoot: townRoot,
+		logf:     logf,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Start begins following the events log. Only events written after Start
+// run hooks.
+func (r *HookRunner) Start() error {
+	tailer, err := NewTailer(filepath.Join(r.townRoot, EventsFile), false)
+	if err != nil {
+		return fmt.Errorf("tailing events file: %w", err)
+	}
+
+	r.wg.Add(1)
+	go r.run(tailer)
+	return nil
+}
+
+// Stop stops following the log and waits for running hooks to finish.
+func (r *HookRunner) Stop() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+// run hands each new event to a pool of workers, so a slow hook doesn't
+// hold up the ones after it.
+func (r *HookRunner) run(tailer *Tailer) {
+	defer r.wg.Done()
+	defer tailer.Close()
+
+	queue := make(chan Event)
+	defer close(queue)
+	for i := 0; i < hookWorkers; i++ {
+		r.wg.Add(1)
+		go r.work(queue)
+	}
+
+	lines := tailer.Lines()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case line, ok := <-lines:
+			if !ok {
+				return
+			}
+			var e Event
+			if err := json.Unmarshal([]byte(line), &e); err != nil {
+				continue // Skip malformed lines
+			}
+			select {
+			case queue <- e:
+			case <-r.ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// work runs the hooks of each event from queue until it is closed.
+func (r *HookRunner) work(queue <-chan Event) {
+	defer r.wg.Done()
+	for e := range queue {
+		for _, res := range r.Dispatch(r.ctx, e) {
+			if res.Error != nil {
+				r.logf("event hook %s (%s): %v", res.Hook, e.Type, res.Error)
+			}
+		}
+	}
+}
+
+// Dispatch runs every enabled hook that matches e and waits for them.
+func (r *HookRunner) Dispatch(ctx context.Context, e Event) []HookResult {
+	if strings.HasPrefix(e.Source, hookSourcePrefix) {
+		return nil
+	}
+	cfg, err := r.config()
+	if err != nil {
+		return []HookResult{{Hook: "config", Error: err}}
+	}
+
+	rig := eventRig(e)
+	var results []HookResult
+	for _, hook := range cfg.Hooks {
+		if hook.Disabled || !hook.Match.Matches(e.Type, e.Actor, rig) {
+			continue
+		}
+		results = append(results, HookResult{Hook: hook.Name, Error: r.runHook(ctx, hook, e, rig)})
+	}
+	return results
+}
+
+// config returns the hooks config, reloading it if the file changed.
+func (r *HookRunner) config() (*config.EventHooksConfig, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	path := config.EventHooksConfigPath(r.townRoot)
+	var modTime time.Time
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+	if r.cfg != nil && modTime.Equal(r.cfgTime) {
+		return r.cfg, nil
+	}
+
+	cfg, err := config.LoadOrCreateEventHooksConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	r.cfg = cfg
+	r.cfgTime = modTime
+	return cfg, nil
+}
+
+// runHook runs each of a hook's actions, returning the first failure.
+func (r *HookRunner) runHook(ctx context.Context, hook *config.EventHook, e Event, rig string) error {
+	ctx, cancel := context.WithTimeout(ctx, hook.TimeoutDuration())
+	defer cancel()
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshaling event: %w", err)
+	}
+
+	var errs []string
+	if hook.Command != "" {
+		if err := r.runCommand(ctx, hook, e, rig, data); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if hook.URL != "" {
+		if err := postEvent(ctx, hook, data); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if hook.Sink != "" {
+		if err := r.notifySink(ctx, hook, e); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// runCommand runs a hook's command with the event on stdin.
+func (r *HookRunner) runCommand(ctx context.Context, hook *config.EventHook, e Event, rig string, data []byte) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", hook.Command) //nolint:gosec // G204: command comes from the town's own settings
+	cmd.Dir = r.townRoot
+	cmd.Stdin = bytes.NewReader(append(data, '\n'))
+	cmd.Env = append(os.Environ(),
+		"GT_EVENT_TYPE="+e.Type,
+		"GT_EVENT_ACTOR="+e.Actor,
+		"GT_EVENT_RIG="+rig,
+		"GT_EVENT_TS="+e.Timestamp,
+		"GT_EVENT_HOOK="+hook.Name,
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("command: %w: %s", err, msg)
+		}
+		return fmt.Errorf("command: %w", err)
+	}
+	return nil
+}
+
+// postEvent POSTs the event JSON to a hook's URL.
+func postEvent(ctx context.Context, hook *config.EventHook, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range hook.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook: %s returned %s", hook.URL, resp.Status)
+	}
+	return nil
+}
+
+// notifySink sends the event to a notification sink.
+func (r *HookRunner) notifySink(ctx context.Context, hook *config.EventHook, e Event) error {
+	cfg, err := config.LoadOrCreateNotificationsConfig(config.NotificationsConfigPath(r.townRoot))
+	if err != nil {
+		return fmt.Errorf("sink: %w", err)
+	}
+
+	body := ""
+	if len(e.Payload) > 0 {
+		if data, err := json.MarshalIndent(e.Payload, "", "  "); err == nil {
+			body = string(data)
+		}
+	}
+	n := notify.Notification{
+		Event:    e.Type,
+		Severity: hook.Severity,
+		Title:    fmt.Sprintf("%s by %s", e.Type, e.Actor),
+		Body:     body,
+		Source:   e.Actor,
+	}
+	if t, err := time.Parse(time.RFC3339, e.Timestamp); err == nil {
+		n.Time = t
+	}
+
+	res := notify.NewDispatcher(r.townRoot, cfg).DispatchTo(ctx, hook.Sink, n)
+	if res.RateLimited {
+		return fmt.Errorf("sink %s: rate limited", hook.Sink)
+	}
+	if res.Error != nil {
+		return fmt.Errorf("sink %s: %w", hook.Sink, res.Error)
+	}
+	return nil
+}
+
+// eventRig returns the rig an event concerns: its payload's rig, else the
+// rig in its actor address. Town-level actors have none.
+func eventRig(e Event) string {
+	if rig, ok := e.Payload["rig"].(string); ok && rig != "" {
+		return rig
+	}
+	if i := strings.Index(e.Actor, "/"); i > 0 {
+		return e.Actor[:i]
+	}
+	return ""
+}
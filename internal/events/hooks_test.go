@@ -0,0 +1,189 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeHooks(t *testing.T, townRoot, hooks string) {
+	t.Helper()
+	dir := filepath.Join(townRoot, "settings")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"type": "event-hooks", "version": 1, "hooks": ` + hooks + `}`
+	if err := os.WriteFile(filepath.Join(dir, "event-hooks.json"), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHookRunnerCommand(t *testing.T) {
+	townRoot := t.TempDir()
+	writeHooks(t, townRoot, `[
+		{"name": "kills", "match": {"actor": "*/witness", "types": ["kill"]},
+		 "command": "echo \"$GT_EVENT_TYPE $GT_EVENT_RIG $GT_EVENT_HOOK\" > out.txt; cat >> out.txt"},
+		{"name": "off", "command": "touch off.txt", "disabled": true}
+	]`)
+
+	r := NewHookRunner(townRoot, nil)
+	e := Event{Type: TypeKill, Actor: "gastown/witness", Source: "gt", Payload: map[string]interface{}{"target": "Toast"}}
+	results := r.Dispatch(context.Background(), e)
+	if len(results) != 1 || results[0].Hook != "kills" || results[0].Error != nil {
+		t.Fatalf("results = %+v", results)
+	}
+
+	out, err := os.ReadFile(filepath.Join(townRoot, "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.SplitN(strings.TrimSpace(string(out)), "\n", 2)
+	if lines[0] != "kill gastown kills" {
+		t.Errorf("env line = %q", lines[0])
+	}
+	var got Event
+	if len(lines) < 2 || json.Unmarshal([]byte(lines[1]), &got) != nil || got.Payload["target"] != "Toast" {
+		t.Errorf("stdin = %q, want the event JSON", out)
+	}
+	if _, err := os.Stat(filepath.Join(townRoot, "off.txt")); err == nil {
+		t.Error("disabled hook ran")
+	}
+
+	// Other actors and hook-raised events don't match
+	if res := r.Dispatch(context.Background(), Event{Type: TypeKill, Actor: "mayor"}); len(res) != 0 {
+		t.Errorf("mayor kill ran hooks: %+v", res)
+	}
+	e.Source = hookSourcePrefix + "kills"
+	if res := r.Dispatch(context.Background(), e); len(res) != 0 {
+		t.Errorf("hook-raised event ran hooks: %+v", res)
+	}
+}
+
+func TestHookRunnerCommandFailure(t *testing.T) {
+	townRoot := t.TempDir()
+	writeHooks(t, townRoot, `[{"name": "bad", "command": "echo boom >&2; exit 3"}]`)
+
+	results := NewHookRunner(townRoot, nil).Dispatch(context.Background(), Event{Type: TypeDone, Actor: "mayor"})
+	if len(results) != 1 || results[0].Error == nil || !strings.Contains(results[0].Error.Error(), "boom") {
+		t.Fatalf("results = %+v, want error with command output", results)
+	}
+}
+
+func TestHookRunnerWebhook(t *testing.T) {
+	received := make(chan Event, 1)
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		var e Event
+		_ = json.Unmarshal(body, &e)
+		received <- e
+	}))
+	defer srv.Close()
+
+	townRoot := t.TempDir()
+	writeHooks(t, townRoot, `[{"name": "merges", "match": {"types": ["merge_*"], "rig": "gastown"},
+		"url": "`+srv.URL+`", "headers": {"Authorization": "Bearer x"}}]`)
+
+	r := NewHookRunner(townRoot, nil)
+	results := r.Dispatch(context.Background(), Event{Type: TypeMergeFailed, Actor: "gastown/refinery"})
+	if len(results) != 1 || results[0].Error != nil {
+		t.Fatalf("results = %+v", results)
+	}
+	if e := <-received; e.Type != TypeMergeFailed {
+		t.Errorf("posted type = %q", e.Type)
+	}
+	if auth != "Bearer x" {
+		t.Errorf("Authorization = %q", auth)
+	}
+
+	if res := r.Dispatch(context.Background(), Event{Type: TypeMergeFailed, Actor: "beads/refinery"}); len(res) != 0 {
+		t.Errorf("other rig ran hooks: %+v", res)
+	}
+}
+
+func TestHookRunnerFollowsLog(t *testing.T) {
+	townRoot := t.TempDir()
+	writeHooks(t, townRoot, `[{"name": "touch", "match": {"types": ["done"]}, "command": "cat > done.json"}]`)
+
+	r := NewHookRunner(townRoot, nil)
+	if err := r.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer r.Stop()
+
+	data, _ := json.Marshal(Event{Type: TypeDone, Actor: "gastown/polecats/Toast", Source: "gt"})
+	appendLine(t, filepath.Join(townRoot, EventsFile), string(data)+"\n")
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if out, err := os.ReadFile(filepath.Join(townRoot, "done.json")); err == nil && len(out) > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("hook did not run for logged event")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestHookRunnerBoundsConcurrency(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.Mkdir(filepath.Join(townRoot, "running"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Each run records how many runs are in progress, itself included
+	writeHooks(t, townRoot, `[{"name": "slow", "command": "touch running/$$; ls running | wc -l >> peaks; sleep 0.2; rm running/$$"}]`)
+
+	r := NewHookRunner(townRoot, nil)
+	if err := r.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer r.Stop()
+
+	const n = 3 * hookWorkers
+	data, _ := json.Marshal(Event{Type: TypeDone, Actor: "gastown/polecats/Toast", Source: "gt"})
+	appendLine(t, filepath.Join(townRoot, EventsFile), strings.Repeat(string(data)+"\n", n))
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		out, _ := os.ReadFile(filepath.Join(townRoot, "peaks"))
+		peaks := strings.Fields(string(out))
+		if len(peaks) == n {
+			for _, p := range peaks {
+				if running, _ := strconv.Atoi(p); running > hookWorkers {
+					t.Fatalf("%d hooks ran at once, want at most %d", running, hookWorkers)
+				}
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d of %d hooks ran", len(peaks), n)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestEventRig(t *testing.T) {
+	tests := []struct {
+		e    Event
+		want string
+	}{
+		{Event{Actor: "gastown/witness"}, "gastown"},
+		{Event{Actor: "mayor"}, ""},
+		{Event{Actor: "mayor", Payload: map[string]interface{}{"rig": "beads"}}, "beads"},
+	}
+	for _, tt := range tests {
+		if got := eventRig(tt.e); got != tt.want {
+			t.Errorf("eventRig(%+v) = %q, want %q", tt.e, got, tt.want)
+		}
+	}
+}
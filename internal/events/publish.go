@@ -0,0 +1,140 @@
+package events
+
+// Typed lifecycle events. Rather than building payload maps by hand, packages
+// that change agent or work state publish one of these, so every producer of
+// an event type writes the same fields and the feed can rely on them instead
+// of scraping bd output.
+
+// Lifecycle event types published through Publish.
+const (
+	// Crew workspace events
+	TypeCrewAdded   = "crew_added"
+	TypeCrewRemoved = "crew_removed"
+	TypeCrewRenamed = "crew_renamed"
+
+	// Agent session events (tmux session created or torn down)
+	TypeSessionCreated = "session_created"
+	TypeSessionStopped = "session_stopped"
+
+	// Molecule events
+	TypeMoleculeAttached  = "molecule_attached"
+	TypeMoleculeDetached  = "molecule_detached"
+	TypeStepDone          = "step_done"
+	TypeMoleculeCompleted = "molecule_completed"
+	TypeMoleculeBurned    = "molecule_burned"
+	TypeMoleculeSquashed  = "molecule_squashed"
+	TypeMoleculeCancelled = "molecule_cancelled"
+)
+
+// Typed is an event with a fixed schema.
+type Typed interface {
+	// EventType is the event's type, e.g. TypeCrewAdded.
+	EventType() string
+	// EventPayload is the event's payload as written to the log.
+	EventPayload() map[string]interface{}
+}
+
+// Publish records a typed event in the feed on behalf of actor. Like Log,
+// it is best-effort and a no-op outside a Gas Town workspace.
+func Publish(actor string, e Typed) error {
+	return Log(e.EventType(), actor, e.EventPayload(), VisibilityFeed)
+}
+
+// CrewEvent is a crew workspace being added, removed or renamed.
+type CrewEvent struct {
+	Type    string // TypeCrewAdded, TypeCrewRemoved or TypeCrewRenamed
+	Rig     string
+	Name    string
+	NewName string // renames only
+}
+
+// EventType implements Typed.
+func (e CrewEvent) EventType() string { return e.Type }
+
+// EventPayload implements Typed.
+func (e CrewEvent) EventPayload() map[string]interface{} {
+	p := map[string]interface{}{
+		"rig":  e.Rig,
+		"name": e.Name,
+	}
+	if e.NewName != "" {
+		p["new_name"] = e.NewName
+	}
+	return p
+}
+
+// SessionEvent is an agent's tmux session being created or stopped.
+type SessionEvent struct {
+	Type    string // TypeSessionCreated or TypeSessionStopped
+	Session string // tmux session name
+	Agent   string // agent address, e.g. "gastown/polecats/Toast"
+	Rig     string
+	Reason  string // why a session was stopped, if known
+}
+
+// EventType implements Typed.
+func (e SessionEvent) EventType() string { return e.Type }
+
+// EventPayload implements Typed.
+func (e SessionEvent) EventPayload() map[string]interface{} {
+	p := map[string]interface{}{
+		"session": e.Session,
+		"agent":   e.Agent,
+	}
+	if e.Rig != "" {
+		p["rig"] = e.Rig
+	}
+	if e.Reason != "" {
+		p["reason"] = e.Reason
+	}
+	return p
+}
+
+// MailEvent is a message being sent. Its payload is a superset of
+// MailPayload's.
+type MailEvent struct {
+	To        string
+	Subject   string
+	MessageID string
+}
+
+// EventType implements Typed.
+func (e MailEvent) EventType() string { return TypeMail }
+
+// EventPayload implements Typed.
+func (e MailEvent) EventPayload() map[string]interface{} {
+	p := MailPayload(e.To, e.Subject)
+	if e.MessageID != "" {
+		p["id"] = e.MessageID
+	}
+	return p
+}
+
+// MoleculeEvent is a change in a molecule's lifecycle.
+type MoleculeEvent struct {
+	Type     string // one of the TypeMolecule* types or TypeStepDone
+	Molecule string // molecule root bead
+	Step     string // step bead, for TypeStepDone
+	Holder   string // bead the molecule is attached to, if any
+	Reason   string
+}
+
+// EventType implements Typed.
+func (e MoleculeEvent) EventType() string { return e.Type }
+
+// EventPayload implements Typed.
+func (e MoleculeEvent) EventPayload() map[string]interface{} {
+	p := map[string]interface{}{
+		"molecule": e.Molecule,
+	}
+	if e.Step != "" {
+		p["step"] = e.Step
+	}
+	if e.Holder != "" {
+		p["holder"] = e.Holder
+	}
+	if e.Reason != "" {
+		p["reason"] = e.Reason
+	}
+	return p
+}
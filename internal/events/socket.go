@@ -0,0 +1,122 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SocketFile is the name of the town's event socket. When a subscriber is
+// listening on it, every event written to the log is also sent there, so
+// consumers get events as they happen without polling the log file.
+const SocketFile = ".events.sock"
+
+// socketTimeout bounds how long a publisher waits on a slow subscriber.
+const socketTimeout = 100 * time.Millisecond
+
+// ErrSubscribed means another process already listens on the event socket.
+var ErrSubscribed = errors.New("event socket already has a subscriber")
+
+// broadcast sends an encoded event line to the town's subscriber, if any.
+// Delivery is best-effort: with no subscriber, or a stuck one, it does
+// nothing.
+func broadcast(townRoot string, line []byte) {
+	path := filepath.Join(townRoot, SocketFile)
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+	conn, err := net.DialTimeout("unix", path, socketTimeout)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	_ = conn.SetWriteDeadline(time.Now().Add(socketTimeout))
+	_, _ = conn.Write(line)
+}
+
+// Subscription receives events published in a town through its socket.
+type Subscription struct {
+	listener net.Listener
+	path     string
+	events   chan Event
+	once     sync.Once
+}
+
+// Subscribe listens on townRoot's event socket. It fails with ErrSubscribed
+// if another process is listening; a socket left behind by a process that
+// died is replaced.
+func Subscribe(townRoot string) (*Subscription, error) {
+	path := filepath.Join(townRoot, SocketFile)
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, socketTimeout); err == nil {
+			_ = conn.Close()
+			return nil, ErrSubscribed
+		}
+		_ = os.Remove(path)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Subscription{
+		listener: listener,
+		path:     path,
+		events:   make(chan Event, 100),
+	}
+	go s.accept()
+	return s, nil
+}
+
+// Events returns the channel events arrive on. It is closed by Close.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close stops listening and removes the socket.
+func (s *Subscription) Close() error {
+	var err error
+	s.once.Do(func() {
+		err = s.listener.Close()
+		_ = os.Remove(s.path)
+	})
+	return err
+}
+
+// accept reads each publisher connection's event lines until Close.
+func (s *Subscription) accept() {
+	var wg sync.WaitGroup
+	defer func() {
+		wg.Wait()
+		close(s.events)
+	}()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer conn.Close()
+			scanner := bufio.NewScanner(conn)
+			for scanner.Scan() {
+				var e Event
+				if json.Unmarshal(scanner.Bytes(), &e) != nil {
+					continue
+				}
+				select {
+				case s.events <- e:
+				default:
+					// Drop the event rather than stall publishers
+				}
+			}
+		}()
+	}
+}
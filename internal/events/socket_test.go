@@ -0,0 +1,94 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// shortTempDir returns a temp dir with a path short enough for a unix socket.
+func shortTempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "gtev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return dir
+}
+
+func TestSubscribeReceivesBroadcast(t *testing.T) {
+	townRoot := shortTempDir(t)
+
+	sub, err := Subscribe(townRoot)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer sub.Close()
+
+	if _, err := Subscribe(townRoot); !errors.Is(err, ErrSubscribed) {
+		t.Errorf("second Subscribe error = %v, want ErrSubscribed", err)
+	}
+
+	e := CrewEvent{Type: TypeCrewAdded, Rig: "gastown", Name: "joe"}
+	line, err := json.Marshal(Event{Type: e.EventType(), Actor: "gastown/crew/joe", Payload: e.EventPayload(), Visibility: VisibilityFeed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	broadcast(townRoot, append(line, '\n'))
+
+	select {
+	case got := <-sub.Events():
+		if got.Type != TypeCrewAdded || got.Actor != "gastown/crew/joe" || got.Payload["name"] != "joe" {
+			t.Errorf("received %+v", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+	}
+
+	if err := sub.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(townRoot, SocketFile)); !os.IsNotExist(err) {
+		t.Errorf("socket not removed after Close: %v", err)
+	}
+}
+
+func TestSubscribeReplacesStaleSocket(t *testing.T) {
+	townRoot := shortTempDir(t)
+	// A leftover file nobody listens on
+	if err := os.WriteFile(filepath.Join(townRoot, SocketFile), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := Subscribe(townRoot)
+	if err != nil {
+		t.Fatalf("Subscribe over stale socket: %v", err)
+	}
+	_ = sub.Close()
+}
+
+func TestBroadcastWithoutSubscriber(t *testing.T) {
+	// Must not block or fail when nobody is listening
+	broadcast(shortTempDir(t), []byte("{}\n"))
+}
+
+func TestTypedPayloads(t *testing.T) {
+	mail := MailEvent{To: "gastown/witness", Subject: "hi", MessageID: "hq-1"}.EventPayload()
+	if mail["to"] != "gastown/witness" || mail["subject"] != "hi" || mail["id"] != "hq-1" {
+		t.Errorf("MailEvent payload = %v", mail)
+	}
+
+	session := SessionEvent{Type: TypeSessionStopped, Session: "gt-gastown-Toast", Agent: "gastown/polecats/Toast"}.EventPayload()
+	if _, ok := session["reason"]; ok {
+		t.Errorf("empty reason should be omitted: %v", session)
+	}
+
+	mol := MoleculeEvent{Type: TypeStepDone, Molecule: "gt-abc", Step: "gt-abc.1"}
+	if mol.EventType() != TypeStepDone || mol.EventPayload()["step"] != "gt-abc.1" {
+		t.Errorf("MoleculeEvent = %s %v", mol.EventType(), mol.EventPayload())
+	}
+}
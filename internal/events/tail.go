@@ -0,0 +1,223 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Poll intervals for Tailer. Changes normally arrive through fsnotify; the
+// poll is a backstop for filesystems that don't report them, and the only
+// trigger if a watch can't be set up.
+const (
+	tailPollInterval     = 2 * time.Second
+	tailFallbackInterval = 250 * time.Millisecond
+)
+
+// Tailer follows a line-oriented log such as the events file, sending each
+// complete line as it is appended. It survives log rotation: when the file
+// is truncated in place it starts again from the top, and when it is renamed
+// away and replaced it finishes the old file and then follows the new one.
+type Tailer struct {
+	path    string
+	lines   chan string
+	watcher *fsnotify.Watcher
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	// Read state, owned by the run goroutine
+	file    *os.File
+	info    os.FileInfo
+	reader  *bufio.Reader
+	offset  int64
+	partial string
+}
+
+// NewTailer follows path from its current end, or from the start if
+// fromStart is set. The file need not exist yet; it is read from the start
+// once created. Its directory must exist.
+func NewTailer(path string, fromStart bool) (*Tailer, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &Tailer{
+		path:   path,
+		lines:  make(chan string, 100),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	if err := t.open(); err != nil && !os.IsNotExist(err) {
+		cancel()
+		return nil, err
+	}
+	if t.file != nil && !fromStart {
+		offset, err := t.file.Seek(0, io.SeekEnd)
+		if err != nil {
+			_ = t.file.Close()
+			cancel()
+			return nil, err
+		}
+		t.offset = offset
+	}
+
+	// Watch the directory rather than the file, so a rotated-in replacement
+	// is noticed too
+	if w, err := fsnotify.NewWatcher(); err == nil {
+		if err := w.Add(filepath.Dir(path)); err == nil {
+			t.watcher = w
+		} else {
+			_ = w.Close()
+		}
+	}
+
+	go t.run(ctx)
+	return t, nil
+}
+
+// Lines returns the channel lines arrive on, without their newline. It is
+// closed by Close.
+func (t *Tailer) Lines() <-chan string {
+	return t.lines
+}
+
+// Close stops following the file.
+func (t *Tailer) Close() error {
+	t.cancel()
+	<-t.done
+	return nil
+}
+
+// run reads whatever is new each time the file or its directory changes.
+func (t *Tailer) run(ctx context.Context) {
+	defer close(t.done)
+	defer close(t.lines)
+	defer func() {
+		if t.file != nil {
+			_ = t.file.Close()
+		}
+		if t.watcher != nil {
+			_ = t.watcher.Close()
+		}
+	}()
+
+	interval := tailPollInterval
+	var changes <-chan fsnotify.Event
+	var errs <-chan error
+	if t.watcher != nil {
+		changes = t.watcher.Events
+		errs = t.watcher.Errors
+	} else {
+		interval = tailFallbackInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	if !t.check(ctx) {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-changes:
+			if !ok {
+				changes = nil
+				continue
+			}
+			if filepath.Clean(ev.Name) != filepath.Clean(t.path) {
+				continue
+			}
+		case _, ok := <-errs:
+			if !ok {
+				errs = nil
+			}
+			continue
+		case <-ticker.C:
+		}
+		if !t.check(ctx) {
+			return
+		}
+	}
+}
+
+// check sends any new lines, then handles truncation and rotation. It
+// returns false if the tailer was closed meanwhile.
+func (t *Tailer) check(ctx context.Context) bool {
+	if !t.drain(ctx) {
+		return false
+	}
+
+	info, err := os.Stat(t.path)
+	if err != nil {
+		// Rotated away and not yet replaced: keep what we have open
+		return true
+	}
+	switch {
+	case t.file == nil || !os.SameFile(t.info, info):
+		// Created, or replaced by rotation. The old file was drained above.
+		if t.file != nil {
+			_ = t.file.Close()
+			t.file = nil
+		}
+		if err := t.open(); err != nil {
+			return true
+		}
+	case info.Size() < t.offset:
+		// Truncated in place: start over
+		if _, err := t.file.Seek(0, io.SeekStart); err != nil {
+			return true
+		}
+		t.reader.Reset(t.file)
+		t.offset = 0
+		t.partial = ""
+	default:
+		return true
+	}
+	return t.drain(ctx)
+}
+
+// open opens the file at path and reads it from the start.
+func (t *Tailer) open() error {
+	file, err := os.Open(t.path)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	t.file = file
+	t.info = info
+	t.reader = bufio.NewReader(file)
+	t.offset = 0
+	t.partial = ""
+	return nil
+}
+
+// drain sends every complete line available, holding back a trailing
+// partial line until the rest of it is written.
+func (t *Tailer) drain(ctx context.Context) bool {
+	if t.file == nil {
+		return true
+	}
+	for {
+		chunk, err := t.reader.ReadString('\n')
+		t.offset += int64(len(chunk))
+		if err != nil {
+			t.partial += chunk
+			return true
+		}
+		line := t.partial + chunk[:len(chunk)-1]
+		t.partial = ""
+		select {
+		case t.lines <- line:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
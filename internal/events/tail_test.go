@@ -0,0 +1,115 @@
+package events
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func appendLine(t *testing.T, path, s string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(s); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expectLine(t *testing.T, tl *Tailer, want string) {
+	t.Helper()
+	select {
+	case got := <-tl.Lines():
+		if got != want {
+			t.Fatalf("line = %q, want %q", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %q", want)
+	}
+}
+
+func TestTailerFollowsAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), EventsFile)
+	appendLine(t, path, "old\n")
+
+	tl, err := NewTailer(path, false)
+	if err != nil {
+		t.Fatalf("NewTailer: %v", err)
+	}
+	defer tl.Close()
+
+	// A line written in two parts arrives once, whole
+	appendLine(t, path, "fir")
+	time.Sleep(50 * time.Millisecond)
+	appendLine(t, path, "st\nsecond\n")
+	expectLine(t, tl, "first")
+	expectLine(t, tl, "second")
+}
+
+func TestTailerFromStart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), EventsFile)
+	appendLine(t, path, "old\n")
+
+	tl, err := NewTailer(path, true)
+	if err != nil {
+		t.Fatalf("NewTailer: %v", err)
+	}
+	defer tl.Close()
+	expectLine(t, tl, "old")
+}
+
+func TestTailerTruncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), EventsFile)
+	appendLine(t, path, "a long line before rotation\n")
+
+	tl, err := NewTailer(path, false)
+	if err != nil {
+		t.Fatalf("NewTailer: %v", err)
+	}
+	defer tl.Close()
+
+	// copytruncate-style rotation
+	if err := os.Truncate(path, 0); err != nil {
+		t.Fatal(err)
+	}
+	appendLine(t, path, "after\n")
+	expectLine(t, tl, "after")
+}
+
+func TestTailerRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, EventsFile)
+	appendLine(t, path, "old\n")
+
+	tl, err := NewTailer(path, false)
+	if err != nil {
+		t.Fatalf("NewTailer: %v", err)
+	}
+	defer tl.Close()
+
+	// Written just before rotation: still delivered from the old file
+	appendLine(t, path, "last\n")
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendLine(t, path, "new\n")
+
+	expectLine(t, tl, "last")
+	expectLine(t, tl, "new")
+}
+
+func TestTailerWaitsForFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), EventsFile)
+
+	tl, err := NewTailer(path, false)
+	if err != nil {
+		t.Fatalf("NewTailer: %v", err)
+	}
+	defer tl.Close()
+
+	appendLine(t, path, "first\n")
+	expectLine(t, tl, "first")
+}
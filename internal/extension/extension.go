@@ -0,0 +1,185 @@
+// Package extension discovers and runs external gt subcommands.
+//
+// Any executable on PATH named gt-<name> becomes 'gt <name>', kubectl-style.
+// Dashes map to nested subcommands: gt-team-report runs as 'gt team report'.
+// Built-in commands always take precedence.
+//
+// Extensions receive town context through GT_* environment variables and can
+// query gt state with 'gt ext query <topic>', which answers in a versioned
+// JSON envelope (see Response).
+package extension
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Prefix is the executable name prefix for extensions.
+const Prefix = "gt-"
+
+// ProtocolVersion is the version of the environment and JSON query protocol.
+// It is bumped only for incompatible changes; new fields may be added freely.
+const ProtocolVersion = 1
+
+// Environment variables passed to extensions.
+const (
+	EnvProtocol = "GT_EXT_PROTOCOL" // protocol version
+	EnvBin      = "GT_BIN"          // path to the gt binary, for queries
+	EnvVersion  = "GT_VERSION"      // gt version
+	EnvTownRoot = "GT_TOWN_ROOT"    // town root (unset outside a town)
+	EnvRig      = "GT_RIG"          // rig inferred from cwd (unset if none)
+	EnvActor    = "GT_ACTOR"        // caller identity (e.g., "gastown/crew/max", "overseer")
+)
+
+// Extension is an external subcommand found on PATH.
+type Extension struct {
+	// Name is the subcommand path, space-separated (e.g., "team report").
+	Name string `json:"name"`
+
+	// Path is the executable's absolute path.
+	Path string `json:"path"`
+}
+
+// Context is the town context handed to extensions.
+type Context struct {
+	ProtocolVersion int    `json:"protocol_version"`
+	Version         string `json:"gt_version"`
+	Bin             string `json:"gt_bin"`
+	TownRoot        string `json:"town_root,omitempty"`
+	Rig             string `json:"rig,omitempty"`
+	Role            string `json:"role,omitempty"`
+	Actor           string `json:"actor,omitempty"`
+	Cwd             string `json:"cwd"`
+}
+
+// Env returns the context as GT_* environment assignments.
+func (c Context) Env() []string {
+	env := []string{
+		EnvProtocol + "=" + strconv.Itoa(c.ProtocolVersion),
+		EnvBin + "=" + c.Bin,
+		EnvVersion + "=" + c.Version,
+	}
+	if c.TownRoot != "" {
+		env = append(env, EnvTownRoot+"="+c.TownRoot)
+	}
+	if c.Rig != "" {
+		env = append(env, EnvRig+"="+c.Rig)
+	}
+	if c.Actor != "" {
+		env = append(env, EnvActor+"="+c.Actor)
+	}
+	return env
+}
+
+// Response is the envelope for 'gt ext query' output. Exactly one of Data
+// or Error is set.
+type Response struct {
+	ProtocolVersion int         `json:"protocol_version"`
+	Topic           string      `json:"topic"`
+	Data            interface{} `json:"data,omitempty"`
+	Error           string      `json:"error,omitempty"`
+}
+
+// Lookup finds the extension for a command line, preferring the longest
+// match: for args [team report --all] it tries gt-team-report, then gt-team.
+// It returns the extension and the arguments left for it, or nil.
+func Lookup(args []string) (*Extension, []string) {
+	var words []string
+	for _, a := range args {
+		if strings.HasPrefix(a, "-") || !validName(a) {
+			break
+		}
+		words = append(words, a)
+	}
+
+	for n := len(words); n > 0; n-- {
+		exe := Prefix + strings.Join(words[:n], "-")
+		if path, err := exec.LookPath(exe); err == nil {
+			return &Extension{Name: strings.Join(words[:n], " "), Path: path}, args[n:]
+		}
+	}
+	return nil, nil
+}
+
+// validName reports whether a word can be part of an extension name.
+func validName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// List returns every extension on PATH, sorted by name. When the same name
+// appears in several PATH directories, the first one wins (as for Lookup).
+func List() []Extension {
+	seen := make(map[string]bool)
+	var exts []Extension
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			if !strings.HasPrefix(name, Prefix) || len(name) == len(Prefix) || seen[name] {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			seen[name] = true
+			exts = append(exts, Extension{
+				Name: strings.ReplaceAll(strings.TrimPrefix(name, Prefix), "-", " "),
+				Path: path,
+			})
+		}
+	}
+	sort.Slice(exts, func(i, j int) bool { return exts[i].Name < exts[j].Name })
+	return exts
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return info.Mode().Perm()&0111 != 0
+}
+
+// Run executes an extension with the terminal attached and the context in
+// its environment, returning the extension's exit code.
+func Run(ext *Extension, args []string, ctx Context) (int, error) {
+	cmd := exec.Command(ext.Path, args...) //nolint:gosec // G204: extensions are user-installed executables
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), ctx.Env()...)
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
@@ -0,0 +1,118 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func writeExe(t *testing.T, dir, name, script string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLookup(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts")
+	}
+	dir := t.TempDir()
+	team := writeExe(t, dir, "gt-team", "exit 0")
+	report := writeExe(t, dir, "gt-team-report", "exit 0")
+	t.Setenv("PATH", dir)
+
+	tests := []struct {
+		args     []string
+		wantPath string
+		wantName string
+		wantRest []string
+	}{
+		{[]string{"team", "report", "--all"}, report, "team report", []string{"--all"}},
+		{[]string{"team", "list"}, team, "team", []string{"list"}},
+		{[]string{"team", "--help"}, team, "team", []string{"--help"}},
+		{[]string{"team", "a/b"}, team, "team", []string{"a/b"}},
+		{[]string{"nope"}, "", "", nil},
+		{[]string{"--team"}, "", "", nil},
+	}
+	for _, tt := range tests {
+		ext, rest := Lookup(tt.args)
+		if tt.wantPath == "" {
+			if ext != nil {
+				t.Errorf("Lookup(%v) = %v, want nil", tt.args, ext)
+			}
+			continue
+		}
+		if ext == nil {
+			t.Errorf("Lookup(%v) = nil, want %s", tt.args, tt.wantPath)
+			continue
+		}
+		if ext.Path != tt.wantPath || ext.Name != tt.wantName {
+			t.Errorf("Lookup(%v) = %+v, want %s (%s)", tt.args, ext, tt.wantName, tt.wantPath)
+		}
+		if !reflect.DeepEqual(rest, tt.wantRest) {
+			t.Errorf("Lookup(%v) rest = %v, want %v", tt.args, rest, tt.wantRest)
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts")
+	}
+	first, second := t.TempDir(), t.TempDir()
+	want := writeExe(t, first, "gt-hello", "exit 0")
+	writeExe(t, second, "gt-hello", "exit 0") // shadowed
+	writeExe(t, second, "gt-team-report", "exit 0")
+	writeExe(t, second, "other", "exit 0")
+	if err := os.WriteFile(filepath.Join(second, "gt-notexec"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", first+string(os.PathListSeparator)+second)
+
+	exts := List()
+	if len(exts) != 2 {
+		t.Fatalf("List() = %v, want 2 extensions", exts)
+	}
+	if exts[0].Name != "hello" || exts[0].Path != want {
+		t.Errorf("exts[0] = %+v, want hello at %s", exts[0], want)
+	}
+	if exts[1].Name != "team report" {
+		t.Errorf("exts[1].Name = %q, want %q", exts[1].Name, "team report")
+	}
+}
+
+func TestRunPassesContextAndExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	path := writeExe(t, dir, "gt-ctx", `echo "$GT_EXT_PROTOCOL $GT_TOWN_ROOT $GT_RIG $GT_ACTOR $1" > "`+out+`"; exit 3`)
+
+	ctx := Context{
+		ProtocolVersion: ProtocolVersion,
+		Bin:             "/usr/bin/gt",
+		TownRoot:        "/town",
+		Rig:             "gastown",
+		Actor:           "gastown/crew/max",
+	}
+	code, err := Run(&Extension{Name: "ctx", Path: path}, []string{"arg"}, ctx)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if code != 3 {
+		t.Errorf("exit code = %d, want 3", code)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "1 /town gastown gastown/crew/max arg\n"; got != want {
+		t.Errorf("extension saw %q, want %q", got, want)
+	}
+}
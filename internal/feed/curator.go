@@ -9,11 +9,9 @@
 package feed
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -75,20 +73,21 @@ func NewCurator(townRoot string) *Curator {
 func (c *Curator) Start() error {
 	eventsPath := filepath.Join(c.townRoot, events.EventsFile)
 
-	// Open events file, creating if needed
+	// Create the events file if needed, so the curator has something to follow
 	file, err := os.OpenFile(eventsPath, os.O_RDONLY|os.O_CREATE, 0644) //nolint:gosec // G302: events file is non-sensitive operational data
 	if err != nil {
 		return fmt.Errorf("opening events file: %w", err)
 	}
+	_ = file.Close()
 
-	// Seek to end to only process new events
-	if _, err := file.Seek(0, io.SeekEnd); err != nil {
-		_ = file.Close() //nolint:gosec // G104: best effort cleanup on error
-		return fmt.Errorf("seeking to end: %w", err)
+	// Follow from the end to only process new events, across log rotation
+	tailer, err := events.NewTailer(eventsPath, false)
+	if err != nil {
+		return fmt.Errorf("tailing events file: %w", err)
 	}
 
 	c.wg.Add(1)
-	go c.run(file)
+	go c.run(tailer)
 
 	return nil
 }
@@ -101,28 +100,21 @@ func (c *Curator) Stop() {
 
 // run is the main curator loop.
 // ZFC: No in-memory state to clean up - state is derived from the events file.
-func (c *Curator) run(file *os.File) {
+func (c *Curator) run(tailer *events.Tailer) {
 	defer c.wg.Done()
-	defer file.Close()
-
-	reader := bufio.NewReader(file)
-	ticker := time.NewTicker(100 * time.Millisecond)
-	defer ticker.Stop()
+	defer tailer.Close()
 
+	lines := tailer.Lines()
 	for {
 		select {
 		case <-c.ctx.Done():
 			return
 
-		case <-ticker.C:
-			// Read available lines
-			for {
-				line, err := reader.ReadString('\n')
-				if err != nil {
-					break // No more data available
-				}
-				c.processLine(line)
+		case line, ok := <-lines:
+			if !ok {
+				return
 			}
+			c.processLine(line)
 		}
 	}
 }
@@ -365,6 +357,20 @@ func (c *Curator) generateSummary(event *events.Event) string {
 		}
 		return "Merge failed"
 
+	case events.TypeScheduleRun:
+		if job, ok := event.Payload["job"].(string); ok {
+			return fmt.Sprintf("Scheduled job %s completed", job)
+		}
+		return "Scheduled job completed"
+
+	case events.TypeScheduleFailed:
+		job, _ := event.Payload["job"].(string)
+		status, _ := event.Payload["status"].(string)
+		if job != "" && status != "" {
+			return fmt.Sprintf("Scheduled job %s %s", job, status)
+		}
+		return "Scheduled job failed"
+
 	case events.TypeSessionDeath:
 		session, _ := event.Payload["session"].(string)
 		reason, _ := event.Payload["reason"].(string)
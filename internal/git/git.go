@@ -3,11 +3,17 @@ package git
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/logging"
 )
 
 // GitError contains raw output from a git command for agent observation.
@@ -78,7 +84,9 @@ func (g *Git) run(args ...string) (string, error) {
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
+	start := time.Now()
 	err := cmd.Run()
+	logging.Exec("git", args, cmd.Dir, start, err, stderr.String())
 	if err != nil {
 		return "", g.wrapError(err, stdout.String(), stderr.String(), args)
 	}
@@ -149,6 +157,46 @@ func (g *Git) CloneWithReference(url, dest, reference string) error {
 	return ConfigureSparseCheckout(dest)
 }
 
+// CloneOptions narrows what a clone fetches and checks out.
+type CloneOptions struct {
+	Reference string   // local repo to borrow objects from (--reference-if-able)
+	Depth     int      // commits of history (--depth); 0 for full history
+	Filter    string   // partial clone filter (--filter), e.g. "blob:none"
+	Sparse    []string // sparse-checkout patterns; empty for the whole tree
+}
+
+// CloneWithOptions clones a repository to the destination, shallow, partial
+// or sparse as opts say. A sparse clone is checked out only after its
+// patterns are in place, so the excluded paths are never written.
+func (g *Git) CloneWithOptions(url, dest string, opts CloneOptions) error {
+	args := []string{"clone"}
+	if opts.Reference != "" {
+		args = append(args, "--reference-if-able", opts.Reference)
+	}
+	if opts.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(opts.Depth))
+	}
+	if opts.Filter != "" {
+		args = append(args, "--filter="+opts.Filter)
+	}
+	if len(opts.Sparse) > 0 {
+		args = append(args, "--no-checkout")
+	}
+	cmd := exec.Command("git", append(args, url, dest)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return g.wrapError(err, stdout.String(), stderr.String(), append(args, url))
+	}
+	// Configure sparse checkout (and check out, if deferred) before hooks:
+	// the hooks directory is part of the tree
+	if err := ConfigureSparseCheckoutPatterns(dest, opts.Sparse); err != nil {
+		return err
+	}
+	return configureHooksPath(dest)
+}
+
 // CloneBare clones a repository as a bare repo (no working directory).
 // This is used for the shared repo architecture where all worktrees share a single git database.
 func (g *Git) CloneBare(url, dest string) error {
@@ -471,7 +519,9 @@ func (g *Git) runMergeCheck(args ...string) (string, error) {
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
+	start := time.Now()
 	err := cmd.Run()
+	logging.Exec("git", args, cmd.Dir, start, err, stderr.String())
 	if err != nil {
 		// ZFC: Return raw output for observation, don't interpret CONFLICT
 		return "", g.wrapError(err, stdout.String(), stderr.String(), args)
@@ -517,6 +567,47 @@ func (g *Git) CreateBranch(name string) error {
 	return err
 }
 
+// RenameBranch renames a local branch.
+func (g *Git) RenameBranch(oldName, newName string) error {
+	_, err := g.run("branch", "-m", oldName, newName)
+	return err
+}
+
+// SetUpstream sets the upstream of a local branch (e.g. "origin/main").
+func (g *Git) SetUpstream(branch, upstream string) error {
+	_, err := g.run("branch", "--set-upstream-to="+upstream, branch)
+	return err
+}
+
+// EnableWorktreeConfig turns on per-worktree config (extensions.worktreeConfig)
+// so SetWorktreeConfig can set values for one worktree. A bare repository's
+// core.bare moves to its own worktree config first, as git requires:
+// otherwise every linked worktree would read it and consider itself bare.
+func (g *Git) EnableWorktreeConfig() error {
+	if out, _ := g.run("config", "--bool", "extensions.worktreeConfig"); out == "true" {
+		return nil
+	}
+	bare, _ := g.run("config", "--local", "--bool", "core.bare")
+	if _, err := g.run("config", "extensions.worktreeConfig", "true"); err != nil {
+		return err
+	}
+	if bare != "true" {
+		return nil
+	}
+	if _, err := g.run("config", "--worktree", "core.bare", "true"); err != nil {
+		return err
+	}
+	_, err := g.run("config", "--local", "--unset", "core.bare")
+	return err
+}
+
+// SetWorktreeConfig sets a git config value for this worktree only. The
+// repository needs EnableWorktreeConfig.
+func (g *Git) SetWorktreeConfig(key, value string) error {
+	_, err := g.run("config", "--worktree", key, value)
+	return err
+}
+
 // CreateBranchFrom creates a new branch from a specific ref.
 func (g *Git) CreateBranchFrom(name, ref string) error {
 	_, err := g.run("branch", name, ref)
@@ -585,6 +676,250 @@ func (g *Git) ResetBranch(name, ref string) error {
 	return err
 }
 
+// LastCommitTime returns the committer time of HEAD.
+func (g *Git) LastCommitTime() (time.Time, error) {
+	out, err := g.run("log", "-1", "--format=%ct")
+	if err != nil {
+		return time.Time{}, err
+	}
+	secs, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing commit time %q: %w", out, err)
+	}
+	return time.Unix(secs, 0), nil
+}
+
+// LocalCommit is a commit created in a clone.
+type LocalCommit struct {
+	Hash    string    `json:"hash"`
+	Subject string    `json:"subject"`
+	Time    time.Time `json:"time"` // when it was created here (reflog time)
+	Files   []string  `json:"files,omitempty"`
+}
+
+// LocalCommitsSince returns commits created in this clone at or after since,
+// oldest first, with the files each touched. It reads HEAD's reflog, so
+// commits that were only fetched, pulled, or checked out don't count; a
+// commit amended twice appears as each of its versions.
+func (g *Git) LocalCommitsSince(since time.Time) ([]LocalCommit, error) {
+	out, err := g.run("log", "-g", "--date=unix", "--format=%H%x1f%gd%x1f%gs", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []LocalCommit
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(line, "\x1f", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		// %gd with --date=unix is HEAD@{<seconds>}
+		stamp := strings.TrimSuffix(fields[1][strings.Index(fields[1], "{")+1:], "}")
+		secs, err := strconv.ParseInt(stamp, 10, 64)
+		if err != nil {
+			continue
+		}
+		at := time.Unix(secs, 0)
+		if at.Before(since) {
+			break // reflog is newest first
+		}
+
+		action, subject, _ := strings.Cut(fields[2], ": ")
+		if !isLocalCommitAction(action) || seen[fields[0]] {
+			continue
+		}
+		seen[fields[0]] = true
+		commits = append(commits, LocalCommit{Hash: fields[0], Subject: subject, Time: at})
+	}
+
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	for i := range commits {
+		files, err := g.run("show", "--name-only", "--format=", commits[i].Hash)
+		if err != nil {
+			return nil, err
+		}
+		if files != "" {
+			commits[i].Files = strings.Split(files, "\n")
+		}
+	}
+	return commits, nil
+}
+
+// isLocalCommitAction reports whether a reflog action created a commit, e.g.
+// "commit", "commit (amend)", "cherry-pick", or "revert".
+func isLocalCommitAction(action string) bool {
+	for _, prefix := range []string{"commit", "cherry-pick", "revert"} {
+		if action == prefix || strings.HasPrefix(action, prefix+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// ReferencingCommit is a commit traced to an issue it references.
+type ReferencingCommit struct {
+	Hash    string    `json:"hash"`
+	Subject string    `json:"subject"`
+	Time    time.Time `json:"time"` // committer time
+	Ref     string    `json:"ref"`  // the issue ID it references
+	Via     string    `json:"via"`  // "message" (subject, body, or trailer) or "branch"
+}
+
+// CommitsReferencing returns commits on any local branch since the given time
+// that reference one of ids, oldest first. A commit references an issue if
+// its message mentions the ID (e.g. "fix: thing (gt-abc)" or a
+// "Refs: gt-abc" trailer), or if it is on a polecat branch named for the
+// issue (polecat/<name>/<id>@<timestamp>) and not on the default branch.
+// When a commit references several ids, the first in ids wins.
+func (g *Git) CommitsReferencing(ids []string, since time.Time) ([]ReferencingCommit, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	const format = "--format=%H%x1f%ct%x1f%s%x1f%B%x1e"
+	logArgs := []string{"log", format}
+	if !since.IsZero() {
+		logArgs = append(logArgs, fmt.Sprintf("--since=%d", since.Unix()))
+	}
+
+	out, err := g.run(append(logArgs, "--all")...)
+	if err != nil {
+		return nil, err
+	}
+	var commits []ReferencingCommit
+	seen := make(map[string]bool)
+	for _, c := range parseLogRecords(out) {
+		for _, id := range ids {
+			if MentionsID(c.body, id) {
+				seen[c.Hash] = true
+				c.Ref, c.Via = id, "message"
+				commits = append(commits, c.ReferencingCommit)
+				break
+			}
+		}
+	}
+
+	// Branch convention: commits a polecat made on its issue branch
+	branches, _ := g.ListBranches("polecat/*")
+	base := ""
+	def := g.RemoteDefaultBranch()
+	for _, ref := range []string{"origin/" + def, def, "master"} {
+		if _, err := g.Rev(ref); err == nil {
+			base = ref
+			break
+		}
+	}
+	for _, branch := range branches {
+		id := branchIssueID(branch)
+		if id == "" || base == "" || !containsString(ids, id) {
+			continue
+		}
+		out, err := g.run(append(logArgs, branch, "^"+base)...)
+		if err != nil {
+			continue
+		}
+		for _, c := range parseLogRecords(out) {
+			if seen[c.Hash] {
+				continue
+			}
+			seen[c.Hash] = true
+			c.Ref, c.Via = id, "branch"
+			commits = append(commits, c.ReferencingCommit)
+		}
+	}
+
+	sort.SliceStable(commits, func(i, j int) bool { return commits[i].Time.Before(commits[j].Time) })
+	return commits, nil
+}
+
+// logRecord is a commit parsed from CommitsReferencing's log format.
+type logRecord struct {
+	ReferencingCommit
+	body string // full message
+}
+
+// parseLogRecords parses "%H%x1f%ct%x1f%s%x1f%B%x1e" log output.
+func parseLogRecords(out string) []logRecord {
+	var records []logRecord
+	for _, rec := range strings.Split(out, "\x1e") {
+		fields := strings.SplitN(strings.TrimSpace(rec), "\x1f", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		secs, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		records = append(records, logRecord{
+			ReferencingCommit: ReferencingCommit{Hash: fields[0], Subject: fields[2], Time: time.Unix(secs, 0)},
+			body:              fields[3],
+		})
+	}
+	return records
+}
+
+// branchIssueID returns the issue a polecat branch was created for
+// (polecat/<name>/<id>@<timestamp>), or "" for other branches.
+func branchIssueID(branch string) string {
+	parts := strings.Split(branch, "/")
+	if len(parts) != 3 || parts[0] != "polecat" {
+		return ""
+	}
+	id, _, _ := strings.Cut(parts[2], "@")
+	return id
+}
+
+// MentionsID reports whether text mentions the issue ID as a whole word:
+// "gt-abc" is mentioned in "fix (gt-abc)" and "gt-abc." but not in
+// "gt-abc.1" or "gt-abcd".
+func MentionsID(text, id string) bool {
+	if id == "" {
+		return false
+	}
+	for from := 0; ; {
+		i := strings.Index(text[from:], id)
+		if i < 0 {
+			return false
+		}
+		start, end := from+i, from+i+len(id)
+		from = start + 1
+		if start > 0 && isIDChar(text[start-1]) {
+			continue
+		}
+		after := text[end:]
+		if after == "" || !isIDChar(after[0]) {
+			return true
+		}
+		// A trailing dot ends a sentence unless an ID segment follows
+		if after[0] == '.' && (len(after) == 1 || !isIDChar(after[1])) {
+			return true
+		}
+	}
+}
+
+// isIDChar reports whether c can appear in an issue ID.
+func isIDChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.'
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ResetHard resets the current branch, index, and working tree to ref,
+// discarding uncommitted changes to tracked files.
+func (g *Git) ResetHard(ref string) error {
+	_, err := g.run("reset", "--hard", ref)
+	return err
+}
+
 // Rev returns the commit hash for the given ref.
 func (g *Git) Rev(ref string) (string, error) {
 	return g.run("rev-parse", ref)
@@ -623,6 +958,19 @@ func (g *Git) WorktreeAddFromRef(path, branch, startPoint string) error {
 	return ConfigureSparseCheckout(path)
 }
 
+// WorktreeAddFromRefSparse is WorktreeAddFromRef checking out only the paths
+// matching patterns (see ConfigureSparseCheckoutPatterns). The worktree is
+// populated after the patterns are in place.
+func (g *Git) WorktreeAddFromRefSparse(path, branch, startPoint string, patterns []string) error {
+	if len(patterns) == 0 {
+		return g.WorktreeAddFromRef(path, branch, startPoint)
+	}
+	if _, err := g.run("worktree", "add", "--no-checkout", "-b", branch, path, startPoint); err != nil {
+		return err
+	}
+	return ConfigureSparseCheckoutPatterns(path, patterns)
+}
+
 // WorktreeAddDetached creates a new worktree at the given path with a detached HEAD.
 // Sparse checkout is enabled to exclude .claude/ from source repos.
 func (g *Git) WorktreeAddDetached(path, ref string) error {
@@ -655,6 +1003,14 @@ func (g *Git) WorktreeAddExistingForce(path, branch string) error {
 // This ensures source repo settings don't override Gas Town agent settings.
 // Exported for use by doctor checks.
 func ConfigureSparseCheckout(repoPath string) error {
+	return ConfigureSparseCheckoutPatterns(repoPath, nil)
+}
+
+// ConfigureSparseCheckoutPatterns is ConfigureSparseCheckout that also limits
+// the checkout to paths matching patterns (gitignore syntax, e.g.
+// "/services/api/"). Top-level files and .githooks/ are always kept. With no
+// patterns the whole tree is checked out.
+func ConfigureSparseCheckoutPatterns(repoPath string, patterns []string) error {
 	// Enable sparse checkout
 	cmd := exec.Command("git", "-C", repoPath, "config", "core.sparseCheckout", "true")
 	var stderr bytes.Buffer
@@ -690,7 +1046,11 @@ func ConfigureSparseCheckout(repoPath string) error {
 		return fmt.Errorf("creating info dir: %w", err)
 	}
 	sparseFile := filepath.Join(infoDir, "sparse-checkout")
-	sparsePatterns := "/*\n!/.claude/\n!/CLAUDE.md\n!/CLAUDE.local.md\n!/.mcp.json\n"
+	sparsePatterns := "/*\n"
+	if len(patterns) > 0 {
+		sparsePatterns += "!/*/\n/.githooks/\n" + strings.Join(patterns, "\n") + "\n"
+	}
+	sparsePatterns += "!/.claude/\n!/CLAUDE.md\n!/CLAUDE.local.md\n!/.mcp.json\n"
 	if err := os.WriteFile(sparseFile, []byte(sparsePatterns), 0644); err != nil {
 		return fmt.Errorf("writing sparse-checkout: %w", err)
 	}
@@ -798,6 +1158,13 @@ func (g *Git) WorktreePrune() error {
 	return err
 }
 
+// WorktreeMove moves a worktree to newPath, keeping it registered with the
+// repository.
+func (g *Git) WorktreeMove(path, newPath string) error {
+	_, err := g.run("worktree", "move", path, newPath)
+	return err
+}
+
 // Worktree represents a git worktree.
 type Worktree struct {
 	Path   string
@@ -1143,3 +1510,114 @@ func (g *Git) BranchPushedToRemote(localBranch, remote string) (bool, int, error
 
 	return n == 0, n, nil
 }
+
+// ErrEmptyBundle is returned by BundleCreate when the revisions contain no commits
+// (e.g., everything on the branch is already on the remote).
+var ErrEmptyBundle = errors.New("empty bundle")
+
+// BundleCreate writes a git bundle containing revs to file.
+// revs accepts rev-list arguments, e.g. "my-branch", "--not", "--remotes".
+func (g *Git) BundleCreate(file string, revs ...string) error {
+	args := append([]string{"bundle", "create", file}, revs...)
+	_, err := g.run(args...)
+	if err != nil && strings.Contains(err.Error(), "empty bundle") {
+		return ErrEmptyBundle
+	}
+	return err
+}
+
+// FetchBundle fetches ref (e.g. "refs/heads/my-branch" or "HEAD") from a
+// bundle file. The result is only recorded in FETCH_HEAD; check out the
+// commit by SHA.
+func (g *Git) FetchBundle(file, ref string) error {
+	_, err := g.run("fetch", file, ref)
+	return err
+}
+
+// FetchRef fetches ref from another repository (a path or URL) without
+// configuring it as a remote. The result is only recorded in FETCH_HEAD.
+func (g *Git) FetchRef(source, ref string) error {
+	_, err := g.run("fetch", source, ref)
+	return err
+}
+
+// DiffToFile writes a binary-safe diff of the working tree against base to file.
+// Use base "HEAD" to capture both staged and unstaged changes.
+func (g *Git) DiffToFile(file, base string) error {
+	_, err := g.run("diff", "--binary", "--output="+file, base)
+	return err
+}
+
+// ApplyPatch applies a patch file (as written by DiffToFile) to the working tree.
+func (g *Git) ApplyPatch(file string) error {
+	_, err := g.run("apply", "--binary", "--whitespace=nowarn", file)
+	return err
+}
+
+// UntrackedFiles returns untracked, non-ignored files relative to the work dir.
+func (g *Git) UntrackedFiles() ([]string, error) {
+	out, err := g.run("ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, f := range strings.Split(out, "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// ChangedFiles returns tracked files with staged or unstaged changes
+// relative to HEAD, relative to the work dir.
+func (g *Git) ChangedFiles() ([]string, error) {
+	out, err := g.run("diff", "HEAD", "--name-only", "-z")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, f := range strings.Split(out, "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// CheckoutBranchAt checks out branch, creating or resetting it to point at ref.
+func (g *Git) CheckoutBranchAt(branch, ref string) error {
+	_, err := g.run("checkout", "-B", branch, ref)
+	return err
+}
+
+// StashPush stashes all local changes, including untracked files.
+func (g *Git) StashPush(message string) error {
+	_, err := g.run("stash", "push", "--include-untracked", "-m", message)
+	return err
+}
+
+// CommitsBetween returns the commits reachable from head but not from base,
+// oldest first.
+func (g *Git) CommitsBetween(base, head string) ([]string, error) {
+	out, err := g.run("rev-list", "--reverse", base+".."+head)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// CherryPick applies a commit on top of the current branch.
+func (g *Git) CherryPick(commit string) error {
+	_, err := g.run("cherry-pick", commit)
+	return err
+}
+
+// AbortCherryPick aborts a cherry-pick in progress.
+func (g *Git) AbortCherryPick() error {
+	_, err := g.run("cherry-pick", "--abort")
+	return err
+}
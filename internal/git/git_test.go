@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func initTestRepo(t *testing.T) string {
@@ -89,6 +90,59 @@ func TestCloneWithReferenceCreatesAlternates(t *testing.T) {
 	}
 }
 
+func TestCloneWithOptionsShallowSparse(t *testing.T) {
+	tmp := t.TempDir()
+	src := filepath.Join(tmp, "src")
+	dst := filepath.Join(tmp, "dst")
+
+	if err := exec.Command("git", "init", src).Run(); err != nil {
+		t.Fatalf("init src: %v", err)
+	}
+	_ = exec.Command("git", "-C", src, "config", "user.email", "test@test.com").Run()
+	_ = exec.Command("git", "-C", src, "config", "user.name", "Test User").Run()
+	for _, rel := range []string{"README.md", "api/main.go", "web/index.html", ".claude/settings.json"} {
+		path := filepath.Join(src, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(rel+"\n"), 0644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+		_ = exec.Command("git", "-C", src, "add", ".").Run()
+		_ = exec.Command("git", "-C", src, "commit", "-m", "add "+rel).Run()
+	}
+
+	// file:// so --depth isn't ignored as it is for local paths
+	g := NewGit(tmp)
+	opts := CloneOptions{Depth: 1, Sparse: []string{"/api/"}}
+	if err := g.CloneWithOptions("file://"+src, dst, opts); err != nil {
+		t.Fatalf("CloneWithOptions: %v", err)
+	}
+
+	for rel, want := range map[string]bool{
+		"README.md":             true,
+		"api/main.go":           true,
+		"web/index.html":        false,
+		".claude/settings.json": false,
+	} {
+		_, err := os.Stat(filepath.Join(dst, rel))
+		if got := err == nil; got != want {
+			t.Errorf("%s checked out = %v, want %v", rel, got, want)
+		}
+	}
+
+	out, err := exec.Command("git", "-C", dst, "rev-list", "--count", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("rev-list: %v", err)
+	}
+	if count := strings.TrimSpace(string(out)); count != "1" {
+		t.Errorf("history depth = %s, want 1", count)
+	}
+	if status, _ := exec.Command("git", "-C", dst, "status", "--porcelain").Output(); len(status) != 0 {
+		t.Errorf("expected a clean tree, got %q", status)
+	}
+}
+
 func TestCurrentBranch(t *testing.T) {
 	dir := initTestRepo(t)
 	g := NewGit(dir)
@@ -488,3 +542,127 @@ func stringContains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestLocalCommitsSince(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+
+	for _, f := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, f), []byte(f), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Add("a.txt", "b.txt"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := g.Commit("feature work"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	// Branch switches are in the reflog but aren't commits
+	if err := g.CreateBranch("side"); err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+	if err := g.Checkout("side"); err != nil {
+		t.Fatalf("Checkout: %v", err)
+	}
+
+	commits, err := g.LocalCommitsSince(time.Time{})
+	if err != nil {
+		t.Fatalf("LocalCommitsSince: %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("got %d commits, want 2: %+v", len(commits), commits)
+	}
+	if commits[0].Subject != "initial" || commits[1].Subject != "feature work" {
+		t.Errorf("subjects = %q, %q; want initial, feature work (oldest first)", commits[0].Subject, commits[1].Subject)
+	}
+	if got := strings.Join(commits[1].Files, ","); got != "a.txt,b.txt" {
+		t.Errorf("files = %q, want a.txt,b.txt", got)
+	}
+
+	commits, err = g.LocalCommitsSince(time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("LocalCommitsSince(future): %v", err)
+	}
+	if len(commits) != 0 {
+		t.Errorf("got %d commits since the future, want 0", len(commits))
+	}
+}
+
+func TestMentionsID(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"fix: handle nil (gt-abc)", true},
+		{"Refs: gt-abc", true},
+		{"closes gt-abc.", true},
+		{"gt-abc", true},
+		{"step gt-abc.1 done", false},
+		{"gt-abcd", false},
+		{"xgt-abc", false},
+		{"see gt-abcd and then gt-abc", true},
+	}
+	for _, tt := range tests {
+		if got := MentionsID(tt.text, "gt-abc"); got != tt.want {
+			t.Errorf("MentionsID(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestCommitsReferencing(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+	base, err := g.CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch: %v", err)
+	}
+
+	commit := func(file, msg string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(msg), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := g.Add(file); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+		if err := g.Commit(msg); err != nil {
+			t.Fatalf("Commit: %v", err)
+		}
+	}
+	commit("a.txt", "fix: handle nil (gt-abc.1)")
+	commit("b.txt", "chore: unrelated")
+
+	// A polecat branch for gt-abc.2, with a commit that doesn't mention it
+	if err := g.CreateBranch("polecat/nux/gt-abc.2@mk123"); err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+	if err := g.Checkout("polecat/nux/gt-abc.2@mk123"); err != nil {
+		t.Fatalf("Checkout: %v", err)
+	}
+	commit("c.txt", "wip")
+	if err := g.Checkout(base); err != nil {
+		t.Fatalf("Checkout: %v", err)
+	}
+
+	commits, err := g.CommitsReferencing([]string{"gt-abc", "gt-abc.1", "gt-abc.2"}, time.Time{})
+	if err != nil {
+		t.Fatalf("CommitsReferencing: %v", err)
+	}
+	got := make(map[string]string)
+	for _, c := range commits {
+		got[c.Subject] = c.Ref + " via " + c.Via
+	}
+	want := map[string]string{
+		"fix: handle nil (gt-abc.1)": "gt-abc.1 via message",
+		"wip":                        "gt-abc.2 via branch",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for subject, ref := range want {
+		if got[subject] != ref {
+			t.Errorf("%q: got %q, want %q", subject, got[subject], ref)
+		}
+	}
+}
@@ -17,6 +17,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/tmux"
 )
 
 // Common errors
@@ -264,7 +266,7 @@ func CleanStaleLocks(root string) (int, error) {
 func getActiveTmuxSessions() []string {
 	// Get both session name and ID to handle different lock formats
 	// Format: "session_name:session_id" e.g., "gt-beads-crew-dave:$55"
-	cmd := execCommand("tmux", "list-sessions", "-F", "#{session_name}:#{session_id}")
+	cmd := execCommand("tmux", tmux.Args("list-sessions", "-F", "#{session_name}:#{session_id}")...)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil // tmux not running or not installed
@@ -0,0 +1,107 @@
+// Package logging provides gt's diagnostic logger.
+//
+// Diagnostics go to stderr through log/slog and are separate from command
+// output. By default only warnings and errors are logged; --verbose adds
+// every git/tmux/bd invocation with its timing and, on failure, its stderr;
+// --log-json switches to one JSON object per record for machine ingestion.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Options configures the logger.
+type Options struct {
+	Quiet   bool      // Only errors
+	Verbose bool      // Debug records, including external command invocations
+	JSON    bool      // JSON records instead of text
+	Writer  io.Writer // Defaults to os.Stderr
+}
+
+var logger atomic.Pointer[slog.Logger]
+
+func init() {
+	Configure(Options{})
+}
+
+// Configure replaces the logger. It is called once from the root command
+// with the global flags, before any command runs.
+func Configure(opts Options) {
+	w := opts.Writer
+	if w == nil {
+		w = os.Stderr
+	}
+
+	level := slog.LevelWarn
+	switch {
+	case opts.Verbose:
+		level = slog.LevelDebug
+	case opts.Quiet:
+		level = slog.LevelError
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: level}
+	var h slog.Handler
+	if opts.JSON {
+		h = slog.NewJSONHandler(w, handlerOpts)
+	} else {
+		h = slog.NewTextHandler(w, handlerOpts)
+	}
+	logger.Store(slog.New(h))
+}
+
+// Logger returns the configured logger.
+func Logger() *slog.Logger {
+	return logger.Load()
+}
+
+// Enabled reports whether records at level are logged. Use it to skip
+// building expensive attributes.
+func Enabled(level slog.Level) bool {
+	return Logger().Enabled(context.Background(), level)
+}
+
+// Debug logs at debug level (shown with --verbose).
+func Debug(msg string, args ...any) { Logger().Debug(msg, args...) }
+
+// Info logs at info level.
+func Info(msg string, args ...any) { Logger().Info(msg, args...) }
+
+// Warn logs at warn level.
+func Warn(msg string, args ...any) { Logger().Warn(msg, args...) }
+
+// Error logs at error level.
+func Error(msg string, args ...any) { Logger().Error(msg, args...) }
+
+// Exec records an invocation of an external tool (git, tmux, bd) that was
+// started at start. Failures carry the tool's stderr so it is not lost when
+// callers wrap the error. Invocations are debug records: many failures are
+// expected (e.g., tmux has-session probing for a session).
+func Exec(tool string, args []string, dir string, start time.Time, err error, stderr string) {
+	if !Enabled(slog.LevelDebug) {
+		return
+	}
+	attrs := []any{
+		"tool", tool,
+		"args", strings.Join(args, " "),
+		"duration", time.Since(start).Round(time.Microsecond).String(),
+	}
+	if dir != "" {
+		attrs = append(attrs, "dir", dir)
+	}
+	if err != nil {
+		attrs = append(attrs, "error", err.Error())
+		if s := strings.TrimSpace(stderr); s != "" {
+			attrs = append(attrs, "stderr", s)
+		}
+		Logger().Debug("exec failed", attrs...)
+		return
+	}
+	Logger().Debug("exec", attrs...)
+}
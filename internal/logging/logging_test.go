@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecOnlyLoggedWhenVerbose(t *testing.T) {
+	t.Cleanup(func() { Configure(Options{}) })
+
+	var buf bytes.Buffer
+	Configure(Options{Writer: &buf})
+	Exec("git", []string{"status"}, "/tmp", time.Now(), nil, "")
+	if buf.Len() != 0 {
+		t.Errorf("default level logged an exec record: %q", buf.String())
+	}
+
+	Configure(Options{Writer: &buf, Verbose: true})
+	Exec("git", []string{"fetch", "origin"}, "/tmp", time.Now(), errors.New("exit status 128"), "fatal: no remote\n")
+	out := buf.String()
+	for _, want := range []string{"exec failed", "tool=git", `args="fetch origin"`, "duration=", `stderr="fatal: no remote"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("verbose output %q missing %q", out, want)
+		}
+	}
+}
+
+func TestQuietDropsWarnings(t *testing.T) {
+	t.Cleanup(func() { Configure(Options{}) })
+
+	var buf bytes.Buffer
+	Configure(Options{Writer: &buf, Quiet: true})
+	Warn("slow")
+	if buf.Len() != 0 {
+		t.Errorf("quiet logged a warning: %q", buf.String())
+	}
+	Error("broken")
+	if !strings.Contains(buf.String(), "broken") {
+		t.Errorf("quiet dropped an error: %q", buf.String())
+	}
+}
+
+func TestJSONRecords(t *testing.T) {
+	t.Cleanup(func() { Configure(Options{}) })
+
+	var buf bytes.Buffer
+	Configure(Options{Writer: &buf, JSON: true, Verbose: true})
+	Exec("tmux", []string{"has-session", "-t", "gt-mayor"}, "", time.Now(), nil, "")
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("record is not JSON: %v (%q)", err, buf.String())
+	}
+	if rec["msg"] != "exec" || rec["tool"] != "tmux" || rec["args"] != "has-session -t gt-mayor" {
+		t.Errorf("unexpected record: %v", rec)
+	}
+	if _, ok := rec["dir"]; ok {
+		t.Errorf("empty dir should be omitted: %v", rec)
+	}
+}
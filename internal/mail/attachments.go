@@ -0,0 +1,149 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// MaxAttachmentSize bounds a single attachment's payload.
+const MaxAttachmentSize = 10 << 20 // 10 MiB
+
+// ErrAttachmentMissing is returned when an attachment's payload isn't in the
+// store.
+var ErrAttachmentMissing = errors.New("attachment payload missing")
+
+// Attachment is a file sent along with a message. The payload lives in an
+// AttachmentStore, keyed by its SHA-256 digest; the message only carries
+// this reference.
+type Attachment struct {
+	Name   string `json:"name"`   // base file name as sent
+	Size   int64  `json:"size"`   // payload size in bytes
+	SHA256 string `json:"sha256"` // hex digest, the payload's key in the store
+}
+
+// label encodes the attachment as a beads label: attach:<sha256>:<size>:<name>.
+func (a Attachment) label() string {
+	return fmt.Sprintf("attach:%s:%d:%s", a.SHA256, a.Size, a.Name)
+}
+
+// parseAttachmentLabel decodes the value of an attach: label.
+func parseAttachmentLabel(value string) (Attachment, bool) {
+	parts := strings.SplitN(value, ":", 3)
+	if len(parts) != 3 {
+		return Attachment{}, false
+	}
+	size, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || !validDigest(parts[0]) {
+		return Attachment{}, false
+	}
+	return Attachment{SHA256: parts[0], Size: size, Name: parts[2]}, true
+}
+
+// attachmentName makes a file name safe to carry in a label.
+func attachmentName(path string) string {
+	return strings.NewReplacer(",", "_", "\n", "_").Replace(filepath.Base(path))
+}
+
+func validDigest(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// AttachmentStore holds attachment payloads content-addressed under a
+// mailbox: <dir>/<first two hex digits>/<sha256>. Identical payloads are
+// stored once however many messages carry them.
+type AttachmentStore struct {
+	dir string
+}
+
+// NewAttachmentStore returns the store rooted at dir.
+func NewAttachmentStore(dir string) *AttachmentStore {
+	return &AttachmentStore{dir: dir}
+}
+
+// Dir returns the store's root directory.
+func (s *AttachmentStore) Dir() string {
+	return s.dir
+}
+
+// Path returns where a's payload is (or would be) stored.
+func (s *AttachmentStore) Path(a Attachment) string {
+	return filepath.Join(s.dir, a.SHA256[:2], a.SHA256)
+}
+
+// Put copies the file at path into the store and returns its reference.
+func (s *AttachmentStore) Put(path string) (Attachment, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Attachment{}, err
+	}
+	if !info.Mode().IsRegular() {
+		return Attachment{}, fmt.Errorf("attaching %s: not a regular file", path)
+	}
+	if info.Size() > MaxAttachmentSize {
+		return Attachment{}, fmt.Errorf("attaching %s: %d bytes exceeds the %d byte limit", path, info.Size(), MaxAttachmentSize)
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is chosen by the sender
+	if err != nil {
+		return Attachment{}, err
+	}
+	sum := sha256.Sum256(data)
+	a := Attachment{Name: attachmentName(path), Size: int64(len(data)), SHA256: hex.EncodeToString(sum[:])}
+
+	dest := s.Path(a)
+	if _, err := os.Stat(dest); err == nil {
+		return a, nil // already stored
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return Attachment{}, fmt.Errorf("creating attachment store: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dest), ".put-*")
+	if err != nil {
+		return Attachment{}, err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return Attachment{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return Attachment{}, err
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		_ = os.Remove(tmp.Name())
+		return Attachment{}, err
+	}
+	return a, nil
+}
+
+// Open returns a reader for a's payload after checking it against the
+// digest.
+func (s *AttachmentStore) Open(a Attachment) (io.Reader, error) {
+	if !validDigest(a.SHA256) {
+		return nil, fmt.Errorf("invalid attachment digest %q", a.SHA256)
+	}
+	data, err := os.ReadFile(s.Path(a))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrAttachmentMissing, a.Name)
+		}
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != a.SHA256 {
+		return nil, fmt.Errorf("attachment %s is corrupt: digest mismatch", a.Name)
+	}
+	return bytes.NewReader(data), nil
+}
@@ -0,0 +1,69 @@
+package mail
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAttachmentStorePutOpen(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "fix,v2.patch")
+	patch := "diff --git a/x b/x\n+hello\n"
+	if err := os.WriteFile(src, []byte(patch), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := NewAttachmentStore(filepath.Join(t.TempDir(), "attachments"))
+
+	a, err := store.Put(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Name != "fix_v2.patch" || a.Size != int64(len(patch)) || !validDigest(a.SHA256) {
+		t.Errorf("Put = %+v", a)
+	}
+	if again, err := store.Put(src); err != nil || again != a {
+		t.Errorf("second Put = %+v, %v; want %+v", again, err, a)
+	}
+
+	r, err := store.Open(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := io.ReadAll(r); string(got) != patch {
+		t.Errorf("Open read %q, want %q", got, patch)
+	}
+
+	if err := os.WriteFile(store.Path(a), []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Open(a); err == nil {
+		t.Error("Open of a corrupt payload succeeded")
+	}
+	if err := os.Remove(store.Path(a)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Open(a); !errors.Is(err, ErrAttachmentMissing) {
+		t.Errorf("Open of a missing payload: %v, want ErrAttachmentMissing", err)
+	}
+}
+
+func TestAttachmentStorePutRejects(t *testing.T) {
+	store := NewAttachmentStore(t.TempDir())
+	if _, err := store.Put(t.TempDir()); err == nil {
+		t.Error("Put of a directory succeeded")
+	}
+	if _, err := store.Put(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Put of a missing file succeeded")
+	}
+}
+
+func TestAttachmentLabelRoundTrip(t *testing.T) {
+	a := Attachment{Name: "notes: draft.md", Size: 42, SHA256: "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"}
+	bm := &BeadsMessage{ID: "hq-1", Labels: []string{"from:mayor/", a.label(), "attach:not-a-digest:1:x"}}
+	msg := bm.ToMessage()
+	if len(msg.Attachments) != 1 || msg.Attachments[0] != a {
+		t.Errorf("Attachments = %+v, want [%+v]", msg.Attachments, a)
+	}
+}
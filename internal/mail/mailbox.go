@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
@@ -83,6 +84,19 @@ func (m *Mailbox) Path() string {
 	return m.path
 }
 
+// Attachments returns the store holding payloads of files attached to this
+// mailbox's messages: beside the inbox for legacy mailboxes, beside the
+// mail beads otherwise.
+func (m *Mailbox) Attachments() *AttachmentStore {
+	switch {
+	case m.legacy:
+		return NewAttachmentStore(filepath.Join(filepath.Dir(m.path), "attachments"))
+	case m.beadsDir != "":
+		return NewAttachmentStore(filepath.Join(m.beadsDir, "attachments"))
+	}
+	return NewAttachmentStore(filepath.Join(m.workDir, ".beads", "attachments"))
+}
+
 // List returns all open messages in the mailbox.
 func (m *Mailbox) List() ([]*Message, error) {
 	if m.legacy {
@@ -454,6 +468,8 @@ func (m *Mailbox) markUnreadLegacy(id string) error {
 	for _, msg := range messages {
 		if msg.ID == id {
 			msg.Read = false
+			msg.Acked = false
+			msg.AckedAt = nil
 			found = true
 		}
 	}
@@ -465,6 +481,59 @@ func (m *Mailbox) markUnreadLegacy(id string) error {
 	return m.rewriteLegacy(messages)
 }
 
+// Ack acknowledges a message: the recipient has read it and acted on it
+// (e.g., picked up a handoff). The message stays in the inbox. For beads
+// mode this adds "read" and "acked" labels; for legacy mode it sets Read
+// and Acked and records when.
+func (m *Mailbox) Ack(id string) error {
+	if m.legacy {
+		return m.ackLegacy(id)
+	}
+	for _, label := range []string{"read", "acked"} {
+		if _, err := runBdCommand([]string{"label", "add", id, label}, m.workDir, m.beadsDir); err != nil {
+			if bdErr, ok := err.(*bdError); ok && bdErr.ContainsError("not found") {
+				return ErrMessageNotFound
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Mailbox) ackLegacy(id string) error {
+	messages, err := m.List()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	now := timeNow()
+	for _, msg := range messages {
+		if msg.ID == id {
+			msg.Read = true
+			msg.Acked = true
+			msg.AckedAt = &now
+			found = true
+		}
+	}
+
+	if !found {
+		return ErrMessageNotFound
+	}
+
+	return m.rewriteLegacy(messages)
+}
+
+// State returns a message's state, which lets a sender check whether mail
+// (such as a handoff) was read or acknowledged.
+func (m *Mailbox) State(id string) (MessageState, error) {
+	msg, err := m.Get(id)
+	if err != nil {
+		return "", err
+	}
+	return msg.State(), nil
+}
+
 // Delete removes a message.
 func (m *Mailbox) Delete(id string) error {
 	if m.legacy {
@@ -513,6 +582,206 @@ func (m *Mailbox) Archive(id string) error {
 	return m.Delete(id)
 }
 
+// MoveTo reassigns every message in the mailbox, read or unread, to another
+// address. Used when an agent is renamed. Legacy mailboxes live inside the
+// workspace and move with it, so this is a no-op for them.
+// Returns the number of messages moved.
+func (m *Mailbox) MoveTo(address string) (int, error) {
+	if m.legacy {
+		return 0, nil
+	}
+
+	beadsMsgs, err := m.allMessages()
+	if err != nil {
+		return 0, err
+	}
+	target := AddressToIdentity(address)
+	moved := 0
+	for _, bm := range beadsMsgs {
+		if _, err := runBdCommand([]string{"update", bm.ID, "--assignee=" + target}, m.workDir, m.beadsDir); err != nil {
+			return moved, fmt.Errorf("reassigning %s: %w", bm.ID, err)
+		}
+		moved++
+	}
+	return moved, nil
+}
+
+// CopyTo gives another address its own copy of every message in the
+// mailbox, read or unread, keeping sender, labels and read state. Used when
+// an agent's workspace is cloned. Legacy mailboxes live inside the
+// workspace and are copied with it, so this is a no-op for them.
+// Returns the number of messages copied.
+func (m *Mailbox) CopyTo(address string) (int, error) {
+	if m.legacy {
+		return 0, nil
+	}
+
+	beadsMsgs, err := m.allMessages()
+	if err != nil {
+		return 0, err
+	}
+	target := AddressToIdentity(address)
+	copied := 0
+	for _, bm := range beadsMsgs {
+		bm.ParseLabels()
+		args := []string{"create", bm.Title,
+			"--json",
+			"--type", "message",
+			"--assignee", target,
+			"-d", bm.Description,
+			"--priority", fmt.Sprintf("%d", bm.Priority),
+		}
+		if len(bm.Labels) > 0 {
+			args = append(args, "--labels", strings.Join(bm.Labels, ","))
+		}
+		if bm.sender != "" {
+			args = append(args, "--actor", bm.sender)
+		}
+		if bm.Wisp {
+			args = append(args, "--ephemeral")
+		}
+		stdout, err := runBdCommand(args, m.workDir, m.beadsDir)
+		if err != nil {
+			return copied, fmt.Errorf("copying %s: %w", bm.ID, err)
+		}
+		if bm.Status == "closed" {
+			var created BeadsMessage
+			if err := json.Unmarshal(stdout, &created); err != nil {
+				return copied, fmt.Errorf("parsing copy of %s: %w", bm.ID, err)
+			}
+			if err := m.closeInDir(created.ID, m.beadsDir); err != nil {
+				return copied, fmt.Errorf("marking copy of %s read: %w", bm.ID, err)
+			}
+		}
+		copied++
+	}
+	return copied, nil
+}
+
+// allMessages returns every message assigned to the mailbox, in any status.
+func (m *Mailbox) allMessages() ([]BeadsMessage, error) {
+	var all []BeadsMessage
+	for _, identity := range m.identityVariants() {
+		for _, status := range []string{"open", "hooked", "closed"} {
+			args := []string{"list",
+				"--type", "message",
+				"--assignee", identity,
+				"--status", status,
+				"--json",
+				"--limit=0",
+			}
+			stdout, err := runBdCommand(args, m.workDir, m.beadsDir)
+			if err != nil {
+				return nil, err
+			}
+			var beadsMsgs []BeadsMessage
+			if len(stdout) > 0 && string(stdout) != "null" {
+				if err := json.Unmarshal(stdout, &beadsMsgs); err != nil {
+					return nil, err
+				}
+			}
+			all = append(all, beadsMsgs...)
+		}
+	}
+	return all, nil
+}
+
+// ReceivedSince returns messages addressed to this mailbox, read or unread,
+// sent at or after since, newest first. Legacy mailboxes include the archive.
+func (m *Mailbox) ReceivedSince(since time.Time) ([]*Message, error) {
+	var all []*Message
+	if m.legacy {
+		inbox, err := m.listLegacy()
+		if err != nil {
+			return nil, err
+		}
+		archived, err := m.ListArchived()
+		if err != nil {
+			return nil, err
+		}
+		all = append(inbox, archived...)
+	} else {
+		var err error
+		if all, err = m.queryAllStatuses("--assignee", m.identityVariants()); err != nil {
+			return nil, err
+		}
+	}
+	return messagesSince(all, since), nil
+}
+
+// SentSince returns messages sent by this mailbox's identity at or after
+// since, newest first. Legacy mailboxes don't record sent mail.
+func (m *Mailbox) SentSince(since time.Time) ([]*Message, error) {
+	if m.legacy {
+		return nil, nil
+	}
+	// Senders are recorded by address, which may spell out crew/ or polecats/.
+	var labels []string
+	for _, identity := range m.identityVariants() {
+		labels = append(labels, "from:"+identity)
+		if parts := strings.Split(identity, "/"); len(parts) == 2 && parts[1] != "" {
+			labels = append(labels,
+				"from:"+parts[0]+"/crew/"+parts[1],
+				"from:"+parts[0]+"/polecats/"+parts[1])
+		}
+	}
+	all, err := m.queryAllStatuses("--label", labels)
+	if err != nil {
+		return nil, err
+	}
+	return messagesSince(all, since), nil
+}
+
+// queryAllStatuses lists every message matching any of the filter values,
+// whatever its status, without bd's default result limit.
+func (m *Mailbox) queryAllStatuses(filterFlag string, values []string) ([]*Message, error) {
+	seen := make(map[string]bool)
+	var messages []*Message
+	for _, value := range values {
+		for _, status := range []string{"open", "hooked", "closed"} {
+			args := []string{"list",
+				"--type", "message",
+				filterFlag, value,
+				"--status", status,
+				"--json",
+				"--limit=0",
+			}
+			stdout, err := runBdCommand(args, m.workDir, m.beadsDir)
+			if err != nil {
+				return nil, err
+			}
+			if len(stdout) == 0 || string(stdout) == "null" {
+				continue
+			}
+			var beadsMsgs []BeadsMessage
+			if err := json.Unmarshal(stdout, &beadsMsgs); err != nil {
+				return nil, err
+			}
+			for _, bm := range beadsMsgs {
+				if !seen[bm.ID] {
+					seen[bm.ID] = true
+					messages = append(messages, bm.ToMessage())
+				}
+			}
+		}
+	}
+	return messages, nil
+}
+
+// messagesSince keeps messages sent at or after since, newest first.
+func messagesSince(messages []*Message, since time.Time) []*Message {
+	var kept []*Message
+	for _, msg := range messages {
+		if !msg.Timestamp.Before(since) {
+			kept = append(kept, msg)
+		}
+	}
+	sort.Slice(kept, func(i, j int) bool {
+		return kept[i].Timestamp.After(kept[j].Timestamp)
+	})
+	return kept
+}
+
 // ArchivePath returns the path to the archive file.
 func (m *Mailbox) ArchivePath() string {
 	if m.legacy {
@@ -547,8 +816,22 @@ func (m *Mailbox) appendToArchive(msg *Message) error {
 	return err
 }
 
-// ListArchived returns all messages in the archive file.
+// ListArchived returns all archived messages: the archive file followed by
+// the compressed archive written by Compact.
 func (m *Mailbox) ListArchived() ([]*Message, error) {
+	messages, err := m.listArchiveFile()
+	if err != nil {
+		return nil, err
+	}
+	compressed, err := m.listCompressed()
+	if err != nil {
+		return nil, err
+	}
+	return append(messages, compressed...), nil
+}
+
+// listArchiveFile returns the messages in the uncompressed archive file.
+func (m *Mailbox) listArchiveFile() ([]*Message, error) {
 	archivePath := m.ArchivePath()
 
 	file, err := os.Open(archivePath)
@@ -582,10 +865,37 @@ func (m *Mailbox) ListArchived() ([]*Message, error) {
 	return messages, nil
 }
 
-// PurgeArchive removes messages from the archive, optionally filtering by age.
-// If olderThanDays is 0, removes all archived messages.
+// PurgeArchive removes messages from the archive and compressed archive,
+// optionally filtering by age. If olderThanDays is 0, removes all archived
+// messages.
 func (m *Mailbox) PurgeArchive(olderThanDays int) (int, error) {
-	messages, err := m.ListArchived()
+	compressed, err := m.listCompressed()
+	if err != nil {
+		return 0, err
+	}
+	var keepCompressed []*Message
+	if olderThanDays > 0 {
+		cutoff := timeNow().AddDate(0, 0, -olderThanDays)
+		for _, msg := range compressed {
+			if !msg.Timestamp.Before(cutoff) {
+				keepCompressed = append(keepCompressed, msg)
+			}
+		}
+	}
+	if len(keepCompressed) != len(compressed) {
+		if err := m.rewriteCompressed(keepCompressed); err != nil {
+			return 0, err
+		}
+	}
+	purgedCompressed := len(compressed) - len(keepCompressed)
+
+	purged, err := m.purgeArchiveFile(olderThanDays)
+	return purged + purgedCompressed, err
+}
+
+// purgeArchiveFile removes messages from the uncompressed archive file.
+func (m *Mailbox) purgeArchiveFile(olderThanDays int) (int, error) {
+	messages, err := m.listArchiveFile()
 	if err != nil {
 		return 0, err
 	}
@@ -731,20 +1041,40 @@ func (m *Mailbox) Search(opts SearchOptions) ([]*Message, error) {
 
 // Count returns the total and unread message counts.
 func (m *Mailbox) Count() (total, unread int, err error) {
-	messages, err := m.List()
+	counts, err := m.Counts()
 	if err != nil {
 		return 0, 0, err
 	}
+	return counts.Total, counts.Unread, nil
+}
+
+// MailCounts tallies a mailbox's messages by state.
+type MailCounts struct {
+	Total  int `json:"total"`
+	Unread int `json:"unread"`
+	Read   int `json:"read"` // read but not acknowledged
+	Acked  int `json:"acked"`
+}
 
-	total = len(messages)
-	// Count messages that are NOT marked as read (including via "read" label)
+// Counts returns the number of messages in each state.
+func (m *Mailbox) Counts() (MailCounts, error) {
+	messages, err := m.List()
+	if err != nil {
+		return MailCounts{}, err
+	}
+
+	counts := MailCounts{Total: len(messages)}
 	for _, msg := range messages {
-		if !msg.Read {
-			unread++
+		switch msg.State() {
+		case StateAcked:
+			counts.Acked++
+		case StateRead:
+			counts.Read++
+		default:
+			counts.Unread++
 		}
 	}
-
-	return total, unread, nil
+	return counts, nil
 }
 
 // Append adds a message to the mailbox (legacy mode only).
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 )
@@ -240,6 +242,58 @@ func TestMailboxLegacyCount(t *testing.T) {
 	}
 }
 
+func TestMailboxLegacyAck(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewMailbox(tmpDir)
+
+	for _, msg := range []*Message{
+		{ID: "handoff", Subject: "HANDOFF", Timestamp: time.Now()},
+		{ID: "fyi", Subject: "FYI", Timestamp: time.Now()},
+		{ID: "new", Subject: "New", Timestamp: time.Now()},
+	} {
+		if err := m.Append(msg); err != nil {
+			t.Fatalf("Append error: %v", err)
+		}
+	}
+
+	if err := m.Ack("handoff"); err != nil {
+		t.Fatalf("Ack error: %v", err)
+	}
+	if err := m.MarkReadOnly("fyi"); err != nil {
+		t.Fatalf("MarkReadOnly error: %v", err)
+	}
+	if err := m.Ack("missing"); err != ErrMessageNotFound {
+		t.Errorf("Ack(missing) = %v, want ErrMessageNotFound", err)
+	}
+
+	for id, want := range map[string]MessageState{"handoff": StateAcked, "fyi": StateRead, "new": StateUnread} {
+		got, err := m.State(id)
+		if err != nil || got != want {
+			t.Errorf("State(%s) = %q, %v; want %q", id, got, err, want)
+		}
+	}
+	msg, _ := m.Get("handoff")
+	if !msg.Read || msg.AckedAt == nil {
+		t.Errorf("acked message = %+v, want Read and AckedAt set", msg)
+	}
+
+	counts, err := m.Counts()
+	if err != nil {
+		t.Fatalf("Counts error: %v", err)
+	}
+	if counts != (MailCounts{Total: 3, Unread: 1, Read: 1, Acked: 1}) {
+		t.Errorf("Counts = %+v", counts)
+	}
+
+	// Marking unread clears the acknowledgement
+	if err := m.MarkUnread("handoff"); err != nil {
+		t.Fatalf("MarkUnread error: %v", err)
+	}
+	if got, _ := m.State("handoff"); got != StateUnread {
+		t.Errorf("State after MarkUnread = %q, want unread", got)
+	}
+}
+
 func TestMailboxLegacyListUnread(t *testing.T) {
 	tmpDir := t.TempDir()
 	m := NewMailbox(tmpDir)
@@ -507,3 +561,101 @@ func TestMailboxLegacyMarkReadTwice(t *testing.T) {
 	}
 }
 
+func TestMailboxLegacyReceivedSince(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewMailbox(tmpDir)
+	now := time.Now()
+
+	for _, msg := range []*Message{
+		{ID: "old", From: "mayor/", Subject: "old", Timestamp: now.Add(-48 * time.Hour)},
+		{ID: "new", From: "mayor/", Subject: "new", Timestamp: now.Add(-time.Hour)},
+		{ID: "newer", From: "mayor/", Subject: "newer", Timestamp: now.Add(-time.Minute)},
+	} {
+		if err := m.Append(msg); err != nil {
+			t.Fatalf("Append error: %v", err)
+		}
+	}
+	if err := m.Archive("newer"); err != nil {
+		t.Fatalf("Archive error: %v", err)
+	}
+
+	got, err := m.ReceivedSince(now.Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatalf("ReceivedSince error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "newer" || got[1].ID != "new" {
+		var ids []string
+		for _, msg := range got {
+			ids = append(ids, msg.ID)
+		}
+		t.Errorf("ReceivedSince = %v, want [newer new] (archived included, newest first)", ids)
+	}
+
+	sent, err := m.SentSince(now.Add(-24 * time.Hour))
+	if err != nil || sent != nil {
+		t.Errorf("SentSince on legacy mailbox = %v, %v; want nil, nil", sent, err)
+	}
+}
+
+func TestMailboxBeadsCopyTo(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub bd is a shell script")
+	}
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "bd.log")
+	// Stub bd: dave has one unread and one read message
+	script := `#!/bin/sh
+echo "$*" >> "` + logPath + `"
+case "$1 $7" in
+  "list open")
+    echo '[{"id":"hq-1","title":"Unread","description":"body 1","assignee":"gastown/dave","priority":1,"status":"open","labels":["from:mayor/","thread:t1"]}]' ;;
+  "list closed")
+    echo '[{"id":"hq-2","title":"Read","description":"body 2","assignee":"gastown/dave","priority":2,"status":"closed","labels":["from:gastown/witness"]}]' ;;
+  "list "*)
+    echo '[]' ;;
+  create*)
+    echo '{"id":"hq-new"}' ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(dir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	m := NewMailboxWithBeadsDir("gastown/crew/dave", dir, filepath.Join(dir, ".beads"))
+	copied, err := m.CopyTo("gastown/crew/emma")
+	if err != nil {
+		t.Fatalf("CopyTo: %v", err)
+	}
+	if copied != 2 {
+		t.Errorf("CopyTo copied %d messages, want 2", copied)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var creates, closes []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		switch {
+		case strings.HasPrefix(line, "create "):
+			creates = append(creates, line)
+		case strings.HasPrefix(line, "close "):
+			closes = append(closes, line)
+		case strings.HasPrefix(line, "update "):
+			t.Errorf("CopyTo changed a source message: %s", line)
+		}
+	}
+	if len(creates) != 2 {
+		t.Fatalf("bd create calls = %q, want 2", creates)
+	}
+	for _, want := range []string{"create Unread", "--assignee gastown/emma", "-d body 1", "--priority 1", "--labels from:mayor/,thread:t1", "--actor mayor/"} {
+		if !strings.Contains(creates[0], want) {
+			t.Errorf("copy of the unread message = %q, missing %q", creates[0], want)
+		}
+	}
+	// The read message stays read in the copy
+	if len(closes) != 1 || !strings.HasPrefix(closes[0], "close hq-new") {
+		t.Errorf("bd close calls = %q, want the copy of the read message closed", closes)
+	}
+}
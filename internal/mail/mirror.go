@@ -0,0 +1,103 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/notify"
+)
+
+// mirror copies msg to the external endpoints of every mirror rule that
+// matches it, in the settings of the rigs of its sender and recipient.
+// Mirroring is best-effort: delivery has already succeeded, and a slow or
+// broken endpoint must not fail the send.
+func (r *Router) mirror(msg *Message) {
+	if r.townRoot == "" {
+		return
+	}
+	sinks := make(map[string]*config.NotificationSink)
+	for _, rig := range mirrorRigs(msg) {
+		settings, err := config.LoadRigSettings(config.RigSettingsPath(filepath.Join(r.townRoot, rig)))
+		if err != nil || settings.Mail == nil {
+			continue
+		}
+		for _, rule := range settings.Mail.Mirrors {
+			if mirrorMatches(rule, msg) {
+				sinks[rig+"/mail:"+rule.Name] = rule.Sink
+			}
+		}
+	}
+	if len(sinks) == 0 {
+		return
+	}
+
+	cfg := config.NewNotificationsConfig()
+	cfg.Sinks = sinks
+	notify.NewDispatcher(r.townRoot, cfg).Dispatch(context.Background(), notify.Notification{
+		Event:    notify.EventMail,
+		Severity: prioritySeverity(msg.Priority),
+		Title:    msg.Subject,
+		Body:     fmt.Sprintf("To: %s\n\n%s", msg.To, msg.Body),
+		Source:   msg.From,
+		Time:     msg.Timestamp,
+	})
+}
+
+// mirrorRigs returns the rigs whose mirror rules apply to msg: those of its
+// sender and of its recipient, when they are rig agents.
+func mirrorRigs(msg *Message) []string {
+	var rigs []string
+	for _, addr := range []string{msg.From, msg.To} {
+		if !isAgentAddress(addr) || isTownLevelAddress(addr) {
+			continue
+		}
+		rig, _, _ := strings.Cut(addr, "/")
+		if rig != "" && (len(rigs) == 0 || rigs[0] != rig) {
+			rigs = append(rigs, rig)
+		}
+	}
+	return rigs
+}
+
+// mirrorMatches reports whether every filter set on rule matches msg.
+func mirrorMatches(rule *config.MailMirrorRule, msg *Message) bool {
+	if rule.SubjectContains != "" &&
+		!strings.Contains(strings.ToLower(msg.Subject), strings.ToLower(rule.SubjectContains)) {
+		return false
+	}
+	if rule.From != "" && !matchAddress(rule.From, msg.From) {
+		return false
+	}
+	if rule.To != "" && !matchAddress(rule.To, msg.To) {
+		return false
+	}
+	if rule.MinPriority != "" && PriorityToBeads(msg.Priority) > PriorityToBeads(ParsePriority(rule.MinPriority)) {
+		return false
+	}
+	return true
+}
+
+// matchAddress matches an address pattern against an address in either its
+// canonical or its normalized form ("gastown/crew/max" or "gastown/max").
+func matchAddress(pattern, address string) bool {
+	addr := strings.TrimSuffix(address, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	return matchPattern(pattern, addr) || matchPattern(pattern, strings.TrimSuffix(AddressToIdentity(address), "/"))
+}
+
+// prioritySeverity maps a mail priority to a notification severity.
+func prioritySeverity(p Priority) string {
+	switch p {
+	case PriorityUrgent:
+		return config.SeverityCritical
+	case PriorityHigh:
+		return config.SeverityHigh
+	case PriorityLow:
+		return config.SeverityLow
+	default:
+		return config.SeverityMedium
+	}
+}
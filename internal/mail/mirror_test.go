@@ -0,0 +1,94 @@
+package mail
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestMirrorMatches(t *testing.T) {
+	msg := &Message{From: "gastown/witness", To: "mayor/", Subject: "ESCALATION: polecat stuck", Priority: PriorityHigh}
+	tests := []struct {
+		name string
+		rule config.MailMirrorRule
+		want bool
+	}{
+		{"no filters", config.MailMirrorRule{}, true},
+		{"subject case-insensitive", config.MailMirrorRule{SubjectContains: "escalation"}, true},
+		{"subject miss", config.MailMirrorRule{SubjectContains: "MERGED"}, false},
+		{"from wildcard", config.MailMirrorRule{From: "*/witness"}, true},
+		{"to town agent", config.MailMirrorRule{To: "mayor"}, true},
+		{"to miss", config.MailMirrorRule{To: "deacon/"}, false},
+		{"priority met", config.MailMirrorRule{MinPriority: "high"}, true},
+		{"priority not met", config.MailMirrorRule{MinPriority: "urgent"}, false},
+		{"all filters", config.MailMirrorRule{SubjectContains: "stuck", From: "gastown/*", MinPriority: "normal"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mirrorMatches(&tt.rule, msg); got != tt.want {
+				t.Errorf("mirrorMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	crew := &Message{From: "gastown/crew/max", To: "beads/polecats/Toast"}
+	if !mirrorMatches(&config.MailMirrorRule{From: "gastown/max", To: "beads/*"}, crew) {
+		t.Error("normalized address forms should match")
+	}
+}
+
+func TestMirrorRigs(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     []string
+	}{
+		{"gastown/witness", "mayor/", []string{"gastown"}},
+		{"gastown/crew/max", "gastown/refinery", []string{"gastown"}},
+		{"gastown/witness", "beads/crew/joe", []string{"gastown", "beads"}},
+		{"mayor/", "list:oncall", nil},
+		{"overseer", "deacon/", nil},
+	}
+	for _, tt := range tests {
+		got := mirrorRigs(&Message{From: tt.from, To: tt.to})
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("mirrorRigs(%s -> %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestRouterMirrorPostsToWebhook(t *testing.T) {
+	var received []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var payload map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&payload)
+		received = append(received, payload)
+	}))
+	defer srv.Close()
+
+	townRoot := t.TempDir()
+	settings := config.NewRigSettings()
+	settings.Mail = &config.RigMailConfig{Mirrors: []*config.MailMirrorRule{{
+		Name:            "escalations",
+		SubjectContains: "ESCALATION",
+		Sink:            &config.NotificationSink{Type: config.SinkWebhook, URL: srv.URL},
+	}}}
+	if err := config.SaveRigSettings(config.RigSettingsPath(filepath.Join(townRoot, "gastown")), settings); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRouterWithTownRoot(townRoot, townRoot)
+	r.mirror(&Message{From: "gastown/witness", To: "mayor/", Subject: "routine patrol"})
+	r.mirror(&Message{From: "gastown/witness", To: "mayor/", Subject: "ESCALATION: Toast stuck", Body: "idle 2h", Priority: PriorityUrgent})
+
+	if len(received) != 1 {
+		t.Fatalf("webhook received %d posts, want 1", len(received))
+	}
+	if received[0]["title"] != "ESCALATION: Toast stuck" || received[0]["event"] != "mail" || received[0]["severity"] != "critical" {
+		t.Errorf("payload = %v", received[0])
+	}
+}
@@ -0,0 +1,170 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// DefaultNotifyInterval is how often a Notifier polls mailboxes.
+const DefaultNotifyInterval = 5 * time.Second
+
+// Notice is one new-mail notification delivered to an agent's session.
+type Notice struct {
+	Address   string `json:"address"`
+	Session   string `json:"session"`
+	MessageID string `json:"message_id"`
+	From      string `json:"from"`
+	Subject   string `json:"subject"`
+}
+
+// Notifier watches the town's local JSONL mailboxes and injects a short
+// "you have new mail" line into the recipient's tmux session when a message
+// arrives. Beads-routed mail is announced by the Router at send time; the
+// Notifier covers mail appended straight to an inbox file.
+//
+// Mailboxes are polled: an inbox is only re-read when its size or
+// modification time changes, and the routing table is reloaded every pass
+// so newly added workers are picked up.
+type Notifier struct {
+	townRoot string
+	inboxes  map[string]*inboxState // inbox path -> last state seen
+	primed   bool
+
+	// inject delivers a notice to a running session. It reports false if
+	// the agent has no session to notify.
+	inject func(route *Route, text string) (bool, error)
+}
+
+type inboxState struct {
+	size int64
+	mod  time.Time
+	ids  map[string]bool // messages already accounted for
+}
+
+// NewNotifier creates a notifier for the town at townRoot.
+func NewNotifier(townRoot string) *Notifier {
+	t := tmux.NewTmux()
+	return &Notifier{
+		townRoot: townRoot,
+		inboxes:  make(map[string]*inboxState),
+		inject: func(route *Route, text string) (bool, error) {
+			sessionID := route.SessionName()
+			if sessionID == "" {
+				return false, nil
+			}
+			running, err := t.HasSession(sessionID)
+			if err != nil || !running {
+				return false, nil
+			}
+			return true, t.NudgeSession(sessionID, text)
+		},
+	}
+}
+
+// NoticeText is the line injected into a session for a new message.
+func NoticeText(msg *Message) string {
+	return fmt.Sprintf("📬 You have new mail from %s. Subject: %s. Run 'gt mail inbox' to read.", msg.From, msg.Subject)
+}
+
+// Poll checks every mailbox once and notifies recipients of unread messages
+// that arrived since the previous poll. The first poll only records what is
+// already there.
+func (n *Notifier) Poll() ([]Notice, error) {
+	table, err := LoadRoutingTable(n.townRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var notices []Notice
+	var firstErr error
+	present := make(map[string]bool)
+	for _, route := range table.Routes() {
+		path := filepath.Join(route.MailDir(), "inbox.jsonl")
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		present[path] = true
+
+		prev := n.inboxes[path]
+		if prev != nil && prev.size == info.Size() && prev.mod.Equal(info.ModTime()) {
+			continue
+		}
+		messages, err := NewMailbox(route.MailDir()).List()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("reading %s: %w", path, err)
+			}
+			continue
+		}
+
+		state := &inboxState{size: info.Size(), mod: info.ModTime(), ids: make(map[string]bool, len(messages))}
+		n.inboxes[path] = state
+		// List is newest first; announce in arrival order.
+		for i := len(messages) - 1; i >= 0; i-- {
+			msg := messages[i]
+			state.ids[msg.ID] = true
+			if !n.primed || msg.Read || (prev != nil && prev.ids[msg.ID]) {
+				continue
+			}
+			delivered, err := n.inject(route, NoticeText(msg))
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("notifying %s: %w", route.Address, err)
+				}
+				continue
+			}
+			if delivered {
+				notices = append(notices, Notice{
+					Address:   route.Address,
+					Session:   route.SessionName(),
+					MessageID: msg.ID,
+					From:      msg.From,
+					Subject:   msg.Subject,
+				})
+			}
+		}
+	}
+
+	// Forget removed inboxes so a recreated one is announced in full.
+	for path := range n.inboxes {
+		if !present[path] {
+			delete(n.inboxes, path)
+		}
+	}
+	n.primed = true
+	return notices, firstErr
+}
+
+// Run polls every interval until ctx is canceled, passing each delivered
+// notice to onNotice. Poll errors are reported through onError and don't
+// stop the loop.
+func (n *Notifier) Run(ctx context.Context, interval time.Duration, onNotice func(Notice), onError func(error)) error {
+	if interval <= 0 {
+		interval = DefaultNotifyInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		notices, err := n.Poll()
+		if err != nil && onError != nil {
+			onError(err)
+		}
+		if onNotice != nil {
+			for _, notice := range notices {
+				onNotice(notice)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
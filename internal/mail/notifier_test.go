@@ -0,0 +1,66 @@
+package mail
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNotifierPoll(t *testing.T) {
+	town := setupRoutingTown(t)
+	n := NewNotifier(town)
+	var injected []string
+	n.inject = func(route *Route, text string) (bool, error) {
+		injected = append(injected, route.Address+": "+text)
+		return route.Name != "Toast", nil // Toast has no running session
+	}
+
+	maxBox := NewMailbox(filepath.Join(town, "gastown", "crew", "max", "mail"))
+	send := func(box *Mailbox, id, subject string, read bool) {
+		t.Helper()
+		msg := &Message{ID: id, From: "mayor/", Subject: subject, Timestamp: time.Now(), Read: read}
+		if err := box.Append(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	send(maxBox, "msg-old", "already here", false)
+
+	// The first poll records existing mail without announcing it.
+	if notices, err := n.Poll(); err != nil || len(notices) != 0 || len(injected) != 0 {
+		t.Fatalf("priming poll: notices=%v injected=%v err=%v", notices, injected, err)
+	}
+
+	send(maxBox, "msg-1", "first", false)
+	send(maxBox, "msg-2", "second", true) // already read: not announced
+	notices, err := n.Poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notices) != 1 || notices[0].MessageID != "msg-1" || notices[0].Address != "gastown/crew/max" {
+		t.Fatalf("notices = %+v, want msg-1 for gastown/crew/max", notices)
+	}
+	if notices[0].Session != "gt-gastown-crew-max" {
+		t.Errorf("session = %q", notices[0].Session)
+	}
+
+	// Unchanged inboxes are not re-announced.
+	if notices, _ := n.Poll(); len(notices) != 0 {
+		t.Errorf("repeat poll announced %+v", notices)
+	}
+
+	// Marking read rewrites the inbox; nothing new to announce.
+	if err := maxBox.MarkRead("msg-1"); err != nil {
+		t.Fatal(err)
+	}
+	if notices, _ := n.Poll(); len(notices) != 0 {
+		t.Errorf("poll after mark-read announced %+v", notices)
+	}
+
+	// A new inbox is announced in full; a recipient without a session is
+	// tried but not reported.
+	injected = nil
+	send(NewMailbox(filepath.Join(town, "gastown", "crew", "Toast", "mail")), "msg-3", "hello", false)
+	if notices, _ := n.Poll(); len(notices) != 0 || len(injected) != 1 {
+		t.Errorf("sessionless recipient: notices=%+v injected=%v", notices, injected)
+	}
+}
@@ -34,6 +34,9 @@ type Recipient struct {
 type Resolver struct {
 	beads    *beads.Beads
 	townRoot string
+
+	table       *RoutingTable // loaded on first use; nil outside a town
+	tableLoaded bool
 }
 
 // NewResolver creates a new address resolver.
@@ -100,9 +103,42 @@ func (r *Resolver) resolveAgentAddress(address string) ([]Recipient, error) {
 	}}, nil
 }
 
+// routingTable returns the town's routing table, or nil if it can't be
+// loaded.
+func (r *Resolver) routingTable() *RoutingTable {
+	if !r.tableLoaded && r.townRoot != "" {
+		r.table, _ = LoadRoutingTable(r.townRoot)
+	}
+	r.tableLoaded = true
+	return r.table
+}
+
+// canonical returns the canonical form of an agent address, or the address
+// unchanged if the routing table doesn't know it.
+func (r *Resolver) canonical(address string) string {
+	if t := r.routingTable(); t != nil {
+		if route, err := t.Lookup(address); err == nil {
+			return route.Address
+		}
+	}
+	return address
+}
+
 // resolvePattern expands a wildcard pattern to matching agents.
-// Patterns like "*/witness" or "gastown/*" are expanded.
+// Patterns like "*/witness", "gastown/crew/*" or "gastown/*" (every agent
+// in the rig) are matched against the town's routing table, falling back
+// to agent beads when the table has no match.
 func (r *Resolver) resolvePattern(pattern string) ([]Recipient, error) {
+	if t := r.routingTable(); t != nil {
+		if addrs := t.Match(pattern); len(addrs) > 0 {
+			recipients := make([]Recipient, len(addrs))
+			for i, addr := range addrs {
+				recipients[i] = Recipient{Address: addr, Type: RecipientAgent}
+			}
+			return recipients, nil
+		}
+	}
+
 	if r.beads == nil {
 		return nil, fmt.Errorf("beads not available for pattern resolution")
 	}
@@ -193,10 +229,14 @@ func (r *Resolver) resolveByName(name string) ([]Recipient, error) {
 		}
 	}
 
-	// Check for queue/channel in config (legacy)
+	// Check for group/queue/channel in config (legacy)
 	if r.townRoot != "" {
 		cfg, err := config.LoadMessagingConfig(config.MessagingConfigPath(r.townRoot))
 		if err == nil && cfg != nil {
+			if members, ok := cfg.Groups[name]; ok && groupFields == nil {
+				foundGroup = true
+				groupFields = &beads.GroupFields{Name: name, Members: members}
+			}
 			if _, ok := cfg.Queues[name]; ok {
 				foundQueue = true
 			}
@@ -247,21 +287,42 @@ func (r *Resolver) resolveByName(name string) ([]Recipient, error) {
 	return r.resolveChannel(name)
 }
 
-// resolveBeadsGroup resolves a beads-native group by name.
+// resolveBeadsGroup resolves a group by name: a beads-native group, or
+// else one defined in the messaging config.
 func (r *Resolver) resolveBeadsGroup(name string) ([]Recipient, error) {
+	if r.beads != nil {
+		_, fields, err := r.beads.LookupGroupByName(name)
+		if err != nil {
+			return nil, err
+		}
+		if fields != nil {
+			return r.expandGroupMembers(fields)
+		}
+	}
+	if fields := r.configGroup(name); fields != nil {
+		return r.expandGroupMembers(fields)
+	}
 	if r.beads == nil {
 		return nil, fmt.Errorf("beads not available")
 	}
+	return nil, fmt.Errorf("group not found: %s", name)
+}
 
-	_, fields, err := r.beads.LookupGroupByName(name)
+// configGroup returns the group of that name in the messaging config, or
+// nil.
+func (r *Resolver) configGroup(name string) *beads.GroupFields {
+	if r.townRoot == "" {
+		return nil
+	}
+	cfg, err := config.LoadMessagingConfig(config.MessagingConfigPath(r.townRoot))
 	if err != nil {
-		return nil, err
+		return nil
 	}
-	if fields == nil {
-		return nil, fmt.Errorf("group not found: %s", name)
+	members, ok := cfg.Groups[name]
+	if !ok {
+		return nil
 	}
-
-	return r.expandGroupMembers(fields)
+	return &beads.GroupFields{Name: name, Members: members}
 }
 
 // expandGroupMembers expands a group's members to recipients.
@@ -297,7 +358,10 @@ func (r *Resolver) expandGroupMembersWithVisited(fields *beads.GroupFields, visi
 		}
 
 		for _, rec := range resolved {
-			// Deduplicate
+			// Deduplicate, treating short and canonical forms as one agent
+			if rec.Type == RecipientAgent {
+				rec.Address = r.canonical(rec.Address)
+			}
 			if !seen[rec.Address] {
 				seen[rec.Address] = true
 				recipients = append(recipients, rec)
@@ -311,9 +375,14 @@ func (r *Resolver) expandGroupMembersWithVisited(fields *beads.GroupFields, visi
 // resolveMemberWithVisited resolves a single group member with cycle detection.
 func (r *Resolver) resolveMemberWithVisited(member string, visited map[string]bool) ([]Recipient, error) {
 	// Check if this is a nested group reference
-	if r.beads != nil && !strings.Contains(member, "/") && !strings.HasPrefix(member, "@") {
-		_, fields, err := r.beads.LookupGroupByName(member)
-		if err == nil && fields != nil {
+	if !strings.Contains(member, "/") && !strings.HasPrefix(member, "@") {
+		if r.beads != nil {
+			_, fields, err := r.beads.LookupGroupByName(member)
+			if err == nil && fields != nil {
+				return r.expandGroupMembersWithVisited(fields, visited)
+			}
+		}
+		if fields := r.configGroup(member); fields != nil {
 			return r.expandGroupMembersWithVisited(fields, visited)
 		}
 	}
@@ -1,7 +1,12 @@
 package mail
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
 )
 
 func TestMatchPattern(t *testing.T) {
@@ -160,3 +165,39 @@ func TestResolverResolve_UnknownName(t *testing.T) {
 		t.Error("Resolve(\"unknown-name\") should return error for unknown name")
 	}
 }
+
+func TestResolverResolve_ConfigGroups(t *testing.T) {
+	town := setupRoutingTown(t)
+	if err := os.MkdirAll(filepath.Join(town, "config"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	messaging := `{"type": "messaging", "version": 1, "groups": {
+		"backend-team": ["gastown/crew/*", "gastown/max", "reviewers"],
+		"reviewers": ["gastown/witness", "backend-team"]
+	}}`
+	if err := os.WriteFile(config.MessagingConfigPath(town), []byte(messaging), 0644); err != nil {
+		t.Fatal(err)
+	}
+	resolver := NewResolver(nil, town)
+
+	for _, address := range []string{"backend-team", "group:backend-team"} {
+		got, err := resolver.Resolve(address)
+		if err != nil {
+			t.Fatalf("Resolve(%q): %v", address, err)
+		}
+		var addrs []string
+		for _, rec := range got {
+			addrs = append(addrs, rec.Address)
+		}
+		// gastown/max duplicates gastown/crew/max; the reviewers cycle is cut
+		want := "gastown/crew/Toast gastown/crew/max gastown/witness"
+		if strings.Join(addrs, " ") != want {
+			t.Errorf("Resolve(%q) = %v, want %s", address, addrs, want)
+		}
+	}
+
+	got, err := resolver.Resolve("gastown/polecats/*")
+	if err != nil || len(got) != 2 {
+		t.Errorf("Resolve(gastown/polecats/*) = %v, %v; want 2 polecats", got, err)
+	}
+}
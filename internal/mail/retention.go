@@ -0,0 +1,215 @@
+package mail
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Retention bounds how much read mail a mailbox keeps. Unread and pinned
+// messages are never archived.
+type Retention struct {
+	MaxMessages int           // keep at most this many messages (0 = unlimited)
+	MaxAge      time.Duration // archive read mail older than this (0 = no limit)
+}
+
+// DefaultRetention applies to mailboxes without a configured policy.
+var DefaultRetention = Retention{MaxMessages: 1000, MaxAge: 30 * 24 * time.Hour}
+
+// RetentionFor returns the policy for the mailbox at address: an exact key
+// in cfg.Retention, else the first matching wildcard key in sorted order,
+// else "default", else DefaultRetention.
+func RetentionFor(cfg *config.MessagingConfig, address string) Retention {
+	if cfg == nil || len(cfg.Retention) == 0 {
+		return DefaultRetention
+	}
+	if p, ok := cfg.Retention[address]; ok {
+		return retentionFromConfig(p)
+	}
+	keys := make([]string, 0, len(cfg.Retention))
+	for k := range cfg.Retention {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if k != "default" && matchPattern(k, address) {
+			return retentionFromConfig(cfg.Retention[k])
+		}
+	}
+	if p, ok := cfg.Retention["default"]; ok {
+		return retentionFromConfig(p)
+	}
+	return DefaultRetention
+}
+
+func retentionFromConfig(p config.RetentionPolicy) Retention {
+	r := Retention{MaxMessages: p.MaxMessages}
+	if p.MaxAge != "" {
+		r.MaxAge, _ = time.ParseDuration(p.MaxAge) // validated on load
+	}
+	return r
+}
+
+// CompactResult reports what a compaction did (or would do).
+type CompactResult struct {
+	Kept     int `json:"kept"`
+	Archived int `json:"archived"`
+}
+
+// Compact moves read mail beyond the retention policy into the mailbox's
+// compressed archive: first everything older than MaxAge, then the oldest
+// remaining read messages until at most MaxMessages are left. With dryRun,
+// nothing is changed.
+func (m *Mailbox) Compact(policy Retention, dryRun bool) (*CompactResult, error) {
+	messages, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	archive, keep := selectForArchive(messages, policy, timeNow())
+	result := &CompactResult{Kept: len(keep), Archived: len(archive)}
+	if dryRun || len(archive) == 0 {
+		return result, nil
+	}
+
+	if err := m.appendCompressed(archive); err != nil {
+		return nil, err
+	}
+	if m.legacy {
+		return result, m.rewriteLegacy(keep)
+	}
+	for _, msg := range archive {
+		if err := m.MarkRead(msg.ID); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// selectForArchive splits messages into those the policy archives and
+// those it keeps, each oldest first.
+func selectForArchive(messages []*Message, policy Retention, now time.Time) (archive, keep []*Message) {
+	sorted := append([]*Message(nil), messages...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+
+	excess := 0
+	if policy.MaxMessages > 0 && len(sorted) > policy.MaxMessages {
+		excess = len(sorted) - policy.MaxMessages
+	}
+	for _, msg := range sorted {
+		archivable := msg.Read && !msg.Pinned
+		tooOld := policy.MaxAge > 0 && now.Sub(msg.Timestamp) > policy.MaxAge
+		if archivable && (tooOld || excess > 0) {
+			archive = append(archive, msg)
+			if excess > 0 {
+				excess--
+			}
+			continue
+		}
+		keep = append(keep, msg)
+	}
+	return archive, keep
+}
+
+// CompressedArchivePath returns the path to the gzip archive that
+// compaction writes to.
+func (m *Mailbox) CompressedArchivePath() string {
+	return m.ArchivePath() + ".gz"
+}
+
+// appendCompressed appends messages to the compressed archive as a new gzip
+// member; readers see the concatenated members as one stream.
+func (m *Mailbox) appendCompressed(messages []*Message) error {
+	path := m.CompressedArchivePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: archive is non-sensitive operational data
+	if err != nil {
+		return err
+	}
+	if err := writeGzipMessages(file, messages); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func writeGzipMessages(w io.Writer, messages []*Message) error {
+	zw := gzip.NewWriter(w)
+	enc := json.NewEncoder(zw)
+	for _, msg := range messages {
+		if err := enc.Encode(msg); err != nil {
+			_ = zw.Close()
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// listCompressed returns the messages in the compressed archive.
+func (m *Mailbox) listCompressed() ([]*Message, error) {
+	file, err := os.Open(m.CompressedArchivePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil // empty file
+		}
+		return nil, err
+	}
+	defer func() { _ = zr.Close() }()
+
+	var messages []*Message
+	scanner := bufio.NewScanner(zr)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue // Skip malformed lines
+		}
+		messages = append(messages, &msg)
+	}
+	return messages, scanner.Err()
+}
+
+// rewriteCompressed replaces the compressed archive with messages, removing
+// it if there are none.
+func (m *Mailbox) rewriteCompressed(messages []*Message) error {
+	path := m.CompressedArchivePath()
+	if len(messages) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath) //nolint:gosec // G304: path is derived from the mailbox
+	if err != nil {
+		return err
+	}
+	if err := writeGzipMessages(file, messages); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
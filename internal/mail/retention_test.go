@@ -0,0 +1,126 @@
+package mail
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestRetentionFor(t *testing.T) {
+	cfg := &config.MessagingConfig{Retention: map[string]config.RetentionPolicy{
+		"default":          {MaxAge: "720h"},
+		"gastown/crew/*":   {MaxMessages: 200},
+		"gastown/crew/max": {MaxMessages: 50, MaxAge: "24h"},
+	}}
+
+	tests := []struct {
+		address string
+		want    Retention
+	}{
+		{"gastown/crew/max", Retention{MaxMessages: 50, MaxAge: 24 * time.Hour}},
+		{"gastown/crew/joe", Retention{MaxMessages: 200}},
+		{"gastown/witness", Retention{MaxAge: 720 * time.Hour}},
+	}
+	for _, tt := range tests {
+		if got := RetentionFor(cfg, tt.address); got != tt.want {
+			t.Errorf("RetentionFor(%q) = %+v, want %+v", tt.address, got, tt.want)
+		}
+	}
+	if got := RetentionFor(nil, "mayor/"); got != DefaultRetention {
+		t.Errorf("RetentionFor(nil) = %+v, want DefaultRetention", got)
+	}
+}
+
+func TestSelectForArchive(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	msg := func(id string, age time.Duration, read, pinned bool) *Message {
+		return &Message{ID: id, Timestamp: now.Add(-age), Read: read, Pinned: pinned}
+	}
+	messages := []*Message{
+		msg("old-read", 60*24*time.Hour, true, false),
+		msg("old-unread", 50*24*time.Hour, false, false),
+		msg("old-pinned", 40*24*time.Hour, true, true),
+		msg("mid-read", 5*24*time.Hour, true, false),
+		msg("new-read", time.Hour, true, false),
+		msg("new-unread", time.Minute, false, false),
+	}
+
+	tests := []struct {
+		name   string
+		policy Retention
+		want   string
+	}{
+		{"age only", Retention{MaxAge: 30 * 24 * time.Hour}, "old-read"},
+		{"count only", Retention{MaxMessages: 4}, "old-read,mid-read"},
+		{"age and count", Retention{MaxMessages: 3, MaxAge: 30 * 24 * time.Hour}, "old-read,mid-read,new-read"},
+		{"unlimited", Retention{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive, keep := selectForArchive(messages, tt.policy, now)
+			var ids []string
+			for _, m := range archive {
+				ids = append(ids, m.ID)
+			}
+			if got := strings.Join(ids, ","); got != tt.want {
+				t.Errorf("archived %s, want %s", got, tt.want)
+			}
+			if len(archive)+len(keep) != len(messages) {
+				t.Errorf("archived %d + kept %d != %d", len(archive), len(keep), len(messages))
+			}
+		})
+	}
+}
+
+func TestCompactLegacyMailbox(t *testing.T) {
+	box := NewMailbox(filepath.Join(t.TempDir(), "mail"))
+	now := time.Now()
+	for i, age := range []time.Duration{90 * 24 * time.Hour, 60 * 24 * time.Hour, time.Hour} {
+		msg := &Message{ID: string(rune('a' + i)), Subject: "s", Timestamp: now.Add(-age), Read: i < 2}
+		if err := box.Append(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	policy := Retention{MaxAge: 30 * 24 * time.Hour}
+
+	dry, err := box.Compact(policy, true)
+	if err != nil || dry.Archived != 2 || dry.Kept != 1 {
+		t.Fatalf("dry run = %+v, %v; want 2 archived, 1 kept", dry, err)
+	}
+	if total, _, _ := box.Count(); total != 3 {
+		t.Fatalf("dry run changed the inbox: %d messages", total)
+	}
+
+	if _, err := box.Compact(policy, false); err != nil {
+		t.Fatal(err)
+	}
+	if total, _, _ := box.Count(); total != 1 {
+		t.Errorf("inbox holds %d messages after compaction, want 1", total)
+	}
+	archived, err := box.ListArchived()
+	if err != nil || len(archived) != 2 {
+		t.Fatalf("ListArchived = %d, %v; want 2", len(archived), err)
+	}
+
+	// A second compaction appends another gzip member.
+	if err := box.MarkRead("c"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := box.Compact(Retention{MaxMessages: 0, MaxAge: time.Minute}, false); err != nil {
+		t.Fatal(err)
+	}
+	if archived, _ := box.ListArchived(); len(archived) != 3 {
+		t.Errorf("ListArchived after second compaction = %d, want 3", len(archived))
+	}
+
+	purged, err := box.PurgeArchive(45)
+	if err != nil || purged != 2 {
+		t.Errorf("PurgeArchive(45) = %d, %v; want 2", purged, err)
+	}
+	if archived, _ := box.ListArchived(); len(archived) != 1 {
+		t.Errorf("ListArchived after purge = %d, want 1", len(archived))
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -56,6 +57,23 @@ func NewRouterWithTownRoot(workDir, townRoot string) *Router {
 	}
 }
 
+// ValidateAddress checks that a message to address can be delivered. Agent
+// addresses are checked against the town's routing table when the router
+// knows its town; otherwise only the address syntax is checked.
+func (r *Router) ValidateAddress(address string) error {
+	if err := validateAddressSyntax(address); err != nil {
+		return err
+	}
+	if r.townRoot == "" {
+		return nil
+	}
+	table, err := LoadRoutingTable(r.townRoot)
+	if err != nil {
+		return nil //nolint:nilerr // no rigs config to check against
+	}
+	return table.ValidateAddress(address)
+}
+
 // isListAddress returns true if the address uses list:name syntax.
 func isListAddress(address string) bool {
 	return strings.HasPrefix(address, "list:")
@@ -192,7 +210,7 @@ func (r *Router) resolveBeadsDir(_ string) string { // address unused: all mail
 // isTownLevelAddress returns true if the address is for a town-level agent or the overseer.
 func isTownLevelAddress(address string) bool {
 	addr := strings.TrimSuffix(address, "/")
-	return addr == "mayor" || addr == "deacon" || addr == "overseer"
+	return addr == "mayor" || addr == "deacon" || addr == "overseer" || addr == "human"
 }
 
 // isGroupAddress returns true if the address is a @group address.
@@ -501,7 +519,22 @@ func (r *Router) shouldBeWisp(msg *Message) bool {
 // Supports single-copy delivery for:
 // - Queues (queue:name) - stores single message for worker claiming
 // - Announces (announce:name) - bulletin board, no claiming, retention-limited
+// Delivered mail matching a rig's mirror rules is also copied to their
+// external endpoints, and each delivery is published to the activity feed.
 func (r *Router) Send(msg *Message) error {
+	if err := r.send(msg); err != nil {
+		return err
+	}
+	r.mirror(msg)
+	// Lists fan out through Send, which publishes each member's copy
+	if !isListAddress(msg.To) {
+		_ = events.Publish(msg.From, events.MailEvent{To: msg.To, Subject: msg.Subject, MessageID: msg.ID})
+	}
+	return nil
+}
+
+// send delivers msg according to its address type.
+func (r *Router) send(msg *Message) error {
 	// Check for mailing list address
 	if isListAddress(msg.To) {
 		return r.sendToList(msg)
@@ -571,7 +604,7 @@ func (r *Router) sendToSingle(msg *Message) error {
 	// Convert addresses to beads identities
 	toIdentity := AddressToIdentity(msg.To)
 
-	// Build labels for from/thread/reply-to/cc
+	// Build labels for from/thread/reply-to/attachments/cc
 	var labels []string
 	labels = append(labels, "from:"+msg.From)
 	if msg.ThreadID != "" {
@@ -580,6 +613,18 @@ func (r *Router) sendToSingle(msg *Message) error {
 	if msg.ReplyTo != "" {
 		labels = append(labels, "reply-to:"+msg.ReplyTo)
 	}
+	for _, ref := range msg.References {
+		labels = append(labels, "ref:"+ref)
+	}
+	if msg.BroadcastID != "" {
+		labels = append(labels, "broadcast:"+msg.BroadcastID)
+	}
+	if msg.Bead != "" {
+		labels = append(labels, "bead:"+msg.Bead)
+	}
+	for _, a := range msg.Attachments {
+		labels = append(labels, a.label())
+	}
 	// Add CC labels (one per recipient)
 	for _, cc := range msg.CC {
 		ccIdentity := AddressToIdentity(cc)
@@ -681,7 +726,7 @@ func (r *Router) sendToQueue(msg *Message) error {
 		return err
 	}
 
-	// Build labels for from/thread/reply-to/cc plus queue metadata
+	// Build labels for from/thread/reply-to/attachments/cc plus queue metadata
 	var labels []string
 	labels = append(labels, "from:"+msg.From)
 	labels = append(labels, "queue:"+queueName)
@@ -691,6 +736,9 @@ func (r *Router) sendToQueue(msg *Message) error {
 	if msg.ReplyTo != "" {
 		labels = append(labels, "reply-to:"+msg.ReplyTo)
 	}
+	for _, a := range msg.Attachments {
+		labels = append(labels, a.label())
+	}
 	for _, cc := range msg.CC {
 		ccIdentity := AddressToIdentity(cc)
 		labels = append(labels, "cc:"+ccIdentity)
@@ -752,7 +800,7 @@ func (r *Router) sendToAnnounce(msg *Message) error {
 		}
 	}
 
-	// Build labels for from/thread/reply-to/cc plus announce metadata
+	// Build labels for from/thread/reply-to/attachments/cc plus announce metadata
 	var labels []string
 	labels = append(labels, "from:"+msg.From)
 	labels = append(labels, "announce:"+announceName)
@@ -762,6 +810,9 @@ func (r *Router) sendToAnnounce(msg *Message) error {
 	if msg.ReplyTo != "" {
 		labels = append(labels, "reply-to:"+msg.ReplyTo)
 	}
+	for _, a := range msg.Attachments {
+		labels = append(labels, a.label())
+	}
 	for _, cc := range msg.CC {
 		ccIdentity := AddressToIdentity(cc)
 		labels = append(labels, "cc:"+ccIdentity)
@@ -825,7 +876,7 @@ func (r *Router) sendToChannel(msg *Message) error {
 		return fmt.Errorf("channel %s is closed", channelName)
 	}
 
-	// Build labels for from/thread/reply-to/cc plus channel metadata
+	// Build labels for from/thread/reply-to/attachments/cc plus channel metadata
 	var labels []string
 	labels = append(labels, "from:"+msg.From)
 	labels = append(labels, "channel:"+channelName)
@@ -835,6 +886,9 @@ func (r *Router) sendToChannel(msg *Message) error {
 	if msg.ReplyTo != "" {
 		labels = append(labels, "reply-to:"+msg.ReplyTo)
 	}
+	for _, a := range msg.Attachments {
+		labels = append(labels, a.label())
+	}
 	for _, cc := range msg.CC {
 		ccIdentity := AddressToIdentity(cc)
 		labels = append(labels, "cc:"+ccIdentity)
@@ -961,6 +1015,12 @@ func (r *Router) GetMailbox(address string) (*Mailbox, error) {
 	return NewMailboxFromAddress(address, workDir), nil
 }
 
+// Attachments returns the store holding the payloads of files attached to
+// mail, which lives beside the town's mail beads.
+func (r *Router) Attachments() *AttachmentStore {
+	return NewAttachmentStore(filepath.Join(r.resolveBeadsDir(""), "attachments"))
+}
+
 // notifyRecipient sends a notification to a recipient's tmux session.
 // Uses NudgeSession to add the notification to the agent's conversation history.
 // Supports mayor/, rig/polecat, and rig/refinery addresses.
@@ -977,8 +1037,7 @@ func (r *Router) notifyRecipient(msg *Message) error {
 	}
 
 	// Send notification to the agent's conversation history
-	notification := fmt.Sprintf("📬 You have new mail from %s. Subject: %s. Run 'gt mail inbox' to read.", msg.From, msg.Subject)
-	return r.tmux.NudgeSession(sessionID, notification)
+	return r.tmux.NudgeSession(sessionID, NoticeText(msg))
 }
 
 // addressToSessionID converts a mail address to a tmux session ID.
@@ -0,0 +1,245 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// ErrUnknownRecipient is returned for an agent address with no workspace in
+// the town.
+var ErrUnknownRecipient = errors.New("unknown recipient")
+
+// Route is where mail for one agent address is delivered.
+type Route struct {
+	Address string `json:"address"`       // canonical address (e.g., "gastown/crew/max")
+	Role    string `json:"role"`          // mayor, deacon, witness, refinery, crew, polecat
+	Rig     string `json:"rig,omitempty"` // empty for town-level agents
+	Name    string `json:"name,omitempty"`
+	WorkDir string `json:"work_dir"`
+}
+
+// MailDir returns the route's local JSONL mailbox directory, used by crew
+// workers' legacy inboxes.
+func (r *Route) MailDir() string {
+	return filepath.Join(r.WorkDir, "mail")
+}
+
+// SessionName returns the tmux session the route's agent runs in.
+func (r *Route) SessionName() string {
+	switch r.Role {
+	case "mayor":
+		return session.MayorSessionName()
+	case "deacon":
+		return session.DeaconSessionName()
+	case "witness":
+		return session.WitnessSessionName(r.Rig)
+	case "refinery":
+		return session.RefinerySessionName(r.Rig)
+	case "crew":
+		return session.CrewSessionName(r.Rig, r.Name)
+	case "polecat":
+		return session.PolecatSessionName(r.Rig, r.Name)
+	}
+	return ""
+}
+
+// RoutingTable maps agent addresses to their workspaces across the town. It
+// is built from mayor/rigs.json and each rig's crew and polecat directories,
+// so callers don't need to know where any agent lives on disk.
+type RoutingTable struct {
+	townRoot string
+	rigs     map[string]bool
+	routes   map[string]*Route // canonical address -> route
+	aliases  map[string]string // short "rig/name" form -> canonical address
+}
+
+// LoadRoutingTable builds the routing table for a town.
+func LoadRoutingTable(townRoot string) (*RoutingTable, error) {
+	rigsCfg, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return nil, fmt.Errorf("loading rigs config: %w", err)
+	}
+
+	t := &RoutingTable{
+		townRoot: townRoot,
+		rigs:     make(map[string]bool),
+		routes:   make(map[string]*Route),
+		aliases:  make(map[string]string),
+	}
+	t.add(&Route{Address: "mayor/", Role: "mayor", WorkDir: filepath.Join(townRoot, "mayor")}, "")
+	t.add(&Route{Address: "deacon/", Role: "deacon", WorkDir: filepath.Join(townRoot, "deacon")}, "")
+
+	for rigName := range rigsCfg.Rigs {
+		t.rigs[rigName] = true
+		rigPath := filepath.Join(townRoot, rigName)
+		t.add(&Route{Address: rigName + "/witness", Role: "witness", Rig: rigName,
+			WorkDir: filepath.Join(rigPath, "witness")}, "")
+		t.add(&Route{Address: rigName + "/refinery", Role: "refinery", Rig: rigName,
+			WorkDir: filepath.Join(rigPath, "refinery", "rig")}, "")
+
+		// Crew first, so a polecat of the same name takes the short alias,
+		// matching AddressToIdentity's reading of "rig/name".
+		for _, name := range listDirs(filepath.Join(rigPath, "crew")) {
+			t.add(&Route{Address: rigName + "/crew/" + name, Role: "crew", Rig: rigName, Name: name,
+				WorkDir: filepath.Join(rigPath, "crew", name)}, rigName+"/"+name)
+		}
+		for _, name := range listDirs(filepath.Join(rigPath, "polecats")) {
+			workDir := filepath.Join(rigPath, "polecats", name, rigName)
+			if info, err := os.Stat(workDir); err != nil || !info.IsDir() {
+				workDir = filepath.Join(rigPath, "polecats", name) // old layout
+			}
+			t.add(&Route{Address: rigName + "/polecats/" + name, Role: "polecat", Rig: rigName, Name: name,
+				WorkDir: workDir}, rigName+"/"+name)
+		}
+	}
+	return t, nil
+}
+
+func (t *RoutingTable) add(r *Route, alias string) {
+	t.routes[r.Address] = r
+	if alias != "" {
+		t.aliases[alias] = r.Address
+	}
+}
+
+// listDirs returns the names of dir's visible subdirectories.
+func listDirs(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+// Lookup returns the route for an agent address in any of its accepted
+// forms ("mayor", "rig/witness", "rig/crew/max", "rig/Toast", ...).
+func (t *RoutingTable) Lookup(address string) (*Route, error) {
+	addr := strings.TrimSpace(address)
+	switch addr {
+	case "mayor", "deacon":
+		addr += "/"
+	}
+	if addr != "mayor/" && addr != "deacon/" {
+		addr = strings.TrimSuffix(addr, "/")
+	}
+	if r, ok := t.routes[addr]; ok {
+		return r, nil
+	}
+	if canonical, ok := t.aliases[addr]; ok {
+		return t.routes[canonical], nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownRecipient, address)
+}
+
+// Routes returns every route, sorted by address.
+func (t *RoutingTable) Routes() []*Route {
+	routes := make([]*Route, 0, len(t.routes))
+	for _, r := range t.routes {
+		routes = append(routes, r)
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].Address < routes[j].Address })
+	return routes
+}
+
+// Match returns the canonical addresses of the agents matching a wildcard
+// pattern, sorted. "<rig>/*" means every agent in the rig; otherwise '*'
+// matches one path segment of the canonical or short form ("*/witness",
+// "gastown/crew/*", "gastown/polecats/*").
+func (t *RoutingTable) Match(pattern string) []string {
+	var addrs []string
+	for _, r := range t.Routes() {
+		if matchRoute(pattern, r) {
+			addrs = append(addrs, r.Address)
+		}
+	}
+	return addrs
+}
+
+func matchRoute(pattern string, r *Route) bool {
+	if rig, rest, ok := strings.Cut(pattern, "/"); ok && rest == "*" {
+		return r.Rig != "" && (rig == "*" || rig == r.Rig)
+	}
+	if matchPattern(pattern, r.Address) {
+		return true
+	}
+	return r.Name != "" && matchPattern(pattern, r.Rig+"/"+r.Name)
+}
+
+// Mailbox returns the local JSONL mailbox of the agent at address.
+func (t *RoutingTable) Mailbox(address string) (*Mailbox, error) {
+	r, err := t.Lookup(address)
+	if err != nil {
+		return nil, err
+	}
+	return NewMailbox(r.MailDir()), nil
+}
+
+// ValidateAddress checks that mail can be routed to address. Lists,
+// queues, announces, channels, groups, and the overseer are accepted as-is
+// (they are checked when expanded), as are town-level agents under mayor/
+// and deacon/. Rig addresses must name the rig itself or an agent with a
+// workspace in it.
+func (t *RoutingTable) ValidateAddress(address string) error {
+	if err := validateAddressSyntax(address); err != nil {
+		return err
+	}
+	if !isAgentAddress(address) {
+		return nil
+	}
+	first, rest, _ := strings.Cut(strings.TrimSuffix(address, "/"), "/")
+	switch {
+	case first == "mayor" || first == "deacon":
+		return nil
+	case !t.rigs[first]:
+		return fmt.Errorf("%w: %s (no rig or town agent named %q)", ErrUnknownRecipient, address, first)
+	case rest == "":
+		return nil // the whole rig
+	}
+	_, err := t.Lookup(address)
+	return err
+}
+
+// isAgentAddress reports whether address names a single agent rather than
+// a fan-out or broadcast target.
+func isAgentAddress(address string) bool {
+	switch {
+	case address == "overseer", address == "human",
+		isListAddress(address), isQueueAddress(address),
+		isAnnounceAddress(address), isChannelAddress(address),
+		isGroupAddress(address):
+		return false
+	}
+	return true
+}
+
+// validateAddressSyntax rejects addresses no router could deliver to.
+func validateAddressSyntax(address string) error {
+	if strings.TrimSpace(address) == "" {
+		return errors.New("empty address")
+	}
+	if strings.ContainsAny(address, " \t\n,") {
+		return fmt.Errorf("invalid address %q: contains whitespace or commas", address)
+	}
+	if !isAgentAddress(address) {
+		return nil
+	}
+	for _, p := range strings.Split(strings.TrimSuffix(address, "/"), "/") {
+		if p == "" || p == "." || p == ".." {
+			return fmt.Errorf("invalid address %q: empty or relative path segment", address)
+		}
+	}
+	return nil
+}
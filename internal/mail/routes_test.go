@@ -0,0 +1,123 @@
+package mail
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupRoutingTown lays out a town with one rig holding a crew worker, a
+// new-layout polecat, and an old-layout polecat.
+func setupRoutingTown(t *testing.T) string {
+	t.Helper()
+	town := t.TempDir()
+	for _, dir := range []string{
+		"mayor",
+		"gastown/crew/max",
+		"gastown/crew/Toast",
+		"gastown/polecats/Toast/gastown",
+		"gastown/polecats/Nux",
+	} {
+		if err := os.MkdirAll(filepath.Join(town, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rigs := `{"version": 1, "rigs": {"gastown": {"git_url": "https://example.com/gastown.git"}}}`
+	if err := os.WriteFile(filepath.Join(town, "mayor", "rigs.json"), []byte(rigs), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return town
+}
+
+func TestRoutingTableLookup(t *testing.T) {
+	town := setupRoutingTown(t)
+	table, err := LoadRoutingTable(town)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		address string
+		want    string
+		workDir string
+	}{
+		{"mayor", "mayor/", "mayor"},
+		{"deacon/", "deacon/", "deacon"},
+		{"gastown/witness", "gastown/witness", "gastown/witness"},
+		{"gastown/refinery/", "gastown/refinery", "gastown/refinery/rig"},
+		{"gastown/crew/max", "gastown/crew/max", "gastown/crew/max"},
+		{"gastown/max", "gastown/crew/max", "gastown/crew/max"},
+		{"gastown/polecats/Toast", "gastown/polecats/Toast", "gastown/polecats/Toast/gastown"},
+		{"gastown/Toast", "gastown/polecats/Toast", "gastown/polecats/Toast/gastown"}, // polecat wins the short form
+		{"gastown/Nux", "gastown/polecats/Nux", "gastown/polecats/Nux"},
+	}
+	for _, tt := range tests {
+		r, err := table.Lookup(tt.address)
+		if err != nil {
+			t.Errorf("Lookup(%q): %v", tt.address, err)
+			continue
+		}
+		if r.Address != tt.want || r.WorkDir != filepath.Join(town, tt.workDir) {
+			t.Errorf("Lookup(%q) = %s at %s, want %s at %s", tt.address, r.Address, r.WorkDir, tt.want, tt.workDir)
+		}
+	}
+
+	if _, err := table.Lookup("gastown/ghost"); !errors.Is(err, ErrUnknownRecipient) {
+		t.Errorf("Lookup(gastown/ghost) = %v, want ErrUnknownRecipient", err)
+	}
+	if mb, err := table.Mailbox("gastown/max"); err != nil || mb.Path() != filepath.Join(town, "gastown/crew/max/mail/inbox.jsonl") {
+		t.Errorf("Mailbox(gastown/max) = %v, %v", mb, err)
+	}
+}
+
+func TestRoutingTableValidateAddress(t *testing.T) {
+	table, err := LoadRoutingTable(setupRoutingTown(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := []string{
+		"mayor/", "deacon", "overseer", "gastown", "gastown/", "gastown/witness",
+		"gastown/crew/max", "gastown/Toast", "deacon/dogs/alpha",
+		"list:oncall", "queue:work", "announce:alerts", "channel:ops", "@town",
+	}
+	for _, addr := range valid {
+		if err := table.ValidateAddress(addr); err != nil {
+			t.Errorf("ValidateAddress(%q) = %v, want nil", addr, err)
+		}
+	}
+
+	invalid := []string{"", "gastown/ghost", "nosuchrig/Toast", "max", "gastown//Toast", "gastown/../mayor", "a b"}
+	for _, addr := range invalid {
+		if err := table.ValidateAddress(addr); err == nil {
+			t.Errorf("ValidateAddress(%q) = nil, want an error", addr)
+		}
+	}
+}
+
+func TestRoutingTableMatch(t *testing.T) {
+	town := setupRoutingTown(t)
+	table, err := LoadRoutingTable(town)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"gastown/*", "gastown/crew/Toast gastown/crew/max gastown/polecats/Nux gastown/polecats/Toast gastown/refinery gastown/witness"},
+		{"*/*", "gastown/crew/Toast gastown/crew/max gastown/polecats/Nux gastown/polecats/Toast gastown/refinery gastown/witness"},
+		{"gastown/crew/*", "gastown/crew/Toast gastown/crew/max"},
+		{"gastown/polecats/*", "gastown/polecats/Nux gastown/polecats/Toast"},
+		{"*/witness", "gastown/witness"},
+		{"other/*", ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(table.Match(tt.pattern), " "); got != tt.want {
+			t.Errorf("Match(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package mail
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// ErrUnknownTemplate is returned for a template name not in the registry.
+var ErrUnknownTemplate = errors.New("unknown mail template")
+
+// Template is a structured message form. Rendering it produces a subject
+// with the template's type prefix and a body that starts with one
+// "Key: value" line per field, so the message can be parsed downstream
+// (see ParseTemplateFields).
+type Template struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Subject     string          `json:"subject"` // text/template over the field values
+	Type        MessageType     `json:"type"`
+	Priority    Priority        `json:"priority"`
+	Fields      []TemplateField `json:"fields"`
+}
+
+// TemplateField is one variable of a template.
+type TemplateField struct {
+	Name        string `json:"name"` // variable name, as given to --var
+	Required    bool   `json:"required"`
+	Description string `json:"description"`
+}
+
+// Key returns the field's header key in a rendered body: the name in
+// title case ("next-steps" → "Next-Steps").
+func (f TemplateField) Key() string {
+	parts := strings.Split(f.Name, "-")
+	for i, p := range parts {
+		if p != "" {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// templates is the built-in registry, keyed by name.
+var templates = map[string]*Template{
+	"handoff": {
+		Name:        "handoff",
+		Description: "Session handoff to a successor (or yourself)",
+		Subject:     "🤝 HANDOFF: {{.issue}}{{with .status}} ({{.}}){{end}}",
+		Type:        TypeNotification,
+		Priority:    PriorityNormal,
+		Fields: []TemplateField{
+			{Name: "issue", Required: true, Description: "Issue being worked on"},
+			{Name: "status", Description: "Where things stand"},
+			{Name: "branch", Description: "Git branch with the work"},
+			{Name: "next", Description: "What the successor should do next"},
+		},
+	},
+	"escalation": {
+		Name:        "escalation",
+		Description: "Ask for help with blocked or failing work",
+		Subject:     "ESCALATION: {{.issue}} {{.problem}}",
+		Type:        TypeTask,
+		Priority:    PriorityHigh,
+		Fields: []TemplateField{
+			{Name: "issue", Required: true, Description: "Affected issue"},
+			{Name: "problem", Required: true, Description: "What is wrong"},
+			{Name: "tried", Description: "What was already attempted"},
+			{Name: "severity", Description: "low, medium, high, or critical"},
+		},
+	},
+	"review-request": {
+		Name:        "review-request",
+		Description: "Ask for a review of finished work",
+		Subject:     "REVIEW_REQUEST: {{.issue}}{{with .branch}} on {{.}}{{end}}",
+		Type:        TypeTask,
+		Priority:    PriorityNormal,
+		Fields: []TemplateField{
+			{Name: "issue", Required: true, Description: "Issue the work closes"},
+			{Name: "branch", Description: "Branch to review"},
+			{Name: "pr", Description: "Pull request URL"},
+			{Name: "focus", Description: "What the reviewer should look at"},
+		},
+	},
+	"status-report": {
+		Name:        "status-report",
+		Description: "Progress report on an issue",
+		Subject:     "STATUS: {{.issue}} {{.status}}",
+		Type:        TypeNotification,
+		Priority:    PriorityNormal,
+		Fields: []TemplateField{
+			{Name: "issue", Required: true, Description: "Issue reported on"},
+			{Name: "status", Required: true, Description: "on-track, at-risk, blocked, or done"},
+			{Name: "progress", Description: "What was done since the last report"},
+			{Name: "blockers", Description: "What is in the way"},
+			{Name: "next", Description: "What happens next"},
+		},
+	},
+}
+
+// LookupTemplate returns the template with the given name.
+func LookupTemplate(name string) (*Template, error) {
+	t, ok := templates[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s (available: %s)", ErrUnknownTemplate, name, strings.Join(TemplateNames(), ", "))
+	}
+	return t, nil
+}
+
+// TemplateNames returns the names of the registered templates, sorted.
+func TemplateNames() []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseTemplateVars parses "name=value" pairs as given to --var.
+func ParseTemplateVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid --var %q: want name=value", pair)
+		}
+		vars[strings.TrimSpace(name)] = value
+	}
+	return vars, nil
+}
+
+// Render fills the template with vars and returns the subject and body.
+// Any free-form text is appended to the body after the field lines.
+// Unknown variables and missing required ones are errors.
+func (t *Template) Render(vars map[string]string, text string) (subject, body string, err error) {
+	known := make(map[string]bool, len(t.Fields))
+	for _, f := range t.Fields {
+		known[f.Name] = true
+		if f.Required && strings.TrimSpace(vars[f.Name]) == "" {
+			return "", "", fmt.Errorf("template %s: missing required variable %q", t.Name, f.Name)
+		}
+	}
+	for name := range vars {
+		if !known[name] {
+			return "", "", fmt.Errorf("template %s: unknown variable %q", t.Name, name)
+		}
+		if strings.ContainsAny(vars[name], "\r\n") {
+			return "", "", fmt.Errorf("template %s: variable %q must be a single line", t.Name, name)
+		}
+	}
+
+	tmpl, err := template.New(t.Name).Option("missingkey=zero").Parse(t.Subject)
+	if err != nil {
+		return "", "", fmt.Errorf("template %s: %w", t.Name, err)
+	}
+	data := make(map[string]string, len(t.Fields))
+	for _, f := range t.Fields {
+		data[f.Name] = vars[f.Name]
+	}
+	var s strings.Builder
+	if err := tmpl.Execute(&s, data); err != nil {
+		return "", "", fmt.Errorf("template %s: %w", t.Name, err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Template: %s\n", t.Name)
+	for _, f := range t.Fields {
+		if v := vars[f.Name]; v != "" {
+			fmt.Fprintf(&b, "%s: %s\n", f.Key(), v)
+		}
+	}
+	if text = strings.TrimSpace(text); text != "" {
+		b.WriteString("\n")
+		b.WriteString(text)
+		b.WriteString("\n")
+	}
+	return strings.Join(strings.Fields(s.String()), " "), b.String(), nil
+}
+
+// ParseTemplateFields reads the fields of a body rendered from a template.
+// It returns the template name and the field values keyed by variable name,
+// or "" and nil if the body wasn't rendered from a known template.
+func ParseTemplateFields(body string) (string, map[string]string) {
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	if !scanner.Scan() {
+		return "", nil
+	}
+	name, ok := strings.CutPrefix(scanner.Text(), "Template: ")
+	if !ok {
+		return "", nil
+	}
+	t, ok := templates[strings.TrimSpace(name)]
+	if !ok {
+		return "", nil
+	}
+	byKey := make(map[string]string, len(t.Fields))
+	for _, f := range t.Fields {
+		byKey[f.Key()] = f.Name
+	}
+
+	fields := make(map[string]string)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ": ")
+		if !ok {
+			break // blank line or free-form text ends the fields
+		}
+		if field, ok := byKey[key]; ok {
+			fields[field] = value
+		}
+	}
+	return t.Name, fields
+}
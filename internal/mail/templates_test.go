@@ -0,0 +1,76 @@
+package mail
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTemplateRenderRoundTrip(t *testing.T) {
+	tmpl, err := LookupTemplate("handoff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vars, err := ParseTemplateVars([]string{"issue=gt-123", "next=finish the a=b parser", "status=tests failing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, body, err := tmpl.Render(vars, "Context is in the design doc.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != "🤝 HANDOFF: gt-123 (tests failing)" {
+		t.Errorf("subject = %q", subject)
+	}
+	want := "Template: handoff\nIssue: gt-123\nStatus: tests failing\nNext: finish the a=b parser\n\nContext is in the design doc.\n"
+	if body != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+
+	name, fields := ParseTemplateFields(body)
+	if name != "handoff" || fields["issue"] != "gt-123" || fields["next"] != "finish the a=b parser" || len(fields) != 3 {
+		t.Errorf("ParseTemplateFields = %q, %v", name, fields)
+	}
+}
+
+func TestTemplateRenderOptionalSubjectParts(t *testing.T) {
+	tmpl, _ := LookupTemplate("review-request")
+	subject, _, err := tmpl.Render(map[string]string{"issue": "gt-9"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != "REVIEW_REQUEST: gt-9" {
+		t.Errorf("subject = %q", subject)
+	}
+}
+
+func TestTemplateRenderErrors(t *testing.T) {
+	tmpl, _ := LookupTemplate("escalation")
+	tests := []struct {
+		name string
+		vars map[string]string
+		want string
+	}{
+		{"missing required", map[string]string{"issue": "gt-1"}, `missing required variable "problem"`},
+		{"unknown variable", map[string]string{"issue": "gt-1", "problem": "x", "owner": "me"}, `unknown variable "owner"`},
+		{"multi-line value", map[string]string{"issue": "gt-1", "problem": "x\nTried: spoofed"}, "single line"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tmpl.Render(tt.vars, "")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Render error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := LookupTemplate("memo"); !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("LookupTemplate(memo) error = %v, want ErrUnknownTemplate", err)
+	}
+	if _, err := ParseTemplateVars([]string{"novalue"}); err == nil {
+		t.Error("ParseTemplateVars accepted a pair without '='")
+	}
+	if name, fields := ParseTemplateFields("Just a note"); name != "" || fields != nil {
+		t.Errorf("ParseTemplateFields(free-form) = %q, %v", name, fields)
+	}
+}
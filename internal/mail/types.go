@@ -44,6 +44,21 @@ const (
 	TypeReply MessageType = "reply"
 )
 
+// MessageState is where a message is in the recipient's hands.
+type MessageState string
+
+const (
+	// StateUnread means the recipient hasn't read the message.
+	StateUnread MessageState = "unread"
+
+	// StateRead means the message was read (or archived) but not acknowledged.
+	StateRead MessageState = "read"
+
+	// StateAcked means the recipient confirmed acting on the message, e.g.
+	// picking up a handoff.
+	StateAcked MessageState = "acked"
+)
+
 // Delivery specifies how a message is delivered to the recipient.
 type Delivery string
 
@@ -81,6 +96,12 @@ type Message struct {
 	// Read indicates if the message has been read (closed in beads).
 	Read bool `json:"read"`
 
+	// Acked indicates the recipient acknowledged the message (see Mailbox.Ack).
+	Acked bool `json:"acked,omitempty"`
+
+	// AckedAt is when the message was acknowledged (legacy mailboxes only).
+	AckedAt *time.Time `json:"acked_at,omitempty"`
+
 	// Priority is the message priority.
 	Priority Priority `json:"priority"`
 
@@ -94,9 +115,25 @@ type Message struct {
 	// ThreadID groups related messages into a conversation thread.
 	ThreadID string `json:"thread_id,omitempty"`
 
-	// ReplyTo is the ID of the message this is replying to.
+	// ReplyTo is the ID of the message this is replying to (In-Reply-To).
 	ReplyTo string `json:"reply_to,omitempty"`
 
+	// References lists the IDs of the messages earlier in the reply chain,
+	// oldest first, ending with ReplyTo - like an email References header.
+	References []string `json:"references,omitempty"`
+
+	// Attachments references files sent with the message; their payloads
+	// are kept in the mailbox's AttachmentStore.
+	Attachments []Attachment `json:"attachments,omitempty"`
+
+	// BroadcastID is shared by every copy of a message fanned out to many
+	// recipients (a group or wildcard address), so copies can be recognized
+	// as one message.
+	BroadcastID string `json:"broadcast_id,omitempty"`
+
+	// Bead is the work bead tracking a high-priority message (see LinkWork).
+	Bead string `json:"bead,omitempty"`
+
 	// Pinned marks the message as pinned (won't be auto-archived).
 	Pinned bool `json:"pinned,omitempty"`
 
@@ -144,20 +181,29 @@ func NewMessage(from, to, subject, body string) *Message {
 // NewReplyMessage creates a reply message that inherits the thread from the original.
 func NewReplyMessage(from, to, subject, body string, original *Message) *Message {
 	return &Message{
-		ID:        generateID(),
-		From:      from,
-		To:        to,
-		Subject:   subject,
-		Body:      body,
-		Timestamp: time.Now(),
-		Read:      false,
-		Priority:  PriorityNormal,
-		Type:      TypeReply,
-		ThreadID:  original.ThreadID,
-		ReplyTo:   original.ID,
+		ID:         generateID(),
+		From:       from,
+		To:         to,
+		Subject:    subject,
+		Body:       body,
+		Timestamp:  time.Now(),
+		Read:       false,
+		Priority:   PriorityNormal,
+		Type:       TypeReply,
+		ThreadID:   original.ThreadID,
+		ReplyTo:    original.ID,
+		References: ReplyReferences(original),
 	}
 }
 
+// ReplyReferences returns the References of a reply to original: the
+// original's own references followed by the original.
+func ReplyReferences(original *Message) []string {
+	refs := make([]string, 0, len(original.References)+1)
+	refs = append(refs, original.References...)
+	return append(refs, original.ID)
+}
+
 // NewQueueMessage creates a message destined for a queue.
 // Queue messages have no direct recipient - they are claimed by eligible agents.
 func NewQueueMessage(from, queue, subject, body string) *Message {
@@ -192,6 +238,17 @@ func NewChannelMessage(from, channel, subject, body string) *Message {
 	}
 }
 
+// State returns the message's state: acked, read, or unread.
+func (m *Message) State() MessageState {
+	switch {
+	case m.Acked:
+		return StateAcked
+	case m.Read:
+		return StateRead
+	}
+	return StateUnread
+}
+
 // IsQueueMessage returns true if this is a queue-routed message.
 func (m *Message) IsQueueMessage() bool {
 	return m.Queue != ""
@@ -276,7 +333,7 @@ type BeadsMessage struct {
 	Priority    int       `json:"priority"`    // 0=urgent, 1=high, 2=normal, 3=low
 	Status      string    `json:"status"`      // open=unread, closed=read
 	CreatedAt   time.Time `json:"created_at"`
-	Labels      []string  `json:"labels"` // Metadata labels (from:X, thread:X, reply-to:X, msg-type:X, cc:X, queue:X, channel:X, claimed-by:X, claimed-at:X)
+	Labels      []string  `json:"labels"` // Metadata labels (from:X, thread:X, reply-to:X, ref:X, attach:X, broadcast:X, bead:X, read, acked, msg-type:X, cc:X, queue:X, channel:X, claimed-by:X, claimed-at:X)
 	Pinned      bool      `json:"pinned,omitempty"`
 	Wisp        bool      `json:"wisp,omitempty"` // Ephemeral message (filtered from JSONL export)
 
@@ -284,6 +341,10 @@ type BeadsMessage struct {
 	sender    string
 	threadID  string
 	replyTo   string
+	refs      []string // reply chain, oldest first
+	attach    []Attachment
+	broadcast string
+	bead      string
 	msgType   string
 	cc        []string   // CC recipients
 	queue     string     // Queue name (for queue messages)
@@ -301,6 +362,16 @@ func (bm *BeadsMessage) ParseLabels() {
 			bm.threadID = strings.TrimPrefix(label, "thread:")
 		} else if strings.HasPrefix(label, "reply-to:") {
 			bm.replyTo = strings.TrimPrefix(label, "reply-to:")
+		} else if strings.HasPrefix(label, "ref:") {
+			bm.refs = append(bm.refs, strings.TrimPrefix(label, "ref:"))
+		} else if strings.HasPrefix(label, "attach:") {
+			if a, ok := parseAttachmentLabel(strings.TrimPrefix(label, "attach:")); ok {
+				bm.attach = append(bm.attach, a)
+			}
+		} else if strings.HasPrefix(label, "broadcast:") {
+			bm.broadcast = strings.TrimPrefix(label, "broadcast:")
+		} else if strings.HasPrefix(label, "bead:") {
+			bm.bead = strings.TrimPrefix(label, "bead:")
 		} else if strings.HasPrefix(label, "msg-type:") {
 			bm.msgType = strings.TrimPrefix(label, "msg-type:")
 		} else if strings.HasPrefix(label, "cc:") {
@@ -367,23 +438,28 @@ func (bm *BeadsMessage) ToMessage() *Message {
 	}
 
 	return &Message{
-		ID:        bm.ID,
-		From:      identityToAddress(bm.sender),
-		To:        identityToAddress(bm.Assignee),
-		Subject:   bm.Title,
-		Body:      bm.Description,
-		Timestamp: bm.CreatedAt,
-		Read:      bm.Status == "closed" || bm.HasLabel("read"),
-		Priority:  priority,
-		Type:      msgType,
-		ThreadID:  bm.threadID,
-		ReplyTo:   bm.replyTo,
-		Wisp:      bm.Wisp,
-		CC:        ccAddrs,
-		Queue:     bm.queue,
-		Channel:   bm.channel,
-		ClaimedBy: bm.claimedBy,
-		ClaimedAt: bm.claimedAt,
+		ID:          bm.ID,
+		From:        identityToAddress(bm.sender),
+		To:          identityToAddress(bm.Assignee),
+		Subject:     bm.Title,
+		Body:        bm.Description,
+		Timestamp:   bm.CreatedAt,
+		Read:        bm.Status == "closed" || bm.HasLabel("read") || bm.HasLabel("acked"),
+		Acked:       bm.HasLabel("acked"),
+		Priority:    priority,
+		Type:        msgType,
+		ThreadID:    bm.threadID,
+		ReplyTo:     bm.replyTo,
+		References:  bm.refs,
+		Attachments: bm.attach,
+		BroadcastID: bm.broadcast,
+		Bead:        bm.bead,
+		Wisp:        bm.Wisp,
+		CC:          ccAddrs,
+		Queue:       bm.queue,
+		Channel:     bm.channel,
+		ClaimedBy:   bm.claimedBy,
+		ClaimedAt:   bm.claimedAt,
 	}
 }
 
@@ -495,6 +571,7 @@ func ParseMessageType(s string) MessageType {
 //
 // Addresses use slash format:
 //   - "overseer" → "overseer" (human operator, no trailing slash)
+//   - "human" → "overseer" (alias)
 //   - "mayor/" → "mayor/"
 //   - "mayor" → "mayor/"
 //   - "deacon/" → "deacon/"
@@ -506,7 +583,7 @@ func ParseMessageType(s string) MessageType {
 //   - "gastown/" → "gastown" (rig broadcast)
 func AddressToIdentity(address string) string {
 	// Overseer (human operator) - no trailing slash, distinct from agents
-	if address == "overseer" {
+	if address == "overseer" || address == "human" {
 		return "overseer"
 	}
 
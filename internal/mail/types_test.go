@@ -1,6 +1,7 @@
 package mail
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -16,6 +17,10 @@ func TestAddressToIdentity(t *testing.T) {
 		{"deacon", "deacon/"},
 		{"deacon/", "deacon/"},
 
+		// The overseer and its alias
+		{"overseer", "overseer"},
+		{"human", "overseer"},
+
 		// Rig-level agents: crew/ and polecats/ normalized to canonical form
 		{"gastown/polecats/Toast", "gastown/Toast"},
 		{"gastown/crew/max", "gastown/max"},
@@ -221,6 +226,20 @@ func TestNewReplyMessage(t *testing.T) {
 	}
 }
 
+func TestNewReplyMessageReferences(t *testing.T) {
+	first := &Message{ID: "msg-1", ThreadID: "thread-1"}
+	second := NewReplyMessage("b", "a", "Re: x", "", first)
+	second.ID = "msg-2"
+	third := NewReplyMessage("a", "b", "Re: x", "", second)
+
+	if got := strings.Join(third.References, ","); got != "msg-1,msg-2" {
+		t.Errorf("References = %q, want msg-1,msg-2", got)
+	}
+	if got := strings.Join(second.References, ","); got != "msg-1" {
+		t.Errorf("first reply's References changed to %q", got)
+	}
+}
+
 func TestBeadsMessageToMessage(t *testing.T) {
 	now := time.Now()
 	bm := BeadsMessage{
@@ -281,6 +300,19 @@ func TestBeadsMessageToMessageWithReplyTo(t *testing.T) {
 	}
 }
 
+func TestBeadsMessageToMessageWithReferences(t *testing.T) {
+	bm := BeadsMessage{
+		ID:     "hq-reply2",
+		Labels: []string{"from:mayor/", "reply-to:msg-2", "ref:msg-1", "ref:msg-2"},
+	}
+
+	msg := bm.ToMessage()
+
+	if got := strings.Join(msg.References, ","); got != "msg-1,msg-2" {
+		t.Errorf("References = %q, want msg-1,msg-2", got)
+	}
+}
+
 func TestBeadsMessageToMessagePriorities(t *testing.T) {
 	tests := []struct {
 		priority int
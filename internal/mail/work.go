@@ -0,0 +1,97 @@
+package mail
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// WorkPriority is the lowest priority at which mail is tracked as work.
+const WorkPriority = PriorityHigh
+
+// TracksWork reports whether mail of priority p is tracked as a bead.
+func TracksWork(p Priority) bool {
+	return PriorityToBeads(p) <= PriorityToBeads(WorkPriority)
+}
+
+// WorkTracker is the beads access LinkWork needs; *beads.Beads implements
+// it.
+type WorkTracker interface {
+	Show(id string) (*beads.Issue, error)
+	Create(opts beads.CreateOptions) (*beads.Issue, error)
+	Update(id string, opts beads.UpdateOptions) error
+}
+
+// WorkLink reports the bead a message was linked to.
+type WorkLink struct {
+	BeadID  string `json:"bead_id"`
+	Created bool   `json:"created,omitempty"` // a new bead was created
+	Bumped  bool   `json:"bumped,omitempty"`  // an existing bead's priority was raised
+}
+
+// LinkWork ties msg to a bead assigned to its recipient, so urgent
+// requests show up in the work tracker rather than only in an inbox.
+//
+// If existing names an open bead, that bead is linked: its priority is
+// raised to the message's if lower, and it is assigned to the recipient if
+// unassigned. Otherwise a task bead is created for the recipient. The bead
+// is labeled with the message's thread, and msg.Bead is set so the message
+// carries the link back.
+//
+// trackerFor returns the beads database holding an existing bead ID, or,
+// for "", the one new beads for the recipient belong in.
+func LinkWork(trackerFor func(beadID string) WorkTracker, msg *Message, existing string) (*WorkLink, error) {
+	priority := PriorityToBeads(msg.Priority)
+	threadLabel := "mail-thread:" + msg.ThreadID
+
+	if existing != "" {
+		t := trackerFor(existing)
+		issue, err := t.Show(existing)
+		if err != nil {
+			return nil, fmt.Errorf("looking up bead %s: %w", existing, err)
+		}
+		if issue.Status != "closed" {
+			opts := beads.UpdateOptions{AddLabels: []string{threadLabel}}
+			link := &WorkLink{BeadID: issue.ID}
+			if issue.Priority > priority {
+				opts.Priority = &priority
+				link.Bumped = true
+			}
+			if issue.Assignee == "" {
+				assignee := msg.To
+				opts.Assignee = &assignee
+			}
+			if err := t.Update(issue.ID, opts); err != nil {
+				return nil, fmt.Errorf("updating bead %s: %w", issue.ID, err)
+			}
+			msg.Bead = issue.ID
+			return link, nil
+		}
+	}
+
+	var desc strings.Builder
+	desc.WriteString(msg.Body)
+	fmt.Fprintf(&desc, "\n\nFrom %s mail from %s (thread %s).", msg.Priority, msg.From, msg.ThreadID)
+	if existing != "" {
+		fmt.Fprintf(&desc, " Follows up %s.", existing)
+	}
+
+	t := trackerFor("")
+	issue, err := t.Create(beads.CreateOptions{
+		Title:       msg.Subject,
+		Type:        "task",
+		Priority:    priority,
+		Description: strings.TrimSpace(desc.String()),
+		Actor:       msg.From,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating bead: %w", err)
+	}
+	assignee := msg.To
+	if err := t.Update(issue.ID, beads.UpdateOptions{Assignee: &assignee, AddLabels: []string{threadLabel}}); err != nil {
+		return nil, fmt.Errorf("assigning bead %s: %w", issue.ID, err)
+	}
+	msg.Bead = issue.ID
+	return &WorkLink{BeadID: issue.ID, Created: true}, nil
+}
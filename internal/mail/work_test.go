@@ -0,0 +1,122 @@
+package mail
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// fakeTracker is an in-memory WorkTracker.
+type fakeTracker struct {
+	issues map[string]*beads.Issue
+	labels map[string][]string
+	next   int
+}
+
+func newFakeTracker(issues ...*beads.Issue) *fakeTracker {
+	t := &fakeTracker{issues: make(map[string]*beads.Issue), labels: make(map[string][]string)}
+	for _, i := range issues {
+		t.issues[i.ID] = i
+	}
+	return t
+}
+
+func (t *fakeTracker) Show(id string) (*beads.Issue, error) {
+	if i, ok := t.issues[id]; ok {
+		return i, nil
+	}
+	return nil, beads.ErrNotFound
+}
+
+func (t *fakeTracker) Create(opts beads.CreateOptions) (*beads.Issue, error) {
+	t.next++
+	i := &beads.Issue{ID: fmt.Sprintf("gt-new%d", t.next), Title: opts.Title, Priority: opts.Priority, Status: "open", Description: opts.Description}
+	t.issues[i.ID] = i
+	return i, nil
+}
+
+func (t *fakeTracker) Update(id string, opts beads.UpdateOptions) error {
+	i := t.issues[id]
+	if opts.Priority != nil {
+		i.Priority = *opts.Priority
+	}
+	if opts.Assignee != nil {
+		i.Assignee = *opts.Assignee
+	}
+	t.labels[id] = append(t.labels[id], opts.AddLabels...)
+	return nil
+}
+
+func TestTracksWork(t *testing.T) {
+	for p, want := range map[Priority]bool{PriorityUrgent: true, PriorityHigh: true, PriorityNormal: false, PriorityLow: false} {
+		if got := TracksWork(p); got != want {
+			t.Errorf("TracksWork(%s) = %v, want %v", p, got, want)
+		}
+	}
+}
+
+func TestLinkWorkCreates(t *testing.T) {
+	tracker := newFakeTracker()
+	pick := func(string) WorkTracker { return tracker }
+	msg := &Message{From: "mayor/", To: "gastown/crew/max", Subject: "Prod is down", Body: "Look now.", Priority: PriorityUrgent, ThreadID: "thread-1"}
+
+	link, err := LinkWork(pick, msg, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue := tracker.issues[link.BeadID]
+	if !link.Created || msg.Bead != link.BeadID || issue == nil {
+		t.Fatalf("link = %+v, msg.Bead = %q", link, msg.Bead)
+	}
+	if issue.Priority != 0 || issue.Assignee != "gastown/crew/max" || issue.Title != "Prod is down" {
+		t.Errorf("created bead = %+v", issue)
+	}
+	if !slices.Contains(tracker.labels[issue.ID], "mail-thread:thread-1") {
+		t.Errorf("labels = %v, want mail-thread:thread-1", tracker.labels[issue.ID])
+	}
+}
+
+func TestLinkWorkBumpsExisting(t *testing.T) {
+	tracker := newFakeTracker(
+		&beads.Issue{ID: "gt-123", Status: "open", Priority: 2},
+		&beads.Issue{ID: "gt-124", Status: "in_progress", Priority: 0, Assignee: "gastown/Toast"},
+		&beads.Issue{ID: "gt-125", Status: "closed", Priority: 2},
+	)
+	pick := func(string) WorkTracker { return tracker }
+
+	msg := &Message{From: "mayor/", To: "gastown/crew/max", Subject: "Hurry", Priority: PriorityHigh, ThreadID: "t"}
+	link, err := LinkWork(pick, msg, "gt-123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.BeadID != "gt-123" || !link.Bumped || link.Created {
+		t.Errorf("link = %+v", link)
+	}
+	if i := tracker.issues["gt-123"]; i.Priority != 1 || i.Assignee != "gastown/crew/max" {
+		t.Errorf("bumped bead = %+v", i)
+	}
+
+	// Already more urgent and assigned: linked, left alone.
+	link, err = LinkWork(pick, msg, "gt-124")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Bumped || tracker.issues["gt-124"].Priority != 0 || tracker.issues["gt-124"].Assignee != "gastown/Toast" {
+		t.Errorf("link = %+v, bead = %+v", link, tracker.issues["gt-124"])
+	}
+
+	// Closed: a follow-up bead is created.
+	link, err = LinkWork(pick, msg, "gt-125")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !link.Created || link.BeadID == "gt-125" {
+		t.Errorf("link = %+v, want a new bead", link)
+	}
+
+	if _, err := LinkWork(pick, msg, "gt-999"); err == nil {
+		t.Error("LinkWork with a missing bead succeeded")
+	}
+}
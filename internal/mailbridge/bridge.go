@@ -0,0 +1,329 @@
+// Package mailbridge connects the overseer's Gas Town mailbox to a real
+// email account: mail addressed to the overseer is forwarded over SMTP, and
+// the overseer's email replies are fetched over IMAP and delivered back into
+// the town as mail from the overseer.
+package mailbridge
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// overseerAddress is the mailbox the bridge serves.
+const overseerAddress = "overseer"
+
+// fallbackRecipient receives overseer emails that don't answer a bridged
+// message.
+const fallbackRecipient = "mayor/"
+
+// Event is one message the bridge moved, in either direction.
+type Event struct {
+	Direction string `json:"direction"` // "out" (to email), "in" (to Gas Town), or "rejected"
+	MessageID string `json:"message_id"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Subject   string `json:"subject"`
+}
+
+// Bridge forwards overseer mail to email and ingests replies.
+type Bridge struct {
+	cfg       *config.MailBridgeConfig
+	email     string // overseer's real address
+	router    *mail.Router
+	statePath string
+}
+
+// state records which overseer messages have already been emailed, so a
+// restart doesn't forward them again, and the secret reply tokens are
+// derived from.
+type state struct {
+	Forwarded map[string]time.Time `json:"forwarded"` // mail ID -> when forwarded
+	Secret    string               `json:"secret"`
+}
+
+// StatePath returns where the bridge keeps its state in a town.
+func StatePath(townRoot string) string {
+	return filepath.Join(constants.TownRuntimePath(townRoot), "mail-bridge-state.json")
+}
+
+// New creates a bridge for the town at townRoot. The overseer's email
+// defaults to the one in mayor/overseer.json.
+func New(townRoot string, cfg *config.MailBridgeConfig) (*Bridge, error) {
+	email := cfg.Email
+	if email == "" {
+		if overseer, err := config.LoadOverseerConfig(config.OverseerConfigPath(townRoot)); err == nil {
+			email = overseer.Email
+		}
+	}
+	if email == "" {
+		return nil, errors.New("no overseer email: set email in settings/mail-bridge.json or mayor/overseer.json")
+	}
+
+	return &Bridge{
+		cfg:       cfg,
+		email:     email,
+		router:    mail.NewRouterWithTownRoot(townRoot, townRoot),
+		statePath: StatePath(townRoot),
+	}, nil
+}
+
+// Email returns the overseer address mail is forwarded to.
+func (b *Bridge) Email() string {
+	return b.email
+}
+
+// Poll runs one pass in both directions and returns what moved.
+func (b *Bridge) Poll() ([]Event, error) {
+	out, err := b.Forward()
+	if err != nil {
+		return out, err
+	}
+	in, err := b.Ingest()
+	return append(out, in...), err
+}
+
+// Run polls every interval until ctx is cancelled. Errors are reported
+// through onError and don't stop the bridge.
+func (b *Bridge) Run(ctx context.Context, onEvent func(Event), onError func(error)) error {
+	ticker := time.NewTicker(b.cfg.Interval())
+	defer ticker.Stop()
+
+	for {
+		events, err := b.Poll()
+		if err != nil && onError != nil {
+			onError(err)
+		}
+		if onEvent != nil {
+			for _, e := range events {
+				onEvent(e)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Forward emails the overseer's unread mail that hasn't been forwarded yet.
+func (b *Bridge) Forward() ([]Event, error) {
+	mailbox, err := b.router.GetMailbox(overseerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("opening overseer mailbox: %w", err)
+	}
+	unread, err := mailbox.ListUnread()
+	if err != nil {
+		return nil, fmt.Errorf("listing overseer mail: %w", err)
+	}
+
+	// Only unread mail can be forwarded again, so forget the rest.
+	st := b.loadState()
+	forwarded := make(map[string]time.Time, len(unread))
+	for _, msg := range unread {
+		if at, ok := st.Forwarded[msg.ID]; ok {
+			forwarded[msg.ID] = at
+		}
+	}
+	st.Forwarded = forwarded
+
+	var events []Event
+	for _, msg := range unread {
+		if _, ok := forwarded[msg.ID]; ok {
+			continue
+		}
+		if err := b.sendSMTP(composeEmail(msg, b.cfg.From, b.email, replyToken(st.Secret, msg.ID))); err != nil {
+			_ = b.saveState(st) // keep what was sent so far
+			return events, fmt.Errorf("forwarding %s: %w", msg.ID, err)
+		}
+		forwarded[msg.ID] = time.Now()
+		events = append(events, Event{Direction: "out", MessageID: msg.ID, From: msg.From, To: b.email, Subject: msg.Subject})
+	}
+	return events, b.saveState(st)
+}
+
+// Ingest fetches the overseer's new emails and delivers them as mail from
+// the overseer: replies to a bridged message go back to its sender, other
+// emails to the mayor. A From header is easily forged, so an email is only
+// accepted if it carries the reply token of the message it answers, or the
+// receiving server vouches for its sender with a DMARC pass; others are
+// marked seen and reported as rejected. Without an IMAP server configured
+// it does nothing.
+func (b *Bridge) Ingest() ([]Event, error) {
+	if b.cfg.IMAP == nil {
+		return nil, nil
+	}
+	port := b.cfg.IMAP.Port
+	if port == 0 {
+		port = 993
+	}
+	c, err := dialIMAP(b.cfg.IMAP.Host, port)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = c.Close() }()
+	return b.ingest(c)
+}
+
+func (b *Bridge) ingest(c *imapClient) ([]Event, error) {
+	if err := c.Login(b.cfg.IMAP.Username, b.cfg.IMAP.Password()); err != nil {
+		return nil, err
+	}
+	folder := b.cfg.IMAPFolder
+	if folder == "" {
+		folder = "INBOX"
+	}
+	if err := c.Select(folder); err != nil {
+		return nil, err
+	}
+	uids, err := c.SearchUnseenFrom(b.email)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := b.loadState().Secret
+	var events []Event
+	for _, uid := range uids {
+		raw, err := c.Fetch(uid)
+		if err != nil {
+			return events, err
+		}
+		r, err := parseReply(raw)
+		if err != nil {
+			return events, fmt.Errorf("message %d: %w", uid, err)
+		}
+		// IMAP's FROM search is a substring match; require the exact sender.
+		if !strings.EqualFold(r.From, b.email) {
+			continue
+		}
+		if !authentic(r, secret) {
+			if err := c.MarkSeen(uid); err != nil {
+				return events, err
+			}
+			events = append(events, Event{Direction: "rejected", From: r.From, To: overseerAddress, Subject: r.Subject})
+			continue
+		}
+		msg, err := b.deliver(r)
+		if err != nil {
+			return events, fmt.Errorf("delivering message %d: %w", uid, err)
+		}
+		if err := c.MarkSeen(uid); err != nil {
+			return events, err
+		}
+		events = append(events, Event{Direction: "in", MessageID: msg.ID, From: msg.From, To: msg.To, Subject: msg.Subject})
+	}
+	return events, nil
+}
+
+// authentic reports whether an email from the overseer's address can be
+// trusted to come from the overseer.
+func authentic(r *reply, secret string) bool {
+	if r.InReply != "" && r.Token != "" &&
+		hmac.Equal([]byte(r.Token), []byte(replyToken(secret, r.InReply))) {
+		return true
+	}
+	return dmarcPass(r.AuthResults)
+}
+
+// replyToken returns the token tagged on the email forwarding mail ID id:
+// an HMAC under the bridge's secret, so only someone who received that
+// email can produce it.
+func replyToken(secret, id string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+// deliver sends an overseer email into Gas Town.
+func (b *Bridge) deliver(r *reply) (*mail.Message, error) {
+	var msg *mail.Message
+	if original := b.original(r.InReply); original != nil {
+		subject := original.Subject
+		if !strings.HasPrefix(strings.ToLower(subject), "re:") {
+			subject = "Re: " + subject
+		}
+		msg = mail.NewReplyMessage(overseerAddress, original.From, subject, r.Body, original)
+	} else {
+		msg = mail.NewMessage(overseerAddress, fallbackRecipient, r.Subject, r.Body)
+	}
+	if err := b.router.Send(msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// original returns the overseer's message with the given ID, or nil.
+func (b *Bridge) original(id string) *mail.Message {
+	if id == "" {
+		return nil
+	}
+	mailbox, err := b.router.GetMailbox(overseerAddress)
+	if err != nil {
+		return nil
+	}
+	msg, err := mailbox.Get(id)
+	if err != nil {
+		return nil
+	}
+	return msg
+}
+
+// sendSMTP delivers a rendered email through the configured SMTP server.
+func (b *Bridge) sendSMTP(raw []byte) error {
+	s := b.cfg.SMTP
+	port := s.Port
+	if port == 0 {
+		port = 587
+	}
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password(), s.Host)
+	}
+	addr := s.Host + ":" + strconv.Itoa(port)
+	return smtp.SendMail(addr, auth, b.cfg.From, []string{b.email}, raw)
+}
+
+func (b *Bridge) loadState() *state {
+	st := &state{}
+	if data, err := os.ReadFile(b.statePath); err == nil {
+		_ = json.Unmarshal(data, st)
+	}
+	if st.Forwarded == nil {
+		st.Forwarded = make(map[string]time.Time)
+	}
+	if st.Secret == "" {
+		buf := make([]byte, 32)
+		_, _ = rand.Read(buf)
+		st.Secret = hex.EncodeToString(buf)
+	}
+	return st
+}
+
+func (b *Bridge) saveState(st *state) error {
+	if err := os.MkdirAll(filepath.Dir(b.statePath), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	// The secret authenticates replies; keep it from other users.
+	return util.AtomicWriteFile(b.statePath, data, 0600)
+}
@@ -0,0 +1,231 @@
+package mailbridge
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// subjectTagPattern matches the tag on forwarded subjects and captures its
+// reply token.
+var subjectTagPattern = regexp.MustCompile(`(?i)\[gastown(?: #([0-9a-f]+))?\]`)
+
+// subjectTag returns the tag that marks bridged mail in the overseer's
+// email client. Its token, kept when the overseer replies, shows the reply
+// answers an email the bridge sent.
+func subjectTag(token string) string {
+	return "[gastown #" + token + "] "
+}
+
+// messageIDPrefix tags the local part of the Message-IDs the bridge
+// generates, so a reply's In-Reply-To leads back to the Gas Town message.
+const messageIDPrefix = "gastown."
+
+// emailMessageID returns the Message-ID header value for a forwarded
+// message: <gastown.<mail ID>@<domain>>.
+func emailMessageID(mailID, from string) string {
+	domain := "gastown.local"
+	if _, d, ok := strings.Cut(from, "@"); ok && d != "" {
+		domain = strings.Trim(d, "<> ")
+	}
+	return fmt.Sprintf("<%s%s@%s>", messageIDPrefix, mailID, domain)
+}
+
+// mailIDFromMessageID extracts the Gas Town message ID from a Message-ID
+// the bridge generated, or "" if it isn't one.
+func mailIDFromMessageID(messageID string) string {
+	id := strings.Trim(strings.TrimSpace(messageID), "<>")
+	local, _, ok := strings.Cut(id, "@")
+	if !ok || !strings.HasPrefix(local, messageIDPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(local, messageIDPrefix)
+}
+
+// composeEmail renders msg as an RFC 5322 email from the bridge to the
+// overseer, tagging its subject with the reply token.
+func composeEmail(msg *mail.Message, from, to, token string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subjectTag(token)+msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", msg.Timestamp.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Message-ID: %s\r\n", emailMessageID(msg.ID, from))
+	fmt.Fprintf(&b, "X-Gastown-From: %s\r\n", msg.From)
+	if msg.Priority == mail.PriorityUrgent || msg.Priority == mail.PriorityHigh {
+		b.WriteString("Importance: high\r\n")
+	}
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&b)
+	fmt.Fprintf(qp, "From %s (%s, %s priority):\r\n\r\n", msg.From, msg.ID, msg.Priority)
+	_, _ = io.WriteString(qp, strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	fmt.Fprintf(qp, "\r\n\r\n-- \r\nReply to this email to answer %s in Gas Town.\r\n", msg.From)
+	_ = qp.Close()
+	return b.Bytes()
+}
+
+// reply is an email from the overseer fetched from the bridge mailbox.
+type reply struct {
+	From        string // sender's email address
+	Subject     string // without any Re:/[gastown] prefixes
+	Token       string // reply token from the subject tag, if any
+	InReply     string // Gas Town message ID the email answers, if any
+	AuthResults string // topmost Authentication-Results header, if any
+	Body        string // new text, with quoted history removed
+}
+
+// parseReply parses a raw email into a reply.
+func parseReply(raw []byte) (*reply, error) {
+	m, err := netmail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("parsing email: %w", err)
+	}
+
+	r := &reply{}
+	if addr, err := netmail.ParseAddress(m.Header.Get("From")); err == nil {
+		r.From = addr.Address
+	}
+	dec := new(mime.WordDecoder)
+	subject, err := dec.DecodeHeader(m.Header.Get("Subject"))
+	if err != nil {
+		subject = m.Header.Get("Subject")
+	}
+	if tag := subjectTagPattern.FindStringSubmatch(subject); tag != nil {
+		r.Token = strings.ToLower(tag[1])
+	}
+	r.Subject = cleanSubject(subject)
+	// The receiving server adds its header above any the sender wrote.
+	if results := m.Header["Authentication-Results"]; len(results) > 0 {
+		r.AuthResults = results[0]
+	}
+
+	// In-Reply-To names the direct parent; References the whole chain, in
+	// case the overseer replied to their own reply.
+	candidates := strings.Fields(m.Header.Get("In-Reply-To"))
+	refs := strings.Fields(m.Header.Get("References"))
+	for i := len(refs) - 1; i >= 0; i-- {
+		candidates = append(candidates, refs[i])
+	}
+	for _, c := range candidates {
+		if id := mailIDFromMessageID(c); id != "" {
+			r.InReply = id
+			break
+		}
+	}
+
+	text, err := plainText(m.Header.Get("Content-Type"), m.Header.Get("Content-Transfer-Encoding"), m.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = stripQuoted(text)
+	return r, nil
+}
+
+// plainText returns the first text/plain part of a body, decoding any
+// transfer encoding.
+func plainText(contentType, encoding string, body io.Reader) (string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain" // RFC 2045 default
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				return "", fmt.Errorf("email has no text/plain part")
+			}
+			if err != nil {
+				return "", fmt.Errorf("reading multipart email: %w", err)
+			}
+			text, err := plainText(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if err == nil {
+				return text, nil
+			}
+		}
+	}
+	if mediaType != "text/plain" {
+		return "", fmt.Errorf("unsupported content type %s", mediaType)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", fmt.Errorf("decoding email body: %w", err)
+	}
+	return strings.ReplaceAll(string(data), "\r\n", "\n"), nil
+}
+
+// quoteHeader matches the attribution line mail clients put above quoted
+// history ("On Tue, ... wrote:").
+var quoteHeader = regexp.MustCompile(`^On .+ wrote:$`)
+
+// stripQuoted returns the text above the quoted history and signature of a
+// reply.
+func stripQuoted(text string) string {
+	var kept []string
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimRight(line, " \t")
+		if strings.HasPrefix(trimmed, ">") || trimmed == "--" ||
+			quoteHeader.MatchString(trimmed) ||
+			strings.HasPrefix(trimmed, "-----Original Message-----") {
+			break
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// cleanSubject removes the reply and bridge prefixes email clients stack
+// on a subject.
+func cleanSubject(subject string) string {
+	s := strings.TrimSpace(subject)
+	for {
+		lower := strings.ToLower(s)
+		switch {
+		case strings.HasPrefix(lower, "re:"), strings.HasPrefix(lower, "aw:"):
+			s = strings.TrimSpace(s[3:])
+		case strings.HasPrefix(lower, "[gastown"):
+			loc := subjectTagPattern.FindStringIndex(s)
+			if loc == nil || loc[0] != 0 {
+				return s
+			}
+			s = strings.TrimSpace(s[loc[1]:])
+		default:
+			return s
+		}
+	}
+}
+
+// dmarcPass reports whether an Authentication-Results header records a
+// DMARC pass, which means the receiving server verified the From domain.
+func dmarcPass(results string) bool {
+	// The first element is the authserv-id; results follow, ";"-separated.
+	parts := strings.Split(results, ";")
+	for _, part := range parts[1:] {
+		fields := strings.Fields(part)
+		if len(fields) > 0 && strings.EqualFold(fields[0], "dmarc=pass") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package mailbridge
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+func TestMessageIDRoundTrip(t *testing.T) {
+	id := emailMessageID("hq-abc12", "Gas Town <bridge@example.com>")
+	if id != "<gastown.hq-abc12@example.com>" {
+		t.Errorf("emailMessageID = %q", id)
+	}
+	if got := mailIDFromMessageID(id); got != "hq-abc12" {
+		t.Errorf("mailIDFromMessageID(%q) = %q", id, got)
+	}
+	if got := mailIDFromMessageID("<CAF123@mail.gmail.com>"); got != "" {
+		t.Errorf("foreign Message-ID mapped to %q", got)
+	}
+}
+
+func TestComposeThenParseReply(t *testing.T) {
+	msg := &mail.Message{ID: "hq-abc12", From: "gastown/witness", Subject: "Polecat stuck",
+		Body: "Toast has been idle for 2h.", Priority: mail.PriorityHigh, Timestamp: time.Now()}
+	sent := string(composeEmail(msg, "bridge@example.com", "boss@example.com", "0123abcd"))
+	for _, want := range []string{"Subject: [gastown #0123abcd] Polecat stuck", "Message-ID: <gastown.hq-abc12@example.com>", "Importance: high", "Toast has been idle"} {
+		if !strings.Contains(sent, want) {
+			t.Errorf("composed email missing %q:\n%s", want, sent)
+		}
+	}
+
+	raw := "From: The Boss <boss@example.com>\r\n" +
+		"To: bridge@example.com\r\n" +
+		"Subject: Re: [gastown #0123ABCD] Polecat stuck\r\n" +
+		"In-Reply-To: <gastown.hq-abc12@example.com>\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n\r\n" +
+		"Nuke it and re-sling the issue.\r\n\r\n" +
+		"On Tue, Oct 13, 2026 at 9:00 AM Gas Town <bridge@example.com> wrote:\r\n" +
+		"> Toast has been idle for 2h.\r\n"
+	r, err := parseReply([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.From != "boss@example.com" || r.InReply != "hq-abc12" || r.Subject != "Polecat stuck" || r.Token != "0123abcd" {
+		t.Errorf("parseReply = %+v", r)
+	}
+	if r.Body != "Nuke it and re-sling the issue." {
+		t.Errorf("Body = %q", r.Body)
+	}
+}
+
+func TestParseReplyMultipart(t *testing.T) {
+	raw := "From: boss@example.com\r\n" +
+		"Subject: new idea\r\n" +
+		"References: <gastown.hq-1@example.com> <CAF@mail.example.com>\r\n" +
+		"Content-Type: multipart/alternative; boundary=XYZ\r\n\r\n" +
+		"--XYZ\r\n" +
+		"Content-Type: text/html\r\n\r\n" +
+		"<p>ignored</p>\r\n" +
+		"--XYZ\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"Content-Transfer-Encoding: base64\r\n\r\n" +
+		"TGV0J3MgYWRkIGEgY2FjaGUu\r\n" +
+		"--XYZ--\r\n"
+	r, err := parseReply([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Body != "Let's add a cache." || r.InReply != "hq-1" {
+		t.Errorf("parseReply = %+v", r)
+	}
+}
+
+func TestAuthentic(t *testing.T) {
+	secret := "s3cret"
+	token := replyToken(secret, "hq-abc12")
+	pass := "mx.example.com; dkim=pass header.d=example.com; spf=pass; dmarc=pass (p=REJECT) header.from=example.com"
+
+	tests := []struct {
+		name string
+		r    reply
+		want bool
+	}{
+		{"reply with token", reply{InReply: "hq-abc12", Token: token}, true},
+		{"token for another message", reply{InReply: "hq-other", Token: token}, false},
+		{"reply without token", reply{InReply: "hq-abc12"}, false},
+		{"new email with dmarc pass", reply{AuthResults: pass}, true},
+		{"new email with dmarc fail", reply{AuthResults: "mx.example.com; spf=pass; dmarc=fail header.from=example.com"}, false},
+		{"dmarc=pass only as authserv-id", reply{AuthResults: "dmarc=pass; spf=none"}, false},
+		{"forged new email", reply{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := authentic(&tt.r, secret); got != tt.want {
+				t.Errorf("authentic = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package mailbridge
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// imapClient is the small subset of IMAP4rev1 (RFC 3501) the bridge needs:
+// log in, select a folder, find unseen mail from one sender, fetch it, and
+// flag it seen.
+type imapClient struct {
+	conn net.Conn
+	r    *bufio.Reader
+	w    *bufio.Writer
+	tag  int
+}
+
+// imapResponse is one untagged response line and any literals it carried.
+type imapResponse struct {
+	line     string
+	literals [][]byte
+}
+
+// dialIMAP connects to an IMAP server over TLS.
+func dialIMAP(host string, port int) (*imapClient, error) {
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 30 * time.Second}, "tcp", addr, &tls.Config{ServerName: host})
+	if err != nil {
+		return nil, fmt.Errorf("connecting to IMAP server %s: %w", addr, err)
+	}
+	c, err := newIMAPClient(conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// newIMAPClient wraps an established connection and reads the greeting.
+func newIMAPClient(conn net.Conn) (*imapClient, error) {
+	c := &imapClient{conn: conn, r: bufio.NewReader(conn), w: bufio.NewWriter(conn)}
+	greeting, err := c.readLine()
+	if err != nil {
+		return nil, fmt.Errorf("reading IMAP greeting: %w", err)
+	}
+	if !strings.HasPrefix(greeting, "* OK") && !strings.HasPrefix(greeting, "* PREAUTH") {
+		return nil, fmt.Errorf("unexpected IMAP greeting: %s", greeting)
+	}
+	return c, nil
+}
+
+// Login authenticates with a username and password.
+func (c *imapClient) Login(username, password string) error {
+	_, err := c.cmd("LOGIN %s %s", imapQuote(username), imapQuote(password))
+	if err != nil {
+		return fmt.Errorf("IMAP login: %w", err)
+	}
+	return nil
+}
+
+// Select opens a folder.
+func (c *imapClient) Select(folder string) error {
+	if _, err := c.cmd("SELECT %s", imapQuote(folder)); err != nil {
+		return fmt.Errorf("selecting %s: %w", folder, err)
+	}
+	return nil
+}
+
+// SearchUnseenFrom returns the UIDs of unseen messages from sender.
+func (c *imapClient) SearchUnseenFrom(sender string) ([]uint32, error) {
+	resps, err := c.cmd("UID SEARCH UNSEEN FROM %s", imapQuote(sender))
+	if err != nil {
+		return nil, fmt.Errorf("searching mailbox: %w", err)
+	}
+	var uids []uint32
+	for _, resp := range resps {
+		rest, ok := strings.CutPrefix(resp.line, "* SEARCH")
+		if !ok {
+			continue
+		}
+		for _, f := range strings.Fields(rest) {
+			uid, err := strconv.ParseUint(f, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("bad UID %q in search response", f)
+			}
+			uids = append(uids, uint32(uid))
+		}
+	}
+	return uids, nil
+}
+
+// Fetch returns the raw RFC 5322 message with the given UID without
+// marking it seen.
+func (c *imapClient) Fetch(uid uint32) ([]byte, error) {
+	resps, err := c.cmd("UID FETCH %d BODY.PEEK[]", uid)
+	if err != nil {
+		return nil, fmt.Errorf("fetching message %d: %w", uid, err)
+	}
+	for _, resp := range resps {
+		if strings.Contains(resp.line, " FETCH ") && len(resp.literals) > 0 {
+			return resp.literals[0], nil
+		}
+	}
+	return nil, fmt.Errorf("fetching message %d: no body in response", uid)
+}
+
+// MarkSeen flags the message with the given UID as seen.
+func (c *imapClient) MarkSeen(uid uint32) error {
+	if _, err := c.cmd(`UID STORE %d +FLAGS.SILENT (\Seen)`, uid); err != nil {
+		return fmt.Errorf("flagging message %d: %w", uid, err)
+	}
+	return nil
+}
+
+// Close logs out and closes the connection.
+func (c *imapClient) Close() error {
+	_, _ = c.cmd("LOGOUT")
+	return c.conn.Close()
+}
+
+// cmd sends a tagged command and collects the untagged responses up to its
+// completion, failing unless it completes with OK.
+func (c *imapClient) cmd(format string, args ...interface{}) ([]imapResponse, error) {
+	c.tag++
+	tag := fmt.Sprintf("A%03d", c.tag)
+	if _, err := fmt.Fprintf(c.w, "%s %s\r\n", tag, fmt.Sprintf(format, args...)); err != nil {
+		return nil, err
+	}
+	if err := c.w.Flush(); err != nil {
+		return nil, err
+	}
+
+	var resps []imapResponse
+	for {
+		resp, err := c.readResponse()
+		if err != nil {
+			return nil, err
+		}
+		if status, ok := strings.CutPrefix(resp.line, tag+" "); ok {
+			if !strings.HasPrefix(status, "OK") {
+				return nil, fmt.Errorf("server replied: %s", status)
+			}
+			return resps, nil
+		}
+		resps = append(resps, resp)
+	}
+}
+
+// readResponse reads one response, following any {n} literals into the
+// continuation of the line.
+func (c *imapClient) readResponse() (imapResponse, error) {
+	var resp imapResponse
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return resp, err
+		}
+		resp.line += line
+		n, ok := literalSize(line)
+		if !ok {
+			return resp, nil
+		}
+		lit := make([]byte, n)
+		if _, err := io.ReadFull(c.r, lit); err != nil {
+			return resp, fmt.Errorf("reading IMAP literal: %w", err)
+		}
+		resp.literals = append(resp.literals, lit)
+	}
+}
+
+func (c *imapClient) readLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// literalSize reports the size of the literal announced at the end of line.
+func literalSize(line string) (int, bool) {
+	if !strings.HasSuffix(line, "}") {
+		return 0, false
+	}
+	open := strings.LastIndexByte(line, '{')
+	if open < 0 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(line[open+1 : len(line)-1])
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// imapQuote renders s as an IMAP quoted string.
+func imapQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,94 @@
+package mailbridge
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+)
+
+// serveIMAP plays a scripted IMAP server on conn: for each command it
+// expects (after the tag), it writes the given response lines, then a
+// tagged OK.
+func serveIMAP(t *testing.T, conn net.Conn, script [][2]string) {
+	t.Helper()
+	r := bufio.NewReader(conn)
+	fmt.Fprint(conn, "* OK IMAP4rev1 ready\r\n")
+	for _, step := range script {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Errorf("reading command: %v", err)
+			return
+		}
+		tag, cmd, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+		if cmd != step[0] {
+			t.Errorf("command = %q, want %q", cmd, step[0])
+		}
+		fmt.Fprintf(conn, "%s%s OK done\r\n", step[1], tag)
+	}
+}
+
+func TestIMAPClient(t *testing.T) {
+	client, server := net.Pipe()
+	defer func() { _ = client.Close() }()
+
+	body := "From: boss@example.com\r\nSubject: hi\r\n\r\nhello\r\n"
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		serveIMAP(t, server, [][2]string{
+			{`LOGIN "boss" "pa\"ss"`, ""},
+			{`SELECT "INBOX"`, "* 3 EXISTS\r\n"},
+			{`UID SEARCH UNSEEN FROM "boss@example.com"`, "* SEARCH 7 9\r\n"},
+			{`UID FETCH 7 BODY.PEEK[]`, fmt.Sprintf("* 1 FETCH (UID 7 BODY[] {%d}\r\n%s)\r\n", len(body), body)},
+			{`UID STORE 7 +FLAGS.SILENT (\Seen)`, ""},
+		})
+	}()
+
+	c, err := newIMAPClient(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Login("boss", `pa"ss`); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Select("INBOX"); err != nil {
+		t.Fatal(err)
+	}
+	uids, err := c.SearchUnseenFrom("boss@example.com")
+	if err != nil || len(uids) != 2 || uids[0] != 7 || uids[1] != 9 {
+		t.Fatalf("SearchUnseenFrom = %v, %v", uids, err)
+	}
+	raw, err := c.Fetch(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(raw) != body {
+		t.Errorf("Fetch = %q, want %q", raw, body)
+	}
+	if err := c.MarkSeen(7); err != nil {
+		t.Fatal(err)
+	}
+	<-done
+}
+
+func TestIMAPClientCommandFailure(t *testing.T) {
+	client, server := net.Pipe()
+	defer func() { _ = client.Close() }()
+	go func() {
+		r := bufio.NewReader(server)
+		fmt.Fprint(server, "* OK ready\r\n")
+		line, _ := r.ReadString('\n')
+		tag, _, _ := strings.Cut(line, " ")
+		fmt.Fprintf(server, "%s NO [AUTHENTICATIONFAILED] invalid credentials\r\n", tag)
+	}()
+
+	c, err := newIMAPClient(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Login("boss", "wrong"); err == nil || !strings.Contains(err.Error(), "AUTHENTICATIONFAILED") {
+		t.Errorf("Login error = %v, want AUTHENTICATIONFAILED", err)
+	}
+}
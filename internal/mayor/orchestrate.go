@@ -0,0 +1,142 @@
+package mayor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// mayorAddress is the mail address used as sender for mayor directives.
+const mayorAddress = "mayor/"
+
+// DirectiveResult records the outcome of a directive for one rig.
+type DirectiveResult struct {
+	Rig   string
+	Error error
+}
+
+// Directive mails a directive from the mayor to every agent in the given
+// rigs (all registered rigs when rigs is empty). Each rig is addressed as
+// the @rig/<name> group so the directive reaches witness, refinery, crew,
+// and polecats alike. Per-rig failures are reported in the results rather
+// than aborting the broadcast.
+func (m *Manager) Directive(subject, body string, priority mail.Priority, rigs []string) ([]DirectiveResult, error) {
+	if len(rigs) == 0 {
+		var err error
+		rigs, err = m.RigNames()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	router := mail.NewRouterWithTownRoot(m.townRoot, m.townRoot)
+	results := make([]DirectiveResult, 0, len(rigs))
+	for _, rigName := range rigs {
+		msg := mail.NewMessage(mayorAddress, "@rig/"+rigName, "📋 DIRECTIVE: "+subject, body)
+		msg.Type = mail.TypeTask
+		if priority != "" {
+			msg.Priority = priority
+		}
+		results = append(results, DirectiveResult{Rig: rigName, Error: router.Send(msg)})
+	}
+	return results, nil
+}
+
+// Reassignment describes work moved from one agent to another rig.
+type Reassignment struct {
+	BeadID           string
+	Title            string
+	PreviousAssignee string
+	ToRig            string
+}
+
+// Reassign releases a bead from its current assignee and hands it to another
+// rig: the bead goes back to open and unassigned, the previous assignee is
+// told to stop, and the target rig's witness is asked to pick it up.
+func (m *Manager) Reassign(beadID, toRig, reason string) (*Reassignment, error) {
+	names, err := m.RigNames()
+	if err != nil {
+		return nil, err
+	}
+	known := false
+	for _, name := range names {
+		if name == toRig {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("rig '%s' not found", toRig)
+	}
+
+	b := beads.New(m.townRoot)
+	issue, err := b.Show(beadID)
+	if err != nil {
+		return nil, fmt.Errorf("looking up %s: %w", beadID, err)
+	}
+	if issue.Status == "closed" {
+		return nil, fmt.Errorf("%s is closed", beadID)
+	}
+
+	status := "open"
+	unassigned := ""
+	if err := b.Update(beadID, beads.UpdateOptions{Status: &status, Assignee: &unassigned}); err != nil {
+		return nil, fmt.Errorf("releasing %s: %w", beadID, err)
+	}
+
+	r := &Reassignment{
+		BeadID:           beadID,
+		Title:            issue.Title,
+		PreviousAssignee: issue.Assignee,
+		ToRig:            toRig,
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Bead: %s\nTitle: %s\nTo rig: %s\n", beadID, issue.Title, toRig)
+	if reason != "" {
+		fmt.Fprintf(&body, "Reason: %s\n", reason)
+	}
+
+	// Notifications are best-effort: the reassignment itself already happened.
+	router := mail.NewRouterWithTownRoot(m.townRoot, m.townRoot)
+	if issue.Assignee != "" {
+		stop := mail.NewMessage(mayorAddress, issue.Assignee, "Work reassigned: "+beadID,
+			body.String()+"\nStop work on this bead; it has been moved to another rig.")
+		_ = router.Send(stop)
+	}
+	pickup := mail.NewMessage(mayorAddress, toRig+"/witness", "Work assigned to rig: "+beadID,
+		body.String()+"\nDispatch this bead to a polecat: gt sling "+beadID+" "+toRig)
+	pickup.Type = mail.TypeTask
+	_ = router.Send(pickup)
+
+	return r, nil
+}
+
+// HandoffContent returns the mayor's current handoff notes.
+func (m *Manager) HandoffContent() (string, error) {
+	issue, err := beads.New(m.townRoot).FindHandoffBead("mayor")
+	if err != nil {
+		return "", err
+	}
+	if issue == nil {
+		return "", nil
+	}
+	return issue.Description, nil
+}
+
+// Refresh records handoff notes on the mayor's pinned handoff bead and
+// cycles the mayor session so the next session starts from those notes.
+func (m *Manager) Refresh(notes, agentOverride string) error {
+	if notes != "" {
+		if err := beads.New(m.townRoot).UpdateHandoffContent("mayor", notes); err != nil {
+			return fmt.Errorf("updating handoff bead: %w", err)
+		}
+	}
+
+	if err := m.Stop(); err != nil && err != ErrNotRunning {
+		return fmt.Errorf("stopping session: %w", err)
+	}
+	return m.Start(agentOverride)
+}
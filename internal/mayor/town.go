@@ -0,0 +1,82 @@
+package mayor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+// RigSummary is the mayor's view of a single rig.
+type RigSummary struct {
+	Name     string   `json:"name"`
+	Witness  bool     `json:"witness_running"`
+	Refinery bool     `json:"refinery_running"`
+	Polecats []string `json:"polecats"`
+	Crew     []string `json:"crew"`
+}
+
+// TownSummary is a town-wide snapshot used for cross-rig coordination.
+type TownSummary struct {
+	MayorRunning bool          `json:"mayor_running"`
+	Rigs         []*RigSummary `json:"rigs"`
+}
+
+// rigs loads all registered rigs, sorted by name.
+func (m *Manager) rigs() ([]*rig.Rig, error) {
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(m.townRoot))
+	if err != nil {
+		return nil, fmt.Errorf("loading rigs config: %w", err)
+	}
+
+	mgr := rig.NewManager(m.townRoot, rigsConfig, git.NewGit(m.townRoot))
+	rigs, err := mgr.DiscoverRigs()
+	if err != nil {
+		return nil, fmt.Errorf("discovering rigs: %w", err)
+	}
+	sort.Slice(rigs, func(i, j int) bool { return rigs[i].Name < rigs[j].Name })
+	return rigs, nil
+}
+
+// RigNames returns the names of all registered rigs, sorted.
+func (m *Manager) RigNames() ([]string, error) {
+	rigs, err := m.rigs()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(rigs))
+	for _, r := range rigs {
+		names = append(names, r.Name)
+	}
+	return names, nil
+}
+
+// TownStatus summarizes every rig's agents for the mayor.
+// Session checks are best-effort: a tmux failure reports the agent as stopped.
+func (m *Manager) TownStatus() (*TownSummary, error) {
+	rigs, err := m.rigs()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &TownSummary{Rigs: make([]*RigSummary, 0, len(rigs))}
+	summary.MayorRunning, _ = m.IsRunning()
+
+	for _, r := range rigs {
+		rs := &RigSummary{
+			Name:     r.Name,
+			Polecats: r.Polecats,
+			Crew:     r.Crew,
+		}
+		rs.Witness, _ = witness.NewManager(r).IsRunning()
+		rs.Refinery, _ = refinery.NewManager(r).IsRunning()
+		summary.Rigs = append(summary.Rigs, rs)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,50 @@
+package mayor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setupTown(t *testing.T) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	mayorDir := filepath.Join(townRoot, "mayor")
+	if err := os.MkdirAll(mayorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	rigsJSON := `{"version":1,"rigs":{}}`
+	if err := os.WriteFile(filepath.Join(mayorDir, "rigs.json"), []byte(rigsJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return townRoot
+}
+
+func TestTownStatus_NoRigs(t *testing.T) {
+	m := NewManager(setupTown(t))
+
+	summary, err := m.TownStatus()
+	if err != nil {
+		t.Fatalf("TownStatus() error = %v", err)
+	}
+	if len(summary.Rigs) != 0 {
+		t.Errorf("TownStatus() rigs = %d, want 0", len(summary.Rigs))
+	}
+}
+
+func TestTownStatus_MissingRigsConfig(t *testing.T) {
+	m := NewManager(t.TempDir())
+	if _, err := m.TownStatus(); err == nil {
+		t.Error("TownStatus() without rigs.json should fail")
+	}
+}
+
+func TestReassign_UnknownRig(t *testing.T) {
+	m := NewManager(setupTown(t))
+
+	_, err := m.Reassign("gt-abc", "nope", "")
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Reassign() to unknown rig error = %v, want not found", err)
+	}
+}
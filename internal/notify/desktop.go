@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// runCommand runs a notifier command; replaceable in tests.
+var runCommand = func(ctx context.Context, name string, args ...string) error {
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// DesktopSink shows a desktop notification: Notification Center on macOS
+// (osascript), notify-send elsewhere.
+type DesktopSink struct {
+	// GOOS selects the notifier; empty means this machine's.
+	GOOS string
+}
+
+// Send implements Sink.
+func (s *DesktopSink) Send(ctx context.Context, n Notification) error {
+	name, args := s.command(n)
+	return runCommand(ctx, name, args...)
+}
+
+// command returns the notifier command for n.
+func (s *DesktopSink) command(n Notification) (string, []string) {
+	goos := s.GOOS
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+
+	title := "Gas Town: " + n.Title
+	body := n.Body
+	if n.Source != "" {
+		body = strings.TrimSpace("from " + n.Source + "\n" + body)
+	}
+
+	if goos == "darwin" {
+		script := fmt.Sprintf("display notification %s with title %s",
+			appleScriptString(body), appleScriptString(title))
+		return "osascript", []string{"-e", script}
+	}
+	return "notify-send", []string{"--app-name=Gas Town", "--urgency=" + notifySendUrgency(n.Severity), title, body}
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// notifySendUrgency maps a severity to a notify-send urgency level.
+func notifySendUrgency(severity string) string {
+	switch severity {
+	case config.SeverityCritical:
+		return "critical"
+	case config.SeverityLow:
+		return "low"
+	default:
+		return "normal"
+	}
+}
+
+// BellSink rings the terminal bell of every client attached to the gt tmux
+// server, or of the current terminal when there are none. The daemon has no
+// terminal of its own, so the bell goes where the human is looking.
+type BellSink struct{}
+
+// Send implements Sink.
+func (s *BellSink) Send(_ context.Context, _ Notification) error {
+	ttys := attachedClientTTYs()
+	if len(ttys) == 0 {
+		ttys = []string{"/dev/tty"}
+	}
+
+	rang := 0
+	var errs []error
+	for _, tty := range ttys {
+		f, err := os.OpenFile(tty, os.O_WRONLY, 0) //nolint:gosec // G304: ttys come from tmux
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, err := f.WriteString("\a"); err == nil {
+			rang++
+		} else {
+			errs = append(errs, err)
+		}
+		_ = f.Close()
+	}
+	if rang == 0 {
+		return fmt.Errorf("no terminal to ring: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// attachedClientTTYs returns the terminals attached to the gt tmux server.
+func attachedClientTTYs() []string {
+	out, err := tmux.Command("list-clients", "-F", "#{client_tty}").Output()
+	if err != nil {
+		return nil
+	}
+	var ttys []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if tty := strings.TrimSpace(line); tty != "" {
+			ttys = append(ttys, tty)
+		}
+	}
+	return ttys
+}
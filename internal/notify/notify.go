@@ -0,0 +1,271 @@
+// Package notify delivers important town events to humans outside the town.
+//
+// Sinks (Slack, Discord, generic webhooks, email, desktop notifications, the
+// terminal bell) are configured in settings/notifications.json. Each sink
+// can filter by event type and minimum severity and is rate limited per
+// hour; delivery state is kept in .runtime/notify-state.json so limits hold
+// across gt invocations.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Event types raised by gt subsystems.
+const (
+	EventEscalation  = "escalation"   // gt escalate, witness help escalations
+	EventCostAlert   = "cost_alert"   // gt costs report --alert
+	EventMerged      = "merged"       // refinery merged an MR
+	EventMergeFailed = "merge_failed" // refinery bounced an MR
+	EventMail        = "mail"         // mail matching a rig's mirror rule (local sinks: any mail)
+	EventTest        = "test"         // gt notifications test
+
+	// Raised by the daemon from the event log, for desktop and bell sinks
+	EventMoleculeCompleted = "molecule_completed" // a molecule's last step closed
+)
+
+// Notification is a single event to deliver.
+type Notification struct {
+	Event    string    `json:"event"`
+	Severity string    `json:"severity"`
+	Title    string    `json:"title"`
+	Body     string    `json:"body,omitempty"`
+	Source   string    `json:"source,omitempty"` // who raised it, e.g. "gastown/witness"
+	Time     time.Time `json:"time"`
+}
+
+// Subject returns a one-line summary suitable for email subjects.
+func (n Notification) Subject() string {
+	return fmt.Sprintf("[gastown %s] %s", strings.ToUpper(n.Severity), n.Title)
+}
+
+// Text renders the notification as chat message text.
+func (n Notification) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%s*", n.Subject())
+	if n.Source != "" {
+		fmt.Fprintf(&b, " (from %s)", n.Source)
+	}
+	if n.Body != "" {
+		b.WriteString("\n")
+		b.WriteString(n.Body)
+	}
+	return b.String()
+}
+
+// severityRank orders severities for min_severity filtering.
+func severityRank(s string) int {
+	switch s {
+	case config.SeverityLow:
+		return 0
+	case config.SeverityMedium:
+		return 1
+	case config.SeverityHigh:
+		return 2
+	case config.SeverityCritical:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// Routes reports whether the sink's routing rules accept the notification.
+func Routes(sink *config.NotificationSink, n Notification) bool {
+	if sink.Disabled {
+		return false
+	}
+	if sink.MinSeverity != "" && severityRank(n.Severity) < severityRank(sink.MinSeverity) {
+		return false
+	}
+	if len(sink.Events) == 0 {
+		return true
+	}
+	for _, e := range sink.Events {
+		if e == n.Event || e == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Result is the delivery outcome for one sink.
+type Result struct {
+	Sink        string
+	Error       error
+	RateLimited bool
+}
+
+// state tracks recent deliveries per sink for rate limiting.
+type state struct {
+	Sent map[string][]time.Time `json:"sent"`
+}
+
+// statePath returns the rate-limit state file for a town.
+func statePath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "notify-state.json")
+}
+
+func loadState(townRoot string) *state {
+	s := &state{Sent: make(map[string][]time.Time)}
+	data, err := os.ReadFile(statePath(townRoot))
+	if err != nil {
+		return s
+	}
+	_ = json.Unmarshal(data, s)
+	if s.Sent == nil {
+		s.Sent = make(map[string][]time.Time)
+	}
+	return s
+}
+
+// stateMu serializes rate-limit state updates within the process; a file
+// lock serializes them across gt processes.
+var stateMu sync.Mutex
+
+// reserve records a delivery, at now, for each sink in limits (name ->
+// max per hour) that is within its limit, and returns those sinks. The
+// state is read and written under lock, so concurrent dispatches can't
+// both take a sink's last slot or drop each other's deliveries.
+func reserve(townRoot string, limits map[string]int, now time.Time) map[string]bool {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+
+	path := statePath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err == nil {
+		lock := flock.New(path + ".lock")
+		if err := lock.Lock(); err == nil {
+			defer func() { _ = lock.Unlock() }()
+		}
+	}
+
+	st := loadState(townRoot)
+	allowed := make(map[string]bool, len(limits))
+	for name, perHour := range limits {
+		allowed[name] = st.allow(name, perHour, now)
+	}
+	_ = util.AtomicWriteJSON(path, st)
+	return allowed
+}
+
+// allow records a delivery for sink if it is within maxPerHour.
+func (s *state) allow(sink string, maxPerHour int, now time.Time) bool {
+	cutoff := now.Add(-time.Hour)
+	var recent []time.Time
+	for _, t := range s.Sent[sink] {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	if maxPerHour > 0 && len(recent) >= maxPerHour {
+		s.Sent[sink] = recent
+		return false
+	}
+	s.Sent[sink] = append(recent, now)
+	return true
+}
+
+// Dispatcher delivers notifications to configured sinks.
+type Dispatcher struct {
+	townRoot string
+	cfg      *config.NotificationsConfig
+
+	// newSink builds sinks; replaceable in tests.
+	newSink func(*config.NotificationSink) (Sink, error)
+}
+
+// NewDispatcher creates a dispatcher for a town's notification config.
+func NewDispatcher(townRoot string, cfg *config.NotificationsConfig) *Dispatcher {
+	return &Dispatcher{townRoot: townRoot, cfg: cfg, newSink: NewSink}
+}
+
+// Dispatch sends n to every sink whose rules match it, in sink-name order.
+// Sinks over their hourly limit are skipped and reported as rate limited.
+func (d *Dispatcher) Dispatch(ctx context.Context, n Notification) []Result {
+	if n.Time.IsZero() {
+		n.Time = time.Now()
+	}
+	if n.Severity == "" {
+		n.Severity = config.SeverityMedium
+	}
+
+	names := make([]string, 0, len(d.cfg.Sinks))
+	for name, sink := range d.cfg.Sinks {
+		if Routes(sink, n) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+
+	limits := make(map[string]int, len(names))
+	for _, name := range names {
+		limits[name] = d.cfg.Sinks[name].MaxPerHour
+	}
+	allowed := reserve(d.townRoot, limits, n.Time)
+
+	results := make([]Result, 0, len(names))
+	for _, name := range names {
+		if !allowed[name] {
+			results = append(results, Result{Sink: name, RateLimited: true})
+			continue
+		}
+		sink, err := d.newSink(d.cfg.Sinks[name])
+		if err == nil {
+			err = sink.Send(ctx, n)
+		}
+		results = append(results, Result{Sink: name, Error: err})
+	}
+	return results
+}
+
+// DispatchTo sends n to the named sink regardless of its routing rules,
+// still honoring its hourly limit.
+func (d *Dispatcher) DispatchTo(ctx context.Context, name string, n Notification) Result {
+	cfg, ok := d.cfg.Sinks[name]
+	if !ok {
+		return Result{Sink: name, Error: fmt.Errorf("unknown sink %q", name)}
+	}
+	if n.Time.IsZero() {
+		n.Time = time.Now()
+	}
+	if n.Severity == "" {
+		n.Severity = config.SeverityMedium
+	}
+
+	if !reserve(d.townRoot, map[string]int{name: cfg.MaxPerHour}, n.Time)[name] {
+		return Result{Sink: name, RateLimited: true}
+	}
+	sink, err := d.newSink(cfg)
+	if err == nil {
+		err = sink.Send(ctx, n)
+	}
+	return Result{Sink: name, Error: err}
+}
+
+// Send loads the town's notification config and dispatches n.
+// A town without settings/notifications.json has no sinks, so Send is a no-op.
+func Send(townRoot string, n Notification) ([]Result, error) {
+	cfg, err := config.LoadOrCreateNotificationsConfig(config.NotificationsConfigPath(townRoot))
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Sinks) == 0 {
+		return nil, nil
+	}
+	return NewDispatcher(townRoot, cfg).Dispatch(context.Background(), n), nil
+}
@@ -0,0 +1,261 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestRoutes(t *testing.T) {
+	n := Notification{Event: EventMerged, Severity: config.SeverityLow}
+
+	if !Routes(&config.NotificationSink{}, n) {
+		t.Error("sink with no filters should route everything")
+	}
+	if Routes(&config.NotificationSink{Disabled: true}, n) {
+		t.Error("disabled sink should not route")
+	}
+	if Routes(&config.NotificationSink{MinSeverity: config.SeverityHigh}, n) {
+		t.Error("low severity should not pass min_severity high")
+	}
+	if Routes(&config.NotificationSink{Events: []string{EventEscalation}}, n) {
+		t.Error("event filter should exclude merged")
+	}
+	if !Routes(&config.NotificationSink{Events: []string{EventEscalation, EventMerged}}, n) {
+		t.Error("event filter should include merged")
+	}
+}
+
+func TestStateAllow(t *testing.T) {
+	s := &state{Sent: make(map[string][]time.Time)}
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		if !s.allow("ops", 2, now) {
+			t.Fatalf("delivery %d should be allowed", i+1)
+		}
+	}
+	if s.allow("ops", 2, now) {
+		t.Error("third delivery within the hour should be rate limited")
+	}
+	if !s.allow("ops", 2, now.Add(61*time.Minute)) {
+		t.Error("delivery after the window should be allowed")
+	}
+	if !s.allow("other", 0, now) {
+		t.Error("max_per_hour 0 should be unlimited")
+	}
+}
+
+type recordingSink struct {
+	sent []Notification
+	err  error
+}
+
+func (r *recordingSink) Send(_ context.Context, n Notification) error {
+	r.sent = append(r.sent, n)
+	return r.err
+}
+
+func TestDispatchRoutingAndRateLimit(t *testing.T) {
+	townRoot := t.TempDir()
+	cfg := config.NewNotificationsConfig()
+	cfg.Sinks["all"] = &config.NotificationSink{Type: config.SinkWebhook, URL: "x", MaxPerHour: 1}
+	cfg.Sinks["critical"] = &config.NotificationSink{Type: config.SinkWebhook, URL: "y", MinSeverity: config.SeverityCritical}
+
+	sinks := map[string]*recordingSink{"x": {}, "y": {err: errors.New("boom")}}
+	d := NewDispatcher(townRoot, cfg)
+	d.newSink = func(c *config.NotificationSink) (Sink, error) { return sinks[c.URL], nil }
+
+	results := d.Dispatch(context.Background(), Notification{Event: EventEscalation, Severity: config.SeverityHigh, Title: "help"})
+	if len(results) != 1 || results[0].Sink != "all" || results[0].Error != nil {
+		t.Fatalf("results = %+v, want one successful delivery to 'all'", results)
+	}
+	if len(sinks["x"].sent) != 1 {
+		t.Errorf("sink x got %d notifications, want 1", len(sinks["x"].sent))
+	}
+
+	// Rate limit state persists across dispatchers (i.e. gt invocations).
+	d2 := NewDispatcher(townRoot, cfg)
+	d2.newSink = d.newSink
+	results = d2.Dispatch(context.Background(), Notification{Event: EventEscalation, Severity: config.SeverityCritical, Title: "help"})
+	if len(results) != 2 {
+		t.Fatalf("results = %+v, want 2", results)
+	}
+	if !results[0].RateLimited {
+		t.Errorf("'all' should be rate limited: %+v", results[0])
+	}
+	if results[1].Sink != "critical" || results[1].Error == nil {
+		t.Errorf("'critical' should report its send error: %+v", results[1])
+	}
+}
+
+func TestDispatchTo(t *testing.T) {
+	cfg := config.NewNotificationsConfig()
+	cfg.Sinks["ops"] = &config.NotificationSink{Type: config.SinkWebhook, URL: "x", MinSeverity: config.SeverityCritical, MaxPerHour: 1}
+
+	sink := &recordingSink{}
+	d := NewDispatcher(t.TempDir(), cfg)
+	d.newSink = func(*config.NotificationSink) (Sink, error) { return sink, nil }
+
+	// Named delivery bypasses routing rules but not the rate limit
+	if res := d.DispatchTo(context.Background(), "ops", Notification{Event: "kill", Title: "kill"}); res.Error != nil || res.RateLimited {
+		t.Fatalf("result = %+v, want delivery", res)
+	}
+	if len(sink.sent) != 1 || sink.sent[0].Severity != config.SeverityMedium {
+		t.Errorf("sent = %+v, want one medium notification", sink.sent)
+	}
+	if res := d.DispatchTo(context.Background(), "ops", Notification{Event: "kill"}); !res.RateLimited {
+		t.Errorf("result = %+v, want rate limited", res)
+	}
+	if res := d.DispatchTo(context.Background(), "missing", Notification{}); res.Error == nil {
+		t.Error("unknown sink should be an error")
+	}
+}
+
+func TestSinkPayloads(t *testing.T) {
+	var got map[string]interface{}
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	n := Notification{Event: EventCostAlert, Severity: config.SeverityHigh, Title: "Budget exceeded", Source: "gt-costs"}
+
+	if err := (&SlackSink{URL: srv.URL}).Send(context.Background(), n); err != nil {
+		t.Fatalf("slack: %v", err)
+	}
+	if text, _ := got["text"].(string); text != n.Text() {
+		t.Errorf("slack text = %q, want %q", text, n.Text())
+	}
+
+	if err := (&DiscordSink{URL: srv.URL}).Send(context.Background(), n); err != nil {
+		t.Fatalf("discord: %v", err)
+	}
+	if _, ok := got["content"]; !ok {
+		t.Errorf("discord payload missing content: %v", got)
+	}
+
+	hook := &WebhookSink{URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer t"}}
+	if err := hook.Send(context.Background(), n); err != nil {
+		t.Fatalf("webhook: %v", err)
+	}
+	if got["event"] != EventCostAlert || auth != "Bearer t" {
+		t.Errorf("webhook payload = %v, auth = %q", got, auth)
+	}
+}
+
+func TestDiscordSinkTruncates(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	// A multi-byte rune straddles the cut point
+	n := Notification{Title: "x", Body: strings.Repeat("é", discordMaxContent)}
+	if err := (&DiscordSink{URL: srv.URL}).Send(context.Background(), n); err != nil {
+		t.Fatalf("discord: %v", err)
+	}
+	content := got["content"]
+	if len(content) > discordMaxContent {
+		t.Errorf("content is %d bytes, want at most %d", len(content), discordMaxContent)
+	}
+	if !utf8.ValidString(content) {
+		t.Error("content was cut inside a rune")
+	}
+	if !strings.HasSuffix(content, "é…") {
+		t.Errorf("content ends %q, want the last whole rune then an ellipsis", content[len(content)-8:])
+	}
+}
+
+func TestSinkHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	if err := (&SlackSink{URL: srv.URL}).Send(context.Background(), Notification{Title: "x"}); err == nil {
+		t.Error("expected error for 500 response")
+	}
+}
+
+func TestDesktopSinkCommand(t *testing.T) {
+	n := Notification{Event: EventMail, Severity: config.SeverityCritical, Title: `Say "hi"`, Source: "mayor"}
+
+	name, args := (&DesktopSink{GOOS: "darwin"}).command(n)
+	want := `display notification "from mayor" with title "Gas Town: Say \"hi\""`
+	if name != "osascript" || len(args) != 2 || args[1] != want {
+		t.Errorf("darwin command = %s %q, want osascript -e %q", name, args, want)
+	}
+
+	name, args = (&DesktopSink{GOOS: "linux"}).command(n)
+	if name != "notify-send" || strings.Join(args, "|") != `--app-name=Gas Town|--urgency=critical|Gas Town: Say "hi"|from mayor` {
+		t.Errorf("linux command = %s %q", name, args)
+	}
+
+	var ran []string
+	orig := runCommand
+	runCommand = func(_ context.Context, name string, args ...string) error {
+		ran = append([]string{name}, args...)
+		return errors.New("no display")
+	}
+	defer func() { runCommand = orig }()
+	if err := (&DesktopSink{GOOS: "linux"}).Send(context.Background(), n); err == nil || len(ran) == 0 || ran[0] != "notify-send" {
+		t.Errorf("Send() = %v, ran %q", err, ran)
+	}
+}
+
+func TestSendWithoutConfigIsNoop(t *testing.T) {
+	results, err := Send(t.TempDir(), Notification{Event: EventTest})
+	if err != nil || results != nil {
+		t.Errorf("Send() = %v, %v; want nil, nil", results, err)
+	}
+}
+
+func TestReserveConcurrent(t *testing.T) {
+	townRoot := t.TempDir()
+	now := time.Now()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if reserve(townRoot, map[string]int{"ops": 5}, now)["ops"] {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if allowed != 5 {
+		t.Errorf("%d concurrent deliveries allowed, want 5", allowed)
+	}
+	if sent := loadState(townRoot).Sent["ops"]; len(sent) != 5 {
+		t.Errorf("state recorded %d deliveries, want 5", len(sent))
+	}
+}
+
+func TestHeaderValue(t *testing.T) {
+	got := headerValue("[gastown HIGH] stuck\r\nBcc: victim@example.com")
+	if strings.ContainsAny(got, "\r\n") {
+		t.Errorf("headerValue kept a line break: %q", got)
+	}
+	if got := headerValue("plain subject"); got != "plain subject" {
+		t.Errorf("headerValue(ASCII) = %q", got)
+	}
+}
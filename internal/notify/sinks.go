@@ -0,0 +1,180 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// httpTimeout bounds each webhook delivery so a slow endpoint can't stall
+// the command that raised the notification.
+const httpTimeout = 10 * time.Second
+
+// Sink delivers notifications to one external destination.
+type Sink interface {
+	Send(ctx context.Context, n Notification) error
+}
+
+// NewSink builds the sink described by cfg.
+func NewSink(cfg *config.NotificationSink) (Sink, error) {
+	switch cfg.Type {
+	case config.SinkSlack:
+		return &SlackSink{URL: cfg.URL}, nil
+	case config.SinkDiscord:
+		return &DiscordSink{URL: cfg.URL}, nil
+	case config.SinkWebhook:
+		return &WebhookSink{URL: cfg.URL, Headers: cfg.Headers}, nil
+	case config.SinkEmail:
+		port := cfg.SMTPPort
+		if port == 0 {
+			port = 587
+		}
+		return &EmailSink{
+			Host:     cfg.SMTPHost,
+			Port:     port,
+			From:     cfg.From,
+			To:       cfg.To,
+			Username: cfg.Username,
+			Password: os.Getenv(cfg.PasswordEnv),
+		}, nil
+	case config.SinkDesktop:
+		return &DesktopSink{}, nil
+	case config.SinkBell:
+		return &BellSink{}, nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", cfg.Type)
+	}
+}
+
+// postJSON POSTs payload as JSON and treats any non-2xx response as an error.
+func postJSON(ctx context.Context, url string, headers map[string]string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, httpTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return nil
+}
+
+// SlackSink posts to a Slack incoming webhook.
+type SlackSink struct {
+	URL string
+}
+
+// Send implements Sink.
+func (s *SlackSink) Send(ctx context.Context, n Notification) error {
+	return postJSON(ctx, s.URL, nil, map[string]string{"text": n.Text()})
+}
+
+// DiscordSink posts to a Discord webhook.
+type DiscordSink struct {
+	URL string
+}
+
+// discordMaxContent is Discord's message length limit.
+const discordMaxContent = 2000
+
+// Send implements Sink.
+func (s *DiscordSink) Send(ctx context.Context, n Notification) error {
+	text := n.Text()
+	if len(text) > discordMaxContent {
+		// Cut on a rune boundary, leaving room for the ellipsis
+		n := discordMaxContent - len("…")
+		for n > 0 && !utf8.RuneStart(text[n]) {
+			n--
+		}
+		text = text[:n] + "…"
+	}
+	return postJSON(ctx, s.URL, nil, map[string]string{"content": text})
+}
+
+// WebhookSink POSTs the notification as JSON to an arbitrary endpoint.
+type WebhookSink struct {
+	URL     string
+	Headers map[string]string
+}
+
+// Send implements Sink.
+func (s *WebhookSink) Send(ctx context.Context, n Notification) error {
+	return postJSON(ctx, s.URL, s.Headers, n)
+}
+
+// EmailSink sends notifications over SMTP.
+type EmailSink struct {
+	Host     string
+	Port     int
+	From     string
+	To       []string
+	Username string
+	Password string
+}
+
+// Send implements Sink.
+func (s *EmailSink) Send(_ context.Context, n Notification) error {
+	from := s.From
+	if from == "" {
+		from = "gastown@" + s.Host
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", headerValue(n.Subject()))
+	msg.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(n.Body)
+	msg.WriteString("\r\n")
+
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+	addr := s.Host + ":" + strconv.Itoa(s.Port)
+	return smtp.SendMail(addr, auth, from, s.To, []byte(msg.String()))
+}
+
+// headerValue makes s safe as an email header value: line breaks, which
+// would start headers of the sender's choosing, become spaces, and
+// non-ASCII text is encoded.
+func headerValue(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '\r' || r == '\n' {
+			return ' '
+		}
+		return r
+	}, s)
+	return mime.QEncoding.Encode("utf-8", s)
+}
@@ -0,0 +1,117 @@
+package polecat
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/container"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// containerConfig returns a rig's container settings, or nil if its polecats
+// run on the host.
+func containerConfig(rigPath string) *config.ContainerConfig {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+	if err != nil {
+		return nil
+	}
+	return settings.Container
+}
+
+// containerMounts returns the bind mounts for a polecat's container. Besides
+// the polecat's own directory, gt, git and bd inside the container need the
+// git directory the worktree points into, the rig and town beads, and enough
+// of the town layout (read-only) to find the workspace. Everything is mounted
+// at its host path so paths in the container match the host's.
+func (m *SessionManager) containerMounts(polecat, workDir, runtimeConfigDir string, cc *config.ContainerConfig) ([]container.Mount, error) {
+	townRoot := filepath.Dir(m.rig.Path)
+	shared := []struct {
+		path     string
+		readOnly bool
+	}{
+		{m.polecatDir(polecat), false},
+		{filepath.Join(m.rig.Path, ".repo.git"), false},
+		{filepath.Join(m.rig.Path, "mayor", "rig", ".git"), false},
+		{filepath.Join(m.rig.Path, ".beads"), false}, // redirects may pass through it
+		{beads.ResolveBeadsDir(workDir), false},
+		{filepath.Join(townRoot, ".beads"), false},
+		{filepath.Join(townRoot, "mayor"), true},
+		{filepath.Join(m.rig.Path, "config.json"), true},
+		{filepath.Join(m.rig.Path, "polecats", ".claude"), true},
+		{m.ResumeFilePath(polecat), true}, // left by Restart for the new session
+		{runtimeConfigDir, false},
+	}
+
+	var mounts []container.Mount
+	seen := make(map[string]bool)
+	for _, s := range shared {
+		if s.path == "" || seen[s.path] {
+			continue
+		}
+		if _, err := os.Stat(s.path); err != nil {
+			continue
+		}
+		seen[s.path] = true
+		mounts = append(mounts, container.Mount{Source: s.path, Target: s.path, ReadOnly: s.readOnly})
+	}
+	for _, spec := range cc.Mounts {
+		mount, err := container.ParseMount(spec)
+		if err != nil {
+			return nil, err
+		}
+		mounts = append(mounts, mount)
+	}
+	return mounts, nil
+}
+
+// containerEnvFile returns the path of the env file a polecat's agent is
+// exec'd with, in the rig's runtime dir, which no container mounts.
+func containerEnvFile(rigPath, name string) string {
+	return filepath.Join(constants.RigRuntimePath(rigPath), "containers", name+".env")
+}
+
+// startContainer starts a polecat's container and returns command rewritten
+// to run inside it with env set.
+func (m *SessionManager) startContainer(cc *config.ContainerConfig, polecat, workDir, runtimeConfigDir, command string, env map[string]string) (string, error) {
+	mounts, err := m.containerMounts(polecat, workDir, runtimeConfigDir, cc)
+	if err != nil {
+		return "", err
+	}
+	name := m.SessionName(polecat)
+	rt := container.New(cc.Runtime)
+	if err := rt.Start(container.Spec{
+		Name:      name,
+		Image:     cc.Image,
+		Agent:     m.address(polecat),
+		WorkDir:   workDir,
+		Mounts:    mounts,
+		CPUs:      cc.CPUs,
+		Memory:    cc.Memory,
+		PidsLimit: cc.PidsLimit,
+		Network:   cc.Network,
+	}); err != nil {
+		return "", err
+	}
+	envFile := containerEnvFile(m.rig.Path, name)
+	if err := container.WriteEnvFile(envFile, env); err != nil {
+		_ = rt.Remove(name) // best-effort cleanup
+		return "", fmt.Errorf("writing env file: %w", err)
+	}
+	return rt.ExecCommand(name, workDir, envFile, command), nil
+}
+
+// removeContainer removes a polecat's container if its rig runs polecats in
+// containers. The container is named after the polecat's session.
+func removeContainer(r *rig.Rig, polecat string) error {
+	cc := containerConfig(r.Path)
+	if cc == nil {
+		return nil
+	}
+	name := fmt.Sprintf("gt-%s-%s", r.Name, polecat)
+	_ = os.Remove(containerEnvFile(r.Path, name))
+	return container.New(cc.Runtime).Remove(name)
+}
@@ -0,0 +1,55 @@
+package polecat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/container"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestContainerMounts(t *testing.T) {
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "gastown")
+	workDir := filepath.Join(rigPath, "polecats", "toast", "gastown")
+	for _, dir := range []string{workDir, filepath.Join(rigPath, ".repo.git"), filepath.Join(rigPath, ".beads"), filepath.Join(townRoot, "mayor")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Join(workDir, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, ".beads", "redirect"), []byte("../../../.beads\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewSessionManager(nil, &rig.Rig{Name: "gastown", Path: rigPath})
+	cc := &config.ContainerConfig{Image: "agent", Mounts: []string{"/opt/tools:/tools:ro"}}
+	mounts, err := m.containerMounts("toast", workDir, "", cc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]container.Mount)
+	for _, mount := range mounts {
+		got[mount.Target] = mount
+	}
+	for _, path := range []string{filepath.Join(rigPath, "polecats", "toast"), filepath.Join(rigPath, ".repo.git"), filepath.Join(rigPath, ".beads")} {
+		if mount, ok := got[path]; !ok || mount.Source != path || mount.ReadOnly {
+			t.Errorf("mount for %s = %+v, want read-write at its host path", path, mount)
+		}
+	}
+	if mount := got[filepath.Join(townRoot, "mayor")]; !mount.ReadOnly {
+		t.Errorf("town mayor dir mount = %+v, want read-only", mount)
+	}
+	if _, ok := got[filepath.Join(rigPath, "mayor", "rig", ".git")]; ok {
+		t.Error("mounted a git dir that doesn't exist")
+	}
+	if mount := got["/tools"]; mount.Source != "/opt/tools" || !mount.ReadOnly {
+		t.Errorf("configured mount = %+v", mount)
+	}
+}
@@ -0,0 +1,309 @@
+package polecat
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// Liveness check names, as used in a rig's liveness settings.
+const (
+	LivenessSession = "session" // the tmux session exists
+	LivenessPane    = "pane"    // the pane produced output recently
+	LivenessGit     = "git"     // recent commits or file edits in the worktree
+	LivenessBeads   = "beads"   // the hooked issue was updated recently
+	LivenessScript  = "script"  // a rig-provided command says so
+)
+
+// scriptCheckTimeout bounds a liveness script run.
+const scriptCheckTimeout = 10 * time.Second
+
+// errNoReading means a check had nothing to look at (e.g., no hooked issue).
+// Such a check is left out of the vote rather than counted as inactive.
+var errNoReading = errors.New("no reading")
+
+// LivenessTarget is the polecat session a liveness check looks at.
+type LivenessTarget struct {
+	Rig       string
+	Polecat   string
+	SessionID string
+	WorkDir   string
+	Issue     string        // hooked issue, if known
+	Window    time.Duration // how recent activity must be to count
+	Now       time.Time
+}
+
+// LivenessCheck is one signal that a polecat's agent is still working.
+type LivenessCheck interface {
+	// Name identifies the check in rig settings.
+	Name() string
+
+	// Check reports whether the signal shows activity within the target's
+	// window, with a short reading for display.
+	Check(t LivenessTarget) (active bool, detail string, err error)
+}
+
+// LivenessSignal is one check's reading in a health report.
+type LivenessSignal struct {
+	Check  string  `json:"check"`
+	Active bool    `json:"active"`
+	Weight float64 `json:"weight"`
+	Detail string  `json:"detail,omitempty"`
+	Error  string  `json:"error,omitempty"` // reading failed; not counted
+}
+
+// livenessChecks returns the checks enabled for the rig with their weights.
+// Without settings, pane, git and beads activity each get one vote.
+func (m *SessionManager) livenessChecks(cfg *config.LivenessConfig) ([]LivenessCheck, []float64) {
+	builtins := []struct {
+		check LivenessCheck
+		on    bool
+	}{
+		{sessionCheck{m.tmux}, false},
+		{paneCheck{m.tmux}, true},
+		{gitCheck{}, true},
+		{beadsCheck{}, true},
+		{scriptCheck{}, false},
+	}
+
+	var checks []LivenessCheck
+	var weights []float64
+	for _, b := range builtins {
+		on, weight := b.on, 1.0
+		if cfg != nil {
+			if c := cfg.Checks[b.check.Name()]; c != nil {
+				if b.check.Name() == LivenessScript {
+					b.check = scriptCheck{command: c.Command}
+					on = c.Command != ""
+				}
+				if c.Enabled != nil {
+					on = *c.Enabled
+				}
+				if c.Weight > 0 {
+					weight = c.Weight
+				}
+			}
+		}
+		if on {
+			checks = append(checks, b.check)
+			weights = append(weights, weight)
+		}
+	}
+	return checks, weights
+}
+
+// livenessConfig returns the rig's liveness settings, or nil for defaults.
+func (m *SessionManager) livenessConfig() *config.LivenessConfig {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(m.rig.Path))
+	if err != nil {
+		return nil
+	}
+	return settings.Liveness
+}
+
+// liveness runs the rig's liveness checks against a polecat's session and
+// returns each reading, looking for activity within window.
+func (m *SessionManager) liveness(polecat string, window time.Duration, cfg *config.LivenessConfig) []LivenessSignal {
+	target := LivenessTarget{
+		Rig:       m.rig.Name,
+		Polecat:   polecat,
+		SessionID: m.SessionName(polecat),
+		WorkDir:   m.clonePath(polecat),
+		Window:    window,
+		Now:       time.Now(),
+	}
+	if reg, err := loadSessionRegistry(SessionsFilePath(m.rig.Path)); err == nil {
+		if rec := reg.Sessions[polecat]; rec != nil {
+			target.Issue = rec.Issue
+		}
+	}
+
+	checks, weights := m.livenessChecks(cfg)
+	signals := make([]LivenessSignal, 0, len(checks))
+	for i, check := range checks {
+		sig := LivenessSignal{Check: check.Name(), Weight: weights[i]}
+		active, detail, err := check.Check(target)
+		switch {
+		case errors.Is(err, errNoReading):
+			continue
+		case err != nil:
+			sig.Error = err.Error()
+		default:
+			sig.Active, sig.Detail = active, detail
+		}
+		signals = append(signals, sig)
+	}
+	return signals
+}
+
+// livenessScore is the share of the counted checks' weight that reported
+// activity. Failed readings aren't counted. ok is false if nothing was.
+func livenessScore(signals []LivenessSignal) (score float64, ok bool) {
+	var total, active float64
+	for _, s := range signals {
+		if s.Error != "" {
+			continue
+		}
+		total += s.Weight
+		if s.Active {
+			active += s.Weight
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return active / total, true
+}
+
+// sessionCheck is active while the tmux session exists. It is off by
+// default: Health already requires the session, so it would count every
+// live session as working.
+type sessionCheck struct{ tmux *tmux.Tmux }
+
+func (sessionCheck) Name() string { return LivenessSession }
+
+func (c sessionCheck) Check(t LivenessTarget) (bool, string, error) {
+	running, err := c.tmux.HasSession(t.SessionID)
+	if err != nil {
+		return false, "", err
+	}
+	if !running {
+		return false, "no session", nil
+	}
+	return true, "session exists", nil
+}
+
+// paneCheck is active if the pane produced output within the window.
+type paneCheck struct{ tmux *tmux.Tmux }
+
+func (paneCheck) Name() string { return LivenessPane }
+
+func (c paneCheck) Check(t LivenessTarget) (bool, string, error) {
+	activity, err := c.tmux.GetWindowActivity(t.SessionID)
+	if err != nil {
+		return false, "", err
+	}
+	active, detail := paneActivity(activity, t.Now.Sub(activity), t.Window)
+	return active, detail, nil
+}
+
+// paneActivity judges pane output last seen idle ago. Unknown activity
+// counts as active, so a tmux without activity tracking never looks wedged.
+func paneActivity(lastActivity time.Time, idle, window time.Duration) (bool, string) {
+	if lastActivity.IsZero() {
+		return true, "pane activity unknown"
+	}
+	if idle > window {
+		return false, fmt.Sprintf("no pane output for %s", idle.Round(time.Second))
+	}
+	return true, fmt.Sprintf("last output %s ago", idle.Round(time.Second))
+}
+
+// gitCheck is active if the worktree has a commit or an edited file newer
+// than the window.
+type gitCheck struct{}
+
+func (gitCheck) Name() string { return LivenessGit }
+
+func (gitCheck) Check(t LivenessTarget) (bool, string, error) {
+	g := git.NewGit(t.WorkDir)
+	if !g.IsRepo() {
+		return false, "", errNoReading
+	}
+
+	var latest time.Time
+	what := "commit"
+	if commit, err := g.LastCommitTime(); err == nil {
+		latest = commit
+	}
+	changed, _ := g.ChangedFiles()
+	untracked, _ := g.UntrackedFiles()
+	for _, f := range append(changed, untracked...) {
+		if info, err := os.Stat(filepath.Join(t.WorkDir, f)); err == nil && info.ModTime().After(latest) {
+			latest, what = info.ModTime(), "edit"
+		}
+	}
+	if latest.IsZero() {
+		return false, "", errNoReading
+	}
+
+	age := t.Now.Sub(latest).Round(time.Second)
+	return age <= t.Window, fmt.Sprintf("last %s %s ago", what, age), nil
+}
+
+// beadsCheck is active if the polecat's hooked issue was updated within the
+// window.
+type beadsCheck struct{}
+
+func (beadsCheck) Name() string { return LivenessBeads }
+
+func (beadsCheck) Check(t LivenessTarget) (bool, string, error) {
+	if t.Issue == "" {
+		return false, "", errNoReading
+	}
+	issue, err := beads.New(t.WorkDir).Show(t.Issue)
+	if err != nil {
+		return false, "", err
+	}
+	updated, err := time.Parse(time.RFC3339, issue.UpdatedAt)
+	if err != nil {
+		return false, "", fmt.Errorf("parsing updated_at of %s: %w", t.Issue, err)
+	}
+	age := t.Now.Sub(updated).Round(time.Second)
+	return age <= t.Window, fmt.Sprintf("%s updated %s ago", t.Issue, age), nil
+}
+
+// scriptCheck runs a rig-provided shell command in the polecat's worktree
+// with GT_RIG, GT_POLECAT, GT_SESSION and GT_WINDOW (seconds) set. Exit 0
+// means active, exit 1 inactive; the first line of output is the detail.
+type scriptCheck struct{ command string }
+
+func (scriptCheck) Name() string { return LivenessScript }
+
+func (c scriptCheck) Check(t LivenessTarget) (bool, string, error) {
+	if c.command == "" {
+		return false, "", errNoReading
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), scriptCheckTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", c.command) //nolint:gosec // G204: command comes from rig settings
+	cmd.Dir = t.WorkDir
+	cmd.Env = append(os.Environ(),
+		"GT_RIG="+t.Rig,
+		"GT_POLECAT="+t.Polecat,
+		"GT_SESSION="+t.SessionID,
+		fmt.Sprintf("GT_WINDOW=%d", int(t.Window.Seconds())),
+	)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	err := cmd.Run()
+
+	detail, _, _ := strings.Cut(strings.TrimSpace(out.String()), "\n")
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		if detail == "" {
+			detail = "script reports active"
+		}
+		return true, detail, nil
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		if detail == "" {
+			detail = "script reports inactive"
+		}
+		return false, detail, nil
+	default:
+		return false, "", fmt.Errorf("liveness script: %w", err)
+	}
+}
@@ -0,0 +1,73 @@
+package polecat
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func checkNames(checks []LivenessCheck) string {
+	names := make([]string, len(checks))
+	for i, c := range checks {
+		names[i] = c.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+func TestLivenessChecksConfig(t *testing.T) {
+	m := NewSessionManager(tmux.NewTmux(), &rig.Rig{Name: "liveness-test", Path: t.TempDir()})
+	off, on := false, true
+
+	tests := []struct {
+		name    string
+		cfg     *config.LivenessConfig
+		want    string
+		weights []float64
+	}{
+		{"defaults", nil, "pane,git,beads", []float64{1, 1, 1}},
+		{"disable and weight", &config.LivenessConfig{Checks: map[string]*config.LivenessCheckConfig{
+			LivenessBeads: {Enabled: &off},
+			LivenessGit:   {Weight: 3},
+		}}, "pane,git", []float64{1, 3}},
+		{"script on with command", &config.LivenessConfig{Checks: map[string]*config.LivenessCheckConfig{
+			LivenessScript: {Command: "true"},
+		}}, "pane,git,beads,script", []float64{1, 1, 1, 1}},
+		{"session enabled", &config.LivenessConfig{Checks: map[string]*config.LivenessCheckConfig{
+			LivenessSession: {Enabled: &on},
+		}}, "session,pane,git,beads", []float64{1, 1, 1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks, weights := m.livenessChecks(tt.cfg)
+			if got := checkNames(checks); got != tt.want {
+				t.Errorf("checks = %s, want %s", got, tt.want)
+			}
+			for i := range tt.weights {
+				if i >= len(weights) || weights[i] != tt.weights[i] {
+					t.Errorf("weights = %v, want %v", weights, tt.weights)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestScriptCheck(t *testing.T) {
+	target := LivenessTarget{Rig: "r", Polecat: "Toast", WorkDir: t.TempDir(), Window: time.Minute}
+
+	active, detail, err := scriptCheck{command: `echo "building $GT_POLECAT"`}.Check(target)
+	if err != nil || !active || detail != "building Toast" {
+		t.Errorf("exit 0: got (%v, %q, %v)", active, detail, err)
+	}
+	active, detail, err = scriptCheck{command: "exit 1"}.Check(target)
+	if err != nil || active || detail != "script reports inactive" {
+		t.Errorf("exit 1: got (%v, %q, %v)", active, detail, err)
+	}
+	if _, _, err := (scriptCheck{command: "exit 2"}).Check(target); err == nil {
+		t.Error("exit 2: expected an error")
+	}
+}
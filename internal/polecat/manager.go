@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -183,6 +182,16 @@ func (m *Manager) repoBase() (*git.Git, error) {
 	return git.NewGit(mayorPath), nil
 }
 
+// sparsePatterns returns the rig's sparse-checkout patterns for polecat
+// worktrees, or nil to check out the whole tree.
+func sparsePatterns(rigPath string) []string {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+	if err != nil || settings.Checkout == nil {
+		return nil
+	}
+	return settings.Checkout.Sparse
+}
+
 // polecatDir returns the parent directory for a polecat.
 // This is polecats/<name>/ - the polecat's home directory.
 func (m *Manager) polecatDir(name string) string {
@@ -290,7 +299,7 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (*Polecat, error)
 	// Always create fresh branch - unique name guarantees no collision
 	// git worktree add -b polecat/<name>-<timestamp> <path> <startpoint>
 	// Worktree goes in polecats/<name>/<rigname>/ for LLM ergonomics
-	if err := repoGit.WorktreeAddFromRef(clonePath, branchName, startPoint); err != nil {
+	if err := repoGit.WorktreeAddFromRefSparse(clonePath, branchName, startPoint, sparsePatterns(m.rig.Path)); err != nil {
 		return nil, fmt.Errorf("creating worktree from %s: %w", startPoint, err)
 	}
 
@@ -433,6 +442,14 @@ func (m *Manager) RemoveWithOptions(name string, force, nuclear bool) error {
 		}
 	}
 
+	// A containerized polecat's container outlives its session; it goes with
+	// the worktree (non-fatal)
+	if err := removeContainer(m.rig, name); err != nil {
+		fmt.Printf("Warning: could not remove container: %v\n", err)
+	}
+	// So does the resume file Restart left outside the polecat directory
+	_ = os.Remove(resumeFilePath(m.rig.Path, name))
+
 	// Get repo base to remove the worktree properly
 	repoGit, err := m.repoBase()
 	if err != nil {
@@ -614,7 +631,7 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 	} else {
 		branchName = fmt.Sprintf("polecat/%s-%s", name, timestamp)
 	}
-	if err := repoGit.WorktreeAddFromRef(newClonePath, branchName, startPoint); err != nil {
+	if err := repoGit.WorktreeAddFromRefSparse(newClonePath, branchName, startPoint, sparsePatterns(m.rig.Path)); err != nil {
 		return nil, fmt.Errorf("creating fresh worktree from %s: %w", startPoint, err)
 	}
 
@@ -736,6 +753,7 @@ func (m *Manager) ReconcilePoolWith(namesWithDirs, namesWithSessions []string) {
 			if !dirSet[name] {
 				sessionName := fmt.Sprintf("gt-%s-%s", m.rig.Name, name)
 				_ = m.tmux.KillSessionWithProcesses(sessionName)
+				_ = removeContainer(m.rig, name)
 			}
 		}
 	}
@@ -1073,7 +1091,7 @@ func (m *Manager) DetectStalePolecats(threshold int) ([]*StalenessInfo, error) {
 // checkTmuxSession checks if a tmux session exists.
 func checkTmuxSession(sessionName string) bool {
 	// Use has-session command which returns 0 if session exists
-	cmd := exec.Command("tmux", "has-session", "-t", sessionName) //nolint:gosec // G204: sessionName is constructed internally
+	cmd := tmux.Command("has-session", "-t", sessionName)
 	return cmd.Run() == nil
 }
 
@@ -0,0 +1,167 @@
+package polecat
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ExitReason says why a polecat session ended.
+type ExitReason string
+
+const (
+	// ExitUserStop: stopped through the session manager (gt session stop,
+	// gt polecat nuke, restart, ...).
+	ExitUserStop ExitReason = "user-stop"
+
+	// ExitWitnessKill: killed by the witness, e.g. while nuking a finished polecat.
+	ExitWitnessKill ExitReason = "witness-kill"
+
+	// ExitScheduleStop: stopped by the daemon when the rig's session
+	// window closed.
+	ExitScheduleStop ExitReason = "schedule-stop"
+
+	// ExitRecycle: restarted by the witness because the agent's context
+	// looked exhausted or the session had run too long.
+	ExitRecycle ExitReason = "recycle"
+
+	// ExitAgentExit: the agent process exited and took the session with it.
+	ExitAgentExit ExitReason = "agent-exit"
+
+	// ExitServerDeath: the tmux server went away with the session in it.
+	ExitServerDeath ExitReason = "server-death"
+)
+
+// ExitRecord is one entry in a rig's terminations log.
+type ExitRecord struct {
+	Polecat  string     `json:"polecat"`
+	Reason   ExitReason `json:"reason"`
+	ExitCode *int       `json:"exit_code,omitempty"` // agent exit status, when known
+	Detail   string     `json:"detail,omitempty"`
+	At       time.Time  `json:"at"`
+}
+
+// terminationsFileMu serializes appends to terminations logs.
+var terminationsFileMu sync.Mutex
+
+// TerminationsFilePath returns the path of a rig's polecat terminations log.
+func TerminationsFilePath(rigPath string) string {
+	return filepath.Join(rigPath, ".runtime", "terminations.jsonl")
+}
+
+// RecordExit appends an exit to a rig's terminations log. At defaults to now.
+func RecordExit(rigPath string, rec *ExitRecord) error {
+	if rec.At.IsZero() {
+		rec.At = time.Now()
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	terminationsFileMu.Lock()
+	defer terminationsFileMu.Unlock()
+
+	path := TerminationsFilePath(rigPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: not sensitive
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LastExit returns the most recent recorded exit of a polecat's session,
+// or nil if none was recorded.
+func LastExit(rigPath, polecat string) (*ExitRecord, error) {
+	f, err := os.Open(TerminationsFilePath(rigPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var last *ExitRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec ExitRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			continue // skip torn or foreign lines
+		}
+		if rec.Polecat == polecat {
+			last = &rec
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading terminations log: %w", err)
+	}
+	return last, nil
+}
+
+// FindExits finds sessions that ended without being stopped through the
+// session manager by diffing the session registry against live tmux
+// sessions, without recording anything. A session counts as lost to the
+// server when no tmux server is running at all; otherwise the agent is
+// taken to have exited. Exits already recorded since the session started
+// (e.g., by the pane-died hook, which knows the exit code) are skipped.
+func (m *SessionManager) FindExits() ([]*ExitRecord, error) {
+	records, err := LoadSessionRecords(m.rig.Path)
+	if err != nil {
+		return nil, fmt.Errorf("loading session registry: %w", err)
+	}
+
+	var found []*ExitRecord
+	for _, rec := range records {
+		if !rec.Running {
+			continue
+		}
+		if running, err := m.IsRunning(rec.Polecat); err != nil || running {
+			continue
+		}
+		last, err := LastExit(m.rig.Path, rec.Polecat)
+		if err != nil {
+			return found, err
+		}
+		if last != nil && !last.At.Before(rec.StartedAt) {
+			continue
+		}
+
+		exit := &ExitRecord{
+			Polecat: rec.Polecat,
+			Reason:  ExitAgentExit,
+			Detail:  "session disappeared; exit code unknown",
+		}
+		if !m.tmux.IsServerRunning() {
+			exit.Reason = ExitServerDeath
+			exit.Detail = "tmux server not running"
+		}
+		found = append(found, exit)
+	}
+	return found, nil
+}
+
+// DetectExits finds sessions that went away unnoticed (see FindExits) and
+// records an exit for each.
+func (m *SessionManager) DetectExits() ([]*ExitRecord, error) {
+	found, err := m.FindExits()
+	var detected []*ExitRecord
+	for _, exit := range found {
+		if err := RecordExit(m.rig.Path, exit); err != nil {
+			return detected, err
+		}
+		detected = append(detected, exit)
+	}
+	return detected, err
+}
@@ -0,0 +1,78 @@
+package polecat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func TestLastExit(t *testing.T) {
+	root := t.TempDir()
+
+	if exit, err := LastExit(root, "Toast"); err != nil || exit != nil {
+		t.Fatalf("LastExit with no log = %v, %v; want nil", exit, err)
+	}
+
+	code := 1
+	for _, rec := range []*ExitRecord{
+		{Polecat: "Toast", Reason: ExitUserStop},
+		{Polecat: "Cheedo", Reason: ExitWitnessKill},
+		{Polecat: "Toast", Reason: ExitAgentExit, ExitCode: &code},
+	} {
+		if err := RecordExit(root, rec); err != nil {
+			t.Fatalf("RecordExit: %v", err)
+		}
+	}
+
+	exit, err := LastExit(root, "Toast")
+	if err != nil {
+		t.Fatalf("LastExit: %v", err)
+	}
+	if exit == nil || exit.Reason != ExitAgentExit || exit.ExitCode == nil || *exit.ExitCode != 1 || exit.At.IsZero() {
+		t.Errorf("LastExit(Toast) = %+v, want agent-exit with code 1", exit)
+	}
+	if exit, _ := LastExit(root, "Cheedo"); exit == nil || exit.Reason != ExitWitnessKill {
+		t.Errorf("LastExit(Cheedo) = %+v, want witness-kill", exit)
+	}
+}
+
+func TestDetectExits(t *testing.T) {
+	requireTmux(t)
+
+	root := t.TempDir()
+	m := NewSessionManager(tmux.NewTmux(), &rig.Rig{Name: "exits-test", Path: root})
+	if err := os.MkdirAll(filepath.Join(root, ".runtime"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	started := time.Now().Add(-time.Minute)
+	for _, rec := range []*SessionRecord{
+		{Polecat: "Lost", StartedAt: started, Running: true},
+		{Polecat: "Recorded", StartedAt: started, Running: true},
+		{Polecat: "Stopped", StartedAt: started},
+	} {
+		if err := m.recordSession(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := RecordExit(root, &ExitRecord{Polecat: "Recorded", Reason: ExitUserStop}); err != nil {
+		t.Fatal(err)
+	}
+
+	detected, err := m.DetectExits()
+	if err != nil {
+		t.Fatalf("DetectExits: %v", err)
+	}
+	if len(detected) != 1 || detected[0].Polecat != "Lost" {
+		t.Fatalf("detected = %+v, want only Lost", detected)
+	}
+
+	// Already recorded: a second pass finds nothing new
+	if detected, err := m.DetectExits(); err != nil || len(detected) != 0 {
+		t.Errorf("second DetectExits = %+v, %v; want none", detected, err)
+	}
+}
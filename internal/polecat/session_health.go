@@ -0,0 +1,124 @@
+package polecat
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// SessionHealth classifies a polecat session's liveness.
+type SessionHealth string
+
+const (
+	// HealthRunning means the agent process is alive and the pane has
+	// produced output recently.
+	HealthRunning SessionHealth = "running"
+
+	// HealthWedged means the agent process is alive but the rig's liveness
+	// checks (by default pane output, git activity, and hooked issue
+	// updates) saw too little activity within the wedged threshold.
+	HealthWedged SessionHealth = "wedged"
+
+	// HealthCrashed means the tmux session exists but the agent process in
+	// its pane has exited.
+	HealthCrashed SessionHealth = "crashed"
+)
+
+// DefaultWedgedAfter is how long a live agent's pane may stay silent before
+// Health reports it as wedged.
+const DefaultWedgedAfter = 30 * time.Minute
+
+// HealthInfo is a heartbeat reading for a polecat session.
+type HealthInfo struct {
+	Polecat      string        `json:"polecat"`
+	SessionID    string        `json:"session_id"`
+	Health       SessionHealth `json:"health"`
+	AgentRunning bool          `json:"agent_running"`
+	LastActivity time.Time     `json:"last_activity,omitempty"`
+	Idle         time.Duration `json:"-"`
+	Reason       string        `json:"reason"`
+
+	// Signals are the liveness check readings behind a live agent's
+	// running/wedged verdict.
+	Signals []LivenessSignal `json:"signals,omitempty"`
+}
+
+// Health takes a heartbeat reading of a polecat's session: whether the agent
+// process is still running inside the pane, and whether the rig's liveness
+// checks saw it do anything within wedgedAfter (DefaultWedgedAfter if zero).
+// A live agent is only wedged when too few checks, by weight, report
+// activity; see config.LivenessConfig. Returns ErrSessionNotFound if the
+// polecat has no session.
+func (m *SessionManager) Health(polecat string, wedgedAfter time.Duration) (*HealthInfo, error) {
+	if wedgedAfter <= 0 {
+		wedgedAfter = DefaultWedgedAfter
+	}
+	sessionID := m.SessionName(polecat)
+
+	running, err := m.tmux.HasSession(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("checking session: %w", err)
+	}
+	if !running {
+		return nil, ErrSessionNotFound
+	}
+
+	info := &HealthInfo{
+		Polecat:   polecat,
+		SessionID: sessionID,
+	}
+
+	info.AgentRunning = m.tmux.IsClaudeRunning(sessionID)
+	if !info.AgentRunning {
+		rc := config.LoadRuntimeConfig(m.rig.Path)
+		if rc.Tmux != nil {
+			info.AgentRunning = m.tmux.IsRuntimeRunning(sessionID, rc.Tmux.ProcessNames)
+		}
+	}
+
+	if activity, err := m.tmux.GetWindowActivity(sessionID); err == nil {
+		info.LastActivity = activity
+		info.Idle = time.Since(activity)
+	}
+
+	var threshold float64
+	if info.AgentRunning {
+		cfg := m.livenessConfig()
+		if cfg != nil {
+			threshold = cfg.Threshold
+		}
+		info.Signals = m.liveness(polecat, wedgedAfter, cfg)
+	}
+	info.Health, info.Reason = classifyHealth(info.AgentRunning, info.Signals, threshold)
+	return info, nil
+}
+
+// classifyHealth turns heartbeat readings into a SessionHealth and a short
+// human-readable reason. A live agent is running when the active checks'
+// share of the weight reaches threshold (any active check if zero).
+func classifyHealth(agentRunning bool, signals []LivenessSignal, threshold float64) (SessionHealth, string) {
+	if !agentRunning {
+		return HealthCrashed, "agent process not running in pane"
+	}
+	score, ok := livenessScore(signals)
+	if !ok {
+		return HealthRunning, "agent running (no liveness readings)"
+	}
+
+	var active, inactive []string
+	for _, s := range signals {
+		switch {
+		case s.Error != "":
+		case s.Active:
+			active = append(active, s.Detail)
+		default:
+			inactive = append(inactive, s.Detail)
+		}
+	}
+	if score > 0 && score >= threshold {
+		return HealthRunning, strings.Join(active, "; ")
+	}
+	return HealthWedged, strings.Join(inactive, "; ")
+}
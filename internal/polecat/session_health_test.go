@@ -0,0 +1,47 @@
+package polecat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClassifyHealth(t *testing.T) {
+	now := time.Now()
+	pane := func(lastActivity time.Time, idle time.Duration) LivenessSignal {
+		active, detail := paneActivity(lastActivity, idle, DefaultWedgedAfter)
+		return LivenessSignal{Check: LivenessPane, Active: active, Weight: 1, Detail: detail}
+	}
+	quietGit := LivenessSignal{Check: LivenessGit, Weight: 1, Detail: "last commit 2h0m0s ago"}
+	busyGit := LivenessSignal{Check: LivenessGit, Active: true, Weight: 1, Detail: "last edit 1m0s ago"}
+
+	tests := []struct {
+		name         string
+		agentRunning bool
+		signals      []LivenessSignal
+		threshold    float64
+		want         SessionHealth
+	}{
+		{"agent exited", false, []LivenessSignal{pane(now, time.Second)}, 0, HealthCrashed},
+		{"agent exited long ago", false, []LivenessSignal{pane(now.Add(-time.Hour), time.Hour)}, 0, HealthCrashed},
+		{"busy", true, []LivenessSignal{pane(now, 5*time.Second)}, 0, HealthRunning},
+		{"quiet but under threshold", true, []LivenessSignal{pane(now.Add(-20*time.Minute), 20*time.Minute)}, 0, HealthRunning},
+		{"silent past threshold", true, []LivenessSignal{pane(now.Add(-time.Hour), time.Hour)}, 0, HealthWedged},
+		{"activity unknown", true, []LivenessSignal{pane(time.Time{}, 0)}, 0, HealthRunning},
+		{"no readings", true, nil, 0, HealthRunning},
+		{"quiet pane, busy git", true, []LivenessSignal{pane(now.Add(-time.Hour), time.Hour), busyGit}, 0, HealthRunning},
+		{"quiet pane and git", true, []LivenessSignal{pane(now.Add(-time.Hour), time.Hour), quietGit}, 0, HealthWedged},
+		{"busy git below threshold", true, []LivenessSignal{pane(now.Add(-time.Hour), time.Hour), busyGit}, 0.75, HealthWedged},
+		{"failed reading not counted", true, []LivenessSignal{busyGit, {Check: LivenessBeads, Weight: 5, Error: "bd not installed"}}, 1, HealthRunning},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := classifyHealth(tt.agentRunning, tt.signals, tt.threshold)
+			if got != tt.want {
+				t.Errorf("classifyHealth = %s (%s), want %s", got, reason, tt.want)
+			}
+			if reason == "" {
+				t.Error("classifyHealth returned empty reason")
+			}
+		})
+	}
+}
@@ -13,6 +13,8 @@ import (
 
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/runtime"
 	"github.com/steveyegge/gastown/internal/session"
@@ -91,6 +93,9 @@ type SessionInfo struct {
 
 	// LastActivity is when the session last had activity.
 	LastActivity time.Time `json:"last_activity,omitempty"`
+
+	// Resources is the CPU and memory used by the session's processes.
+	Resources *tmux.ProcessUsage `json:"resources,omitempty"`
 }
 
 // SessionName generates the tmux session name for a polecat.
@@ -180,34 +185,53 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 		return fmt.Errorf("ensuring runtime settings: %w", err)
 	}
 
+	// Use centralized AgentEnv for consistency across all role startup paths
+	townRoot := filepath.Dir(m.rig.Path)
+	envVars := config.AgentEnv(config.AgentEnvConfig{
+		Role:             "polecat",
+		Rig:              m.rig.Name,
+		AgentName:        polecat,
+		TownRoot:         townRoot,
+		RuntimeConfigDir: opts.RuntimeConfigDir,
+		BeadsNoDaemon:    true,
+	})
+	rigEnv := config.RigEnv(m.rig.Env, envVars)
+
 	// Build startup command first
 	command := opts.Command
 	if command == "" {
 		command = config.BuildPolecatStartupCommand(m.rig.Name, polecat, m.rig.Path, "")
 	}
+	baseCommand := command // recorded for resume; env prefixes are re-applied then
 	// Prepend runtime config dir env if needed
 	if runtimeConfig.Session != nil && runtimeConfig.Session.ConfigDirEnv != "" && opts.RuntimeConfigDir != "" {
 		command = config.PrependEnv(command, map[string]string{runtimeConfig.Session.ConfigDirEnv: opts.RuntimeConfigDir})
 	}
+	// Rig-declared env (rigs.json) must reach the agent process itself
+	command = config.PrependEnv(command, rigEnv)
+
+	// In a containerized rig the session execs the agent in the polecat's
+	// container. tmux's session environment doesn't reach it, so the env is
+	// passed to exec as well, through a file so values stay off the command.
+	cc := containerConfig(m.rig.Path)
+	if cc != nil {
+		command, err = m.startContainer(cc, polecat, workDir, opts.RuntimeConfigDir, command, config.MergeEnv(rigEnv, envVars))
+		if err != nil {
+			return fmt.Errorf("starting container: %w", err)
+		}
+	}
 
 	// Create session with command directly to avoid send-keys race condition.
 	// See: https://github.com/anthropics/gastown/issues/280
 	if err := m.tmux.NewSessionWithCommand(sessionID, workDir, command); err != nil {
+		if cc != nil {
+			debugSession("removeContainer", removeContainer(m.rig, polecat))
+		}
 		return fmt.Errorf("creating session: %w", err)
 	}
 
 	// Set environment (non-fatal: session works without these)
-	// Use centralized AgentEnv for consistency across all role startup paths
-	townRoot := filepath.Dir(m.rig.Path)
-	envVars := config.AgentEnv(config.AgentEnvConfig{
-		Role:             "polecat",
-		Rig:              m.rig.Name,
-		AgentName:        polecat,
-		TownRoot:         townRoot,
-		RuntimeConfigDir: opts.RuntimeConfigDir,
-		BeadsNoDaemon:    true,
-	})
-	for k, v := range envVars {
+	for k, v := range config.MergeEnv(rigEnv, envVars) {
 		debugSession("SetEnvironment "+k, m.tmux.SetEnvironment(sessionID, k, v))
 	}
 
@@ -257,14 +281,63 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 		return fmt.Errorf("verifying session: %w", err)
 	}
 	if !running {
+		if cc != nil {
+			debugSession("removeContainer", removeContainer(m.rig, polecat))
+		}
 		return fmt.Errorf("session %s died during startup (agent command may have failed)", sessionID)
 	}
 
+	// Record how the session was started so it can be resumed after a reboot (non-fatal)
+	debugSession("recordSession", m.recordSession(&SessionRecord{
+		Polecat:          polecat,
+		WorkDir:          workDir,
+		Command:          baseCommand,
+		Issue:            opts.Issue,
+		RuntimeConfigDir: opts.RuntimeConfigDir,
+		StartedAt:        time.Now(),
+		Running:          true,
+	}))
+
+	m.publish(polecat, events.SessionEvent{Type: events.TypeSessionCreated, Session: sessionID, Agent: m.address(polecat), Rig: m.rig.Name})
 	return nil
 }
 
+// DefaultDrainTimeout is how long a drained session gets to commit its work.
+const DefaultDrainTimeout = 5 * time.Minute
+
+// drainPollInterval is how often a draining worktree is checked for a clean state.
+var drainPollInterval = 2 * time.Second
+
+// DrainPrompt is injected into a session to ask the agent to checkpoint
+// before it is stopped.
+const DrainPrompt = "Your session is about to be stopped. Wrap up now: finish or back out the edit you're in, commit your work, and then stop."
+
+// SessionStopOptions configures StopWithOptions.
+type SessionStopOptions struct {
+	// Force skips beads sync and graceful shutdown.
+	Force bool
+
+	// Drain asks the agent to wrap up and commit, then waits for its
+	// worktree to come clean (or DrainTimeout to pass) before stopping, so
+	// the session isn't killed mid-edit.
+	Drain bool
+
+	// DrainTimeout bounds the wait for a clean worktree (default DefaultDrainTimeout).
+	DrainTimeout time.Duration
+
+	// Reason is recorded in the terminations log (default ExitUserStop).
+	Reason ExitReason
+}
+
 // Stop terminates a polecat session.
 func (m *SessionManager) Stop(polecat string, force bool) error {
+	return m.StopWithOptions(polecat, SessionStopOptions{Force: force})
+}
+
+// StopWithOptions terminates a polecat session, optionally draining it first.
+// A drain that times out still stops the session, with a warning.
+func (m *SessionManager) StopWithOptions(polecat string, opts SessionStopOptions) error {
+	force := opts.Force
 	sessionID := m.SessionName(polecat)
 
 	running, err := m.tmux.HasSession(sessionID)
@@ -275,6 +348,20 @@ func (m *SessionManager) Stop(polecat string, force bool) error {
 		return ErrSessionNotFound
 	}
 
+	if opts.Drain {
+		timeout := opts.DrainTimeout
+		if timeout <= 0 {
+			timeout = DefaultDrainTimeout
+		}
+		if err := m.Inject(polecat, DrainPrompt); err != nil {
+			fmt.Printf("Warning: could not ask %s to wrap up: %v\n", polecat, err)
+		} else if clean, err := waitForCleanWorktree(git.NewGit(m.clonePath(polecat)), timeout, drainPollInterval); err != nil {
+			fmt.Printf("Warning: could not check %s worktree: %v\n", polecat, err)
+		} else if !clean {
+			fmt.Printf("Warning: %s still has uncommitted changes after %s; stopping anyway\n", polecat, timeout)
+		}
+	}
+
 	// Sync beads before shutdown (non-fatal)
 	if !force {
 		polecatDir := m.polecatDir(polecat)
@@ -294,10 +381,144 @@ func (m *SessionManager) Stop(polecat string, force bool) error {
 	if err := m.tmux.KillSessionWithProcesses(sessionID); err != nil {
 		return fmt.Errorf("killing session: %w", err)
 	}
+	debugSession("removeContainer", removeContainer(m.rig, polecat))
+
+	// A deliberate stop shouldn't be resumed after a reboot (non-fatal)
+	debugSession("markSessionStopped", m.markSessionStopped(polecat))
+
+	reason := opts.Reason
+	if reason == "" {
+		reason = ExitUserStop
+	}
+	exit := &ExitRecord{Polecat: polecat, Reason: reason}
+	if force {
+		exit.Detail = "forced"
+	}
+	debugSession("RecordExit", RecordExit(m.rig.Path, exit))
 
+	m.publish(polecat, events.SessionEvent{Type: events.TypeSessionStopped, Session: sessionID, Agent: m.address(polecat), Rig: m.rig.Name, Reason: string(reason)})
 	return nil
 }
 
+// address returns a polecat's agent address, e.g. "gastown/polecats/Toast".
+func (m *SessionManager) address(polecat string) string {
+	return fmt.Sprintf("%s/polecats/%s", m.rig.Name, polecat)
+}
+
+// publish records a lifecycle event for a polecat in the feed.
+func (m *SessionManager) publish(polecat string, e events.Typed) {
+	_ = events.Publish(m.address(polecat), e)
+}
+
+// DefaultResumeLines is how much pane output Restart preserves by default.
+const DefaultResumeLines = 200
+
+// SessionRestartOptions configures Restart.
+type SessionRestartOptions struct {
+	// Lines is how many lines of pane output to preserve (default DefaultResumeLines).
+	Lines int
+
+	// Force skips graceful shutdown of the old session.
+	Force bool
+
+	// Reason is recorded in the terminations log for the old session
+	// (default ExitUserStop).
+	Reason ExitReason
+
+	// Start configures the new session. If Start.Command is set it is used
+	// as-is, without the resume prompt.
+	Start SessionStartOptions
+}
+
+// ResumeFilePath returns where Restart saves a polecat's previous session output.
+// It lives under the rig's .runtime, since in the legacy layout the polecat
+// directory is the git worktree itself.
+func (m *SessionManager) ResumeFilePath(polecat string) string {
+	return resumeFilePath(m.rig.Path, polecat)
+}
+
+func resumeFilePath(rigPath, polecat string) string {
+	return filepath.Join(constants.RigRuntimePath(rigPath), "resume", polecat+".md")
+}
+
+// Restart replaces a polecat's session without losing its context: the last
+// lines of pane output are saved to the resume file, the session is stopped,
+// and the agent is relaunched with a prompt pointing at that file.
+// Returns the resume file path.
+func (m *SessionManager) Restart(polecat string, opts SessionRestartOptions) (string, error) {
+	lines := opts.Lines
+	if lines <= 0 {
+		lines = DefaultResumeLines
+	}
+
+	output, err := m.Capture(polecat, lines)
+	if err != nil {
+		return "", err
+	}
+
+	address := fmt.Sprintf("%s/polecats/%s", m.rig.Name, polecat)
+	resumePath := m.ResumeFilePath(polecat)
+	if err := os.MkdirAll(filepath.Dir(resumePath), 0755); err != nil {
+		return "", fmt.Errorf("creating resume dir: %w", err)
+	}
+	if err := os.WriteFile(resumePath, []byte(formatResumeFile(address, time.Now(), output)), 0644); err != nil { //nolint:gosec // G306: not sensitive
+		return "", fmt.Errorf("writing resume file: %w", err)
+	}
+
+	if err := m.StopWithOptions(polecat, SessionStopOptions{Force: opts.Force, Reason: opts.Reason}); err != nil {
+		return resumePath, fmt.Errorf("stopping session: %w", err)
+	}
+
+	startOpts := opts.Start
+	if startOpts.Command == "" {
+		prompt := fmt.Sprintf("Your session was restarted. The end of your previous session's output is saved in %s - read it to pick up where you left off.", resumePath)
+		startOpts.Command = config.BuildPolecatStartupCommand(m.rig.Name, polecat, m.rig.Path, prompt)
+	}
+	if err := m.Start(polecat, startOpts); err != nil {
+		return resumePath, fmt.Errorf("starting session: %w", err)
+	}
+	return resumePath, nil
+}
+
+// formatResumeFile renders captured pane output as a resume file.
+func formatResumeFile(address string, at time.Time, output string) string {
+	output = strings.TrimRight(output, " \t\n")
+	lines := 0
+	if output != "" {
+		lines = strings.Count(output, "\n") + 1
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Session resume: %s\n\n", address)
+	fmt.Fprintf(&b, "Restarted at %s. Last %d lines of the previous session's output:\n\n", at.Format(time.RFC3339), lines)
+	b.WriteString("```\n")
+	if output != "" {
+		b.WriteString(output)
+		b.WriteString("\n")
+	}
+	b.WriteString("```\n")
+	return b.String()
+}
+
+// waitForCleanWorktree polls a worktree until it has no uncommitted changes
+// or timeout passes. Returns whether it came clean.
+func waitForCleanWorktree(g *git.Git, timeout, interval time.Duration) (bool, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		dirty, err := g.HasUncommittedChanges()
+		if err != nil {
+			return false, err
+		}
+		if !dirty {
+			return true, nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return false, nil
+		}
+		time.Sleep(interval)
+	}
+}
+
 // syncBeads runs bd sync in the given directory.
 func (m *SessionManager) syncBeads(workDir string) error {
 	cmd := exec.Command("bd", "sync")
@@ -361,9 +582,25 @@ func (m *SessionManager) Status(polecat string) (*SessionInfo, error) {
 		}
 	}
 
+	info.Resources, _ = m.Resources(polecat)
+
 	return info, nil
 }
 
+// Resources returns the CPU and memory used by the processes in a polecat's
+// session: the pane process and everything it spawned.
+func (m *SessionManager) Resources(polecat string) (*tmux.ProcessUsage, error) {
+	sessionID := m.SessionName(polecat)
+	running, err := m.tmux.HasSession(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("checking session: %w", err)
+	}
+	if !running {
+		return nil, ErrSessionNotFound
+	}
+	return m.tmux.GetSessionResourceUsage(sessionID)
+}
+
 // List returns information about all polecat sessions for this rig.
 func (m *SessionManager) List() ([]SessionInfo, error) {
 	sessions, err := m.tmux.ListSessions()
@@ -434,8 +671,23 @@ func (m *SessionManager) CaptureSession(sessionID string, lines int) (string, er
 	return m.tmux.CapturePane(sessionID, lines)
 }
 
-// Inject sends a message to a polecat session.
+// InjectOptions configures sending a message to a polecat session.
+type InjectOptions struct {
+	// Priority skips the per-session rate limit, for messages that must
+	// reach the agent ahead of queued automated traffic.
+	Priority bool
+}
+
+// Inject sends a message to a polecat session, waiting its turn under the
+// session's rate limit.
 func (m *SessionManager) Inject(polecat, message string) error {
+	return m.InjectWithOptions(polecat, message, InjectOptions{})
+}
+
+// InjectWithOptions sends a message to a polecat session. Unless opts.Priority
+// is set, messages to the same session are queued in arrival order and sent
+// no faster than the agent can take them (see tmux.DefaultInjectInterval).
+func (m *SessionManager) InjectWithOptions(polecat, message string, opts InjectOptions) error {
 	sessionID := m.SessionName(polecat)
 
 	running, err := m.tmux.HasSession(sessionID)
@@ -446,12 +698,13 @@ func (m *SessionManager) Inject(polecat, message string) error {
 		return ErrSessionNotFound
 	}
 
-	debounceMs := 200 + (len(message)/1024)*100
-	if debounceMs > 1500 {
-		debounceMs = 1500
+	t := m.tmux
+	if opts.Priority {
+		t = t.Priority()
 	}
-
-	return m.tmux.SendKeysDebounced(sessionID, message, debounceMs)
+	// SendKeysDebounced waits for the pane to take the whole message before
+	// Enter, however large, so the pause only covers the agent's own settling.
+	return t.SendKeysDebounced(sessionID, message, 200)
 }
 
 // StopAll terminates all polecat sessions for this rig.
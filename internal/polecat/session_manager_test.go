@@ -7,7 +7,9 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/tmux"
 )
@@ -213,3 +215,72 @@ func TestPolecatCommandFormat(t *testing.T) {
 		t.Error("GT_ROLE must be 'polecat', not 'mayor' or 'crew'")
 	}
 }
+
+func TestFormatResumeFile(t *testing.T) {
+	at := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	got := formatResumeFile("gastown/polecats/Toast", at, "$ go test\nok\n\n\n")
+	want := "# Session resume: gastown/polecats/Toast\n\n" +
+		"Restarted at 2026-01-02T15:04:05Z. Last 2 lines of the previous session's output:\n\n" +
+		"```\n$ go test\nok\n```\n"
+	if got != want {
+		t.Errorf("formatResumeFile =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := formatResumeFile("gastown/polecats/Toast", at, ""); !strings.Contains(got, "Last 0 lines") || !strings.HasSuffix(got, "```\n```\n") {
+		t.Errorf("formatResumeFile(empty) = %q", got)
+	}
+}
+
+func TestResumeFilePath(t *testing.T) {
+	r := &rig.Rig{Name: "gastown", Path: "/home/user/ai/gastown"}
+	m := NewSessionManager(tmux.NewTmux(), r)
+
+	if got := filepath.ToSlash(m.ResumeFilePath("Toast")); got != "/home/user/ai/gastown/.runtime/resume/Toast.md" {
+		t.Errorf("ResumeFilePath = %q", got)
+	}
+}
+
+func TestWaitForCleanWorktree(t *testing.T) {
+	for _, kv := range [][2]string{
+		{"GIT_AUTHOR_NAME", "Test"}, {"GIT_AUTHOR_EMAIL", "test@test.com"},
+		{"GIT_COMMITTER_NAME", "Test"}, {"GIT_COMMITTER_EMAIL", "test@test.com"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	g := git.NewGit(dir)
+	if err := os.WriteFile(filepath.Join(dir, "edit.go"), []byte("package x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Stays dirty: times out
+	clean, err := waitForCleanWorktree(g, 50*time.Millisecond, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("waitForCleanWorktree: %v", err)
+	}
+	if clean {
+		t.Fatal("dirty worktree reported clean")
+	}
+
+	// Agent commits while we wait
+	done := make(chan error, 1)
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		if err := g.Add("edit.go"); err != nil {
+			done <- err
+			return
+		}
+		done <- g.Commit("checkpoint")
+	}()
+	clean, err = waitForCleanWorktree(g, 5*time.Second, 10*time.Millisecond)
+	if commitErr := <-done; commitErr != nil {
+		t.Fatalf("commit: %v", commitErr)
+	}
+	if err != nil || !clean {
+		t.Fatalf("waitForCleanWorktree = %v, %v; want clean after commit", clean, err)
+	}
+}
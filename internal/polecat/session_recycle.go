@@ -0,0 +1,56 @@
+package polecat
+
+import (
+	"strings"
+	"time"
+)
+
+// DefaultExhaustionPatterns are phrases in an agent's pane output that
+// suggest its context window is nearly used up.
+var DefaultExhaustionPatterns = []string{"compacting", "context low"}
+
+// exhaustionScanLines is how many lines of pane output ContextExhausted reads.
+const exhaustionScanLines = 30
+
+// ContextExhausted reports whether a polecat's recent pane output matches one
+// of patterns (DefaultExhaustionPatterns if empty), ignoring case, and
+// returns the first matching line.
+func (m *SessionManager) ContextExhausted(polecat string, patterns []string) (string, bool, error) {
+	output, err := m.Capture(polecat, exhaustionScanLines)
+	if err != nil {
+		return "", false, err
+	}
+	line, ok := matchExhaustion(output, patterns)
+	return line, ok, nil
+}
+
+// matchExhaustion returns the first line of output containing one of patterns.
+func matchExhaustion(output string, patterns []string) (string, bool) {
+	if len(patterns) == 0 {
+		patterns = DefaultExhaustionPatterns
+	}
+	for _, line := range strings.Split(output, "\n") {
+		lower := strings.ToLower(line)
+		for _, p := range patterns {
+			if p != "" && strings.Contains(lower, strings.ToLower(p)) {
+				return strings.TrimSpace(line), true
+			}
+		}
+	}
+	return "", false
+}
+
+// SessionAge returns how long a polecat's current session has been running,
+// from its start time in the session registry. Returns 0 if the polecat has
+// no recorded running session.
+func (m *SessionManager) SessionAge(polecat string, now time.Time) time.Duration {
+	reg, err := loadSessionRegistry(SessionsFilePath(m.rig.Path))
+	if err != nil {
+		return 0
+	}
+	rec, ok := reg.Sessions[polecat]
+	if !ok || !rec.Running || rec.StartedAt.IsZero() {
+		return 0
+	}
+	return now.Sub(rec.StartedAt)
+}
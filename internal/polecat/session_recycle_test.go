@@ -0,0 +1,57 @@
+package polecat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func TestMatchExhaustion(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		patterns []string
+		want     string
+		ok       bool
+	}{
+		{"quiet pane", "running tests\nall passed\n", nil, "", false},
+		{"compacting", "working...\n  Compacting conversation…\n", nil, "Compacting conversation…", true},
+		{"context low", "Context low (8% remaining)", nil, "Context low (8% remaining)", true},
+		{"custom pattern", "tokens exhausted", []string{"exhausted"}, "tokens exhausted", true},
+		{"custom replaces defaults", "compacting", []string{"exhausted"}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := matchExhaustion(tt.output, tt.patterns)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("matchExhaustion = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSessionAge(t *testing.T) {
+	root := t.TempDir()
+	m := NewSessionManager(tmux.NewTmux(), &rig.Rig{Name: "age-test", Path: root})
+	now := time.Now()
+
+	if age := m.SessionAge("Toast", now); age != 0 {
+		t.Errorf("SessionAge with no registry = %s, want 0", age)
+	}
+
+	err := updateSessionRegistry(root, func(reg *sessionRegistry) {
+		reg.Sessions["Toast"] = &SessionRecord{Polecat: "Toast", StartedAt: now.Add(-2 * time.Hour), Running: true}
+		reg.Sessions["Nux"] = &SessionRecord{Polecat: "Nux", StartedAt: now.Add(-time.Hour)}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if age := m.SessionAge("Toast", now); age != 2*time.Hour {
+		t.Errorf("SessionAge(Toast) = %s, want 2h", age)
+	}
+	if age := m.SessionAge("Nux", now); age != 0 {
+		t.Errorf("SessionAge of a stopped session = %s, want 0", age)
+	}
+}
@@ -0,0 +1,145 @@
+package polecat
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// SessionRecord holds what's needed to recreate a polecat session after its
+// tmux server is gone (e.g., after a reboot). The session env isn't stored:
+// it can hold secrets, and resuming rebuilds it from the rig config.
+type SessionRecord struct {
+	Polecat          string    `json:"polecat"`
+	WorkDir          string    `json:"work_dir"`
+	Command          string    `json:"command"`
+	Issue            string    `json:"issue,omitempty"`
+	RuntimeConfigDir string    `json:"runtime_config_dir,omitempty"`
+	StartedAt        time.Time `json:"started_at"`
+
+	// Running is cleared when the session is stopped deliberately, so only
+	// sessions that were lost get resumed.
+	Running bool `json:"running"`
+}
+
+// sessionRegistry is the on-disk format of the sessions file.
+type sessionRegistry struct {
+	Sessions map[string]*SessionRecord `json:"sessions"`
+}
+
+// sessionsFileMu serializes read-modify-write of sessions files, since
+// StartAll records sessions concurrently.
+var sessionsFileMu sync.Mutex
+
+// SessionsFilePath returns the path of a rig's polecat session registry.
+func SessionsFilePath(rigPath string) string {
+	return filepath.Join(rigPath, ".runtime", "sessions.json")
+}
+
+// LoadSessionRecords reads a rig's session registry, sorted by polecat name.
+// A missing file yields no records.
+func LoadSessionRecords(rigPath string) ([]*SessionRecord, error) {
+	reg, err := loadSessionRegistry(SessionsFilePath(rigPath))
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*SessionRecord, 0, len(reg.Sessions))
+	for _, rec := range reg.Sessions {
+		records = append(records, rec)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Polecat < records[j].Polecat })
+	return records, nil
+}
+
+func loadSessionRegistry(path string) (*sessionRegistry, error) {
+	reg := &sessionRegistry{}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is under the rig's .runtime
+	if err != nil {
+		if os.IsNotExist(err) {
+			reg.Sessions = make(map[string]*SessionRecord)
+			return reg, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, reg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if reg.Sessions == nil {
+		reg.Sessions = make(map[string]*SessionRecord)
+	}
+	return reg, nil
+}
+
+// updateSessionRegistry applies fn to a rig's session registry and saves it.
+func updateSessionRegistry(rigPath string, fn func(reg *sessionRegistry)) error {
+	sessionsFileMu.Lock()
+	defer sessionsFileMu.Unlock()
+
+	path := SessionsFilePath(rigPath)
+	reg, err := loadSessionRegistry(path)
+	if err != nil {
+		return err
+	}
+	fn(reg)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(path, reg)
+}
+
+// recordSession saves a started session to the rig's registry.
+func (m *SessionManager) recordSession(rec *SessionRecord) error {
+	return updateSessionRegistry(m.rig.Path, func(reg *sessionRegistry) {
+		reg.Sessions[rec.Polecat] = rec
+	})
+}
+
+// markSessionStopped records that a polecat's session was stopped on purpose.
+func (m *SessionManager) markSessionStopped(polecat string) error {
+	return updateSessionRegistry(m.rig.Path, func(reg *sessionRegistry) {
+		if rec, ok := reg.Sessions[polecat]; ok {
+			rec.Running = false
+		}
+	})
+}
+
+// Resume recreates sessions that were running when their tmux server went
+// away, e.g. across a machine reboot. Sessions that are still alive, were
+// stopped deliberately, or belong to polecats that no longer exist are
+// skipped. Sessions start in parallel as with StartAll.
+func (m *SessionManager) Resume(opts StartAllOptions) ([]StartResult, error) {
+	records, err := LoadSessionRecords(m.rig.Path)
+	if err != nil {
+		return nil, fmt.Errorf("loading session registry: %w", err)
+	}
+
+	toResume := make(map[string]*SessionRecord)
+	var names []string
+	for _, rec := range records {
+		if !rec.Running || !m.hasPolecat(rec.Polecat) {
+			continue
+		}
+		if running, err := m.IsRunning(rec.Polecat); err != nil || running {
+			continue
+		}
+		toResume[rec.Polecat] = rec
+		names = append(names, rec.Polecat)
+	}
+
+	concurrency, stagger := opts.limits()
+	return startAll(names, concurrency, stagger, func(polecat string) error {
+		rec := toResume[polecat]
+		return m.Start(polecat, SessionStartOptions{
+			WorkDir:          rec.WorkDir,
+			Issue:            rec.Issue,
+			Command:          rec.Command,
+			RuntimeConfigDir: rec.RuntimeConfigDir,
+		})
+	}), nil
+}
@@ -0,0 +1,75 @@
+package polecat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func TestSessionRegistry(t *testing.T) {
+	root := t.TempDir()
+	m := NewSessionManager(tmux.NewTmux(), &rig.Rig{Name: "gastown", Path: root})
+
+	records, err := LoadSessionRecords(root)
+	if err != nil || len(records) != 0 {
+		t.Fatalf("LoadSessionRecords on empty rig = %v, %v; want none", records, err)
+	}
+
+	for _, rec := range []*SessionRecord{
+		{Polecat: "Toast", WorkDir: "/w/Toast", Command: "claude", Issue: "gt-1", Running: true},
+		{Polecat: "Cheedo", WorkDir: "/w/Cheedo", Command: "claude", Running: true},
+	} {
+		if err := m.recordSession(rec); err != nil {
+			t.Fatalf("recordSession: %v", err)
+		}
+	}
+	if err := m.markSessionStopped("Cheedo"); err != nil {
+		t.Fatalf("markSessionStopped: %v", err)
+	}
+	if err := m.markSessionStopped("Unknown"); err != nil {
+		t.Fatalf("markSessionStopped(unknown): %v", err)
+	}
+
+	records, err = LoadSessionRecords(root)
+	if err != nil {
+		t.Fatalf("LoadSessionRecords: %v", err)
+	}
+	if len(records) != 2 || records[0].Polecat != "Cheedo" || records[1].Polecat != "Toast" {
+		t.Fatalf("records = %+v, want Cheedo and Toast sorted", records)
+	}
+	if records[0].Running {
+		t.Error("stopped session still marked running")
+	}
+	if toast := records[1]; !toast.Running || toast.Issue != "gt-1" {
+		t.Errorf("Toast record = %+v", toast)
+	}
+}
+
+func TestResumeSkipsStoppedAndMissing(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "polecats", "Cheedo"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	m := NewSessionManager(tmux.NewTmux(), &rig.Rig{Name: "gastown", Path: root})
+
+	// Gone no longer exists; Cheedo was stopped on purpose
+	for _, rec := range []*SessionRecord{
+		{Polecat: "Gone", Running: true},
+		{Polecat: "Cheedo", Running: false},
+	} {
+		if err := m.recordSession(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := m.Resume(StartAllOptions{})
+	if err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Resume started %+v, want nothing", results)
+	}
+}
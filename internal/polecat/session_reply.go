@@ -0,0 +1,93 @@
+package polecat
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrReplyTimeout is returned when an agent doesn't finish a reply in time.
+var ErrReplyTimeout = errors.New("timed out waiting for reply")
+
+// replyCaptureLines is how much scrollback InjectAndCapture searches for a reply.
+const replyCaptureLines = 2000
+
+// replyPollInterval is how often InjectAndCapture checks the pane for a reply.
+var replyPollInterval = 500 * time.Millisecond
+
+// InjectAndCapture sends a prompt to a polecat session and waits for the
+// agent's textual reply. The prompt asks the agent to wrap its answer in
+// markers tagged with a fresh reply ID; the pane is polled until the closing
+// marker appears or timeout passes.
+func (m *SessionManager) InjectAndCapture(polecat, message string, timeout time.Duration) (string, error) {
+	id, err := newReplyID()
+	if err != nil {
+		return "", err
+	}
+	if err := m.Inject(polecat, message+replyInstructions(id)); err != nil {
+		return "", err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		out, err := m.Capture(polecat, replyCaptureLines)
+		if err != nil {
+			return "", err
+		}
+		if reply, ok := extractReply(out, id); ok {
+			return reply, nil
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("%w from %s after %s", ErrReplyTimeout, polecat, timeout)
+		}
+		time.Sleep(replyPollInterval)
+	}
+}
+
+func newReplyID() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating reply id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// replyInstructions tells the agent how to delimit its reply. The markers
+// are described rather than spelled out, so the echoed prompt in the pane
+// never matches them.
+func replyInstructions(id string) string {
+	return fmt.Sprintf("\n\nReply in plain text. Put a line reading [[reply:ID]] before your answer "+
+		"and a line reading [[end:ID]] after it, where ID is %s. Don't run any tools for this.", id)
+}
+
+func replyMarkers(id string) (begin, end string) {
+	return "[[reply:" + id + "]]", "[[end:" + id + "]]"
+}
+
+// extractReply finds the agent's reply for id in captured pane output. The
+// text between the last begin marker and the end marker after it is returned
+// with the agent UI's bullet and indentation stripped.
+func extractReply(capture, id string) (string, bool) {
+	begin, end := replyMarkers(id)
+	start := strings.LastIndex(capture, begin)
+	if start < 0 {
+		return "", false
+	}
+	body := capture[start+len(begin):]
+	stop := strings.Index(body, end)
+	if stop < 0 {
+		return "", false
+	}
+	body = body[:stop]
+
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, " \t")
+		line = strings.TrimPrefix(strings.TrimLeft(line, " \t"), "⏺")
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")), true
+}
@@ -0,0 +1,53 @@
+package polecat
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractReply(t *testing.T) {
+	id := "1a2b3c4d"
+	begin, end := replyMarkers(id)
+
+	tests := []struct {
+		name    string
+		capture string
+		want    string
+		ok      bool
+	}{
+		{
+			name:    "prompt echo only",
+			capture: "> Are you blocked?" + replyInstructions(id) + "\n",
+			ok:      false,
+		},
+		{
+			name:    "reply still streaming",
+			capture: "⏺ " + begin + "\n  Working on it\n",
+			ok:      false,
+		},
+		{
+			name: "complete reply",
+			capture: "> Are you blocked?" + replyInstructions(id) + "\n\n" +
+				"⏺ " + begin + "\n  No, tests are running.\n  ETA 5 minutes.\n  " + end + "\n\n> ",
+			want: "No, tests are running.\nETA 5 minutes.",
+			ok:   true,
+		},
+		{
+			name:    "other reply id",
+			capture: "⏺ [[reply:ffffffff]]\n  stale\n  [[end:ffffffff]]\n",
+			ok:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := extractReply(tt.capture, id)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("extractReply = %q, %v; want %q, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+
+	if strings.Contains(replyInstructions(id), begin) || strings.Contains(replyInstructions(id), end) {
+		t.Error("reply instructions contain a literal marker; the echoed prompt would match")
+	}
+}
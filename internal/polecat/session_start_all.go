@@ -0,0 +1,83 @@
+package polecat
+
+import (
+	"sync"
+	"time"
+)
+
+// StartAll defaults, tuned so a full rig comes up quickly without every
+// agent hitting the API at the same instant.
+const (
+	DefaultStartConcurrency = 4
+	DefaultStartStagger     = 3 * time.Second
+)
+
+// StartAllOptions configures StartAll.
+type StartAllOptions struct {
+	// Concurrency is the most sessions starting at once (default DefaultStartConcurrency).
+	Concurrency int
+
+	// Stagger is the minimum delay between launching consecutive sessions
+	// (default DefaultStartStagger; negative for none).
+	Stagger time.Duration
+
+	// Start configures each session.
+	Start SessionStartOptions
+}
+
+// StartResult is the outcome of starting one polecat's session.
+type StartResult struct {
+	Polecat string
+	Err     error
+}
+
+// StartAll starts sessions for the given polecats in parallel, at most
+// opts.Concurrency at a time and with launches spaced opts.Stagger apart so
+// a large rig doesn't stampede the API. Results are in the order given.
+func (m *SessionManager) StartAll(polecats []string, opts StartAllOptions) []StartResult {
+	concurrency, stagger := opts.limits()
+	return startAll(polecats, concurrency, stagger, func(polecat string) error {
+		return m.Start(polecat, opts.Start)
+	})
+}
+
+// limits returns the concurrency and stagger to use, applying defaults.
+func (o StartAllOptions) limits() (int, time.Duration) {
+	concurrency := o.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultStartConcurrency
+	}
+	stagger := o.Stagger
+	if stagger == 0 {
+		stagger = DefaultStartStagger
+	}
+	return concurrency, stagger
+}
+
+// startAll runs start for each polecat with bounded concurrency and staggered launches.
+func startAll(polecats []string, concurrency int, stagger time.Duration, start func(string) error) []StartResult {
+	results := make([]StartResult, len(polecats))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var lastLaunch time.Time
+
+	for i, name := range polecats {
+		sem <- struct{}{}
+		if stagger > 0 && !lastLaunch.IsZero() {
+			if wait := stagger - time.Since(lastLaunch); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+		lastLaunch = time.Now()
+
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = StartResult{Polecat: name, Err: start(name)}
+		}(i, name)
+	}
+
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,57 @@
+package polecat
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStartAll(t *testing.T) {
+	names := []string{"Toast", "Cheedo", "Nux", "Slit", "Ace"}
+	errNux := errors.New("boom")
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	var launches []time.Time
+	start := func(name string) error {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		launches = append(launches, time.Now())
+		mu.Unlock()
+
+		time.Sleep(30 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		if name == "Nux" {
+			return errNux
+		}
+		return nil
+	}
+
+	results := startAll(names, 2, 5*time.Millisecond, start)
+
+	if peak > 2 {
+		t.Errorf("peak concurrency = %d, want <= 2", peak)
+	}
+	if len(results) != len(names) {
+		t.Fatalf("got %d results, want %d", len(results), len(names))
+	}
+	for i, res := range results {
+		if res.Polecat != names[i] {
+			t.Errorf("results[%d].Polecat = %q, want %q", i, res.Polecat, names[i])
+		}
+		wantErr := names[i] == "Nux"
+		if (res.Err != nil) != wantErr {
+			t.Errorf("results[%d].Err = %v, want error: %v", i, res.Err, wantErr)
+		}
+	}
+	for i := 1; i < len(launches); i++ {
+		if gap := launches[i].Sub(launches[i-1]); gap < 4*time.Millisecond {
+			t.Errorf("launch %d came %v after the previous, want staggered", i, gap)
+		}
+	}
+}
@@ -10,13 +10,16 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/convoy"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/notify"
 	"github.com/steveyegge/gastown/internal/protocol"
 	"github.com/steveyegge/gastown/internal/rig"
 )
@@ -35,6 +38,11 @@ type MergeQueueConfig struct {
 	// OnConflict is the strategy for handling conflicts: "assign_back" or "auto_rebase".
 	OnConflict string `json:"on_conflict"`
 
+	// Rebase rebases each branch onto the target before testing and merging,
+	// so tests run against exactly what will land. Off by default, so
+	// existing rigs keep merging branches as they are until they opt in.
+	Rebase bool `json:"rebase"`
+
 	// RunTests controls whether to run tests before merging.
 	RunTests bool `json:"run_tests"`
 
@@ -61,6 +69,7 @@ func DefaultMergeQueueConfig() *MergeQueueConfig {
 		TargetBranch:         "main",
 		IntegrationBranches:  true,
 		OnConflict:           "assign_back",
+		Rebase:               false,
 		RunTests:             true,
 		TestCommand:          "",
 		DeleteMergedBranches: true,
@@ -168,6 +177,7 @@ func (e *Engineer) LoadConfig() error {
 		TargetBranch         *string `json:"target_branch"`
 		IntegrationBranches  *bool   `json:"integration_branches"`
 		OnConflict           *string `json:"on_conflict"`
+		Rebase               *bool   `json:"rebase"`
 		RunTests             *bool   `json:"run_tests"`
 		TestCommand          *string `json:"test_command"`
 		DeleteMergedBranches *bool   `json:"delete_merged_branches"`
@@ -193,6 +203,9 @@ func (e *Engineer) LoadConfig() error {
 	if mqRaw.OnConflict != nil {
 		e.config.OnConflict = *mqRaw.OnConflict
 	}
+	if mqRaw.Rebase != nil {
+		e.config.Rebase = *mqRaw.Rebase
+	}
 	if mqRaw.RunTests != nil {
 		e.config.RunTests = *mqRaw.RunTests
 	}
@@ -231,8 +244,17 @@ type ProcessResult struct {
 	Error       string
 	Conflict    bool
 	TestsFailed bool
+	Output      string // Tail of the test log, for failure reports
 }
 
+// rebaseBranch is the scratch branch used to rebase MRs onto their target.
+// Polecat branches may be checked out in other worktrees, so the refinery
+// never rewrites them in place.
+const rebaseBranch = "refinery-rebase"
+
+// testLogTailLines caps how much test output is kept for failure reports.
+const testLogTailLines = 60
+
 // ProcessMR processes a single merge request from a beads issue.
 func (e *Engineer) ProcessMR(ctx context.Context, mr *beads.Issue) ProcessResult {
 	// Parse MR fields from description
@@ -287,36 +309,47 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: pull from origin/%s: %v (continuing)\n", target, err)
 	}
 
-	// Step 3: Check for merge conflicts (using local branch)
-	_, _ = fmt.Fprintf(e.output, "[Engineer] Checking for conflicts...\n")
-	conflicts, err := e.git.CheckConflicts(branch, target)
-	if err != nil {
-		return ProcessResult{
-			Success:  false,
-			Conflict: true,
-			Error:    fmt.Sprintf("conflict check failed: %v", err),
+	mergeRef := branch
+	if e.config.Rebase {
+		// Steps 3-4 (rebase mode): rebase onto target and test the result
+		if result := e.rebaseAndTest(ctx, branch, target); !result.Success {
+			return result
 		}
-	}
-	if len(conflicts) > 0 {
-		return ProcessResult{
-			Success:  false,
-			Conflict: true,
-			Error:    fmt.Sprintf("merge conflicts in: %v", conflicts),
+		defer func() { _ = e.git.DeleteBranch(rebaseBranch, true) }()
+		mergeRef = rebaseBranch
+	} else {
+		// Step 3: Check for merge conflicts (using local branch)
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Checking for conflicts...\n")
+		conflicts, err := e.git.CheckConflicts(branch, target)
+		if err != nil {
+			return ProcessResult{
+				Success:  false,
+				Conflict: true,
+				Error:    fmt.Sprintf("conflict check failed: %v", err),
+			}
 		}
-	}
-
-	// Step 4: Run tests if configured
-	if e.config.RunTests && e.config.TestCommand != "" {
-		_, _ = fmt.Fprintf(e.output, "[Engineer] Running tests: %s\n", e.config.TestCommand)
-		result := e.runTests(ctx)
-		if !result.Success {
+		if len(conflicts) > 0 {
 			return ProcessResult{
-				Success:     false,
-				TestsFailed: true,
-				Error:       result.Error,
+				Success:  false,
+				Conflict: true,
+				Error:    fmt.Sprintf("merge conflicts in: %v", conflicts),
 			}
 		}
-		_, _ = fmt.Fprintln(e.output, "[Engineer] Tests passed")
+
+		// Step 4: Run tests if configured
+		if e.config.RunTests && e.config.TestCommand != "" {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Running tests: %s\n", e.config.TestCommand)
+			result := e.runTests(ctx)
+			if !result.Success {
+				return ProcessResult{
+					Success:     false,
+					TestsFailed: true,
+					Error:       result.Error,
+					Output:      result.Output,
+				}
+			}
+			_, _ = fmt.Fprintln(e.output, "[Engineer] Tests passed")
+		}
 	}
 
 	// Step 5: Perform the actual merge
@@ -325,7 +358,7 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 		mergeMsg = fmt.Sprintf("Merge %s into %s (%s)", branch, target, sourceIssue)
 	}
 	_, _ = fmt.Fprintf(e.output, "[Engineer] Merging with message: %s\n", mergeMsg)
-	if err := e.git.MergeNoFF(branch, mergeMsg); err != nil {
+	if err := e.git.MergeNoFF(mergeRef, mergeMsg); err != nil {
 		// ZFC: Use git's porcelain output to detect conflicts instead of parsing stderr.
 		// GetConflictingFiles() uses `git diff --diff-filter=U` which is proper.
 		conflicts, conflictErr := e.git.GetConflictingFiles()
@@ -381,6 +414,7 @@ func (e *Engineer) runTests(ctx context.Context) ProcessResult {
 	}
 
 	var lastErr error
+	var lastOutput string
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		if attempt > 1 {
 			_, _ = fmt.Fprintf(e.output, "[Engineer] Retrying tests (attempt %d/%d)...\n", attempt, maxRetries)
@@ -390,15 +424,16 @@ func (e *Engineer) runTests(ctx context.Context) ProcessResult {
 		// not from PR branches. Shell execution is intentional for flexibility (pipes, etc).
 		cmd := exec.CommandContext(ctx, "sh", "-c", e.config.TestCommand) //nolint:gosec // G204: TestCommand is from trusted rig config
 		cmd.Dir = e.workDir
-		var stdout, stderr bytes.Buffer
-		cmd.Stdout = &stdout
-		cmd.Stderr = &stderr
+		var output bytes.Buffer
+		cmd.Stdout = &output
+		cmd.Stderr = &output
 
 		err := cmd.Run()
 		if err == nil {
 			return ProcessResult{Success: true}
 		}
 		lastErr = err
+		lastOutput = tailLines(output.String(), testLogTailLines)
 
 		// Check if context was canceled
 		if ctx.Err() != nil {
@@ -413,9 +448,64 @@ func (e *Engineer) runTests(ctx context.Context) ProcessResult {
 		Success:     false,
 		TestsFailed: true,
 		Error:       fmt.Sprintf("tests failed after %d attempts: %v", maxRetries, lastErr),
+		Output:      lastOutput,
 	}
 }
 
+// rebaseAndTest rebases a copy of branch onto target and runs the configured
+// tests on the result. On success the rebased copy is left in rebaseBranch
+// and target is checked out, ready to merge. On failure the scratch branch
+// is removed and target is checked out again.
+func (e *Engineer) rebaseAndTest(ctx context.Context, branch, target string) ProcessResult {
+	fail := func(r ProcessResult) ProcessResult {
+		_ = e.git.Checkout(target)
+		_ = e.git.DeleteBranch(rebaseBranch, true)
+		return r
+	}
+
+	_ = e.git.DeleteBranch(rebaseBranch, true) // leftover from an interrupted run
+	if err := e.git.CreateBranchFrom(rebaseBranch, branch); err != nil {
+		return ProcessResult{Error: fmt.Sprintf("failed to create rebase branch: %v", err)}
+	}
+	if err := e.git.Checkout(rebaseBranch); err != nil {
+		return fail(ProcessResult{Error: fmt.Sprintf("failed to checkout rebase branch: %v", err)})
+	}
+
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Rebasing %s onto %s...\n", branch, target)
+	if err := e.git.Rebase(target); err != nil {
+		conflicts, _ := e.git.GetConflictingFiles()
+		_ = e.git.AbortRebase()
+		msg := fmt.Sprintf("rebase onto %s failed: %v", target, err)
+		if len(conflicts) > 0 {
+			msg = fmt.Sprintf("rebase conflicts in: %v", conflicts)
+		}
+		return fail(ProcessResult{Conflict: true, Error: msg})
+	}
+
+	if e.config.RunTests && e.config.TestCommand != "" {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Running tests on rebased branch: %s\n", e.config.TestCommand)
+		result := e.runTests(ctx)
+		if !result.Success {
+			return fail(ProcessResult{TestsFailed: true, Error: result.Error, Output: result.Output})
+		}
+		_, _ = fmt.Fprintln(e.output, "[Engineer] Tests passed")
+	}
+
+	if err := e.git.Checkout(target); err != nil {
+		return fail(ProcessResult{Error: fmt.Sprintf("failed to checkout target %s: %v", target, err)})
+	}
+	return ProcessResult{Success: true}
+}
+
+// tailLines returns the last n lines of s.
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
 // handleSuccess handles a successful merge completion.
 // Steps:
 // 1. Update MR with merge_commit SHA
@@ -591,6 +681,13 @@ func (e *Engineer) HandleMRInfoSuccess(mr *MRInfo, result ProcessResult) {
 
 	// 3. Log success
 	_, _ = fmt.Fprintf(e.output, "[Engineer] ✓ Merged: %s (commit: %s)\n", mr.ID, result.MergeCommit)
+
+	// 4. Notify external sinks (settings/notifications.json)
+	body := fmt.Sprintf("Branch: %s\nTarget: %s\nCommit: %s", mr.Branch, mr.Target, result.MergeCommit)
+	if mr.SourceIssue != "" {
+		body += "\nIssue: " + mr.SourceIssue
+	}
+	e.sendNotification(notify.EventMerged, config.SeverityLow, fmt.Sprintf("Merged %s into %s", mr.Branch, mr.Target), body)
 }
 
 // HandleMRInfoFailure handles a failed merge from MRInfo.
@@ -612,6 +709,13 @@ func (e *Engineer) HandleMRInfoFailure(mr *MRInfo, result ProcessResult) {
 		fmt.Fprintf(e.output, "[Engineer] Notified witness of merge failure for %s\n", mr.Worker)
 	}
 
+	// Test and build failures bounce back to the author with the failure log.
+	// Assigning the MR to the author takes it out of the ready queue until
+	// they fix the branch and release it ('gt refinery release <mr-id>').
+	if !result.Conflict && mr.Worker != "" {
+		e.bounceToAuthor(mr, failureType, result)
+	}
+
 	// If this was a conflict, create a conflict-resolution task for dispatch
 	// and block the MR until the task is resolved (non-blocking delegation)
 	if result.Conflict {
@@ -629,6 +733,10 @@ func (e *Engineer) HandleMRInfoFailure(mr *MRInfo, result ProcessResult) {
 		}
 	}
 
+	e.sendNotification(notify.EventMergeFailed, config.SeverityMedium,
+		fmt.Sprintf("Merge failed (%s): %s", failureType, mr.Branch),
+		fmt.Sprintf("MR: %s\nBranch: %s\nTarget: %s\nWorker: %s\nError: %s", mr.ID, mr.Branch, mr.Target, mr.Worker, result.Error))
+
 	// Log the failure - MR stays in queue but may be blocked
 	_, _ = fmt.Fprintf(e.output, "[Engineer] ✗ Failed: %s - %s\n", mr.ID, result.Error)
 	if mr.BlockedBy != "" {
@@ -638,6 +746,130 @@ func (e *Engineer) HandleMRInfoFailure(mr *MRInfo, result ProcessResult) {
 	}
 }
 
+// sendNotification delivers a refinery result to the town's external
+// notification sinks. Delivery problems are logged, never fatal.
+func (e *Engineer) sendNotification(event, severity, title, body string) {
+	townRoot := findTownRoot(e.rig.Path)
+	if townRoot == "" {
+		return
+	}
+	results, err := notify.Send(townRoot, notify.Notification{
+		Event:    event,
+		Severity: severity,
+		Title:    title,
+		Body:     body,
+		Source:   e.rig.Name + "/refinery",
+	})
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: notifications: %v\n", err)
+		return
+	}
+	for _, r := range results {
+		if r.Error != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: notification to %s failed: %v\n", r.Sink, r.Error)
+		}
+	}
+}
+
+// authorAddress returns the mail address of the worker who submitted an MR.
+func (e *Engineer) authorAddress(mr *MRInfo) string {
+	if strings.Contains(mr.Worker, "/") {
+		return mr.Worker
+	}
+	return fmt.Sprintf("%s/%s", e.rig.Name, mr.Worker)
+}
+
+// bounceToAuthor mails the MR author the failure and its log, and assigns
+// the MR to them so the queue moves on.
+func (e *Engineer) bounceToAuthor(mr *MRInfo, failureType string, result ProcessResult) {
+	author := e.authorAddress(mr)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Your merge request %s was bounced (%s failure).\n\n", mr.ID, failureType)
+	fmt.Fprintf(&body, "Branch: %s\nTarget: %s\n", mr.Branch, mr.Target)
+	if mr.SourceIssue != "" {
+		fmt.Fprintf(&body, "Issue: %s\n", mr.SourceIssue)
+	}
+	fmt.Fprintf(&body, "Error: %s\n", result.Error)
+	if result.Output != "" {
+		fmt.Fprintf(&body, "\nTest log (last %d lines):\n%s\n", testLogTailLines, result.Output)
+	}
+	fmt.Fprintf(&body, "\nFix the branch, push, then requeue with: gt refinery release %s\n", mr.ID)
+
+	msg := mail.NewMessage(fmt.Sprintf("%s/refinery", e.rig.Name), author,
+		fmt.Sprintf("MR bounced: %s (%s)", mr.Branch, failureType), body.String())
+	msg.Priority = mail.PriorityHigh
+	msg.Type = mail.TypeTask
+	if err := e.router.Send(msg); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to mail %s: %v\n", author, err)
+	}
+
+	if mr.ID != "" {
+		if err := e.ClaimMR(mr.ID, author); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to assign MR %s to %s: %v\n", mr.ID, author, err)
+		} else {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Bounced %s back to %s\n", mr.ID, author)
+		}
+	}
+}
+
+// QueueResult records the outcome of one MR in a queue run.
+type QueueResult struct {
+	MR     *MRInfo
+	Result ProcessResult
+}
+
+// OrderedReadyMRs returns the ready queue in processing order (highest score first).
+func (e *Engineer) OrderedReadyMRs() ([]*MRInfo, error) {
+	ready, err := e.ListReadyMRs()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	sort.SliceStable(ready, func(i, j int) bool {
+		return ready[i].ScoreAt(now) > ready[j].ScoreAt(now)
+	})
+	return ready, nil
+}
+
+// ProcessQueue drains the ready queue in order: each MR is claimed, rebased
+// onto its target, tested, and merged, or bounced back on failure. Because
+// MRs are merged one at a time, each rebase sees the previous merges.
+// limit caps how many MRs are processed (0 = all).
+func (e *Engineer) ProcessQueue(ctx context.Context, workerID string, limit int) ([]QueueResult, error) {
+	queue, err := e.OrderedReadyMRs()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []QueueResult
+	for _, mr := range queue {
+		if limit > 0 && len(results) >= limit {
+			break
+		}
+		if ctx.Err() != nil {
+			return results, ctx.Err()
+		}
+
+		if err := e.ClaimMR(mr.ID, workerID); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to claim %s: %v\n", mr.ID, err)
+			continue
+		}
+
+		result := e.ProcessMRInfo(ctx, mr)
+		if result.Success {
+			e.HandleMRInfoSuccess(mr, result)
+		} else {
+			if err := e.ReleaseMR(mr.ID); err != nil {
+				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to release %s: %v\n", mr.ID, err)
+			}
+			e.HandleMRInfoFailure(mr, result)
+		}
+		results = append(results, QueueResult{MR: mr, Result: result})
+	}
+	return results, nil
+}
+
 // createConflictResolutionTaskForMR creates a dispatchable task for resolving merge conflicts.
 // This task will be picked up by bd ready and can be slung to a fresh polecat (spawned on demand).
 // Returns the created task's ID for blocking the MR until resolution.
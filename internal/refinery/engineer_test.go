@@ -1,12 +1,17 @@
 package refinery
 
 import (
+	"context"
 	"encoding/json"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 )
 
@@ -28,6 +33,9 @@ func TestDefaultMergeQueueConfig(t *testing.T) {
 	if cfg.OnConflict != "assign_back" {
 		t.Errorf("expected OnConflict to be 'assign_back', got %q", cfg.OnConflict)
 	}
+	if cfg.Rebase {
+		t.Error("expected Rebase to be false by default")
+	}
 }
 
 func TestEngineer_LoadConfig_NoFile(t *testing.T) {
@@ -215,3 +223,115 @@ func TestEngineer_DeleteMergedBranchesConfig(t *testing.T) {
 		t.Error("expected DeleteMergedBranches to be true by default")
 	}
 }
+
+// initRebaseRepo creates a repo where "feature" branched from main before
+// main gained another commit.
+func initRebaseRepo(t *testing.T) string {
+	t.Helper()
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run("init", "-b", "main")
+	write("base.txt", "base\n")
+	run("add", ".")
+	run("commit", "-m", "base")
+	run("checkout", "-b", "feature")
+	write("feature.txt", "feature\n")
+	run("add", ".")
+	run("commit", "-m", "feature")
+	run("checkout", "main")
+	write("main.txt", "main\n")
+	run("add", ".")
+	run("commit", "-m", "main moved")
+	return dir
+}
+
+func newTestEngineer(dir string, cfg *MergeQueueConfig) *Engineer {
+	return &Engineer{
+		rig:     &rig.Rig{Name: "test-rig", Path: dir},
+		git:     git.NewGit(dir),
+		config:  cfg,
+		workDir: dir,
+		output:  io.Discard,
+	}
+}
+
+func TestEngineer_RebaseAndTest(t *testing.T) {
+	dir := initRebaseRepo(t)
+
+	cfg := DefaultMergeQueueConfig()
+	cfg.TestCommand = "test -f feature.txt && test -f main.txt"
+	e := newTestEngineer(dir, cfg)
+
+	result := e.rebaseAndTest(context.Background(), "feature", "main")
+	if !result.Success {
+		t.Fatalf("rebaseAndTest() failed: %s", result.Error)
+	}
+
+	current, err := e.git.CurrentBranch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current != "main" {
+		t.Errorf("current branch = %q, want main", current)
+	}
+
+	// The original branch must be untouched; only the scratch copy is rebased.
+	if err := exec.Command("git", "-C", dir, "merge-base", "--is-ancestor", "main", rebaseBranch).Run(); err != nil {
+		t.Errorf("%s is not rebased onto main", rebaseBranch)
+	}
+	if err := exec.Command("git", "-C", dir, "merge-base", "--is-ancestor", "main", "feature").Run(); err == nil {
+		t.Error("feature branch was rewritten in place")
+	}
+}
+
+func TestEngineer_RebaseAndTest_TestFailureCapturesLog(t *testing.T) {
+	dir := initRebaseRepo(t)
+
+	cfg := DefaultMergeQueueConfig()
+	cfg.TestCommand = "echo FAIL: TestSomething; exit 1"
+	e := newTestEngineer(dir, cfg)
+
+	result := e.rebaseAndTest(context.Background(), "feature", "main")
+	if result.Success || !result.TestsFailed {
+		t.Fatalf("rebaseAndTest() = %+v, want test failure", result)
+	}
+	if !strings.Contains(result.Output, "FAIL: TestSomething") {
+		t.Errorf("Output = %q, want test log", result.Output)
+	}
+
+	exists, err := e.git.BranchExists(rebaseBranch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists {
+		t.Errorf("%s should be deleted after failure", rebaseBranch)
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	if got := tailLines("a\nb\nc\n", 2); got != "b\nc" {
+		t.Errorf("tailLines() = %q, want %q", got, "b\nc")
+	}
+	if got := tailLines("a", 5); got != "a" {
+		t.Errorf("tailLines() = %q, want %q", got, "a")
+	}
+}
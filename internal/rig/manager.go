@@ -159,6 +159,7 @@ func (m *Manager) loadRig(name string, entry config.RigEntry) (*Rig, error) {
 		GitURL:    entry.GitURL,
 		LocalRepo: entry.LocalRepo,
 		Config:    entry.BeadsConfig,
+		Env:       entry.Env,
 	}
 
 	// Scan for polecats
@@ -522,6 +523,11 @@ Use crew for your own workspace. Polecats are for batch work dispatch.
 		return nil, fmt.Errorf("creating polecats dir: %w", err)
 	}
 
+	// Create runtime state directory (witness loop state, patrol history)
+	if err := os.MkdirAll(filepath.Join(rigPath, ".runtime"), 0755); err != nil {
+		return nil, fmt.Errorf("creating runtime dir: %w", err)
+	}
+
 	// Install Claude settings for all agent directories.
 	// Settings are placed in parent directories (not inside git repos) so Claude
 	// finds them via directory traversal without polluting source repos.
@@ -1189,10 +1195,12 @@ See docs/deacon-plugins.md for full documentation.
 		return fmt.Errorf("creating rig plugins directory: %w", err)
 	}
 
-	// Add plugins/ and .repo.git/ to rig .gitignore
+	// Add plugins/, .repo.git/ and .runtime/ to rig .gitignore
 	gitignorePath := filepath.Join(rigPath, ".gitignore")
-	if err := m.ensureGitignoreEntry(gitignorePath, "plugins/"); err != nil {
-		return err
+	for _, entry := range []string{"plugins/", ".repo.git/", ".runtime/"} {
+		if err := m.ensureGitignoreEntry(gitignorePath, entry); err != nil {
+			return err
+		}
 	}
-	return m.ensureGitignoreEntry(gitignorePath, ".repo.git/")
+	return nil
 }
@@ -327,6 +327,24 @@ func TestEnsureGitignoreEntry_AppendsToExisting(t *testing.T) {
 	}
 }
 
+func TestCreatePluginDirectories_IgnoresRigState(t *testing.T) {
+	root, rigsConfig := setupTestTown(t)
+	manager := NewManager(root, rigsConfig, git.NewGit(root))
+
+	rigPath := filepath.Join(root, "testrig")
+	if err := os.MkdirAll(rigPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.createPluginDirectories(rigPath); err != nil {
+		t.Fatalf("createPluginDirectories: %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(rigPath, ".gitignore"))
+	if string(content) != "plugins/\n.repo.git/\n.runtime/\n" {
+		t.Errorf(".gitignore = %q", string(content))
+	}
+}
+
 func TestInitBeads_TrackedBeads_CreatesRedirect(t *testing.T) {
 	t.Parallel()
 	// When the cloned repo has tracked beads (mayor/rig/.beads exists),
@@ -22,6 +22,9 @@ type Rig struct {
 	// Config is the rig-level configuration.
 	Config *config.BeadsConfig `json:"config,omitempty"`
 
+	// Env is extra environment for the rig's polecat and crew sessions.
+	Env map[string]string `json:"env,omitempty"`
+
 	// Polecats is the list of polecat names in this rig.
 	Polecats []string `json:"polecats,omitempty"`
 
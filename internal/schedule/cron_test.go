@@ -0,0 +1,65 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		if _, err := ParseCron(spec); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// Wednesday, 2026-01-14 10:07
+	base := time.Date(2026, 1, 14, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 1, 14, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 1, 14, 10, 15, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2026, 1, 15, 3, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 1, 14, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 8 * * 1-5", time.Date(2026, 1, 15, 8, 0, 0, 0, time.UTC)},
+		{"0 9 * * 6,7", time.Date(2026, 1, 17, 9, 0, 0, 0, time.UTC)}, // Saturday
+		{"0 0 1 * *", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 12 29 2 *", time.Date(2028, 2, 29, 12, 30, 0, 0, time.UTC)}, // leap day
+		// Both day fields restricted: either matches (the 20th, or Fridays).
+		{"0 0 20 * 5", time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tt.spec, err)
+		}
+		if got := c.Next(base); !got.Equal(tt.want) {
+			t.Errorf("%q.Next(%v) = %v, want %v", tt.spec, base, got, tt.want)
+		}
+	}
+}
+
+func TestCronNextNeverMatches(t *testing.T) {
+	c, err := ParseCron("0 0 31 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Next() = %v, want zero time", got)
+	}
+}
@@ -0,0 +1,316 @@
+// Package schedule runs recurring town maintenance jobs and keeps polecat
+// sessions within each rig's active hours.
+//
+// Jobs are defined in settings/schedule.json and run by the daemon, which
+// calls Tick about every 30 seconds. Session windows come from each rig's
+// settings/config.json and are enforced by TickWindows. Run history is kept in
+// daemon/schedule-state.json so 'gt schedule list/status' can report it, and
+// every run emits a feed event.
+package schedule
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Run outcomes recorded in JobState.LastStatus.
+const (
+	StatusOK      = "ok"
+	StatusFailed  = "failed"
+	StatusTimeout = "timeout"
+)
+
+// outputTailLines is how much job output is kept for 'gt schedule status'.
+const outputTailLines = 20
+
+// Parse returns the Schedule for a job definition.
+func Parse(job *config.ScheduledJob) (Schedule, error) {
+	if job.Every != "" {
+		d, err := time.ParseDuration(job.Every)
+		if err != nil {
+			return nil, fmt.Errorf("every %q: %w", job.Every, err)
+		}
+		return Every(d), nil
+	}
+	return ParseCron(job.Cron)
+}
+
+// JobState is the run history for one job.
+type JobState struct {
+	Spec       string    `json:"spec"` // schedule the NextRun was computed from
+	NextRun    time.Time `json:"next_run"`
+	LastRun    time.Time `json:"last_run,omitempty"`
+	LastStatus string    `json:"last_status,omitempty"`
+	LastError  string    `json:"last_error,omitempty"`
+	LastOutput string    `json:"last_output,omitempty"`
+	Duration   string    `json:"duration,omitempty"`
+	Runs       int       `json:"runs"`
+	Failures   int       `json:"failures"`
+}
+
+// State is the persisted scheduler state.
+type State struct {
+	Jobs map[string]*JobState `json:"jobs"`
+
+	// Windows tracks each scheduled rig's session window, keyed by rig.
+	Windows map[string]*WindowState `json:"windows,omitempty"`
+}
+
+// StatePath returns the scheduler state file for a town.
+func StatePath(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "schedule-state.json")
+}
+
+// LoadState loads scheduler state, returning empty state if none exists.
+func LoadState(townRoot string) (*State, error) {
+	st := &State{Jobs: make(map[string]*JobState), Windows: make(map[string]*WindowState)}
+	data, err := os.ReadFile(StatePath(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return st, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, fmt.Errorf("parsing schedule state: %w", err)
+	}
+	if st.Jobs == nil {
+		st.Jobs = make(map[string]*JobState)
+	}
+	if st.Windows == nil {
+		st.Windows = make(map[string]*WindowState)
+	}
+	return st, nil
+}
+
+// updateState applies fn to the state under a file lock, so the daemon and
+// 'gt schedule run' don't clobber each other's updates.
+func updateState(townRoot string, fn func(*State)) error {
+	path := StatePath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	lock := flock.New(path + ".lock")
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf("locking schedule state: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	st, err := LoadState(townRoot)
+	if err != nil {
+		return err
+	}
+	fn(st)
+	return util.AtomicWriteJSON(path, st)
+}
+
+// RunResult is the outcome of one job run.
+type RunResult struct {
+	Job      string
+	Status   string
+	Output   string
+	Error    error
+	Duration time.Duration
+}
+
+// Scheduler runs due jobs and enforces rig session windows for a town.
+type Scheduler struct {
+	townRoot string
+	logf     func(format string, args ...interface{})
+
+	// exec runs a job command; replaceable in tests.
+	exec func(ctx context.Context, dir, command string, env []string) (string, error)
+
+	// sessions stops and starts polecat sessions at window boundaries;
+	// replaceable in tests.
+	sessions rigSessions
+}
+
+// NewScheduler creates a scheduler for townRoot. logf may be nil.
+func NewScheduler(townRoot string, logf func(format string, args ...interface{})) *Scheduler {
+	if logf == nil {
+		logf = func(string, ...interface{}) {}
+	}
+	return &Scheduler{townRoot: townRoot, logf: logf, exec: runShell, sessions: townSessions{townRoot}}
+}
+
+// Tick runs every enabled job whose next run time has passed, then
+// schedules its following run. Jobs seen for the first time (or whose
+// schedule changed) are scheduled from now rather than run immediately.
+// A job missed while the daemon was down runs once on the next tick.
+func (s *Scheduler) Tick(ctx context.Context, now time.Time) ([]RunResult, error) {
+	cfg, err := config.LoadOrCreateScheduleConfig(config.ScheduleConfigPath(s.townRoot))
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make(map[string]Schedule)
+	var due []string
+	err = updateState(s.townRoot, func(st *State) {
+		for name := range st.Jobs {
+			if _, ok := cfg.Jobs[name]; !ok {
+				delete(st.Jobs, name) // job removed from config
+			}
+		}
+		for name, job := range cfg.Jobs {
+			sched, err := Parse(job)
+			if err != nil {
+				s.logf("Schedule %s: %v", name, err)
+				continue
+			}
+			schedules[name] = sched
+
+			js := st.Jobs[name]
+			if js == nil {
+				js = &JobState{}
+				st.Jobs[name] = js
+			}
+			if js.Spec != job.Spec() || js.NextRun.IsZero() {
+				js.Spec = job.Spec()
+				js.NextRun = sched.Next(now)
+				continue
+			}
+			if !job.Disabled && !now.Before(js.NextRun) {
+				due = append(due, name)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(due)
+	var results []RunResult
+	for _, name := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		res := s.run(ctx, name, cfg.Jobs[name], schedules[name], now)
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// RunNow runs a job immediately, regardless of its schedule.
+func (s *Scheduler) RunNow(ctx context.Context, name string) (*RunResult, error) {
+	cfg, err := config.LoadOrCreateScheduleConfig(config.ScheduleConfigPath(s.townRoot))
+	if err != nil {
+		return nil, err
+	}
+	job, ok := cfg.Jobs[name]
+	if !ok {
+		return nil, fmt.Errorf("no scheduled job named %q", name)
+	}
+	sched, err := Parse(job)
+	if err != nil {
+		return nil, err
+	}
+	res := s.run(ctx, name, job, sched, time.Now())
+	return &res, nil
+}
+
+// run executes one job, records the result, and emits a feed event.
+// now is the scheduler's notion of the start time; the next run is
+// computed from when the job finishes.
+func (s *Scheduler) run(ctx context.Context, name string, job *config.ScheduledJob, sched Schedule, now time.Time) RunResult {
+	dir := s.townRoot
+	if job.Dir != "" {
+		dir = filepath.Join(s.townRoot, job.Dir)
+	}
+	env := []string{"GT_TOWN_ROOT=" + s.townRoot, "GT_SCHEDULE_JOB=" + name}
+
+	s.logf("Schedule %s: running %q", name, job.Command)
+	runCtx, cancel := context.WithTimeout(ctx, job.TimeoutDuration())
+	start := time.Now()
+	output, err := s.exec(runCtx, dir, job.Command, env)
+	elapsed := time.Since(start).Round(time.Second)
+	timedOut := errors.Is(runCtx.Err(), context.DeadlineExceeded)
+	cancel()
+
+	res := RunResult{Job: name, Status: StatusOK, Output: tailLines(output, outputTailLines), Error: err, Duration: elapsed}
+	switch {
+	case timedOut:
+		res.Status = StatusTimeout
+		res.Error = fmt.Errorf("timed out after %s", job.TimeoutDuration())
+	case err != nil:
+		res.Status = StatusFailed
+	}
+
+	end := now.Add(time.Since(start))
+	if err := updateState(s.townRoot, func(st *State) {
+		js := st.Jobs[name]
+		if js == nil {
+			js = &JobState{}
+			st.Jobs[name] = js
+		}
+		js.Spec = job.Spec()
+		js.NextRun = sched.Next(end)
+		js.LastRun = now
+		js.LastStatus = res.Status
+		js.LastOutput = res.Output
+		js.Duration = elapsed.String()
+		js.LastError = ""
+		js.Runs++
+		if res.Error != nil {
+			js.LastError = res.Error.Error()
+			js.Failures++
+		}
+	}); err != nil {
+		s.logf("Schedule %s: saving state: %v", name, err)
+	}
+
+	eventType := events.TypeScheduleRun
+	if res.Error != nil {
+		eventType = events.TypeScheduleFailed
+		s.logf("Schedule %s: %s after %s: %v", name, res.Status, elapsed, res.Error)
+	} else {
+		s.logf("Schedule %s: ok (%s)", name, elapsed)
+	}
+	errMsg := ""
+	if res.Error != nil {
+		errMsg = res.Error.Error()
+	}
+	_ = events.LogFeed(eventType, "daemon", events.SchedulePayload(name, job.Command, res.Status, elapsed.String(), errMsg))
+
+	return res
+}
+
+// killWaitDelay bounds how long a killed job may keep its output open.
+const killWaitDelay = 5 * time.Second
+
+// runShell runs command with sh -c, returning combined output.
+func runShell(ctx context.Context, dir, command string, env []string) (string, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec // G204: commands come from town config
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	// Don't wait on grandchildren still holding the output pipe after a timeout.
+	cmd.WaitDelay = killWaitDelay
+	err := cmd.Run()
+	return out.String(), err
+}
+
+// tailLines returns the last n lines of s.
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
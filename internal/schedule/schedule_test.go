@@ -0,0 +1,128 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func writeJobs(t *testing.T, townRoot string, jobs map[string]*config.ScheduledJob) {
+	t.Helper()
+	cfg := config.NewScheduleConfig()
+	cfg.Jobs = jobs
+	if err := config.SaveScheduleConfig(config.ScheduleConfigPath(townRoot), cfg); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTickRunsDueJobs(t *testing.T) {
+	t.Chdir(t.TempDir()) // keep feed events out of any enclosing town
+	townRoot := t.TempDir()
+	writeJobs(t, townRoot, map[string]*config.ScheduledJob{
+		"fetch":  {Every: "30m", Command: "git fetch --prune"},
+		"broken": {Every: "1h", Command: "false"},
+		"paused": {Every: "30m", Command: "echo", Disabled: true},
+	})
+
+	var ran []string
+	s := NewScheduler(townRoot, nil)
+	s.exec = func(_ context.Context, dir, command string, env []string) (string, error) {
+		ran = append(ran, command)
+		if dir != townRoot {
+			t.Errorf("dir = %q, want town root", dir)
+		}
+		if command == "false" {
+			return "boom\n", errors.New("exit status 1")
+		}
+		return "ok\n", nil
+	}
+
+	start := time.Now()
+
+	// First tick only schedules.
+	results, err := s.Tick(context.Background(), start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("first tick ran %d jobs, want 0", len(results))
+	}
+
+	// Nothing due yet.
+	if results, _ := s.Tick(context.Background(), start.Add(10*time.Minute)); len(results) != 0 {
+		t.Fatalf("ran %d jobs before due", len(results))
+	}
+
+	// After 2h both enabled jobs are due; a missed window runs once.
+	results, err = s.Tick(context.Background(), start.Add(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || len(ran) != 2 {
+		t.Fatalf("ran %v, want broken and fetch", ran)
+	}
+
+	st, err := LoadState(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if js := st.Jobs["fetch"]; js.LastStatus != StatusOK || js.Runs != 1 || !js.NextRun.After(start.Add(2*time.Hour)) {
+		t.Errorf("fetch state = %+v", js)
+	}
+	if js := st.Jobs["broken"]; js.LastStatus != StatusFailed || js.Failures != 1 || js.LastOutput != "boom" {
+		t.Errorf("broken state = %+v", js)
+	}
+	if js := st.Jobs["paused"]; js.Runs != 0 {
+		t.Errorf("disabled job ran: %+v", js)
+	}
+}
+
+func TestTickReschedulesChangedJob(t *testing.T) {
+	t.Chdir(t.TempDir())
+	townRoot := t.TempDir()
+	writeJobs(t, townRoot, map[string]*config.ScheduledJob{"j": {Every: "1h", Command: "true"}})
+
+	s := NewScheduler(townRoot, nil)
+	s.exec = func(context.Context, string, string, []string) (string, error) { return "", nil }
+
+	now := time.Now()
+	if _, err := s.Tick(context.Background(), now); err != nil {
+		t.Fatal(err)
+	}
+
+	writeJobs(t, townRoot, map[string]*config.ScheduledJob{"j": {Every: "6h", Command: "true"}})
+	results, err := s.Tick(context.Background(), now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Errorf("changed job ran immediately; want it rescheduled")
+	}
+	st, _ := LoadState(townRoot)
+	if want := now.Add(8 * time.Hour); !st.Jobs["j"].NextRun.Equal(want) {
+		t.Errorf("NextRun = %v, want %v", st.Jobs["j"].NextRun, want)
+	}
+}
+
+func TestRunNowTimeout(t *testing.T) {
+	t.Chdir(t.TempDir())
+	townRoot := t.TempDir()
+	writeJobs(t, townRoot, map[string]*config.ScheduledJob{
+		"slow": {Every: "1h", Command: "exec sleep 5", Timeout: "50ms"},
+	})
+
+	res, err := NewScheduler(townRoot, nil).RunNow(context.Background(), "slow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != StatusTimeout {
+		t.Errorf("status = %q, want %q", res.Status, StatusTimeout)
+	}
+
+	if _, err := NewScheduler(townRoot, nil).RunNow(context.Background(), "missing"); err == nil {
+		t.Error("expected error for unknown job")
+	}
+}
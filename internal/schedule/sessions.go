@@ -0,0 +1,251 @@
+package schedule
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/wisp"
+)
+
+// WindowState is the last known state of a rig's session window.
+type WindowState struct {
+	Spec  string    `json:"spec"` // window the state was computed from
+	Open  bool      `json:"open"`
+	Since time.Time `json:"since"`
+
+	// Paused lists the polecats stopped when the window last closed, to be
+	// started again when it reopens.
+	Paused []string `json:"paused,omitempty"`
+}
+
+// WindowChange is a session window boundary acted on by TickWindows.
+type WindowChange struct {
+	Rig      string
+	Open     bool     // true if the window opened, false if it closed
+	Polecats []string // polecats started or stopped
+	Errors   []error
+}
+
+// RigWindow returns a rig's configured session window, or nil if the rig
+// runs around the clock.
+func RigWindow(rigPath string) (*Window, *config.RigScheduleConfig, error) {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+	if err != nil || settings.Schedule == nil || settings.Schedule.Active == "" {
+		return nil, nil, nil //nolint:nilerr // missing settings mean no schedule
+	}
+	w, err := ParseWindow(settings.Schedule.Active)
+	if err != nil {
+		return nil, settings.Schedule, err
+	}
+	return &w, settings.Schedule, nil
+}
+
+// TickWindows stops polecat sessions in rigs whose session window has
+// closed since the last tick and starts the stopped ones again when it
+// reopens, mailing the rig's notify address about each. A window seen for
+// the first time (or changed) only has its state recorded, so setting a
+// schedule never kills running sessions on the spot.
+func (s *Scheduler) TickWindows(now time.Time) ([]WindowChange, error) {
+	rigsCfg, err := config.LoadRigsConfig(filepath.Join(s.townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	type transition struct {
+		rig, notify string
+		window      Window
+		open        bool
+		paused      []string
+	}
+	var due, unpaused []transition
+	err = updateState(s.townRoot, func(st *State) {
+		for name := range st.Windows {
+			if _, ok := rigsCfg.Rigs[name]; !ok {
+				delete(st.Windows, name)
+			}
+		}
+		for name := range rigsCfg.Rigs {
+			w, cfg, err := RigWindow(filepath.Join(s.townRoot, name))
+			if err != nil {
+				s.logf("Session window %s: %v", name, err)
+				continue
+			}
+			if w == nil {
+				// Schedule cleared while closed: bring the paused polecats back
+				if ws := st.Windows[name]; ws != nil && !ws.Open && len(ws.Paused) > 0 {
+					unpaused = append(unpaused, transition{rig: name, open: true, paused: ws.Paused})
+				}
+				delete(st.Windows, name)
+				continue
+			}
+
+			open := w.Contains(now)
+			ws := st.Windows[name]
+			if ws == nil || ws.Spec != w.String() {
+				st.Windows[name] = &WindowState{Spec: w.String(), Open: open, Since: now}
+				continue
+			}
+			if ws.Open == open {
+				continue
+			}
+			notify := cfg.Notify
+			if notify == "" {
+				notify = name + "/witness"
+			}
+			due = append(due, transition{rig: name, notify: notify, window: *w, open: open, paused: ws.Paused})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(due, func(i, j int) bool { return due[i].rig < due[j].rig })
+	var changes []WindowChange
+	for _, tr := range unpaused {
+		s.logf("Session window %s removed: starting %d paused polecat(s)", tr.rig, len(tr.paused))
+		change := WindowChange{Rig: tr.rig, Open: true}
+		change.Polecats, change.Errors = s.sessions.start(tr.rig, tr.paused)
+		for _, err := range change.Errors {
+			s.logf("Session window %s: %v", tr.rig, err)
+		}
+		changes = append(changes, change)
+	}
+	for _, tr := range due {
+		change := WindowChange{Rig: tr.rig, Open: tr.open}
+		var subject, body string
+		if tr.open {
+			s.logf("Session window %s opened: starting %d polecat(s)", tr.rig, len(tr.paused))
+			change.Polecats, change.Errors = s.sessions.start(tr.rig, tr.paused)
+			subject = fmt.Sprintf("Session window opened: %s", tr.rig)
+			body = fmt.Sprintf("The %s session window opened. Polecats restarted: %s\n\nThe window closes at %s.",
+				tr.window, listOrNone(change.Polecats), tr.window.Next(now).Format("Mon 15:04"))
+		} else {
+			s.logf("Session window %s closed: stopping polecat sessions", tr.rig)
+			change.Polecats, change.Errors = s.sessions.stop(tr.rig)
+			subject = fmt.Sprintf("Session window closed: %s", tr.rig)
+			body = fmt.Sprintf("The %s session window closed. Polecats stopped: %s\n\nThey will be restarted when the window opens at %s.",
+				tr.window, listOrNone(change.Polecats), tr.window.Next(now).Format("Mon 15:04"))
+		}
+		for _, err := range change.Errors {
+			s.logf("Session window %s: %v", tr.rig, err)
+			body += "\nError: " + err.Error()
+		}
+		if err := s.sessions.notify(tr.notify, subject, body); err != nil {
+			s.logf("Session window %s: notifying %s: %v", tr.rig, tr.notify, err)
+		}
+
+		if err := updateState(s.townRoot, func(st *State) {
+			ws := st.Windows[tr.rig]
+			if ws == nil {
+				return // schedule removed while we were acting
+			}
+			ws.Open = tr.open
+			ws.Since = now
+			ws.Paused = nil
+			if !tr.open {
+				ws.Paused = change.Polecats
+			}
+		}); err != nil {
+			s.logf("Session window %s: saving state: %v", tr.rig, err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func listOrNone(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
+// rigSessions is how TickWindows acts on a rig's polecat sessions.
+type rigSessions interface {
+	// stop stops every running polecat session in the rig, returning the
+	// polecats stopped.
+	stop(rigName string) ([]string, []error)
+
+	// start starts sessions for the given polecats, returning those started.
+	start(rigName string, polecats []string) ([]string, []error)
+
+	// notify mails a boundary notice.
+	notify(to, subject, body string) error
+}
+
+// townSessions acts on real tmux sessions in a town.
+type townSessions struct {
+	townRoot string
+}
+
+func (ts townSessions) sessionManager(rigName string) (*polecat.SessionManager, error) {
+	rigsCfg, err := config.LoadRigsConfig(filepath.Join(ts.townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return nil, err
+	}
+	r, err := rig.NewManager(ts.townRoot, rigsCfg, git.NewGit(ts.townRoot)).GetRig(rigName)
+	if err != nil {
+		return nil, err
+	}
+	return polecat.NewSessionManager(tmux.NewTmux(), r), nil
+}
+
+func (ts townSessions) stop(rigName string) ([]string, []error) {
+	sessMgr, err := ts.sessionManager(rigName)
+	if err != nil {
+		return nil, []error{err}
+	}
+	infos, err := sessMgr.List()
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var stopped []string
+	var errs []error
+	for _, info := range infos {
+		if err := sessMgr.StopWithOptions(info.Polecat, polecat.SessionStopOptions{Reason: polecat.ExitScheduleStop}); err != nil {
+			errs = append(errs, fmt.Errorf("stopping %s: %w", info.Polecat, err))
+			continue
+		}
+		stopped = append(stopped, info.Polecat)
+	}
+	return stopped, errs
+}
+
+func (ts townSessions) start(rigName string, polecats []string) ([]string, []error) {
+	if len(polecats) == 0 {
+		return nil, nil
+	}
+	// Parked and docked rigs stay down regardless of schedule
+	if status := wisp.NewConfig(ts.townRoot, rigName).GetString("status"); status == "parked" || status == "docked" {
+		return nil, []error{fmt.Errorf("rig is %s; not starting sessions", status)}
+	}
+	sessMgr, err := ts.sessionManager(rigName)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var started []string
+	var errs []error
+	for _, res := range sessMgr.StartAll(polecats, polecat.StartAllOptions{}) {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("starting %s: %w", res.Polecat, res.Err))
+			continue
+		}
+		started = append(started, res.Polecat)
+	}
+	return started, errs
+}
+
+func (ts townSessions) notify(to, subject, body string) error {
+	return mail.NewRouter(ts.townRoot).Send(mail.NewMessage("deacon/", to, subject, body))
+}
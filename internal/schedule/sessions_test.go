@@ -0,0 +1,129 @@
+package schedule
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// fakeSessions records what TickWindows asks of a rig's sessions.
+type fakeSessions struct {
+	running map[string][]string
+	mailed  []string
+}
+
+func (f *fakeSessions) stop(rigName string) ([]string, []error) {
+	stopped := f.running[rigName]
+	f.running[rigName] = nil
+	return stopped, nil
+}
+
+func (f *fakeSessions) start(rigName string, polecats []string) ([]string, []error) {
+	f.running[rigName] = append(f.running[rigName], polecats...)
+	return polecats, nil
+}
+
+func (f *fakeSessions) notify(to, subject, body string) error {
+	f.mailed = append(f.mailed, to+": "+subject)
+	return nil
+}
+
+func setupScheduledRig(t *testing.T, window string) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	rigsCfg := &config.RigsConfig{Version: 1, Rigs: map[string]config.RigEntry{"gastown": {}}}
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"), rigsCfg); err != nil {
+		t.Fatal(err)
+	}
+	setRigWindow(t, townRoot, window)
+	return townRoot
+}
+
+func setRigWindow(t *testing.T, townRoot, window string) {
+	t.Helper()
+	settings := config.NewRigSettings()
+	if window != "" {
+		settings.Schedule = &config.RigScheduleConfig{Active: window}
+	}
+	if err := config.SaveRigSettings(config.RigSettingsPath(filepath.Join(townRoot, "gastown")), settings); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTickWindows(t *testing.T) {
+	townRoot := setupScheduledRig(t, "22:00-06:00")
+	fake := &fakeSessions{running: map[string][]string{"gastown": {"Cheedo", "Toast"}}}
+	s := NewScheduler(townRoot, nil)
+	s.sessions = fake
+
+	at := func(day, h int) time.Time { return time.Date(2026, 1, day, h, 0, 0, 0, time.Local) }
+
+	// First sight inside the window only records state
+	if changes, err := s.TickWindows(at(14, 23)); err != nil || len(changes) != 0 {
+		t.Fatalf("first tick = %+v, %v; want no changes", changes, err)
+	}
+	if changes, _ := s.TickWindows(at(15, 2)); len(changes) != 0 {
+		t.Fatalf("tick inside window changed %+v", changes)
+	}
+
+	// Window closes: sessions stop and the witness is told
+	changes, err := s.TickWindows(at(15, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Open || !reflect.DeepEqual(changes[0].Polecats, []string{"Cheedo", "Toast"}) {
+		t.Fatalf("close tick = %+v", changes)
+	}
+	if len(fake.running["gastown"]) != 0 {
+		t.Errorf("sessions still running after close: %v", fake.running["gastown"])
+	}
+	if len(fake.mailed) != 1 || fake.mailed[0] != "gastown/witness: Session window closed: gastown" {
+		t.Errorf("mailed = %v", fake.mailed)
+	}
+
+	// Window reopens: the same polecats start again
+	changes, err = s.TickWindows(at(15, 22))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || !changes[0].Open || !reflect.DeepEqual(fake.running["gastown"], []string{"Cheedo", "Toast"}) {
+		t.Fatalf("open tick = %+v, running %v", changes, fake.running["gastown"])
+	}
+	st, _ := LoadState(townRoot)
+	if ws := st.Windows["gastown"]; ws == nil || !ws.Open || len(ws.Paused) != 0 {
+		t.Errorf("window state after reopen = %+v", ws)
+	}
+}
+
+func TestTickWindowsScheduleCleared(t *testing.T) {
+	townRoot := setupScheduledRig(t, "22:00-06:00")
+	fake := &fakeSessions{running: map[string][]string{"gastown": {"Toast"}}}
+	s := NewScheduler(townRoot, nil)
+	s.sessions = fake
+
+	at := func(h int) time.Time { return time.Date(2026, 1, 14, h, 0, 0, 0, time.Local) }
+	_, _ = s.TickWindows(at(5))
+	if changes, _ := s.TickWindows(at(7)); len(changes) != 1 {
+		t.Fatalf("window didn't close: %+v", changes)
+	}
+
+	setRigWindow(t, townRoot, "")
+	changes, err := s.TickWindows(at(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || !reflect.DeepEqual(fake.running["gastown"], []string{"Toast"}) {
+		t.Errorf("clearing schedule = %+v, running %v; want Toast restarted", changes, fake.running["gastown"])
+	}
+	st, _ := LoadState(townRoot)
+	if _, ok := st.Windows["gastown"]; ok {
+		t.Error("window state kept after schedule cleared")
+	}
+}
@@ -0,0 +1,87 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Window is a daily span of local clock time, such as 22:00-06:00.
+// A window whose end is before its start wraps past midnight.
+type Window struct {
+	Start, End time.Duration // offsets from midnight
+}
+
+// ParseWindow parses "HH:MM-HH:MM". Start and end must differ.
+func ParseWindow(spec string) (Window, error) {
+	startStr, endStr, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return Window{}, fmt.Errorf("window %q: expected HH:MM-HH:MM", spec)
+	}
+	start, err := parseClock(startStr)
+	if err != nil {
+		return Window{}, fmt.Errorf("window %q: %w", spec, err)
+	}
+	end, err := parseClock(endStr)
+	if err != nil {
+		return Window{}, fmt.Errorf("window %q: %w", spec, err)
+	}
+	if start == end {
+		return Window{}, fmt.Errorf("window %q: start and end are the same", spec)
+	}
+	return Window{Start: start, End: end}, nil
+}
+
+// parseClock parses HH:MM (24-hour) into an offset from midnight.
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (want HH:MM)", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// String formats the window as HH:MM-HH:MM.
+func (w Window) String() string {
+	return formatClock(w.Start) + "-" + formatClock(w.End)
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// Contains reports whether t falls inside the window. The start is
+// inclusive and the end exclusive.
+func (w Window) Contains(t time.Time) bool {
+	offset := t.Sub(midnight(t))
+	if w.Start < w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// Next returns the first window boundary (opening or closing) strictly
+// after t.
+func (w Window) Next(t time.Time) time.Time {
+	day := midnight(t)
+	var next time.Time
+	for i := 0; i < 2 && next.IsZero(); i++ {
+		for _, off := range []time.Duration{w.Start, w.End} {
+			b := atClock(day.AddDate(0, 0, i), off)
+			if b.After(t) && (next.IsZero() || b.Before(next)) {
+				next = b
+			}
+		}
+	}
+	return next
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// atClock returns the wall-clock time off after day's midnight, so window
+// boundaries stay put across daylight saving changes.
+func atClock(day time.Time, off time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), int(off.Hours()), int(off.Minutes())%60, 0, 0, day.Location())
+}
@@ -0,0 +1,53 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWindow(t *testing.T) {
+	w, err := ParseWindow(" 22:00-6:30 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.String() != "22:00-06:30" {
+		t.Errorf("String() = %q, want 22:00-06:30", w)
+	}
+
+	for _, spec := range []string{"", "22:00", "25:00-06:00", "22:00-xx", "08:00-08:00"} {
+		if _, err := ParseWindow(spec); err == nil {
+			t.Errorf("ParseWindow(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestWindowContainsAndNext(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2026, 1, 14, h, m, 0, 0, time.UTC) }
+
+	overnight, _ := ParseWindow("22:00-06:00")
+	daytime, _ := ParseWindow("09:00-17:00")
+
+	tests := []struct {
+		w        Window
+		t        time.Time
+		contains bool
+		next     time.Time
+	}{
+		{overnight, at(23, 0), true, at(6, 0).AddDate(0, 0, 1)},
+		{overnight, at(3, 0), true, at(6, 0)},
+		{overnight, at(6, 0), false, at(22, 0)},
+		{overnight, at(12, 0), false, at(22, 0)},
+		{overnight, at(22, 0), true, at(6, 0).AddDate(0, 0, 1)},
+		{daytime, at(8, 59), false, at(9, 0)},
+		{daytime, at(12, 0), true, at(17, 0)},
+		{daytime, at(18, 0), false, at(9, 0).AddDate(0, 0, 1)},
+	}
+	for _, tt := range tests {
+		if got := tt.w.Contains(tt.t); got != tt.contains {
+			t.Errorf("%s.Contains(%s) = %v, want %v", tt.w, tt.t.Format("15:04"), got, tt.contains)
+		}
+		if got := tt.w.Next(tt.t); !got.Equal(tt.next) {
+			t.Errorf("%s.Next(%s) = %v, want %v", tt.w, tt.t.Format("15:04"), got, tt.next)
+		}
+	}
+}
@@ -95,6 +95,27 @@ func ParseSessionName(session string) (*AgentIdentity, error) {
 	return &AgentIdentity{Role: RolePolecat, Rig: rig, Name: name}, nil
 }
 
+// ParseAddress parses a mail-style agent address into an AgentIdentity. It
+// is the inverse of Address; a trailing slash (e.g., "mayor/") is allowed.
+func ParseAddress(address string) (*AgentIdentity, error) {
+	parts := strings.Split(strings.TrimSuffix(address, "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == "mayor":
+		return &AgentIdentity{Role: RoleMayor}, nil
+	case len(parts) == 1 && parts[0] == "deacon":
+		return &AgentIdentity{Role: RoleDeacon}, nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] == "witness":
+		return &AgentIdentity{Role: RoleWitness, Rig: parts[0]}, nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] == "refinery":
+		return &AgentIdentity{Role: RoleRefinery, Rig: parts[0]}, nil
+	case len(parts) == 3 && parts[0] != "" && parts[1] == "crew" && parts[2] != "":
+		return &AgentIdentity{Role: RoleCrew, Rig: parts[0], Name: parts[2]}, nil
+	case len(parts) == 3 && parts[0] != "" && parts[1] == "polecats" && parts[2] != "":
+		return &AgentIdentity{Role: RolePolecat, Rig: parts[0], Name: parts[2]}, nil
+	}
+	return nil, fmt.Errorf("invalid agent address %q", address)
+}
+
 // SessionName returns the tmux session name for this identity.
 func (a *AgentIdentity) SessionName() string {
 	switch a.Role {
@@ -250,3 +250,35 @@ func TestParseSessionName_RoundTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAddress_RoundTrip(t *testing.T) {
+	addresses := []string{
+		"mayor",
+		"deacon",
+		"gastown/witness",
+		"foo-bar/refinery",
+		"gastown/crew/max",
+		"gastown/polecats/Toast",
+	}
+
+	for _, addr := range addresses {
+		t.Run(addr, func(t *testing.T) {
+			identity, err := ParseAddress(addr)
+			if err != nil {
+				t.Fatalf("ParseAddress(%q) error = %v", addr, err)
+			}
+			if got := identity.Address(); got != addr {
+				t.Errorf("Round-trip failed: ParseAddress(%q).Address() = %q", addr, got)
+			}
+		})
+	}
+
+	if identity, err := ParseAddress("mayor/"); err != nil || identity.Role != RoleMayor {
+		t.Errorf("ParseAddress(\"mayor/\") = %v, %v; want mayor", identity, err)
+	}
+	for _, bad := range []string{"", "gastown", "gastown/polecats", "gastown/other/x", "/witness"} {
+		if _, err := ParseAddress(bad); err == nil {
+			t.Errorf("ParseAddress(%q) should fail", bad)
+		}
+	}
+}
@@ -14,6 +14,10 @@ const Prefix = "gt-"
 // HQPrefix is the prefix for town-level services (Mayor, Deacon).
 const HQPrefix = "hq-"
 
+// PairPrefix is the prefix for crew pairing sessions. They sit outside the
+// gt- namespace because they host two agents and are not agent identities.
+const PairPrefix = "pair-"
+
 // MayorSessionName returns the session name for the Mayor agent.
 // One mayor per machine - multi-town requires containers/VMs for isolation.
 func MayorSessionName() string {
@@ -41,6 +45,15 @@ func CrewSessionName(rig, name string) string {
 	return fmt.Sprintf("%s%s-crew-%s", Prefix, rig, name)
 }
 
+// CrewPairSessionName returns the session name pairing two crew workers.
+// The rig is only repeated when the workers are in different rigs.
+func CrewPairSessionName(rigA, nameA, rigB, nameB string) string {
+	if rigA == rigB {
+		return fmt.Sprintf("%s%s-%s-%s", PairPrefix, rigA, nameA, nameB)
+	}
+	return fmt.Sprintf("%s%s-%s-%s-%s", PairPrefix, rigA, nameA, rigB, nameB)
+}
+
 // PolecatSessionName returns the session name for a polecat in a rig.
 func PolecatSessionName(rig, name string) string {
 	return fmt.Sprintf("%s%s-%s", Prefix, rig, name)
@@ -83,6 +83,18 @@ func TestCrewSessionName(t *testing.T) {
 	}
 }
 
+func TestCrewPairSessionName(t *testing.T) {
+	if got, want := CrewPairSessionName("gastown", "max", "gastown", "joe"), "pair-gastown-max-joe"; got != want {
+		t.Errorf("same rig: got %q, want %q", got, want)
+	}
+	if got, want := CrewPairSessionName("gastown", "max", "beads", "emma"), "pair-gastown-max-beads-emma"; got != want {
+		t.Errorf("cross rig: got %q, want %q", got, want)
+	}
+	if _, err := ParseSessionName(CrewPairSessionName("gastown", "max", "gastown", "joe")); err == nil {
+		t.Error("pair session parsed as an agent identity")
+	}
+}
+
 func TestPolecatSessionName(t *testing.T) {
 	tests := []struct {
 		rig  string
@@ -0,0 +1,171 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// RestoreOptions configures Restore.
+type RestoreOptions struct {
+	// Force restores over a dirty workspace, stashing its changes first.
+	Force bool
+
+	// SkipMail leaves the target's inbox untouched.
+	SkipMail bool
+}
+
+// RestoreResult reports what Restore did.
+type RestoreResult struct {
+	Manifest        *Manifest
+	Stashed         bool // target's previous changes were stashed
+	MailRestored    int
+	HandoffRestored bool
+	Transcript      string // captured pane output, for reference
+	Warnings        []string
+}
+
+// Restore reconstitutes a snapshot into the target workspace: the branch is
+// checked out at the snapshotted HEAD, uncommitted and untracked work is
+// reapplied, and missing mail and the handoff note are restored. The target
+// may be a different worker (or machine) than the one snapshotted, as long
+// as its workspace is a clone of the same repository.
+func Restore(townRoot, path string, t Target, opts RestoreOptions) (*RestoreResult, error) {
+	stage, m, err := open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(stage)
+
+	res := &RestoreResult{Manifest: m}
+	g := git.NewGit(t.WorkDir)
+	if !g.IsRepo() {
+		return nil, fmt.Errorf("%s is not a git workspace", t.WorkDir)
+	}
+
+	dirty, err := g.HasUncommittedChanges()
+	if err != nil {
+		return nil, fmt.Errorf("checking workspace status: %w", err)
+	}
+	if dirty {
+		if !opts.Force {
+			return nil, fmt.Errorf("%s has uncommitted changes (use --force to stash them first)", t.WorkDir)
+		}
+		if err := g.StashPush("gt snapshot restore: backup before " + filepath.Base(path)); err != nil {
+			return nil, fmt.Errorf("stashing changes: %w", err)
+		}
+		res.Stashed = true
+	}
+
+	// Remote commits may be newer than this clone; the bundle holds the rest.
+	if err := g.Fetch("origin"); err != nil {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("fetch origin: %v", err))
+	}
+	if m.HasBundle {
+		ref := "HEAD"
+		if m.Branch != "" {
+			ref = "refs/heads/" + m.Branch
+		}
+		if err := g.FetchBundle(filepath.Join(stage, bundleFile), ref); err != nil {
+			return res, fmt.Errorf("fetching snapshot commits: %w", err)
+		}
+	}
+
+	if m.Branch != "" {
+		err = g.CheckoutBranchAt(m.Branch, m.Head)
+	} else {
+		err = g.Checkout(m.Head)
+	}
+	if err != nil {
+		return res, fmt.Errorf("checking out %s: %w", m.Head, err)
+	}
+
+	if m.HasPatch {
+		if err := g.ApplyPatch(filepath.Join(stage, patchFile)); err != nil {
+			return res, fmt.Errorf("applying uncommitted changes: %w", err)
+		}
+	}
+
+	for _, rel := range m.Untracked {
+		dst := filepath.Join(t.WorkDir, rel)
+		if _, err := os.Lstat(dst); err == nil {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("untracked file %s already exists, kept existing", rel))
+			continue
+		}
+		src := filepath.Join(stage, untrackedDir, rel)
+		info, err := os.Stat(src)
+		if err != nil {
+			return res, fmt.Errorf("reading untracked file %s: %w", rel, err)
+		}
+		if err := copyFile(src, dst, info.Mode().Perm()); err != nil {
+			return res, fmt.Errorf("restoring untracked file %s: %w", rel, err)
+		}
+	}
+
+	if !opts.SkipMail && m.MailCount > 0 {
+		n, err := restoreMail(townRoot, t, filepath.Join(stage, mailFile))
+		res.MailRestored = n
+		if err != nil {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("mail: %v", err))
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(stage, handoffFile)); err == nil && len(data) > 0 {
+		if err := beads.New(t.WorkDir).UpdateHandoffContent(t.Name, string(data)); err != nil {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("handoff: %v", err))
+		} else {
+			res.HandoffRestored = true
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(stage, transcriptFile)); err == nil {
+		res.Transcript = string(data)
+	}
+	return res, nil
+}
+
+// restoreMail re-sends snapshotted messages that aren't already in the
+// target's inbox, addressed to the target.
+func restoreMail(townRoot string, t Target, path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	var msgs []*mail.Message
+	if err := json.Unmarshal(data, &msgs); err != nil {
+		return 0, fmt.Errorf("parsing mail: %w", err)
+	}
+
+	router := mail.NewRouterWithTownRoot(t.WorkDir, townRoot)
+	type key struct{ from, subject, body string }
+	have := make(map[key]bool)
+	if mailbox, err := router.GetMailbox(t.Address); err == nil {
+		if existing, err := mailbox.List(); err == nil {
+			for _, msg := range existing {
+				have[key{msg.From, msg.Subject, msg.Body}] = true
+			}
+		}
+	}
+
+	restored := 0
+	for _, msg := range msgs {
+		if have[key{msg.From, msg.Subject, msg.Body}] {
+			continue
+		}
+		out := mail.NewMessage(msg.From, t.Address, msg.Subject, msg.Body)
+		out.Priority = msg.Priority
+		out.Type = msg.Type
+		out.ThreadID = msg.ThreadID
+		out.ReplyTo = msg.ReplyTo
+		if err := router.Send(out); err != nil {
+			return restored, err
+		}
+		restored++
+	}
+	return restored, nil
+}
@@ -0,0 +1,363 @@
+// Package snapshot captures and restores the full working state of a worker.
+//
+// A snapshot is a single .tar.gz artifact holding:
+//   - manifest.json   identity, branch, HEAD, and what else is included
+//   - repo.bundle     commits on the branch not yet on the remote (git bundle)
+//   - wip.patch       uncommitted changes (staged and unstaged) against HEAD
+//   - untracked/      untracked, non-ignored files
+//   - mail.json       the worker's inbox
+//   - handoff.md      the worker's handoff bead content
+//   - transcript.txt  recent tmux pane output
+//
+// Snapshots are used to checkpoint a worker before risky operations and to
+// migrate a worker between machines.
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// FormatVersion is the current snapshot artifact format.
+const FormatVersion = 1
+
+// DefaultTranscriptLines is how much pane scrollback is captured by default.
+const DefaultTranscriptLines = 2000
+
+// Artifact member names.
+const (
+	manifestFile   = "manifest.json"
+	bundleFile     = "repo.bundle"
+	patchFile      = "wip.patch"
+	untrackedDir   = "untracked"
+	mailFile       = "mail.json"
+	handoffFile    = "handoff.md"
+	transcriptFile = "transcript.txt"
+)
+
+// Dir returns the default directory for snapshots in a town.
+func Dir(townRoot string) string {
+	return filepath.Join(townRoot, "snapshots")
+}
+
+// Target identifies the worker being snapshotted or restored.
+type Target struct {
+	Rig     string
+	Kind    string // "crew" or "polecat"
+	Name    string
+	Address string // mail address, e.g. "gastown/crew/max" or "gastown/Toast"
+	WorkDir string // git workspace
+	Session string // tmux session name
+}
+
+// Manifest describes a snapshot's contents.
+type Manifest struct {
+	Version   int       `json:"version"`
+	Address   string    `json:"address"`
+	Rig       string    `json:"rig"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	Host      string    `json:"host,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Branch    string   `json:"branch,omitempty"` // empty when HEAD was detached
+	Head      string   `json:"head"`
+	HasBundle bool     `json:"has_bundle"` // false when all commits are on the remote
+	HasPatch  bool     `json:"has_patch"`
+	Untracked []string `json:"untracked,omitempty"`
+
+	MailCount     int    `json:"mail_count"`
+	HandoffBead   string `json:"handoff_bead,omitempty"`
+	HasTranscript bool   `json:"has_transcript"`
+
+	// Warnings records parts that could not be captured.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// CreateOptions configures Create.
+type CreateOptions struct {
+	// Output is the artifact path (default: <town>/snapshots/<rig>-<name>-<time>.tar.gz).
+	Output string
+
+	// TranscriptLines is how many lines of pane scrollback to capture.
+	TranscriptLines int
+}
+
+// Create captures t into a snapshot artifact and returns its path.
+// Git state must be captured; mail, handoff, and transcript are best-effort
+// and failures are recorded as manifest warnings.
+func Create(townRoot string, t Target, opts CreateOptions) (string, *Manifest, error) {
+	g := git.NewGit(t.WorkDir)
+	if !g.IsRepo() {
+		return "", nil, fmt.Errorf("%s is not a git workspace", t.WorkDir)
+	}
+
+	stage, err := os.MkdirTemp("", "gt-snapshot-")
+	if err != nil {
+		return "", nil, err
+	}
+	defer os.RemoveAll(stage)
+
+	host, _ := os.Hostname()
+	m := &Manifest{
+		Version:   FormatVersion,
+		Address:   t.Address,
+		Rig:       t.Rig,
+		Kind:      t.Kind,
+		Name:      t.Name,
+		Host:      host,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := captureGit(g, t.WorkDir, stage, m); err != nil {
+		return "", nil, err
+	}
+	captureMail(townRoot, t, stage, m)
+	captureHandoff(t, stage, m)
+	lines := opts.TranscriptLines
+	if lines <= 0 {
+		lines = DefaultTranscriptLines
+	}
+	captureTranscript(t, lines, stage, m)
+
+	if err := writeJSON(filepath.Join(stage, manifestFile), m); err != nil {
+		return "", nil, err
+	}
+
+	out := opts.Output
+	if out == "" {
+		out = filepath.Join(Dir(townRoot), fmt.Sprintf("%s-%s-%s.tar.gz",
+			t.Rig, t.Name, m.CreatedAt.Local().Format("20060102-150405")))
+	}
+	if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+		return "", nil, fmt.Errorf("creating snapshot directory: %w", err)
+	}
+	if err := writeArchive(stage, out); err != nil {
+		return "", nil, fmt.Errorf("writing snapshot: %w", err)
+	}
+	return out, m, nil
+}
+
+// captureGit records branch and HEAD, bundles unpushed commits, and saves
+// uncommitted and untracked work.
+func captureGit(g *git.Git, workDir, stage string, m *Manifest) error {
+	head, err := g.Rev("HEAD")
+	if err != nil {
+		return fmt.Errorf("reading HEAD: %w", err)
+	}
+	m.Head = head
+
+	rev := "HEAD"
+	if branch, err := g.CurrentBranch(); err == nil && branch != "HEAD" {
+		m.Branch = branch
+		rev = branch
+	}
+
+	switch err := g.BundleCreate(filepath.Join(stage, bundleFile), rev, "--not", "--remotes"); {
+	case err == nil:
+		m.HasBundle = true
+	case errors.Is(err, git.ErrEmptyBundle):
+		// Everything is already on the remote.
+	default:
+		return fmt.Errorf("bundling commits: %w", err)
+	}
+
+	patch := filepath.Join(stage, patchFile)
+	if err := g.DiffToFile(patch, "HEAD"); err != nil {
+		return fmt.Errorf("saving uncommitted changes: %w", err)
+	}
+	if info, err := os.Stat(patch); err == nil && info.Size() > 0 {
+		m.HasPatch = true
+	} else {
+		_ = os.Remove(patch)
+	}
+
+	untracked, err := g.UntrackedFiles()
+	if err != nil {
+		return fmt.Errorf("listing untracked files: %w", err)
+	}
+	for _, rel := range untracked {
+		src := filepath.Join(workDir, rel)
+		info, err := os.Lstat(src)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if err := copyFile(src, filepath.Join(stage, untrackedDir, rel), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("saving untracked file %s: %w", rel, err)
+		}
+		m.Untracked = append(m.Untracked, rel)
+	}
+	return nil
+}
+
+func captureMail(townRoot string, t Target, stage string, m *Manifest) {
+	mailbox, err := mail.NewRouterWithTownRoot(t.WorkDir, townRoot).GetMailbox(t.Address)
+	if err != nil {
+		m.Warnings = append(m.Warnings, fmt.Sprintf("mail: %v", err))
+		return
+	}
+	msgs, err := mailbox.List()
+	if err != nil {
+		m.Warnings = append(m.Warnings, fmt.Sprintf("mail: %v", err))
+		return
+	}
+	if len(msgs) == 0 {
+		return
+	}
+	if err := writeJSON(filepath.Join(stage, mailFile), msgs); err != nil {
+		m.Warnings = append(m.Warnings, fmt.Sprintf("mail: %v", err))
+		return
+	}
+	m.MailCount = len(msgs)
+}
+
+func captureHandoff(t Target, stage string, m *Manifest) {
+	issue, err := beads.New(t.WorkDir).FindHandoffBead(t.Name)
+	if err != nil {
+		m.Warnings = append(m.Warnings, fmt.Sprintf("handoff: %v", err))
+		return
+	}
+	if issue == nil || issue.Description == "" {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(stage, handoffFile), []byte(issue.Description), 0644); err != nil { //nolint:gosec // G306: staged into the artifact
+		m.Warnings = append(m.Warnings, fmt.Sprintf("handoff: %v", err))
+		return
+	}
+	m.HandoffBead = issue.ID
+}
+
+func captureTranscript(t Target, lines int, stage string, m *Manifest) {
+	if t.Session == "" {
+		return
+	}
+	tm := tmux.NewTmux()
+	if ok, _ := tm.HasSession(t.Session); !ok {
+		return
+	}
+	out, err := tm.CapturePane(t.Session, lines)
+	if err != nil {
+		m.Warnings = append(m.Warnings, fmt.Sprintf("transcript: %v", err))
+		return
+	}
+	if err := os.WriteFile(filepath.Join(stage, transcriptFile), []byte(out), 0644); err != nil { //nolint:gosec // G306: staged into the artifact
+		m.Warnings = append(m.Warnings, fmt.Sprintf("transcript: %v", err))
+		return
+	}
+	m.HasTranscript = true
+}
+
+// Inspect reads a snapshot's manifest and transcript without restoring it.
+func Inspect(path string) (*Manifest, string, error) {
+	stage, m, err := open(path)
+	if err != nil {
+		return nil, "", err
+	}
+	defer os.RemoveAll(stage)
+
+	transcript := ""
+	if m.HasTranscript {
+		data, err := os.ReadFile(filepath.Join(stage, transcriptFile))
+		if err == nil {
+			transcript = string(data)
+		}
+	}
+	return m, transcript, nil
+}
+
+// List returns snapshot artifacts in dir, newest first.
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var paths []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".tar.gz") {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+	return paths, nil
+}
+
+// open extracts an artifact to a temp dir and reads its manifest.
+// The caller removes the returned directory.
+func open(path string) (string, *Manifest, error) {
+	stage, err := os.MkdirTemp("", "gt-snapshot-")
+	if err != nil {
+		return "", nil, err
+	}
+	if err := extractArchive(path, stage); err != nil {
+		os.RemoveAll(stage)
+		return "", nil, fmt.Errorf("reading snapshot %s: %w", path, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(stage, manifestFile))
+	if err != nil {
+		os.RemoveAll(stage)
+		return "", nil, fmt.Errorf("snapshot %s has no manifest: %w", path, err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		os.RemoveAll(stage)
+		return "", nil, fmt.Errorf("parsing snapshot manifest: %w", err)
+	}
+	if m.Version > FormatVersion {
+		os.RemoveAll(stage)
+		return "", nil, fmt.Errorf("snapshot format %d is newer than supported (%d); upgrade gt", m.Version, FormatVersion)
+	}
+	// Untracked files are restored relative to the workspace; a crafted
+	// manifest must not be able to write outside it.
+	for _, rel := range m.Untracked {
+		if !filepath.IsLocal(rel) {
+			os.RemoveAll(stage)
+			return "", nil, fmt.Errorf("snapshot lists untracked file %q outside the workspace", rel)
+		}
+	}
+	return stage, &m, nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644) //nolint:gosec // G306: staged into the artifact
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src) //nolint:gosec // G304: path comes from git ls-files
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm) //nolint:gosec // G304: destination is within the workspace
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,173 @@
+package snapshot
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setGitEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+}
+
+func gitRun(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// setupRepos creates a bare origin with one pushed commit and two clones.
+func setupRepos(t *testing.T) (src, dst string) {
+	t.Helper()
+	setGitEnv(t)
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin.git")
+	gitRun(t, root, "init", "--bare", "-b", "main", origin)
+
+	src = filepath.Join(root, "src")
+	gitRun(t, root, "clone", origin, src)
+	writeFile(t, filepath.Join(src, "README.md"), "hello\n")
+	gitRun(t, src, "add", ".")
+	gitRun(t, src, "commit", "-m", "initial")
+	gitRun(t, src, "push", "origin", "HEAD:main")
+
+	dst = filepath.Join(root, "dst")
+	gitRun(t, root, "clone", origin, dst)
+	return src, dst
+}
+
+func TestCreateAndRestore(t *testing.T) {
+	src, dst := setupRepos(t)
+
+	// Unpushed commit, uncommitted edit, and untracked file.
+	gitRun(t, src, "checkout", "-b", "feature")
+	writeFile(t, filepath.Join(src, "feature.go"), "package feature\n")
+	gitRun(t, src, "add", ".")
+	gitRun(t, src, "commit", "-m", "add feature")
+	head := gitRun(t, src, "rev-parse", "HEAD")
+	writeFile(t, filepath.Join(src, "README.md"), "hello\nwip\n")
+	writeFile(t, filepath.Join(src, "notes", "todo.txt"), "remember\n")
+
+	town := t.TempDir()
+	out := filepath.Join(town, "snap.tar.gz")
+	path, m, err := Create(town, Target{Rig: "rig", Kind: "crew", Name: "max", Address: "rig/crew/max", WorkDir: src},
+		CreateOptions{Output: out})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if path != out {
+		t.Errorf("path = %q, want %q", path, out)
+	}
+	if m.Branch != "feature" || m.Head != head || !m.HasBundle || !m.HasPatch {
+		t.Errorf("manifest = %+v", m)
+	}
+	if len(m.Untracked) != 1 || m.Untracked[0] != "notes/todo.txt" {
+		t.Errorf("untracked = %v", m.Untracked)
+	}
+
+	inspected, _, err := Inspect(path)
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	if inspected.Address != "rig/crew/max" {
+		t.Errorf("inspected address = %q", inspected.Address)
+	}
+
+	res, err := Restore(town, path, Target{Rig: "rig", Kind: "crew", Name: "max", Address: "rig/crew/max", WorkDir: dst},
+		RestoreOptions{SkipMail: true})
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if res.Stashed {
+		t.Error("clean target should not be stashed")
+	}
+	if got := gitRun(t, dst, "rev-parse", "--abbrev-ref", "HEAD"); got != "feature" {
+		t.Errorf("branch = %q, want feature", got)
+	}
+	if got := gitRun(t, dst, "rev-parse", "HEAD"); got != head {
+		t.Errorf("HEAD = %q, want %q", got, head)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dst, "README.md")); string(data) != "hello\nwip\n" {
+		t.Errorf("README.md = %q, want WIP restored", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dst, "notes", "todo.txt")); string(data) != "remember\n" {
+		t.Errorf("untracked file = %q", data)
+	}
+}
+
+func TestRestoreRefusesDirtyTarget(t *testing.T) {
+	src, dst := setupRepos(t)
+
+	town := t.TempDir()
+	path, m, err := Create(town, Target{Rig: "rig", Name: "a", Address: "rig/a", WorkDir: src}, CreateOptions{})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if m.HasBundle || m.HasPatch {
+		t.Errorf("pushed, clean workspace should have no bundle or patch: %+v", m)
+	}
+	if filepath.Dir(path) != Dir(town) {
+		t.Errorf("default path %q not in %q", path, Dir(town))
+	}
+
+	writeFile(t, filepath.Join(dst, "README.md"), "local edit\n")
+	target := Target{Rig: "rig", Name: "b", Address: "rig/b", WorkDir: dst}
+	if _, err := Restore(town, path, target, RestoreOptions{SkipMail: true}); err == nil {
+		t.Fatal("expected dirty target to be refused")
+	}
+
+	res, err := Restore(town, path, target, RestoreOptions{Force: true, SkipMail: true})
+	if err != nil {
+		t.Fatalf("Restore --force: %v", err)
+	}
+	if !res.Stashed {
+		t.Error("expected changes to be stashed")
+	}
+	if got := gitRun(t, dst, "stash", "list"); !strings.Contains(got, "gt snapshot restore") {
+		t.Errorf("stash list = %q", got)
+	}
+}
+
+func TestRestoreRejectsUntrackedOutsideWorkspace(t *testing.T) {
+	_, dst := setupRepos(t)
+
+	stage := t.TempDir()
+	m := Manifest{Version: FormatVersion, Head: gitRun(t, dst, "rev-parse", "HEAD"), Untracked: []string{"../escape.txt"}}
+	if err := writeJSON(filepath.Join(stage, manifestFile), m); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(stage, untrackedDir, "..", "escape.txt"), "gotcha\n")
+	path := filepath.Join(t.TempDir(), "crafted.tar.gz")
+	if err := writeArchive(stage, path); err != nil {
+		t.Fatal(err)
+	}
+
+	target := Target{Rig: "rig", Name: "b", Address: "rig/b", WorkDir: dst}
+	if _, err := Restore(t.TempDir(), path, target, RestoreOptions{SkipMail: true}); err == nil {
+		t.Fatal("expected a manifest with ../ untracked entries to be refused")
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dst), "escape.txt")); !os.IsNotExist(err) {
+		t.Errorf("file written outside the workspace: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package tmux
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Inject rate limit: a burst of DefaultInjectBurst messages to a session,
+// then one every DefaultInjectInterval. Faster input garbles the agent's
+// input line.
+const (
+	DefaultInjectBurst    = 3
+	DefaultInjectInterval = 2 * time.Second
+)
+
+// injectLimitDir returns the directory holding each session's bucket. It is
+// per user and per tmux server, like the server's own socket; replaceable
+// in tests.
+var injectLimitDir = func() string {
+	server := Socket()
+	if server == "" {
+		server = DefaultServer
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("gt-tmux-%d", os.Getuid()), server, "inject")
+}
+
+// injectMu serializes bucket updates within the process; a file lock
+// serializes them across gt processes (mail, nudge, witness, deacon...).
+var injectMu sync.Mutex
+
+// injectBucket is a session's token bucket as stored on disk. Tokens may go
+// negative: each caller waiting its turn holds a later send slot, so queued
+// messages go out first-come first-served.
+type injectBucket struct {
+	Tokens float64   `json:"tokens"`
+	Last   time.Time `json:"last"`
+}
+
+// reserve takes a token from the bucket and returns how long the caller
+// must wait before sending.
+func (b *injectBucket) reserve(now time.Time, burst int, interval time.Duration) time.Duration {
+	if b.Last.IsZero() {
+		b.Tokens = float64(burst)
+	} else {
+		b.Tokens += float64(now.Sub(b.Last)) / float64(interval)
+		if b.Tokens > float64(burst) {
+			b.Tokens = float64(burst)
+		}
+	}
+	b.Last = now
+
+	b.Tokens--
+	if b.Tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.Tokens * float64(interval))
+}
+
+// reserveInject reserves a send slot for target in its shared bucket and
+// returns how long to wait for it. The limit is best effort: if the state
+// can't be read or written, the caller sends without waiting.
+func reserveInject(target string, now time.Time) time.Duration {
+	injectMu.Lock()
+	defer injectMu.Unlock()
+
+	dir := injectLimitDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return 0
+	}
+	path := filepath.Join(dir, strings.NewReplacer("/", "_", string(os.PathSeparator), "_").Replace(target)+".json")
+	lock := flock.New(path + ".lock")
+	if err := lock.Lock(); err != nil {
+		return 0
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	var bucket injectBucket
+	if data, err := os.ReadFile(path); err == nil { //nolint:gosec // G304: path is under our own state dir
+		_ = json.Unmarshal(data, &bucket)
+	}
+	wait := bucket.reserve(now, DefaultInjectBurst, DefaultInjectInterval)
+	_ = util.AtomicWriteJSON(path, bucket)
+	return wait
+}
+
+// waitInjectTurn blocks until target's next send slot comes up, unless t
+// skips the limit (see Priority).
+func (t *Tmux) waitInjectTurn(target string) {
+	if t.priority {
+		return
+	}
+	if d := reserveInject(target, time.Now()); d > 0 {
+		time.Sleep(d)
+	}
+}
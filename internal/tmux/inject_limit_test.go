@@ -0,0 +1,76 @@
+package tmux
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInjectBucketReserve(t *testing.T) {
+	var b injectBucket
+	start := time.Now()
+
+	// Burst goes straight through
+	for i := 0; i < 2; i++ {
+		if d := b.reserve(start, 2, time.Second); d != 0 {
+			t.Fatalf("burst reserve %d waited %v", i, d)
+		}
+	}
+
+	// Then callers queue up one interval apart
+	if d := b.reserve(start, 2, time.Second); d != time.Second {
+		t.Errorf("third reserve waits %v, want 1s", d)
+	}
+	if d := b.reserve(start, 2, time.Second); d != 2*time.Second {
+		t.Errorf("fourth reserve waits %v, want 2s", d)
+	}
+
+	// Tokens refill over time, capped at the burst
+	later := start.Add(time.Minute)
+	for i := 0; i < 2; i++ {
+		if d := b.reserve(later, 2, time.Second); d != 0 {
+			t.Errorf("reserve after refill %d waited %v", i, d)
+		}
+	}
+	if d := b.reserve(later, 2, time.Second); d == 0 {
+		t.Error("refill exceeded burst")
+	}
+}
+
+func TestReserveInjectSharesState(t *testing.T) {
+	dir := t.TempDir()
+	orig := injectLimitDir
+	injectLimitDir = func() string { return dir }
+	defer func() { injectLimitDir = orig }()
+
+	now := time.Now()
+	for i := 0; i < DefaultInjectBurst; i++ {
+		if d := reserveInject("gt-gastown-toast", now); d != 0 {
+			t.Fatalf("burst reserve %d waited %v", i, d)
+		}
+	}
+	// The bucket lives in the state file, so another gt process (or a fresh
+	// Tmux) sees it drained
+	if d := reserveInject("gt-gastown-toast", now); d != DefaultInjectInterval {
+		t.Errorf("reserve after the burst waits %v, want %v", d, DefaultInjectInterval)
+	}
+	if d := reserveInject("gt-gastown-nux", now); d != 0 {
+		t.Errorf("another session's reserve waited %v", d)
+	}
+}
+
+func TestPriorityBypassesInjectLimit(t *testing.T) {
+	dir := t.TempDir()
+	orig := injectLimitDir
+	injectLimitDir = func() string { return dir }
+	defer func() { injectLimitDir = orig }()
+
+	now := time.Now()
+	for i := 0; i < DefaultInjectBurst; i++ {
+		reserveInject("gt-gastown-toast", now)
+	}
+	start := time.Now()
+	NewTmux().Priority().waitInjectTurn("gt-gastown-toast")
+	if elapsed := time.Since(start); elapsed > DefaultInjectInterval/2 {
+		t.Errorf("priority send waited %v for a drained bucket", elapsed)
+	}
+}
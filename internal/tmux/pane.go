@@ -0,0 +1,223 @@
+package tmux
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Window describes a tmux window.
+type Window struct {
+	Session string
+	Index   int
+	ID      string // e.g. "@3"
+	Name    string
+	Active  bool
+	Panes   int
+}
+
+// Pane describes a tmux pane.
+type Pane struct {
+	ID          string // e.g. "%5"; stable for the pane's lifetime
+	Session     string
+	WindowIndex int
+	WindowName  string
+	Index       int
+	Active      bool
+	Dead        bool // the pane's process exited (remain-on-exit)
+	PID         string
+	Command     string // current foreground command
+	WorkDir     string
+	Width       int
+	Height      int
+}
+
+// Target returns the pane's ID, usable wherever tmux takes a target pane.
+func (p Pane) Target() string {
+	return p.ID
+}
+
+// SplitDirection is the direction a pane is split in.
+type SplitDirection int
+
+const (
+	// SplitHorizontal puts the new pane to the right (side by side).
+	SplitHorizontal SplitDirection = iota
+	// SplitVertical puts the new pane below.
+	SplitVertical
+)
+
+// fieldSep separates fields in the -F formats below. Window names,
+// commands and paths are free-form, so it is a sequence they won't
+// contain rather than "|". It must be printable: when the client's locale
+// isn't UTF-8, tmux prints a tab (like any control character) as "_".
+const fieldSep = "<|>"
+
+var windowFormat = strings.Join([]string{
+	"#{session_name}", "#{window_index}", "#{window_id}", "#{window_name}", "#{window_active}", "#{window_panes}",
+}, fieldSep)
+
+var paneFormat = strings.Join([]string{
+	"#{pane_id}", "#{session_name}", "#{window_index}", "#{window_name}", "#{pane_index}", "#{pane_active}",
+	"#{pane_dead}", "#{pane_pid}", "#{pane_current_command}", "#{pane_current_path}", "#{pane_width}", "#{pane_height}",
+}, fieldSep)
+
+// NewWindow adds a window to a session without switching to it and returns
+// its first pane's ID. name, workDir and command are optional; with no
+// command the window runs the default shell.
+func (t *Tmux) NewWindow(session, name, workDir, command string) (string, error) {
+	args := []string{"new-window", "-d", "-P", "-F", "#{pane_id}", "-t", session + ":"}
+	if name != "" {
+		args = append(args, "-n", name)
+	}
+	if workDir != "" {
+		args = append(args, "-c", workDir)
+	}
+	if command != "" {
+		args = append(args, command)
+	}
+	return t.run(args...)
+}
+
+// SplitPane splits target (a pane ID or session:window.pane) and returns the
+// new pane's ID. percent sizes the new pane (0 = tmux default, half).
+// workDir and command are optional.
+func (t *Tmux) SplitPane(target string, dir SplitDirection, percent int, workDir, command string) (string, error) {
+	args := []string{"split-window", "-d", "-P", "-F", "#{pane_id}", "-t", target}
+	if dir == SplitVertical {
+		args = append(args, "-v")
+	} else {
+		args = append(args, "-h")
+	}
+	if percent > 0 && percent < 100 {
+		args = append(args, "-l", fmt.Sprintf("%d%%", percent))
+	}
+	if workDir != "" {
+		args = append(args, "-c", workDir)
+	}
+	if command != "" {
+		args = append(args, command)
+	}
+	return t.run(args...)
+}
+
+// ListWindows returns the windows of a session.
+func (t *Tmux) ListWindows(session string) ([]Window, error) {
+	out, err := t.run("list-windows", "-t", session, "-F", windowFormat)
+	if err != nil {
+		return nil, err
+	}
+	var windows []Window
+	for _, line := range splitLines(out) {
+		f := strings.Split(line, fieldSep)
+		if len(f) < 6 {
+			return nil, fmt.Errorf("unexpected list-windows output: %q", line)
+		}
+		windows = append(windows, Window{
+			Session: f[0],
+			Index:   atoi(f[1]),
+			ID:      f[2],
+			Name:    f[3],
+			Active:  f[4] == "1",
+			Panes:   atoi(f[5]),
+		})
+	}
+	return windows, nil
+}
+
+// FindWindow returns the session's window with the given name, or nil.
+func (t *Tmux) FindWindow(session, name string) (*Window, error) {
+	windows, err := t.ListWindows(session)
+	if err != nil {
+		return nil, err
+	}
+	for i := range windows {
+		if windows[i].Name == name {
+			return &windows[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// ListPanes returns the panes of every window of a session, or of a single
+// window if target is session:window.
+func (t *Tmux) ListPanes(target string) ([]Pane, error) {
+	args := []string{"list-panes", "-t", target, "-F", paneFormat}
+	if !strings.Contains(target, ":") {
+		args = append(args, "-s")
+	}
+	out, err := t.run(args...)
+	if err != nil {
+		return nil, err
+	}
+	var panes []Pane
+	for _, line := range splitLines(out) {
+		f := strings.Split(line, fieldSep)
+		if len(f) < 12 {
+			return nil, fmt.Errorf("unexpected list-panes output: %q", line)
+		}
+		panes = append(panes, Pane{
+			ID:          f[0],
+			Session:     f[1],
+			WindowIndex: atoi(f[2]),
+			WindowName:  f[3],
+			Index:       atoi(f[4]),
+			Active:      f[5] == "1",
+			Dead:        f[6] == "1",
+			PID:         f[7],
+			Command:     f[8],
+			WorkDir:     f[9],
+			Width:       atoi(f[10]),
+			Height:      atoi(f[11]),
+		})
+	}
+	return panes, nil
+}
+
+// CapturePaneByID captures the last lines of a specific pane, or its whole
+// scrollback if lines <= 0. Unlike CapturePane, which captures a session's
+// active pane, it works for any pane in a multi-pane window.
+func (t *Tmux) CapturePaneByID(paneID string, lines int) (string, error) {
+	start := "-"
+	if lines > 0 {
+		start = fmt.Sprintf("-%d", lines)
+	}
+	return t.run("capture-pane", "-p", "-J", "-t", paneID, "-S", start)
+}
+
+// SelectWindowTarget selects a window by target (session:name or
+// session:index, or a window ID).
+func (t *Tmux) SelectWindowTarget(target string) error {
+	_, err := t.run("select-window", "-t", target)
+	return err
+}
+
+// SelectPane makes a pane the active pane of its window.
+func (t *Tmux) SelectPane(paneID string) error {
+	_, err := t.run("select-pane", "-t", paneID)
+	return err
+}
+
+// KillPane closes a pane and its process.
+func (t *Tmux) KillPane(paneID string) error {
+	_, err := t.run("kill-pane", "-t", paneID)
+	return err
+}
+
+// KillWindow closes a window and all its panes.
+func (t *Tmux) KillWindow(target string) error {
+	_, err := t.run("kill-window", "-t", target)
+	return err
+}
+
+func splitLines(out string) []string {
+	if out == "" {
+		return nil
+	}
+	return strings.Split(out, "\n")
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
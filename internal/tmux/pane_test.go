@@ -0,0 +1,134 @@
+package tmux
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWindowAndPanePrimitives(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-windows-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	dir := t.TempDir()
+	first, err := tm.NewWindow(sessionName, "logs | tail", dir, "")
+	if err != nil {
+		t.Fatalf("NewWindow: %v", err)
+	}
+	if !strings.HasPrefix(first, "%") {
+		t.Fatalf("NewWindow pane ID = %q, want %%N", first)
+	}
+
+	win, err := tm.FindWindow(sessionName, "logs | tail")
+	if err != nil || win == nil {
+		t.Fatalf("FindWindow = %v, %v", win, err)
+	}
+
+	// A plain sh: the user's interactive shell may take seconds to start
+	second, err := tm.SplitPane(first, SplitVertical, 30, dir, "sh")
+	if err != nil {
+		t.Fatalf("SplitPane: %v", err)
+	}
+
+	panes, err := tm.ListPanes(sessionName)
+	if err != nil {
+		t.Fatalf("ListPanes: %v", err)
+	}
+	byID := make(map[string]Pane)
+	for _, p := range panes {
+		byID[p.ID] = p
+	}
+	if len(panes) != 3 {
+		t.Errorf("ListPanes(session) = %d panes, want 3 (1 + 2 in the new window)", len(panes))
+	}
+	for _, id := range []string{first, second} {
+		p, ok := byID[id]
+		if !ok {
+			t.Fatalf("pane %s missing from %v", id, panes)
+		}
+		if p.WindowName != "logs | tail" || p.WindowIndex != win.Index || p.Session != sessionName {
+			t.Errorf("pane %s = %+v, want window %q (%d)", id, p, win.Name, win.Index)
+		}
+	}
+	windowPanes, err := tm.ListPanes(sessionName + ":" + win.ID)
+	if err != nil || len(windowPanes) != 2 {
+		t.Errorf("ListPanes(window) = %v, %v; want 2 panes", windowPanes, err)
+	}
+
+	if err := tm.WaitForPaneShellReady(second, 5*time.Second); err != nil {
+		t.Skipf("shell not ready: %v", err)
+	}
+	if err := tm.SendKeysToPane(second, "echo PANE-MARK"); err != nil {
+		t.Fatalf("SendKeysToPane: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		out, err := tm.CapturePaneByID(second, 0)
+		if err == nil && strings.Contains(out, "PANE-MARK\n") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("CapturePaneByID never showed the marker: %q, %v", out, err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if out, _ := tm.CapturePaneByID(first, 0); strings.Contains(out, "PANE-MARK\n") {
+		t.Error("CapturePaneByID(first) captured the other pane")
+	}
+
+	if err := tm.KillPane(second); err != nil {
+		t.Fatalf("KillPane: %v", err)
+	}
+	if err := tm.KillWindow(win.ID); err != nil {
+		t.Fatalf("KillWindow: %v", err)
+	}
+	if win, _ := tm.FindWindow(sessionName, "logs | tail"); win != nil {
+		t.Errorf("window still exists after KillWindow: %+v", win)
+	}
+}
+
+func TestListPanesNonUTF8Locale(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+	// tmux escapes control characters in -F output for non-UTF-8 clients.
+	// A client run from inside tmux assumes UTF-8, so drop $TMUX too.
+	t.Setenv("LC_ALL", "C")
+	t.Setenv("LC_CTYPE", "C")
+	t.Setenv("LANG", "C")
+	t.Setenv("TMUX", "")
+	os.Unsetenv("TMUX")
+
+	tm := NewTmux()
+	sessionName := "gt-test-locale-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSessionWithCommand(sessionName, "", "sleep 30"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	if _, err := tm.NewWindow(sessionName, "feed", "", "sleep 30"); err != nil {
+		t.Fatalf("NewWindow: %v", err)
+	}
+	win, err := tm.FindWindow(sessionName, "feed")
+	if err != nil || win == nil {
+		t.Fatalf("FindWindow = %v, %v", win, err)
+	}
+	panes, err := tm.ListPanes(sessionName)
+	if err != nil {
+		t.Fatalf("ListPanes: %v", err)
+	}
+	if len(panes) != 2 || panes[1].WindowName != "feed" || panes[1].Session != sessionName {
+		t.Errorf("ListPanes = %+v, want the session's two panes", panes)
+	}
+}
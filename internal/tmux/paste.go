@@ -0,0 +1,124 @@
+package tmux
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// pasteChunkSize bounds a single paste. Multi-KB writes to a pane were
+// intermittently truncated: the pty only buffers a few KB until the program
+// reads it. Larger input is pasted in pieces, each one confirmed before the
+// next is sent.
+const pasteChunkSize = 4 * 1024
+
+// pasteTimeout is how long a pane gets to show a pasted chunk; replaceable
+// in tests.
+var pasteTimeout = 5 * time.Second
+
+// ErrInputNotConsumed means a pane never showed text pasted into it, so
+// Enter was not sent.
+var ErrInputNotConsumed = errors.New("pane did not take the input")
+
+// pasteBufferSeq makes paste buffer names unique within the process.
+var pasteBufferSeq atomic.Uint64
+
+// pasteInput types text into target without pressing Enter. Each chunk is
+// loaded into a tmux buffer and pasted with bracketed paste (when the
+// program asked for it), so the program sees one paste rather than
+// keystrokes, then the pane is watched until it has drawn the chunk.
+func (t *Tmux) pasteInput(target, text string) error {
+	if text == "" {
+		return nil
+	}
+	for _, chunk := range splitChunks(text, pasteChunkSize) {
+		before, err := t.capturePaneScreen(target)
+		if err != nil {
+			return err
+		}
+		if err := t.pasteChunk(target, chunk); err != nil {
+			return err
+		}
+		// Whitespace may not change the screen; there is nothing to watch for.
+		if strings.TrimSpace(chunk) == "" {
+			continue
+		}
+		if err := t.waitForInput(target, before, pasteTimeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pasteChunk pastes text into target through a temporary tmux buffer.
+// Unlike send-keys, this has no argument length limit and no key-name
+// interpretation.
+func (t *Tmux) pasteChunk(target, text string) error {
+	buffer := fmt.Sprintf("gt-paste-%d-%d", os.Getpid(), pasteBufferSeq.Add(1))
+	if _, err := t.runInput(strings.NewReader(text), "load-buffer", "-b", buffer, "-"); err != nil {
+		return err
+	}
+	// -d deletes the buffer once pasted; -p brackets the paste
+	if _, err := t.run("paste-buffer", "-d", "-p", "-b", buffer, "-t", target); err != nil {
+		_, _ = t.run("delete-buffer", "-b", buffer)
+		return err
+	}
+	return nil
+}
+
+// waitForInput waits for target's screen to differ from before and then hold
+// still for a poll interval, i.e. for the program to have read and drawn
+// the paste. A screen that keeps changing (a spinner, a clock) is accepted
+// at the timeout; one that never changed is ErrInputNotConsumed.
+func (t *Tmux) waitForInput(target, before string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	changed := false
+	last := before
+	for time.Now().Before(deadline) {
+		time.Sleep(constants.PollInterval)
+		screen, err := t.capturePaneScreen(target)
+		if err != nil {
+			return err
+		}
+		if screen != before {
+			if changed && screen == last {
+				return nil
+			}
+			changed = true
+		}
+		last = screen
+	}
+	if changed {
+		return nil
+	}
+	return fmt.Errorf("%s: %w after %s", target, ErrInputNotConsumed, timeout)
+}
+
+// capturePaneScreen captures the visible screen of target.
+func (t *Tmux) capturePaneScreen(target string) (string, error) {
+	return t.run("capture-pane", "-p", "-t", target)
+}
+
+// splitChunks splits s into pieces of at most size bytes without splitting
+// a UTF-8 sequence.
+func splitChunks(s string, size int) []string {
+	var chunks []string
+	for len(s) > size {
+		n := size
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		if n == 0 {
+			n = size
+		}
+		chunks = append(chunks, s[:n])
+		s = s[n:]
+	}
+	return append(chunks, s)
+}
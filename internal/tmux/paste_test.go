@@ -0,0 +1,100 @@
+package tmux
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSplitChunks(t *testing.T) {
+	if got := splitChunks("abcdef", 4); len(got) != 2 || got[0] != "abcd" || got[1] != "ef" {
+		t.Errorf("splitChunks = %q", got)
+	}
+	if got := splitChunks("abc", 4); len(got) != 1 || got[0] != "abc" {
+		t.Errorf("splitChunks(short) = %q", got)
+	}
+	// "é" is two bytes; a cut at byte 4 would split it
+	got := splitChunks("abcé", 4)
+	if len(got) != 2 || got[0] != "abc" || got[1] != "é" {
+		t.Errorf("splitChunks(utf8) = %q, want [abc é]", got)
+	}
+}
+
+func TestSendKeysLargePayload(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-paste-" + t.Name()
+	_ = tm.KillSession(sessionName)
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out.txt")
+	if err := tm.NewSessionWithCommand(sessionName, dir, "cat > out.txt"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	// Several chunks' worth, in lines short enough for the tty's line buffer
+	var lines []string
+	for i := 0; len(strings.Join(lines, "\n")) < 3*pasteChunkSize; i++ {
+		lines = append(lines, fmt.Sprintf("%04d %s", i, strings.Repeat("x", 90)))
+	}
+	payload := strings.Join(lines, "\n")
+
+	if err := tm.SendKeysDebounced(sessionName, payload, 0); err != nil {
+		t.Fatalf("SendKeysDebounced: %v", err)
+	}
+	if err := tm.SendKeysRaw(sessionName, "C-d"); err != nil {
+		t.Fatalf("SendKeysRaw: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		data, _ := os.ReadFile(out)
+		if string(data) == payload+"\n" {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("pane received %d bytes, want %d", len(data), len(payload)+1)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestSendKeysUnshownInput(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+	orig := pasteTimeout
+	pasteTimeout = time.Second
+	defer func() { pasteTimeout = orig }()
+
+	tm := NewTmux()
+	sessionName := "gt-test-paste-" + t.Name()
+	_ = tm.KillSession(sessionName)
+
+	// With echo off the pane never shows what is pasted
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out.txt")
+	if err := tm.NewSessionWithCommand(sessionName, dir, "stty -echo; cat > out.txt"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+	time.Sleep(300 * time.Millisecond)
+
+	err := tm.SendKeysDebounced(sessionName, "hello", 0)
+	if !errors.Is(err, ErrInputNotConsumed) {
+		t.Fatalf("SendKeysDebounced = %v, want ErrInputNotConsumed", err)
+	}
+	// Enter was never pressed, so the line never reached cat
+	time.Sleep(300 * time.Millisecond)
+	if data, _ := os.ReadFile(out); len(data) != 0 {
+		t.Errorf("pane received %q after an unconfirmed paste", data)
+	}
+}
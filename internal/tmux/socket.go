@@ -0,0 +1,76 @@
+package tmux
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// SocketEnv names the tmux socket (tmux -L) Gas Town sessions run on. gt
+// sets it at startup from the town's tmux_socket setting (default
+// "gastown"), so every tmux command gt runs, and every process it starts,
+// talks to the same server. A dedicated server keeps `tmux kill-server` in
+// a personal tmux from taking down the agents, and keeps personal sessions
+// out of gt's session lists.
+const SocketEnv = "GT_TMUX_SOCKET"
+
+// DefaultServer is the tmux_socket value that selects tmux's default server
+// (no -L), as before dedicated sockets.
+const DefaultServer = "default"
+
+// Socket returns the tmux socket name in use, or "" for tmux's default
+// server.
+func Socket() string {
+	s := os.Getenv(SocketEnv)
+	if s == DefaultServer {
+		return ""
+	}
+	return s
+}
+
+// Args prefixes tmux arguments with the socket selection.
+func Args(args ...string) []string {
+	if s := Socket(); s != "" {
+		return append([]string{"-L", s}, args...)
+	}
+	return args
+}
+
+// Command returns an exec.Cmd running tmux on Gas Town's socket. Use it
+// instead of exec.Command("tmux", ...).
+func Command(args ...string) *exec.Cmd {
+	return exec.Command("tmux", Args(args...)...) //nolint:gosec // G204: args are constructed internally
+}
+
+// AttachCommand returns a command that attaches the terminal to session.
+// $TMUX is dropped so a gt session can be opened from inside a personal
+// tmux, which is a different server, without tmux refusing to nest.
+func AttachCommand(session string) *exec.Cmd {
+	cmd := Command("attach-session", "-t", session)
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, "TMUX=") {
+			cmd.Env = append(cmd.Env, kv)
+		}
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd
+}
+
+// AttachHint returns the command line a user runs to attach to session.
+func AttachHint(session string) string {
+	return strings.Join(append([]string{"tmux"}, Args("attach", "-t", session)...), " ")
+}
+
+// onSocket reports whether a $TMUX value refers to the Gas Town server.
+// $TMUX is "<socket path>,<pid>,<session index>"; -L names the socket file.
+func onSocket(tmuxEnv string) bool {
+	s := Socket()
+	if s == "" {
+		return true
+	}
+	path, _, _ := strings.Cut(tmuxEnv, ",")
+	return filepath.Base(path) == s
+}
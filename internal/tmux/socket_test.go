@@ -0,0 +1,58 @@
+package tmux
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestArgsUsesSocket(t *testing.T) {
+	tests := []struct {
+		env  string
+		want []string
+	}{
+		{"gastown", []string{"-L", "gastown", "ls"}},
+		{"other", []string{"-L", "other", "ls"}},
+		{DefaultServer, []string{"ls"}},
+		{"", []string{"ls"}},
+	}
+	for _, tt := range tests {
+		t.Setenv(SocketEnv, tt.env)
+		if got := Args("ls"); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Args with %s=%q = %v, want %v", SocketEnv, tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestAttachHint(t *testing.T) {
+	t.Setenv(SocketEnv, "gastown")
+	if got, want := AttachHint("gt-gastown-crew-max"), "tmux -L gastown attach -t gt-gastown-crew-max"; got != want {
+		t.Errorf("AttachHint = %q, want %q", got, want)
+	}
+	t.Setenv(SocketEnv, DefaultServer)
+	if got, want := AttachHint("hq-mayor"), "tmux attach -t hq-mayor"; got != want {
+		t.Errorf("AttachHint = %q, want %q", got, want)
+	}
+}
+
+func TestIsInsideTmuxChecksSocket(t *testing.T) {
+	t.Setenv(SocketEnv, "gastown")
+
+	t.Setenv("TMUX", "/tmp/tmux-501/gastown,12345,0")
+	if !IsInsideTmux() {
+		t.Error("IsInsideTmux = false on the gastown socket")
+	}
+	t.Setenv("TMUX", "/tmp/tmux-501/default,12345,0")
+	if IsInsideTmux() {
+		t.Error("IsInsideTmux = true in a personal tmux server")
+	}
+	t.Setenv("TMUX", "")
+	if IsInsideTmux() {
+		t.Error("IsInsideTmux = true outside tmux")
+	}
+
+	t.Setenv(SocketEnv, DefaultServer)
+	t.Setenv("TMUX", "/tmp/tmux-501/default,12345,0")
+	if !IsInsideTmux() {
+		t.Error("IsInsideTmux = false on the default server with tmux_socket=default")
+	}
+}
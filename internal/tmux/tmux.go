@@ -5,15 +5,18 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/logging"
 )
 
 // sessionNudgeLocks serializes nudges to the same session.
@@ -35,21 +38,38 @@ var (
 )
 
 // Tmux wraps tmux operations.
-type Tmux struct{}
+type Tmux struct {
+	priority bool // skip the inject rate limit
+}
 
 // NewTmux creates a new Tmux wrapper.
 func NewTmux() *Tmux {
 	return &Tmux{}
 }
 
+// Priority returns a Tmux whose input to sessions skips the inject rate
+// limit, for messages that must reach the agent ahead of queued automated
+// traffic.
+func (t *Tmux) Priority() *Tmux {
+	return &Tmux{priority: true}
+}
+
 // run executes a tmux command and returns stdout.
 func (t *Tmux) run(args ...string) (string, error) {
-	cmd := exec.Command("tmux", args...)
+	return t.runInput(nil, args...)
+}
+
+// runInput is run with stdin, for commands like load-buffer that read it.
+func (t *Tmux) runInput(stdin io.Reader, args ...string) (string, error) {
+	cmd := Command(args...)
+	cmd.Stdin = stdin
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
+	start := time.Now()
 	err := cmd.Run()
+	logging.Exec("tmux", args, "", start, err, stderr.String())
 	if err != nil {
 		return "", t.wrapError(err, stderr.String(), args)
 	}
@@ -284,6 +304,60 @@ func getAllDescendants(pid string) []string {
 	return result
 }
 
+// ProcessUsage is the combined resource usage of a session's process tree.
+type ProcessUsage struct {
+	Processes int `json:"processes"`
+
+	// CPUPercent is summed across processes, as reported by ps (on Linux,
+	// CPU time over process lifetime; may exceed 100 on multiple cores).
+	CPUPercent float64 `json:"cpu_percent"`
+
+	// RSSBytes is the total resident memory.
+	RSSBytes int64 `json:"rss_bytes"`
+}
+
+// GetSessionResourceUsage sums CPU and memory for a session's pane process
+// and all of its descendants.
+func (t *Tmux) GetSessionResourceUsage(session string) (*ProcessUsage, error) {
+	pid, err := t.GetPanePID(session)
+	if err != nil {
+		return nil, err
+	}
+	if pid == "" {
+		return nil, fmt.Errorf("no pane process for session %s", session)
+	}
+
+	pids := append([]string{pid}, getAllDescendants(pid)...)
+	out, err := exec.Command("ps", "-o", "pid=,%cpu=,rss=", "-p", strings.Join(pids, ",")).Output()
+	if err != nil && len(out) == 0 {
+		return nil, fmt.Errorf("reading process usage: %w", err)
+	}
+	return parseProcessUsage(string(out)), nil
+}
+
+// parseProcessUsage sums "pid %cpu rss(KiB)" lines from ps.
+func parseProcessUsage(out string) *ProcessUsage {
+	usage := &ProcessUsage{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		cpu, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			continue
+		}
+		rss, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		usage.Processes++
+		usage.CPUPercent += cpu
+		usage.RSSBytes += rss * 1024
+	}
+	return usage
+}
+
 // KillPaneProcesses explicitly kills all processes associated with a tmux pane.
 // This prevents orphan processes that survive pane respawn due to SIGHUP being ignored.
 //
@@ -335,6 +409,12 @@ func (t *Tmux) KillServer() error {
 	return err
 }
 
+// IsServerRunning reports whether a tmux server is up.
+func (t *Tmux) IsServerRunning() bool {
+	_, err := t.run("list-sessions")
+	return !errors.Is(err, ErrNoServer)
+}
+
 // SetExitEmpty controls the tmux exit-empty server option.
 // When on (default), the server exits when there are no sessions.
 // When off, the server stays running even with no sessions.
@@ -495,15 +575,18 @@ func (t *Tmux) ListSessionIDs() (map[string]string, error) {
 // Always sends Enter as a separate command for reliability.
 // Uses a debounce delay between paste and Enter to ensure paste completes.
 func (t *Tmux) SendKeys(session, keys string) error {
-	return t.SendKeysDebounced(session, keys, constants.DefaultDebounceMs) // 100ms default debounce
+	return t.SendKeysDebounced(session, keys, constants.DefaultDebounceMs)
 }
 
 // SendKeysDebounced sends keystrokes with a configurable delay before Enter.
-// The debounceMs parameter controls how long to wait after paste before sending Enter.
-// This prevents race conditions where Enter arrives before paste is processed.
+// The text is pasted through a tmux buffer (bracketed, chunked for large
+// payloads) and Enter is only sent once the pane has shown it; see
+// pasteInput. debounceMs is a further pause before Enter for programs that
+// need time after a paste. Sends to the same session are rate limited
+// across processes (see DefaultInjectBurst).
 func (t *Tmux) SendKeysDebounced(session, keys string, debounceMs int) error {
-	// Send text using literal mode (-l) to handle special chars
-	if _, err := t.run("send-keys", "-t", session, "-l", keys); err != nil {
+	t.waitInjectTurn(session)
+	if err := t.pasteInput(session, keys); err != nil {
 		return err
 	}
 	// Wait for paste to be processed
@@ -515,7 +598,8 @@ func (t *Tmux) SendKeysDebounced(session, keys string, debounceMs int) error {
 	return err
 }
 
-// SendKeysRaw sends keystrokes without adding Enter.
+// SendKeysRaw sends keystrokes without adding Enter. Key presses like C-c
+// aren't messages, so they skip the inject rate limit.
 func (t *Tmux) SendKeysRaw(session, keys string) error {
 	_, err := t.run("send-keys", "-t", session, keys)
 	return err
@@ -568,21 +652,23 @@ func getSessionNudgeLock(session string) *sync.Mutex {
 
 // NudgeSession sends a message to a Claude Code session reliably.
 // This is the canonical way to send messages to Claude sessions.
-// Uses: literal mode + 500ms debounce + ESC (for vim mode) + separate Enter.
+// Uses: verified paste + 500ms debounce + ESC (for vim mode) + separate Enter.
 // Verification is the Witness's job (AI), not this function.
 //
 // IMPORTANT: Nudges to the same session are serialized to prevent interleaving.
 // If multiple goroutines try to nudge the same session concurrently, they will
 // queue up and execute one at a time. This prevents garbled input when
-// SessionStart hooks and nudges arrive simultaneously.
+// SessionStart hooks and nudges arrive simultaneously. Across processes,
+// nudges share the session's inject rate limit (see DefaultInjectBurst).
 func (t *Tmux) NudgeSession(session, message string) error {
 	// Serialize nudges to this session to prevent interleaving
 	lock := getSessionNudgeLock(session)
 	lock.Lock()
 	defer lock.Unlock()
+	t.waitInjectTurn(session)
 
-	// 1. Send text in literal mode (handles special characters)
-	if _, err := t.run("send-keys", "-t", session, "-l", message); err != nil {
+	// 1. Paste the text (bracketed, chunked, confirmed on screen)
+	if err := t.pasteInput(session, message); err != nil {
 		return err
 	}
 
@@ -617,9 +703,10 @@ func (t *Tmux) NudgePane(pane, message string) error {
 	lock := getSessionNudgeLock(pane)
 	lock.Lock()
 	defer lock.Unlock()
+	t.waitInjectTurn(pane)
 
-	// 1. Send text in literal mode (handles special characters)
-	if _, err := t.run("send-keys", "-t", pane, "-l", message); err != nil {
+	// 1. Paste the text (bracketed, chunked, confirmed on screen)
+	if err := t.pasteInput(pane, message); err != nil {
 		return err
 	}
 
@@ -728,6 +815,19 @@ func (t *Tmux) GetPanePID(session string) (string, error) {
 	return strings.TrimSpace(out), nil
 }
 
+// GetWindowActivity returns when a session's active window last produced output.
+func (t *Tmux) GetWindowActivity(session string) (time.Time, error) {
+	out, err := t.run("display-message", "-t", session, "-p", "#{window_activity}")
+	if err != nil {
+		return time.Time{}, err
+	}
+	var unix int64
+	if _, err := fmt.Sscanf(strings.TrimSpace(out), "%d", &unix); err != nil || unix <= 0 {
+		return time.Time{}, fmt.Errorf("unexpected window activity %q", out)
+	}
+	return time.Unix(unix, 0), nil
+}
+
 // hasClaudeChild checks if a process has a child running claude/node.
 // Used when the pane command is a shell (bash, zsh) that launched claude.
 func hasClaudeChild(pid string) bool {
@@ -1256,9 +1356,12 @@ func (t *Tmux) EnableMouseMode(session string) error {
 }
 
 // IsInsideTmux checks if the current process is running inside a tmux session.
-// This is detected by the presence of the TMUX environment variable.
+// This is detected by the presence of the TMUX environment variable, which
+// must point at Gas Town's socket: a client of a personal tmux server can't
+// switch to or query gt sessions.
 func IsInsideTmux() bool {
-	return os.Getenv("TMUX") != ""
+	env := os.Getenv("TMUX")
+	return env != "" && onSocket(env)
 }
 
 // SetMailClickBinding configures left-click on status-right to show mail preview.
@@ -1287,6 +1390,90 @@ func (t *Tmux) ClearHistory(pane string) error {
 	return err
 }
 
+// NewWindowWithPanes creates a window split side by side into one pane per
+// working directory (empty means tmux's default). If the session doesn't
+// exist it is created with this window; otherwise the window is added to it.
+// Returns the pane IDs (e.g., "%3") in workDirs order.
+func (t *Tmux) NewWindowWithPanes(session string, workDirs []string) ([]string, error) {
+	if len(workDirs) == 0 {
+		return nil, fmt.Errorf("no panes requested")
+	}
+	withDir := func(args []string, dir string) []string {
+		if dir != "" {
+			args = append(args, "-c", dir)
+		}
+		return args
+	}
+
+	exists, err := t.HasSession(session)
+	if err != nil {
+		return nil, err
+	}
+	var args []string
+	if exists {
+		args = []string{"new-window", "-P", "-F", "#{pane_id}", "-t", session + ":"}
+	} else {
+		args = []string{"new-session", "-d", "-P", "-F", "#{pane_id}", "-s", session}
+	}
+	out, err := t.run(withDir(args, workDirs[0])...)
+	if err != nil {
+		return nil, err
+	}
+	panes := []string{strings.TrimSpace(out)}
+
+	for _, dir := range workDirs[1:] {
+		pane, err := t.SplitPane(panes[len(panes)-1], SplitHorizontal, 0, dir, "")
+		if err != nil {
+			return panes, err
+		}
+		panes = append(panes, pane)
+	}
+	if len(panes) > 1 {
+		_, _ = t.run("select-layout", "-t", panes[0], "even-horizontal")
+	}
+	return panes, nil
+}
+
+// WaitForPaneShellReady polls until a specific pane is running a shell.
+// Unlike WaitForShellReady, it looks only at the given pane, so it works in
+// windows with several panes.
+func (t *Tmux) WaitForPaneShellReady(pane string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		out, err := t.run("display-message", "-p", "-t", pane, "#{pane_current_command}")
+		if err == nil {
+			for _, shell := range constants.SupportedShells {
+				if strings.TrimSpace(out) == shell {
+					return nil
+				}
+			}
+		}
+		time.Sleep(constants.PollInterval)
+	}
+	return fmt.Errorf("timeout waiting for shell in pane %s", pane)
+}
+
+// validEnvKeyRe matches environment variable names safe to export.
+var validEnvKeyRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SetPaneEnvironment exports an environment variable in a pane's shell.
+// tmux environments are per-session and only reach new panes, so panes that
+// share a session but need different values (e.g., paired crew workers) get
+// them exported directly. The pane must be at a shell prompt.
+func (t *Tmux) SetPaneEnvironment(pane, key, value string) error {
+	if !validEnvKeyRe.MatchString(key) {
+		return fmt.Errorf("invalid environment variable name %q", key)
+	}
+	quoted := "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+	return t.SendKeysToPane(pane, "export "+key+"="+quoted)
+}
+
+// SendKeysToPane sends keystrokes to a specific pane and presses Enter.
+// The pane parameter should be a pane ID (e.g., "%0") or session:window.pane format.
+func (t *Tmux) SendKeysToPane(pane, keys string) error {
+	return t.SendKeysDebounced(pane, keys, constants.DefaultDebounceMs)
+}
+
 // SwitchClient switches the current tmux client to a different session.
 // Used after remote recycle to move the user's view to the recycled session.
 func (t *Tmux) SwitchClient(targetSession string) error {
@@ -2,9 +2,11 @@ package tmux
 
 import (
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 )
 
 func hasTmux() bool {
@@ -757,3 +759,125 @@ func TestSessionSet(t *testing.T) {
 		t.Errorf("SessionSet.Names() doesn't contain %q", sessionName)
 	}
 }
+
+func TestNewWindowWithPanes(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-panes-" + t.Name()
+	_ = tm.KillSession(sessionName)
+
+	dirA, dirB := t.TempDir(), t.TempDir()
+	panes, err := tm.NewWindowWithPanes(sessionName, []string{dirA, dirB})
+	if err != nil {
+		t.Fatalf("NewWindowWithPanes: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	if len(panes) != 2 {
+		t.Fatalf("got %d panes, want 2: %v", len(panes), panes)
+	}
+	for i, dir := range []string{dirA, dirB} {
+		if !strings.HasPrefix(panes[i], "%") {
+			t.Errorf("pane %d ID = %q, want %%N", i, panes[i])
+		}
+		got, err := tm.run("display-message", "-p", "-t", panes[i], "#{pane_current_path}")
+		if err != nil {
+			t.Fatalf("display-message(%s): %v", panes[i], err)
+		}
+		if resolved, _ := filepath.EvalSymlinks(dir); got != dir && got != resolved {
+			t.Errorf("pane %d workdir = %q, want %q", i, got, dir)
+		}
+	}
+
+	if err := tm.WaitForPaneShellReady(panes[1], 5*time.Second); err != nil {
+		t.Skipf("shell not ready: %v", err)
+	}
+	if err := tm.SetPaneEnvironment(panes[1], "GT_TEST_PANE", "it's pane b"); err != nil {
+		t.Fatalf("SetPaneEnvironment: %v", err)
+	}
+	if err := tm.SendKeysToPane(panes[1], `echo "MARK:$GT_TEST_PANE"`); err != nil {
+		t.Fatalf("SendKeysToPane: %v", err)
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		out, _ := tm.CapturePane(panes[1], 20)
+		if strings.Contains(out, "MARK:it's pane b") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("pane output missing exported value:\n%s", out)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if err := tm.SetPaneEnvironment(panes[0], "BAD KEY", "x"); err == nil {
+		t.Error("SetPaneEnvironment accepted an invalid key")
+	}
+}
+
+func TestGetWindowActivity(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-activity-" + t.Name()
+	_ = tm.KillSession(sessionName)
+
+	before := time.Now().Add(-time.Minute)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	activity, err := tm.GetWindowActivity(sessionName)
+	if err != nil {
+		t.Fatalf("GetWindowActivity: %v", err)
+	}
+	if activity.Before(before) || activity.After(time.Now().Add(time.Minute)) {
+		t.Errorf("GetWindowActivity = %v, want around now", activity)
+	}
+
+	if _, err := tm.GetWindowActivity("nonexistent-session-xyz"); err == nil {
+		t.Error("GetWindowActivity on missing session succeeded")
+	}
+}
+
+func TestParseProcessUsage(t *testing.T) {
+	out := "  1234  0.5  2048\n  1300 97.0 409600\nbogus line\n  1301  abc 10\n"
+	got := parseProcessUsage(out)
+	if got.Processes != 2 {
+		t.Errorf("Processes = %d, want 2", got.Processes)
+	}
+	if got.CPUPercent != 97.5 {
+		t.Errorf("CPUPercent = %v, want 97.5", got.CPUPercent)
+	}
+	if want := int64(2048+409600) * 1024; got.RSSBytes != want {
+		t.Errorf("RSSBytes = %d, want %d", got.RSSBytes, want)
+	}
+}
+
+func TestGetSessionResourceUsage(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-usage-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	usage, err := tm.GetSessionResourceUsage(sessionName)
+	if err != nil {
+		t.Fatalf("GetSessionResourceUsage: %v", err)
+	}
+	if usage.Processes < 1 || usage.RSSBytes <= 0 {
+		t.Errorf("usage = %+v, want at least the pane shell", usage)
+	}
+}
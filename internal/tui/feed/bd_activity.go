@@ -0,0 +1,210 @@
+package feed
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/deps"
+)
+
+// bdActivityFormat is how bd activity output is read.
+type bdActivityFormat int
+
+const (
+	// bdActivityText is the human-readable output ("[15:04:05] ✓ gt-abc
+	// closed · ..."), parsed by pattern. Its layout has changed between bd
+	// releases, so it is only used when bd can't do better.
+	bdActivityText bdActivityFormat = iota
+
+	// bdActivityJSON is one JSON object per event (--json).
+	bdActivityJSON
+)
+
+// minBdActivityJSONVersion is the first bd release whose activity command
+// takes --json.
+const minBdActivityJSONVersion = "0.44.0"
+
+var (
+	bdFormatOnce sync.Once
+	bdFormat     bdActivityFormat
+)
+
+// detectBdActivityFormat picks the activity format the installed bd
+// supports, probing bd's version once per process. A bd whose version
+// can't be read gets the text format.
+func detectBdActivityFormat() bdActivityFormat {
+	bdFormatOnce.Do(func() {
+		_, version := deps.CheckBeads()
+		bdFormat = bdActivityFormatFor(version)
+	})
+	return bdFormat
+}
+
+// bdActivityFormatFor returns the activity format for a bd version.
+func bdActivityFormatFor(version string) bdActivityFormat {
+	if deps.BeadsVersionAtLeast(version, minBdActivityJSONVersion) {
+		return bdActivityJSON
+	}
+	return bdActivityText
+}
+
+// bdActivityArgs returns the bd arguments for an activity stream (follow)
+// or the last limit events, in the given format.
+func bdActivityArgs(format bdActivityFormat, follow bool, limit int) []string {
+	args := []string{"activity"}
+	if follow {
+		args = append(args, "--follow")
+	}
+	if limit > 0 {
+		args = append(args, "--limit", strconv.Itoa(limit))
+	}
+	if format == bdActivityJSON {
+		args = append(args, "--json")
+	}
+	return args
+}
+
+// BdActivityEvent is an event from bd activity --json.
+type BdActivityEvent struct {
+	Timestamp string `json:"timestamp"`
+	Type      string `json:"type"` // create, update, delete, comment, status, bonded, squashed, burned
+	IssueID   string `json:"issue_id"`
+	Symbol    string `json:"symbol"`
+	Message   string `json:"message"`
+	OldStatus string `json:"old_status,omitempty"`
+	NewStatus string `json:"new_status,omitempty"`
+	Actor     string `json:"actor,omitempty"`
+}
+
+// parseBdActivity parses one line of bd activity output in format. A line
+// that isn't JSON is parsed as text even in the JSON format, so warnings or
+// a bd that ignores --json still show up.
+func parseBdActivity(format bdActivityFormat, line string) *Event {
+	trimmed := strings.TrimSpace(line)
+	if format == bdActivityJSON && strings.HasPrefix(trimmed, "{") {
+		return parseBdActivityJSON(trimmed)
+	}
+	return parseBdActivityLine(line)
+}
+
+// parseBdActivityOutput parses the whole output of a non-follow bd activity
+// run, which in the JSON format may be a single array rather than one
+// object per line.
+func parseBdActivityOutput(format bdActivityFormat, out string) []*Event {
+	var parsed []*Event
+	if format == bdActivityJSON && strings.HasPrefix(strings.TrimSpace(out), "[") {
+		var raw []json.RawMessage
+		if err := json.Unmarshal([]byte(out), &raw); err == nil {
+			for _, r := range raw {
+				if event := parseBdActivityJSON(string(r)); event != nil {
+					parsed = append(parsed, event)
+				}
+			}
+			return parsed
+		}
+	}
+	for _, line := range strings.Split(out, "\n") {
+		// Untimestamped text lines (headers, blanks) can't be placed in time
+		trimmed := strings.TrimSpace(ansiPattern.ReplaceAllString(line, ""))
+		if !strings.HasPrefix(trimmed, "[") && !strings.HasPrefix(trimmed, "{") {
+			continue
+		}
+		if event := parseBdActivity(format, line); event != nil {
+			parsed = append(parsed, event)
+		}
+	}
+	return parsed
+}
+
+// parseBdActivityJSON parses a bd activity --json event.
+func parseBdActivityJSON(line string) *Event {
+	var be BdActivityEvent
+	if err := json.Unmarshal([]byte(line), &be); err != nil {
+		return nil
+	}
+	if be.Type == "" && be.IssueID == "" && be.Message == "" {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, be.Timestamp)
+	if err != nil {
+		t = time.Now()
+	}
+
+	actor, rig, role := parseBeadContext(be.IssueID)
+	if be.Actor != "" {
+		actor = be.Actor
+		if r, ro := actorContext(be.Actor); r != "" || ro != "" {
+			rig, role = r, ro
+		}
+	}
+
+	message := be.Message
+	if message == "" {
+		message = strings.TrimSpace(be.IssueID + " " + be.Type)
+	}
+
+	return &Event{
+		Time:    t.Local(),
+		Type:    bdActivityEventType(be),
+		Actor:   actor,
+		Target:  be.IssueID,
+		Message: message,
+		Rig:     rig,
+		Role:    role,
+		Raw:     line,
+	}
+}
+
+// bdActivityEventType maps a bd activity event to a feed event type. The
+// symbol, which the text format shows too, is the most specific; bd types
+// cover events from releases that add symbols.
+func bdActivityEventType(be BdActivityEvent) string {
+	if t, ok := bdSymbolTypes[be.Symbol]; ok {
+		return t
+	}
+	switch be.Type {
+	case "create", "delete":
+		return be.Type
+	case "status":
+		if be.NewStatus == "closed" {
+			return "complete"
+		}
+	case "burned":
+		return "delete"
+	}
+	return "update"
+}
+
+// bdSymbolTypes maps bd activity symbols to feed event types.
+var bdSymbolTypes = map[string]string{
+	"+": "create",
+	"→": "update",
+	"✓": "complete",
+	"✗": "fail",
+	"⊘": "delete",
+	"📌": "pin",
+}
+
+// actorContext returns the rig and role of an actor address like
+// "gastown/polecats/Toast". Town-level actors have no rig.
+func actorContext(actor string) (rig, role string) {
+	parts := strings.Split(actor, "/")
+	switch {
+	case len(parts) == 1:
+		return "", parts[0]
+	case parts[0] == "mayor" || parts[0] == "deacon":
+		return "", parts[0]
+	}
+	rig, role = parts[0], parts[len(parts)-1]
+	switch parts[len(parts)-2] {
+	case "polecats":
+		role = "polecat"
+	case "crew":
+		role = "crew"
+	}
+	return rig, role
+}
@@ -0,0 +1,165 @@
+package feed
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// parsedEvent is the part of an Event the corpus tests compare.
+type parsedEvent struct {
+	Type, Target, Actor, Rig, Role, Message string
+}
+
+func summarize(events []*Event) []parsedEvent {
+	var got []parsedEvent
+	for _, e := range events {
+		got = append(got, parsedEvent{e.Type, e.Target, e.Actor, e.Rig, e.Role, e.Message})
+	}
+	return got
+}
+
+func readCorpus(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "bd_activity", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// TestBdActivityCorpus parses bd activity output captured from different bd
+// releases and formats, as a live stream would, line by line.
+func TestBdActivityCorpus(t *testing.T) {
+	tests := []struct {
+		file   string
+		format bdActivityFormat
+		want   []parsedEvent
+	}{
+		{
+			file:   "text-basic.txt",
+			format: bdActivityText,
+			want: []parsedEvent{
+				{"create", "gt-a1b", "", "", "", "created Add login page"},
+				{"update", "gt-a1b", "", "", "", "updated status: in_progress"},
+				{"complete", "gt-a1b", "", "", "", "closed Add login page"},
+				{"fail", "gt-c3d", "", "", "", "failed Build broke"},
+				{"delete", "gt-e5f", "", "", "", "deleted"},
+				{"pin", "gt-g7h", "", "", "", "pinned Handoff"},
+			},
+		},
+		{
+			file:   "text-colored-header.txt",
+			format: bdActivityText,
+			want: []parsedEvent{
+				{"update", "", "", "", "", "Watching activity (Ctrl+C to stop)..."},
+				{"create", "gt-x9y", "", "", "", "created Colored output"},
+				{"complete", "gt-gastown-crew-joe", "gastown/crew/joe", "gastown", "crew", "closed Session ended"},
+			},
+		},
+		{
+			file:   "json-follow.jsonl",
+			format: bdActivityJSON,
+			want: []parsedEvent{
+				{"create", "gt-a1b", "", "", "", "gt-a1b created · Add login page"},
+				{"complete", "gt-a1b", "gastown/polecats/Toast", "gastown", "polecat", "gt-a1b closed"},
+				{"update", "gt-c3d", "gastown/crew/joe", "gastown", "crew", "gt-c3d commented"},
+				{"complete", "gt-e5f", "", "", "", "gt-e5f closed"},
+				{"update", "", "", "", "", "warning: daemon not running, reading database directly"},
+				{"delete", "gt-wisp-1", "mayor", "", "mayor", "wisp burned"},
+				{"update", "gt-gastown-witness", "witness", "gastown", "witness", "agent state: working"},
+			},
+		},
+		{
+			// A bd that takes --json but still prints text
+			file:   "text-basic.txt",
+			format: bdActivityJSON,
+			want: []parsedEvent{
+				{"create", "gt-a1b", "", "", "", "created Add login page"},
+				{"update", "gt-a1b", "", "", "", "updated status: in_progress"},
+				{"complete", "gt-a1b", "", "", "", "closed Add login page"},
+				{"fail", "gt-c3d", "", "", "", "failed Build broke"},
+				{"delete", "gt-e5f", "", "", "", "deleted"},
+				{"pin", "gt-g7h", "", "", "", "pinned Handoff"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			var events []*Event
+			for _, line := range strings.Split(readCorpus(t, tt.file), "\n") {
+				if e := parseBdActivity(tt.format, line); e != nil {
+					events = append(events, e)
+				}
+			}
+			if got := summarize(events); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsed events:\n got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBdActivityOutputCorpus parses whole non-follow outputs, as history
+// loading does.
+func TestBdActivityOutputCorpus(t *testing.T) {
+	tests := []struct {
+		file   string
+		format bdActivityFormat
+		want   int
+	}{
+		{"json-array.json", bdActivityJSON, 2},
+		{"json-follow.jsonl", bdActivityJSON, 6}, // the warning line has no timestamp
+		{"text-basic.txt", bdActivityText, 6},
+		{"text-colored-header.txt", bdActivityText, 2}, // not the header
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			if got := parseBdActivityOutput(tt.format, readCorpus(t, tt.file)); len(got) != tt.want {
+				t.Errorf("got %d events, want %d: %+v", len(got), tt.want, summarize(got))
+			}
+		})
+	}
+}
+
+func TestBdActivityJSONTime(t *testing.T) {
+	e := parseBdActivityJSON(`{"timestamp":"2026-01-05T09:15:02.5Z","type":"create","issue_id":"gt-a1b"}`)
+	if e == nil {
+		t.Fatal("expected event")
+	}
+	want := time.Date(2026, 1, 5, 9, 15, 2, 500000000, time.UTC)
+	if !e.Time.Equal(want) {
+		t.Errorf("time = %v, want %v", e.Time, want)
+	}
+	if e.Message != "gt-a1b create" {
+		t.Errorf("message = %q, want fallback from id and type", e.Message)
+	}
+}
+
+func TestBdActivityFormatNegotiation(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bdActivityFormat
+	}{
+		{"", bdActivityText},
+		{"0.43.0", bdActivityText},
+		{minBdActivityJSONVersion, bdActivityJSON},
+		{"1.2.0", bdActivityJSON},
+	}
+	for _, tt := range tests {
+		if got := bdActivityFormatFor(tt.version); got != tt.want {
+			t.Errorf("bdActivityFormatFor(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+
+	if got := strings.Join(bdActivityArgs(bdActivityJSON, true, 0), " "); got != "activity --follow --json" {
+		t.Errorf("follow args = %q", got)
+	}
+	if got := strings.Join(bdActivityArgs(bdActivityText, false, 50), " "); got != "activity --limit 50" {
+		t.Errorf("history args = %q", got)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/steveyegge/gastown/internal/beads"
 )
 
 // convoyIDPattern validates convoy IDs to prevent SQL injection
@@ -22,6 +23,11 @@ var convoyIDPattern = regexp.MustCompile(`^hq-[a-zA-Z0-9-]+$`)
 // Prevents TUI freezing if these commands hang.
 const convoySubprocessTimeout = 5 * time.Second
 
+// trackedIssueCache holds tracked issues between convoy panel refreshes.
+// Landed convoys are re-read on every refresh but their issues rarely
+// change, so most refreshes need no bd show at all.
+var trackedIssueCache = beads.NewCache(30 * time.Second)
+
 // Convoy represents a convoy's status for the dashboard
 type Convoy struct {
 	ID        string    `json:"id"`
@@ -198,15 +204,27 @@ func getTrackedIssueStatus(beadsDir, convoyID string) []trackedStatus {
 		}
 
 		// Get issue status
-		status := getIssueStatus(issueID)
+		status := getIssueStatus(beadsDir, issueID)
 		tracked = append(tracked, trackedStatus{ID: issueID, Status: status})
 	}
 
 	return tracked
 }
 
-// getIssueStatus fetches just the status of an issue
-func getIssueStatus(issueID string) string {
+// getIssueStatus fetches just the status of an issue, through the tracked
+// issue cache
+func getIssueStatus(beadsDir, issueID string) string {
+	issue, err := trackedIssueCache.Issue(beadsDir, issueID, func() (*beads.Issue, error) {
+		return showIssue(issueID)
+	})
+	if err != nil {
+		return "unknown"
+	}
+	return issue.Status
+}
+
+// showIssue runs bd show for an issue, with the panel's subprocess timeout
+func showIssue(issueID string) (*beads.Issue, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), convoySubprocessTimeout)
 	defer cancel()
 
@@ -215,17 +233,18 @@ func getIssueStatus(issueID string) string {
 	cmd.Stdout = &stdout
 
 	if err := cmd.Run(); err != nil {
-		return "unknown"
+		return nil, err
 	}
 
-	var issues []struct {
-		Status string `json:"status"`
+	var issues []*beads.Issue
+	if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil {
+		return nil, err
 	}
-	if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil || len(issues) == 0 {
-		return "unknown"
+	if len(issues) == 0 {
+		return nil, beads.ErrNotFound
 	}
 
-	return issues[0].Status
+	return issues[0], nil
 }
 
 // Convoy panel styles
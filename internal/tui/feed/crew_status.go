@@ -0,0 +1,205 @@
+package feed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CrewStatusRow is one crew worker's line in the crew status watch view.
+type CrewStatusRow struct {
+	Rig        string
+	Name       string
+	Running    bool
+	DirtyFiles int    // modified plus untracked files in the workspace
+	MailUnread int    // unread messages in the worker's inbox
+	LastLine   string // last non-blank line of the session's pane
+}
+
+// CrewStatusLoader collects fresh rows for the crew status watch view.
+type CrewStatusLoader func() ([]CrewStatusRow, error)
+
+// crewStatusMsg carries the result of a load. Scheduled loads re-arm the
+// refresh timer; manual ones don't, so there's only ever one timer running.
+type crewStatusMsg struct {
+	rows      []CrewStatusRow
+	err       error
+	at        time.Time
+	scheduled bool
+}
+
+// crewStatusTickMsg fires when the next scheduled refresh is due.
+type crewStatusTickMsg struct{}
+
+// CrewStatusModel is a live view of crew workers for 'gt crew status --watch'.
+// It reloads rows on a fixed interval and shares the feed's styles and keys.
+type CrewStatusModel struct {
+	load     CrewStatusLoader
+	interval time.Duration
+	keys     KeyMap
+
+	rows    []CrewStatusRow
+	err     error
+	updated time.Time
+	width   int
+}
+
+// NewCrewStatusModel creates a crew status view that calls load every interval.
+func NewCrewStatusModel(load CrewStatusLoader, interval time.Duration) *CrewStatusModel {
+	return &CrewStatusModel{
+		load:     load,
+		interval: interval,
+		keys:     DefaultKeyMap(),
+		width:    80,
+	}
+}
+
+// Init starts the first load.
+func (m *CrewStatusModel) Init() tea.Cmd {
+	return m.fetch(true)
+}
+
+// fetch returns a command that runs the loader off the UI goroutine.
+func (m *CrewStatusModel) fetch(scheduled bool) tea.Cmd {
+	load := m.load
+	return func() tea.Msg {
+		rows, err := load()
+		return crewStatusMsg{rows: rows, err: err, at: time.Now(), scheduled: scheduled}
+	}
+}
+
+// Update handles messages
+func (m *CrewStatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Refresh):
+			return m, m.fetch(false)
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+
+	case crewStatusMsg:
+		// Keep showing the last good rows if a load fails.
+		m.err = msg.err
+		if msg.err == nil {
+			m.rows = msg.rows
+			m.updated = msg.at
+		}
+		if msg.scheduled {
+			return m, tea.Tick(m.interval, func(time.Time) tea.Msg {
+				return crewStatusTickMsg{}
+			})
+		}
+
+	case crewStatusTickMsg:
+		return m, m.fetch(true)
+	}
+	return m, nil
+}
+
+// View renders the TUI
+func (m *CrewStatusModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(HeaderStyle.Render(TitleStyle.Render("Crew Status")))
+	b.WriteString("\n\n")
+
+	if len(m.rows) == 0 {
+		if m.updated.IsZero() && m.err == nil {
+			b.WriteString(AgentIdleStyle.Render("  Loading..."))
+		} else {
+			b.WriteString(AgentIdleStyle.Render("  No crew workspaces found."))
+		}
+		b.WriteString("\n")
+	}
+
+	nameWidth := 0
+	for _, r := range m.rows {
+		nameWidth = max(nameWidth, lipgloss.Width(r.Rig+"/"+r.Name))
+	}
+	for _, r := range m.rows {
+		b.WriteString(m.renderRow(r, nameWidth))
+		b.WriteString("\n")
+	}
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(EventFailStyle.Render("  refresh failed: " + m.err.Error()))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderStatusBar())
+	return b.String()
+}
+
+// renderRow renders one worker: session liveness, git dirtiness, unread mail,
+// and the last line the session printed.
+func (m *CrewStatusModel) renderRow(r CrewStatusRow, nameWidth int) string {
+	session := AgentIdleStyle.Render("○")
+	if r.Running {
+		session = AgentActiveStyle.Render("●")
+	}
+
+	name := r.Rig + "/" + r.Name
+	name = RigStyle.Render(r.Rig+"/") + AgentNameStyle.Render(r.Name) +
+		strings.Repeat(" ", nameWidth-lipgloss.Width(name))
+
+	gitCol := AgentIdleStyle.Render(fmt.Sprintf("%-9s", "clean"))
+	if r.DirtyFiles > 0 {
+		gitCol = EventDeleteStyle.Render(fmt.Sprintf("%-9s", fmt.Sprintf("%d dirty", r.DirtyFiles)))
+	}
+
+	mailCol := AgentIdleStyle.Render(fmt.Sprintf("%-10s", "no mail"))
+	if r.MailUnread > 0 {
+		mailCol = EventUpdateStyle.Render(fmt.Sprintf("%-10s", fmt.Sprintf("%d unread", r.MailUnread)))
+	}
+
+	row := fmt.Sprintf("  %s %s  %s %s ", session, name, gitCol, mailCol)
+	if r.Running && r.LastLine != "" {
+		avail := m.width - lipgloss.Width(row) - 1
+		if avail > 3 {
+			row += TimestampStyle.Render(truncate(r.LastLine, avail))
+		}
+	}
+	return row
+}
+
+// renderStatusBar renders the bottom status bar
+func (m *CrewStatusModel) renderStatusBar() string {
+	left := fmt.Sprintf("%d workers", len(m.rows))
+	if !m.updated.IsZero() {
+		left += fmt.Sprintf(" · updated %s · every %s", m.updated.Format("15:04:05"), m.interval)
+	}
+
+	help := strings.Join([]string{
+		HelpKeyStyle.Render("r") + HelpDescStyle.Render(":refresh"),
+		HelpKeyStyle.Render("q") + HelpDescStyle.Render(":quit"),
+	}, "  ")
+
+	gap := m.width - lipgloss.Width(left) - lipgloss.Width(help) - 4
+	if gap < 1 {
+		gap = 1
+	}
+	return StatusBarStyle.Width(m.width).Render(left + strings.Repeat(" ", gap) + help)
+}
+
+// truncate shortens s to at most width cells, marking the cut with "…".
+func truncate(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
@@ -0,0 +1,123 @@
+package feed
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestCrewStatusModelLoad(t *testing.T) {
+	rows := []CrewStatusRow{
+		{Rig: "gastown", Name: "joe", Running: true, DirtyFiles: 2, MailUnread: 3, LastLine: "running tests"},
+		{Rig: "beads", Name: "emma"},
+	}
+	m := NewCrewStatusModel(func() ([]CrewStatusRow, error) { return rows, nil }, time.Minute)
+
+	if view := m.View(); !strings.Contains(view, "Loading...") {
+		t.Errorf("view before the first load = %q, want Loading...", view)
+	}
+
+	msg := m.Init()()
+	loaded, ok := msg.(crewStatusMsg)
+	if !ok || !loaded.scheduled {
+		t.Fatalf("Init load = %#v, want a scheduled crewStatusMsg", msg)
+	}
+	if _, cmd := m.Update(msg); cmd == nil {
+		t.Error("scheduled load did not re-arm the refresh timer")
+	}
+
+	view := m.View()
+	for _, want := range []string{"gastown/", "joe", "2 dirty", "3 unread", "running tests", "beads/", "emma", "clean", "no mail", "2 workers"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestCrewStatusModelManualRefresh(t *testing.T) {
+	loads := 0
+	m := NewCrewStatusModel(func() ([]CrewStatusRow, error) {
+		loads++
+		return nil, nil
+	}, time.Minute)
+
+	_, cmd := m.Update(runeKey("r"))
+	if cmd == nil {
+		t.Fatal("r did not start a refresh")
+	}
+	msg := cmd()
+	if loads != 1 {
+		t.Errorf("loader called %d times, want 1", loads)
+	}
+	// Only scheduled loads re-arm the timer, so there's never a second one
+	if _, cmd := m.Update(msg); cmd != nil {
+		t.Error("manual refresh re-armed the refresh timer")
+	}
+	if view := m.View(); !strings.Contains(view, "No crew workspaces found.") {
+		t.Errorf("view after an empty load = %q", view)
+	}
+
+	if _, cmd := m.Update(crewStatusTickMsg{}); cmd == nil {
+		t.Error("refresh tick did not start a load")
+	}
+	if _, cmd := m.Update(runeKey("q")); cmd == nil {
+		t.Error("q did not quit")
+	} else if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("q returned a command other than tea.Quit")
+	}
+}
+
+func TestCrewStatusModelKeepsRowsOnError(t *testing.T) {
+	m := NewCrewStatusModel(nil, time.Minute)
+	m.Update(crewStatusMsg{rows: []CrewStatusRow{{Rig: "gastown", Name: "joe"}}, at: time.Now()})
+	m.Update(crewStatusMsg{err: errors.New("tmux gone"), at: time.Now()})
+
+	view := m.View()
+	if !strings.Contains(view, "joe") {
+		t.Errorf("rows dropped after a failed load:\n%s", view)
+	}
+	if !strings.Contains(view, "refresh failed: tmux gone") {
+		t.Errorf("view missing the load error:\n%s", view)
+	}
+}
+
+func TestCrewStatusModelLastLineFitsWidth(t *testing.T) {
+	m := NewCrewStatusModel(nil, time.Minute)
+	m.Update(tea.WindowSizeMsg{Width: 60, Height: 20})
+	m.Update(crewStatusMsg{rows: []CrewStatusRow{
+		{Rig: "gastown", Name: "joe", Running: true, LastLine: strings.Repeat("x", 200)},
+		{Rig: "gastown", Name: "max", LastLine: "stale output"},
+	}, at: time.Now()})
+
+	view := m.View()
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "joe") {
+			if w := lipgloss.Width(line); w > 60 {
+				t.Errorf("row is %d cells wide, want at most 60: %q", w, line)
+			}
+			if !strings.Contains(line, "…") {
+				t.Errorf("long last line not truncated: %q", line)
+			}
+		}
+	}
+	if strings.Contains(view, "stale output") {
+		t.Error("last line shown for a worker whose session isn't running")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("short", 10); got != "short" {
+		t.Errorf("truncate(short) = %q", got)
+	}
+	if got := truncate("abcdefghij", 5); got != "abcd…" {
+		t.Errorf("truncate = %q, want abcd…", got)
+	}
+}
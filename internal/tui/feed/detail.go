@@ -0,0 +1,214 @@
+package feed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// detailComments is how many of a bead's most recent comments the detail
+// pane shows.
+const detailComments = 5
+
+// eventDetail is the detail pane's state: the event it was opened on and
+// the bead that event references, fetched in the background.
+type eventDetail struct {
+	event    Event
+	loading  bool
+	issue    *beads.Issue
+	comments []*beads.Comment
+	err      error
+	notice   string // outcome of the last attach attempt
+}
+
+// detailMsg carries a fetched bead back to the model.
+type detailMsg struct {
+	target   string
+	issue    *beads.Issue
+	comments []*beads.Comment
+	err      error
+}
+
+// attachDoneMsg is sent when an attached agent session is detached from.
+type attachDoneMsg struct {
+	err error
+}
+
+// openDetail opens the detail pane on e and starts fetching its bead.
+func (m *Model) openDetail(e Event) tea.Cmd {
+	m.detail = &eventDetail{event: e, loading: e.Target != ""}
+	m.detailViewport.GotoTop()
+	m.updateViewContent()
+	if e.Target == "" {
+		return nil
+	}
+	return fetchDetail(m.townRoot, e.Target)
+}
+
+// closeDetail returns to the event feed.
+func (m *Model) closeDetail() {
+	m.detail = nil
+	m.updateViewContent()
+}
+
+// fetchDetail loads a bead and its comments. bd routes the ID to its rig's
+// database by prefix, so the town root serves for every rig.
+func fetchDetail(townRoot, id string) tea.Cmd {
+	return func() tea.Msg {
+		b := beads.New(townRoot)
+		issue, err := b.Show(id)
+		if err != nil {
+			return detailMsg{target: id, err: err}
+		}
+		// Comments are a nice-to-have; the bead alone is still worth showing
+		comments, _ := b.Comments(id)
+		return detailMsg{target: id, issue: issue, comments: comments}
+	}
+}
+
+// setDetail applies a fetched bead, unless the pane has moved on since.
+func (m *Model) setDetail(msg detailMsg) {
+	if m.detail == nil || m.detail.event.Target != msg.target {
+		return
+	}
+	m.detail.loading = false
+	m.detail.issue = msg.issue
+	m.detail.comments = msg.comments
+	m.detail.err = msg.err
+	m.updateViewContent()
+}
+
+// attachAgent opens the tmux session of e's actor. Inside tmux the client
+// switches to it and the feed keeps running in its own session; outside,
+// the feed is suspended until the session is detached from.
+func (m *Model) attachAgent(e Event) tea.Cmd {
+	sessionName := agentSession(e.Actor)
+	if sessionName == "" {
+		m.setNotice(fmt.Sprintf("no session for %q", e.Actor))
+		return nil
+	}
+	if ok, _ := tmux.NewTmux().HasSession(sessionName); !ok {
+		m.setNotice(fmt.Sprintf("session %s is not running", sessionName))
+		return nil
+	}
+
+	if tmux.IsInsideTmux() {
+		if err := tmux.Command("switch-client", "-t", sessionName).Run(); err != nil {
+			m.setNotice(fmt.Sprintf("switching to %s: %v", sessionName, err))
+		}
+		return nil
+	}
+	return tea.ExecProcess(tmux.AttachCommand(sessionName), func(err error) tea.Msg {
+		return attachDoneMsg{err: err}
+	})
+}
+
+// setNotice reports the outcome of an action in the detail pane.
+func (m *Model) setNotice(notice string) {
+	if m.detail != nil {
+		m.detail.notice = notice
+		m.updateViewContent()
+	}
+}
+
+// agentSession returns the tmux session of the agent at address, or "" if
+// the address isn't an agent's.
+func agentSession(address string) string {
+	identity, err := session.ParseAddress(address)
+	if err != nil {
+		return ""
+	}
+	return identity.SessionName()
+}
+
+// renderDetail renders the detail pane content.
+func (m *Model) renderDetail() string {
+	d := m.detail
+	if d == nil {
+		return ""
+	}
+	width := m.detailViewport.Width
+	var lines []string
+
+	// The event itself
+	e := d.event
+	lines = append(lines, RigStyle.Render(e.Type)+" "+TimestampStyle.Render(e.Time.Format("2006-01-02 15:04:05")))
+	if e.Actor != "" {
+		actor := RoleStyle.Render(e.Actor)
+		if s := agentSession(e.Actor); s != "" {
+			actor += TimestampStyle.Render(" (session " + s + ")")
+		}
+		lines = append(lines, actor)
+	}
+	if msg := e.Message; msg != "" {
+		lines = append(lines, wrapText(msg, width))
+	}
+	if d.notice != "" {
+		lines = append(lines, EventFailStyle.Render(d.notice))
+	}
+	lines = append(lines, "")
+
+	// The bead it references
+	switch {
+	case e.Target == "":
+		lines = append(lines, AgentIdleStyle.Render("This event doesn't reference a bead"))
+	case d.loading:
+		lines = append(lines, AgentIdleStyle.Render("Loading "+e.Target+"..."))
+	case d.err != nil:
+		lines = append(lines, EventFailStyle.Render(fmt.Sprintf("%s: %v", e.Target, d.err)))
+	default:
+		lines = append(lines, m.renderIssue(d.issue, d.comments)...)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderIssue renders a bead's summary, description and recent comments.
+func (m *Model) renderIssue(issue *beads.Issue, comments []*beads.Comment) []string {
+	width := m.detailViewport.Width
+	lines := []string{
+		TitleStyle.Render(issue.ID + "  " + issue.Title),
+	}
+
+	meta := []string{"status: " + issue.Status, fmt.Sprintf("priority: P%d", issue.Priority)}
+	if issue.Type != "" {
+		meta = append(meta, "type: "+issue.Type)
+	}
+	if issue.Assignee != "" {
+		meta = append(meta, "assignee: "+issue.Assignee)
+	}
+	lines = append(lines, TimestampStyle.Render(strings.Join(meta, "  ")))
+
+	if desc := strings.TrimSpace(issue.Description); desc != "" {
+		lines = append(lines, "", wrapText(desc, width))
+	}
+
+	if len(comments) > 0 {
+		lines = append(lines, "", RigStyle.Render(fmt.Sprintf("Comments (%d)", len(comments))))
+		if len(comments) > detailComments {
+			comments = comments[len(comments)-detailComments:]
+		}
+		for _, c := range comments {
+			header := RoleStyle.Render(c.Author)
+			if t, err := time.Parse(time.RFC3339, c.CreatedAt); err == nil {
+				header += TimestampStyle.Render(" " + formatAge(time.Since(t)))
+			}
+			lines = append(lines, header, wrapText(strings.TrimSpace(c.Text), width))
+		}
+	}
+	return lines
+}
+
+// wrapText wraps s to width, keeping its line breaks.
+func wrapText(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	return lipgloss.NewStyle().Width(width).Render(s)
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
 )
 
 // EventSource represents a source of events
@@ -21,7 +22,8 @@ type EventSource interface {
 	Close() error
 }
 
-// BdActivitySource reads events from bd activity --follow
+// BdActivitySource reads events from bd activity --follow, as JSON when the
+// installed bd supports it
 type BdActivitySource struct {
 	cmd     *exec.Cmd
 	events  chan Event
@@ -33,7 +35,8 @@ type BdActivitySource struct {
 func NewBdActivitySource(workDir string) (*BdActivitySource, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	cmd := exec.CommandContext(ctx, "bd", "activity", "--follow")
+	format := detectBdActivityFormat()
+	cmd := exec.CommandContext(ctx, "bd", bdActivityArgs(format, true, 0)...)
 	cmd.Dir = workDir
 
 	stdout, err := cmd.StdoutPipe()
@@ -58,7 +61,7 @@ func NewBdActivitySource(workDir string) (*BdActivitySource, error) {
 		scanner := bufio.NewScanner(stdout)
 		for scanner.Scan() {
 			line := scanner.Text()
-			if event := parseBdActivityLine(line); event != nil {
+			if event := parseBdActivity(format, line); event != nil {
 				select {
 				case source.events <- *event:
 				default:
@@ -86,8 +89,13 @@ func (s *BdActivitySource) Close() error {
 // bd activity line pattern: [HH:MM:SS] SYMBOL BEAD_ID action · description
 var bdActivityPattern = regexp.MustCompile(`^\[(\d{2}:\d{2}:\d{2})\]\s+([+→✓✗⊘📌])\s+(\S+)?\s*(\w+)?\s*·?\s*(.*)$`)
 
+// ansiPattern matches terminal color codes, which some bd releases print
+// even when piped.
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
 // parseBdActivityLine parses a line from bd activity output
 func parseBdActivityLine(line string) *Event {
+	line = ansiPattern.ReplaceAllString(line, "")
 	matches := bdActivityPattern.FindStringSubmatch(line)
 	if matches == nil {
 		// Try simpler pattern
@@ -111,19 +119,8 @@ func parseBdActivityLine(line string) *Event {
 
 	// Map symbol to event type
 	eventType := "update"
-	switch symbol {
-	case "+":
-		eventType = "create"
-	case "→":
-		eventType = "update"
-	case "✓":
-		eventType = "complete"
-	case "✗":
-		eventType = "fail"
-	case "⊘":
-		eventType = "delete"
-	case "📌":
-		eventType = "pin"
+	if t, ok := bdSymbolTypes[symbol]; ok {
+		eventType = t
 	}
 
 	// Try to extract actor and rig from bead ID
@@ -180,9 +177,10 @@ func parseBeadContext(beadID string) (actor, rig, role string) {
 		return
 	}
 
-	// Use the canonical parser
+	// Use the canonical parser. Any two-part ID parses, so ordinary issues
+	// (gt-a1b) are told apart by their role not being an agent's.
 	parsedRig, parsedRole, name, ok := beads.ParseAgentBeadID(beadID)
-	if !ok {
+	if !ok || !beads.IsAgentSessionBead(beadID) {
 		return
 	}
 
@@ -214,9 +212,8 @@ func parseBeadContext(beadID string) (actor, rig, role string) {
 
 // GtEventsSource reads events from ~/gt/.events.jsonl (gt activity log)
 type GtEventsSource struct {
-	file   *os.File
+	tailer *events.Tailer
 	events chan Event
-	cancel context.CancelFunc
 }
 
 // GtEvent is the structure of events in .events.jsonl
@@ -229,51 +226,35 @@ type GtEvent struct {
 	Visibility string                 `json:"visibility"`
 }
 
-// NewGtEventsSource creates a source that tails ~/gt/.events.jsonl
+// NewGtEventsSource creates a source that tails ~/gt/.events.jsonl, following
+// it across log rotation
 func NewGtEventsSource(townRoot string) (*GtEventsSource, error) {
-	eventsPath := filepath.Join(townRoot, ".events.jsonl")
-	file, err := os.Open(eventsPath)
+	// Start at the end for live tailing; history is loaded separately. A
+	// town that hasn't logged anything yet is followed from the first event.
+	tailer, err := events.NewTailer(filepath.Join(townRoot, events.EventsFile), false)
 	if err != nil {
 		return nil, err
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-
 	source := &GtEventsSource{
-		file:   file,
+		tailer: tailer,
 		events: make(chan Event, 100),
-		cancel: cancel,
 	}
 
-	go source.tail(ctx)
+	go source.tail()
 
 	return source, nil
 }
 
-// tail follows the file and sends events
-func (s *GtEventsSource) tail(ctx context.Context) {
+// tail parses the tailed lines and sends events
+func (s *GtEventsSource) tail() {
 	defer close(s.events)
 
-	// Seek to end for live tailing
-	_, _ = s.file.Seek(0, 2)
-
-	scanner := bufio.NewScanner(s.file)
-	ticker := time.NewTicker(100 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			for scanner.Scan() {
-				line := scanner.Text()
-				if event := parseGtEventLine(line); event != nil {
-					select {
-					case s.events <- *event:
-					default:
-					}
-				}
+	for line := range s.tailer.Lines() {
+		if event := parseGtEventLine(line); event != nil {
+			select {
+			case s.events <- *event:
+			default:
 			}
 		}
 	}
@@ -286,8 +267,7 @@ func (s *GtEventsSource) Events() <-chan Event {
 
 // Close stops the source
 func (s *GtEventsSource) Close() error {
-	s.cancel()
-	return s.file.Close()
+	return s.tailer.Close()
 }
 
 // parseGtEventLine parses a line from .events.jsonl
@@ -412,6 +392,17 @@ func buildEventMessage(eventType string, payload map[string]interface{}) string
 		}
 		return "polecat nudged"
 
+	case "polecat_recycled":
+		polecat := getPayloadString(payload, "polecat")
+		reason := getPayloadString(payload, "reason")
+		if polecat != "" {
+			if reason != "" {
+				return fmt.Sprintf("recycled %s: %s", polecat, reason)
+			}
+			return fmt.Sprintf("recycled %s", polecat)
+		}
+		return "polecat recycled"
+
 	case "escalation_sent":
 		target := getPayloadString(payload, "target")
 		to := getPayloadString(payload, "to")
@@ -478,6 +469,54 @@ func buildEventMessage(eventType string, payload map[string]interface{}) string
 		}
 		return "merge failed"
 
+	case "crew_added", "crew_removed":
+		name := getPayloadString(payload, "name")
+		verb := strings.TrimPrefix(eventType, "crew_")
+		if name != "" {
+			return fmt.Sprintf("crew %s %s", name, verb)
+		}
+		return "crew " + verb
+
+	case "crew_renamed":
+		return fmt.Sprintf("crew %s renamed to %s", getPayloadString(payload, "name"), getPayloadString(payload, "new_name"))
+
+	case "session_created", "session_stopped":
+		verb := "started"
+		if eventType == "session_stopped" {
+			verb = "stopped"
+		}
+		msg := "session " + verb
+		if session := getPayloadString(payload, "session"); session != "" {
+			msg = fmt.Sprintf("session %s %s", session, verb)
+		}
+		if reason := getPayloadString(payload, "reason"); reason != "" {
+			msg += ": " + reason
+		}
+		return msg
+
+	case "molecule_attached", "molecule_detached", "molecule_completed",
+		"molecule_burned", "molecule_squashed", "molecule_cancelled":
+		verb := strings.TrimPrefix(eventType, "molecule_")
+		msg := fmt.Sprintf("molecule %s %s", getPayloadString(payload, "molecule"), verb)
+		if holder := getPayloadString(payload, "holder"); holder != "" {
+			switch verb {
+			case "attached":
+				msg += " to " + holder
+			case "detached":
+				msg += " from " + holder
+			}
+		}
+		if reason := getPayloadString(payload, "reason"); reason != "" {
+			msg += ": " + reason
+		}
+		return msg
+
+	case "step_done":
+		if step := getPayloadString(payload, "step"); step != "" {
+			return fmt.Sprintf("step %s done", step)
+		}
+		return "step done"
+
 	default:
 		if msg := getPayloadString(payload, "message"); msg != "" {
 			return msg
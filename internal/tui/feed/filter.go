@@ -0,0 +1,122 @@
+package feed
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// EventFilter selects the events shown in the feed. Field filters match
+// case-insensitively: Rig, Role and Type exactly, Actor by substring (so
+// "joe" matches "gastown/crew/joe"). Search fuzzy-matches the event text.
+// The zero value shows everything.
+type EventFilter struct {
+	Rig    string
+	Role   string
+	Actor  string
+	Type   string
+	Search string
+}
+
+// filterKeys are the fields a filter expression may set.
+var filterKeys = []string{"rig", "role", "actor", "type"}
+
+// ParseFilter parses a filter expression: space-separated key:value terms
+// for rig, role, actor and type. An empty expression clears the fields.
+func ParseFilter(expr string) (EventFilter, error) {
+	var f EventFilter
+	for _, term := range strings.Fields(expr) {
+		k, v, ok := strings.Cut(term, ":")
+		if !ok || v == "" {
+			return EventFilter{}, fmt.Errorf("bad term %q (want key:value, keys: %s)", term, strings.Join(filterKeys, ", "))
+		}
+		switch strings.ToLower(k) {
+		case "rig":
+			f.Rig = v
+		case "role":
+			f.Role = v
+		case "actor":
+			f.Actor = v
+		case "type":
+			f.Type = v
+		default:
+			return EventFilter{}, fmt.Errorf("unknown filter key %q (keys: %s)", k, strings.Join(filterKeys, ", "))
+		}
+	}
+	return f, nil
+}
+
+// Active reports whether the filter hides anything.
+func (f EventFilter) Active() bool {
+	return f != EventFilter{}
+}
+
+// Fields returns the filter's key:value expression, without the search.
+func (f EventFilter) Fields() string {
+	var terms []string
+	for _, kv := range [][2]string{{"rig", f.Rig}, {"role", f.Role}, {"actor", f.Actor}, {"type", f.Type}} {
+		if kv[1] != "" {
+			terms = append(terms, kv[0]+":"+kv[1])
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+// String describes the filter for the header.
+func (f EventFilter) String() string {
+	s := f.Fields()
+	if f.Search != "" {
+		if s != "" {
+			s += " "
+		}
+		s += "/" + f.Search
+	}
+	if s == "" {
+		return "all"
+	}
+	return s
+}
+
+// Matches reports whether e passes the filter.
+func (f EventFilter) Matches(e Event) bool {
+	if f.Rig != "" && !strings.EqualFold(e.Rig, f.Rig) {
+		return false
+	}
+	if f.Role != "" && !strings.EqualFold(e.Role, f.Role) {
+		return false
+	}
+	if f.Type != "" && !strings.EqualFold(e.Type, f.Type) {
+		return false
+	}
+	if f.Actor != "" && !strings.Contains(strings.ToLower(e.Actor), strings.ToLower(f.Actor)) {
+		return false
+	}
+	if f.Search != "" {
+		text := strings.Join([]string{e.Type, e.Actor, e.Target, e.Message, e.Raw}, " ")
+		for _, word := range strings.Fields(f.Search) {
+			if !fuzzyMatch(word, text) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// fuzzyMatch reports whether the letters of query appear in text in order,
+// ignoring case, e.g. "mrgfl" matches "merge_failed".
+func fuzzyMatch(query, text string) bool {
+	q := []rune(strings.ToLower(query))
+	if len(q) == 0 {
+		return true
+	}
+	i := 0
+	for _, r := range text {
+		if unicode.ToLower(r) == q[i] {
+			i++
+			if i == len(q) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package feed
+
+import "testing"
+
+func TestParseFilter(t *testing.T) {
+	f, err := ParseFilter("rig:gastown ROLE:crew actor:joe type:merge_failed")
+	if err != nil {
+		t.Fatalf("ParseFilter: %v", err)
+	}
+	want := EventFilter{Rig: "gastown", Role: "crew", Actor: "joe", Type: "merge_failed"}
+	if f != want {
+		t.Errorf("ParseFilter = %+v, want %+v", f, want)
+	}
+	if got := f.Fields(); got != "rig:gastown role:crew actor:joe type:merge_failed" {
+		t.Errorf("Fields() = %q", got)
+	}
+
+	if f, err := ParseFilter("  "); err != nil || f.Active() {
+		t.Errorf("ParseFilter(blank) = %+v, %v; want the zero filter", f, err)
+	}
+	for _, bad := range []string{"gastown", "rig:", "color:red"} {
+		if _, err := ParseFilter(bad); err == nil {
+			t.Errorf("ParseFilter(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestEventFilterMatches(t *testing.T) {
+	e := Event{Type: "merge_failed", Actor: "gastown/crew/joe", Target: "gt-abc", Rig: "gastown", Role: "crew", Message: "conflict in main.go"}
+
+	tests := []struct {
+		name   string
+		filter EventFilter
+		want   bool
+	}{
+		{"zero", EventFilter{}, true},
+		{"rig case-insensitive", EventFilter{Rig: "GASTOWN"}, true},
+		{"other rig", EventFilter{Rig: "beads"}, false},
+		{"role", EventFilter{Role: "crew"}, true},
+		{"other role", EventFilter{Role: "polecat"}, false},
+		{"type is exact", EventFilter{Type: "merge"}, false},
+		{"actor substring", EventFilter{Actor: "Joe"}, true},
+		{"other actor", EventFilter{Actor: "emma"}, false},
+		{"fuzzy search", EventFilter{Search: "mrgfl"}, true},
+		{"search all words", EventFilter{Search: "conflict gt-abc"}, true},
+		{"search one word missing", EventFilter{Search: "conflict refinery"}, false},
+		{"fields and search", EventFilter{Rig: "gastown", Search: "main.go"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(e); got != tt.want {
+				t.Errorf("%+v.Matches = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventFilterString(t *testing.T) {
+	tests := []struct {
+		filter EventFilter
+		want   string
+	}{
+		{EventFilter{}, "all"},
+		{EventFilter{Search: "merge"}, "/merge"},
+		{EventFilter{Rig: "gastown", Search: "merge"}, "rig:gastown /merge"},
+	}
+	for _, tt := range tests {
+		if got := tt.filter.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query, text string
+		want        bool
+	}{
+		{"", "anything", true},
+		{"mrgfl", "merge_failed", true},
+		{"MRG", "merge", true},
+		{"gm", "merge", false}, // letters out of order
+		{"mergee", "merge", false},
+	}
+	for _, tt := range tests {
+		if got := fuzzyMatch(tt.query, tt.text); got != tt.want {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, want %v", tt.query, tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package feed
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// historyTimeout bounds how long startup waits on bd for past activity.
+const historyTimeout = 5 * time.Second
+
+// HistoryDir is a beads workspace to load past bd activity from, and the rig
+// it belongs to (empty if not a rig's).
+type HistoryDir struct {
+	Rig     string
+	WorkDir string
+}
+
+// LoadHistory returns up to n of the most recent past events, oldest first,
+// from the town's .events.jsonl and bd activity in each of dirs, so the feed
+// opens on what already happened rather than a blank screen. Any source may
+// be missing; whatever can be read is returned.
+func LoadHistory(townRoot string, dirs []HistoryDir, n int) []Event {
+	if n <= 0 {
+		return nil
+	}
+
+	var events []Event
+	if townRoot != "" {
+		events = append(events, loadGtEventsHistory(filepath.Join(townRoot, ".events.jsonl"), n)...)
+	}
+	for _, dir := range dirs {
+		for _, e := range loadBdActivityHistory(dir.WorkDir, n) {
+			if e.Rig == "" {
+				e.Rig = dir.Rig
+			}
+			events = append(events, e)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	if len(events) > n {
+		events = events[len(events)-n:]
+	}
+	for i := range events {
+		events[i].Backfill = true
+	}
+	return events
+}
+
+// loadGtEventsHistory parses the last n events in an .events.jsonl file.
+func loadGtEventsHistory(path string, n int) []Event {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	// Keep a ring of the last n parsed events rather than the whole file
+	var events []Event
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		event := parseGtEventLine(scanner.Text())
+		if event == nil {
+			continue
+		}
+		events = append(events, *event)
+		if len(events) > n {
+			events = events[1:]
+		}
+	}
+	return events
+}
+
+// loadBdActivityHistory runs bd activity once (no --follow) for the last n
+// events.
+func loadBdActivityHistory(workDir string, n int) []Event {
+	ctx, cancel := context.WithTimeout(context.Background(), historyTimeout)
+	defer cancel()
+
+	format := detectBdActivityFormat()
+	out, err := runBdActivity(ctx, workDir, format, n)
+	if err != nil && format == bdActivityJSON {
+		// A bd that reports a new enough version but rejects --json
+		format = bdActivityText
+		out, err = runBdActivity(ctx, workDir, format, n)
+	}
+	if err != nil {
+		return nil
+	}
+
+	now := time.Now()
+	var events []Event
+	for _, event := range parseBdActivityOutput(format, string(out)) {
+		// Text output has only the time of day, which parses as today;
+		// anything "later" than now happened yesterday.
+		if event.Time.After(now) {
+			event.Time = event.Time.AddDate(0, 0, -1)
+		}
+		events = append(events, *event)
+	}
+	return events
+}
+
+// runBdActivity runs bd activity once for the last n events.
+func runBdActivity(ctx context.Context, workDir string, format bdActivityFormat, n int) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "bd", bdActivityArgs(format, false, n)...)
+	cmd.Dir = workDir
+	return cmd.Output()
+}
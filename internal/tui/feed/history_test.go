@@ -0,0 +1,94 @@
+package feed
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeEventsFile writes an .events.jsonl to townRoot with one feed event
+// per type, a minute apart starting at start, plus an audit-only event that
+// the feed never shows.
+func writeEventsFile(t *testing.T, townRoot string, start time.Time, types ...string) {
+	t.Helper()
+	var lines []string
+	for i, typ := range types {
+		ts := start.Add(time.Duration(i) * time.Minute).Format(time.RFC3339)
+		lines = append(lines, fmt.Sprintf(`{"ts":%q,"source":"gt","type":%q,"actor":"gastown/crew/joe","visibility":"feed"}`, ts, typ))
+	}
+	lines = append(lines, `{"ts":"2026-01-01T00:00:00Z","source":"gt","type":"secret","actor":"mayor","visibility":"audit"}`)
+	data := strings.Join(lines, "\n") + "\n"
+	if err := os.WriteFile(filepath.Join(townRoot, ".events.jsonl"), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadHistory(t *testing.T) {
+	townRoot := t.TempDir()
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	writeEventsFile(t, townRoot, start, "sling", "done", "merged", "handoff")
+
+	events := LoadHistory(townRoot, nil, 3)
+	var types []string
+	for _, e := range events {
+		types = append(types, e.Type)
+		if !e.Backfill {
+			t.Errorf("%s event not marked as backfill", e.Type)
+		}
+		if e.Rig != "gastown" {
+			t.Errorf("%s event rig = %q, want gastown", e.Type, e.Rig)
+		}
+	}
+	// The most recent three, oldest first; the audit-only event is skipped
+	if got := strings.Join(types, ","); got != "done,merged,handoff" {
+		t.Errorf("history = %s, want done,merged,handoff", got)
+	}
+	if len(events) == 3 && !events[0].Time.Equal(start.Add(time.Minute)) {
+		t.Errorf("first event at %v, want %v", events[0].Time, start.Add(time.Minute))
+	}
+}
+
+func TestLoadHistoryEmpty(t *testing.T) {
+	townRoot := t.TempDir()
+	if events := LoadHistory(townRoot, nil, 10); len(events) != 0 {
+		t.Errorf("history without an events file = %+v, want none", events)
+	}
+
+	writeEventsFile(t, townRoot, time.Now(), "sling")
+	if events := LoadHistory(townRoot, nil, 0); events != nil {
+		t.Errorf("history with n=0 = %+v, want nil", events)
+	}
+}
+
+func TestModelHistorySeparator(t *testing.T) {
+	last := time.Date(2026, 3, 4, 9, 30, 0, 0, time.Local)
+	m := feedModel()
+	m.SetHistory([]Event{
+		{Time: last.Add(-time.Minute), Type: "sling", Target: "gt-1", Message: "old sling"},
+		{Time: last, Type: "done", Target: "gt-1", Message: "old done"},
+	})
+	for _, e := range m.events {
+		if !e.Backfill {
+			t.Errorf("SetHistory event %s not marked as backfill", e.Type)
+		}
+	}
+	m.addEvent(Event{Time: last.Add(time.Hour), Type: "merged", Target: "gt-1", Message: "new merge"})
+
+	feed := m.renderFeed()
+	live := strings.Index(feed, "new merge")
+	sep := strings.Index(feed, "history to Mar 4 09:30")
+	old := strings.Index(feed, "old done")
+	if live < 0 || sep < 0 || old < 0 {
+		t.Fatalf("feed missing the live event, separator or history:\n%s", feed)
+	}
+	// Newest first: live events, then the separator, then history
+	if !(live < sep && sep < old) {
+		t.Errorf("separator not between live events and history:\n%s", feed)
+	}
+	if n := strings.Count(feed, "history to"); n != 1 {
+		t.Errorf("feed has %d history separators, want 1", n)
+	}
+}
@@ -23,11 +23,14 @@ type KeyMap struct {
 	Enter   key.Binding
 	Expand  key.Binding
 	Refresh key.Binding
+	Attach  key.Binding
 
 	// Search/Filter
 	Search      key.Binding
 	Filter      key.Binding
 	ClearFilter key.Binding
+	Pause       key.Binding
+	Mute        key.Binding
 
 	// General
 	Help key.Binding
@@ -83,7 +86,7 @@ func DefaultKeyMap() KeyMap {
 		),
 		Enter: key.NewBinding(
 			key.WithKeys("enter"),
-			key.WithHelp("enter", "expand/details"),
+			key.WithHelp("enter", "event details"),
 		),
 		Expand: key.NewBinding(
 			key.WithKeys("o", "l"),
@@ -93,18 +96,30 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
 		),
+		Attach: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "attach agent session"),
+		),
 		Search: key.NewBinding(
 			key.WithKeys("/"),
 			key.WithHelp("/", "search"),
 		),
 		Filter: key.NewBinding(
 			key.WithKeys("f"),
-			key.WithHelp("f", "filter"),
+			key.WithHelp("f", "filter (rig:/role:/actor:/type:)"),
 		),
 		ClearFilter: key.NewBinding(
 			key.WithKeys("esc"),
 			key.WithHelp("esc", "clear"),
 		),
+		Pause: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "pause/resume"),
+		),
+		Mute: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "mute/unmute rigs"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
@@ -118,15 +133,15 @@ func DefaultKeyMap() KeyMap {
 
 // ShortHelp returns key bindings for the short help view.
 func (k KeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Up, k.Down, k.Tab, k.Search, k.Filter, k.Quit, k.Help}
+	return []key.Binding{k.Up, k.Down, k.Tab, k.Search, k.Filter, k.Pause, k.Quit, k.Help}
 }
 
 // FullHelp returns key bindings for the full help view.
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.PageUp, k.PageDown, k.Top, k.Bottom},
-		{k.Tab, k.FocusTree, k.FocusConvoy, k.FocusFeed, k.Enter, k.Expand},
-		{k.Search, k.Filter, k.ClearFilter, k.Refresh},
+		{k.Tab, k.FocusTree, k.FocusConvoy, k.FocusFeed, k.Enter, k.Expand, k.Attach},
+		{k.Search, k.Filter, k.ClearFilter, k.Pause, k.Mute, k.Refresh},
 		{k.Help, k.Quit},
 	}
 }
@@ -1,11 +1,16 @@
 package feed
 
 import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/steveyegge/gastown/internal/beads"
@@ -30,6 +35,7 @@ type Event struct {
 	Rig      string // which rig
 	Role     string // actor's role
 	Raw      string // raw line for fallback display
+	Backfill bool   // loaded from history at startup rather than seen live
 }
 
 // Agent represents an agent in the tree
@@ -62,6 +68,7 @@ type Model struct {
 	treeViewport   viewport.Model
 	convoyViewport viewport.Model
 	feedViewport   viewport.Model
+	detailViewport viewport.Model
 
 	// Data
 	rigs        map[string]*Rig
@@ -73,7 +80,29 @@ type Model struct {
 	keys     KeyMap
 	help     help.Model
 	showHelp bool
-	filter   string
+	filter   EventFilter
+
+	// Search and filter input
+	inputMode inputMode
+	input     textinput.Model
+	inputErr  string
+
+	// Paused feed: the events shown are frozen at pause time while new ones
+	// keep arriving, so the scrollback can be read without it moving.
+	paused        bool
+	pausedEvents  []Event
+	newSincePause int
+
+	// Multi-rig view: events carry a rig column, and rigs can be muted
+	multiRig  bool
+	rigNames  []string
+	mutedRigs map[string]bool
+
+	// Event selection in the feed panel, counted from the newest event
+	// shown, and the detail pane opened on it
+	selected     int
+	selectedLine int // line of the selected event in the feed content
+	detail       *eventDetail
 
 	// Event source
 	eventChan <-chan Event
@@ -81,20 +110,36 @@ type Model struct {
 	closeOnce sync.Once
 }
 
+// inputMode is what the input line is editing, if anything.
+type inputMode int
+
+const (
+	inputNone inputMode = iota
+	inputSearch
+	inputFilter
+	inputMute
+)
+
 // NewModel creates a new feed TUI model
 func NewModel() *Model {
 	h := help.New()
 	h.ShowAll = false
 
+	in := textinput.New()
+	in.CharLimit = 200
+
 	return &Model{
 		focusedPanel:   PanelTree,
 		treeViewport:   viewport.New(0, 0),
 		convoyViewport: viewport.New(0, 0),
 		feedViewport:   viewport.New(0, 0),
+		detailViewport: viewport.New(0, 0),
 		rigs:           make(map[string]*Rig),
 		events:         make([]Event, 0, 1000),
+		mutedRigs:      make(map[string]bool),
 		keys:           DefaultKeyMap(),
 		help:           h,
+		input:          in,
 		done:           make(chan struct{}),
 	}
 }
@@ -104,6 +149,14 @@ func (m *Model) SetTownRoot(townRoot string) {
 	m.townRoot = townRoot
 }
 
+// SetRigs switches the feed to the multi-rig view over the named rigs: each
+// event shows its rig, and rigs can be muted by name.
+func (m *Model) SetRigs(names []string) {
+	m.multiRig = true
+	m.rigNames = append([]string(nil), names...)
+	sort.Strings(m.rigNames)
+}
+
 // Init initializes the model
 func (m *Model) Init() tea.Cmd {
 	return tea.Batch(
@@ -201,16 +254,26 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tickMsg:
 		cmds = append(cmds, tick())
+
+	case detailMsg:
+		m.setDetail(msg)
+
+	case attachDoneMsg:
+		if msg.err != nil {
+			m.setNotice(fmt.Sprintf("attach: %v", msg.err))
+		}
 	}
 
 	// Update viewports
 	var cmd tea.Cmd
-	switch m.focusedPanel {
-	case PanelTree:
+	switch {
+	case m.focusedPanel == PanelTree:
 		m.treeViewport, cmd = m.treeViewport.Update(msg)
-	case PanelConvoy:
+	case m.focusedPanel == PanelConvoy:
 		m.convoyViewport, cmd = m.convoyViewport.Update(msg)
-	case PanelFeed:
+	case m.detail != nil:
+		m.detailViewport, cmd = m.detailViewport.Update(msg)
+	default:
 		m.feedViewport, cmd = m.feedViewport.Update(msg)
 	}
 	cmds = append(cmds, cmd)
@@ -220,6 +283,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleKey processes key presses
 func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.inputMode != inputNone {
+		return m.handleInputKey(msg)
+	}
+	if m.detail != nil {
+		return m.handleDetailKey(msg)
+	}
+
 	switch {
 	case key.Matches(msg, m.keys.Quit):
 		m.closeOnce.Do(func() { close(m.done) })
@@ -240,23 +310,55 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case PanelFeed:
 			m.focusedPanel = PanelTree
 		}
+		m.updateViewContent() // the feed shows its selection only when focused
 		return m, nil
 
 	case key.Matches(msg, m.keys.FocusTree):
 		m.focusedPanel = PanelTree
+		m.updateViewContent()
 		return m, nil
 
 	case key.Matches(msg, m.keys.FocusFeed):
 		m.focusedPanel = PanelFeed
+		m.updateViewContent()
 		return m, nil
 
 	case key.Matches(msg, m.keys.FocusConvoy):
 		m.focusedPanel = PanelConvoy
+		m.updateViewContent()
 		return m, nil
 
 	case key.Matches(msg, m.keys.Refresh):
 		m.updateViewContent()
 		return m, nil
+
+	case key.Matches(msg, m.keys.Search):
+		return m, m.startInput(inputSearch, m.filter.Search)
+
+	case key.Matches(msg, m.keys.Filter):
+		return m, m.startInput(inputFilter, m.filter.Fields())
+
+	case key.Matches(msg, m.keys.ClearFilter):
+		if m.filter.Active() {
+			m.filter = EventFilter{}
+			m.updateViewContent()
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Pause):
+		m.togglePause()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Mute):
+		return m, m.startInput(inputMute, "")
+	}
+
+	// The feed panel moves a selection through the events rather than
+	// scrolling, so an event can be opened
+	if m.focusedPanel == PanelFeed {
+		if handled, cmd := m.handleFeedKey(msg); handled {
+			return m, cmd
+		}
 	}
 
 	// Pass to focused viewport
@@ -272,6 +374,205 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// handleFeedKey moves the feed selection and acts on the selected event.
+func (m *Model) handleFeedKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.moveSelection(-1)
+	case key.Matches(msg, m.keys.Down):
+		m.moveSelection(1)
+	case key.Matches(msg, m.keys.PageUp):
+		m.moveSelection(-m.feedViewport.Height)
+	case key.Matches(msg, m.keys.PageDown):
+		m.moveSelection(m.feedViewport.Height)
+	case key.Matches(msg, m.keys.Top):
+		m.moveSelection(-len(m.events))
+	case key.Matches(msg, m.keys.Bottom):
+		m.moveSelection(len(m.events))
+	case key.Matches(msg, m.keys.Enter):
+		if e, ok := m.selectedEvent(); ok {
+			return true, m.openDetail(e)
+		}
+	case key.Matches(msg, m.keys.Attach):
+		if e, ok := m.selectedEvent(); ok {
+			return true, m.attachAgent(e)
+		}
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// handleDetailKey handles keys while the detail pane is open: it scrolls,
+// attaches to the event's agent, or closes.
+func (m *Model) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		m.closeOnce.Do(func() { close(m.done) })
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.ClearFilter), key.Matches(msg, m.keys.Enter):
+		m.closeDetail()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Attach):
+		return m, m.attachAgent(m.detail.event)
+
+	case key.Matches(msg, m.keys.Help):
+		m.showHelp = !m.showHelp
+		m.help.ShowAll = m.showHelp
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.detailViewport, cmd = m.detailViewport.Update(msg)
+	return m, cmd
+}
+
+// moveSelection moves the feed selection by delta events and scrolls the
+// feed to keep it in view.
+func (m *Model) moveSelection(delta int) {
+	last := len(m.shownEvents()) - 1
+	m.selected = max(0, min(min(m.selected, last)+delta, last))
+	m.updateViewContent()
+
+	if m.selectedLine < m.feedViewport.YOffset {
+		m.feedViewport.SetYOffset(m.selectedLine)
+	} else if bottom := m.feedViewport.YOffset + m.feedViewport.Height; m.selectedLine >= bottom {
+		m.feedViewport.SetYOffset(m.selectedLine - m.feedViewport.Height + 1)
+	}
+}
+
+// selectedEvent returns the selected event, if any events are shown.
+func (m *Model) selectedEvent() (Event, bool) {
+	events := m.shownEvents()
+	if len(events) == 0 {
+		return Event{}, false
+	}
+	return events[min(m.selected, len(events)-1)], true
+}
+
+// startInput opens the input line for a search or filter expression.
+func (m *Model) startInput(mode inputMode, value string) tea.Cmd {
+	m.inputMode = mode
+	m.inputErr = ""
+	switch mode {
+	case inputSearch:
+		m.input.Prompt = "/"
+		m.input.Placeholder = "fuzzy search"
+	case inputFilter:
+		m.input.Prompt = "filter: "
+		m.input.Placeholder = "rig:NAME role:ROLE actor:NAME type:TYPE"
+	case inputMute:
+		m.input.Prompt = "mute: "
+		m.input.Placeholder = "rig names to toggle (empty unmutes all)"
+	}
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	return m.input.Focus()
+}
+
+// handleInputKey edits the input line. Search applies as you type; a
+// filter expression applies on enter. Esc abandons the edit.
+func (m *Model) handleInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.closeOnce.Do(func() { close(m.done) })
+		return m, tea.Quit
+
+	case tea.KeyEsc:
+		if m.inputMode == inputSearch {
+			m.filter.Search = ""
+			m.updateViewContent()
+		}
+		m.stopInput()
+		return m, nil
+
+	case tea.KeyEnter:
+		switch m.inputMode {
+		case inputFilter:
+			f, err := ParseFilter(m.input.Value())
+			if err != nil {
+				m.inputErr = err.Error()
+				return m, nil
+			}
+			f.Search = m.filter.Search
+			m.filter = f
+			m.updateViewContent()
+		case inputMute:
+			if err := m.toggleMuted(strings.Fields(m.input.Value())); err != nil {
+				m.inputErr = err.Error()
+				return m, nil
+			}
+			m.updateViewContent()
+		}
+		m.stopInput()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.inputMode == inputSearch {
+		m.filter.Search = strings.TrimSpace(m.input.Value())
+		m.updateViewContent()
+	}
+	return m, cmd
+}
+
+// stopInput closes the input line.
+func (m *Model) stopInput() {
+	m.inputMode = inputNone
+	m.inputErr = ""
+	m.input.Blur()
+}
+
+// toggleMuted mutes each named rig that isn't muted and unmutes each one
+// that is. No names unmutes every rig. In the multi-rig view, names must be
+// rigs of the town.
+func (m *Model) toggleMuted(names []string) error {
+	if len(names) == 0 {
+		m.mutedRigs = make(map[string]bool)
+		return nil
+	}
+	for _, name := range names {
+		if len(m.rigNames) > 0 && !slices.Contains(m.rigNames, name) {
+			return fmt.Errorf("unknown rig %q (rigs: %s)", name, strings.Join(m.rigNames, ", "))
+		}
+	}
+	for _, name := range names {
+		if m.mutedRigs[name] {
+			delete(m.mutedRigs, name)
+		} else {
+			m.mutedRigs[name] = true
+		}
+	}
+	return nil
+}
+
+// mutedRigList returns the muted rigs, sorted.
+func (m *Model) mutedRigList() []string {
+	names := make([]string, 0, len(m.mutedRigs))
+	for name := range m.mutedRigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// togglePause freezes or unfreezes the event feed. While paused, the feed
+// shows all retained events (not just the latest page) for scrollback.
+func (m *Model) togglePause() {
+	m.paused = !m.paused
+	if m.paused {
+		m.pausedEvents = append([]Event(nil), m.events...)
+		m.newSincePause = 0
+	} else {
+		m.pausedEvents = nil
+		m.feedViewport.GotoTop()
+	}
+	m.updateViewContent()
+}
+
 // updateViewportSizes recalculates viewport dimensions
 func (m *Model) updateViewportSizes() {
 	// Reserve space: header (1) + borders (6 for 3 panels) + status bar (1) + help (1-2)
@@ -315,6 +616,8 @@ func (m *Model) updateViewportSizes() {
 	m.convoyViewport.Height = convoyHeight
 	m.feedViewport.Width = contentWidth
 	m.feedViewport.Height = feedHeight
+	m.detailViewport.Width = contentWidth
+	m.detailViewport.Height = feedHeight
 
 	m.updateViewContent()
 }
@@ -324,6 +627,9 @@ func (m *Model) updateViewContent() {
 	m.treeViewport.SetContent(m.renderTree())
 	m.convoyViewport.SetContent(m.renderConvoys())
 	m.feedViewport.SetContent(m.renderFeed())
+	if m.detail != nil {
+		m.detailViewport.SetContent(m.renderDetail())
+	}
 }
 
 // addEvent adds an event and updates the agent tree
@@ -387,6 +693,12 @@ func (m *Model) addEvent(e Event) {
 
 	// Add to event feed
 	m.events = append(m.events, e)
+	if m.paused {
+		m.newSincePause++
+	} else if m.selected > 0 && m.filter.Matches(e) && !m.mutedRigs[e.Rig] {
+		// Keep the same event selected as new ones arrive above it
+		m.selected++
+	}
 
 	// Keep max 1000 events
 	if len(m.events) > 1000 {
@@ -401,6 +713,15 @@ func (m *Model) SetEventChannel(ch <-chan Event) {
 	m.eventChan = ch
 }
 
+// SetHistory loads past events, oldest first, ahead of the live stream. The
+// feed marks where history ends and live events begin.
+func (m *Model) SetHistory(events []Event) {
+	for _, e := range events {
+		e.Backfill = true
+		m.addEvent(e)
+	}
+}
+
 // View renders the TUI
 func (m *Model) View() string {
 	return m.render()
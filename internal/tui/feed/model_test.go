@@ -0,0 +1,194 @@
+package feed
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// feedModel returns a sized model holding events, oldest first.
+func feedModel(events ...Event) *Model {
+	m := NewModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	for _, e := range events {
+		m.addEvent(e)
+	}
+	return m
+}
+
+// visibleTypes returns the types of the events the feed shows, oldest first.
+func visibleTypes(m *Model) []string {
+	var types []string
+	for _, e := range m.visibleEvents() {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func typeKeys(m *Model, s string) {
+	for _, r := range s {
+		m.Update(runeKey(string(r)))
+	}
+}
+
+func TestModelSearchAppliesAsYouType(t *testing.T) {
+	now := time.Now()
+	m := feedModel(
+		Event{Time: now, Type: "create", Target: "gt-1", Rig: "gastown"},
+		Event{Time: now, Type: "merge_failed", Target: "gt-2", Rig: "gastown"},
+	)
+
+	m.Update(runeKey("/"))
+	typeKeys(m, "mrg")
+	if got := visibleTypes(m); !slices.Equal(got, []string{"merge_failed"}) {
+		t.Errorf("visible while searching = %v, want [merge_failed]", got)
+	}
+	if m.filter.Search != "mrg" {
+		t.Errorf("filter.Search = %q, want mrg", m.filter.Search)
+	}
+
+	// Esc abandons the search
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.inputMode != inputNone || m.filter.Search != "" {
+		t.Errorf("after esc: inputMode=%v search=%q, want no input and no search", m.inputMode, m.filter.Search)
+	}
+	if got := visibleTypes(m); len(got) != 2 {
+		t.Errorf("visible after esc = %v, want both events", got)
+	}
+}
+
+func TestModelFilterAppliesOnEnter(t *testing.T) {
+	now := time.Now()
+	m := feedModel(
+		Event{Time: now, Type: "create", Target: "gt-1", Rig: "gastown"},
+		Event{Time: now, Type: "create", Target: "bd-1", Rig: "beads"},
+	)
+
+	m.Update(runeKey("f"))
+	typeKeys(m, "rig:beads")
+	if got := visibleTypes(m); len(got) != 2 {
+		t.Errorf("filter applied before enter: visible = %v", got)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.inputMode != inputNone {
+		t.Error("enter left the filter input open")
+	}
+	if events := m.visibleEvents(); len(events) != 1 || events[0].Rig != "beads" {
+		t.Errorf("visible after enter = %+v, want the beads event", events)
+	}
+
+	// The esc key outside the input clears the filter
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.filter.Active() {
+		t.Errorf("filter after esc = %+v, want none", m.filter)
+	}
+}
+
+func TestModelFilterRejectsBadExpression(t *testing.T) {
+	m := feedModel()
+	m.Update(runeKey("f"))
+	typeKeys(m, "color:red")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.inputMode != inputFilter {
+		t.Error("bad filter closed the input")
+	}
+	if m.inputErr == "" {
+		t.Error("bad filter set no input error")
+	}
+	if m.filter.Active() {
+		t.Errorf("bad filter applied: %+v", m.filter)
+	}
+}
+
+func TestModelPauseFreezesFeed(t *testing.T) {
+	now := time.Now()
+	m := feedModel(Event{Time: now, Type: "create", Target: "gt-1"})
+
+	m.Update(runeKey("p"))
+	if !m.paused {
+		t.Fatal("p did not pause the feed")
+	}
+	m.addEvent(Event{Time: now, Type: "create", Target: "gt-2"})
+	m.addEvent(Event{Time: now, Type: "create", Target: "gt-3"})
+
+	if got := m.visibleEvents(); len(got) != 1 || got[0].Target != "gt-1" {
+		t.Errorf("visible while paused = %+v, want only gt-1", got)
+	}
+	if m.newSincePause != 2 {
+		t.Errorf("newSincePause = %d, want 2", m.newSincePause)
+	}
+
+	m.Update(runeKey("p"))
+	if m.paused {
+		t.Fatal("second p did not resume the feed")
+	}
+	if got := m.visibleEvents(); len(got) != 3 {
+		t.Errorf("visible after resume = %d events, want 3", len(got))
+	}
+}
+
+func TestModelMuteRigs(t *testing.T) {
+	now := time.Now()
+	m := feedModel()
+	m.SetRigs([]string{"gastown", "beads"})
+	m.addEvent(Event{Time: now, Type: "create", Target: "gt-1", Rig: "gastown"})
+	m.addEvent(Event{Time: now, Type: "create", Target: "bd-1", Rig: "beads"})
+
+	m.Update(runeKey("m"))
+	typeKeys(m, "beads")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.inputMode != inputNone {
+		t.Fatalf("mute input still open: %s", m.inputErr)
+	}
+	if events := m.visibleEvents(); len(events) != 1 || events[0].Rig != "gastown" {
+		t.Errorf("visible with beads muted = %+v, want only the gastown event", events)
+	}
+	// Muted rigs still get events, so unmuting brings them back
+	m.addEvent(Event{Time: now, Type: "create", Target: "bd-2", Rig: "beads"})
+	if !strings.Contains(m.renderTree(), "(muted)") {
+		t.Error("agent tree does not mark beads as muted")
+	}
+
+	// Naming a muted rig again unmutes it
+	if err := m.toggleMuted([]string{"beads", "gastown"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.mutedRigList(); !slices.Equal(got, []string{"gastown"}) {
+		t.Errorf("muted = %v, want [gastown]", got)
+	}
+	if events := m.visibleEvents(); len(events) != 2 || events[0].Rig != "beads" {
+		t.Errorf("visible with gastown muted = %+v, want both beads events", events)
+	}
+
+	// No names unmutes everything
+	if err := m.toggleMuted(nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.visibleEvents(); len(got) != 3 {
+		t.Errorf("visible after unmuting all = %d events, want 3", len(got))
+	}
+}
+
+func TestModelMuteUnknownRig(t *testing.T) {
+	m := feedModel()
+	m.SetRigs([]string{"gastown", "beads"})
+
+	m.Update(runeKey("m"))
+	typeKeys(m, "beads nope")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.inputMode != inputMute {
+		t.Error("unknown rig closed the mute input")
+	}
+	if !strings.Contains(m.inputErr, `unknown rig "nope"`) {
+		t.Errorf("inputErr = %q, want an unknown rig error", m.inputErr)
+	}
+	// Nothing is muted when any name is wrong
+	if len(m.mutedRigs) != 0 {
+		t.Errorf("muted = %v, want none", m.mutedRigList())
+	}
+}
@@ -78,3 +78,46 @@ func (m *MultiSource) Close() error {
 	}
 	return lastErr
 }
+
+// RigSource tags the events of a rig's own source with the rig, for events
+// whose rig can't be told from the event itself (e.g. plain issue updates).
+type RigSource struct {
+	src    EventSource
+	events chan Event
+	done   chan struct{}
+	once   sync.Once
+}
+
+// NewRigSource wraps src, setting Rig on its events that lack one.
+func NewRigSource(rig string, src EventSource) *RigSource {
+	s := &RigSource{
+		src:    src,
+		events: make(chan Event, 100),
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(s.events)
+		for event := range src.Events() {
+			if event.Rig == "" {
+				event.Rig = rig
+			}
+			select {
+			case s.events <- event:
+			case <-s.done:
+				return
+			}
+		}
+	}()
+	return s
+}
+
+// Events returns the tagged event channel.
+func (s *RigSource) Events() <-chan Event {
+	return s.events
+}
+
+// Close stops the wrapped source.
+func (s *RigSource) Close() error {
+	s.once.Do(func() { close(s.done) })
+	return s.src.Close()
+}
@@ -0,0 +1,28 @@
+package feed
+
+import "testing"
+
+// chanSource is an EventSource over a channel.
+type chanSource chan Event
+
+func (c chanSource) Events() <-chan Event { return c }
+func (c chanSource) Close() error         { return nil }
+
+func TestRigSourceTagsEvents(t *testing.T) {
+	src := make(chanSource, 2)
+	src <- Event{Type: "create", Target: "bd-1"}
+	src <- Event{Type: "create", Target: "hq-1", Rig: "town"}
+	close(src)
+
+	s := NewRigSource("beads", src)
+	defer s.Close()
+
+	var rigs []string
+	for e := range s.Events() {
+		rigs = append(rigs, e.Rig)
+	}
+	// Events that already name a rig keep it
+	if len(rigs) != 2 || rigs[0] != "beads" || rigs[1] != "town" {
+		t.Errorf("rigs = %v, want [beads town]", rigs)
+	}
+}
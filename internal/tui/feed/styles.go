@@ -4,6 +4,7 @@ package feed
 import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/ui"
 )
 
@@ -94,6 +95,13 @@ var (
 	HelpDescStyle = lipgloss.NewStyle().
 			Foreground(colorDim)
 
+	PausedStyle = lipgloss.NewStyle().
+			Foreground(colorWarning).
+			Bold(true)
+
+	SeparatorStyle = lipgloss.NewStyle().
+			Foreground(colorDim)
+
 	// Focus indicator
 	FocusedBorderStyle = lipgloss.NewStyle().
 				Border(lipgloss.RoundedBorder()).
@@ -134,11 +142,12 @@ var (
 		"delete":   "⊘",
 		"pin":      "📌",
 		// Witness patrol events
-		"patrol_started":  constants.EmojiWitness,
-		"patrol_complete": "✓",
-		"polecat_checked": "·",
-		"polecat_nudged":  "⚡",
-		"escalation_sent": "⬆",
+		"patrol_started":   constants.EmojiWitness,
+		"patrol_complete":  "✓",
+		"polecat_checked":  "·",
+		"polecat_nudged":   "⚡",
+		"polecat_recycled": "♻",
+		"escalation_sent":  "⬆",
 		// Merge events
 		"merge_started": "⚙",
 		"merged":        "✓",
@@ -156,5 +165,28 @@ var (
 		"nudge":   "⚡",
 		"boot":    "🔌",
 		"halt":    "⏹",
+		// Lifecycle events
+		"crew_added":         "+",
+		"crew_removed":       "⊘",
+		"crew_renamed":       "→",
+		"session_created":    "▶",
+		"session_stopped":    "⏹",
+		"molecule_attached":  "📌",
+		"molecule_detached":  "↩",
+		"step_done":          "✓",
+		"molecule_completed": "✓",
+		"molecule_burned":    "🔥",
+		"molecule_squashed":  "📦",
+		"molecule_cancelled": "✗",
 	}
 )
+
+// RigTagStyle returns the style for a rig's tag in the multi-rig feed: the
+// rig's tmux status bar colors, padded.
+func RigTagStyle(rig string) lipgloss.Style {
+	theme := tmux.AssignTheme(rig)
+	return lipgloss.NewStyle().
+		Background(lipgloss.Color(theme.BG)).
+		Foreground(lipgloss.Color(theme.FG)).
+		Padding(0, 1)
+}
@@ -47,11 +47,12 @@ func (m *Model) render() string {
 func (m *Model) renderHeader() string {
 	title := TitleStyle.Render("GT Feed")
 
-	filter := ""
-	if m.filter != "" {
-		filter = FilterStyle.Render(fmt.Sprintf("Filter: %s", m.filter))
-	} else {
-		filter = FilterStyle.Render("Filter: all")
+	filter := FilterStyle.Render(fmt.Sprintf("Filter: %s", m.filter))
+	if len(m.mutedRigs) > 0 {
+		filter = FilterStyle.Render(fmt.Sprintf("Muted: %s", strings.Join(m.mutedRigList(), ","))) + "  " + filter
+	}
+	if m.paused {
+		filter = PausedStyle.Render("PAUSED") + " " + filter
 	}
 
 	// Right-align filter
@@ -78,6 +79,9 @@ func (m *Model) renderFeedPanel() string {
 	if m.focusedPanel == PanelFeed {
 		style = FocusedBorderStyle
 	}
+	if m.detail != nil {
+		return style.Width(m.width - 2).Render(m.detailViewport.View())
+	}
 	return style.Width(m.width - 2).Render(m.feedViewport.View())
 }
 
@@ -101,6 +105,9 @@ func (m *Model) renderTree() string {
 
 		// Rig header
 		rigLine := RigStyle.Render(rigName + "/")
+		if m.mutedRigs[rigName] {
+			rigLine += AgentIdleStyle.Render(" (muted)")
+		}
 		lines = append(lines, rigLine)
 
 		// Group agents by role
@@ -215,24 +222,84 @@ func (m *Model) renderAgent(icon string, agent *Agent, indent int) string {
 
 // renderFeed renders the event feed content
 func (m *Model) renderFeed() string {
-	if len(m.events) == 0 {
+	events := m.visibleEvents()
+	if len(events) == 0 {
+		if len(m.events) > 0 {
+			return AgentIdleStyle.Render("No events match the filter")
+		}
 		return AgentIdleStyle.Render("No events yet")
 	}
 
 	var lines []string
+	shown := m.shownEvents()
+	selected := min(m.selected, len(shown)-1)
+	for i, e := range shown {
+		// History ends here: everything above arrived live
+		if e.Backfill && (i == 0 || !shown[i-1].Backfill) {
+			lines = append(lines, m.renderHistorySeparator(e.Time))
+		}
+		line := m.renderEvent(e)
+		if m.focusedPanel == PanelFeed {
+			// Cursor column for the selection
+			if i == selected {
+				m.selectedLine = len(lines)
+				line = HelpKeyStyle.Render("▌") + line
+			} else {
+				line = " " + line
+			}
+		}
+		lines = append(lines, line)
+	}
 
-	// Show most recent events first (reversed)
+	return strings.Join(lines, "\n")
+}
+
+// shownEvents returns the events the feed lists, most recent first. Live,
+// only the latest page; paused, everything retained, for scrollback.
+func (m *Model) shownEvents() []Event {
+	events := m.visibleEvents()
 	start := 0
-	if len(m.events) > 100 {
-		start = len(m.events) - 100
+	if !m.paused && len(events) > 100 {
+		start = len(events) - 100
 	}
+	shown := make([]Event, 0, len(events)-start)
+	for i := len(events) - 1; i >= start; i-- {
+		shown = append(shown, events[i])
+	}
+	return shown
+}
 
-	for i := len(m.events) - 1; i >= start; i-- {
-		event := m.events[i]
-		lines = append(lines, m.renderEvent(event))
+// renderHistorySeparator renders the line between live events and the
+// history loaded at startup, which ends at last.
+func (m *Model) renderHistorySeparator(last time.Time) string {
+	label := fmt.Sprintf(" history to %s ", last.Format("15:04"))
+	if time.Since(last) >= 24*time.Hour {
+		label = fmt.Sprintf(" history to %s ", last.Format("Jan 2 15:04"))
 	}
+	side := (m.feedViewport.Width - lipgloss.Width(label)) / 2
+	if side < 2 {
+		side = 2
+	}
+	return SeparatorStyle.Render(strings.Repeat("─", side) + label + strings.Repeat("─", side))
+}
 
-	return strings.Join(lines, "\n")
+// visibleEvents returns the events the feed shows: the frozen set while
+// paused, the live one otherwise, narrowed by the filter and muted rigs.
+func (m *Model) visibleEvents() []Event {
+	events := m.events
+	if m.paused {
+		events = m.pausedEvents
+	}
+	if !m.filter.Active() && len(m.mutedRigs) == 0 {
+		return events
+	}
+	var matched []Event
+	for _, e := range events {
+		if m.filter.Matches(e) && !m.mutedRigs[e.Rig] {
+			matched = append(matched, e)
+		}
+	}
+	return matched
 }
 
 // renderEvent renders a single event line
@@ -253,11 +320,11 @@ func (m *Model) renderEvent(e Event) string {
 		symbolStyle = EventCreateStyle
 	case "update":
 		symbolStyle = EventUpdateStyle
-	case "complete", "patrol_complete", "merged", "done":
+	case "complete", "patrol_complete", "merged", "done", "step_done", "molecule_completed":
 		symbolStyle = EventCompleteStyle
-	case "fail", "merge_failed":
+	case "fail", "merge_failed", "molecule_cancelled":
 		symbolStyle = EventFailStyle
-	case "delete":
+	case "delete", "crew_removed", "session_stopped", "molecule_burned":
 		symbolStyle = EventDeleteStyle
 	case "merge_started":
 		symbolStyle = EventMergeStartedStyle
@@ -267,9 +334,9 @@ func (m *Model) renderEvent(e Event) string {
 		symbolStyle = EventUpdateStyle
 	case "polecat_nudged", "escalation_sent", "nudge":
 		symbolStyle = EventFailStyle // Use red/warning style for nudges and escalations
-	case "sling", "hook", "spawn", "boot":
+	case "sling", "hook", "spawn", "boot", "crew_added", "session_created", "molecule_attached":
 		symbolStyle = EventCreateStyle
-	case "handoff", "mail":
+	case "handoff", "mail", "polecat_recycled":
 		symbolStyle = EventUpdateStyle
 	default:
 		symbolStyle = EventUpdateStyle
@@ -296,9 +363,29 @@ func (m *Model) renderEvent(e Event) string {
 		msg = e.Raw
 	}
 
+	if m.multiRig {
+		return fmt.Sprintf("%s %s %s %s%s", ts, m.renderRigColumn(e.Rig), styledSymbol, actor, msg)
+	}
 	return fmt.Sprintf("%s %s %s%s", ts, styledSymbol, actor, msg)
 }
 
+// renderRigColumn renders an event's rig as a fixed-width tag in the rig's
+// tmux theme color, so each rig's events are told apart at a glance.
+func (m *Model) renderRigColumn(rig string) string {
+	width := 4
+	for _, name := range m.rigNames {
+		width = max(width, lipgloss.Width(name))
+	}
+	width = min(width, 12)
+	if rig == "" {
+		return strings.Repeat(" ", width+2)
+	}
+	if lipgloss.Width(rig) > width {
+		rig = rig[:width-1] + "…"
+	}
+	return RigTagStyle(rig).Width(width + 2).Render(rig)
+}
+
 // renderStatusBar renders the bottom status bar
 func (m *Model) renderStatusBar() string {
 	// Panel indicator
@@ -310,17 +397,32 @@ func (m *Model) renderStatusBar() string {
 		panelName = "convoy"
 	case PanelFeed:
 		panelName = "feed"
+		if m.detail != nil {
+			panelName = "detail"
+		}
 	}
 	panel := fmt.Sprintf("[%s]", panelName)
 
 	// Event count
 	count := fmt.Sprintf("%d events", len(m.events))
+	if m.filter.Active() || len(m.mutedRigs) > 0 {
+		count = fmt.Sprintf("%d/%d events", len(m.visibleEvents()), len(m.events))
+	}
+	if m.paused && m.newSincePause > 0 {
+		count += PausedStyle.Render(fmt.Sprintf(" (+%d new)", m.newSincePause))
+	}
 
 	// Short help
 	help := m.renderShortHelp()
 
 	// Combine
 	left := panel + " " + count
+	if m.inputMode != inputNone {
+		left = m.input.View()
+		if m.inputErr != "" {
+			left += " " + EventFailStyle.Render(m.inputErr)
+		}
+	}
 	gap := m.width - lipgloss.Width(left) - lipgloss.Width(help) - 4
 	if gap < 1 {
 		gap = 1
@@ -335,9 +437,23 @@ func (m *Model) renderShortHelp() string {
 		HelpKeyStyle.Render("j/k") + HelpDescStyle.Render(":scroll"),
 		HelpKeyStyle.Render("tab") + HelpDescStyle.Render(":switch"),
 		HelpKeyStyle.Render("/") + HelpDescStyle.Render(":search"),
-		HelpKeyStyle.Render("q") + HelpDescStyle.Render(":quit"),
-		HelpKeyStyle.Render("?") + HelpDescStyle.Render(":help"),
+		HelpKeyStyle.Render("f") + HelpDescStyle.Render(":filter"),
+		HelpKeyStyle.Render("p") + HelpDescStyle.Render(":pause"),
+	}
+	if m.multiRig {
+		hints = append(hints, HelpKeyStyle.Render("m")+HelpDescStyle.Render(":mute"))
+	}
+	if m.detail != nil {
+		hints = []string{
+			HelpKeyStyle.Render("j/k") + HelpDescStyle.Render(":scroll"),
+			HelpKeyStyle.Render("a") + HelpDescStyle.Render(":attach"),
+			HelpKeyStyle.Render("esc") + HelpDescStyle.Render(":back"),
+		}
 	}
+	hints = append(hints,
+		HelpKeyStyle.Render("q")+HelpDescStyle.Render(":quit"),
+		HelpKeyStyle.Render("?")+HelpDescStyle.Render(":help"),
+	)
 	return strings.Join(hints, "  ")
 }
 
@@ -19,6 +19,15 @@ import (
 // processes and avoids killing legitimate short-lived subagents.
 const minOrphanAge = 60
 
+// tmuxCommand runs tmux on Gas Town's socket. It mirrors tmux.Command,
+// which util can't import (tmux depends on util).
+func tmuxCommand(args ...string) *exec.Cmd {
+	if s := os.Getenv("GT_TMUX_SOCKET"); s != "" && s != "default" {
+		args = append([]string{"-L", s}, args...)
+	}
+	return exec.Command("tmux", args...) //nolint:gosec // G204: args are constructed internally
+}
+
 // getGasTownSessionPIDs returns a set of PIDs belonging to valid Gas Town tmux sessions.
 // This prevents killing Claude processes that are part of witness/refinery/deacon sessions
 // even if they temporarily show TTY "?" during startup or session transitions.
@@ -26,7 +35,7 @@ func getGasTownSessionPIDs() map[int]bool {
 	pids := make(map[int]bool)
 
 	// Get list of Gas Town tmux sessions (gt-* and hq-*)
-	out, err := exec.Command("tmux", "list-sessions", "-F", "#{session_name}").Output()
+	out, err := tmuxCommand("list-sessions", "-F", "#{session_name}").Output()
 	if err != nil {
 		return pids // tmux not available or no sessions
 	}
@@ -40,7 +49,7 @@ func getGasTownSessionPIDs() map[int]bool {
 
 	// For each Gas Town session, get the PIDs of processes in its panes
 	for _, session := range gasTownSessions {
-		out, err := exec.Command("tmux", "list-panes", "-t", session, "-F", "#{pane_pid}").Output()
+		out, err := tmuxCommand("list-panes", "-t", session, "-F", "#{pane_pid}").Output()
 		if err != nil {
 			continue
 		}
@@ -381,7 +390,7 @@ func FindZombieClaudeProcesses() ([]ZombieProcess, error) {
 	// Returning empty is safer than marking all Claude processes as zombies.
 	if len(validPIDs) == 0 {
 		// Check if tmux is even running
-		if err := exec.Command("tmux", "list-sessions").Run(); err != nil {
+		if err := tmuxCommand("list-sessions").Run(); err != nil {
 			return nil, fmt.Errorf("tmux not available: %w", err)
 		}
 		// tmux is running but no gt-*/hq-* sessions - that's a valid state,
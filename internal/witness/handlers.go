@@ -9,9 +9,12 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/convoy"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/notify"
+	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/util"
@@ -197,6 +200,17 @@ func HandleHelp(workDir, rigName string, msg *mail.Message, router *mail.Router)
 		result.Handled = true
 		result.MailSent = mailID
 		result.Action = fmt.Sprintf("escalated '%s' to mayor: %s", payload.Topic, assessment.EscalationReason)
+
+		// Also page humans via external sinks; best-effort.
+		if townRoot, err := workspace.Find(workDir); err == nil && townRoot != "" {
+			_, _ = notify.Send(townRoot, notify.Notification{
+				Event:    notify.EventEscalation,
+				Severity: config.SeverityHigh,
+				Title:    fmt.Sprintf("%s needs help: %s", payload.Agent, payload.Topic),
+				Body:     fmt.Sprintf("Problem: %s\nTried: %s\nReason: %s", payload.Problem, payload.Tried, assessment.EscalationReason),
+				Source:   rigName + "/witness",
+			})
+		}
 	}
 
 	return result
@@ -683,6 +697,13 @@ func NukePolecat(workDir, rigName, polecatName string) error {
 			// Log but continue - session might already be dead
 			// The important thing is we tried
 		}
+		if townRoot, err := workspace.Find(workDir); err == nil && townRoot != "" {
+			_ = polecat.RecordExit(filepath.Join(townRoot, rigName), &polecat.ExitRecord{
+				Polecat: polecatName,
+				Reason:  polecat.ExitWitnessKill,
+				Detail:  "nuked",
+			})
+		}
 	}
 
 	// Now run gt polecat nuke to clean up worktree, branch, and beads
@@ -709,6 +730,24 @@ type NukePolecatResult struct {
 // An orphan is likely from a crash before gt done completed.
 // Returns whether the nuke was performed and any error.
 func AutoNukeIfClean(workDir, rigName, polecatName string) *NukePolecatResult {
+	result := CheckAutoNuke(workDir, rigName, polecatName)
+	if result.Skipped {
+		return result
+	}
+
+	if err := NukePolecat(workDir, rigName, polecatName); err != nil {
+		result.Error = err
+		result.Reason = fmt.Sprintf("nuke failed: %v", err)
+	} else {
+		result.Nuked = true
+	}
+	return result
+}
+
+// CheckAutoNuke decides whether AutoNukeIfClean would nuke a polecat,
+// without nuking it. Skipped is set (with the reason) if the polecat would
+// be kept; otherwise Reason says why it is safe to nuke.
+func CheckAutoNuke(workDir, rigName, polecatName string) *NukePolecatResult {
 	result := &NukePolecatResult{}
 
 	// Check cleanup_status from agent bead
@@ -717,13 +756,7 @@ func AutoNukeIfClean(workDir, rigName, polecatName string) *NukePolecatResult {
 	switch cleanupStatus {
 	case "clean":
 		// Safe to nuke
-		if err := NukePolecat(workDir, rigName, polecatName); err != nil {
-			result.Error = err
-			result.Reason = fmt.Sprintf("nuke failed: %v", err)
-		} else {
-			result.Nuked = true
-			result.Reason = "auto-nuked (cleanup_status=clean, no MR)"
-		}
+		result.Reason = "auto-nuked (cleanup_status=clean, no MR)"
 
 	case "has_uncommitted", "has_stash", "has_unpushed":
 		// Not safe - has work that could be lost
@@ -739,13 +772,7 @@ func AutoNukeIfClean(workDir, rigName, polecatName string) *NukePolecatResult {
 			result.Reason = fmt.Sprintf("skipped: couldn't verify git state: %v", err)
 		} else if onMain {
 			// Commit is on main, likely safe
-			if err := NukePolecat(workDir, rigName, polecatName); err != nil {
-				result.Error = err
-				result.Reason = fmt.Sprintf("nuke failed: %v", err)
-			} else {
-				result.Nuked = true
-				result.Reason = "auto-nuked (commit on main, no cleanup_status)"
-			}
+			result.Reason = "auto-nuked (commit on main, no cleanup_status)"
 		} else {
 			// Not on main - skip, might have unpushed work
 			result.Skipped = true
@@ -0,0 +1,154 @@
+package witness
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/polecat"
+)
+
+// historyFileMu serializes appends to witness history files.
+var historyFileMu sync.Mutex
+
+// HistoryFilePath returns the path of a rig's witness patrol history.
+func HistoryFilePath(rigPath string) string {
+	return filepath.Join(rigPath, ".runtime", "witness-history.jsonl")
+}
+
+// AppendHistory appends a patrol report to the rig's witness history.
+func AppendHistory(rigPath string, report *PatrolReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	historyFileMu.Lock()
+	defer historyFileMu.Unlock()
+
+	path := HistoryFilePath(rigPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: not sensitive
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadHistory reads the patrol reports recorded at or after since, oldest
+// first. A zero since returns the whole history; a missing file returns none.
+func LoadHistory(rigPath string, since time.Time) ([]*PatrolReport, error) {
+	f, err := os.Open(HistoryFilePath(rigPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var reports []*PatrolReport
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var r PatrolReport
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue // skip torn lines
+		}
+		if r.At.Before(since) {
+			continue
+		}
+		reports = append(reports, &r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading witness history: %w", err)
+	}
+	return reports, nil
+}
+
+// PolecatSummary totals what patrols saw of one polecat.
+type PolecatSummary struct {
+	Polecat    string                        `json:"polecat"`
+	Checks     int                           `json:"checks"`
+	Health     map[polecat.SessionHealth]int `json:"health,omitempty"`  // checks per health state
+	Actions    map[string]int                `json:"actions,omitempty"` // findings per kind
+	LastHealth polecat.SessionHealth         `json:"last_health,omitempty"`
+	LastSeen   time.Time                     `json:"last_seen"`
+}
+
+// HistorySummary totals a span of witness history.
+type HistorySummary struct {
+	Rig      string            `json:"rig"`
+	Passes   int               `json:"passes"`
+	First    time.Time         `json:"first,omitempty"`
+	Last     time.Time         `json:"last,omitempty"`
+	Actions  map[string]int    `json:"actions,omitempty"` // findings per kind, all polecats
+	Errors   []string          `json:"errors,omitempty"`  // rig-level errors, most recent last
+	Polecats []*PolecatSummary `json:"polecats"`
+}
+
+// maxSummaryErrors bounds the rig-level errors kept in a summary.
+const maxSummaryErrors = 10
+
+// Summarize totals patrol reports, oldest first, by polecat.
+func Summarize(rigName string, reports []*PatrolReport) *HistorySummary {
+	sum := &HistorySummary{Rig: rigName, Actions: make(map[string]int), Polecats: []*PolecatSummary{}}
+	byName := make(map[string]*PolecatSummary)
+	get := func(name string, at time.Time) *PolecatSummary {
+		ps := byName[name]
+		if ps == nil {
+			ps = &PolecatSummary{Polecat: name, Health: make(map[polecat.SessionHealth]int), Actions: make(map[string]int)}
+			byName[name] = ps
+		}
+		if at.After(ps.LastSeen) {
+			ps.LastSeen = at
+		}
+		return ps
+	}
+
+	for _, r := range reports {
+		sum.Passes++
+		if sum.First.IsZero() || r.At.Before(sum.First) {
+			sum.First = r.At
+		}
+		if r.At.After(sum.Last) {
+			sum.Last = r.At
+		}
+		for _, c := range r.Polecats {
+			ps := get(c.Polecat, r.At)
+			ps.Checks++
+			ps.Health[c.Health]++
+			ps.LastHealth = c.Health
+		}
+		for _, f := range r.Findings {
+			sum.Actions[f.Kind]++
+			if f.Polecat == "" {
+				if f.Kind == FindingError {
+					sum.Errors = append(sum.Errors, f.Detail)
+				}
+				continue
+			}
+			get(f.Polecat, r.At).Actions[f.Kind]++
+		}
+	}
+	if len(sum.Errors) > maxSummaryErrors {
+		sum.Errors = sum.Errors[len(sum.Errors)-maxSummaryErrors:]
+	}
+
+	for _, ps := range byName {
+		sum.Polecats = append(sum.Polecats, ps)
+	}
+	sort.Slice(sum.Polecats, func(i, j int) bool { return sum.Polecats[i].Polecat < sum.Polecats[j].Polecat })
+	return sum
+}
@@ -0,0 +1,62 @@
+package witness
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/polecat"
+)
+
+func TestHistoryRoundTripAndSummary(t *testing.T) {
+	rigPath := t.TempDir()
+	base := time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC)
+
+	reports := []*PatrolReport{
+		{Rig: "gastown", At: base, Checked: 2,
+			Polecats: []PolecatCheck{{Polecat: "Toast", Health: polecat.HealthRunning}, {Polecat: "Cheedo", Health: polecat.HealthWedged}},
+			Findings: []Finding{{Polecat: "Cheedo", Kind: FindingNudged}}},
+		{Rig: "gastown", At: base.Add(time.Minute), Checked: 1,
+			Polecats: []PolecatCheck{{Polecat: "Cheedo", Health: polecat.HealthRunning}},
+			Findings: []Finding{{Polecat: "Toast", Kind: FindingExited}, {Kind: FindingError, Detail: "listing sessions: boom"}}},
+		{Rig: "gastown", At: base.Add(2 * time.Hour)},
+	}
+	for _, r := range reports {
+		if err := AppendHistory(rigPath, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, err := LoadHistory(rigPath, time.Time{})
+	if err != nil || len(all) != 3 {
+		t.Fatalf("LoadHistory = %d reports, %v; want 3", len(all), err)
+	}
+	recent, _ := LoadHistory(rigPath, base.Add(time.Hour))
+	if len(recent) != 1 {
+		t.Errorf("LoadHistory since 1h = %d reports, want 1", len(recent))
+	}
+
+	sum := Summarize("gastown", all[:2])
+	if sum.Passes != 2 || !sum.First.Equal(base) || !sum.Last.Equal(base.Add(time.Minute)) {
+		t.Errorf("summary span = %+v", sum)
+	}
+	if sum.Actions[FindingNudged] != 1 || sum.Actions[FindingExited] != 1 || len(sum.Errors) != 1 {
+		t.Errorf("summary actions = %v, errors %v", sum.Actions, sum.Errors)
+	}
+	if len(sum.Polecats) != 2 || sum.Polecats[0].Polecat != "Cheedo" {
+		t.Fatalf("polecats = %+v", sum.Polecats)
+	}
+	cheedo, toast := sum.Polecats[0], sum.Polecats[1]
+	if cheedo.Checks != 2 || cheedo.Health[polecat.HealthWedged] != 1 || cheedo.LastHealth != polecat.HealthRunning || cheedo.Actions[FindingNudged] != 1 {
+		t.Errorf("Cheedo = %+v", cheedo)
+	}
+	if toast.Checks != 1 || toast.Actions[FindingExited] != 1 || !toast.LastSeen.Equal(base.Add(time.Minute)) {
+		t.Errorf("Toast = %+v", toast)
+	}
+}
+
+func TestLoadHistoryMissing(t *testing.T) {
+	reports, err := LoadHistory(t.TempDir(), time.Time{})
+	if err != nil || reports != nil {
+		t.Errorf("LoadHistory on empty rig = %v, %v", reports, err)
+	}
+}
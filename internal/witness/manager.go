@@ -12,6 +12,7 @@ import (
 	"github.com/steveyegge/gastown/internal/claude"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -196,6 +197,7 @@ func (m *Manager) Start(foreground bool, agentOverride string, envOverrides []st
 	time.Sleep(2 * time.Second)
 	_ = t.NudgeSession(sessionID, session.PropulsionNudgeForRole("witness", witnessDir)) // Non-fatal
 
+	_ = events.Publish(address, events.SessionEvent{Type: events.TypeSessionCreated, Session: sessionID, Agent: address, Rig: m.rig.Name})
 	return nil
 }
 
@@ -259,5 +261,11 @@ func (m *Manager) Stop() error {
 	}
 
 	// Kill the tmux session
-	return t.KillSession(sessionID)
+	if err := t.KillSession(sessionID); err != nil {
+		return err
+	}
+
+	address := fmt.Sprintf("%s/witness", m.rig.Name)
+	_ = events.Publish(address, events.SessionEvent{Type: events.TypeSessionStopped, Session: sessionID, Agent: address, Rig: m.rig.Name})
+	return nil
 }
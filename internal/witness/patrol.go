@@ -0,0 +1,323 @@
+package witness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// DefaultPatrolInterval is how often 'gt witness run' patrols by default.
+const DefaultPatrolInterval = 60 * time.Second
+
+// StalledNudge is injected into a wedged polecat session.
+const StalledNudge = "Witness check-in: your session has been quiet for a while. If you're stuck, say what's blocking you; otherwise carry on with your hooked work."
+
+// RecycleCooldown is the least time between two recycles of one polecat, so
+// a fresh session echoing its predecessor's output isn't recycled again.
+const RecycleCooldown = 10 * time.Minute
+
+// Finding kinds reported by a patrol.
+const (
+	FindingNudged   = "nudged"   // wedged agent nudged
+	FindingRecycled = "recycled" // session restarted with a resume file
+	FindingCrashed  = "crashed"  // session alive, agent process gone
+	FindingExited   = "exited"   // session vanished without being stopped
+	FindingNuked    = "nuked"    // finished polecat's workspace removed
+	FindingSkipped  = "skipped"  // finished polecat kept, e.g. unpushed work
+	FindingSLA      = "sla"      // molecule step past its SLA escalated to the Mayor
+	FindingError    = "error"
+)
+
+// PatrolOptions configures a witness patrol.
+type PatrolOptions struct {
+	// Interval between patrols (default DefaultPatrolInterval).
+	Interval time.Duration
+
+	// WedgedAfter is how long a live agent may be silent before it is
+	// nudged (default polecat.DefaultWedgedAfter). A wedged polecat is
+	// nudged at most once per WedgedAfter.
+	WedgedAfter time.Duration
+
+	// NoGC disables removing workspaces of finished polecats.
+	NoGC bool
+
+	// NoRecycle disables restarting sessions whose pane output matches
+	// ExhaustionPatterns.
+	NoRecycle bool
+
+	// ExhaustionPatterns are pane output phrases that mean an agent's
+	// context is nearly used up (default polecat.DefaultExhaustionPatterns).
+	ExhaustionPatterns []string
+
+	// MaxSessionAge recycles sessions that have been running longer than
+	// this, whatever their output. Zero means no age limit.
+	MaxSessionAge time.Duration
+
+	// DryRun reports what the patrol would do without doing it: no nudges,
+	// no recycles, no nukes, and nothing written to the events feed, the terminations
+	// log, or the witness history.
+	DryRun bool
+}
+
+// Finding is something a patrol noticed or did.
+type Finding struct {
+	Polecat string `json:"polecat"`
+	Kind    string `json:"kind"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// PolecatCheck is the health of one polecat session seen by a patrol.
+type PolecatCheck struct {
+	Polecat string                `json:"polecat"`
+	Health  polecat.SessionHealth `json:"health"`
+	Reason  string                `json:"reason,omitempty"`
+}
+
+// PatrolReport is the outcome of one patrol pass.
+type PatrolReport struct {
+	Rig      string         `json:"rig"`
+	At       time.Time      `json:"at"`
+	DryRun   bool           `json:"dry_run,omitempty"` // findings are actions not taken
+	Checked  int            `json:"checked"`           // running sessions checked
+	Polecats []PolecatCheck `json:"polecats,omitempty"`
+	Findings []Finding      `json:"findings,omitempty"`
+}
+
+// Patroller runs the witness patrol loop for a rig: it checks the health of
+// every polecat session, nudges wedged agents, recycles sessions that ran
+// out of context, records sessions that went
+// away, escalates molecule steps running past their SLA, garbage-collects
+// finished polecats' workspaces, and logs each finding to the events feed. Every pass is kept in the rig's witness
+// history for 'gt witness report'.
+type Patroller struct {
+	m        *Manager
+	opts     PatrolOptions
+	sessions *polecat.SessionManager
+	polecats *polecat.Manager
+
+	lastNudge   map[string]time.Time
+	lastRecycle map[string]time.Time
+	skipped     map[string]string // last skip reason reported per polecat
+}
+
+// NewPatroller creates a patroller for the manager's rig.
+func (m *Manager) NewPatroller(opts PatrolOptions) *Patroller {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultPatrolInterval
+	}
+	if opts.WedgedAfter <= 0 {
+		opts.WedgedAfter = polecat.DefaultWedgedAfter
+	}
+	t := tmux.NewTmux()
+	return &Patroller{
+		m:           m,
+		opts:        opts,
+		sessions:    polecat.NewSessionManager(t, m.rig),
+		polecats:    polecat.NewManager(m.rig, git.NewGit(m.rig.Path), t),
+		lastNudge:   make(map[string]time.Time),
+		lastRecycle: make(map[string]time.Time),
+		skipped:     make(map[string]string),
+	}
+}
+
+// Run patrols every Interval until ctx is done, passing each report to
+// onReport (which may be nil).
+func (p *Patroller) Run(ctx context.Context, onReport func(*PatrolReport)) error {
+	ticker := time.NewTicker(p.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		report := p.Once()
+		if onReport != nil {
+			onReport(report)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Once runs a single patrol pass.
+func (p *Patroller) Once() *PatrolReport {
+	rigName := p.m.rig.Name
+	report := &PatrolReport{Rig: rigName, At: time.Now(), DryRun: p.opts.DryRun}
+	add := func(name, kind, detail string) {
+		report.Findings = append(report.Findings, Finding{Polecat: name, Kind: kind, Detail: detail})
+	}
+
+	// Sessions that vanished since the last pass
+	findExits := p.sessions.DetectExits
+	if p.opts.DryRun {
+		findExits = p.sessions.FindExits
+	}
+	exits, err := findExits()
+	if err != nil {
+		add("", FindingError, fmt.Sprintf("detecting exits: %v", err))
+	}
+	for _, exit := range exits {
+		add(exit.Polecat, FindingExited, string(exit.Reason))
+	}
+
+	// Health of running sessions
+	infos, err := p.sessions.List()
+	if err != nil {
+		add("", FindingError, fmt.Sprintf("listing sessions: %v", err))
+	}
+	p.logFeed(events.TypePatrolStarted, events.PatrolPayload(rigName, len(infos), ""))
+	running := make(map[string]bool)
+	for _, info := range infos {
+		running[info.Polecat] = true
+		health, err := p.sessions.Health(info.Polecat, p.opts.WedgedAfter)
+		if err != nil {
+			if !errors.Is(err, polecat.ErrSessionNotFound) {
+				add(info.Polecat, FindingError, fmt.Sprintf("checking health: %v", err))
+			}
+			continue
+		}
+		report.Checked++
+		report.Polecats = append(report.Polecats, PolecatCheck{Polecat: info.Polecat, Health: health.Health, Reason: health.Reason})
+		p.logFeed(events.TypePolecatChecked, events.PolecatCheckPayload(rigName, info.Polecat, string(health.Health), ""))
+
+		if health.Health != polecat.HealthCrashed {
+			if reason := p.recycleReason(info.Polecat, report.At); reason != "" {
+				p.recycle(info.Polecat, reason, report.At, add)
+				continue
+			}
+		}
+
+		switch health.Health {
+		case polecat.HealthWedged:
+			if last, ok := p.lastNudge[info.Polecat]; ok && report.At.Sub(last) < p.opts.WedgedAfter {
+				continue
+			}
+			if p.opts.DryRun {
+				add(info.Polecat, FindingNudged, health.Reason)
+				continue
+			}
+			if err := p.sessions.Inject(info.Polecat, StalledNudge); err != nil {
+				add(info.Polecat, FindingError, fmt.Sprintf("nudging: %v", err))
+				continue
+			}
+			p.lastNudge[info.Polecat] = report.At
+			add(info.Polecat, FindingNudged, health.Reason)
+			p.logFeed(events.TypePolecatNudged, events.NudgePayload(rigName, info.Polecat, health.Reason))
+		case polecat.HealthCrashed:
+			add(info.Polecat, FindingCrashed, health.Reason)
+		default:
+			delete(p.lastNudge, info.Polecat)
+		}
+	}
+
+	// Molecule steps running past their SLA
+	p.checkStepSLAs(report.At, add)
+
+	// Finished polecats whose session is gone but whose workspace lingers
+	if !p.opts.NoGC {
+		p.collectGarbage(running, add)
+	}
+
+	p.logFeed(events.TypePatrolComplete, events.PatrolPayload(rigName, report.Checked,
+		fmt.Sprintf("%d finding(s)", len(report.Findings))))
+
+	if !p.opts.DryRun {
+		if err := AppendHistory(p.m.rig.Path, report); err != nil {
+			add("", FindingError, fmt.Sprintf("writing history: %v", err))
+		}
+	}
+	return report
+}
+
+// recycleReason says why a polecat's session should be recycled, or returns
+// "" if it should be left alone.
+func (p *Patroller) recycleReason(name string, now time.Time) string {
+	if last, ok := p.lastRecycle[name]; ok && now.Sub(last) < RecycleCooldown {
+		return ""
+	}
+	if p.opts.MaxSessionAge > 0 {
+		if age := p.sessions.SessionAge(name, now); age > p.opts.MaxSessionAge {
+			return fmt.Sprintf("session running for %s", age.Round(time.Minute))
+		}
+	}
+	if !p.opts.NoRecycle {
+		if line, ok, err := p.sessions.ContextExhausted(name, p.opts.ExhaustionPatterns); err == nil && ok {
+			return fmt.Sprintf("context exhausted: %q", line)
+		}
+	}
+	return ""
+}
+
+// recycle restarts a polecat's session the way 'gt session restart' does:
+// the end of its output is saved to a resume file and the new agent is
+// pointed at it, so work carries over to a fresh context.
+func (p *Patroller) recycle(name, reason string, now time.Time, add func(name, kind, detail string)) {
+	if p.opts.DryRun {
+		add(name, FindingRecycled, reason)
+		return
+	}
+	if _, err := p.sessions.Restart(name, polecat.SessionRestartOptions{Reason: polecat.ExitRecycle}); err != nil {
+		add(name, FindingError, fmt.Sprintf("recycling: %v", err))
+		return
+	}
+	p.lastRecycle[name] = now
+	delete(p.lastNudge, name)
+	add(name, FindingRecycled, reason)
+	p.logFeed(events.TypePolecatRecycled, events.RecyclePayload(p.m.rig.Name, name, reason))
+}
+
+// collectGarbage nukes polecats that called 'gt done' but whose cleanup never
+// finished, when their work is safely pushed.
+func (p *Patroller) collectGarbage(running map[string]bool, add func(name, kind, detail string)) {
+	pcs, err := p.polecats.List()
+	if err != nil {
+		add("", FindingError, fmt.Sprintf("listing polecats: %v", err))
+		return
+	}
+	for _, pc := range pcs {
+		if running[pc.Name] || pc.State != polecat.StateDone {
+			continue
+		}
+		var result *NukePolecatResult
+		if p.opts.DryRun {
+			result = CheckAutoNuke(p.m.witnessDir(), p.m.rig.Name, pc.Name)
+			if !result.Skipped {
+				add(pc.Name, FindingNuked, strings.Trim(strings.TrimPrefix(result.Reason, "auto-nuked "), "()"))
+				continue
+			}
+		} else {
+			result = AutoNukeIfClean(p.m.witnessDir(), p.m.rig.Name, pc.Name)
+		}
+		switch {
+		case result.Nuked:
+			add(pc.Name, FindingNuked, result.Reason)
+			p.logFeed(events.TypeKill, events.KillPayload(p.m.rig.Name, pc.Name, result.Reason))
+		case result.Error != nil:
+			add(pc.Name, FindingError, result.Reason)
+		default:
+			// Report a kept workspace once, not on every pass
+			if p.skipped[pc.Name] != result.Reason {
+				p.skipped[pc.Name] = result.Reason
+				add(pc.Name, FindingSkipped, result.Reason)
+			}
+		}
+	}
+}
+
+// logFeed appends an event to the feed, except in a dry run.
+func (p *Patroller) logFeed(eventType string, payload map[string]interface{}) {
+	if !p.opts.DryRun {
+		_ = events.LogFeed(eventType, p.actor(), payload)
+	}
+}
+
+func (p *Patroller) actor() string {
+	return p.m.rig.Name + "/witness"
+}
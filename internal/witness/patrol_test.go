@@ -0,0 +1,64 @@
+package witness
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestPatrolEmptyRig(t *testing.T) {
+	t.Chdir(t.TempDir()) // keep feed events out of any enclosing town
+	m := NewManager(&rig.Rig{Name: "patrol-test-rig", Path: t.TempDir()})
+
+	p := m.NewPatroller(PatrolOptions{})
+	if p.opts.Interval != DefaultPatrolInterval || p.opts.WedgedAfter != polecat.DefaultWedgedAfter {
+		t.Errorf("defaults not applied: %+v", p.opts)
+	}
+
+	report := p.Once()
+	if report.Rig != "patrol-test-rig" || report.Checked != 0 || len(report.Findings) != 0 {
+		t.Errorf("report = %+v, want an empty patrol", report)
+	}
+}
+
+func TestPatrolRunStopsOnCancel(t *testing.T) {
+	t.Chdir(t.TempDir())
+	m := NewManager(&rig.Rig{Name: "patrol-test-rig", Path: t.TempDir()})
+	p := m.NewPatroller(PatrolOptions{Interval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	passes := 0
+	err := p.Run(ctx, func(*PatrolReport) {
+		passes++
+		if passes == 3 {
+			cancel()
+		}
+	})
+	if err != context.Canceled {
+		t.Errorf("Run = %v, want context.Canceled", err)
+	}
+	if passes != 3 {
+		t.Errorf("patrolled %d times, want 3", passes)
+	}
+}
+
+func TestPatrolDryRunWritesNothing(t *testing.T) {
+	t.Chdir(t.TempDir())
+	rigPath := t.TempDir()
+	m := NewManager(&rig.Rig{Name: "patrol-test-rig", Path: rigPath})
+
+	report := m.NewPatroller(PatrolOptions{DryRun: true}).Once()
+	if !report.DryRun {
+		t.Error("report not marked as a dry run")
+	}
+	if _, err := os.Stat(HistoryFilePath(rigPath)); !os.IsNotExist(err) {
+		t.Errorf("dry run wrote history (stat err = %v)", err)
+	}
+	if _, err := os.Stat(polecat.TerminationsFilePath(rigPath)); !os.IsNotExist(err) {
+		t.Errorf("dry run wrote terminations (stat err = %v)", err)
+	}
+}
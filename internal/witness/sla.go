@@ -0,0 +1,147 @@
+package witness
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// SLABreach is a molecule step that has been running longer than its SLA.
+type SLABreach struct {
+	Step       *beads.Issue
+	MoleculeID string // instance root the step belongs to
+	Timing     beads.StepTiming
+}
+
+// FindSLABreaches returns molecule steps in progress past their SLA that
+// haven't been escalated yet.
+func FindSLABreaches(b *beads.Beads, now time.Time) ([]SLABreach, error) {
+	var candidates []*beads.Issue
+	for _, status := range []string{"in_progress", beads.StatusHooked, beads.StatusPinned} {
+		issues, err := b.List(beads.ListOptions{Status: status, Priority: -1})
+		if err != nil {
+			return nil, fmt.Errorf("listing %s issues: %w", status, err)
+		}
+		for _, issue := range issues {
+			fields := beads.ParseStepTimingFields(issue)
+			if fields.SLA != "" && fields.SLAEscalatedAt == "" {
+				candidates = append(candidates, issue)
+			}
+		}
+	}
+
+	// Time each candidate among its siblings, whose close times tell when
+	// a step without started_at became ready
+	var breaches []SLABreach
+	siblingsOf := make(map[string][]*beads.Issue)
+	for _, step := range candidates {
+		rootID := stepRootID(step)
+		if rootID == "" {
+			continue
+		}
+		siblings, ok := siblingsOf[rootID]
+		if !ok {
+			var err error
+			siblings, err = b.List(beads.ListOptions{Parent: rootID, Status: "all", Priority: -1})
+			if err != nil {
+				return nil, fmt.Errorf("listing steps of %s: %w", rootID, err)
+			}
+			siblingsOf[rootID] = siblings
+		}
+		for _, st := range beads.TimeMoleculeSteps(siblings, now).Steps {
+			if st.ID == step.ID && st.Running && st.OverSLA {
+				breaches = append(breaches, SLABreach{Step: step, MoleculeID: rootID, Timing: st})
+			}
+		}
+	}
+	return breaches, nil
+}
+
+// stepRootID returns the instance root of a molecule step: its parent, or
+// the ID it extends (gt-abc.2 -> gt-abc).
+func stepRootID(step *beads.Issue) string {
+	if step.Parent != "" {
+		return step.Parent
+	}
+	if i := strings.LastIndex(step.ID, "."); i > 0 {
+		return step.ID[:i]
+	}
+	return ""
+}
+
+// EscalateSLABreach sends an SLA_EXCEEDED escalation to the Mayor.
+func EscalateSLABreach(router *mail.Router, rigName string, breach SLABreach) (string, error) {
+	assignee := breach.Step.Assignee
+	if assignee == "" {
+		assignee = "(unassigned)"
+	}
+	msg := &mail.Message{
+		From:     fmt.Sprintf("%s/witness", rigName),
+		To:       "mayor/",
+		Subject:  fmt.Sprintf("SLA_EXCEEDED %s: %s", breach.Step.ID, breach.Step.Title),
+		Priority: mail.PriorityHigh,
+		Body: fmt.Sprintf(`Step: %s
+Molecule: %s
+Proto: %s
+Assignee: %s
+Started: %s
+Running for: %s
+SLA: %s
+
+This molecule step has run past its SLA. Check whether the agent is stuck,
+needs help, or the SLA in the proto is too tight.`,
+			breach.Step.ID,
+			breach.MoleculeID,
+			beads.StepMoleculeID(breach.Step),
+			assignee,
+			breach.Timing.StartedAt,
+			breach.Timing.Duration(),
+			breach.Timing.SLA(),
+		),
+	}
+
+	if err := router.Send(msg); err != nil {
+		return "", err
+	}
+
+	return msg.ID, nil
+}
+
+// checkStepSLAs escalates molecule steps running past their SLA to the
+// Mayor, once per step.
+func (p *Patroller) checkStepSLAs(now time.Time, add func(name, kind, detail string)) {
+	workDir := p.m.witnessDir()
+	if _, err := os.Stat(beads.ResolveBeadsDir(workDir)); err != nil {
+		return // no rig beads, so no molecule steps
+	}
+	b := beads.New(workDir)
+	breaches, err := FindSLABreaches(b, now)
+	if err != nil {
+		add("", FindingError, fmt.Sprintf("checking step SLAs: %v", err))
+		return
+	}
+
+	router := mail.NewRouter(p.m.townRoot())
+	for _, breach := range breaches {
+		detail := fmt.Sprintf("%s running %s, SLA %s", breach.Step.ID, breach.Timing.Duration(), breach.Timing.SLA())
+		if breach.Step.Assignee != "" {
+			detail += " (" + breach.Step.Assignee + ")"
+		}
+		if p.opts.DryRun {
+			add("", FindingSLA, detail)
+			continue
+		}
+		if _, err := EscalateSLABreach(router, p.m.rig.Name, breach); err != nil {
+			add("", FindingError, fmt.Sprintf("escalating SLA breach of %s: %v", breach.Step.ID, err))
+			continue
+		}
+		if err := b.MarkStepSLAEscalated(breach.Step, now); err != nil {
+			add("", FindingError, fmt.Sprintf("marking %s escalated: %v", breach.Step.ID, err))
+		}
+		add("", FindingSLA, detail)
+	}
+}
@@ -0,0 +1,133 @@
+package witness
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// maxLoopCrashes bounds the crash records kept in the supervisor state.
+const maxLoopCrashes = 10
+
+// LoopCrash records one unexpected exit of a supervised patrol loop.
+type LoopCrash struct {
+	At     time.Time `json:"at"`
+	PID    int       `json:"pid"`
+	Error  string    `json:"error"`
+	Uptime string    `json:"uptime"` // how long it had been running
+}
+
+// SupervisorState is what the daemon records about a rig's supervised
+// 'gt witness run' loop. Whether the loop is actually alive is judged from
+// the patrol history (see LoopHealth), not from PID, so a loop killed
+// without a trace (e.g., by the OOM killer) never looks healthy.
+type SupervisorState struct {
+	PID       int           `json:"pid,omitempty"`
+	StartedAt time.Time     `json:"started_at,omitempty"`
+	Interval  time.Duration `json:"interval"`
+	Running   bool          `json:"running"` // false after a deliberate stop
+	Starts    int           `json:"starts"`
+	Crashes   []LoopCrash   `json:"crashes,omitempty"` // most recent last
+	Restarts  int           `json:"restarts"`          // starts that followed a crash
+}
+
+// SupervisorStatePath returns where the daemon records a rig's witness loop.
+func SupervisorStatePath(rigPath string) string {
+	return filepath.Join(rigPath, ".runtime", "witness-supervisor.json")
+}
+
+// LoadSupervisorState reads the supervisor state, or nil if the loop has
+// never been supervised.
+func LoadSupervisorState(rigPath string) (*SupervisorState, error) {
+	data, err := os.ReadFile(SupervisorStatePath(rigPath)) //nolint:gosec // G304: path is under the rig's .runtime
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var st SupervisorState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+func updateSupervisorState(rigPath string, fn func(*SupervisorState)) error {
+	st, err := LoadSupervisorState(rigPath)
+	if err != nil || st == nil {
+		st = &SupervisorState{}
+	}
+	fn(st)
+	path := SupervisorStatePath(rigPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(path, st)
+}
+
+// RecordLoopStart records that the supervisor launched a patrol loop.
+func RecordLoopStart(rigPath string, pid int, interval time.Duration, afterCrash bool) error {
+	return updateSupervisorState(rigPath, func(st *SupervisorState) {
+		st.PID = pid
+		st.StartedAt = time.Now()
+		st.Interval = interval
+		st.Running = true
+		st.Starts++
+		if afterCrash {
+			st.Restarts++
+		}
+	})
+}
+
+// RecordLoopExit records that a patrol loop ended. A nil crash means it
+// was stopped on purpose.
+func RecordLoopExit(rigPath string, crash error) error {
+	return updateSupervisorState(rigPath, func(st *SupervisorState) {
+		if crash == nil {
+			st.Running = false
+			st.PID = 0
+			return
+		}
+		st.Crashes = append(st.Crashes, LoopCrash{
+			At:     time.Now(),
+			PID:    st.PID,
+			Error:  crash.Error(),
+			Uptime: time.Since(st.StartedAt).Round(time.Second).String(),
+		})
+		if len(st.Crashes) > maxLoopCrashes {
+			st.Crashes = st.Crashes[len(st.Crashes)-maxLoopCrashes:]
+		}
+		st.PID = 0
+	})
+}
+
+// LoopHealth says whether a supervised loop is alive, judged by when it
+// last completed a patrol: a loop that hasn't patrolled for three
+// intervals is stale whatever its recorded PID. Returns the time of the
+// last patrol (zero if none since the loop started).
+func LoopHealth(rigPath string, st *SupervisorState, now time.Time) (alive bool, lastPatrol time.Time, err error) {
+	if st == nil || !st.Running {
+		return false, time.Time{}, nil
+	}
+	reports, err := LoadHistory(rigPath, st.StartedAt)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	if len(reports) > 0 {
+		lastPatrol = reports[len(reports)-1].At
+	}
+
+	interval := st.Interval
+	if interval <= 0 {
+		interval = DefaultPatrolInterval
+	}
+	since := lastPatrol
+	if since.IsZero() {
+		since = st.StartedAt // give a fresh loop time for its first pass
+	}
+	return now.Sub(since) < 3*interval, lastPatrol, nil
+}
@@ -0,0 +1,62 @@
+package witness
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSupervisorStateAndLoopHealth(t *testing.T) {
+	rigPath := t.TempDir()
+
+	if st, err := LoadSupervisorState(rigPath); err != nil || st != nil {
+		t.Fatalf("LoadSupervisorState on fresh rig = %+v, %v", st, err)
+	}
+
+	if err := RecordLoopStart(rigPath, 100, time.Minute, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordLoopExit(rigPath, errors.New("signal: killed")); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordLoopStart(rigPath, 200, time.Minute, true); err != nil {
+		t.Fatal(err)
+	}
+
+	st, err := LoadSupervisorState(rigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.PID != 200 || st.Starts != 2 || st.Restarts != 1 || !st.Running {
+		t.Errorf("state = %+v", st)
+	}
+	if len(st.Crashes) != 1 || st.Crashes[0].PID != 100 || st.Crashes[0].Error != "signal: killed" {
+		t.Errorf("crashes = %+v", st.Crashes)
+	}
+
+	// A fresh loop is alive until it misses three intervals without patrolling
+	if alive, _, _ := LoopHealth(rigPath, st, st.StartedAt.Add(2*time.Minute)); !alive {
+		t.Error("fresh loop reported dead")
+	}
+	if alive, _, _ := LoopHealth(rigPath, st, st.StartedAt.Add(4*time.Minute)); alive {
+		t.Error("loop that never patrolled reported alive")
+	}
+
+	patrolAt := st.StartedAt.Add(5 * time.Minute)
+	if err := AppendHistory(rigPath, &PatrolReport{Rig: "gastown", At: patrolAt}); err != nil {
+		t.Fatal(err)
+	}
+	alive, last, err := LoopHealth(rigPath, st, patrolAt.Add(time.Minute))
+	if err != nil || !alive || !last.Equal(patrolAt) {
+		t.Errorf("LoopHealth after patrol = %v, %v, %v", alive, last, err)
+	}
+
+	// A deliberate stop is never alive
+	if err := RecordLoopExit(rigPath, nil); err != nil {
+		t.Fatal(err)
+	}
+	st, _ = LoadSupervisorState(rigPath)
+	if alive, _, _ := LoopHealth(rigPath, st, patrolAt); alive || st.Running || len(st.Crashes) != 1 {
+		t.Errorf("stopped loop = %+v, alive %v", st, alive)
+	}
+}
@@ -14,21 +14,15 @@ import (
 // ErrNotFound indicates no workspace was found.
 var ErrNotFound = errors.New("not in a Gas Town workspace")
 
-// Markers used to detect a Gas Town workspace.
-const (
-	// PrimaryMarker is the main config file that identifies a workspace.
-	// The town.json file lives in mayor/ along with other mayor config.
-	PrimaryMarker = "mayor/town.json"
-
-	// SecondaryMarker is an alternative indicator at the town level.
-	// Note: This can match rig-level mayors too, so we continue searching
-	// upward after finding this to look for primary markers.
-	SecondaryMarker = "mayor"
-)
-
-// Find locates the town root by walking up from the given directory.
-// It prefers mayor/town.json over mayor/ directory as workspace marker.
-// When in a worktree path (polecats/ or crew/), continues to outermost workspace.
+// PrimaryMarker is the file that identifies a town root. It is written by
+// gt install (and gt init --hq); a directory without it is never treated
+// as a town, even if it has a mayor/ directory (every rig has one).
+const PrimaryMarker = "mayor/town.json"
+
+// Find locates the town root by walking up from the given directory to the
+// nearest mayor/town.json. When in a worktree path (polecats/ or crew/),
+// continues to the outermost town so a town checked out inside a workspace
+// is not mistaken for the real one.
 // Does not resolve symlinks to stay consistent with os.Getwd().
 func Find(startDir string) (string, error) {
 	absDir, err := filepath.Abs(startDir)
@@ -37,7 +31,7 @@ func Find(startDir string) (string, error) {
 	}
 
 	inWorktree := isInWorktreePath(absDir)
-	var primaryMatch, secondaryMatch string
+	var match string
 
 	current := absDir
 	for {
@@ -45,23 +39,12 @@ func Find(startDir string) (string, error) {
 			if !inWorktree {
 				return current, nil
 			}
-			primaryMatch = current
-		}
-
-		// Always keep updating secondaryMatch to find the outermost mayor/ directory.
-		// This handles nested structures where rigs have their own mayor/ directories
-		// but only the town root should be detected as the workspace.
-		// The primary marker (mayor/town.json) is authoritative and returns early above.
-		if info, err := os.Stat(filepath.Join(current, SecondaryMarker)); err == nil && info.IsDir() {
-			secondaryMatch = current
+			match = current
 		}
 
 		parent := filepath.Dir(current)
 		if parent == current {
-			if primaryMatch != "" {
-				return primaryMatch, nil
-			}
-			return secondaryMatch, nil
+			return match, nil
 		}
 		current = parent
 	}
@@ -84,8 +67,12 @@ func FindOrError(startDir string) (string, error) {
 	return root, nil
 }
 
-// FindFromCwd locates the town root from the current working directory.
+// FindFromCwd locates the town root from the current working directory,
+// unless GT_TOWN selects a registered town.
 func FindFromCwd() (string, error) {
+	if root := selectedTown(); root != "" {
+		return root, nil
+	}
 	cwd, err := os.Getwd()
 	if err != nil {
 		return "", fmt.Errorf("getting current directory: %w", err)
@@ -96,6 +83,9 @@ func FindFromCwd() (string, error) {
 // FindFromCwdOrError is like FindFromCwd but returns an error if not found.
 // If getcwd fails (e.g., worktree deleted), falls back to GT_TOWN_ROOT env var.
 func FindFromCwdOrError() (string, error) {
+	if root := selectedTown(); root != "" {
+		return root, nil
+	}
 	cwd, err := os.Getwd()
 	if err != nil {
 		// Fallback: try GT_TOWN_ROOT env var (set by polecat sessions)
@@ -127,6 +117,9 @@ func FindFromCwdWithFallback() (townRoot string, cwd string, err error) {
 		return "", "", fmt.Errorf("getting current directory: %w", err)
 	}
 
+	if townRoot = selectedTown(); townRoot != "" {
+		return townRoot, cwd, nil
+	}
 	townRoot, err = FindOrError(cwd)
 	if err != nil {
 		return "", "", err
@@ -134,28 +127,17 @@ func FindFromCwdWithFallback() (townRoot string, cwd string, err error) {
 	return townRoot, cwd, nil
 }
 
-// IsWorkspace checks if the given directory is a Gas Town workspace root.
-// A directory is a workspace if it has a primary marker (mayor/town.json)
-// or a secondary marker (mayor/ directory).
+// IsWorkspace checks if the given directory is a Gas Town workspace root,
+// i.e. has the marker file (mayor/town.json).
 func IsWorkspace(dir string) (bool, error) {
 	absDir, err := filepath.Abs(dir)
 	if err != nil {
 		return false, fmt.Errorf("resolving path: %w", err)
 	}
 
-	// Check for primary marker (mayor/town.json)
-	primaryPath := filepath.Join(absDir, PrimaryMarker)
-	if _, err := os.Stat(primaryPath); err == nil {
-		return true, nil
-	}
-
-	// Check for secondary marker (mayor/ directory)
-	secondaryPath := filepath.Join(absDir, SecondaryMarker)
-	info, err := os.Stat(secondaryPath)
-	if err == nil && info.IsDir() {
+	if _, err := os.Stat(filepath.Join(absDir, PrimaryMarker)); err == nil {
 		return true, nil
 	}
-
 	return false, nil
 }
 
@@ -43,8 +43,8 @@ func TestFindWithPrimaryMarker(t *testing.T) {
 	}
 }
 
-func TestFindWithSecondaryMarker(t *testing.T) {
-	// Create temp workspace with just mayor/ directory
+func TestFindIgnoresBareMayorDir(t *testing.T) {
+	// A mayor/ directory without town.json (e.g. a rig's mayor/) is not a town
 	root := realPath(t, t.TempDir())
 	mayorDir := filepath.Join(root, "mayor")
 	if err := os.MkdirAll(mayorDir, 0755); err != nil {
@@ -57,13 +57,15 @@ func TestFindWithSecondaryMarker(t *testing.T) {
 		t.Fatalf("mkdir nested: %v", err)
 	}
 
-	// Find from nested should return root
 	found, err := Find(nested)
 	if err != nil {
 		t.Fatalf("Find: %v", err)
 	}
-	if found != root {
-		t.Errorf("Find = %q, want %q", found, root)
+	if found != "" {
+		t.Errorf("Find = %q, want not found", found)
+	}
+	if is, _ := IsWorkspace(root); is {
+		t.Error("IsWorkspace = true for a bare mayor/ directory")
 	}
 }
 
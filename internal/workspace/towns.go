@@ -0,0 +1,147 @@
+package workspace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/state"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// TownEnv names the environment variable that selects a registered town
+// by name (set by the global --town flag).
+const TownEnv = "GT_TOWN"
+
+// ErrUnknownTown indicates a town name that is not in the towns registry.
+var ErrUnknownTown = errors.New("unknown town")
+
+// TownEntry is a town registered on this machine.
+type TownEntry struct {
+	Path    string    `json:"path"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// TownsRegistry lists the towns on this machine by name
+// (~/.config/gastown/towns.json).
+type TownsRegistry struct {
+	Version int                  `json:"version"`
+	Towns   map[string]TownEntry `json:"towns"`
+}
+
+// TownsRegistryPath returns the path to the machine's towns registry.
+func TownsRegistryPath() string {
+	return filepath.Join(state.ConfigDir(), "towns.json")
+}
+
+// LoadTownsRegistry loads the towns registry. A missing registry is empty.
+func LoadTownsRegistry() (*TownsRegistry, error) {
+	reg := &TownsRegistry{Version: 1, Towns: make(map[string]TownEntry)}
+	data, err := os.ReadFile(TownsRegistryPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return reg, nil
+		}
+		return nil, fmt.Errorf("reading towns registry: %w", err)
+	}
+	if err := json.Unmarshal(data, reg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", TownsRegistryPath(), err)
+	}
+	if reg.Towns == nil {
+		reg.Towns = make(map[string]TownEntry)
+	}
+	return reg, nil
+}
+
+// Save writes the towns registry atomically.
+func (r *TownsRegistry) Save() error {
+	if err := os.MkdirAll(filepath.Dir(TownsRegistryPath()), 0755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding towns registry: %w", err)
+	}
+	return util.AtomicWriteFile(TownsRegistryPath(), data, 0644)
+}
+
+// Names returns the registered town names, sorted.
+func (r *TownsRegistry) Names() []string {
+	names := make([]string, 0, len(r.Towns))
+	for name := range r.Towns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RegisterTown adds a town root to the registry under name. The path must
+// be a town root (have mayor/town.json).
+func RegisterTown(name, townRoot string) error {
+	absRoot, err := filepath.Abs(townRoot)
+	if err != nil {
+		return fmt.Errorf("resolving path: %w", err)
+	}
+	if is, _ := IsWorkspace(absRoot); !is {
+		return fmt.Errorf("%s is not a town root (no %s)", absRoot, PrimaryMarker)
+	}
+
+	reg, err := LoadTownsRegistry()
+	if err != nil {
+		return err
+	}
+	if existing, ok := reg.Towns[name]; ok && existing.Path != absRoot {
+		return fmt.Errorf("town %q is already registered at %s", name, existing.Path)
+	}
+	reg.Towns[name] = TownEntry{Path: absRoot, AddedAt: time.Now()}
+	return reg.Save()
+}
+
+// UnregisterTown removes a town from the registry. The town itself is
+// not touched.
+func UnregisterTown(name string) error {
+	reg, err := LoadTownsRegistry()
+	if err != nil {
+		return err
+	}
+	if _, ok := reg.Towns[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownTown, name)
+	}
+	delete(reg.Towns, name)
+	return reg.Save()
+}
+
+// LookupTown returns the root of a registered town.
+func LookupTown(name string) (string, error) {
+	reg, err := LoadTownsRegistry()
+	if err != nil {
+		return "", err
+	}
+	entry, ok := reg.Towns[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s (see gt town list)", ErrUnknownTown, name)
+	}
+	if is, _ := IsWorkspace(entry.Path); !is {
+		return "", fmt.Errorf("town %s: %s is no longer a town root", name, entry.Path)
+	}
+	return entry.Path, nil
+}
+
+// selectedTown returns the town selected by GT_TOWN. Sessions may carry
+// GT_TOWN for a town that is not registered; that is not a selection, and
+// discovery falls back to the working directory.
+func selectedTown() string {
+	name := os.Getenv(TownEnv)
+	if name == "" {
+		return ""
+	}
+	root, err := LookupTown(name)
+	if err != nil {
+		return ""
+	}
+	return root
+}
@@ -0,0 +1,87 @@
+package workspace
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// makeTown creates a town root with the marker file.
+func makeTown(t *testing.T) string {
+	t.Helper()
+	root := realPath(t, t.TempDir())
+	if err := os.MkdirAll(filepath.Join(root, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, PrimaryMarker), []byte(`{"type":"town"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestTownsRegistry(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	acme := makeTown(t)
+	home := makeTown(t)
+
+	if err := RegisterTown("acme", acme); err != nil {
+		t.Fatalf("RegisterTown: %v", err)
+	}
+	if err := RegisterTown("home", home); err != nil {
+		t.Fatalf("RegisterTown: %v", err)
+	}
+	if err := RegisterTown("acme", home); err == nil {
+		t.Error("RegisterTown reused a name for a different path")
+	}
+	if err := RegisterTown("bogus", t.TempDir()); err == nil {
+		t.Error("RegisterTown accepted a directory without the marker")
+	}
+
+	if root, err := LookupTown("acme"); err != nil || root != acme {
+		t.Errorf("LookupTown(acme) = %q, %v; want %q", root, err, acme)
+	}
+	if _, err := LookupTown("nope"); !errors.Is(err, ErrUnknownTown) {
+		t.Errorf("LookupTown(nope) err = %v, want ErrUnknownTown", err)
+	}
+
+	if err := UnregisterTown("home"); err != nil {
+		t.Fatalf("UnregisterTown: %v", err)
+	}
+	reg, err := LoadTownsRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := reg.Names(); len(names) != 1 || names[0] != "acme" {
+		t.Errorf("Names = %v, want [acme]", names)
+	}
+}
+
+func TestFindFromCwdSelectedTown(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	acme := makeTown(t)
+	other := makeTown(t)
+	if err := RegisterTown("acme", acme); err != nil {
+		t.Fatal(err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(cwd) })
+	if err := os.Chdir(other); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(TownEnv, "acme")
+	if root, err := FindFromCwdOrError(); err != nil || root != acme {
+		t.Errorf("with GT_TOWN=acme, FindFromCwdOrError = %q, %v; want %q", root, err, acme)
+	}
+
+	// An unregistered name (e.g. inherited from a session) is not a selection
+	t.Setenv(TownEnv, "elsewhere")
+	if root, err := FindFromCwdOrError(); err != nil || root != other {
+		t.Errorf("with GT_TOWN=elsewhere, FindFromCwdOrError = %q, %v; want %q", root, err, other)
+	}
+}